@@ -83,6 +83,14 @@ struct command {
             [1] = NULL,
         },
     },
+    {
+        .name = "chronyc-authdata",
+        .params = "-n -c authdata",
+        .search = {
+            [0] = "chronyc",
+            [1] = NULL,
+        },
+    },
     {
         .name = "dmsetup-status-cache",
         .params = "status --target cache --noflush",
@@ -171,6 +179,22 @@ struct command {
             [1] = NULL,
         },
     },
+    {
+        .name = "storcli-patrolread-info",
+        .params = "/cALL show patrolread J nolog",
+        .search = {
+            [0] = "storcli",
+            [1] = NULL,
+        },
+    },
+    {
+        .name = "storcli-rebuild-info",
+        .params = "/cALL/eALL/sALL show rebuild J nolog",
+        .search = {
+            [0] = "storcli",
+            [1] = NULL,
+        },
+    },
     {
         .name = "lvs-report-json",
         .params = "--reportformat json --units b --nosuffix -o {{options}}",
@@ -237,6 +261,15 @@ struct command {
             [2] = "MegaCli64",
         },
     },
+    {
+        .name = "megacli-patrolread-info",
+        .params = "-AdpPR -Info -aAll -NoLog",
+        .search = {
+            [0] = "megacli",
+            [1] = "MegaCli",
+            [2] = "MegaCli64",
+        },
+    },
     {
         .name = "arcconf-ld-info",
         .params = "GETCONFIG 1 LD",
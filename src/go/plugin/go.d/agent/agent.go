@@ -21,6 +21,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/functions"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/jobmgr"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
 
 	"github.com/mattn/go-isatty"
 )
@@ -178,7 +179,9 @@ func (a *Agent) run(ctx context.Context) {
 		return
 	}
 
-	discCfg := a.buildDiscoveryConf(enabledModules)
+	module.MaxCharts(cfg.Cardinality.MaxCharts)
+
+	discCfg := a.buildDiscoveryConf(enabledModules, cfg.Cardinality)
 
 	discMgr, err := discovery.NewManager(discCfg)
 	if err != nil {
@@ -215,6 +218,12 @@ func (a *Agent) run(ctx context.Context) {
 	wg.Add(1)
 	go func() { defer wg.Done(); discMgr.Run(ctx, in) }()
 
+	if cfg.Telemetry.Enabled {
+		srv := telemetry.NewServer(cfg.Telemetry, telemetry.Default)
+		wg.Add(1)
+		go func() { defer wg.Done(); srv.Run(ctx) }()
+	}
+
 	wg.Wait()
 	<-ctx.Done()
 }
@@ -23,6 +23,9 @@ const (
 	keyPriority    = "priority"
 	keyLabels      = "labels"
 	keyVnode       = "vnode"
+	keyDerived     = "derived"
+	keyMaxCharts   = "max_charts"
+	keyMaxDims     = "max_dims"
 
 	ikeySource     = "__source__"
 	ikeySourceType = "__source_type__"
@@ -55,6 +58,9 @@ func (c Config) Priority() int           { v, _ := c.Get(keyPriority).(int); ret
 func (c Config) Labels() map[any]any     { v, _ := c.Get(keyLabels).(map[any]any); return v }
 func (c Config) Hash() uint64            { return calcHash(c) }
 func (c Config) Vnode() string           { v, _ := c.Get(keyVnode).(string); return v }
+func (c Config) Derived() any            { return c.Get(keyDerived) }
+func (c Config) MaxCharts() int          { v, _ := c.Get(keyMaxCharts).(int); return v }
+func (c Config) MaxDims() int            { v, _ := c.Get(keyMaxDims).(int); return v }
 
 func (c Config) SetName(v string) Config   { return c.Set(keyName, v) }
 func (c Config) SetModule(v string) Config { return c.Set(keyModule, v) }
@@ -111,6 +117,12 @@ func (c Config) ApplyDefaults(def Default) {
 		v := firstPositive(def.Priority, module.Priority)
 		c.Set("priority", v)
 	}
+	if c.MaxCharts() <= 0 && def.MaxCharts > 0 {
+		c.Set(keyMaxCharts, def.MaxCharts)
+	}
+	if c.MaxDims() <= 0 && def.MaxDims > 0 {
+		c.Set(keyMaxDims, def.MaxDims)
+	}
 	if c.UpdateEvery() < def.MinUpdateEvery && def.MinUpdateEvery > 0 {
 		c.Set("update_every", def.MinUpdateEvery)
 	}
@@ -285,6 +285,26 @@ func TestConfig_Apply(t *testing.T) {
 				"priority":            module.Priority,
 			},
 		},
+		"+job +def cardinality limits": {
+			def: Default{
+				MaxCharts: 100,
+				MaxDims:   1000,
+			},
+			origCfg: Config{
+				"name":       "name",
+				"module":     "module",
+				"max_charts": 10,
+			},
+			expectedCfg: Config{
+				"name":                "name",
+				"module":              "module",
+				"update_every":        module.UpdateEvery,
+				"autodetection_retry": module.AutoDetectionRetry,
+				"priority":            module.Priority,
+				"max_charts":          10,
+				"max_dims":            1000,
+			},
+		},
 		"set name to module name if name not set": {
 			def: Default{},
 			origCfg: Config{
@@ -9,6 +9,8 @@ type Default struct {
 	UpdateEvery        int `yaml:"update_every"`
 	AutoDetectionRetry int `yaml:"autodetection_retry"`
 	Priority           int `yaml:"priority"`
+	MaxCharts          int `yaml:"max_charts"`
+	MaxDims            int `yaml:"max_dims"`
 }
 
 func (r Registry) Register(name string, def Default) {
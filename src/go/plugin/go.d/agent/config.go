@@ -5,6 +5,8 @@ package agent
 import (
 	"fmt"
 
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
+
 	"gopkg.in/yaml.v2"
 )
 
@@ -17,16 +19,26 @@ func defaultConfig() config {
 	}
 }
 
-type config struct {
-	Enabled    bool            `yaml:"enabled"`
-	DefaultRun bool            `yaml:"default_run"`
-	MaxProcs   int             `yaml:"max_procs"`
-	Modules    map[string]bool `yaml:"modules"`
-}
+type (
+	config struct {
+		Enabled     bool              `yaml:"enabled"`
+		DefaultRun  bool              `yaml:"default_run"`
+		MaxProcs    int               `yaml:"max_procs"`
+		Modules     map[string]bool   `yaml:"modules"`
+		Telemetry   telemetry.Config  `yaml:"telemetry"`
+		Cardinality cardinalityConfig `yaml:"cardinality"`
+	}
+	cardinalityConfig struct {
+		MaxCharts       int `yaml:"max_charts"`
+		MaxChartsPerJob int `yaml:"max_charts_per_job"`
+		MaxDimsPerJob   int `yaml:"max_dims_per_job"`
+	}
+)
 
 func (c *config) String() string {
-	return fmt.Sprintf("enabled '%v', default_run '%v', max_procs '%d'",
-		c.Enabled, c.DefaultRun, c.MaxProcs)
+	return fmt.Sprintf("enabled '%v', default_run '%v', max_procs '%d', telemetry '%v', max_charts '%d', max_charts_per_job '%d', max_dims_per_job '%d'",
+		c.Enabled, c.DefaultRun, c.MaxProcs, c.Telemetry.Enabled,
+		c.Cardinality.MaxCharts, c.Cardinality.MaxChartsPerJob, c.Cardinality.MaxDimsPerJob)
 }
 
 func (c *config) isExplicitlyEnabled(moduleName string) bool {
@@ -60,7 +72,7 @@ func (c *config) UnmarshalYAML(unmarshal func(any) error) error {
 
 	for key, value := range m {
 		switch key {
-		case "enabled", "default_run", "max_procs", "modules":
+		case "enabled", "default_run", "max_procs", "modules", "telemetry", "cardinality":
 			continue
 		}
 		var b bool
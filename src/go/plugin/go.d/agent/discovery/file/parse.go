@@ -125,6 +125,8 @@ func mergeDef(a, b confgroup.Default) confgroup.Default {
 		UpdateEvery:        firstPositive(a.UpdateEvery, b.UpdateEvery),
 		AutoDetectionRetry: firstPositive(a.AutoDetectionRetry, b.AutoDetectionRetry),
 		Priority:           firstPositive(a.Priority, b.Priority),
+		MaxCharts:          firstPositive(a.MaxCharts, b.MaxCharts),
+		MaxDims:            firstPositive(a.MaxDims, b.MaxDims),
 	}
 }
 
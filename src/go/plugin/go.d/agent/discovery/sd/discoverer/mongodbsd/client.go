@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongodbsd
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoConn interface {
+	hello(ctx context.Context) (*helloInfo, error)
+	listShards(ctx context.Context) ([]shardInfo, error)
+	close()
+}
+
+// https://www.mongodb.com/docs/manual/reference/command/hello/
+type helloInfo struct {
+	Msg      string   `bson:"msg"` // "isdbgrid" on mongos
+	SetName  string   `bson:"setName"`
+	Hosts    []string `bson:"hosts"`
+	Passives []string `bson:"passives"`
+	Arbiters []string `bson:"arbiters"`
+	Primary  string   `bson:"primary"`
+}
+
+// https://www.mongodb.com/docs/manual/reference/command/listShards/
+type shardInfo struct {
+	ID   string `bson:"_id"`
+	Host string `bson:"host"` // "rs/host1:port,host2:port"
+}
+
+func newMongoConn(uri string, timeout time.Duration) (mongoConn, error) {
+	opts := options.Client().
+		ApplyURI(uri).
+		SetConnectTimeout(timeout).
+		SetServerSelectionTimeout(timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoClient{client: client, timeout: timeout}, nil
+}
+
+type mongoClient struct {
+	client  *mongo.Client
+	timeout time.Duration
+}
+
+func (c *mongoClient) hello(ctx context.Context) (*helloInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var info helloInfo
+	if err := c.client.Database("admin").RunCommand(ctx, bson.M{"hello": 1}).Decode(&info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (c *mongoClient) listShards(ctx context.Context) ([]shardInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var resp struct {
+		Shards []shardInfo `bson:"shards"`
+	}
+	if err := c.client.Database("admin").RunCommand(ctx, bson.M{"listShards": 1}).Decode(&resp); err != nil {
+		return nil, err
+	}
+	return resp.Shards, nil
+}
+
+func (c *mongoClient) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_ = c.client.Disconnect(ctx)
+}
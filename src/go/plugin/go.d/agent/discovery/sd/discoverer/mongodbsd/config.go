@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongodbsd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+)
+
+type Config struct {
+	Source string `yaml:"-"`
+
+	// Seeds are MongoDB connection strings of mongos routers, replica set members or standalone instances.
+	// Credentials and options of a seed are reused for every discovered member.
+	Seeds []string `yaml:"seeds"`
+	// RescanInterval defines how often to refresh the topology (default: 5m)
+	RescanInterval *confopt.Duration `yaml:"rescan_interval"`
+	// Timeout defines the connection and query timeout (default: 2s)
+	Timeout confopt.Duration `yaml:"timeout"`
+}
+
+func (c *Config) validateAndParse() ([]*url.URL, error) {
+	if len(c.Seeds) == 0 {
+		return nil, errors.New("no seeds provided")
+	}
+
+	var seeds []*url.URL
+	for i, s := range c.Seeds {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("seed %d: invalid URI: %v", i+1, err)
+		}
+		if u.Scheme != "mongodb" && u.Scheme != "mongodb+srv" {
+			return nil, fmt.Errorf("seed %d: unsupported scheme '%s'", i+1, u.Scheme)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("seed %d: no hosts", i+1)
+		}
+		seeds = append(seeds, u)
+	}
+
+	return seeds, nil
+}
+
+// memberURI returns the connection string for a single topology member.
+// It keeps seed credentials and options, but forces a direct connection.
+func memberURI(seed *url.URL, addr string) string {
+	u := *seed
+	q := u.Query()
+	if u.Scheme == "mongodb+srv" {
+		u.Scheme = "mongodb"
+		if q.Get("tls") == "" && q.Get("ssl") == "" {
+			q.Set("tls", "true")
+		}
+	}
+	u.Host = addr
+	q.Del("replicaSet")
+	q.Set("directConnection", "true")
+	u.RawQuery = q.Encode()
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String()
+}
+
+// shardURI returns the connection string for a shard replica set ("rs/host1,host2").
+func shardURI(seed *url.URL, shardHost string) string {
+	u := *seed
+	q := u.Query()
+	if u.Scheme == "mongodb+srv" {
+		u.Scheme = "mongodb"
+		if q.Get("tls") == "" && q.Get("ssl") == "" {
+			q.Set("tls", "true")
+		}
+	}
+	rs, hosts, ok := strings.Cut(shardHost, "/")
+	if !ok {
+		hosts, rs = rs, ""
+	}
+	u.Host = hosts
+	q.Del("directConnection")
+	if rs != "" {
+		q.Set("replicaSet", rs)
+	} else {
+		q.Del("replicaSet")
+	}
+	u.RawQuery = q.Encode()
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String()
+}
+
+// seedKey identifies a seed without exposing credentials.
+func seedKey(seed *url.URL) string {
+	return seed.Host
+}
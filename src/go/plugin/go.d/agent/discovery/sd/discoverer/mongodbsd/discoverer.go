@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongodbsd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/model"
+)
+
+const (
+	defaultRescanInterval = time.Minute * 5
+	defaultTimeout        = time.Second * 2
+)
+
+func NewDiscoverer(cfg Config) (*Discoverer, error) {
+	seeds, err := cfg.validateAndParse()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Discoverer{
+		Logger: logger.New().With(
+			slog.String("component", "service discovery"),
+			slog.String("discoverer", "mongodb"),
+		),
+		cfgSource:      cfg.Source,
+		started:        make(chan struct{}),
+		seeds:          seeds,
+		newConn:        newMongoConn,
+		rescanInterval: defaultRescanInterval,
+		timeout:        defaultTimeout,
+		shards:         make(map[string][]model.Target),
+	}
+
+	if cfg.RescanInterval != nil && *cfg.RescanInterval >= 0 {
+		d.rescanInterval = cfg.RescanInterval.Duration()
+	}
+	if cfg.Timeout > 0 {
+		d.timeout = cfg.Timeout.Duration()
+	}
+
+	return d, nil
+}
+
+type Discoverer struct {
+	*logger.Logger
+	model.Base
+
+	cfgSource string // pipeline configuration source
+	started   chan struct{}
+
+	seeds []*url.URL
+
+	newConn func(uri string, timeout time.Duration) (mongoConn, error)
+
+	rescanInterval time.Duration
+	timeout        time.Duration
+
+	// last known members of each shard, used when a shard is temporarily unreachable
+	shards map[string][]model.Target
+}
+
+func (d *Discoverer) String() string {
+	return "sd:mongodb"
+}
+
+func (d *Discoverer) Discover(ctx context.Context, in chan<- []model.TargetGroup) {
+	d.Info("instance is started")
+	defer func() { d.Info("instance is stopped") }()
+
+	close(d.started)
+
+	d.discoverSeeds(ctx, in)
+
+	if d.rescanInterval <= 0 {
+		return
+	}
+
+	tk := time.NewTicker(d.rescanInterval)
+	defer tk.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tk.C:
+			d.discoverSeeds(ctx, in)
+		}
+	}
+}
+
+func (d *Discoverer) discoverSeeds(ctx context.Context, in chan<- []model.TargetGroup) {
+	for _, seed := range d.seeds {
+		if isDone(ctx) {
+			return
+		}
+
+		tgg, err := d.discoverSeed(ctx, seed)
+		if err != nil {
+			// keep the previously discovered targets, the seed may be temporarily unavailable
+			d.Warningf("seed '%s': topology discovery failed: %v", seedKey(seed), err)
+			continue
+		}
+
+		send(ctx, in, tgg)
+	}
+}
+
+func (d *Discoverer) discoverSeed(ctx context.Context, seed *url.URL) (*targetGroup, error) {
+	key := seedKey(seed)
+
+	tgg := newTargetGroup(key)
+	if d.cfgSource != "" {
+		tgg.source += fmt.Sprintf(",%s", d.cfgSource)
+	}
+
+	conn, err := d.newConn(seed.String(), d.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %v", err)
+	}
+	defer conn.close()
+
+	hello, err := conn.hello(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("hello command failed: %v", err)
+	}
+
+	switch {
+	case hello.Msg == "isdbgrid":
+		shards, err := conn.listShards(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listShards command failed: %v", err)
+		}
+
+		if seed.Scheme == "mongodb+srv" {
+			tgg.targets = append(tgg.targets, newTarget(typeMongos, seed.Host, seed.String(), "", ""))
+		} else {
+			for _, addr := range strings.Split(seed.Host, ",") {
+				tgg.targets = append(tgg.targets, newTarget(typeMongos, addr, memberURI(seed, addr), "", ""))
+			}
+		}
+
+		for _, shard := range shards {
+			tgg.targets = append(tgg.targets, d.discoverShard(ctx, seed, shard)...)
+		}
+	case hello.SetName != "":
+		for _, addr := range replicaSetMembers(hello) {
+			tgg.targets = append(tgg.targets, newTarget(typeReplicaSet, addr, memberURI(seed, addr), hello.SetName, ""))
+		}
+	default:
+		if seed.Scheme == "mongodb+srv" {
+			tgg.targets = append(tgg.targets, newTarget(typeStandalone, seed.Host, seed.String(), "", ""))
+		} else {
+			addr, _, _ := strings.Cut(seed.Host, ",")
+			tgg.targets = append(tgg.targets, newTarget(typeStandalone, addr, memberURI(seed, addr), "", ""))
+		}
+	}
+
+	d.Debugf("seed '%s': discovered %d targets", key, len(tgg.targets))
+
+	return tgg, nil
+}
+
+func (d *Discoverer) discoverShard(ctx context.Context, seed *url.URL, shard shardInfo) []model.Target {
+	cacheKey := seedKey(seed) + "/" + shard.ID
+
+	members, err := d.shardMembers(ctx, seed, shard)
+	if err != nil {
+		d.Warningf("seed '%s': shard '%s' discovery failed (using last known members): %v", seedKey(seed), shard.ID, err)
+		return d.shards[cacheKey]
+	}
+
+	var tgts []model.Target
+	for _, addr := range members.addrs {
+		tgts = append(tgts, newTarget(typeShard, addr, memberURI(seed, addr), members.setName, shard.ID))
+	}
+	d.shards[cacheKey] = tgts
+
+	return tgts
+}
+
+type shardMembers struct {
+	setName string
+	addrs   []string
+}
+
+func (d *Discoverer) shardMembers(ctx context.Context, seed *url.URL, shard shardInfo) (*shardMembers, error) {
+	conn, err := d.newConn(shardURI(seed, shard.Host), d.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %v", err)
+	}
+	defer conn.close()
+
+	hello, err := conn.hello(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("hello command failed: %v", err)
+	}
+
+	if hello.SetName == "" {
+		// a standalone shard (not supported since MongoDB 3.6, but still can be found in old clusters)
+		rs, hosts, ok := strings.Cut(shard.Host, "/")
+		if !ok {
+			hosts = rs
+		}
+		return &shardMembers{addrs: strings.Split(hosts, ",")}, nil
+	}
+
+	return &shardMembers{setName: hello.SetName, addrs: replicaSetMembers(hello)}, nil
+}
+
+// replicaSetMembers returns data bearing members. Arbiters are skipped: they hold no data
+// and do not allow authentication. Hidden members are not reported by the hello command.
+func replicaSetMembers(hello *helloInfo) []string {
+	var addrs []string
+	seen := make(map[string]bool)
+	for _, addr := range append(append([]string{}, hello.Hosts...), hello.Passives...) {
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func send(ctx context.Context, in chan<- []model.TargetGroup, tgg model.TargetGroup) {
+	select {
+	case <-ctx.Done():
+	case in <- []model.TargetGroup{tgg}:
+	}
+}
+
+func isDone(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongodbsd
+
+import (
+	"fmt"
+
+	"github.com/gohugoio/hashstructure"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/model"
+)
+
+const (
+	typeMongos     = "mongos"
+	typeShard      = "shard"
+	typeReplicaSet = "replicaset"
+	typeStandalone = "standalone"
+)
+
+func targetSource(key string) string { return fmt.Sprintf("discoverer=mongodb,seed=%s", key) }
+
+func newTargetGroup(key string) *targetGroup {
+	return &targetGroup{
+		provider: "sd:mongodb",
+		source:   targetSource(key),
+	}
+}
+
+type targetGroup struct {
+	provider string
+	source   string
+	targets  []model.Target
+}
+
+func (g *targetGroup) Provider() string        { return g.provider }
+func (g *targetGroup) Source() string          { return g.source }
+func (g *targetGroup) Targets() []model.Target { return g.targets }
+
+func newTarget(typ, addr, uri, replicaSet, shard string) *target {
+	tg := &target{
+		Type:       typ,
+		Address:    addr,
+		URI:        uri,
+		ReplicaSet: replicaSet,
+		Shard:      shard,
+	}
+
+	tg.hash, _ = hashstructure.Hash(tg, nil)
+
+	return tg
+}
+
+type target struct {
+	model.Base `hash:"ignore"`
+	hash       uint64
+
+	Type       string // "mongos", "shard", "replicaset" or "standalone"
+	Address    string // "host:port"
+	URI        string // connection string of the member
+	ReplicaSet string
+	Shard      string
+}
+
+func (t *target) TUID() string { return fmt.Sprintf("mongodb_%s_%d", t.Address, t.hash) }
+func (t *target) Hash() uint64 { return t.hash }
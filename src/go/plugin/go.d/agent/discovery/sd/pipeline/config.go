@@ -9,6 +9,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/confgroup"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/dockersd"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/k8ssd"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/mongodbsd"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/netlistensd"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/snmpsd"
 )
@@ -30,6 +31,7 @@ type DiscoveryConfig struct {
 	Docker       dockersd.Config    `yaml:"docker"`
 	K8s          []k8ssd.Config     `yaml:"k8s"`
 	SNMP         snmpsd.Config      `yaml:"snmp"`
+	MongoDB      mongodbsd.Config   `yaml:"mongodb"`
 }
 
 type ClassifyRuleConfig struct {
@@ -73,7 +75,7 @@ func validateDiscoveryConfig(config []DiscoveryConfig) error {
 	}
 	for _, cfg := range config {
 		switch cfg.Discoverer {
-		case "net_listeners", "docker", "k8s", "snmp":
+		case "net_listeners", "docker", "k8s", "snmp", "mongodb":
 		default:
 			return fmt.Errorf("unknown discoverer: '%s'", cfg.Discoverer)
 		}
@@ -13,10 +13,12 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/confgroup"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/dockersd"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/k8ssd"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/mongodbsd"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/netlistensd"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/discoverer/snmpsd"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/discovery/sd/model"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/hostinfo"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
 )
 
 func New(cfg Config) (*Pipeline, error) {
@@ -39,6 +41,7 @@ func New(cfg Config) (*Pipeline, error) {
 			slog.String("component", "service discovery"),
 			slog.String("pipeline", cfg.Name),
 		),
+		name:           cfg.Name,
 		configDefaults: cfg.ConfigDefaults,
 		clr:            clr,
 		cmr:            cmr,
@@ -59,6 +62,7 @@ type (
 	Pipeline struct {
 		*logger.Logger
 
+		name           string
 		configDefaults confgroup.Registry
 		discoverers    []model.Discoverer
 		accum          *accumulator
@@ -111,6 +115,13 @@ func (p *Pipeline) registerDiscoverers(conf Config) error {
 				return fmt.Errorf("failed to create '%s' discoverer: %v", cfg.Discoverer, err)
 			}
 			p.discoverers = append(p.discoverers, td)
+		case "mongodb":
+			cfg.MongoDB.Source = conf.Source
+			td, err := mongodbsd.NewDiscoverer(cfg.MongoDB)
+			if err != nil {
+				return fmt.Errorf("failed to create '%s' discoverer: %v", cfg.Discoverer, err)
+			}
+			p.discoverers = append(p.discoverers, td)
 		default:
 			return fmt.Errorf("unknown discoverer: '%s'", cfg.Discoverer)
 		}
@@ -134,6 +145,11 @@ func (p *Pipeline) Run(ctx context.Context, in chan<- []*confgroup.Group) {
 
 	go func() { defer close(done); p.accum.run(ctx, updates) }()
 
+	defer func() {
+		telemetry.DiscoveryTargets.Delete(p.name)
+		telemetry.DiscoveryConfigs.Delete(p.name)
+	}()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -146,7 +162,9 @@ func (p *Pipeline) Run(ctx context.Context, in chan<- []*confgroup.Group) {
 			return
 		case tggs := <-updates:
 			p.Debugf("received %d target groups", len(tggs))
-			if cfggs := p.processGroups(tggs); len(cfggs) > 0 {
+			cfggs := p.processGroups(tggs)
+			p.updateTelemetry()
+			if len(cfggs) > 0 {
 				select {
 				case <-ctx.Done():
 				case in <- cfggs: // FIXME: potentially stale configs if upstream cannot receive (blocking)
@@ -168,6 +186,18 @@ func (p *Pipeline) processGroups(tggs []model.TargetGroup) []*confgroup.Group {
 	return groups
 }
 
+func (p *Pipeline) updateTelemetry() {
+	var targets, configs int
+	for _, cache := range p.configs {
+		targets += len(cache)
+		for _, cfgs := range cache {
+			configs += len(cfgs)
+		}
+	}
+	telemetry.DiscoveryTargets.Set(float64(targets), p.name)
+	telemetry.DiscoveryConfigs.Set(float64(configs), p.name)
+}
+
 func (p *Pipeline) processGroup(tgg model.TargetGroup) *confgroup.Group {
 	if len(tgg.Targets()) == 0 {
 		if _, ok := p.configs[tgg.Source()]; !ok {
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/functions"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
 )
 
 type dyncfgStatus int
@@ -48,6 +49,8 @@ func (m *Manager) dyncfgConfig(fn functions.Function) {
 		return
 	}
 
+	telemetry.DyncfgCommands.Inc(strings.ToLower(fn.Args[1]))
+
 	select {
 	case <-m.ctx.Done():
 		m.dyncfgRespf(fn, 503, "Job manager is shutting down.")
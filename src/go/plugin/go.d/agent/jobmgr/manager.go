@@ -19,6 +19,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/confgroup"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/functions"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/vnodes"
 
 	"github.com/mattn/go-isatty"
@@ -211,6 +212,10 @@ func (m *Manager) addConfig(cfg confgroup.Config) {
 }
 
 func (m *Manager) removeConfig(cfg confgroup.Config) {
+	if _, ok := m.retryingTasks.lookup(cfg); ok {
+		// the failed job kept its error series for the detection retry
+		telemetry.JobErrors.DeleteMatching(cfg.Module(), cfg.Name())
+	}
 	m.retryingTasks.remove(cfg)
 
 	scfg, ok := m.seenConfigs.lookup(cfg)
@@ -306,6 +311,11 @@ func (m *Manager) createCollectorJob(cfg confgroup.Config) (*module.Job, error)
 		return nil, err
 	}
 
+	derived, err := makeDerived(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	jobCfg := module.JobConfig{
 		PluginName:      m.PluginName,
 		Name:            cfg.Name(),
@@ -315,6 +325,9 @@ func (m *Manager) createCollectorJob(cfg confgroup.Config) (*module.Job, error)
 		AutoDetectEvery: cfg.AutoDetectionRetry(),
 		Priority:        cfg.Priority(),
 		Labels:          makeLabels(cfg),
+		Derived:         derived,
+		MaxCharts:       cfg.MaxCharts(),
+		MaxDims:         cfg.MaxDims(),
 		IsStock:         cfg.SourceType() == "stock",
 		Module:          mod,
 		Out:             m.Out,
@@ -365,6 +378,23 @@ func applyConfig(cfg confgroup.Config, module any) error {
 	return yaml.Unmarshal(bs, module)
 }
 
+func makeDerived(cfg confgroup.Config) ([]module.DerivedChartConfig, error) {
+	if cfg.Derived() == nil {
+		return nil, nil
+	}
+
+	bs, err := yaml.Marshal(cfg.Derived())
+	if err != nil {
+		return nil, err
+	}
+
+	var derived []module.DerivedChartConfig
+	if err := yaml.Unmarshal(bs, &derived); err != nil {
+		return nil, fmt.Errorf("invalid 'derived' config: %v", err)
+	}
+	return derived, nil
+}
+
 func makeLabels(cfg confgroup.Config) map[string]string {
 	labels := make(map[string]string)
 	for name, value := range cfg.Labels() {
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package module
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+)
+
+// chartsBudget tracks the number of charts created by all jobs against the plugin-wide limit.
+type chartsBudget struct {
+	limit atomic.Int64
+	used  atomic.Int64
+}
+
+// pluginCharts is the budget shared by all jobs of the plugin.
+var pluginCharts = &chartsBudget{}
+
+// MaxCharts sets the plugin-wide limit on the number of charts created by all jobs. Zero means no limit.
+func MaxCharts(n int) {
+	pluginCharts.limit.Store(int64(max(n, 0)))
+}
+
+func (b *chartsBudget) hasLimit() bool {
+	return b.limit.Load() > 0
+}
+
+func (b *chartsBudget) acquire() bool {
+	for {
+		used := b.used.Load()
+		if limit := b.limit.Load(); limit > 0 && used >= limit {
+			return false
+		}
+		if b.used.CompareAndSwap(used, used+1) {
+			return true
+		}
+	}
+}
+
+func (b *chartsBudget) release(n int) {
+	if n > 0 {
+		b.used.Add(-int64(n))
+	}
+}
+
+func newCardinalityStatusChart(pluginName string) *Chart {
+	return &Chart{
+		typ:      "netdata",
+		Title:    "Cardinality Limit Status",
+		Units:    "status",
+		Fam:      pluginName,
+		Ctx:      "netdata.plugin_cardinality_status",
+		Priority: 145100,
+		Dims: Dims{
+			{ID: "ok"},
+			{ID: "limit_reached"},
+		},
+	}
+}
+
+func newCardinalityDroppedChart(pluginName string) *Chart {
+	return &Chart{
+		typ:      "netdata",
+		Title:    "Instances Dropped Due to Cardinality Limits",
+		Units:    "instances",
+		Fam:      pluginName,
+		Ctx:      "netdata.plugin_cardinality_dropped",
+		Priority: 145200,
+		Dims: Dims{
+			{ID: "charts"},
+			{ID: "dimensions"},
+		},
+	}
+}
+
+func (j *Job) hasCardinalityLimits() bool {
+	return j.maxCharts > 0 || j.maxDims > 0 || j.chartsBudget.hasLimit()
+}
+
+// admissionCharts returns the module charts followed by the derived charts,
+// derived charts count towards the same budgets.
+func (j *Job) admissionCharts() []*Chart {
+	charts := *j.charts
+	if j.derived != nil {
+		charts = append(charts[:len(charts):len(charts)], j.derived.charts...)
+	}
+	return charts
+}
+
+// admitCharts decides which charts can be sent to Netdata.
+// The drop policy is stable: already admitted charts are never evicted,
+// and new charts are admitted first come first served (in the order they were added to the job)
+// as long as they fit into the per-job and plugin-wide budgets.
+// Dropped charts are re-evaluated on every run, so they are admitted once the budget allows.
+// Dimensions added to an already admitted chart are not dropped, but count towards the per-job limit.
+func (j *Job) admitCharts() {
+	all := j.admissionCharts()
+
+	var charts, dims int
+	for _, chart := range all {
+		if chart.admitted && !chart.remove {
+			charts++
+			dims += len(chart.Dims)
+		}
+	}
+
+	var droppedCharts, droppedDims int
+	for _, chart := range all {
+		if chart.admitted || chart.remove {
+			continue
+		}
+		n := len(chart.Dims)
+		if (j.maxCharts > 0 && charts >= j.maxCharts) ||
+			(j.maxDims > 0 && dims+n > j.maxDims) ||
+			!j.chartsBudget.acquire() {
+			droppedCharts++
+			droppedDims += n
+			continue
+		}
+		chart.admitted = true
+		j.acquiredCharts++
+		charts++
+		dims += n
+	}
+
+	j.droppedCharts, j.droppedDims = droppedCharts, droppedDims
+
+	if limitReached := droppedCharts > 0; limitReached != j.limitReached {
+		j.limitReached = limitReached
+		if limitReached {
+			j.Warningf("cardinality limit reached (%s), %d chart(s) with %d dimension(s) are not collected",
+				j.cardinalityLimitsString(), droppedCharts, droppedDims)
+		} else {
+			j.Info("cardinality is back within limits, all charts are collected")
+		}
+	}
+
+	telemetry.JobDroppedCharts.Set(float64(droppedCharts), j.moduleName, j.name)
+}
+
+// releaseCharts returns charts acquired by the job to the plugin-wide budget.
+func (j *Job) releaseCharts() {
+	j.chartsBudget.release(j.acquiredCharts)
+	j.acquiredCharts = 0
+	if j.charts != nil {
+		for _, chart := range j.admissionCharts() {
+			chart.admitted = false
+		}
+	}
+}
+
+func (j *Job) cardinalityLimitsString() string {
+	return fmt.Sprintf("max charts per job %d, max dims per job %d, max charts %d",
+		j.maxCharts, j.maxDims, j.chartsBudget.limit.Load())
+}
+
+func (j *Job) processCardinalityCharts(createChart bool, sinceLastRun int) {
+	if !j.hasCardinalityLimits() {
+		return
+	}
+
+	if !j.cardinalityStatusChart.created || createChart {
+		j.cardinalityStatusChart.ID = fmt.Sprintf("%s_%s_cardinality_status", cleanPluginName(j.pluginName), j.FullName())
+		j.createChart(j.cardinalityStatusChart)
+	}
+	if !j.cardinalityDroppedChart.created || createChart {
+		j.cardinalityDroppedChart.ID = fmt.Sprintf("%s_%s_cardinality_dropped", cleanPluginName(j.pluginName), j.FullName())
+		j.createChart(j.cardinalityDroppedChart)
+	}
+
+	j.updateChart(
+		j.cardinalityStatusChart,
+		map[string]int64{"ok": metrix.Bool(!j.limitReached), "limit_reached": metrix.Bool(j.limitReached)},
+		sinceLastRun,
+	)
+	j.updateChart(
+		j.cardinalityDroppedChart,
+		map[string]int64{"charts": int64(j.droppedCharts), "dimensions": int64(j.droppedDims)},
+		sinceLastRun,
+	)
+}
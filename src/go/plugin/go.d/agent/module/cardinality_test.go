@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package module
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCardinalityTestJob(out *bytes.Buffer, name string, maxCharts, maxDims int, charts *Charts) *Job {
+	job := NewJob(JobConfig{
+		PluginName: pluginName,
+		Name:       name,
+		ModuleName: modName,
+		FullName:   modName + "_" + name,
+		Out:        out,
+		MaxCharts:  maxCharts,
+		MaxDims:    maxDims,
+		Module: &MockModule{
+			ChartsFunc: func() *Charts { return charts },
+			CollectFunc: func(context.Context) map[string]int64 {
+				return map[string]int64{"a": 1, "b": 2, "c": 3}
+			},
+		},
+	})
+	// tests don't share the plugin-wide budget
+	job.chartsBudget = &chartsBudget{}
+	return job
+}
+
+func TestJob_Cardinality_NoLimits(t *testing.T) {
+	var buf bytes.Buffer
+	charts := &Charts{
+		{ID: "c1", Title: "C1", Units: "n", Dims: Dims{{ID: "a"}}},
+		{ID: "c2", Title: "C2", Units: "n", Dims: Dims{{ID: "b"}}},
+	}
+	job := newCardinalityTestJob(&buf, jobName, 0, 0, charts)
+
+	require.NoError(t, job.AutoDetection())
+	job.runOnce()
+
+	out := buf.String()
+	assert.Contains(t, out, "CHART 'module_job.c1'")
+	assert.Contains(t, out, "CHART 'module_job.c2'")
+	assert.NotContains(t, out, "cardinality")
+	assert.NotContains(t, out, "DIMENSION 'limited'")
+}
+
+func TestJob_Cardinality_MaxCharts(t *testing.T) {
+	var buf bytes.Buffer
+	charts := &Charts{
+		{ID: "c1", Title: "C1", Units: "n", Dims: Dims{{ID: "a"}}},
+		{ID: "c2", Title: "C2", Units: "n", Dims: Dims{{ID: "b"}}},
+		{ID: "c3", Title: "C3", Units: "n", Dims: Dims{{ID: "c"}}},
+	}
+	job := newCardinalityTestJob(&buf, jobName, 2, 0, charts)
+
+	require.NoError(t, job.AutoDetection())
+	job.runOnce()
+
+	out := buf.String()
+	assert.Contains(t, out, "CHART 'module_job.c1'")
+	assert.Contains(t, out, "CHART 'module_job.c2'")
+	assert.NotContains(t, out, "CHART 'module_job.c3'")
+	assert.Contains(t, out, "CHART 'netdata.plugin_module_job_cardinality_status'")
+	assert.Contains(t, out, "SET 'limit_reached' = 1\n")
+	assert.Contains(t, out, "SET 'charts' = 1\n")
+	assert.Contains(t, out, "SET 'dimensions' = 1\n")
+	assert.Len(t, *charts, 3)
+
+	buf.Reset()
+	(*charts)[0].MarkRemove()
+	(*charts)[0].MarkNotCreated()
+	job.runOnce()
+
+	out = buf.String()
+	assert.Contains(t, out, "CHART 'module_job.c3'")
+	assert.NotContains(t, out, "CHART 'module_job.c2'")
+	assert.Contains(t, out, "SET 'limit_reached' = 0\n")
+	assert.Contains(t, out, "SET 'charts' = 0\n")
+	assert.Len(t, *charts, 2)
+}
+
+func TestJob_Cardinality_MaxDims(t *testing.T) {
+	var buf bytes.Buffer
+	charts := &Charts{
+		{ID: "c1", Title: "C1", Units: "n", Dims: Dims{{ID: "a"}, {ID: "b"}}},
+		{ID: "c2", Title: "C2", Units: "n", Dims: Dims{{ID: "b"}, {ID: "c"}}},
+		{ID: "c3", Title: "C3", Units: "n", Dims: Dims{{ID: "c"}}},
+	}
+	job := newCardinalityTestJob(&buf, jobName, 0, 3, charts)
+
+	require.NoError(t, job.AutoDetection())
+	job.runOnce()
+
+	out := buf.String()
+	assert.Contains(t, out, "CHART 'module_job.c1'")
+	assert.NotContains(t, out, "CHART 'module_job.c2'")
+	assert.Contains(t, out, "CHART 'module_job.c3'")
+	assert.Contains(t, out, "SET 'charts' = 1\n")
+	assert.Contains(t, out, "SET 'dimensions' = 2\n")
+
+	// dimensions added to an admitted chart are kept
+	buf.Reset()
+	require.NoError(t, (*charts)[0].AddDim(&Dim{ID: "c"}))
+	(*charts)[0].MarkNotCreated()
+	job.runOnce()
+
+	out = buf.String()
+	assert.Contains(t, out, "CHART 'module_job.c1'")
+	assert.Contains(t, out, "DIMENSION 'c'")
+	assert.NotContains(t, out, "CHART 'module_job.c2'")
+}
+
+func TestJob_Cardinality_PluginMaxCharts(t *testing.T) {
+	budget := &chartsBudget{}
+	budget.limit.Store(2)
+
+	var buf1, buf2 bytes.Buffer
+	job1 := newCardinalityTestJob(&buf1, "job1", 0, 0, &Charts{
+		{ID: "c1", Title: "C1", Units: "n", Dims: Dims{{ID: "a"}}},
+		{ID: "c2", Title: "C2", Units: "n", Dims: Dims{{ID: "b"}}},
+	})
+	job2 := newCardinalityTestJob(&buf2, "job2", 0, 0, &Charts{
+		{ID: "c1", Title: "C1", Units: "n", Dims: Dims{{ID: "a"}}},
+	})
+	job1.chartsBudget, job2.chartsBudget = budget, budget
+	defer job2.Cleanup()
+
+	require.NoError(t, job1.AutoDetection())
+	require.NoError(t, job2.AutoDetection())
+
+	job1.runOnce()
+	job2.runOnce()
+
+	assert.Contains(t, buf1.String(), "CHART 'module_job1.c1'")
+	assert.Contains(t, buf1.String(), "CHART 'module_job1.c2'")
+	assert.NotContains(t, buf2.String(), "CHART 'module_job2.c1'")
+	assert.Contains(t, buf2.String(), "SET 'limit_reached' = 1\n")
+
+	job1.Cleanup()
+	buf2.Reset()
+	job2.runOnce()
+
+	assert.Contains(t, buf2.String(), "CHART 'module_job2.c1'")
+	assert.Contains(t, buf2.String(), "SET 'limit_reached' = 0\n")
+}
+
+func TestJob_Cardinality_DerivedCharts(t *testing.T) {
+	var buf bytes.Buffer
+	charts := &Charts{
+		{ID: "c1", Title: "C1", Units: "n", Dims: Dims{{ID: "a"}}},
+	}
+	job := newCardinalityTestJob(&buf, jobName, 2, 0, charts)
+	job.derivedConfigs = []DerivedChartConfig{
+		{ID: "sum", Units: "n", Dims: []DerivedDimConfig{{Name: "sum", Expr: "a + b"}}},
+		{ID: "diff", Units: "n", Dims: []DerivedDimConfig{{Name: "diff", Expr: "c - a"}}},
+	}
+
+	require.NoError(t, job.AutoDetection())
+	job.runOnce()
+
+	out := buf.String()
+	assert.Contains(t, out, "CHART 'module_job.c1'")
+	assert.Contains(t, out, "CHART 'module_job.derived_sum'")
+	assert.NotContains(t, out, "CHART 'module_job.derived_diff'")
+	assert.Contains(t, out, "SET 'charts' = 1\n")
+}
+
+func TestJob_Cardinality_CollectStatusLimited(t *testing.T) {
+	var buf bytes.Buffer
+	charts := &Charts{
+		{ID: "c1", Title: "C1", Units: "n", Dims: Dims{{ID: "a"}}},
+	}
+	job := newCardinalityTestJob(&buf, jobName, 0, 0, charts)
+	job.chartsBudget.limit.Store(1)
+	job.chartsBudget.used.Store(1)
+
+	require.NoError(t, job.AutoDetection())
+	job.runOnce()
+
+	out := buf.String()
+	assert.NotContains(t, out, "CHART 'module_job.c1'")
+	assert.Contains(t, out, "SET 'success' = 0\n")
+	assert.Contains(t, out, "SET 'failed' = 0\n")
+	assert.Contains(t, out, "DIMENSION 'limited'")
+	assert.Contains(t, out, "SET 'limited' = 1\n")
+	assert.Equal(t, 0, job.retries)
+}
@@ -111,6 +111,8 @@ type (
 
 		// ignore flag is used to indicate that the chart shouldn't be sent to the netdata plugins.d
 		ignore bool
+		// admitted flag is used to indicate that the chart fits into the cardinality limits.
+		admitted bool
 	}
 
 	Label struct {
@@ -0,0 +1,486 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package module
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// DerivedChartConfig defines a chart whose dimensions are computed from the metrics returned by Collect.
+	DerivedChartConfig struct {
+		ID      string             `yaml:"id" json:"id"`
+		Title   string             `yaml:"title,omitempty" json:"title"`
+		Units   string             `yaml:"units" json:"units"`
+		Family  string             `yaml:"family,omitempty" json:"family"`
+		Context string             `yaml:"context,omitempty" json:"context"`
+		Type    string             `yaml:"type,omitempty" json:"type"`
+		Dims    []DerivedDimConfig `yaml:"dimensions" json:"dimensions"`
+	}
+	// DerivedDimConfig defines a derived dimension.
+	// Expr is an arithmetic expression over the collected metrics, see compileDerivedExpr for the syntax.
+	DerivedDimConfig struct {
+		Name string `yaml:"name" json:"name"`
+		Expr string `yaml:"expr" json:"expr"`
+	}
+)
+
+// derivedPrecision keeps 3 decimal places of the evaluated expressions.
+const derivedPrecision = 1000
+
+type (
+	derivedMetrics struct {
+		charts Charts
+		dims   []*derivedDim
+	}
+	derivedDim struct {
+		id   string
+		expr derivedExpr
+	}
+)
+
+func newDerivedMetrics(moduleName string, cfgs []DerivedChartConfig) (*derivedMetrics, error) {
+	d := &derivedMetrics{}
+	seen := make(map[string]bool)
+	// dimension IDs join the chart ID and the dimension name, e.g. chart 'a' dim 'b_c' and chart 'a_b' dim 'c'
+	seenDims := make(map[string]string)
+
+	for _, cfg := range cfgs {
+		if cfg.ID == "" {
+			return nil, errors.New("chart 'id' can not be empty")
+		}
+		if seen[cfg.ID] {
+			return nil, fmt.Errorf("chart '%s': duplicate id", cfg.ID)
+		}
+		seen[cfg.ID] = true
+
+		if cfg.Units == "" {
+			return nil, fmt.Errorf("chart '%s': 'units' can not be empty", cfg.ID)
+		}
+		if len(cfg.Dims) == 0 {
+			return nil, fmt.Errorf("chart '%s': no dimensions", cfg.ID)
+		}
+
+		chart := &Chart{
+			ID:    "derived_" + cfg.ID,
+			Title: cfg.Title,
+			Units: cfg.Units,
+			Fam:   cfg.Family,
+			Ctx:   cfg.Context,
+		}
+		if chart.Title == "" {
+			chart.Title = cfg.ID
+		}
+		if chart.Fam == "" {
+			chart.Fam = "derived"
+		}
+		if chart.Ctx == "" {
+			chart.Ctx = fmt.Sprintf("%s.derived_%s", moduleName, cfg.ID)
+		}
+		switch typ := ChartType(strings.ToLower(cfg.Type)); typ {
+		case "":
+			chart.Type = Line
+		case Line, Area, Stacked:
+			chart.Type = typ
+		default:
+			return nil, fmt.Errorf("chart '%s': unknown chart type '%s'", cfg.ID, cfg.Type)
+		}
+
+		for _, dimCfg := range cfg.Dims {
+			if dimCfg.Name == "" {
+				return nil, fmt.Errorf("chart '%s': dimension 'name' can not be empty", cfg.ID)
+			}
+			expr, err := compileDerivedExpr(dimCfg.Expr)
+			if err != nil {
+				return nil, fmt.Errorf("chart '%s': dimension '%s': %v", cfg.ID, dimCfg.Name, err)
+			}
+
+			dim := &Dim{ID: chart.ID + "_" + dimCfg.Name, Name: dimCfg.Name, Div: derivedPrecision}
+			if chartID, ok := seenDims[dim.ID]; ok && chartID != cfg.ID {
+				return nil, fmt.Errorf("chart '%s': dimension '%s': id '%s' collides with a dimension of chart '%s'",
+					cfg.ID, dimCfg.Name, dim.ID, chartID)
+			}
+			seenDims[dim.ID] = cfg.ID
+			if err := chart.AddDim(dim); err != nil {
+				return nil, err
+			}
+			d.dims = append(d.dims, &derivedDim{id: dim.ID, expr: expr})
+		}
+
+		if err := checkChart(chart); err != nil {
+			return nil, fmt.Errorf("chart '%s': %v", cfg.ID, err)
+		}
+
+		d.charts = append(d.charts, chart)
+	}
+
+	return d, nil
+}
+
+// collect evaluates the derived dimensions. Dimensions whose expression can not be evaluated
+// (missing metrics, division by zero, first run of a rate) are left out.
+func (d *derivedMetrics) collect(mx map[string]int64, now time.Time) map[string]int64 {
+	ctx := &derivedEvalCtx{mx: mx, now: now}
+	res := make(map[string]int64)
+
+	for _, dim := range d.dims {
+		if v, ok := dim.expr.eval(ctx); ok {
+			if n, ok := derivedValueToInt64(v); ok {
+				res[dim.id] = n
+			}
+		}
+	}
+
+	return res
+}
+
+// derivedValueToInt64 scales v by derivedPrecision. It fails if v is NaN, Inf or the scaled value overflows int64.
+func derivedValueToInt64(v float64) (int64, bool) {
+	v *= derivedPrecision
+	// float64(math.MaxInt64) rounds up to 2^63, which is out of the int64 range
+	if math.IsNaN(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+		return 0, false
+	}
+	return int64(v), true
+}
+
+type (
+	derivedEvalCtx struct {
+		mx  map[string]int64
+		now time.Time
+	}
+
+	derivedExpr interface {
+		eval(ctx *derivedEvalCtx) (float64, bool)
+	}
+
+	numberExpr float64
+	metricExpr string
+	unaryExpr  struct {
+		op token.Token
+		x  derivedExpr
+	}
+	binaryExpr struct {
+		op   token.Token
+		x, y derivedExpr
+	}
+	rateExpr struct {
+		x        derivedExpr
+		isDelta  bool
+		hasPrev  bool
+		prev     float64
+		prevTime time.Time
+	}
+	clampExpr struct {
+		x, lo, hi derivedExpr
+	}
+	minMaxExpr struct {
+		args  []derivedExpr
+		isMax bool
+	}
+	absExpr struct {
+		x derivedExpr
+	}
+	sumExpr struct {
+		re *regexp.Regexp
+	}
+)
+
+func (e numberExpr) eval(*derivedEvalCtx) (float64, bool) { return float64(e), true }
+
+func (e metricExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	v, ok := ctx.mx[string(e)]
+	return float64(v), ok
+}
+
+func (e *unaryExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	v, ok := e.x.eval(ctx)
+	if e.op == token.SUB {
+		v = -v
+	}
+	return v, ok
+}
+
+func (e *binaryExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	// both operands are evaluated, rate() and delta() update their state on every evaluation
+	x, okX := e.x.eval(ctx)
+	y, okY := e.y.eval(ctx)
+	if !okX || !okY {
+		return 0, false
+	}
+
+	var v float64
+	switch e.op {
+	case token.ADD:
+		v = x + y
+	case token.SUB:
+		v = x - y
+	case token.MUL:
+		v = x * y
+	case token.QUO:
+		if y == 0 {
+			return 0, false
+		}
+		v = x / y
+	}
+
+	return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func (e *rateExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	v, ok := e.x.eval(ctx)
+	if !ok {
+		e.hasPrev = false
+		return 0, false
+	}
+
+	prev, prevTime, hasPrev := e.prev, e.prevTime, e.hasPrev
+	e.prev, e.prevTime, e.hasPrev = v, ctx.now, true
+
+	if !hasPrev {
+		return 0, false
+	}
+	if e.isDelta {
+		return v - prev, true
+	}
+
+	secs := ctx.now.Sub(prevTime).Seconds()
+	// a counter reset
+	if secs <= 0 || v < prev {
+		return 0, false
+	}
+	return (v - prev) / secs, true
+}
+
+func (e *clampExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	v, ok1 := e.x.eval(ctx)
+	lo, ok2 := e.lo.eval(ctx)
+	hi, ok3 := e.hi.eval(ctx)
+	if !ok1 || !ok2 || !ok3 {
+		return 0, false
+	}
+	return math.Min(math.Max(v, lo), hi), true
+}
+
+func (e *minMaxExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	// all arguments are evaluated, rate() and delta() update their state on every evaluation
+	var res float64
+	allOk := true
+	for i, arg := range e.args {
+		v, ok := arg.eval(ctx)
+		allOk = allOk && ok
+		switch {
+		case i == 0:
+			res = v
+		case e.isMax:
+			res = math.Max(res, v)
+		default:
+			res = math.Min(res, v)
+		}
+	}
+	if !allOk {
+		return 0, false
+	}
+	return res, true
+}
+
+func (e *absExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	v, ok := e.x.eval(ctx)
+	return math.Abs(v), ok
+}
+
+func (e *sumExpr) eval(ctx *derivedEvalCtx) (float64, bool) {
+	var sum float64
+	var found bool
+	for k, v := range ctx.mx {
+		if e.re.MatchString(k) {
+			sum += float64(v)
+			found = true
+		}
+	}
+	return sum, found
+}
+
+// compileDerivedExpr compiles an arithmetic expression over the collected metrics.
+//
+// Supported syntax:
+//   - numbers and the +, -, *, / operators, parentheses.
+//   - metric keys as identifiers (hits, cpu.user), or metric("key") for keys that are not valid identifiers.
+//   - rate(x): the per-second rate of x over the collection interval.
+//   - delta(x): the difference of x between two collections.
+//   - clamp(x, min, max), min(x, y, ...), max(x, y, ...), abs(x).
+//   - sum("regex"): the sum of all metrics whose key matches the regular expression.
+func compileDerivedExpr(s string) (derivedExpr, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("'expr' can not be empty")
+	}
+
+	node, err := parser.ParseExpr(s)
+	if err != nil {
+		return nil, fmt.Errorf("parsing expression '%s': %v", s, err)
+	}
+
+	return compileDerivedNode(node)
+}
+
+func compileDerivedNode(node ast.Expr) (derivedExpr, error) {
+	switch n := node.(type) {
+	case *ast.ParenExpr:
+		return compileDerivedNode(n.X)
+	case *ast.BasicLit:
+		switch n.Kind {
+		case token.INT:
+			v, err := strconv.ParseInt(n.Value, 0, 64)
+			if err != nil {
+				return nil, err
+			}
+			return numberExpr(v), nil
+		case token.FLOAT:
+			v, err := strconv.ParseFloat(n.Value, 64)
+			if err != nil {
+				return nil, err
+			}
+			return numberExpr(v), nil
+		}
+		return nil, fmt.Errorf("unexpected literal %s", n.Value)
+	case *ast.Ident, *ast.SelectorExpr:
+		key, ok := exprKey(n)
+		if !ok {
+			return nil, fmt.Errorf("invalid metric key")
+		}
+		return metricExpr(key), nil
+	case *ast.UnaryExpr:
+		if n.Op != token.ADD && n.Op != token.SUB {
+			return nil, fmt.Errorf("unsupported operator '%s'", n.Op)
+		}
+		x, err := compileDerivedNode(n.X)
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: n.Op, x: x}, nil
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO:
+		default:
+			return nil, fmt.Errorf("unsupported operator '%s'", n.Op)
+		}
+		x, err := compileDerivedNode(n.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := compileDerivedNode(n.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &binaryExpr{op: n.Op, x: x, y: y}, nil
+	case *ast.CallExpr:
+		return compileDerivedCall(n)
+	}
+
+	return nil, fmt.Errorf("unsupported expression '%T'", node)
+}
+
+func compileDerivedCall(call *ast.CallExpr) (derivedExpr, error) {
+	fn, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return nil, errors.New("unsupported function call")
+	}
+
+	wantArgs := func(n int) error {
+		if len(call.Args) != n {
+			return fmt.Errorf("%s(): expected %d argument(s), got %d", fn.Name, n, len(call.Args))
+		}
+		return nil
+	}
+	compileArgs := func() ([]derivedExpr, error) {
+		var args []derivedExpr
+		for _, arg := range call.Args {
+			v, err := compileDerivedNode(arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, v)
+		}
+		return args, nil
+	}
+
+	switch fn.Name {
+	case "metric", "sum":
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		s, err := stringLit(call.Args[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s(): %v", fn.Name, err)
+		}
+		if fn.Name == "metric" {
+			return metricExpr(s), nil
+		}
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, fmt.Errorf("sum(): %v", err)
+		}
+		return &sumExpr{re: re}, nil
+	case "rate", "delta", "abs":
+		if err := wantArgs(1); err != nil {
+			return nil, err
+		}
+		args, err := compileArgs()
+		if err != nil {
+			return nil, err
+		}
+		if fn.Name == "abs" {
+			return &absExpr{x: args[0]}, nil
+		}
+		return &rateExpr{x: args[0], isDelta: fn.Name == "delta"}, nil
+	case "clamp":
+		if err := wantArgs(3); err != nil {
+			return nil, err
+		}
+		args, err := compileArgs()
+		if err != nil {
+			return nil, err
+		}
+		return &clampExpr{x: args[0], lo: args[1], hi: args[2]}, nil
+	case "min", "max":
+		if len(call.Args) < 2 {
+			return nil, fmt.Errorf("%s(): expected at least 2 arguments, got %d", fn.Name, len(call.Args))
+		}
+		args, err := compileArgs()
+		if err != nil {
+			return nil, err
+		}
+		return &minMaxExpr{args: args, isMax: fn.Name == "max"}, nil
+	}
+
+	return nil, fmt.Errorf("unknown function '%s'", fn.Name)
+}
+
+func exprKey(node ast.Expr) (string, bool) {
+	switch n := node.(type) {
+	case *ast.Ident:
+		return n.Name, true
+	case *ast.SelectorExpr:
+		if k, ok := exprKey(n.X); ok {
+			return k + "." + n.Sel.Name, true
+		}
+	}
+	return "", false
+}
+
+func stringLit(node ast.Expr) (string, error) {
+	lit, ok := node.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", errors.New("expected a string literal")
+	}
+	return strconv.Unquote(lit.Value)
+}
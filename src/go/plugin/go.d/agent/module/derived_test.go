@@ -0,0 +1,334 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package module
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileDerivedExpr(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		wantFail bool
+	}{
+		"arithmetic":               {expr: "hits / (hits + misses) * 100"},
+		"unary minus":              {expr: "-hits + 1.5"},
+		"dotted key":               {expr: "cpu.user + cpu.system"},
+		"metric func":              {expr: `metric("conn-active") * 2`},
+		"functions":                {expr: `clamp(rate(requests), 0, 100) + min(a, b) + max(a, b, c) + abs(delta(a))`},
+		"sum func":                 {expr: `sum("^db_.+_keys$")`},
+		"empty":                    {expr: "", wantFail: true},
+		"syntax error":             {expr: "hits / (", wantFail: true},
+		"unsupported operator":     {expr: "hits % 2", wantFail: true},
+		"unknown function":         {expr: "sqrt(hits)", wantFail: true},
+		"wrong number of args":     {expr: "clamp(hits, 0)", wantFail: true},
+		"sum of non string":        {expr: "sum(hits)", wantFail: true},
+		"sum invalid regex":        {expr: `sum("[a-")`, wantFail: true},
+		"min with single argument": {expr: "min(hits)", wantFail: true},
+		"string literal":           {expr: `"hits"`, wantFail: true},
+		"comparison":               {expr: "hits > 1", wantFail: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := compileDerivedExpr(test.expr)
+
+			if test.wantFail {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDerivedExpr_Eval(t *testing.T) {
+	mx := map[string]int64{
+		"hits":           30,
+		"misses":         10,
+		"zero":           0,
+		"cpu.user":       5,
+		"conn-active":    7,
+		"db_db0_keys":    100,
+		"db_db1_keys":    50,
+		"db_db1_expires": 20,
+		"requests":       1000,
+	}
+
+	tests := map[string]struct {
+		expr   string
+		wantV  float64
+		wantOK bool
+	}{
+		"ratio":            {expr: "hits / (hits + misses) * 100", wantV: 75, wantOK: true},
+		"unary minus":      {expr: "-hits + 1.5", wantV: -28.5, wantOK: true},
+		"dotted key":       {expr: "cpu.user * 2", wantV: 10, wantOK: true},
+		"metric func":      {expr: `metric("conn-active")`, wantV: 7, wantOK: true},
+		"clamp high":       {expr: "clamp(hits, 0, 20)", wantV: 20, wantOK: true},
+		"clamp low":        {expr: "clamp(-hits, 0, 20)", wantV: 0, wantOK: true},
+		"min":              {expr: "min(hits, misses, 20)", wantV: 10, wantOK: true},
+		"max":              {expr: "max(hits, misses, 20)", wantV: 30, wantOK: true},
+		"abs":              {expr: "abs(misses - hits)", wantV: 20, wantOK: true},
+		"sum":              {expr: `sum("^db_.+_keys$")`, wantV: 150, wantOK: true},
+		"sum no matches":   {expr: `sum("^nomatch$")`, wantOK: false},
+		"missing metric":   {expr: "hits + nomatch", wantOK: false},
+		"division by zero": {expr: "hits / zero", wantOK: false},
+		"rate first run":   {expr: "rate(requests)", wantOK: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := compileDerivedExpr(test.expr)
+			require.NoError(t, err)
+
+			v, ok := expr.eval(&derivedEvalCtx{mx: mx, now: time.Now()})
+
+			assert.Equal(t, test.wantOK, ok)
+			if test.wantOK {
+				assert.InDelta(t, test.wantV, v, 1e-9)
+			}
+		})
+	}
+}
+
+func TestDerivedExpr_EvalRate(t *testing.T) {
+	rate, err := compileDerivedExpr("rate(requests)")
+	require.NoError(t, err)
+	delta, err := compileDerivedExpr("delta(requests)")
+	require.NoError(t, err)
+
+	now := time.Now()
+	steps := []struct {
+		value     int64
+		wantRate  float64
+		wantDelta float64
+		wantOK    bool
+	}{
+		{value: 100, wantOK: false},
+		{value: 200, wantRate: 10, wantDelta: 100, wantOK: true},
+		{value: 500, wantRate: 30, wantDelta: 300, wantOK: true},
+	}
+
+	for i, step := range steps {
+		ctx := &derivedEvalCtx{
+			mx:  map[string]int64{"requests": step.value},
+			now: now.Add(time.Duration(i) * time.Second * 10),
+		}
+
+		v, ok := rate.eval(ctx)
+		assert.Equalf(t, step.wantOK, ok, "step %d rate", i+1)
+		assert.InDeltaf(t, step.wantRate, v, 1e-9, "step %d rate", i+1)
+
+		v, ok = delta.eval(ctx)
+		assert.Equalf(t, step.wantOK, ok, "step %d delta", i+1)
+		assert.InDeltaf(t, step.wantDelta, v, 1e-9, "step %d delta", i+1)
+	}
+
+	// counter reset
+	v, ok := rate.eval(&derivedEvalCtx{mx: map[string]int64{"requests": 10}, now: now.Add(time.Second * 30)})
+	assert.False(t, ok)
+	assert.Zero(t, v)
+}
+
+func TestDerivedExpr_EvalRateStateOnFailedOperand(t *testing.T) {
+	// the other operand can't be evaluated on the first run, the rate() state must be updated nevertheless
+	tests := map[string]float64{
+		"misses / rate(requests)":     2,
+		"min(misses, rate(requests))": 10,
+	}
+
+	for expr, want := range tests {
+		t.Run(expr, func(t *testing.T) {
+			e, err := compileDerivedExpr(expr)
+			require.NoError(t, err)
+
+			now := time.Now()
+			_, ok := e.eval(&derivedEvalCtx{mx: map[string]int64{"requests": 100}, now: now})
+			assert.False(t, ok)
+
+			v, ok := e.eval(&derivedEvalCtx{mx: map[string]int64{"requests": 200, "misses": 20}, now: now.Add(time.Second * 10)})
+			assert.True(t, ok)
+			assert.InDelta(t, want, v, 1e-9)
+		})
+	}
+}
+
+func TestDerivedMetrics_CollectSkipsOutOfRangeValues(t *testing.T) {
+	d, err := newDerivedMetrics(modName, []DerivedChartConfig{
+		{ID: "values", Units: "values", Dims: []DerivedDimConfig{
+			{Name: "ok", Expr: "hits / 2"},
+			{Name: "overflow", Expr: "hits * hits * hits"},
+			{Name: "negative_overflow", Expr: "-hits * hits * hits"},
+		}},
+	})
+	require.NoError(t, err)
+
+	mx := d.collect(map[string]int64{"hits": 1 << 31}, time.Now())
+
+	assert.Equal(t, map[string]int64{"derived_values_ok": 1 << 30 * derivedPrecision}, mx)
+}
+
+func TestNewDerivedMetrics(t *testing.T) {
+	tests := map[string]struct {
+		cfgs     []DerivedChartConfig
+		wantFail bool
+	}{
+		"valid": {
+			cfgs: []DerivedChartConfig{
+				{ID: "hit_ratio", Units: "percentage", Type: "area", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits / (hits + misses) * 100"}}},
+			},
+		},
+		"empty id": {
+			wantFail: true,
+			cfgs:     []DerivedChartConfig{{Units: "percentage", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}}}},
+		},
+		"duplicate id": {
+			wantFail: true,
+			cfgs: []DerivedChartConfig{
+				{ID: "hits", Units: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}}},
+				{ID: "hits", Units: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}}},
+			},
+		},
+		"no units": {
+			wantFail: true,
+			cfgs:     []DerivedChartConfig{{ID: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}}}},
+		},
+		"no dimensions": {
+			wantFail: true,
+			cfgs:     []DerivedChartConfig{{ID: "hits", Units: "hits"}},
+		},
+		"unknown chart type": {
+			wantFail: true,
+			cfgs:     []DerivedChartConfig{{ID: "hits", Units: "hits", Type: "pie", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}}}},
+		},
+		"duplicate dimension": {
+			wantFail: true,
+			cfgs: []DerivedChartConfig{
+				{ID: "hits", Units: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}, {Name: "hits", Expr: "misses"}}},
+			},
+		},
+		"dimension id collides across charts": {
+			wantFail: true,
+			cfgs: []DerivedChartConfig{
+				{ID: "a", Units: "hits", Dims: []DerivedDimConfig{{Name: "b_c", Expr: "hits"}}},
+				{ID: "a_b", Units: "hits", Dims: []DerivedDimConfig{{Name: "c", Expr: "misses"}}},
+			},
+		},
+		"invalid expression": {
+			wantFail: true,
+			cfgs:     []DerivedChartConfig{{ID: "hits", Units: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits +"}}}},
+		},
+		"id with space": {
+			wantFail: true,
+			cfgs:     []DerivedChartConfig{{ID: "hit ratio", Units: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, err := newDerivedMetrics(modName, test.cfgs)
+
+			if test.wantFail {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, d.charts, len(test.cfgs))
+				assert.Equal(t, "module.derived_hit_ratio", d.charts[0].Ctx)
+				assert.Equal(t, Area, d.charts[0].Type)
+			}
+		})
+	}
+}
+
+func TestJob_DerivedMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	job := NewJob(JobConfig{
+		PluginName:  pluginName,
+		Name:        jobName,
+		ModuleName:  modName,
+		FullName:    modName + "_" + jobName,
+		Out:         &buf,
+		UpdateEvery: 1,
+		Derived: []DerivedChartConfig{
+			{
+				ID:    "hit_ratio",
+				Title: "Hit Ratio",
+				Units: "percentage",
+				Dims: []DerivedDimConfig{
+					{Name: "hits", Expr: "hits / (hits + misses) * 100"},
+					{Name: "unknown", Expr: "nomatch * 100"},
+				},
+			},
+		},
+		Module: &MockModule{
+			ChartsFunc: func() *Charts {
+				return &Charts{
+					{ID: "requests", Title: "Requests", Units: "requests", Dims: Dims{{ID: "hits"}, {ID: "misses"}}},
+				}
+			},
+			CollectFunc: func(context.Context) map[string]int64 {
+				return map[string]int64{"hits": 3, "misses": 1}
+			},
+		},
+	})
+
+	require.NoError(t, job.AutoDetection())
+
+	job.runOnce()
+
+	out := buf.String()
+	assert.Contains(t, out, "CHART 'module_job.derived_hit_ratio' '' 'Hit Ratio' 'percentage' 'derived' 'module.derived_hit_ratio' 'line'")
+	assert.Contains(t, out, "SET 'hits' = 75000\n")
+	assert.Contains(t, out, "SET 'unknown' = \n")
+}
+
+func TestJob_DerivedMetrics_ChartIDTooLong(t *testing.T) {
+	var buf bytes.Buffer
+	job := NewJob(JobConfig{
+		PluginName: pluginName,
+		Name:       jobName,
+		ModuleName: modName,
+		FullName:   modName + "_" + jobName,
+		Out:        &buf,
+		Derived: []DerivedChartConfig{
+			{ID: strings.Repeat("x", NetdataChartIDMaxLength), Units: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits"}}},
+		},
+		Module: &MockModule{
+			ChartsFunc: func() *Charts {
+				return &Charts{{ID: "requests", Title: "Requests", Units: "requests", Dims: Dims{{ID: "hits"}}}}
+			},
+			CollectFunc: func(context.Context) map[string]int64 {
+				return map[string]int64{"hits": 3}
+			},
+		},
+	})
+
+	require.NoError(t, job.AutoDetection())
+
+	job.runOnce()
+
+	out := buf.String()
+	assert.Contains(t, out, "CHART 'module_job.requests'")
+	assert.NotContains(t, out, "derived_xxx")
+}
+
+func TestJob_DerivedMetrics_InvalidConfig(t *testing.T) {
+	job := NewJob(JobConfig{
+		PluginName: pluginName,
+		Name:       jobName,
+		ModuleName: modName,
+		FullName:   modName + "_" + jobName,
+		Out:        &bytes.Buffer{},
+		Derived:    []DerivedChartConfig{{ID: "hits", Units: "hits", Dims: []DerivedDimConfig{{Name: "hits", Expr: "hits +"}}}},
+		Module:     &MockModule{},
+	})
+
+	assert.Error(t, job.AutoDetection())
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/logger"
 	"github.com/netdata/netdata/go/plugins/pkg/netdataapi"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/vnodes"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
 )
@@ -77,6 +78,9 @@ type JobConfig struct {
 	Priority        int
 	IsStock         bool
 	Vnode           vnodes.VirtualNode
+	Derived         []DerivedChartConfig
+	MaxCharts       int
+	MaxDims         int
 }
 
 const (
@@ -96,24 +100,30 @@ func NewJob(cfg JobConfig) *Job {
 		AutoDetectEvery: cfg.AutoDetectEvery,
 		AutoDetectTries: infTries,
 
-		pluginName:           cfg.PluginName,
-		name:                 cfg.Name,
-		moduleName:           cfg.ModuleName,
-		fullName:             cfg.FullName,
-		updateEvery:          cfg.UpdateEvery,
-		priority:             cfg.Priority,
-		isStock:              cfg.IsStock,
-		module:               cfg.Module,
-		labels:               cfg.Labels,
-		out:                  cfg.Out,
-		collectStatusChart:   newCollectStatusChart(cfg.PluginName),
-		collectDurationChart: newCollectDurationChart(cfg.PluginName),
-		stop:                 make(chan struct{}),
-		tick:                 make(chan int),
-		buf:                  &buf,
-		api:                  netdataapi.New(&buf),
-		vnode:                cfg.Vnode,
-		updVnode:             make(chan *vnodes.VirtualNode, 1),
+		pluginName:              cfg.PluginName,
+		name:                    cfg.Name,
+		moduleName:              cfg.ModuleName,
+		fullName:                cfg.FullName,
+		updateEvery:             cfg.UpdateEvery,
+		priority:                cfg.Priority,
+		isStock:                 cfg.IsStock,
+		module:                  cfg.Module,
+		labels:                  cfg.Labels,
+		out:                     cfg.Out,
+		collectStatusChart:      newCollectStatusChart(cfg.PluginName),
+		collectDurationChart:    newCollectDurationChart(cfg.PluginName),
+		maxCharts:               cfg.MaxCharts,
+		maxDims:                 cfg.MaxDims,
+		chartsBudget:            pluginCharts,
+		cardinalityStatusChart:  newCardinalityStatusChart(cfg.PluginName),
+		cardinalityDroppedChart: newCardinalityDroppedChart(cfg.PluginName),
+		stop:                    make(chan struct{}),
+		tick:                    make(chan int),
+		buf:                     &buf,
+		api:                     netdataapi.New(&buf),
+		vnode:                   cfg.Vnode,
+		updVnode:                make(chan *vnodes.VirtualNode, 1),
+		derivedConfigs:          cfg.Derived,
 	}
 
 	log := logger.New().With(
@@ -153,11 +163,24 @@ type Job struct {
 
 	collectStatusChart   *Chart
 	collectDurationChart *Chart
-	charts               *Charts
-	tick                 chan int
-	out                  io.Writer
-	buf                  *bytes.Buffer
-	api                  *netdataapi.API
+
+	maxCharts               int
+	maxDims                 int
+	chartsBudget            *chartsBudget
+	acquiredCharts          int
+	droppedCharts           int
+	droppedDims             int
+	limitReached            bool
+	cardinalityStatusChart  *Chart
+	cardinalityDroppedChart *Chart
+
+	charts         *Charts
+	derivedConfigs []DerivedChartConfig
+	derived        *derivedMetrics
+	tick           chan int
+	out            io.Writer
+	buf            *bytes.Buffer
+	api            *netdataapi.API
 
 	vnodeCreated bool
 	vnode        vnodes.VirtualNode
@@ -216,6 +239,7 @@ func (j *Job) AutoDetection() (err error) {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("panic %v", err)
 			j.panicked = true
+			j.countError(telemetry.ErrorTypePanic)
 			j.disableAutoDetection()
 
 			j.Errorf("PANIC %v", r)
@@ -225,6 +249,10 @@ func (j *Job) AutoDetection() (err error) {
 		}
 		if err != nil {
 			j.module.Cleanup(context.TODO())
+			// the job never reaches Start, keep its error series only while detection is retried
+			if !j.RetryAutoDetection() {
+				j.deleteErrorsTelemetry()
+			}
 		}
 	}()
 
@@ -234,6 +262,7 @@ func (j *Job) AutoDetection() (err error) {
 
 	if err = j.init(); err != nil {
 		j.Errorf("init failed: %v", err)
+		j.countError(telemetry.ErrorTypeInit)
 		j.Unmute()
 		j.disableAutoDetection()
 		return err
@@ -241,6 +270,7 @@ func (j *Job) AutoDetection() (err error) {
 
 	if err = j.check(); err != nil {
 		j.Errorf("check failed: %v", err)
+		j.countError(telemetry.ErrorTypeCheck)
 		j.Unmute()
 		return err
 	}
@@ -250,6 +280,7 @@ func (j *Job) AutoDetection() (err error) {
 
 	if err = j.postCheck(); err != nil {
 		j.Errorf("postCheck failed: %v", err)
+		j.countError(telemetry.ErrorTypePostCheck)
 		j.disableAutoDetection()
 		return err
 	}
@@ -279,6 +310,16 @@ func (j *Job) Start() {
 	j.Infof("started, data collection interval %ds", j.updateEvery)
 	defer func() { j.Info("stopped") }()
 
+	telemetry.RunningJobs.Add(1, j.moduleName)
+	defer func() {
+		telemetry.RunningJobs.Add(-1, j.moduleName)
+		telemetry.JobCollectDuration.Delete(j.moduleName, j.name)
+		telemetry.JobCharts.Delete(j.moduleName, j.name)
+		telemetry.JobDimensions.Delete(j.moduleName, j.name)
+		telemetry.JobDroppedCharts.Delete(j.moduleName, j.name)
+		j.deleteErrorsTelemetry()
+	}()
+
 LOOP:
 	for {
 		select {
@@ -308,6 +349,7 @@ func (j *Job) disableAutoDetection() {
 
 func (j *Job) Cleanup() {
 	j.buf.Reset()
+	j.releaseCharts()
 	if !shouldObsoleteCharts() {
 		return
 	}
@@ -330,6 +372,14 @@ func (j *Job) Cleanup() {
 		j.collectDurationChart.MarkRemove()
 		j.createChart(j.collectDurationChart)
 	}
+	if j.cardinalityStatusChart.created {
+		j.cardinalityStatusChart.MarkRemove()
+		j.createChart(j.cardinalityStatusChart)
+	}
+	if j.cardinalityDroppedChart.created {
+		j.cardinalityDroppedChart.MarkRemove()
+		j.createChart(j.cardinalityDroppedChart)
+	}
 
 	if j.charts != nil {
 		for _, chart := range *j.charts {
@@ -340,6 +390,15 @@ func (j *Job) Cleanup() {
 		}
 	}
 
+	if j.derived != nil {
+		for _, chart := range j.derived.charts {
+			if chart.created {
+				chart.MarkRemove()
+				j.createChart(chart)
+			}
+		}
+	}
+
 	if j.buf.Len() > 0 {
 		_, _ = io.Copy(j.out, j.buf)
 	}
@@ -350,6 +409,14 @@ func (j *Job) init() error {
 		return nil
 	}
 
+	if len(j.derivedConfigs) > 0 {
+		derived, err := newDerivedMetrics(j.moduleName, j.derivedConfigs)
+		if err != nil {
+			return fmt.Errorf("derived metrics: %v", err)
+		}
+		j.derived = derived
+	}
+
 	if err := j.module.Init(context.TODO()); err != nil {
 		return err
 	}
@@ -388,7 +455,10 @@ func (j *Job) runOnce() {
 
 	metrics := j.collect()
 
+	telemetry.JobCollectDuration.Observe(time.Since(curTime).Seconds(), j.moduleName, j.name)
+
 	if j.panicked {
+		j.countError(telemetry.ErrorTypePanic)
 		return
 	}
 
@@ -396,8 +466,11 @@ func (j *Job) runOnce() {
 		j.retries = 0
 	} else {
 		j.retries++
+		j.countError(telemetry.ErrorTypeNoData)
 	}
 
+	j.updateChartsTelemetry()
+
 	_, _ = io.Copy(j.out, j.buf)
 	j.buf.Reset()
 }
@@ -416,6 +489,44 @@ func (j *Job) collect() (result map[string]int64) {
 	return j.module.Collect(context.TODO())
 }
 
+func (j *Job) countError(typ string) {
+	telemetry.JobErrors.Inc(j.moduleName, j.name, typ)
+}
+
+// deleteErrorsTelemetry deletes the job error series of all types.
+func (j *Job) deleteErrorsTelemetry() {
+	telemetry.JobErrors.DeleteMatching(j.moduleName, j.name)
+}
+
+func (j *Job) updateChartsTelemetry() {
+	var charts, dims int
+	count := func(chart *Chart) {
+		if chart.remove || chart.ignore {
+			return
+		}
+		charts++
+		for _, dim := range chart.Dims {
+			if !dim.remove {
+				dims++
+			}
+		}
+	}
+
+	if j.charts != nil {
+		for _, chart := range *j.charts {
+			count(chart)
+		}
+	}
+	if j.derived != nil {
+		for _, chart := range j.derived.charts {
+			count(chart)
+		}
+	}
+
+	telemetry.JobCharts.Set(float64(charts), j.moduleName, j.name)
+	telemetry.JobDimensions.Set(float64(dims), j.moduleName, j.name)
+}
+
 func (j *Job) processMetrics(metrics map[string]int64, startTime time.Time, sinceLastRun int) bool {
 	var createChart bool
 	if j.module.VirtualNode() == nil {
@@ -447,6 +558,10 @@ func (j *Job) processMetrics(metrics map[string]int64, startTime time.Time, sinc
 	j.api.HOST(j.vnode.GUID)
 
 	if !j.collectStatusChart.created || createChart {
+		// collected, but dropped due to cardinality limits, is possible only if a budget is configured
+		if j.hasCardinalityLimits() && j.collectStatusChart.GetDim("limited") == nil {
+			_ = j.collectStatusChart.AddDim(&Dim{ID: "limited"})
+		}
 		j.collectStatusChart.ID = fmt.Sprintf("%s_%s_data_collection_status", cleanPluginName(j.pluginName), j.FullName())
 		j.createChart(j.collectStatusChart)
 	}
@@ -458,18 +573,25 @@ func (j *Job) processMetrics(metrics map[string]int64, startTime time.Time, sinc
 
 	elapsed := int64(durationTo(time.Since(startTime), time.Millisecond))
 
+	j.admitCharts()
+
 	var i, updated int
 	for _, chart := range *j.charts {
-		if !chart.created || createChart {
-			typeID := fmt.Sprintf("%s.%s", j.FullName(), chart.ID)
-			if len(typeID) >= NetdataChartIDMaxLength {
-				j.Warningf("chart 'type.id' length (%d) >= max allowed (%d), the chart is ignored (%s)",
-					len(typeID), NetdataChartIDMaxLength, typeID)
-				chart.ignore = true
+		if !chart.admitted {
+			if !chart.remove {
+				(*j.charts)[i] = chart
+				i++
 			}
+			continue
+		}
+		if !chart.created || createChart {
+			j.checkChartIDLength(chart)
 			j.createChart(chart)
 		}
 		if chart.remove {
+			chart.admitted = false
+			j.chartsBudget.release(1)
+			j.acquiredCharts--
 			continue
 		}
 		(*j.charts)[i] = chart
@@ -483,13 +605,26 @@ func (j *Job) processMetrics(metrics map[string]int64, startTime time.Time, sinc
 	}
 	*j.charts = (*j.charts)[:i]
 
+	if j.derived != nil {
+		updated += j.processDerivedMetrics(metrics, startTime, sinceLastRun, createChart)
+	}
+
+	j.processCardinalityCharts(createChart, sinceLastRun)
+
+	// collected, but all the charts are dropped due to cardinality limits
+	limited := updated == 0 && len(metrics) > 0 && j.limitReached
+
 	j.updateChart(
 		j.collectStatusChart,
-		map[string]int64{"success": metrix.Bool(updated > 0), "failed": metrix.Bool(updated == 0)},
+		map[string]int64{
+			"success": metrix.Bool(updated > 0),
+			"failed":  metrix.Bool(updated == 0 && !limited),
+			"limited": metrix.Bool(limited),
+		},
 		sinceLastRun,
 	)
 
-	if updated == 0 {
+	if updated == 0 && !limited {
 		return false
 	}
 
@@ -498,6 +633,37 @@ func (j *Job) processMetrics(metrics map[string]int64, startTime time.Time, sinc
 	return true
 }
 
+func (j *Job) processDerivedMetrics(metrics map[string]int64, now time.Time, sinceLastRun int, createChart bool) (updated int) {
+	var mx map[string]int64
+	if len(metrics) > 0 {
+		mx = j.derived.collect(metrics, now)
+	}
+
+	for _, chart := range j.derived.charts {
+		if !chart.admitted {
+			continue
+		}
+		if !chart.created || createChart {
+			j.checkChartIDLength(chart)
+			j.createChart(chart)
+		}
+		if len(mx) > 0 && j.updateChart(chart, mx, sinceLastRun) {
+			updated++
+		}
+	}
+	return updated
+}
+
+// checkChartIDLength marks the chart as ignored if its 'type.id' exceeds the Netdata limit.
+func (j *Job) checkChartIDLength(chart *Chart) {
+	typeID := fmt.Sprintf("%s.%s", j.FullName(), chart.ID)
+	if len(typeID) >= NetdataChartIDMaxLength {
+		j.Warningf("chart 'type.id' length (%d) >= max allowed (%d), the chart is ignored (%s)",
+			len(typeID), NetdataChartIDMaxLength, typeID)
+		chart.ignore = true
+	}
+}
+
 func (j *Job) createChart(chart *Chart) {
 	defer func() { chart.created = true }()
 	if chart.ignore {
@@ -3,13 +3,17 @@
 package module
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -172,6 +176,35 @@ func TestJob_AutoDetection_FailPostCheck(t *testing.T) {
 	assert.True(t, m.CleanupDone)
 }
 
+func TestJob_AutoDetection_FailDeletesErrorsTelemetry(t *testing.T) {
+	hasErrorsSeries := func() bool {
+		var buf bytes.Buffer
+		_, _ = telemetry.Default.WriteTo(&buf)
+		return strings.Contains(buf.String(), fmt.Sprintf(`job_errors_total{module="%s",job="%s"`, modName, jobName))
+	}
+	failCheck := &MockModule{
+		InitFunc:  func(context.Context) error { return nil },
+		CheckFunc: func(context.Context) error { return errors.New("check error") },
+	}
+
+	job := newTestJob()
+	job.AutoDetectEvery = 10
+	job.module = failCheck
+	assert.Error(t, job.AutoDetection())
+	assert.True(t, hasErrorsSeries(), "kept while detection is retried")
+
+	job = newTestJob()
+	job.module = failCheck
+	assert.Error(t, job.AutoDetection())
+	assert.False(t, hasErrorsSeries(), "deleted when detection is not retried")
+
+	job = newTestJob()
+	job.module = &MockModule{InitFunc: func(context.Context) error { return errors.New("init error") }}
+	job.AutoDetectEvery = 10
+	assert.Error(t, job.AutoDetection())
+	assert.False(t, hasErrorsSeries(), "deleted after init failure")
+}
+
 func TestJob_AutoDetection_PanicInit(t *testing.T) {
 	job := newTestJob()
 	m := &MockModule{
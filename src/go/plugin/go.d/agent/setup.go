@@ -75,7 +75,7 @@ func (a *Agent) loadEnabledModules(cfg config) module.Registry {
 	return enabled
 }
 
-func (a *Agent) buildDiscoveryConf(enabled module.Registry) discovery.Config {
+func (a *Agent) buildDiscoveryConf(enabled module.Registry, limits cardinalityConfig) discovery.Config {
 	a.Info("building discovery config")
 
 	reg := confgroup.Registry{}
@@ -85,6 +85,8 @@ func (a *Agent) buildDiscoveryConf(enabled module.Registry) discovery.Config {
 			UpdateEvery:        creator.UpdateEvery,
 			AutoDetectionRetry: creator.AutoDetectionRetry,
 			Priority:           creator.Priority,
+			MaxCharts:          limits.MaxChartsPerJob,
+			MaxDims:            limits.MaxDimsPerJob,
 		})
 	}
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/telemetry"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +29,35 @@ func TestConfig_UnmarshalYAML(t *testing.T) {
 				},
 			},
 		},
+		"valid configuration with telemetry": {
+			input: "enabled: yes\ndefault_run: yes\ntelemetry:\n  enabled: yes\n  address: 127.0.0.1:9999\nmodules:\n  module1: yes",
+			wantCfg: config{
+				Enabled:    true,
+				DefaultRun: true,
+				Modules: map[string]bool{
+					"module1": true,
+				},
+				Telemetry: telemetry.Config{
+					Enabled: true,
+					Address: "127.0.0.1:9999",
+				},
+			},
+		},
+		"valid configuration with cardinality limits": {
+			input: "enabled: yes\ndefault_run: yes\ncardinality:\n  max_charts: 1000\n  max_charts_per_job: 100\n  max_dims_per_job: 500\nmodules:\n  module1: yes",
+			wantCfg: config{
+				Enabled:    true,
+				DefaultRun: true,
+				Modules: map[string]bool{
+					"module1": true,
+				},
+				Cardinality: cardinalityConfig{
+					MaxCharts:       1000,
+					MaxChartsPerJob: 100,
+					MaxDimsPerJob:   500,
+				},
+			},
+		},
 		"valid configuration with broken modules section": {
 			input: "enabled: yes\ndefault_run: yes\nmodules:\nmodule1: yes\nmodule2: yes",
 			wantCfg: config{
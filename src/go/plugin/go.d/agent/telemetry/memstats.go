@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package telemetry
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// memStatsCache avoids calling runtime.ReadMemStats (stop-the-world) for every memory metric of a single scrape.
+type memStatsCache struct {
+	mu      sync.Mutex
+	stats   runtime.MemStats
+	updated time.Time
+}
+
+func newMemStatsCache() *memStatsCache {
+	return &memStatsCache{}
+}
+
+func (c *memStatsCache) get() runtime.MemStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now := time.Now(); now.Sub(c.updated) > time.Second {
+		runtime.ReadMemStats(&c.stats)
+		c.updated = now
+	}
+	return c.stats
+}
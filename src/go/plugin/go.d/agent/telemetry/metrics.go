@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package telemetry
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/buildinfo"
+)
+
+// Default is the registry of the plugin self-metrics served by the telemetry server.
+var Default = NewRegistry()
+
+const namespace = "godplugin_"
+
+// Job error types.
+const (
+	ErrorTypeInit      = "init"
+	ErrorTypeCheck     = "check"
+	ErrorTypePostCheck = "post_check"
+	ErrorTypePanic     = "panic"
+	ErrorTypeNoData    = "no_data"
+)
+
+var (
+	JobCollectDuration = Default.NewHistogramVec(
+		namespace+"job_collect_duration_seconds",
+		"Data collection duration of the job.",
+		[]float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		"module", "job",
+	)
+	JobErrors = Default.NewCounterVec(
+		namespace+"job_errors_total",
+		"Number of job errors by type (init, check, post_check, panic, no_data).",
+		"module", "job", "type",
+	)
+	JobCharts = Default.NewGaugeVec(
+		namespace+"job_charts",
+		"Number of charts of the job.",
+		"module", "job",
+	)
+	JobDimensions = Default.NewGaugeVec(
+		namespace+"job_dimensions",
+		"Number of dimensions of the job.",
+		"module", "job",
+	)
+	JobDroppedCharts = Default.NewGaugeVec(
+		namespace+"job_dropped_charts",
+		"Number of charts of the job dropped due to cardinality limits.",
+		"module", "job",
+	)
+	RunningJobs = Default.NewGaugeVec(
+		namespace+"running_jobs",
+		"Number of running jobs.",
+		"module",
+	)
+	DiscoveryTargets = Default.NewGaugeVec(
+		namespace+"discovery_targets",
+		"Number of targets discovered by the service discovery pipeline.",
+		"pipeline",
+	)
+	DiscoveryConfigs = Default.NewGaugeVec(
+		namespace+"discovery_configs",
+		"Number of job configurations composed by the service discovery pipeline.",
+		"pipeline",
+	)
+	DyncfgCommands = Default.NewCounterVec(
+		namespace+"dyncfg_commands_total",
+		"Number of received dynamic configuration commands.",
+		"command",
+	)
+)
+
+var startTime = time.Now()
+
+func init() {
+	Default.NewGaugeVec(
+		namespace+"build_info",
+		"A metric with a constant '1' value labeled by the plugin version and the Go version it was built with.",
+		"version", "goversion",
+	).Set(1, buildinfo.Version, runtime.Version())
+
+	Default.NewGaugeFunc(
+		"process_start_time_seconds",
+		"Start time of the process since unix epoch in seconds.",
+		func() float64 { return float64(startTime.Unix()) },
+	)
+	Default.NewGaugeFunc(
+		"go_goroutines",
+		"Number of goroutines that currently exist.",
+		func() float64 { return float64(runtime.NumGoroutine()) },
+	)
+
+	ms := newMemStatsCache()
+	Default.NewGaugeFunc(
+		"go_memstats_alloc_bytes",
+		"Number of bytes allocated and still in use.",
+		func() float64 { return float64(ms.get().Alloc) },
+	)
+	Default.NewGaugeFunc(
+		"go_memstats_heap_inuse_bytes",
+		"Number of heap bytes that are in use.",
+		func() float64 { return float64(ms.get().HeapInuse) },
+	)
+	Default.NewGaugeFunc(
+		"go_memstats_sys_bytes",
+		"Number of bytes obtained from system.",
+		func() float64 { return float64(ms.get().Sys) },
+	)
+	Default.NewCounterFunc(
+		"go_memstats_mallocs_total",
+		"Total number of mallocs.",
+		func() float64 { return float64(ms.get().Mallocs) },
+	)
+	Default.NewCounterFunc(
+		"go_memstats_frees_total",
+		"Total number of frees.",
+		func() float64 { return float64(ms.get().Frees) },
+	)
+	Default.NewCounterFunc(
+		"go_gc_cycles_total",
+		"Number of completed GC cycles.",
+		func() float64 { return float64(ms.get().NumGC) },
+	)
+}
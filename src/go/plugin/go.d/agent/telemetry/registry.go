@@ -0,0 +1,349 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package telemetry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// labelValuesSep separates label values in series keys. It is not a valid UTF-8 byte.
+const labelValuesSep = "\xff"
+
+type metric interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds metrics and writes them in the Prometheus text exposition format.
+// https://prometheus.io/docs/instrumenting/exposition_formats/#text-based-format
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// WriteTo writes all registered metrics to w.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	metrics := slices.Clone(r.metrics)
+	r.mu.Unlock()
+
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, m := range metrics {
+		m.write(bw)
+	}
+	err := bw.Flush()
+
+	return cw.n, err
+}
+
+// NewCounterVec creates and registers a counter partitioned by the given labels.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	v := &CounterVec{vec: newVec[float64](name, help, labels)}
+	r.register(v)
+	return v
+}
+
+// NewGaugeVec creates and registers a gauge partitioned by the given labels.
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	v := &GaugeVec{vec: newVec[float64](name, help, labels)}
+	r.register(v)
+	return v
+}
+
+// NewGaugeFunc creates and registers a gauge whose value is obtained by calling fn on every write.
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	r.register(&gaugeFunc{name: name, help: help, typ: typeGauge, fn: fn})
+}
+
+// NewCounterFunc creates and registers a counter whose value is obtained by calling fn on every write.
+func (r *Registry) NewCounterFunc(name, help string, fn func() float64) {
+	r.register(&gaugeFunc{name: name, help: help, typ: typeCounter, fn: fn})
+}
+
+// NewHistogramVec creates and registers a histogram partitioned by the given labels.
+// Buckets are the upper inclusive bounds in increasing order, the +Inf bucket is added implicitly.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	v := &HistogramVec{vec: newVec[*histogram](name, help, labels), buckets: slices.Clone(buckets)}
+	r.register(v)
+	return v
+}
+
+type vec[T any] struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*series[T]
+}
+
+type series[T any] struct {
+	labelValues []string
+	value       T
+}
+
+func newVec[T any](name, help string, labels []string) vec[T] {
+	return vec[T]{
+		name:   name,
+		help:   help,
+		labels: labels,
+		series: make(map[string]*series[T]),
+	}
+}
+
+// get returns the series for the label values, creating it if needed. The caller must hold the lock.
+func (v *vec[T]) get(labelValues []string, newValue func() T) *series[T] {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("telemetry: metric '%s': expected %d label values, got %d", v.name, len(v.labels), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, labelValuesSep)
+	s, ok := v.series[key]
+	if !ok {
+		s = &series[T]{labelValues: slices.Clone(labelValues), value: newValue()}
+		v.series[key] = s
+	}
+	return s
+}
+
+// Delete removes the series with the given label values.
+func (v *vec[T]) Delete(labelValues ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.series, strings.Join(labelValues, labelValuesSep))
+}
+
+// DeleteMatching removes all series whose label values start with the given ones.
+func (v *vec[T]) DeleteMatching(labelValues ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for key, s := range v.series {
+		if len(s.labelValues) >= len(labelValues) && slices.Equal(s.labelValues[:len(labelValues)], labelValues) {
+			delete(v.series, key)
+		}
+	}
+}
+
+// sorted returns the series sorted by label values. The caller must hold the lock.
+func (v *vec[T]) sorted() []*series[T] {
+	keys := make([]string, 0, len(v.series))
+	for k := range v.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make([]*series[T], 0, len(keys))
+	for _, k := range keys {
+		res = append(res, v.series[k])
+	}
+	return res
+}
+
+func zero() float64 { return 0 }
+
+type CounterVec struct {
+	vec[float64]
+}
+
+// Inc increments the counter by 1.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds the given value to the counter. Negative values are ignored.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.get(labelValues, zero).value += v
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, typeCounter)
+	for _, s := range c.sorted() {
+		writeSample(w, c.name, c.labels, s.labelValues, "", "", s.value)
+	}
+}
+
+type GaugeVec struct {
+	vec[float64]
+}
+
+// Set sets the gauge to the given value.
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.get(labelValues, zero).value = v
+}
+
+// Add adds the given value (can be negative) to the gauge.
+func (g *GaugeVec) Add(v float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.get(labelValues, zero).value += v
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	writeHeader(w, g.name, g.help, typeGauge)
+	for _, s := range g.sorted() {
+		writeSample(w, g.name, g.labels, s.labelValues, "", "", s.value)
+	}
+}
+
+type gaugeFunc struct {
+	name string
+	help string
+	typ  string
+	fn   func() float64
+}
+
+func (g *gaugeFunc) write(w *bufio.Writer) {
+	writeHeader(w, g.name, g.help, g.typ)
+	writeSample(w, g.name, nil, nil, "", "", g.fn())
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative; the last one is +Inf
+	sum    float64
+	count  uint64
+}
+
+type HistogramVec struct {
+	vec[*histogram]
+	buckets []float64
+}
+
+// Observe adds a single observation to the histogram.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.get(labelValues, func() *histogram { return &histogram{counts: make([]uint64, len(h.buckets)+1)} })
+
+	idx, _ := slices.BinarySearch(h.buckets, v)
+	s.value.counts[idx]++
+	s.value.sum += v
+	s.value.count++
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, typeHistogram)
+	for _, s := range h.sorted() {
+		var cumulative uint64
+		for i, n := range s.value.counts {
+			cumulative += n
+			le := "+Inf"
+			if i < len(h.buckets) {
+				le = formatFloat(h.buckets[i])
+			}
+			writeSample(w, h.name+"_bucket", h.labels, s.labelValues, "le", le, float64(cumulative))
+		}
+		writeSample(w, h.name+"_sum", h.labels, s.labelValues, "", "", s.value.sum)
+		writeSample(w, h.name+"_count", h.labels, s.labelValues, "", "", float64(s.value.count))
+	}
+}
+
+func writeHeader(w *bufio.Writer, name, help, typ string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(help))
+	_, _ = fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+func writeSample(w *bufio.Writer, name string, labels, labelValues []string, extraLabel, extraValue string, value float64) {
+	_, _ = w.WriteString(name)
+
+	if len(labels) > 0 || extraLabel != "" {
+		_ = w.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				_ = w.WriteByte(',')
+			}
+			writeLabel(w, l, labelValues[i])
+		}
+		if extraLabel != "" {
+			if len(labels) > 0 {
+				_ = w.WriteByte(',')
+			}
+			writeLabel(w, extraLabel, extraValue)
+		}
+		_ = w.WriteByte('}')
+	}
+
+	_ = w.WriteByte(' ')
+	_, _ = w.WriteString(formatFloat(value))
+	_ = w.WriteByte('\n')
+}
+
+func writeLabel(w *bufio.Writer, name, value string) {
+	_, _ = w.WriteString(name)
+	_, _ = w.WriteString(`="`)
+	_, _ = w.WriteString(labelValueReplacer.Replace(value))
+	_ = w.WriteByte('"')
+}
+
+var (
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
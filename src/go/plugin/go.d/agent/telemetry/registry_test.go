@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package telemetry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	reg := NewRegistry()
+
+	counter := reg.NewCounterVec("test_errors_total", "Number of errors.", "module", "type")
+	counter.Inc("ping", "check")
+	counter.Inc("ping", "check")
+	counter.Add(3, "apache", "no_data")
+	counter.Add(-1, "apache", "no_data")
+
+	gauge := reg.NewGaugeVec("test_charts", "Number of charts.", "job")
+	gauge.Set(10, `local "1"`)
+	gauge.Set(5, "remote\\2")
+	gauge.Add(-2, "remote\\2")
+
+	hist := reg.NewHistogramVec("test_duration_seconds", "Collect duration.", []float64{0.1, 1}, "job")
+	hist.Observe(0.05, "local")
+	hist.Observe(0.1, "local")
+	hist.Observe(0.5, "local")
+	hist.Observe(2, "local")
+
+	reg.NewGaugeFunc("test_goroutines", "Number of goroutines.\nMultiline.", func() float64 { return 7 })
+
+	var buf bytes.Buffer
+	n, err := reg.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	want := `# HELP test_errors_total Number of errors.
+# TYPE test_errors_total counter
+test_errors_total{module="apache",type="no_data"} 3
+test_errors_total{module="ping",type="check"} 2
+# HELP test_charts Number of charts.
+# TYPE test_charts gauge
+test_charts{job="local \"1\""} 10
+test_charts{job="remote\\2"} 3
+# HELP test_duration_seconds Collect duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{job="local",le="0.1"} 2
+test_duration_seconds_bucket{job="local",le="1"} 3
+test_duration_seconds_bucket{job="local",le="+Inf"} 4
+test_duration_seconds_sum{job="local"} 2.65
+test_duration_seconds_count{job="local"} 4
+# HELP test_goroutines Number of goroutines.\nMultiline.
+# TYPE test_goroutines gauge
+test_goroutines 7
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestVec_Delete(t *testing.T) {
+	reg := NewRegistry()
+
+	gauge := reg.NewGaugeVec("test_charts", "Number of charts.", "module", "job")
+	gauge.Set(1, "ping", "local")
+	gauge.Set(2, "ping", "remote")
+	gauge.Set(3, "apache", "local")
+
+	gauge.Delete("ping", "local")
+
+	var buf bytes.Buffer
+	_, err := reg.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), `module="ping",job="local"`)
+	assert.Contains(t, buf.String(), `module="ping",job="remote"`)
+
+	gauge.DeleteMatching("ping")
+
+	buf.Reset()
+	_, err = reg.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), `module="ping"`)
+	assert.Contains(t, buf.String(), `test_charts{module="apache",job="local"} 3`)
+}
+
+func TestVec_WrongNumberOfLabelValues(t *testing.T) {
+	counter := NewRegistry().NewCounterVec("test_total", "Test.", "module", "job")
+
+	assert.Panics(t, func() { counter.Inc("ping") })
+}
+
+func TestDefault_WriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := Default.WriteTo(&buf)
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		"godplugin_build_info{",
+		"go_goroutines ",
+		"go_memstats_alloc_bytes ",
+		"process_start_time_seconds ",
+	} {
+		assert.Contains(t, buf.String(), "\n"+name)
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+)
+
+const (
+	DefaultAddress = "127.0.0.1:8201"
+	DefaultPath    = "/metrics"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type Config struct {
+	Enabled bool   `yaml:"enabled"`
+	Address string `yaml:"address"`
+	Path    string `yaml:"path"`
+}
+
+// Server serves the registry metrics over HTTP.
+type Server struct {
+	*logger.Logger
+
+	addr     string
+	path     string
+	registry *Registry
+}
+
+func NewServer(cfg Config, reg *Registry) *Server {
+	if cfg.Address == "" {
+		cfg.Address = DefaultAddress
+	}
+	if cfg.Path == "" {
+		cfg.Path = DefaultPath
+	}
+	return &Server{
+		Logger: logger.New().With(
+			slog.String("component", "telemetry"),
+		),
+		addr:     cfg.Address,
+		path:     cfg.Path,
+		registry: reg,
+	}
+}
+
+// Run listens on the configured address until the context is canceled.
+func (s *Server) Run(ctx context.Context) {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		s.Errorf("failed to listen on '%s': %v", s.addr, err)
+		return
+	}
+
+	s.Infof("serving self-metrics on 'http://%s%s'", ln.Addr(), s.path)
+
+	srv := &http.Server{
+		Handler:           s.handler(),
+		ReadHeaderTimeout: time.Second * 5,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.Errorf("server error: %v", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-done:
+		return
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+	<-done
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		if _, err := s.registry.WriteTo(w); err != nil {
+			s.Debugf("failed to write metrics: %v", err)
+		}
+	})
+	return mux
+}
@@ -86,6 +86,10 @@ var (
 )
 
 func (c *Collector) addLogicalDeviceCharts(ld *logicalDevice) {
+	if c.RAIDCharts {
+		return
+	}
+
 	charts := ldChartsTmpl.Copy()
 
 	for _, chart := range *charts {
@@ -106,6 +110,10 @@ func (c *Collector) addLogicalDeviceCharts(ld *logicalDevice) {
 }
 
 func (c *Collector) addPhysicalDeviceCharts(pd *physicalDevice) {
+	if c.RAIDCharts {
+		return
+	}
+
 	charts := pdChartsTmpl.Copy()
 
 	if _, err := strconv.ParseInt(pd.temperature, 10, 64); err != nil {
@@ -6,18 +6,30 @@ package adaptecraid
 
 import (
 	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
 )
 
 func (c *Collector) collect() (map[string]int64, error) {
 	mx := make(map[string]int64)
 
-	if err := c.collectLogicalDevices(mx); err != nil {
+	lds, err := c.collectLogicalDevices(mx)
+	if err != nil {
 		return nil, err
 	}
-	if err := c.collectPhysicalDevices(mx); err != nil {
+	pds, err := c.collectPhysicalDevices(mx)
+	if err != nil {
 		return nil, err
 	}
 
+	raidControllers := raidControllers(lds, pds)
+	hwraid.WriteMetrics(mx, raidControllers)
+	if c.RAIDCharts {
+		if err := c.raidCharts.Update(raidControllers); err != nil {
+			c.Warning(err)
+		}
+	}
+
 	return mx, nil
 }
 
@@ -18,21 +18,22 @@ type logicalDevice struct {
 	raidLevel     string
 	status        string
 	failedStripes string
+	segments      []string // member locations, see segmentLocation
 }
 
-func (c *Collector) collectLogicalDevices(mx map[string]int64) error {
+func (c *Collector) collectLogicalDevices(mx map[string]int64) (map[string]*logicalDevice, error) {
 	bs, err := c.exec.logicalDevicesInfo()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	devices, err := parseLogicDevInfo(bs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(devices) == 0 {
-		return errors.New("no logical devices found")
+		return nil, errors.New("no logical devices found")
 	}
 
 	for _, ld := range devices {
@@ -53,7 +54,7 @@ func (c *Collector) collectLogicalDevices(mx map[string]int64) error {
 		}
 	}
 
-	return nil
+	return devices, nil
 }
 
 func isOkLDStatus(ld *logicalDevice) bool {
@@ -95,8 +96,35 @@ func parseLogicDevInfo(bs []byte) (map[string]*logicalDevice, error) {
 			ld.status = getColonSepValue(line)
 		case strings.HasPrefix(line, "Failed stripes"):
 			ld.failedStripes = getColonSepValue(line)
+		case strings.HasPrefix(line, "Group") && strings.Contains(line, "Segment"):
+			if loc := segmentLocation(line); loc != "" {
+				ld.segments = append(ld.segments, loc)
+			}
 		}
 	}
 
 	return devices, nil
 }
+
+// segmentLocation returns the normalized location ("connector:0,device:1" or "enclosure:0,slot:1")
+// of a logical device segment.
+func segmentLocation(line string) string {
+	// Group 0, Segment 0 : Present (457862MB, SATA, SSD, Connector:0, Device:0) 7CS009RP
+	// Group 0, Segment 0 : Present (Controller:1,Connector:0,Device:0) 7CS009RP
+	i, j := strings.IndexByte(line, '('), strings.LastIndexByte(line, ')')
+	if i == -1 || j < i {
+		return ""
+	}
+
+	var parts []string
+	for _, v := range strings.Split(line[i+1:j], ",") {
+		v = strings.ToLower(strings.ReplaceAll(v, " ", ""))
+		for _, px := range []string{"connector:", "device:", "enclosure:", "slot:"} {
+			if strings.HasPrefix(v, px) {
+				parts = append(parts, v)
+			}
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
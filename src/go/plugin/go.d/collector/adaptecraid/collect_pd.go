@@ -22,22 +22,23 @@ type physicalDevice struct {
 	smart         string
 	smartWarnings string
 	powerState    string
+	ssd           string
 	temperature   string
 }
 
-func (c *Collector) collectPhysicalDevices(mx map[string]int64) error {
+func (c *Collector) collectPhysicalDevices(mx map[string]int64) (map[string]*physicalDevice, error) {
 	bs, err := c.exec.physicalDevicesInfo()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	devices, err := parsePhysDevInfo(bs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(devices) == 0 {
-		return errors.New("no physical devices found")
+		return nil, errors.New("no physical devices found")
 	}
 
 	for _, pd := range devices {
@@ -65,7 +66,7 @@ func (c *Collector) collectPhysicalDevices(mx map[string]int64) error {
 		}
 	}
 
-	return nil
+	return devices, nil
 }
 
 func isOkPDState(pd *physicalDevice) bool {
@@ -120,6 +121,8 @@ func parsePhysDevInfo(bs []byte) (map[string]*physicalDevice, error) {
 			pd.smart = getColonSepValue(line)
 		case strings.HasPrefix(line, "Power State"):
 			pd.powerState = getColonSepValue(line)
+		case strings.HasPrefix(line, "SSD"):
+			pd.ssd = getColonSepValue(line)
 		case strings.HasPrefix(line, "Temperature"):
 			v := getColonSepValue(line) // '42 C/ 107 F' or 'Not Supported'
 			pd.temperature = strings.Fields(v)[0]
@@ -13,6 +13,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
 )
 
 //go:embed "config_schema.json"
@@ -30,26 +31,31 @@ func init() {
 }
 
 func New() *Collector {
+	charts := &module.Charts{}
+
 	return &Collector{
 		Config: Config{
 			Timeout: confopt.Duration(time.Second * 2),
 		},
-		charts: &module.Charts{},
-		lds:    make(map[string]bool),
-		pds:    make(map[string]bool),
+		charts:     charts,
+		raidCharts: hwraid.NewCharts(charts),
+		lds:        make(map[string]bool),
+		pds:        make(map[string]bool),
 	}
 }
 
 type Config struct {
 	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
 	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	RAIDCharts  bool             `yaml:"raid_charts,omitempty" json:"raid_charts"`
 }
 
 type Collector struct {
 	module.Base
 	Config `yaml:",inline" json:""`
 
-	charts *module.Charts
+	charts     *module.Charts
+	raidCharts *hwraid.Charts
 
 	exec arcconfCli
 
@@ -152,13 +152,16 @@ func TestCollector_Check(t *testing.T) {
 
 func TestCollector_Collect(t *testing.T) {
 	tests := map[string]struct {
-		prepareMock func() *mockArcconfExec
-		wantMetrics map[string]int64
-		wantCharts  int
+		prepareMock    func() *mockArcconfExec
+		wantMetrics    map[string]int64
+		wantCharts     int
+		wantRAIDCharts int
 	}{
 		"success case old data": {
 			prepareMock: prepareMockOkOld,
-			wantCharts:  len(ldChartsTmpl)*1 + (len(pdChartsTmpl)-1)*4,
+			// raid: 1 ld + 4 pds * (state, predictive failure status)
+			wantCharts:     len(ldChartsTmpl)*1 + (len(pdChartsTmpl)-1)*4,
+			wantRAIDCharts: 1 + 4*2,
 			wantMetrics: map[string]int64{
 				"ld_0_health_state_critical": 0,
 				"ld_0_health_state_ok":       1,
@@ -174,11 +177,56 @@ func TestCollector_Collect(t *testing.T) {
 				"pd_3_health_state_critical": 0,
 				"pd_3_health_state_ok":       1,
 				"pd_3_smart_warnings":        0,
+
+				"raid_cntrl_1_ld_0_state_degraded":                     0,
+				"raid_cntrl_1_ld_0_state_failed":                       0,
+				"raid_cntrl_1_ld_0_state_offline":                      0,
+				"raid_cntrl_1_ld_0_state_optimal":                      1,
+				"raid_cntrl_1_ld_0_state_rebuilding":                   0,
+				"raid_cntrl_1_ld_0_state_unknown":                      0,
+				"raid_cntrl_1_pd_0_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_0_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_0_state_failed":                       0,
+				"raid_cntrl_1_pd_0_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_0_state_offline":                      0,
+				"raid_cntrl_1_pd_0_state_online":                       1,
+				"raid_cntrl_1_pd_0_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_0_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_0_state_unknown":                      0,
+				"raid_cntrl_1_pd_1_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_1_state_failed":                       0,
+				"raid_cntrl_1_pd_1_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_1_state_offline":                      0,
+				"raid_cntrl_1_pd_1_state_online":                       1,
+				"raid_cntrl_1_pd_1_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_1_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_1_state_unknown":                      0,
+				"raid_cntrl_1_pd_2_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_2_state_failed":                       0,
+				"raid_cntrl_1_pd_2_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_2_state_offline":                      0,
+				"raid_cntrl_1_pd_2_state_online":                       1,
+				"raid_cntrl_1_pd_2_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_2_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_2_state_unknown":                      0,
+				"raid_cntrl_1_pd_3_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_3_state_failed":                       0,
+				"raid_cntrl_1_pd_3_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_3_state_offline":                      0,
+				"raid_cntrl_1_pd_3_state_online":                       1,
+				"raid_cntrl_1_pd_3_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_3_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_3_state_unknown":                      0,
 			},
 		},
 		"success case current data": {
 			prepareMock: prepareMockOkCurrent,
-			wantCharts:  len(ldChartsTmpl)*1 + (len(pdChartsTmpl)-1)*6,
+			// raid: 1 ld + 6 pds * (state, predictive failure status)
+			wantCharts:     len(ldChartsTmpl)*1 + (len(pdChartsTmpl)-1)*6,
+			wantRAIDCharts: 1 + 6*2,
 			wantMetrics: map[string]int64{
 				"ld_0_health_state_critical": 0,
 				"ld_0_health_state_ok":       1,
@@ -200,6 +248,67 @@ func TestCollector_Collect(t *testing.T) {
 				"pd_5_health_state_critical": 0,
 				"pd_5_health_state_ok":       1,
 				"pd_5_smart_warnings":        0,
+
+				"raid_cntrl_1_ld_0_state_degraded":                     0,
+				"raid_cntrl_1_ld_0_state_failed":                       0,
+				"raid_cntrl_1_ld_0_state_offline":                      0,
+				"raid_cntrl_1_ld_0_state_optimal":                      1,
+				"raid_cntrl_1_ld_0_state_rebuilding":                   0,
+				"raid_cntrl_1_ld_0_state_unknown":                      0,
+				"raid_cntrl_1_pd_0_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_0_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_0_state_failed":                       0,
+				"raid_cntrl_1_pd_0_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_0_state_offline":                      0,
+				"raid_cntrl_1_pd_0_state_online":                       1,
+				"raid_cntrl_1_pd_0_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_0_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_0_state_unknown":                      0,
+				"raid_cntrl_1_pd_1_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_1_state_failed":                       0,
+				"raid_cntrl_1_pd_1_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_1_state_offline":                      0,
+				"raid_cntrl_1_pd_1_state_online":                       1,
+				"raid_cntrl_1_pd_1_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_1_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_1_state_unknown":                      0,
+				"raid_cntrl_1_pd_2_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_2_state_failed":                       0,
+				"raid_cntrl_1_pd_2_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_2_state_offline":                      0,
+				"raid_cntrl_1_pd_2_state_online":                       1,
+				"raid_cntrl_1_pd_2_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_2_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_2_state_unknown":                      0,
+				"raid_cntrl_1_pd_3_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_3_state_failed":                       0,
+				"raid_cntrl_1_pd_3_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_3_state_offline":                      0,
+				"raid_cntrl_1_pd_3_state_online":                       1,
+				"raid_cntrl_1_pd_3_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_3_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_3_state_unknown":                      0,
+				"raid_cntrl_1_pd_4_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_4_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_4_state_failed":                       0,
+				"raid_cntrl_1_pd_4_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_4_state_offline":                      0,
+				"raid_cntrl_1_pd_4_state_online":                       1,
+				"raid_cntrl_1_pd_4_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_4_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_4_state_unknown":                      0,
+				"raid_cntrl_1_pd_5_predictive_failure_status_active":   0,
+				"raid_cntrl_1_pd_5_predictive_failure_status_inactive": 1,
+				"raid_cntrl_1_pd_5_state_failed":                       0,
+				"raid_cntrl_1_pd_5_state_hot_spare":                    0,
+				"raid_cntrl_1_pd_5_state_offline":                      0,
+				"raid_cntrl_1_pd_5_state_online":                       1,
+				"raid_cntrl_1_pd_5_state_rebuilding":                   0,
+				"raid_cntrl_1_pd_5_state_unconfigured":                 0,
+				"raid_cntrl_1_pd_5_state_unknown":                      0,
 			},
 		},
 		"err on exec": {
@@ -223,6 +332,14 @@ func TestCollector_Collect(t *testing.T) {
 
 			assert.Equal(t, test.wantMetrics, mx)
 			assert.Len(t, *collr.Charts(), test.wantCharts)
+
+			collr = New()
+			collr.RAIDCharts = true
+			collr.exec = test.prepareMock()
+
+			_ = collr.Collect(context.Background())
+
+			assert.Len(t, *collr.Charts(), test.wantRAIDCharts)
 		})
 	}
 }
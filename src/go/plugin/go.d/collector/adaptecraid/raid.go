@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package adaptecraid
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
+)
+
+// arcconf is always queried for the first controller ('arcconf GETCONFIG 1').
+const raidControllerID = "1"
+
+func raidControllers(lds map[string]*logicalDevice, pds map[string]*physicalDevice) []*hwraid.Controller {
+	rc := &hwraid.Controller{ID: raidControllerID}
+
+	pdLD := make(map[string]string)
+
+	for _, ld := range lds {
+		rc.LogicalDrives = append(rc.LogicalDrives, &hwraid.LogicalDrive{
+			ID:        ld.number,
+			Name:      ld.name,
+			RAIDLevel: ldRAIDLevel(ld.raidLevel),
+			State:     ldRAIDState(ld.status),
+		})
+		for _, loc := range ld.segments {
+			pdLD[loc] = ld.number
+		}
+	}
+
+	for _, pd := range pds {
+		loc := pdLocation(pd.location)
+
+		rpd := &hwraid.PhysicalDrive{
+			ID:           pd.number,
+			LogicalDrive: pdLD[loc],
+			Model:        strings.TrimSpace(pd.vendor + " " + pd.model),
+			State:        pdRAIDState(pd.state),
+		}
+		if v, ok := strings.CutPrefix(loc, "enclosure:"); ok {
+			rpd.Enclosure, rpd.Slot, _ = strings.Cut(v, ",slot:")
+		}
+		switch pd.ssd {
+		case "Yes":
+			rpd.MediaType = "SSD"
+		case "No":
+			rpd.MediaType = "HDD"
+		}
+		if v, err := strconv.ParseInt(pd.smartWarnings, 10, 64); err == nil {
+			rpd.PredictiveFailure = v > 0
+		}
+		if v, err := strconv.ParseInt(pd.temperature, 10, 64); err == nil {
+			rpd.Temperature = &v
+		}
+
+		rc.PhysicalDrives = append(rc.PhysicalDrives, rpd)
+	}
+
+	return []*hwraid.Controller{rc}
+}
+
+// pdLocation returns the normalized location of a physical device (the same format as segmentLocation).
+func pdLocation(location string) string {
+	// 'Connector 0, Device 1', 'Enclosure 0, Slot 1'
+	var parts []string
+	for _, v := range strings.Split(location, ",") {
+		parts = append(parts, strings.ToLower(strings.Join(strings.Fields(v), ":")))
+	}
+	return strings.Join(parts, ",")
+}
+
+func ldRAIDLevel(level string) string {
+	if level == "" {
+		return ""
+	}
+	return "RAID" + level
+}
+
+func ldRAIDState(status string) string {
+	// 'Optimal', 'Degraded', 'Rebuilding', 'Suboptimal, Fault Tolerant', 'Failed', 'Offline'
+	switch s := strings.ToLower(status); {
+	case s == "optimal":
+		return hwraid.LDStateOptimal
+	case strings.Contains(s, "rebuild"):
+		return hwraid.LDStateRebuilding
+	case strings.HasPrefix(s, "degraded"), strings.HasPrefix(s, "suboptimal"), strings.HasPrefix(s, "impacted"):
+		return hwraid.LDStateDegraded
+	case s == "failed":
+		return hwraid.LDStateFailed
+	case s == "offline":
+		return hwraid.LDStateOffline
+	default:
+		return hwraid.LDStateUnknown
+	}
+}
+
+func pdRAIDState(state string) string {
+	switch state {
+	case "Online", "Online (JBOD)", "Raw (Pass Through)":
+		return hwraid.PDStateOnline
+	case "Global Hot-Spare", "Dedicated Hot-Spare", "Pooled Hot-Spare", "Hot Spare":
+		return hwraid.PDStateHotSpare
+	case "Ready":
+		return hwraid.PDStateUnconfigured
+	case "Rebuilding":
+		return hwraid.PDStateRebuilding
+	case "Failed":
+		return hwraid.PDStateFailed
+	case "Offline":
+		return hwraid.PDStateOffline
+	default:
+		return hwraid.PDStateUnknown
+	}
+}
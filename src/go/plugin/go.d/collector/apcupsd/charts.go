@@ -9,11 +9,15 @@ import (
 const (
 	prioUpsStatus = module.Priority + iota
 	prioUpsSelftest
+	prioUpsTimeSinceSelftest
 
 	prioUpsBatteryCharge
 	prioUpsBatteryTimeRemaining
 	prioUpsBatteryTimeSinceReplacement
 	prioUpsBatteryVoltage
+	prioUpsBatteryTransfers
+	prioUpsOnBatteryDuration
+	prioUpsOnBatteryTime
 
 	prioUpsLoadCapacityUtilization
 	prioUpsLoad
@@ -29,11 +33,15 @@ const (
 var charts = module.Charts{
 	statusChart.Copy(),
 	selftestChart.Copy(),
+	timeSinceSelftestChart.Copy(),
 
 	batteryChargeChart.Copy(),
 	batteryTimeRemainingChart.Copy(),
 	batteryTimeSinceReplacementChart.Copy(),
 	batteryVoltageChart.Copy(),
+	batteryTransfersChart.Copy(),
+	onBatteryDurationChart.Copy(),
+	onBatteryTimeChart.Copy(),
 
 	loadCapacityUtilizationChart.Copy(),
 	loadChart.Copy(),
@@ -78,6 +86,18 @@ var (
 		}
 		return chart
 	}()
+	timeSinceSelftestChart = module.Chart{
+		ID:       "ups_time_since_selftest",
+		Title:    "UPS Time Since Last Self-Test",
+		Units:    "seconds",
+		Fam:      "status",
+		Ctx:      "apcupsd.ups_time_since_selftest",
+		Priority: prioUpsTimeSinceSelftest,
+		Type:     module.Line,
+		Dims: module.Dims{
+			{ID: "selftest_seconds_since_last", Name: "since_selftest"},
+		},
+	}
 )
 
 // Battery
@@ -131,6 +151,43 @@ var (
 			{ID: "battery_voltage_nominal", Name: "nominal_voltage", Div: precision},
 		},
 	}
+	batteryTransfersChart = module.Chart{
+		ID:       "ups_battery_transfers",
+		Title:    "UPS Transfers to Battery",
+		Units:    "transfers/s",
+		Fam:      "battery",
+		Ctx:      "apcupsd.ups_battery_transfers",
+		Priority: prioUpsBatteryTransfers,
+		Type:     module.Line,
+		Dims: module.Dims{
+			{ID: "battery_transfers", Name: "transfers", Algo: module.Incremental},
+		},
+	}
+	onBatteryDurationChart = module.Chart{
+		ID:       "ups_on_battery_duration",
+		Title:    "UPS On Battery Event Duration",
+		Units:    "seconds",
+		Fam:      "battery",
+		Ctx:      "apcupsd.ups_on_battery_duration",
+		Priority: prioUpsOnBatteryDuration,
+		Type:     module.Line,
+		Dims: module.Dims{
+			{ID: "on_battery_current_duration", Name: "current"},
+			{ID: "on_battery_last_duration", Name: "last"},
+		},
+	}
+	onBatteryTimeChart = module.Chart{
+		ID:       "ups_on_battery_time",
+		Title:    "UPS Cumulative Time on Battery",
+		Units:    "seconds",
+		Fam:      "battery",
+		Ctx:      "apcupsd.ups_on_battery_time",
+		Priority: prioUpsOnBatteryTime,
+		Type:     module.Line,
+		Dims: module.Dims{
+			{ID: "on_battery_total_time", Name: "on_battery"},
+		},
+	}
 )
 
 // Load
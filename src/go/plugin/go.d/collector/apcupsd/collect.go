@@ -64,6 +64,26 @@ func (c *Collector) collectStatus(mx map[string]int64, resp []byte) error {
 		}
 		mx["selftest_"+st.selftest] = 1
 	}
+	if st.laststest != "" {
+		if v, err := parseDateTime(st.laststest); err != nil {
+			c.Debugf("failed to parse last self-test date '%s': %v", st.laststest, err)
+		} else {
+			mx["selftest_seconds_since_last"] = max(0, int64(time.Since(v).Seconds()))
+		}
+	}
+
+	if st.numxfers != nil {
+		mx["battery_transfers"] = int64(*st.numxfers)
+	}
+	if st.tonbatt != nil {
+		mx["on_battery_current_duration"] = int64(*st.tonbatt)
+	}
+	if st.cumonbatt != nil {
+		mx["on_battery_total_time"] = int64(*st.cumonbatt)
+	}
+	if v, ok := lastOnBatteryDuration(st.xonbatt, st.xoffbatt); ok {
+		mx["on_battery_last_duration"] = v
+	}
 
 	if st.bcharge != nil {
 		mx["battery_charge"] = int64(*st.bcharge * precision)
@@ -134,6 +154,32 @@ func battdateSecondsAgo(battdate string) (int64, error) {
 	return secsAgo, nil
 }
 
+// lastOnBatteryDuration returns the duration of the last completed on-battery event, if any.
+func lastOnBatteryDuration(xonbatt, xoffbatt string) (int64, bool) {
+	if xonbatt == "" || xoffbatt == "" {
+		return 0, false
+	}
+	on, err := parseDateTime(xonbatt)
+	if err != nil {
+		return 0, false
+	}
+	off, err := parseDateTime(xoffbatt)
+	if err != nil || off.Before(on) {
+		// still on battery
+		return 0, false
+	}
+	return int64(off.Sub(on).Seconds()), true
+}
+
+func parseDateTime(s string) (time.Time, error) {
+	// apcupsd >= 3.14 uses '2006-01-02 15:04:05 -0700', older versions use the ctime-like format
+	t, err := time.Parse("2006-01-02 15:04:05 -0700", s)
+	if err == nil {
+		return t, nil
+	}
+	return time.Parse("Mon Jan 02 15:04:05 MST 2006", s)
+}
+
 func (c *Collector) establishConnection() (apcupsdConn, error) {
 	conn := c.newConn(c.Config)
 
@@ -137,6 +137,7 @@ func TestCollector_Collect(t *testing.T) {
 			wantCollected: map[string]int64{
 				"battery_charge":                    10000,
 				"battery_seconds_since_replacement": 86400,
+				"battery_transfers":                 2,
 				"battery_voltage":                   2790,
 				"battery_voltage_nominal":           2400,
 				"input_frequency":                   5000,
@@ -147,6 +148,9 @@ func TestCollector_Collect(t *testing.T) {
 				"load":                              5580,
 				"load_percent":                      930,
 				"output_voltage":                    23660,
+				"on_battery_current_duration":       0,
+				"on_battery_last_duration":          75,
+				"on_battery_total_time":             95,
 				"output_voltage_nominal":            23000,
 				"selftest_BT":                       0,
 				"selftest_IP":                       0,
@@ -155,6 +159,7 @@ func TestCollector_Collect(t *testing.T) {
 				"selftest_OK":                       0,
 				"selftest_UNK":                      0,
 				"selftest_WN":                       0,
+				"selftest_seconds_since_last":       86400,
 				"status_BOOST":                      0,
 				"status_CAL":                        0,
 				"status_COMMLOST":                   0,
@@ -213,8 +218,10 @@ func TestCollector_Collect(t *testing.T) {
 
 			mx := collr.Collect(context.Background())
 
-			if _, ok := mx["battery_seconds_since_replacement"]; ok {
-				mx["battery_seconds_since_replacement"] = 86400
+			for _, k := range []string{"battery_seconds_since_replacement", "selftest_seconds_since_last"} {
+				if _, ok := mx[k]; ok {
+					mx[k] = 86400
+				}
 			}
 
 			assert.Equal(t, test.wantCollected, mx)
@@ -234,6 +241,46 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func Test_lastOnBatteryDuration(t *testing.T) {
+	tests := map[string]struct {
+		xonbatt  string
+		xoffbatt string
+		wantOK   bool
+		want     int64
+	}{
+		"completed event": {
+			xonbatt:  "2024-03-05 10:00:00 +0100",
+			xoffbatt: "2024-03-05 10:02:30 +0100",
+			wantOK:   true,
+			want:     150,
+		},
+		"completed event (old format)": {
+			xonbatt:  "Wed Sep 27 12:01:10 CEST 2000",
+			xoffbatt: "Wed Sep 27 12:02:25 CEST 2000",
+			wantOK:   true,
+			want:     75,
+		},
+		"still on battery": {
+			xonbatt:  "2024-03-05 10:00:00 +0100",
+			xoffbatt: "2024-03-04 09:00:00 +0100",
+		},
+		"no transfers": {},
+		"invalid date": {
+			xonbatt:  "yesterday",
+			xoffbatt: "2024-03-05 10:02:30 +0100",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, ok := lastOnBatteryDuration(test.xonbatt, test.xoffbatt)
+
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.want, v)
+		})
+	}
+}
+
 func prepareMockOk() *mockApcupsdConn {
 	return &mockApcupsdConn{
 		dataStatus: dataStatus,
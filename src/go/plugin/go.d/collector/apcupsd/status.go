@@ -54,6 +54,13 @@ type apcupsdStatus struct {
 	battdate string   // Last battery change date (MM/DD/YY or YYYY-MM-DD)
 	status   string
 	selftest string
+
+	numxfers  *float64 // number of transfers to battery since apcupsd startup
+	tonbatt   *float64 // time on battery of the current event (seconds)
+	cumonbatt *float64 // cumulative time on battery since apcupsd startup (seconds)
+	xonbatt   string   // time and date of the last transfer to battery
+	xoffbatt  string   // time and date of the last transfer from battery
+	laststest string   // time and date of the last self-test
 }
 
 func parseStatus(resp []byte) (*apcupsdStatus, error) {
@@ -111,6 +118,18 @@ func parseStatus(resp []byte) (*apcupsdStatus, error) {
 				value = "SHUTTING_DOWN"
 			}
 			st.status = value
+		case "NUMXFERS":
+			st.numxfers, err = parseFloat(value)
+		case "TONBATT":
+			st.tonbatt, err = parseFloat(value)
+		case "CUMONBATT":
+			st.cumonbatt, err = parseFloat(value)
+		case "XONBATT":
+			st.xonbatt = value
+		case "XOFFBATT":
+			st.xoffbatt = value
+		case "LASTSTEST":
+			st.laststest = value
 		case "SELFTEST":
 			if value == "??" {
 				value = "UNK"
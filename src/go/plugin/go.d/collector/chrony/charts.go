@@ -3,7 +3,10 @@
 package chrony
 
 import (
+	"fmt"
+
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/timesync"
 )
 
 const (
@@ -22,9 +25,22 @@ const (
 	prioActivity
 	prioNTPPackets
 	prioCommandPackets
+
+	prioSourceState
+	prioSourceReachability
+	prioSourceStratum
+	prioSourceOffset
+	prioSourceJitter
+	prioSourceLastSampleAgo
+	prioSourceNTSKeyExchangeAgo
+	prioSourceNTSKeyExchangeAttempts
+	prioSourceNTSNaks
+	prioSourceNTSCookies
 )
 
 var charts = module.Charts{
+	timesync.Chart(),
+
 	stratumChart.Copy(),
 
 	currentCorrectionChart.Copy(),
@@ -253,3 +269,207 @@ func (c *Collector) addServerStatsCharts() {
 		c.Warning(err)
 	}
 }
+
+var sourceChartsTmpl = module.Charts{
+	sourceStateChartTmpl.Copy(),
+	sourceReachabilityChartTmpl.Copy(),
+	sourceStratumChartTmpl.Copy(),
+	sourceOffsetChartTmpl.Copy(),
+	sourceJitterChartTmpl.Copy(),
+	sourceLastSampleAgoChartTmpl.Copy(),
+}
+
+var (
+	sourceStateChartTmpl = module.Chart{
+		ID:       "source_%s_state",
+		Title:    "Source selection state",
+		Units:    "state",
+		Fam:      "sources",
+		Ctx:      "chrony.source_state",
+		Type:     module.Line,
+		Priority: prioSourceState,
+		Dims: module.Dims{
+			{ID: "source_%s_state_sync", Name: "sync"},
+			{ID: "source_%s_state_candidate", Name: "candidate"},
+			{ID: "source_%s_state_outlier", Name: "outlier"},
+			{ID: "source_%s_state_unreachable", Name: "unreachable"},
+			{ID: "source_%s_state_falseticker", Name: "falseticker"},
+			{ID: "source_%s_state_jittery", Name: "jittery"},
+		},
+	}
+	sourceReachabilityChartTmpl = module.Chart{
+		ID:       "source_%s_reachability",
+		Title:    "Source reachability (last 8 polls)",
+		Units:    "percentage",
+		Fam:      "sources",
+		Ctx:      "chrony.source_reachability",
+		Type:     module.Line,
+		Priority: prioSourceReachability,
+		Dims: module.Dims{
+			{ID: "source_%s_reachability", Name: "reachability"},
+		},
+	}
+	sourceStratumChartTmpl = module.Chart{
+		ID:       "source_%s_stratum",
+		Title:    "Source stratum",
+		Units:    "level",
+		Fam:      "sources",
+		Ctx:      "chrony.source_stratum",
+		Type:     module.Line,
+		Priority: prioSourceStratum,
+		Dims: module.Dims{
+			{ID: "source_%s_stratum", Name: "stratum"},
+		},
+	}
+	sourceOffsetChartTmpl = module.Chart{
+		ID:       "source_%s_offset",
+		Title:    "Source offset",
+		Units:    "seconds",
+		Fam:      "sources",
+		Ctx:      "chrony.source_offset",
+		Type:     module.Line,
+		Priority: prioSourceOffset,
+		Dims: module.Dims{
+			{ID: "source_%s_offset_last_sample", Name: "last_sample", Div: scaleFactor},
+			{ID: "source_%s_offset_estimated", Name: "estimated", Div: scaleFactor},
+		},
+	}
+	sourceJitterChartTmpl = module.Chart{
+		ID:       "source_%s_jitter",
+		Title:    "Source jitter (standard deviation of samples)",
+		Units:    "seconds",
+		Fam:      "sources",
+		Ctx:      "chrony.source_jitter",
+		Type:     module.Line,
+		Priority: prioSourceJitter,
+		Dims: module.Dims{
+			{ID: "source_%s_jitter", Name: "jitter", Div: scaleFactor},
+		},
+	}
+	sourceLastSampleAgoChartTmpl = module.Chart{
+		ID:       "source_%s_last_sample_ago",
+		Title:    "Source time since the last sample",
+		Units:    "seconds",
+		Fam:      "sources",
+		Ctx:      "chrony.source_last_sample_ago",
+		Type:     module.Line,
+		Priority: prioSourceLastSampleAgo,
+		Dims: module.Dims{
+			{ID: "source_%s_last_sample_ago", Name: "ago"},
+		},
+	}
+)
+
+var sourceNTSChartsTmpl = module.Charts{
+	sourceNTSKeyExchangeAgoChartTmpl.Copy(),
+	sourceNTSKeyExchangeAttemptsChartTmpl.Copy(),
+	sourceNTSNaksChartTmpl.Copy(),
+	sourceNTSCookiesChartTmpl.Copy(),
+}
+
+var (
+	sourceNTSKeyExchangeAgoChartTmpl = module.Chart{
+		ID:       "source_%s_nts_key_exchange_ago",
+		Title:    "Source time since the last NTS key exchange",
+		Units:    "seconds",
+		Fam:      "nts",
+		Ctx:      "chrony.source_nts_key_exchange_ago",
+		Type:     module.Line,
+		Priority: prioSourceNTSKeyExchangeAgo,
+		Dims: module.Dims{
+			{ID: "source_%s_nts_key_exchange_ago", Name: "ago"},
+		},
+	}
+	sourceNTSKeyExchangeAttemptsChartTmpl = module.Chart{
+		ID:       "source_%s_nts_key_exchange_attempts",
+		Title:    "Source failed NTS key exchange attempts since the last successful one",
+		Units:    "attempts",
+		Fam:      "nts",
+		Ctx:      "chrony.source_nts_key_exchange_attempts",
+		Type:     module.Line,
+		Priority: prioSourceNTSKeyExchangeAttempts,
+		Dims: module.Dims{
+			{ID: "source_%s_nts_key_exchange_attempts", Name: "attempts"},
+		},
+	}
+	sourceNTSNaksChartTmpl = module.Chart{
+		ID:       "source_%s_nts_naks",
+		Title:    "Source NTS NAKs received since the last key exchange",
+		Units:    "naks",
+		Fam:      "nts",
+		Ctx:      "chrony.source_nts_naks",
+		Type:     module.Line,
+		Priority: prioSourceNTSNaks,
+		Dims: module.Dims{
+			{ID: "source_%s_nts_naks", Name: "naks"},
+		},
+	}
+	sourceNTSCookiesChartTmpl = module.Chart{
+		ID:       "source_%s_nts_cookies",
+		Title:    "Source NTS cookies",
+		Units:    "cookies",
+		Fam:      "nts",
+		Ctx:      "chrony.source_nts_cookies",
+		Type:     module.Line,
+		Priority: prioSourceNTSCookies,
+		Dims: module.Dims{
+			{ID: "source_%s_nts_cookies", Name: "cookies"},
+		},
+	}
+)
+
+func (c *Collector) addSourceCharts(id, name, mode string) {
+	charts := sourceChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		chart.Labels = []module.Label{
+			{Key: "source", Value: name},
+			{Key: "mode", Value: mode},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+		return
+	}
+	for _, chart := range *charts {
+		c.sourceCharts[id] = append(c.sourceCharts[id], chart.ID)
+	}
+}
+
+func (c *Collector) addSourceNTSCharts(id, name string) {
+	charts := sourceNTSChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		chart.Labels = []module.Label{
+			{Key: "source", Value: name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+		return
+	}
+	for _, chart := range *charts {
+		c.sourceCharts[id] = append(c.sourceCharts[id], chart.ID)
+	}
+}
+
+func (c *Collector) removeSourceCharts(id string) {
+	// removed by the exact IDs, source IDs are not prefix-free (e.g. 'fe80__1' and 'fe80__1_2')
+	for _, chartID := range c.sourceCharts[id] {
+		if chart := c.Charts().Get(chartID); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+	delete(c.sourceCharts, id)
+}
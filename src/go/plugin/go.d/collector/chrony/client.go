@@ -13,6 +13,9 @@ import (
 type chronyConn interface {
 	tracking() (*chrony.ReplyTracking, error)
 	activity() (*chrony.ReplyActivity, error)
+	sources() (int, error)
+	sourceData(index int) (*chrony.ReplySourceData, error)
+	sourceStats(index int) (*chrony.ReplySourceStats, error)
 	close()
 }
 
@@ -72,6 +75,54 @@ func (c *chronyClient) activity() (*chrony.ReplyActivity, error) {
 	return activity, nil
 }
 
+func (c *chronyClient) sources() (int, error) {
+	req := chrony.NewSourcesPacket()
+
+	reply, err := c.client.Communicate(req)
+	if err != nil {
+		return 0, err
+	}
+
+	sources, ok := reply.(*chrony.ReplySources)
+	if !ok {
+		return 0, fmt.Errorf("unexpected reply type, want=%T, got=%T", &chrony.ReplySources{}, reply)
+	}
+
+	return sources.NSources, nil
+}
+
+func (c *chronyClient) sourceData(index int) (*chrony.ReplySourceData, error) {
+	req := chrony.NewSourceDataPacket(int32(index))
+
+	reply, err := c.client.Communicate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceData, ok := reply.(*chrony.ReplySourceData)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply type, want=%T, got=%T", &chrony.ReplySourceData{}, reply)
+	}
+
+	return sourceData, nil
+}
+
+func (c *chronyClient) sourceStats(index int) (*chrony.ReplySourceStats, error) {
+	req := chrony.NewSourceStatsPacket(int32(index))
+
+	reply, err := c.client.Communicate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceStats, ok := reply.(*chrony.ReplySourceStats)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply type, want=%T, got=%T", &chrony.ReplySourceStats{}, reply)
+	}
+
+	return sourceStats, nil
+}
+
 func (c *chronyClient) close() {
 	if c.conn != nil {
 		_ = c.conn.Close()
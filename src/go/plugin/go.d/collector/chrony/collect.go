@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/timesync"
 )
 
 const scaleFactor = 1000000000
@@ -24,6 +25,11 @@ const (
 	leapStatusUnsynchronised = 3
 )
 
+const (
+	authDataRetryMinDelay = time.Minute
+	authDataRetryMaxDelay = time.Hour
+)
+
 func (c *Collector) collect() (map[string]int64, error) {
 	if c.conn == nil {
 		client, err := c.newConn(c.Config)
@@ -41,6 +47,11 @@ func (c *Collector) collect() (map[string]int64, error) {
 	if err := c.collectActivity(mx); err != nil {
 		return mx, err
 	}
+	if c.CollectSources {
+		if err := c.collectSources(mx); err != nil {
+			c.Warning(err)
+		}
+	}
 	if c.exec != nil {
 		if err := c.collectServerStats(mx); err != nil {
 			c.Warning(err)
@@ -49,6 +60,15 @@ func (c *Collector) collect() (map[string]int64, error) {
 			c.addServerStatsChartsOnce.Do(c.addServerStatsCharts)
 		}
 	}
+	if c.exec != nil && c.CollectSources && !time.Now().Before(c.authDataRetryTime) {
+		if err := c.collectAuthData(mx); err != nil {
+			c.authDataRetryDelay = min(max(c.authDataRetryDelay*2, authDataRetryMinDelay), authDataRetryMaxDelay)
+			c.authDataRetryTime = time.Now().Add(c.authDataRetryDelay)
+			c.Warningf("%v (NTS metrics collection is retried in %s)", err, c.authDataRetryDelay)
+		} else {
+			c.authDataRetryDelay = 0
+		}
+	}
 
 	return mx, nil
 }
@@ -64,6 +84,7 @@ func (c *Collector) collectTracking(mx map[string]int64) error {
 	mx["leap_status_insert_second"] = metrix.Bool(reply.LeapStatus == leapStatusInsertSecond)
 	mx["leap_status_delete_second"] = metrix.Bool(reply.LeapStatus == leapStatusDeleteSecond)
 	mx["leap_status_unsynchronised"] = metrix.Bool(reply.LeapStatus == leapStatusUnsynchronised)
+	timesync.WriteMetrics(mx, timesync.State(int(reply.LeapStatus), reply.LeapStatus != leapStatusUnsynchronised))
 	mx["root_delay"] = int64(reply.RootDelay * scaleFactor)
 	mx["root_dispersion"] = int64(reply.RootDispersion * scaleFactor)
 	mx["skew"] = int64(reply.SkewPPM * scaleFactor)
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package chrony
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+
+	"github.com/facebook/time/ntp/chrony"
+)
+
+const (
+	sourceStateSync        = "sync"
+	sourceStateCandidate   = "candidate"
+	sourceStateOutlier     = "outlier"
+	sourceStateUnreachable = "unreachable"
+	sourceStateFalseTicker = "falseticker"
+	sourceStateJittery     = "jittery"
+)
+
+var sourceStates = []string{
+	sourceStateSync,
+	sourceStateCandidate,
+	sourceStateOutlier,
+	sourceStateUnreachable,
+	sourceStateFalseTicker,
+	sourceStateJittery,
+}
+
+func (c *Collector) collectSources(mx map[string]int64) error {
+	num, err := c.conn.sources()
+	if err != nil {
+		return fmt.Errorf("error on collecting sources: %v", err)
+	}
+
+	seen := make(map[string]bool)
+
+	for i := 0; i < num; i++ {
+		sd, err := c.conn.sourceData(i)
+		if err != nil {
+			c.Debugf("error on collecting source data (index %d): %v", i, err)
+			continue
+		}
+
+		name := sourceName(&sd.SourceData)
+		if name == "" {
+			// unresolved source
+			continue
+		}
+
+		id := sourceID(name)
+		seen[id] = true
+
+		if !c.sources[id] {
+			c.sources[id] = true
+			c.addSourceCharts(id, name, sourceMode(sd.Mode))
+		}
+
+		px := "source_" + id + "_"
+
+		state := sourceState(sd.State)
+		for _, st := range sourceStates {
+			mx[px+"state_"+st] = metrix.Bool(st == state)
+		}
+		// the reachability register is an 8-bit shift register of the last polls
+		mx[px+"reachability"] = int64(bits.OnesCount16(sd.Reachability&0xff)) * 100 / 8
+		mx[px+"stratum"] = int64(sd.Stratum)
+		mx[px+"last_sample_ago"] = int64(sd.SinceSample)
+		mx[px+"offset_last_sample"] = int64(sd.LatestMeas * scaleFactor)
+
+		ss, err := c.conn.sourceStats(i)
+		if err != nil {
+			c.Debugf("error on collecting source stats (index %d): %v", i, err)
+			continue
+		}
+
+		mx[px+"offset_estimated"] = int64(ss.EstimatedOffset * scaleFactor)
+		mx[px+"jitter"] = int64(ss.StandardDeviation * scaleFactor)
+	}
+
+	for id := range c.sources {
+		if !seen[id] {
+			delete(c.sources, id)
+			delete(c.ntsSources, id)
+			c.removeSourceCharts(id)
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectAuthData(mx map[string]int64) error {
+	bs, err := c.exec.authData()
+	if err != nil {
+		return fmt.Errorf("error on collecting auth data: %v", err)
+	}
+
+	// CSV output of 'chronyc -n -c authdata':
+	// Name/IP address,Mode,KeyID,Type,KLen,Last,Atmp,NAK,Cook,CLen
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		parts := strings.Split(strings.TrimSpace(sc.Text()), ",")
+		if len(parts) < 10 || parts[1] != "NTS" {
+			continue
+		}
+
+		id := sourceID(parts[0])
+		if !c.sources[id] {
+			continue
+		}
+
+		if !c.ntsSources[id] {
+			c.ntsSources[id] = true
+			c.addSourceNTSCharts(id, parts[0])
+		}
+
+		px := "source_" + id + "_nts_"
+
+		for _, v := range []struct {
+			key   string
+			value string
+		}{
+			{key: "key_exchange_ago", value: parts[5]},
+			{key: "key_exchange_attempts", value: parts[6]},
+			{key: "naks", value: parts[7]},
+			{key: "cookies", value: parts[8]},
+		} {
+			if n, err := strconv.ParseInt(v.value, 10, 64); err == nil {
+				mx[px+v.key] = n
+			}
+		}
+	}
+
+	return nil
+}
+
+// sourceName returns the source IP address, or the reference ID for reference clocks.
+func sourceName(sd *chrony.SourceData) string {
+	if sd.Mode == chrony.SourceModeRef {
+		// chrony reports the refid of a reference clock ('GPS', 'PPS', 'PHC0') in place of the IPv4 address
+		if ip := sd.IPAddr.To4(); ip != nil {
+			return strings.TrimRight(string(ip), "\x00")
+		}
+		return ""
+	}
+	if sd.IPAddr == nil || sd.IPAddr.IsUnspecified() {
+		return ""
+	}
+	return sd.IPAddr.String()
+}
+
+func sourceID(name string) string {
+	return strings.NewReplacer(".", "_", ":", "_").Replace(name)
+}
+
+func sourceMode(mode chrony.ModeType) string {
+	switch mode {
+	case chrony.SourceModeClient:
+		return "server"
+	case chrony.SourceModePeer:
+		return "peer"
+	case chrony.SourceModeRef:
+		return "refclock"
+	default:
+		return "unknown"
+	}
+}
+
+func sourceState(state chrony.SourceStateType) string {
+	// 'chronyc sources' state column: '*' sync, '+' candidate, '-' outlier, '?' unreachable, 'x' falseticker, '~' jittery
+	switch state {
+	case chrony.SourceStateSync:
+		return sourceStateSync
+	case chrony.SourceStateCandidate:
+		return sourceStateCandidate
+	case chrony.SourceStateOutlier:
+		return sourceStateOutlier
+	case chrony.SourceStateUnreach:
+		return sourceStateUnreachable
+	case chrony.SourceStateFalseTicker:
+		return sourceStateFalseTicker
+	case chrony.SourceStateJittery:
+		return sourceStateJittery
+	default:
+		return ""
+	}
+}
@@ -28,20 +28,25 @@ func init() {
 func New() *Collector {
 	return &Collector{
 		Config: Config{
-			Address: "127.0.0.1:323",
-			Timeout: confopt.Duration(time.Second),
+			Address:        "127.0.0.1:323",
+			Timeout:        confopt.Duration(time.Second),
+			CollectSources: true,
 		},
 		charts:                   charts.Copy(),
 		addServerStatsChartsOnce: &sync.Once{},
 		newConn:                  newChronyConn,
+		sources:                  make(map[string]bool),
+		ntsSources:               make(map[string]bool),
+		sourceCharts:             make(map[string][]string),
 	}
 }
 
 type Config struct {
-	Vnode       string           `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
-	Address     string           `yaml:"address" json:"address"`
-	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	Vnode          string           `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery    int              `yaml:"update_every,omitempty" json:"update_every"`
+	Address        string           `yaml:"address" json:"address"`
+	Timeout        confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	CollectSources bool             `yaml:"collect_sources" json:"collect_sources"`
 }
 
 type Collector struct {
@@ -52,9 +57,17 @@ type Collector struct {
 	addServerStatsChartsOnce *sync.Once
 
 	exec chronyBinary
+	// 'chronyc authdata' may fail (e.g. no permission to access the command socket),
+	// failed queries are retried with a backoff
+	authDataRetryTime  time.Time
+	authDataRetryDelay time.Duration
 
 	conn    chronyConn
 	newConn func(c Config) (chronyConn, error)
+
+	sources      map[string]bool
+	ntsSources   map[string]bool
+	sourceCharts map[string][]string
 }
 
 func (c *Collector) Configuration() any {
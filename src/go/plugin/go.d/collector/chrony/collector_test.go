@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -153,37 +154,83 @@ func TestCollector_Cleanup(t *testing.T) {
 
 func TestCollector_Collect(t *testing.T) {
 	tests := map[string]struct {
-		prepare  func() *Collector
-		expected map[string]int64
+		prepare    func() *Collector
+		expected   map[string]int64
+		wantCharts int
 	}{
 		"tracking: success, activity: success, serverstats: success": {
 			prepare: func() *Collector { return prepareChronyWithMock(&mockClient{}) },
 			expected: map[string]int64{
-				"burst_offline_sources":      3,
-				"burst_online_sources":       4,
-				"command_packets_dropped":    1,
-				"command_packets_received":   652,
-				"current_correction":         154872,
-				"frequency":                  51051185607,
-				"last_offset":                3095,
-				"leap_status_delete_second":  0,
-				"leap_status_insert_second":  1,
-				"leap_status_normal":         0,
-				"leap_status_unsynchronised": 0,
-				"ntp_packets_dropped":        1,
-				"ntp_packets_received":       1,
-				"offline_sources":            2,
-				"online_sources":             8,
-				"ref_measurement_time":       63793323616,
-				"residual_frequency":         -571789,
-				"rms_offset":                 130089,
-				"root_delay":                 59576179,
-				"root_dispersion":            1089275,
-				"skew":                       41821926,
-				"stratum":                    4,
-				"unresolved_sources":         1,
-				"update_interval":            1044219238281,
+				"burst_offline_sources":                      3,
+				"burst_online_sources":                       4,
+				"command_packets_dropped":                    1,
+				"command_packets_received":                   652,
+				"current_correction":                         154872,
+				"frequency":                                  51051185607,
+				"last_offset":                                3095,
+				"leap_status_delete_second":                  0,
+				"leap_status_insert_second":                  1,
+				"leap_status_normal":                         0,
+				"leap_status_unsynchronised":                 0,
+				"ntp_packets_dropped":                        1,
+				"ntp_packets_received":                       1,
+				"offline_sources":                            2,
+				"online_sources":                             8,
+				"ref_measurement_time":                       63793323616,
+				"residual_frequency":                         -571789,
+				"rms_offset":                                 130089,
+				"root_delay":                                 59576179,
+				"root_dispersion":                            1089275,
+				"skew":                                       41821926,
+				"source_192_0_2_1_jitter":                    12000,
+				"source_192_0_2_1_last_sample_ago":           33,
+				"source_192_0_2_1_nts_cookies":               8,
+				"source_192_0_2_1_nts_key_exchange_ago":      1980,
+				"source_192_0_2_1_nts_key_exchange_attempts": 0,
+				"source_192_0_2_1_nts_naks":                  0,
+				"source_192_0_2_1_offset_estimated":          -3500,
+				"source_192_0_2_1_offset_last_sample":        -4000,
+				"source_192_0_2_1_reachability":              100,
+				"source_192_0_2_1_state_candidate":           0,
+				"source_192_0_2_1_state_falseticker":         0,
+				"source_192_0_2_1_state_jittery":             0,
+				"source_192_0_2_1_state_outlier":             0,
+				"source_192_0_2_1_state_sync":                1,
+				"source_192_0_2_1_state_unreachable":         0,
+				"source_192_0_2_1_stratum":                   1,
+				"source_2001_db8__1_jitter":                  12000,
+				"source_2001_db8__1_last_sample_ago":         21,
+				"source_2001_db8__1_offset_estimated":        -3500,
+				"source_2001_db8__1_offset_last_sample":      121000,
+				"source_2001_db8__1_reachability":            100,
+				"source_2001_db8__1_state_candidate":         1,
+				"source_2001_db8__1_state_falseticker":       0,
+				"source_2001_db8__1_state_jittery":           0,
+				"source_2001_db8__1_state_outlier":           0,
+				"source_2001_db8__1_state_sync":              0,
+				"source_2001_db8__1_state_unreachable":       0,
+				"source_2001_db8__1_stratum":                 2,
+				"source_GPS_jitter":                          12000,
+				"source_GPS_last_sample_ago":                 130,
+				"source_GPS_offset_estimated":                -3500,
+				"source_GPS_offset_last_sample":              12000000,
+				"source_GPS_reachability":                    50,
+				"source_GPS_state_candidate":                 0,
+				"source_GPS_state_falseticker":               0,
+				"source_GPS_state_jittery":                   0,
+				"source_GPS_state_outlier":                   0,
+				"source_GPS_state_sync":                      0,
+				"source_GPS_state_unreachable":               1,
+				"source_GPS_stratum":                         0,
+				"stratum":                                    4,
+				"timesync_health_state_leap_delete_pending":  0,
+				"timesync_health_state_leap_insert_pending":  1,
+				"timesync_health_state_synchronized":         0,
+				"timesync_health_state_unsynchronized":       0,
+				"unresolved_sources":                         1,
+				"update_interval":                            1044219238281,
 			},
+			wantCharts: len(charts) + len(serverStatsCharts) + len(sourceChartsTmpl)*3 + len(sourceNTSChartsTmpl),
 		},
 		"tracking: success, activity: fail": {
 			prepare: func() *Collector { return prepareChronyWithMock(&mockClient{errOnActivity: true}) },
@@ -202,20 +249,100 @@ func TestCollector_Collect(t *testing.T) {
 				"root_dispersion":            1089275,
 				"skew":                       41821926,
 				"stratum":                    4,
-				"update_interval":            1044219238281,
+				"timesync_health_state_leap_delete_pending": 0,
+				"timesync_health_state_leap_insert_pending": 1,
+				"timesync_health_state_synchronized":        0,
+				"timesync_health_state_unsynchronized":      0,
+				"update_interval":                           1044219238281,
+			},
+			wantCharts: len(charts),
+		},
+		"sources: fail": {
+			prepare: func() *Collector { return prepareChronyWithMock(&mockClient{errOnSources: true}) },
+			expected: map[string]int64{
+				"burst_offline_sources":                     3,
+				"burst_online_sources":                      4,
+				"command_packets_dropped":                   1,
+				"command_packets_received":                  652,
+				"current_correction":                        154872,
+				"frequency":                                 51051185607,
+				"last_offset":                               3095,
+				"leap_status_delete_second":                 0,
+				"leap_status_insert_second":                 1,
+				"leap_status_normal":                        0,
+				"leap_status_unsynchronised":                0,
+				"ntp_packets_dropped":                       1,
+				"ntp_packets_received":                      1,
+				"offline_sources":                           2,
+				"online_sources":                            8,
+				"ref_measurement_time":                      63928027953,
+				"residual_frequency":                        -571789,
+				"rms_offset":                                130089,
+				"root_delay":                                59576179,
+				"root_dispersion":                           1089275,
+				"skew":                                      41821926,
+				"stratum":                                   4,
+				"timesync_health_state_leap_delete_pending": 0,
+				"timesync_health_state_leap_insert_pending": 1,
+				"timesync_health_state_synchronized":        0,
+				"timesync_health_state_unsynchronized":      0,
+				"unresolved_sources":                        1,
+				"update_interval":                           1044219238281,
+			},
+			wantCharts: len(charts) + len(serverStatsCharts),
+		},
+		"sources: disabled": {
+			prepare: func() *Collector {
+				collr := prepareChronyWithMock(&mockClient{})
+				collr.CollectSources = false
+				return collr
+			},
+			expected: map[string]int64{
+				"burst_offline_sources":                     3,
+				"burst_online_sources":                      4,
+				"command_packets_dropped":                   1,
+				"command_packets_received":                  652,
+				"current_correction":                        154872,
+				"frequency":                                 51051185607,
+				"last_offset":                               3095,
+				"leap_status_delete_second":                 0,
+				"leap_status_insert_second":                 1,
+				"leap_status_normal":                        0,
+				"leap_status_unsynchronised":                0,
+				"ntp_packets_dropped":                       1,
+				"ntp_packets_received":                      1,
+				"offline_sources":                           2,
+				"online_sources":                            8,
+				"ref_measurement_time":                      63928027953,
+				"residual_frequency":                        -571789,
+				"rms_offset":                                130089,
+				"root_delay":                                59576179,
+				"root_dispersion":                           1089275,
+				"skew":                                      41821926,
+				"stratum":                                   4,
+				"timesync_health_state_leap_delete_pending": 0,
+				"timesync_health_state_leap_insert_pending": 1,
+				"timesync_health_state_synchronized":        0,
+				"timesync_health_state_unsynchronized":      0,
+				"unresolved_sources":                        1,
+				"update_interval":                           1044219238281,
 			},
+			wantCharts: len(charts) + len(serverStatsCharts),
 		},
 		"tracking: fail, activity: success": {
-			prepare:  func() *Collector { return prepareChronyWithMock(&mockClient{errOnTracking: true}) },
-			expected: nil,
+			prepare:    func() *Collector { return prepareChronyWithMock(&mockClient{errOnTracking: true}) },
+			expected:   nil,
+			wantCharts: len(charts),
 		},
 		"tracking: fail, activity: fail": {
-			prepare:  func() *Collector { return prepareChronyWithMock(&mockClient{errOnTracking: true}) },
-			expected: nil,
+			prepare:    func() *Collector { return prepareChronyWithMock(&mockClient{errOnTracking: true}) },
+			expected:   nil,
+			wantCharts: len(charts),
 		},
 		"fail on creating client": {
-			prepare:  func() *Collector { return prepareChronyWithMock(nil) },
-			expected: nil,
+			prepare:    func() *Collector { return prepareChronyWithMock(nil) },
+			expected:   nil,
+			wantCharts: len(charts),
 		},
 	}
 
@@ -231,10 +358,91 @@ func TestCollector_Collect(t *testing.T) {
 			copyRefMeasurementTime(mx, test.expected)
 
 			assert.Equal(t, test.expected, mx)
+			assert.Equal(t, test.wantCharts, len(*collr.Charts()))
 		})
 	}
 }
 
+func TestCollector_Collect_SourceRemoved(t *testing.T) {
+	collr := prepareChronyWithMock(&mockClient{})
+	require.NoError(t, collr.Init(context.Background()))
+	collr.exec = &mockChronyc{}
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+	require.NotNil(t, collr.Charts().Get("source_192_0_2_1_nts_cookies"))
+
+	chart := collr.Charts().Get("source_GPS_state")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "source", Value: "GPS"})
+	assert.Contains(t, chart.Labels, module.Label{Key: "mode", Value: "refclock"})
+
+	sources := mockSources
+	defer func() { mockSources = sources }()
+	mockSources = mockSources[2:]
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.NotContains(t, mx, "source_192_0_2_1_state_sync")
+	for _, chart := range *collr.Charts() {
+		removed := strings.HasPrefix(chart.ID, "source_GPS_") || strings.HasPrefix(chart.ID, "source_192_0_2_1_")
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", chart.ID)
+	}
+}
+
+func TestCollector_Collect_SourceRemovedByExactID(t *testing.T) {
+	sources := mockSources
+	defer func() { mockSources = sources }()
+	mockSources = []chrony.SourceData{
+		{IPAddr: net.ParseIP("fe80::1"), Mode: chrony.SourceModeClient, State: chrony.SourceStateSync},
+		{IPAddr: net.ParseIP("fe80::1:2"), Mode: chrony.SourceModeClient, State: chrony.SourceStateCandidate},
+	}
+
+	collr := prepareChronyWithMock(&mockClient{})
+	require.NoError(t, collr.Init(context.Background()))
+
+	require.NotNil(t, collr.Collect(context.Background()))
+	require.True(t, collr.Charts().Has("source_fe80__1_state"))
+	require.True(t, collr.Charts().Has("source_fe80__1_2_state"))
+
+	mockSources = mockSources[1:]
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	for _, chart := range *collr.Charts() {
+		removed := strings.HasPrefix(chart.ID, "source_fe80__1_") && !strings.HasPrefix(chart.ID, "source_fe80__1_2_")
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", chart.ID)
+	}
+}
+
+func TestCollector_Collect_AuthDataRetry(t *testing.T) {
+	collr := prepareChronyWithMock(&mockClient{})
+	require.NoError(t, collr.Init(context.Background()))
+	chronyc := &mockChronyc{errOnAuthData: true}
+	collr.exec = chronyc
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.NotContains(t, mx, "source_192_0_2_1_nts_cookies")
+
+	// the query is not repeated until the retry delay passes
+	_ = collr.Collect(context.Background())
+	assert.Equal(t, 1, chronyc.authDataCalls)
+	assert.Equal(t, authDataRetryMinDelay, collr.authDataRetryDelay)
+
+	chronyc.errOnAuthData = false
+	collr.authDataRetryTime = time.Time{}
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, 2, chronyc.authDataCalls)
+	assert.Equal(t, int64(8), mx["source_192_0_2_1_nts_cookies"])
+	assert.Zero(t, collr.authDataRetryDelay)
+}
+
 func prepareChronyWithMock(m *mockClient) *Collector {
 	c := New()
 	if m == nil {
@@ -245,7 +453,22 @@ func prepareChronyWithMock(m *mockClient) *Collector {
 	return c
 }
 
-type mockChronyc struct{}
+type mockChronyc struct {
+	errOnAuthData bool
+	authDataCalls int
+}
+
+func (m *mockChronyc) authData() ([]byte, error) {
+	m.authDataCalls++
+	if m.errOnAuthData {
+		return nil, errors.New("mockChronyc.authData call error")
+	}
+	data := `
+192.0.2.1,NTS,1,15,256,1980,0,0,8,100
+192.0.2.2,-,0,0,0,-,0,0,0,0
+`
+	return []byte(data), nil
+}
 
 func (m *mockChronyc) serverStats() ([]byte, error) {
 	data := `
@@ -268,6 +491,7 @@ type mockClient struct {
 	errOnTracking    bool
 	errOnActivity    bool
 	errOnServerStats bool
+	errOnSources     bool
 	closeCalled      bool
 }
 
@@ -312,6 +536,71 @@ func (m *mockClient) activity() (*chrony.ReplyActivity, error) {
 	return &reply, nil
 }
 
+func (m *mockClient) sources() (int, error) {
+	if m.errOnSources {
+		return 0, errors.New("mockClient.Sources call error")
+	}
+	return len(mockSources), nil
+}
+
+func (m *mockClient) sourceData(index int) (*chrony.ReplySourceData, error) {
+	if index >= len(mockSources) {
+		return nil, errors.New("mockClient.SourceData: invalid index")
+	}
+	return &chrony.ReplySourceData{SourceData: mockSources[index]}, nil
+}
+
+func (m *mockClient) sourceStats(index int) (*chrony.ReplySourceStats, error) {
+	if index >= len(mockSources) {
+		return nil, errors.New("mockClient.SourceStats: invalid index")
+	}
+	reply := chrony.ReplySourceStats{
+		SourceStats: chrony.SourceStats{
+			IPAddr:             mockSources[index].IPAddr,
+			NSamples:           12,
+			StandardDeviation:  0.000012,
+			EstimatedOffset:    -0.0000035,
+			EstimatedOffsetErr: 0.000008,
+		},
+	}
+	return &reply, nil
+}
+
+var mockSources = []chrony.SourceData{
+	{
+		// GPS reference clock, the refid is reported in place of the IPv4 address
+		IPAddr:       net.IPv4('G', 'P', 'S', 0),
+		Mode:         chrony.SourceModeRef,
+		State:        chrony.SourceStateUnreach,
+		Reachability: 0o360,
+		SinceSample:  130,
+		LatestMeas:   0.012,
+	},
+	{
+		IPAddr:       net.ParseIP("192.0.2.1"),
+		Mode:         chrony.SourceModeClient,
+		State:        chrony.SourceStateSync,
+		Stratum:      1,
+		Reachability: 0o377,
+		SinceSample:  33,
+		LatestMeas:   -0.000004,
+	},
+	{
+		IPAddr:       net.ParseIP("2001:db8::1"),
+		Mode:         chrony.SourceModeClient,
+		State:        chrony.SourceStateCandidate,
+		Stratum:      2,
+		Reachability: 0o377,
+		SinceSample:  21,
+		LatestMeas:   0.000121,
+	},
+	{
+		// unresolved source
+		IPAddr: net.IPv4zero,
+		Mode:   chrony.SourceModeClient,
+	},
+}
+
 func (m *mockClient) close() {
 	m.closeCalled = true
 }
@@ -13,6 +13,7 @@ import (
 
 type chronyBinary interface {
 	serverStats() ([]byte, error)
+	authData() ([]byte, error)
 }
 
 func newChronycExec(ndsudoPath string, timeout time.Duration, log *logger.Logger) *chronycExec {
@@ -44,3 +45,18 @@ func (e *chronycExec) serverStats() ([]byte, error) {
 
 	return bs, nil
 }
+
+func (e *chronycExec) authData() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, e.ndsudoPath, "chronyc-authdata")
+	e.Debugf("executing '%s'", cmd)
+
+	bs, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error on '%s': %v", cmd, err)
+	}
+
+	return bs, nil
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	assertionPassed        = "passed"
+	assertionValueMismatch = "value_mismatch"
+	assertionTTLTooLow     = "ttl_too_low"
+)
+
+type (
+	assertionKey struct {
+		domain     string
+		recordType string
+	}
+	assertion struct {
+		values map[string]bool
+		minTTL uint32
+	}
+)
+
+// check verifies the answer records of the queried type against the expected values and minimum TTL.
+// An answer without records of the queried type never matches the expected values.
+func (a *assertion) check(resp *dns.Msg, rtype uint16) string {
+	var found bool
+
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != rtype {
+			continue
+		}
+		found = true
+
+		if a.values != nil && !a.values[recordValue(rr)] {
+			return assertionValueMismatch
+		}
+		if rr.Header().Ttl < a.minTTL {
+			return assertionTTLTooLow
+		}
+	}
+
+	if !found && a.values != nil {
+		return assertionValueMismatch
+	}
+
+	return assertionPassed
+}
+
+// recordValue returns the RDATA of the record in presentation format.
+func recordValue(rr dns.RR) string {
+	return normalizeRecordValue(strings.TrimPrefix(rr.String(), rr.Header().String()))
+}
+
+func normalizeRecordValue(v string) string {
+	v = strings.TrimSpace(v)
+	if ip := net.ParseIP(v); ip != nil {
+		return ip.String()
+	}
+	return strings.TrimSuffix(strings.ToLower(v), ".")
+}
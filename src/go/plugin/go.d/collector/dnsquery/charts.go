@@ -12,12 +12,22 @@ import (
 const (
 	prioDNSQueryStatus = module.Priority + iota
 	prioDNSQueryTime
+	prioDNSQueryRcode
+	prioDNSSECStatus
+	prioRRSIGTimeUntilExpiration
+	prioAssertionStatus
 )
 
 var (
 	dnsChartsTmpl = module.Charts{
 		dnsQueryStatusChartTmpl.Copy(),
 		dnsQueryTimeChartTmpl.Copy(),
+		dnsQueryRcodeChartTmpl.Copy(),
+	}
+	dnsDomainChartsTmpl = module.Charts{
+		dnsDNSSECStatusChartTmpl.Copy(),
+		dnsRRSIGTimeUntilExpirationChartTmpl.Copy(),
+		dnsAssertionStatusChartTmpl.Copy(),
 	}
 	dnsQueryStatusChartTmpl = module.Chart{
 		ID:       "server_%s_record_%s_query_status",
@@ -43,13 +53,67 @@ var (
 			{ID: "server_%s_record_%s_query_time", Name: "query_time", Div: 1e9},
 		},
 	}
+	dnsQueryRcodeChartTmpl = module.Chart{
+		ID:       "server_%s_record_%s_query_rcode",
+		Title:    "DNS Query Response Code",
+		Units:    "status",
+		Fam:      "query status",
+		Ctx:      "dns_query.query_rcode",
+		Priority: prioDNSQueryRcode,
+		Dims:     dnsQueryRcodeDims(),
+	}
+	dnsDNSSECStatusChartTmpl = module.Chart{
+		ID:       "server_%s_record_%s_domain_%s_dnssec_status",
+		Title:    "DNSSEC Validation Status",
+		Units:    "status",
+		Fam:      "dnssec",
+		Ctx:      "dns_query.dnssec_status",
+		Priority: prioDNSSECStatus,
+		Dims: module.Dims{
+			{ID: "server_%s_record_%s_domain_%s_dnssec_status_secure", Name: "secure"},
+			{ID: "server_%s_record_%s_domain_%s_dnssec_status_insecure", Name: "insecure"},
+			{ID: "server_%s_record_%s_domain_%s_dnssec_status_bogus", Name: "bogus"},
+		},
+	}
+	dnsRRSIGTimeUntilExpirationChartTmpl = module.Chart{
+		ID:       "server_%s_record_%s_domain_%s_rrsig_time_until_expiration",
+		Title:    "DNSSEC Signature Time Until Expiration",
+		Units:    "seconds",
+		Fam:      "dnssec",
+		Ctx:      "dns_query.rrsig_time_until_expiration",
+		Priority: prioRRSIGTimeUntilExpiration,
+		Dims: module.Dims{
+			{ID: "server_%s_record_%s_domain_%s_rrsig_expiry", Name: "expiry"},
+		},
+	}
+	dnsAssertionStatusChartTmpl = module.Chart{
+		ID:       "server_%s_record_%s_domain_%s_assertion_status",
+		Title:    "DNS Response Assertion Status",
+		Units:    "status",
+		Fam:      "assertions",
+		Ctx:      "dns_query.assertion_status",
+		Priority: prioAssertionStatus,
+		Dims: module.Dims{
+			{ID: "server_%s_record_%s_domain_%s_assertion_status_passed", Name: "passed"},
+			{ID: "server_%s_record_%s_domain_%s_assertion_status_value_mismatch", Name: "value_mismatch"},
+			{ID: "server_%s_record_%s_domain_%s_assertion_status_ttl_too_low", Name: "ttl_too_low"},
+		},
+	}
 )
 
+func dnsQueryRcodeDims() module.Dims {
+	var dims module.Dims
+	for _, rcode := range rcodeNames {
+		dims = append(dims, &module.Dim{ID: "server_%s_record_%s_query_rcode_" + rcode, Name: rcode})
+	}
+	return dims
+}
+
 func newDNSServerCharts(server, network, rtype string) *module.Charts {
 	charts := dnsChartsTmpl.Copy()
 
 	for _, chart := range *charts {
-		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(server, ".", "_"), rtype)
+		chart.ID = fmt.Sprintf(chart.ID, serverChartID(server), rtype)
 		chart.Labels = []module.Label{
 			{Key: "server", Value: server},
 			{Key: "network", Value: network},
@@ -62,3 +126,34 @@ func newDNSServerCharts(server, network, rtype string) *module.Charts {
 
 	return charts
 }
+
+func newDNSDomainCharts(server, network, rtype, domain string) *module.Charts {
+	charts := dnsDomainChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, serverChartID(server), rtype, domainChartID(domain))
+		chart.Labels = []module.Label{
+			{Key: "server", Value: server},
+			{Key: "network", Value: network},
+			{Key: "record_type", Value: rtype},
+			{Key: "domain", Value: domain},
+		}
+		for _, d := range chart.Dims {
+			d.ID = fmt.Sprintf(d.ID, server, rtype, domain)
+		}
+	}
+
+	return charts
+}
+
+func domainChartID(domain string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(domain, "."), ".", "_")
+}
+
+func serverChartID(server string) string {
+	if s, ok := strings.CutPrefix(server, "https://"); ok {
+		// DNS over HTTPS URL
+		return strings.NewReplacer(".", "_", "/", "_", ":", "_").Replace(strings.TrimSuffix(s, "/"))
+	}
+	return strings.ReplaceAll(server, ".", "_")
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	networkUDP   = "udp"
+	networkTCP   = "tcp"
+	networkTLS   = "tcp-tls"
+	networkHTTPS = "https"
+)
+
+const (
+	dnsDefaultPort = 53
+	dotDefaultPort = 853 // RFC 7858
+
+	dohContentType = "application/dns-message"
+	dohDefaultPath = "/dns-query"
+)
+
+func newDNSClient(cfg Config) (dnsClient, error) {
+	if cfg.Network == networkHTTPS {
+		httpClient, err := web.NewHTTPClient(web.ClientConfig{
+			Timeout:   cfg.Timeout,
+			TLSConfig: cfg.TLSConfig,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &dohClient{httpClient: httpClient}, nil
+	}
+
+	tlsConfig, err := tlscfg.NewTLSConfig(cfg.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dns.Client{
+		Net:         cfg.Network,
+		ReadTimeout: cfg.Timeout.Duration(),
+		TLSConfig:   tlsConfig,
+	}, nil
+}
+
+// dohClient implements DNS over HTTPS (RFC 8484) using the POST method.
+type dohClient struct {
+	httpClient *http.Client
+}
+
+func (c *dohClient) Exchange(msg *dns.Msg, address string) (*dns.Msg, time.Duration, error) {
+	// RFC 8484, section 4.1: the DNS ID SHOULD be set to 0 to maximize HTTP cache friendliness
+	m := msg.Copy()
+	m.Id = 0
+
+	buf, err := m.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, address, bytes.NewReader(buf))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer web.CloseBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("'%s' returned HTTP status code: %d", address, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rtt := time.Since(start)
+
+	answer := new(dns.Msg)
+	if err := answer.Unpack(body); err != nil {
+		return nil, 0, fmt.Errorf("unpack response from '%s': %v", address, err)
+	}
+	answer.Id = msg.Id
+
+	return answer, rtt, nil
+}
@@ -4,66 +4,151 @@ package dnsquery
 
 import (
 	"math/rand"
-	"net"
-	"strconv"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/miekg/dns"
 )
 
-func (c *Collector) collect() (map[string]int64, error) {
-	if c.dnsClient == nil {
-		c.dnsClient = c.newDNSClient(c.Network, c.Timeout.Duration())
-	}
+// rcodeNames are the response codes with a dedicated dimension, the rest are counted as "other".
+var rcodeNames = []string{
+	"noerror",
+	"formerr",
+	"servfail",
+	"nxdomain",
+	"notimp",
+	"refused",
+	"other",
+}
 
+func (c *Collector) collect() (map[string]int64, error) {
 	mx := make(map[string]int64)
 	domain := randomDomain(c.Domains)
 	c.Debugf("current domain : %s", domain)
 
 	var wg sync.WaitGroup
-	var mux sync.RWMutex
+	var mux sync.Mutex
 	for _, srv := range c.Servers {
 		for rtypeName, rtype := range c.recordTypes {
-			wg.Add(1)
-			go func(srv, rtypeName string, rtype uint16, wg *sync.WaitGroup) {
-				defer wg.Done()
+			// the query status metrics use a random domain, DNSSEC and assertions are checked for every domain
+			for _, d := range c.queryDomains(rtypeName, domain) {
+				wg.Add(1)
+				go func(srv, rtypeName string, rtype uint16, d string) {
+					defer wg.Done()
+					c.collectQuery(mx, &mux, srv, rtypeName, rtype, d, d == domain)
+				}(srv, rtypeName, rtype, d)
+			}
+		}
+	}
+	wg.Wait()
 
-				msg := new(dns.Msg)
-				msg.SetQuestion(dns.Fqdn(domain), rtype)
-				address := net.JoinHostPort(srv, strconv.Itoa(c.Port))
+	return mx, nil
+}
 
-				resp, rtt, err := c.dnsClient.Exchange(msg, address)
+func (c *Collector) collectQuery(mx map[string]int64, mux *sync.Mutex, srv, rtypeName string, rtype uint16, domain string, isRandom bool) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), rtype)
+	if c.DNSSEC {
+		msg.SetEdns0(4096, true)
+		// we validate ourselves, a validating resolver would answer SERVFAIL for bogus data otherwise
+		msg.CheckingDisabled = true
+	}
+	address := c.serverAddrs[srv]
 
-				mux.Lock()
-				defer mux.Unlock()
+	resp, rtt, err := c.dnsClient.Exchange(msg, address)
 
-				px := "server_" + srv + "_record_" + rtypeName + "_"
+	isChecked := c.isCheckedDomain(rtypeName, domain)
 
-				mx[px+"query_status_success"] = 0
-				mx[px+"query_status_network_error"] = 0
-				mx[px+"query_status_dns_error"] = 0
+	var sec dnssecResult
+	if isChecked && err == nil && resp != nil && c.dnssec != nil {
+		if sec = c.dnssec.validate(c.dnsClient, address, resp); sec.err != nil {
+			c.Debugf("DNSSEC validation failed for %s %s query to %s : %v", domain, rtypeName, srv, sec.err)
+		}
+	}
 
-				if err != nil {
-					c.Debugf("error on querying %s after %s query for %s : %s", srv, rtypeName, domain, err)
-					mx[px+"query_status_network_error"] = 1
-					return
-				}
+	mux.Lock()
+	defer mux.Unlock()
+
+	if isRandom {
+		px := "server_" + srv + "_record_" + rtypeName + "_"
+
+		mx[px+"query_status_success"] = 0
+		mx[px+"query_status_network_error"] = 0
+		mx[px+"query_status_dns_error"] = 0
+
+		if err != nil {
+			c.Debugf("error on querying %s after %s query for %s : %s", srv, rtypeName, domain, err)
+			mx[px+"query_status_network_error"] = 1
+		} else {
+			if resp != nil && resp.Rcode != dns.RcodeSuccess {
+				c.Debugf("invalid answer from %s after %s query for %s (rcode %d)", srv, rtypeName, domain, resp.Rcode)
+				mx[px+"query_status_dns_error"] = 1
+			} else {
+				mx[px+"query_status_success"] = 1
+			}
+			mx[px+"query_time"] = rtt.Nanoseconds()
+
+			if resp != nil {
+				c.collectRcode(mx, px, resp.Rcode)
+			}
+		}
+	}
 
-				if resp != nil && resp.Rcode != dns.RcodeSuccess {
-					c.Debugf("invalid answer from %s after %s query for %s (rcode %d)", srv, rtypeName, domain, resp.Rcode)
-					mx[px+"query_status_dns_error"] = 1
-				} else {
-					mx[px+"query_status_success"] = 1
-				}
-				mx["server_"+srv+"_record_"+rtypeName+"_query_time"] = rtt.Nanoseconds()
+	if !isChecked || err != nil || resp == nil {
+		return
+	}
 
-			}(srv, rtypeName, rtype, &wg)
+	px := domainMetricPx(srv, rtypeName, domain)
+
+	if sec.status != "" {
+		for _, v := range []string{dnssecSecure, dnssecInsecure, dnssecBogus} {
+			mx[px+"dnssec_status_"+v] = boolToInt(sec.status == v)
+		}
+		if !sec.rrsigExpiration.IsZero() {
+			mx[px+"rrsig_expiry"] = int64(time.Until(sec.rrsigExpiration).Seconds())
 		}
 	}
-	wg.Wait()
 
-	return mx, nil
+	if a, ok := c.assertions[assertionKey{domain: domain, recordType: rtypeName}]; ok && resp.Rcode == dns.RcodeSuccess {
+		status := a.check(resp, rtype)
+		if status != assertionPassed {
+			c.Debugf("assertion failed for %s %s query to %s : %s", domain, rtypeName, srv, status)
+		}
+		for _, v := range []string{assertionPassed, assertionValueMismatch, assertionTTLTooLow} {
+			mx[px+"assertion_status_"+v] = boolToInt(status == v)
+		}
+	}
+}
+
+// queryDomains returns the random domain followed by the other domains checked for the record type.
+func (c *Collector) queryDomains(rtypeName, domain string) []string {
+	domains := []string{domain}
+	for _, d := range c.checkedDomains[rtypeName] {
+		if d != domain {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+func (c *Collector) isCheckedDomain(rtypeName, domain string) bool {
+	return slices.Contains(c.checkedDomains[rtypeName], domain)
+}
+
+func domainMetricPx(srv, rtypeName, domain string) string {
+	return "server_" + srv + "_record_" + rtypeName + "_domain_" + domain + "_"
+}
+
+func (c *Collector) collectRcode(mx map[string]int64, px string, rcode int) {
+	name := strings.ToLower(dns.RcodeToString[rcode])
+	if !slices.Contains(rcodeNames, name) {
+		name = "other"
+	}
+	for _, v := range rcodeNames {
+		mx[px+"query_rcode_"+v] = boolToInt(v == name)
+	}
 }
 
 func randomDomain(domains []string) string {
@@ -71,3 +156,10 @@ func randomDomain(domains []string) string {
 	r := rand.New(src)
 	return domains[r.Intn(len(domains))]
 }
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
 
 	"github.com/miekg/dns"
 )
@@ -34,27 +35,32 @@ func New() *Collector {
 			Timeout:     confopt.Duration(time.Second * 2),
 			Network:     "udp",
 			RecordTypes: []string{"A"},
-			Port:        53,
-		},
-		newDNSClient: func(network string, timeout time.Duration) dnsClient {
-			return &dns.Client{
-				Net:         network,
-				ReadTimeout: timeout,
-			}
 		},
+		newDNSClient: newDNSClient,
 	}
 }
 
 type Config struct {
-	Vnode       string           `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
-	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
-	Domains     []string         `yaml:"domains" json:"domains"`
-	Servers     []string         `yaml:"servers" json:"servers"`
-	Network     string           `yaml:"network,omitempty" json:"network"`
-	RecordType  string           `yaml:"record_type,omitempty" json:"record_type"`
-	RecordTypes []string         `yaml:"record_types,omitempty" json:"record_types"`
-	Port        int              `yaml:"port,omitempty" json:"port"`
+	Vnode       string            `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery int               `yaml:"update_every,omitempty" json:"update_every"`
+	Timeout     confopt.Duration  `yaml:"timeout,omitempty" json:"timeout"`
+	Domains     []string          `yaml:"domains" json:"domains"`
+	Servers     []string          `yaml:"servers" json:"servers"`
+	Network     string            `yaml:"network,omitempty" json:"network"`
+	RecordType  string            `yaml:"record_type,omitempty" json:"record_type"`
+	RecordTypes []string          `yaml:"record_types,omitempty" json:"record_types"`
+	Port        int               `yaml:"port,omitempty" json:"port"`
+	DNSSEC      bool              `yaml:"dnssec,omitempty" json:"dnssec"`
+	Assertions  []AssertionConfig `yaml:"assertions,omitempty" json:"assertions"`
+
+	tlscfg.TLSConfig `yaml:",inline" json:""`
+}
+
+type AssertionConfig struct {
+	Domain     string   `yaml:"domain" json:"domain"`
+	RecordType string   `yaml:"record_type" json:"record_type"`
+	Values     []string `yaml:"values,omitempty" json:"values"`
+	MinTTL     int      `yaml:"min_ttl,omitempty" json:"min_ttl"`
 }
 
 type (
@@ -65,9 +71,13 @@ type (
 		charts *module.Charts
 
 		dnsClient    dnsClient
-		newDNSClient func(network string, duration time.Duration) dnsClient
+		newDNSClient func(cfg Config) (dnsClient, error)
 
-		recordTypes map[string]uint16
+		recordTypes    map[string]uint16
+		serverAddrs    map[string]string
+		assertions     map[assertionKey]*assertion
+		checkedDomains map[string][]string
+		dnssec         *dnssecValidator
 	}
 	dnsClient interface {
 		Exchange(msg *dns.Msg, address string) (response *dns.Msg, rtt time.Duration, err error)
@@ -93,6 +103,26 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.recordTypes = rt
 
+	c.serverAddrs = c.initServerAddresses()
+
+	as, err := c.initAssertions()
+	if err != nil {
+		return fmt.Errorf("init assertions: %v", err)
+	}
+	c.assertions = as
+
+	c.checkedDomains = c.initCheckedDomains()
+
+	if c.DNSSEC {
+		c.dnssec = newDNSSECValidator()
+	}
+
+	dc, err := c.newDNSClient(c.Config)
+	if err != nil {
+		return fmt.Errorf("create DNS client: %v", err)
+	}
+	c.dnsClient = dc
+
 	charts, err := c.initCharts()
 	if err != nil {
 		return fmt.Errorf("init charts: %v", err)
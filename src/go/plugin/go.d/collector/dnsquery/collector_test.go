@@ -4,8 +4,16 @@ package dnsquery
 
 import (
 	"context"
+	"crypto"
 	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -62,6 +70,67 @@ func TestCollector_Init(t *testing.T) {
 				Timeout:    confopt.Duration(time.Second),
 			},
 		},
+		"success when using DNS over HTTPS": {
+			wantFail: false,
+			config: Config{
+				Domains:     []string{"example.com"},
+				Servers:     []string{"https://dns.example/dns-query"},
+				Network:     "https",
+				RecordTypes: []string{"A"},
+				Timeout:     confopt.Duration(time.Second),
+			},
+		},
+		"success with dnssec and assertions": {
+			wantFail: false,
+			config: Config{
+				Domains:     []string{"example.com"},
+				Servers:     []string{"192.0.2.0"},
+				Network:     "udp",
+				RecordTypes: []string{"A"},
+				Port:        53,
+				Timeout:     confopt.Duration(time.Second),
+				DNSSEC:      true,
+				Assertions: []AssertionConfig{
+					{Domain: "example.com", RecordType: "A", Values: []string{"192.0.2.10"}, MinTTL: 60},
+				},
+			},
+		},
+		"fail when assertion domain not in domains": {
+			wantFail: true,
+			config: Config{
+				Domains:     []string{"example.com"},
+				Servers:     []string{"192.0.2.0"},
+				Network:     "udp",
+				RecordTypes: []string{"A"},
+				Port:        53,
+				Timeout:     confopt.Duration(time.Second),
+				Assertions:  []AssertionConfig{{Domain: "example.org", RecordType: "A", MinTTL: 60}},
+			},
+		},
+		"fail when assertion record type not in record_types": {
+			wantFail: true,
+			config: Config{
+				Domains:     []string{"example.com"},
+				Servers:     []string{"192.0.2.0"},
+				Network:     "udp",
+				RecordTypes: []string{"A"},
+				Port:        53,
+				Timeout:     confopt.Duration(time.Second),
+				Assertions:  []AssertionConfig{{Domain: "example.com", RecordType: "AAAA", MinTTL: 60}},
+			},
+		},
+		"fail when assertion has no checks": {
+			wantFail: true,
+			config: Config{
+				Domains:     []string{"example.com"},
+				Servers:     []string{"192.0.2.0"},
+				Network:     "udp",
+				RecordTypes: []string{"A"},
+				Port:        53,
+				Timeout:     confopt.Duration(time.Second),
+				Assertions:  []AssertionConfig{{Domain: "example.com", RecordType: "A"}},
+			},
+		},
 		"fail with default": {
 			wantFail: true,
 			config:   New().Config,
@@ -153,7 +222,18 @@ func TestCollector_Charts(t *testing.T) {
 	require.NoError(t, collr.Init(context.Background()))
 
 	assert.NotNil(t, collr.Charts())
-	assert.Len(t, *collr.Charts(), len(dnsChartsTmpl)*len(collr.Servers))
+	// query status, query time and rcode charts
+	assert.Len(t, *collr.Charts(), 3*len(collr.Servers))
+
+	collr = New()
+	collr.Domains = []string{"google.com"}
+	collr.Servers = []string{"192.0.2.0", "192.0.2.1"}
+	collr.DNSSEC = true
+	collr.Assertions = []AssertionConfig{{Domain: "google.com", RecordType: "A", MinTTL: 60}}
+	require.NoError(t, collr.Init(context.Background()))
+
+	// plus DNSSEC status, RRSIG expiration and assertion status charts per domain
+	assert.Len(t, *collr.Charts(), (len(dnsChartsTmpl)+len(dnsDomainChartsTmpl))*len(collr.Servers))
 }
 
 func TestCollector_Collect(t *testing.T) {
@@ -200,12 +280,331 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_RcodeAndAssertions(t *testing.T) {
+	tests := map[string]struct {
+		answer      func(q *dns.Msg) *dns.Msg
+		wantMetrics map[string]int64
+	}{
+		"assertion passed": {
+			answer: func(q *dns.Msg) *dns.Msg {
+				return newAnswer(q, dns.RcodeSuccess, "example.com. 300 IN A 192.0.2.10", "example.com. 300 IN A 192.0.2.11")
+			},
+			wantMetrics: map[string]int64{
+				"query_rcode_noerror":                                1,
+				"domain_example.com_assertion_status_passed":         1,
+				"domain_example.com_assertion_status_value_mismatch": 0,
+				"domain_example.com_assertion_status_ttl_too_low":    0,
+			},
+		},
+		"value mismatch": {
+			answer: func(q *dns.Msg) *dns.Msg {
+				return newAnswer(q, dns.RcodeSuccess, "example.com. 300 IN A 203.0.113.1")
+			},
+			wantMetrics: map[string]int64{
+				"query_rcode_noerror":                                1,
+				"domain_example.com_assertion_status_passed":         0,
+				"domain_example.com_assertion_status_value_mismatch": 1,
+				"domain_example.com_assertion_status_ttl_too_low":    0,
+			},
+		},
+		"ttl too low": {
+			answer: func(q *dns.Msg) *dns.Msg {
+				return newAnswer(q, dns.RcodeSuccess,
+					"example.com. 30 IN CNAME edge.example.net.", "edge.example.net. 30 IN A 192.0.2.10")
+			},
+			wantMetrics: map[string]int64{
+				"query_rcode_noerror":                                1,
+				"domain_example.com_assertion_status_passed":         0,
+				"domain_example.com_assertion_status_value_mismatch": 0,
+				"domain_example.com_assertion_status_ttl_too_low":    1,
+			},
+		},
+		"nxdomain": {
+			answer: func(q *dns.Msg) *dns.Msg {
+				return newAnswer(q, dns.RcodeNameError)
+			},
+			wantMetrics: map[string]int64{
+				"query_rcode_nxdomain":   1,
+				"query_status_dns_error": 1,
+			},
+		},
+		"badvers is other": {
+			answer: func(q *dns.Msg) *dns.Msg {
+				return newAnswer(q, dns.RcodeBadVers)
+			},
+			wantMetrics: map[string]int64{
+				"query_rcode_other":      1,
+				"query_status_dns_error": 1,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Domains = []string{"example.com"}
+			collr.Servers = []string{"192.0.2.0"}
+			collr.Assertions = []AssertionConfig{
+				{Domain: "example.com", RecordType: "A", Values: []string{"192.0.2.10", "192.0.2.11"}, MinTTL: 60},
+			}
+			collr.newDNSClient = func(Config) (dnsClient, error) {
+				return &mockAnswerDNSClient{answer: test.answer}, nil
+			}
+			require.NoError(t, collr.Init(context.Background()))
+
+			mx := collr.Collect(context.Background())
+			require.NotNil(t, mx)
+
+			px := "server_192.0.2.0_record_A_"
+			for k, v := range test.wantMetrics {
+				assert.Equalf(t, v, mx[px+k], "metric '%s'", k)
+			}
+			for _, rcode := range rcodeNames {
+				assert.Contains(t, mx, px+"query_rcode_"+rcode)
+			}
+			if mx[px+"query_rcode_noerror"] == 1 {
+				module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+			} else {
+				assert.NotContains(t, mx, px+"domain_example.com_assertion_status_passed")
+			}
+		})
+	}
+}
+
+func TestCollector_Collect_AssertionsForEveryDomain(t *testing.T) {
+	var mux sync.Mutex
+	queried := make(map[string]int)
+
+	collr := New()
+	collr.Domains = []string{"a.example", "b.example", "c.example"}
+	collr.Servers = []string{"192.0.2.0"}
+	collr.Assertions = []AssertionConfig{
+		{Domain: "a.example", RecordType: "A", Values: []string{"192.0.2.10"}},
+		{Domain: "b.example", RecordType: "A", Values: []string{"192.0.2.10"}},
+	}
+	collr.newDNSClient = func(Config) (dnsClient, error) {
+		return &mockAnswerDNSClient{answer: func(q *dns.Msg) *dns.Msg {
+			name := q.Question[0].Name
+			mux.Lock()
+			queried[name]++
+			mux.Unlock()
+			if name == "b.example." {
+				return newAnswer(q, dns.RcodeSuccess, name+" 300 IN A 203.0.113.1")
+			}
+			return newAnswer(q, dns.RcodeSuccess, name+" 300 IN A 192.0.2.10")
+		}}, nil
+	}
+	require.NoError(t, collr.Init(context.Background()))
+
+	for i := 0; i < 3; i++ {
+		mx := collr.Collect(context.Background())
+		require.NotNil(t, mx)
+
+		px := "server_192.0.2.0_record_A_domain_"
+		assert.Equal(t, int64(1), mx[px+"a.example_assertion_status_passed"])
+		assert.Equal(t, int64(1), mx[px+"b.example_assertion_status_value_mismatch"])
+		assert.NotContains(t, mx, px+"c.example_assertion_status_passed")
+		module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+	}
+
+	// the asserted domains are queried every collection
+	assert.Equal(t, 3, queried["a.example."])
+	assert.Equal(t, 3, queried["b.example."])
+	assert.True(t, collr.Charts().Has("server_192_0_2_0_record_A_domain_a_example_assertion_status"))
+	assert.True(t, collr.Charts().Has("server_192_0_2_0_record_A_domain_b_example_assertion_status"))
+	assert.False(t, collr.Charts().Has("server_192_0_2_0_record_A_domain_c_example_assertion_status"))
+}
+
+func TestCollector_Collect_DNSSEC(t *testing.T) {
+	tests := map[string]struct {
+		modify     func(z *testZones)
+		wantStatus string
+		wantExpiry bool
+	}{
+		"secure": {
+			wantStatus: dnssecSecure,
+			wantExpiry: true,
+		},
+		"bogus when record is tampered": {
+			modify: func(z *testZones) {
+				z.answers["example.com."][0].(*dns.A).A = net.ParseIP("203.0.113.1")
+			},
+			wantStatus: dnssecBogus,
+		},
+		"bogus when trust anchor does not match": {
+			modify: func(z *testZones) {
+				z.trustAnchor.Digest = strings.Repeat("0", len(z.trustAnchor.Digest))
+			},
+			wantStatus: dnssecBogus,
+		},
+		"bogus when signature expired": {
+			modify: func(z *testZones) {
+				z.now = time.Now().Add(time.Hour * 24 * 60)
+			},
+			wantStatus: dnssecBogus,
+		},
+		"bogus when answer of a signed zone is unsigned": {
+			modify: func(z *testZones) {
+				z.answers["example.com."] = z.answers["example.com."][:1]
+			},
+			wantStatus: dnssecBogus,
+		},
+		"insecure when unsigned below a delegation proven by NSEC": {
+			modify: func(z *testZones) {
+				z.answers["example.com."] = z.answers["example.com."][:1]
+				delete(z.ds, "example.com.")
+				z.nsec["example.com."] = z.sign("com.", newTestNSEC("example.com.", "www.example.com.", dns.TypeNS))
+			},
+			wantStatus: dnssecInsecure,
+		},
+		"insecure when unsigned below a delegation proven by NSEC3 opt-out": {
+			modify: func(z *testZones) {
+				z.answers["example.com."] = z.answers["example.com."][:1]
+				delete(z.ds, "example.com.")
+				z.nsec["example.com."] = z.sign("com.", &dns.NSEC3{
+					Hdr:        dns.RR_Header{Name: strings.Repeat("0", 32) + ".com.", Rrtype: dns.TypeNSEC3, Class: dns.ClassINET, Ttl: 300},
+					Hash:       dns.SHA1,
+					Flags:      nsec3OptOut,
+					NextDomain: strings.Repeat("V", 32),
+					HashLength: 20,
+				})
+			},
+			wantStatus: dnssecInsecure,
+		},
+		"insecure when delegation has no DS": {
+			modify: func(z *testZones) {
+				delete(z.ds, "example.com.")
+				z.nsec["example.com."] = z.sign("com.", newTestNSEC("example.com.", "www.example.com.", dns.TypeNS))
+			},
+			wantStatus: dnssecInsecure,
+		},
+		"bogus when delegation has no DS and no proof": {
+			modify: func(z *testZones) {
+				delete(z.ds, "example.com.")
+			},
+			wantStatus: dnssecBogus,
+		},
+		"bogus when no DS proof is signed by the child zone": {
+			modify: func(z *testZones) {
+				delete(z.ds, "example.com.")
+				z.nsec["example.com."] = z.sign("example.com.", newTestNSEC("example.com.", "www.example.com.", dns.TypeNS))
+			},
+			wantStatus: dnssecBogus,
+		},
+		"bogus when unsigned and NSEC proves the name is not a delegation": {
+			modify: func(z *testZones) {
+				z.answers["example.com."] = z.answers["example.com."][:1]
+				delete(z.ds, "example.com.")
+				z.nsec["example.com."] = z.sign("com.", newTestNSEC("example.com.", "www.example.com.", dns.TypeA))
+			},
+			wantStatus: dnssecBogus,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			zones := newTestZones(t)
+			if test.modify != nil {
+				test.modify(zones)
+			}
+
+			collr := New()
+			collr.Domains = []string{"example.com"}
+			collr.Servers = []string{"192.0.2.0"}
+			collr.DNSSEC = true
+			collr.newDNSClient = func(Config) (dnsClient, error) { return zones, nil }
+			require.NoError(t, collr.Init(context.Background()))
+			collr.dnssec.trustAnchors = []*dns.DS{zones.trustAnchor}
+			collr.dnssec.now = func() time.Time { return zones.now }
+
+			mx := collr.Collect(context.Background())
+			require.NotNil(t, mx)
+
+			assert.Zero(t, zones.queriesWithoutCD, "queries without the CD flag")
+
+			px := "server_192.0.2.0_record_A_domain_example.com_"
+			for _, v := range []string{dnssecSecure, dnssecInsecure, dnssecBogus} {
+				assert.Equalf(t, boolToInt(v == test.wantStatus), mx[px+"dnssec_status_"+v], "dnssec status '%s'", v)
+			}
+			if test.wantExpiry {
+				assert.InDelta(t, (time.Hour * 24 * 30).Seconds(), mx[px+"rrsig_expiry"], 60)
+				module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+			} else {
+				assert.NotContains(t, mx, px+"rrsig_expiry")
+			}
+		})
+	}
+}
+
+func TestDoHClient_Exchange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dns-query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, dohContentType, r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		q := new(dns.Msg)
+		require.NoError(t, q.Unpack(body))
+		assert.Zero(t, q.Id)
+
+		resp, err := newAnswer(q, dns.RcodeSuccess, "example.com. 300 IN A 192.0.2.10").Pack()
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", dohContentType)
+		_, _ = w.Write(resp)
+	}))
+	defer srv.Close()
+
+	cfg := New().Config
+	cfg.Network = networkHTTPS
+	client, err := newDNSClient(cfg)
+	require.NoError(t, err)
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("example.com.", dns.TypeA)
+
+	resp, _, err := client.Exchange(msg, srv.URL+"/dns-query")
+	require.NoError(t, err)
+	assert.Equal(t, msg.Id, resp.Id)
+	require.Len(t, resp.Answer, 1)
+	assert.Equal(t, "192.0.2.10", resp.Answer[0].(*dns.A).A.String())
+
+	_, _, err = client.Exchange(msg, srv.URL+"/nonexistent")
+	assert.Error(t, err)
+}
+
+func TestCollector_initServerAddresses(t *testing.T) {
+	collr := New()
+	collr.Network = networkHTTPS
+	collr.Servers = []string{"dns.example", "https://dns.example:8443/resolve"}
+
+	assert.Equal(t, map[string]string{
+		"dns.example":                      "https://dns.example/dns-query",
+		"https://dns.example:8443/resolve": "https://dns.example:8443/resolve",
+	}, collr.initServerAddresses())
+
+	assert.Equal(t, "dns_example_8443_resolve", serverChartID("https://dns.example:8443/resolve"))
+
+	collr = New()
+	collr.Servers = []string{"192.0.2.0"}
+	assert.Equal(t, map[string]string{"192.0.2.0": "192.0.2.0:53"}, collr.initServerAddresses())
+
+	collr.Network = networkTLS
+	assert.Equal(t, map[string]string{"192.0.2.0": "192.0.2.0:853"}, collr.initServerAddresses())
+
+	collr.Port = 8853
+	assert.Equal(t, map[string]string{"192.0.2.0": "192.0.2.0:8853"}, collr.initServerAddresses())
+}
+
 func caseDNSClientOK() *Collector {
 	collr := New()
 	collr.Domains = []string{"example.com"}
 	collr.Servers = []string{"192.0.2.0", "192.0.2.1"}
-	collr.newDNSClient = func(_ string, _ time.Duration) dnsClient {
-		return mockDNSClient{errOnExchange: false}
+	collr.newDNSClient = func(Config) (dnsClient, error) {
+		return mockDNSClient{errOnExchange: false}, nil
 	}
 	return collr
 }
@@ -214,8 +613,8 @@ func caseDNSClientErr() *Collector {
 	collr := New()
 	collr.Domains = []string{"example.com"}
 	collr.Servers = []string{"192.0.2.0", "192.0.2.1"}
-	collr.newDNSClient = func(_ string, _ time.Duration) dnsClient {
-		return mockDNSClient{errOnExchange: true}
+	collr.newDNSClient = func(Config) (dnsClient, error) {
+		return mockDNSClient{errOnExchange: true}, nil
 	}
 	return collr
 }
@@ -230,3 +629,123 @@ func (m mockDNSClient) Exchange(_ *dns.Msg, _ string) (response *dns.Msg, rtt ti
 	}
 	return nil, time.Second, nil
 }
+
+type mockAnswerDNSClient struct {
+	answer func(q *dns.Msg) *dns.Msg
+}
+
+func (m *mockAnswerDNSClient) Exchange(msg *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	return m.answer(msg), time.Millisecond, nil
+}
+
+func newAnswer(q *dns.Msg, rcode int, rrs ...string) *dns.Msg {
+	resp := new(dns.Msg)
+	resp.SetRcode(q, rcode)
+	for _, v := range rrs {
+		rr, err := dns.NewRR(v)
+		if err != nil {
+			panic(err)
+		}
+		resp.Answer = append(resp.Answer, rr)
+	}
+	return resp
+}
+
+// testZones is a signed "." -> "com." -> "example.com." hierarchy served by a mock resolver.
+type testZones struct {
+	queriesWithoutCD int
+
+	now         time.Time
+	trustAnchor *dns.DS
+	keys        map[string]*dns.DNSKEY
+	ds          map[string][]dns.RR
+	nsec        map[string][]dns.RR
+	answers     map[string][]dns.RR
+	sign        func(signer string, rrset ...dns.RR) []dns.RR
+}
+
+func newTestZones(t *testing.T) *testZones {
+	z := &testZones{
+		now:     time.Now(),
+		keys:    make(map[string]*dns.DNSKEY),
+		ds:      make(map[string][]dns.RR),
+		nsec:    make(map[string][]dns.RR),
+		answers: make(map[string][]dns.RR),
+	}
+
+	signers := make(map[string]crypto.Signer)
+	for _, zone := range []string{".", "com.", "example.com."} {
+		key := &dns.DNSKEY{
+			Hdr:       dns.RR_Header{Name: zone, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+			Flags:     257,
+			Protocol:  3,
+			Algorithm: dns.ECDSAP256SHA256,
+		}
+		priv, err := key.Generate(256)
+		require.NoError(t, err)
+		z.keys[zone] = key
+		signers[zone] = priv.(crypto.Signer)
+	}
+
+	z.sign = func(signer string, rrset ...dns.RR) []dns.RR {
+		key := z.keys[signer]
+		hdr := rrset[0].Header()
+		sig := &dns.RRSIG{
+			Hdr:        dns.RR_Header{Name: hdr.Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: hdr.Ttl},
+			Inception:  uint32(z.now.Add(-time.Hour).Unix()),
+			Expiration: uint32(z.now.Add(time.Hour * 24 * 30).Unix()),
+			KeyTag:     key.KeyTag(),
+			SignerName: signer,
+			Algorithm:  key.Algorithm,
+		}
+		require.NoError(t, sig.Sign(signers[signer], rrset))
+		return append(rrset, sig)
+	}
+
+	z.trustAnchor = z.keys["."].ToDS(dns.SHA256)
+	for zone, key := range z.keys {
+		z.answers["dnskey "+zone] = z.sign(zone, key)
+	}
+	z.ds["com."] = z.sign(".", z.keys["com."].ToDS(dns.SHA256))
+	z.ds["example.com."] = z.sign("com.", z.keys["example.com."].ToDS(dns.SHA256))
+
+	a, err := dns.NewRR("example.com. 300 IN A 192.0.2.10")
+	require.NoError(t, err)
+	z.answers["example.com."] = z.sign("example.com.", a)
+
+	return z
+}
+
+func newTestNSEC(owner, next string, types ...uint16) *dns.NSEC {
+	types = append(types, dns.TypeRRSIG, dns.TypeNSEC)
+	slices.Sort(types)
+	return &dns.NSEC{
+		Hdr:        dns.RR_Header{Name: owner, Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 300},
+		NextDomain: next,
+		TypeBitMap: types,
+	}
+}
+
+func (z *testZones) Exchange(msg *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	q := msg.Question[0]
+	resp := new(dns.Msg)
+	resp.SetReply(msg)
+
+	if !msg.CheckingDisabled {
+		z.queriesWithoutCD++
+	}
+
+	switch q.Qtype {
+	case dns.TypeDNSKEY:
+		resp.Answer = z.answers["dnskey "+q.Name]
+	case dns.TypeDS:
+		resp.Answer = z.ds[q.Name]
+		if len(resp.Answer) == 0 {
+			resp.Ns = z.nsec[q.Name]
+		}
+	case dns.TypeA:
+		resp.Answer = z.answers[q.Name]
+	}
+
+	return resp, time.Millisecond, nil
+}
@@ -0,0 +1,442 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	dnssecSecure   = "secure"
+	dnssecInsecure = "insecure"
+	dnssecBogus    = "bogus"
+)
+
+const (
+	// maxZoneKeysCacheTime limits how long validated zone keys are reused.
+	maxZoneKeysCacheTime = time.Hour
+	// maxChainDepth limits the number of zones walked while building the chain of trust.
+	maxChainDepth = 16
+	// nsec3OptOut is the NSEC3 opt-out flag (RFC 5155).
+	nsec3OptOut = 1
+)
+
+// rootTrustAnchors are the DS records of the root zone KSKs.
+// https://data.iana.org/root-anchors/root-anchors.xml
+var rootTrustAnchors = []*dns.DS{
+	{
+		Hdr:        dns.RR_Header{Name: ".", Rrtype: dns.TypeDS, Class: dns.ClassINET},
+		KeyTag:     20326,
+		Algorithm:  dns.RSASHA256,
+		DigestType: dns.SHA256,
+		Digest:     "E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+	},
+	{
+		Hdr:        dns.RR_Header{Name: ".", Rrtype: dns.TypeDS, Class: dns.ClassINET},
+		KeyTag:     38696,
+		Algorithm:  dns.RSASHA256,
+		DigestType: dns.SHA256,
+		Digest:     "683D2D0ACB8C9B712A1948B27F741219298D0A450D612C483AF444A4C0FB2B16",
+	},
+}
+
+var (
+	errInsecureDelegation = errors.New("insecure delegation")
+	errNotZoneCut         = errors.New("not a zone cut")
+)
+
+type (
+	// dnssecValidator validates answers by building the chain of trust from the root trust anchors.
+	// DNSKEY and DS records are queried from the same server as the answer, so the server must be a recursive resolver.
+	dnssecValidator struct {
+		trustAnchors []*dns.DS
+		now          func() time.Time
+
+		mu       sync.Mutex
+		zoneKeys map[string]*cachedZoneKeys
+	}
+	cachedZoneKeys struct {
+		keys    []*dns.DNSKEY
+		err     error
+		expires time.Time
+	}
+
+	dnssecResult struct {
+		status          string
+		rrsigExpiration time.Time
+		err             error
+	}
+)
+
+func newDNSSECValidator() *dnssecValidator {
+	return &dnssecValidator{
+		trustAnchors: rootTrustAnchors,
+		now:          time.Now,
+		zoneKeys:     make(map[string]*cachedZoneKeys),
+	}
+}
+
+func (v *dnssecValidator) validate(client dnsClient, address string, resp *dns.Msg) dnssecResult {
+	rrsets, sigs := splitRRsets(resp.Answer)
+	if len(rrsets) == 0 {
+		return dnssecResult{}
+	}
+
+	res := dnssecResult{status: dnssecSecure}
+
+	for key, rrset := range rrsets {
+		rrsetSigs := sigs[key]
+		if len(rrsetSigs) == 0 {
+			// unsigned data is insecure only if it is below a provably unsigned delegation
+			// (e.g. a CNAME pointing to an unsigned zone), otherwise the signatures were stripped
+			if err := v.proveInsecure(client, address, key.name); err != nil {
+				return dnssecResult{status: dnssecBogus, err: fmt.Errorf("%s %s: unsigned: %v", key.name, dns.TypeToString[key.rtype], err)}
+			}
+			res.status = dnssecInsecure
+			continue
+		}
+
+		exp, err := v.verifyRRset(client, address, rrset, rrsetSigs, 0)
+		if errors.Is(err, errInsecureDelegation) {
+			res.status = dnssecInsecure
+			continue
+		}
+		if err != nil {
+			return dnssecResult{status: dnssecBogus, err: fmt.Errorf("%s %s: %v", key.name, dns.TypeToString[key.rtype], err)}
+		}
+		if res.rrsigExpiration.IsZero() || exp.Before(res.rrsigExpiration) {
+			res.rrsigExpiration = exp
+		}
+	}
+
+	return res
+}
+
+// verifyRRset verifies the RRset using any of its valid signatures and returns the signature expiration time.
+func (v *dnssecValidator) verifyRRset(client dnsClient, address string, rrset []dns.RR, sigs []*dns.RRSIG, depth int) (time.Time, error) {
+	if depth > maxChainDepth {
+		return time.Time{}, errors.New("chain of trust is too long")
+	}
+
+	owner := rrset[0].Header().Name
+	now := v.now()
+	var lastErr error
+
+	for _, sig := range sigs {
+		if !dns.IsSubDomain(sig.SignerName, owner) {
+			lastErr = fmt.Errorf("signer '%s' is not an ancestor of '%s'", sig.SignerName, owner)
+			continue
+		}
+		if rrset[0].Header().Rrtype == dns.TypeDS && dns.CanonicalName(sig.SignerName) == dns.CanonicalName(owner) {
+			lastErr = errors.New("DS RRset is signed by the child zone")
+			continue
+		}
+		if !sig.ValidityPeriod(now) {
+			lastErr = fmt.Errorf("signature (key tag %d) is outside its validity period", sig.KeyTag)
+			continue
+		}
+
+		var keys []*dns.DNSKEY
+		var err error
+		if rrset[0].Header().Rrtype == dns.TypeDNSKEY && dns.CanonicalName(sig.SignerName) == dns.CanonicalName(owner) {
+			// DNSKEY RRsets are self-signed and verified while building the zone keys
+			keys = dnskeys(rrset)
+		} else if keys, err = v.getZoneKeys(client, address, sig.SignerName, depth+1); err != nil {
+			return time.Time{}, err
+		}
+
+		for _, key := range keys {
+			if key.KeyTag() != sig.KeyTag || key.Algorithm != sig.Algorithm {
+				continue
+			}
+			if err := sig.Verify(key, rrset); err != nil {
+				lastErr = fmt.Errorf("signature (key tag %d): %v", sig.KeyTag, err)
+				continue
+			}
+			return time.Unix(int64(sig.Expiration), 0), nil
+		}
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no DNSKEY matches signature key tag %d", sig.KeyTag)
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no signatures")
+	}
+	return time.Time{}, lastErr
+}
+
+func (v *dnssecValidator) getZoneKeys(client dnsClient, address, zone string, depth int) ([]*dns.DNSKEY, error) {
+	zone = dns.CanonicalName(zone)
+	cacheKey := address + "|" + zone
+
+	v.mu.Lock()
+	cached, ok := v.zoneKeys[cacheKey]
+	v.mu.Unlock()
+
+	if ok && v.now().Before(cached.expires) {
+		return cached.keys, cached.err
+	}
+
+	keys, expires, err := v.buildZoneKeys(client, address, zone, depth)
+	if err != nil && !errors.Is(err, errInsecureDelegation) && !errors.Is(err, errNotZoneCut) {
+		// retry on the next collection
+		expires = v.now()
+	}
+
+	v.mu.Lock()
+	v.zoneKeys[cacheKey] = &cachedZoneKeys{keys: keys, err: err, expires: expires}
+	v.mu.Unlock()
+
+	return keys, err
+}
+
+func (v *dnssecValidator) buildZoneKeys(client dnsClient, address, zone string, depth int) ([]*dns.DNSKEY, time.Time, error) {
+	var dsSet []*dns.DS
+	expires := v.now().Add(maxZoneKeysCacheTime)
+
+	if zone == "." {
+		dsSet = v.trustAnchors
+	} else {
+		resp, err := queryDNSSEC(client, address, zone, dns.TypeDS)
+		if err != nil {
+			return nil, expires, fmt.Errorf("query DS for '%s': %v", zone, err)
+		}
+		rrsets, sigs := splitRRsets(resp.Answer)
+		key := rrsetKey{name: zone, rtype: dns.TypeDS}
+		rrset := rrsets[key]
+		if len(rrset) == 0 {
+			delegation, err := v.verifyNoDS(client, address, zone, resp, depth)
+			if err != nil {
+				return nil, expires, fmt.Errorf("no DS for '%s': %w", zone, err)
+			}
+			if !delegation {
+				return nil, expires, errNotZoneCut
+			}
+			return nil, expires, errInsecureDelegation
+		}
+		exp, err := v.verifyRRset(client, address, rrset, sigs[key], depth)
+		if err != nil {
+			return nil, expires, fmt.Errorf("DS for '%s': %w", zone, err)
+		}
+		expires = minTime(expires, exp)
+		for _, rr := range rrset {
+			dsSet = append(dsSet, rr.(*dns.DS))
+		}
+	}
+
+	resp, err := queryDNSSEC(client, address, zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, expires, fmt.Errorf("query DNSKEY for '%s': %v", zone, err)
+	}
+	rrsets, sigs := splitRRsets(resp.Answer)
+	key := rrsetKey{name: zone, rtype: dns.TypeDNSKEY}
+	rrset := rrsets[key]
+	if len(rrset) == 0 {
+		return nil, expires, fmt.Errorf("no DNSKEY records for '%s'", zone)
+	}
+
+	// the DNSKEY RRset must be signed by a key referenced by the parent DS records
+	var trustedSigs []*dns.RRSIG
+	for _, sig := range sigs[key] {
+		for _, k := range dnskeys(rrset) {
+			if k.KeyTag() == sig.KeyTag && k.Algorithm == sig.Algorithm && matchesDS(k, dsSet) {
+				trustedSigs = append(trustedSigs, sig)
+				break
+			}
+		}
+	}
+	if len(trustedSigs) == 0 {
+		return nil, expires, fmt.Errorf("no DNSKEY for '%s' matches the DS records", zone)
+	}
+
+	exp, err := v.verifyRRset(client, address, rrset, trustedSigs, depth)
+	if err != nil {
+		return nil, expires, fmt.Errorf("DNSKEY for '%s': %v", zone, err)
+	}
+	expires = minTime(expires, exp)
+	expires = minTime(expires, v.now().Add(time.Duration(rrset[0].Header().Ttl)*time.Second))
+
+	return dnskeys(rrset), expires, nil
+}
+
+// proveInsecure walks the names from the top-level domain down to the name and succeeds
+// if one of them is a delegation proven to have no DS records.
+func (v *dnssecValidator) proveInsecure(client dnsClient, address, name string) error {
+	labels := dns.SplitDomainName(dns.CanonicalName(name))
+
+	for i := len(labels) - 1; i >= 0; i-- {
+		zone := dns.Fqdn(strings.Join(labels[i:], "."))
+
+		_, err := v.getZoneKeys(client, address, zone, 1)
+		switch {
+		case err == nil, errors.Is(err, errNotZoneCut):
+			continue
+		case errors.Is(err, errInsecureDelegation):
+			return nil
+		default:
+			return err
+		}
+	}
+
+	return errors.New("the zone is signed")
+}
+
+// verifyNoDS verifies the NSEC or NSEC3 records of a DS query response without DS records.
+// It returns whether the name is a delegation (an insecure one, as it has no DS records).
+// A covering NSEC3 record with the opt-out flag is accepted as a proof of an insecure delegation,
+// while a covering NSEC or NSEC3 record without it proves the name is not a delegation.
+func (v *dnssecValidator) verifyNoDS(client dnsClient, address, name string, resp *dns.Msg, depth int) (bool, error) {
+	rrsets, sigs := splitRRsets(resp.Ns)
+
+	for key, rrset := range rrsets {
+		var types []uint16
+		var optOut bool
+
+		switch rr := rrset[0].(type) {
+		case *dns.NSEC:
+			switch {
+			case key.name == name:
+				types = rr.TypeBitMap
+			case nsecCovers(rr, name):
+				// the name does not exist (or is an empty non-terminal), so it is not a delegation
+			default:
+				continue
+			}
+		case *dns.NSEC3:
+			switch {
+			case rr.Match(name):
+				types = rr.TypeBitMap
+			case rr.Cover(name):
+				optOut = rr.Flags&nsec3OptOut != 0
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+
+		// the proof must come from the parent zone, not from the apex of the child zone
+		rrsetSigs := slices.DeleteFunc(slices.Clone(sigs[key]), func(sig *dns.RRSIG) bool {
+			return dns.CanonicalName(sig.SignerName) == name
+		})
+		if len(rrsetSigs) == 0 {
+			return false, fmt.Errorf("%s record is not signed by the parent zone", dns.TypeToString[key.rtype])
+		}
+		if _, err := v.verifyRRset(client, address, rrset, rrsetSigs, depth); err != nil {
+			return false, fmt.Errorf("%s record: %w", dns.TypeToString[key.rtype], err)
+		}
+
+		if optOut {
+			return true, nil
+		}
+		if slices.Contains(types, dns.TypeDS) {
+			return false, fmt.Errorf("%s record lists the DS type", dns.TypeToString[key.rtype])
+		}
+		return slices.Contains(types, dns.TypeNS) && !slices.Contains(types, dns.TypeSOA), nil
+	}
+
+	return false, errors.New("no NSEC or NSEC3 record proves that DS records do not exist")
+}
+
+// nsecCovers reports whether the name falls between the owner and the next name of the NSEC record.
+func nsecCovers(nsec *dns.NSEC, name string) bool {
+	owner, next := nsec.Hdr.Name, nsec.NextDomain
+	if compareCanonical(owner, name) >= 0 {
+		return false
+	}
+	// the last NSEC record of a zone points back to the apex
+	return compareCanonical(name, next) < 0 || compareCanonical(next, owner) <= 0
+}
+
+// compareCanonical compares the names in the canonical DNS name order (RFC 4034, section 6.1).
+func compareCanonical(a, b string) int {
+	la := dns.SplitDomainName(strings.ToLower(a))
+	lb := dns.SplitDomainName(strings.ToLower(b))
+
+	for i := 1; i <= min(len(la), len(lb)); i++ {
+		if c := strings.Compare(la[len(la)-i], lb[len(lb)-i]); c != 0 {
+			return c
+		}
+	}
+	return len(la) - len(lb)
+}
+
+func queryDNSSEC(client dnsClient, address, name string, qtype uint16) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+	msg.SetEdns0(4096, true)
+	// we validate ourselves, so get the data even if the resolver considers it bogus
+	msg.CheckingDisabled = true
+
+	resp, _, err := client.Exchange(msg, address)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("empty response")
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("rcode %s", dns.RcodeToString[resp.Rcode])
+	}
+	return resp, nil
+}
+
+type rrsetKey struct {
+	name  string
+	rtype uint16
+}
+
+func splitRRsets(rrs []dns.RR) (map[rrsetKey][]dns.RR, map[rrsetKey][]*dns.RRSIG) {
+	rrsets := make(map[rrsetKey][]dns.RR)
+	sigs := make(map[rrsetKey][]*dns.RRSIG)
+
+	for _, rr := range rrs {
+		name := dns.CanonicalName(rr.Header().Name)
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			key := rrsetKey{name: name, rtype: sig.TypeCovered}
+			sigs[key] = append(sigs[key], sig)
+		} else {
+			key := rrsetKey{name: name, rtype: rr.Header().Rrtype}
+			rrsets[key] = append(rrsets[key], rr)
+		}
+	}
+
+	return rrsets, sigs
+}
+
+func dnskeys(rrset []dns.RR) []*dns.DNSKEY {
+	var keys []*dns.DNSKEY
+	for _, rr := range rrset {
+		if k, ok := rr.(*dns.DNSKEY); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func matchesDS(key *dns.DNSKEY, dsSet []*dns.DS) bool {
+	for _, ds := range dsSet {
+		if ds.KeyTag != key.KeyTag() || ds.Algorithm != key.Algorithm {
+			continue
+		}
+		if v := key.ToDS(ds.DigestType); v != nil && strings.EqualFold(v.Digest, ds.Digest) {
+			return true
+		}
+	}
+	return false
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
@@ -5,6 +5,10 @@ package dnsquery
 import (
 	"errors"
 	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 
@@ -16,7 +20,7 @@ func (c *Collector) verifyConfig() error {
 		return errors.New("no domains specified")
 	}
 
-	if !(c.Network == "" || c.Network == "udp" || c.Network == "tcp" || c.Network == "tcp-tls") {
+	if !(c.Network == "" || c.Network == networkUDP || c.Network == networkTCP || c.Network == networkTLS || c.Network == networkHTTPS) {
 		return fmt.Errorf("wrong network transport : %s", c.Network)
 	}
 
@@ -64,15 +68,104 @@ func (c *Collector) initRecordTypes() (map[string]uint16, error) {
 	return types, nil
 }
 
+func (c *Collector) initServerAddresses() map[string]string {
+	addrs := make(map[string]string)
+
+	port := c.Port
+	if port == 0 {
+		port = dnsDefaultPort
+		if c.Network == networkTLS {
+			port = dotDefaultPort
+		}
+	}
+
+	for _, srv := range c.Servers {
+		switch {
+		case c.Network != networkHTTPS:
+			addrs[srv] = net.JoinHostPort(srv, strconv.Itoa(port))
+		case strings.HasPrefix(srv, "https://"):
+			addrs[srv] = srv
+		default:
+			addrs[srv] = "https://" + srv + dohDefaultPath
+		}
+	}
+
+	return addrs
+}
+
+func (c *Collector) initAssertions() (map[assertionKey]*assertion, error) {
+	assertions := make(map[assertionKey]*assertion)
+
+	for i, cfg := range c.Assertions {
+		if !slices.Contains(c.Domains, cfg.Domain) {
+			return nil, fmt.Errorf("assertion %d: domain '%s' is not in 'domains'", i+1, cfg.Domain)
+		}
+		if _, ok := c.recordTypes[cfg.RecordType]; !ok {
+			return nil, fmt.Errorf("assertion %d: record type '%s' is not in 'record_types'", i+1, cfg.RecordType)
+		}
+		if len(cfg.Values) == 0 && cfg.MinTTL <= 0 {
+			return nil, fmt.Errorf("assertion %d: neither 'values' nor 'min_ttl' is set", i+1)
+		}
+
+		key := assertionKey{domain: cfg.Domain, recordType: cfg.RecordType}
+		if _, ok := assertions[key]; ok {
+			return nil, fmt.Errorf("assertion %d: duplicate assertion for '%s' %s", i+1, cfg.Domain, cfg.RecordType)
+		}
+
+		a := &assertion{minTTL: uint32(max(cfg.MinTTL, 0))}
+		if len(cfg.Values) > 0 {
+			a.values = make(map[string]bool)
+			for _, v := range cfg.Values {
+				a.values[normalizeRecordValue(v)] = true
+			}
+		}
+		assertions[key] = a
+	}
+
+	return assertions, nil
+}
+
+// initCheckedDomains returns the domains queried every collection, per record type:
+// all domains if DNSSEC validation is enabled, otherwise the domains with assertions.
+func (c *Collector) initCheckedDomains() map[string][]string {
+	checked := make(map[string][]string)
+
+	for rtype := range c.recordTypes {
+		for _, domain := range c.Domains {
+			_, ok := c.assertions[assertionKey{domain: domain, recordType: rtype}]
+			if (c.DNSSEC || ok) && !slices.Contains(checked[rtype], domain) {
+				checked[rtype] = append(checked[rtype], domain)
+			}
+		}
+	}
+
+	return checked
+}
+
 func (c *Collector) initCharts() (*module.Charts, error) {
 	charts := module.Charts{}
 
 	for _, srv := range c.Servers {
 		for _, rtype := range c.RecordTypes {
-			cs := newDNSServerCharts(srv, c.Network, rtype)
-			if err := charts.Add(*cs...); err != nil {
+			if err := charts.Add(*newDNSServerCharts(srv, c.Network, rtype)...); err != nil {
 				return nil, err
 			}
+
+			for _, domain := range c.checkedDomains[rtype] {
+				cs := newDNSDomainCharts(srv, c.Network, rtype, domain)
+
+				if !c.DNSSEC {
+					_ = cs.Remove(fmt.Sprintf(dnsDNSSECStatusChartTmpl.ID, serverChartID(srv), rtype, domainChartID(domain)))
+					_ = cs.Remove(fmt.Sprintf(dnsRRSIGTimeUntilExpirationChartTmpl.ID, serverChartID(srv), rtype, domainChartID(domain)))
+				}
+				if _, ok := c.assertions[assertionKey{domain: domain, recordType: rtype}]; !ok {
+					_ = cs.Remove(fmt.Sprintf(dnsAssertionStatusChartTmpl.ID, serverChartID(srv), rtype, domainChartID(domain)))
+				}
+
+				if err := charts.Add(*cs...); err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
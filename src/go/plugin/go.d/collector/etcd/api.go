@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	urlPathMetrics           = "/metrics"
+	urlPathHealth            = "/health"
+	urlPathMaintenanceStatus = "/v3/maintenance/status"
+	urlPathMemberList        = "/v3/cluster/member/list"
+)
+
+// The v3 JSON gateway (grpc-gateway) encodes 64-bit integers as strings and omits zero values.
+
+type (
+	responseHeader struct {
+		ClusterID uint64Str `json:"cluster_id"`
+		MemberID  uint64Str `json:"member_id"`
+		RaftTerm  uint64Str `json:"raft_term"`
+	}
+	statusResponse struct {
+		Header           responseHeader `json:"header"`
+		Version          string         `json:"version"`
+		DBSize           uint64Str      `json:"dbSize"`
+		DBSizeInUse      uint64Str      `json:"dbSizeInUse"`
+		Leader           uint64Str      `json:"leader"`
+		RaftIndex        uint64Str      `json:"raftIndex"`
+		RaftTerm         uint64Str      `json:"raftTerm"`
+		RaftAppliedIndex uint64Str      `json:"raftAppliedIndex"`
+		Errors           []string       `json:"errors"`
+		IsLearner        bool           `json:"isLearner"`
+	}
+	memberListResponse struct {
+		Header  responseHeader `json:"header"`
+		Members []struct {
+			ID         uint64Str `json:"ID"`
+			Name       string    `json:"name"`
+			PeerURLs   []string  `json:"peerURLs"`
+			ClientURLs []string  `json:"clientURLs"`
+			IsLearner  bool      `json:"isLearner"`
+		} `json:"members"`
+	}
+	healthResponse struct {
+		Health string `json:"health"`
+		Reason string `json:"reason"`
+	}
+)
+
+type uint64Str uint64
+
+func (v *uint64Str) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*v = 0
+		return nil
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*v = uint64Str(n)
+	return nil
+}
+
+// memberID formats a member ID the same way etcdctl does.
+func memberID(id uint64Str) string {
+	return strconv.FormatUint(uint64(id), 16)
+}
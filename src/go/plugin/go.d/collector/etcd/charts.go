@@ -0,0 +1,378 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus"
+)
+
+const (
+	prioClusterQuorumStatus = module.Priority + iota
+	prioClusterFailureTolerance
+	prioClusterMembers
+	prioClusterVotingMembersHealth
+
+	prioServerLeaderStatus
+	prioServerLeaderChanges
+
+	prioRaftProposals
+	prioRaftProposalsApplyLag
+	prioRaftProposalsPending
+	prioRaftProposalsFailed
+
+	prioDBSize
+	prioDBQuotaUtilization
+
+	prioWALFsyncDuration
+	prioBackendCommitDuration
+
+	prioServerSlowOperations
+
+	prioMemberHealthStatus
+	prioMemberRole
+	prioMemberDBSize
+	prioMemberRaftAppliedIndexLag
+)
+
+var serverCharts = module.Charts{
+	serverLeaderStatusChart.Copy(),
+	serverLeaderChangesChart.Copy(),
+
+	raftProposalsChart.Copy(),
+	raftProposalsApplyLagChart.Copy(),
+	raftProposalsPendingChart.Copy(),
+	raftProposalsFailedChart.Copy(),
+
+	dbSizeChart.Copy(),
+	dbQuotaUtilizationChart.Copy(),
+
+	serverSlowOperationsChart.Copy(),
+}
+
+var clusterCharts = module.Charts{
+	clusterQuorumStatusChart.Copy(),
+	clusterFailureToleranceChart.Copy(),
+	clusterMembersChart.Copy(),
+}
+
+var (
+	clusterQuorumStatusChart = module.Chart{
+		ID:       "cluster_quorum_status",
+		Title:    "Cluster quorum status",
+		Units:    "status",
+		Fam:      "cluster",
+		Ctx:      "etcd.cluster_quorum_status",
+		Priority: prioClusterQuorumStatus,
+		Dims: module.Dims{
+			{ID: "cluster_quorum_ok", Name: "ok"},
+			{ID: "cluster_quorum_lost", Name: "lost"},
+		},
+	}
+	clusterFailureToleranceChart = module.Chart{
+		ID:       "cluster_failure_tolerance",
+		Title:    "Cluster failure tolerance",
+		Units:    "members",
+		Fam:      "cluster",
+		Ctx:      "etcd.cluster_failure_tolerance",
+		Priority: prioClusterFailureTolerance,
+		Dims: module.Dims{
+			{ID: "cluster_failure_tolerance", Name: "failure_tolerance"},
+		},
+	}
+	clusterMembersChart = module.Chart{
+		ID:       "cluster_members",
+		Title:    "Cluster members",
+		Units:    "members",
+		Fam:      "cluster",
+		Ctx:      "etcd.cluster_members",
+		Priority: prioClusterMembers,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "cluster_members_voting", Name: "voting"},
+			{ID: "cluster_members_learner", Name: "learner"},
+		},
+	}
+	clusterVotingMembersHealthChart = module.Chart{
+		ID:       "cluster_voting_members_health",
+		Title:    "Cluster voting members health",
+		Units:    "members",
+		Fam:      "cluster",
+		Ctx:      "etcd.cluster_voting_members_health",
+		Priority: prioClusterVotingMembersHealth,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "cluster_voting_members_healthy", Name: "healthy"},
+			{ID: "cluster_voting_members_unhealthy", Name: "unhealthy"},
+		},
+	}
+)
+
+var (
+	serverLeaderStatusChart = module.Chart{
+		ID:       "server_leader_status",
+		Title:    "Server leader status",
+		Units:    "status",
+		Fam:      "leader",
+		Ctx:      "etcd.server_leader_status",
+		Priority: prioServerLeaderStatus,
+		Dims: module.Dims{
+			{ID: "server_has_leader", Name: "has_leader"},
+			{ID: "server_is_leader", Name: "is_leader"},
+		},
+	}
+	serverLeaderChangesChart = module.Chart{
+		ID:       "server_leader_changes",
+		Title:    "Server leader changes",
+		Units:    "changes/s",
+		Fam:      "leader",
+		Ctx:      "etcd.server_leader_changes",
+		Priority: prioServerLeaderChanges,
+		Dims: module.Dims{
+			{ID: "server_leader_changes_seen", Name: "changes", Algo: module.Incremental},
+		},
+	}
+)
+
+var (
+	raftProposalsChart = module.Chart{
+		ID:       "raft_proposals",
+		Title:    "Raft proposals",
+		Units:    "proposals/s",
+		Fam:      "raft",
+		Ctx:      "etcd.raft_proposals",
+		Priority: prioRaftProposals,
+		Dims: module.Dims{
+			{ID: "proposals_committed", Name: "committed", Algo: module.Incremental},
+			{ID: "proposals_applied", Name: "applied", Algo: module.Incremental},
+		},
+	}
+	raftProposalsApplyLagChart = module.Chart{
+		ID:       "raft_proposals_apply_lag",
+		Title:    "Raft proposals committed but not yet applied",
+		Units:    "proposals",
+		Fam:      "raft",
+		Ctx:      "etcd.raft_proposals_apply_lag",
+		Priority: prioRaftProposalsApplyLag,
+		Dims: module.Dims{
+			{ID: "proposals_apply_lag", Name: "lag"},
+		},
+	}
+	raftProposalsPendingChart = module.Chart{
+		ID:       "raft_proposals_pending",
+		Title:    "Raft pending proposals",
+		Units:    "proposals",
+		Fam:      "raft",
+		Ctx:      "etcd.raft_proposals_pending",
+		Priority: prioRaftProposalsPending,
+		Dims: module.Dims{
+			{ID: "proposals_pending", Name: "pending"},
+		},
+	}
+	raftProposalsFailedChart = module.Chart{
+		ID:       "raft_proposals_failed",
+		Title:    "Raft failed proposals",
+		Units:    "proposals/s",
+		Fam:      "raft",
+		Ctx:      "etcd.raft_proposals_failed",
+		Priority: prioRaftProposalsFailed,
+		Dims: module.Dims{
+			{ID: "proposals_failed", Name: "failed", Algo: module.Incremental},
+		},
+	}
+)
+
+var (
+	dbSizeChart = module.Chart{
+		ID:       "db_size",
+		Title:    "Backend database size",
+		Units:    "bytes",
+		Fam:      "database",
+		Ctx:      "etcd.db_size",
+		Priority: prioDBSize,
+		Dims: module.Dims{
+			{ID: "db_total_size", Name: "allocated"},
+			{ID: "db_in_use_size", Name: "in_use"},
+			{ID: "db_quota", Name: "quota"},
+		},
+	}
+	dbQuotaUtilizationChart = module.Chart{
+		ID:       "db_quota_utilization",
+		Title:    "Backend database quota utilization",
+		Units:    "percentage",
+		Fam:      "database",
+		Ctx:      "etcd.db_quota_utilization",
+		Priority: prioDBQuotaUtilization,
+		Dims: module.Dims{
+			{ID: "db_quota_utilization", Name: "used", Div: precision},
+		},
+	}
+)
+
+var (
+	walFsyncDurationChartTmpl = module.Chart{
+		ID:       "wal_fsync_duration",
+		Title:    "WAL fsync latency distribution",
+		Units:    "observations/s",
+		Fam:      "disk",
+		Ctx:      "etcd.wal_fsync_duration",
+		Priority: prioWALFsyncDuration,
+		Type:     module.Stacked,
+	}
+	backendCommitDurationChartTmpl = module.Chart{
+		ID:       "backend_commit_duration",
+		Title:    "Backend commit latency distribution",
+		Units:    "observations/s",
+		Fam:      "disk",
+		Ctx:      "etcd.backend_commit_duration",
+		Priority: prioBackendCommitDuration,
+		Type:     module.Stacked,
+	}
+)
+
+var serverSlowOperationsChart = module.Chart{
+	ID:       "server_slow_operations",
+	Title:    "Server slow operations",
+	Units:    "operations/s",
+	Fam:      "server",
+	Ctx:      "etcd.server_slow_operations",
+	Priority: prioServerSlowOperations,
+	Dims: module.Dims{
+		{ID: "slow_applies", Name: "slow_applies", Algo: module.Incremental},
+		{ID: "slow_read_indexes", Name: "slow_read_indexes", Algo: module.Incremental},
+		{ID: "heartbeat_send_failures", Name: "heartbeat_send_failures", Algo: module.Incremental},
+	},
+}
+
+var memberChartsTmpl = module.Charts{
+	memberHealthStatusChartTmpl.Copy(),
+	memberRoleChartTmpl.Copy(),
+}
+
+var memberStatusChartsTmpl = module.Charts{
+	memberDBSizeChartTmpl.Copy(),
+	memberRaftAppliedIndexLagChartTmpl.Copy(),
+}
+
+var (
+	memberHealthStatusChartTmpl = module.Chart{
+		ID:       "member_%s_health_status",
+		Title:    "Member health status",
+		Units:    "status",
+		Fam:      "members",
+		Ctx:      "etcd.member_health_status",
+		Priority: prioMemberHealthStatus,
+		Dims: module.Dims{
+			{ID: "member_%s_health_status_healthy", Name: "healthy"},
+			{ID: "member_%s_health_status_unhealthy", Name: "unhealthy"},
+		},
+	}
+	memberRoleChartTmpl = module.Chart{
+		ID:       "member_%s_role",
+		Title:    "Member role",
+		Units:    "status",
+		Fam:      "members",
+		Ctx:      "etcd.member_role",
+		Priority: prioMemberRole,
+		Dims: module.Dims{
+			{ID: "member_%s_role_leader", Name: "leader"},
+			{ID: "member_%s_role_follower", Name: "follower"},
+			{ID: "member_%s_role_learner", Name: "learner"},
+		},
+	}
+	memberDBSizeChartTmpl = module.Chart{
+		ID:       "member_%s_db_size",
+		Title:    "Member backend database size",
+		Units:    "bytes",
+		Fam:      "members",
+		Ctx:      "etcd.member_db_size",
+		Priority: prioMemberDBSize,
+		Dims: module.Dims{
+			{ID: "member_%s_db_total_size", Name: "allocated"},
+			{ID: "member_%s_db_in_use_size", Name: "in_use"},
+		},
+	}
+	memberRaftAppliedIndexLagChartTmpl = module.Chart{
+		ID:       "member_%s_raft_applied_index_lag",
+		Title:    "Member raft applied index lag",
+		Units:    "entries",
+		Fam:      "members",
+		Ctx:      "etcd.member_raft_applied_index_lag",
+		Priority: prioMemberRaftAppliedIndexLag,
+		Dims: module.Dims{
+			{ID: "member_%s_raft_applied_index_lag", Name: "lag"},
+		},
+	}
+)
+
+func (c *Collector) addClusterCharts() {
+	charts := clusterCharts.Copy()
+	if c.CollectMembersHealth {
+		_ = charts.Add(clusterVotingMembersHealthChart.Copy())
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) addHistogramChart(tmpl module.Chart, name string, buckets []prometheus.Bucket) {
+	chart := tmpl.Copy()
+
+	for _, b := range buckets {
+		le := formatBucket(b.UpperBound())
+		chart.Dims = append(chart.Dims, &module.Dim{
+			ID:   fmt.Sprintf("%s_bucket_%s", name, le),
+			Name: le,
+			Algo: module.Incremental,
+		})
+	}
+
+	if err := c.Charts().Add(chart); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) addMemberCharts(m *memberInfo) {
+	c.addMemberChartsFromTmpl(m, memberChartsTmpl)
+}
+
+func (c *Collector) addMemberStatusCharts(m *memberInfo) {
+	c.addMemberChartsFromTmpl(m, memberStatusChartsTmpl)
+}
+
+func (c *Collector) addMemberChartsFromTmpl(m *memberInfo, tmpl module.Charts) {
+	charts := tmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, m.id)
+		chart.Labels = []module.Label{
+			{Key: "member_id", Value: m.id},
+			{Key: "member_name", Value: m.name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, m.id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeMemberCharts(m *memberInfo) {
+	for _, tmpl := range append(memberChartsTmpl, memberStatusChartsTmpl...) {
+		id := fmt.Sprintf(tmpl.ID, m.id)
+		if chart := c.Charts().Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func formatBucket(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+const precision = 1000
+
+func (c *Collector) collect() (map[string]int64, error) {
+	mfs, err := c.prom.Scrape()
+	if err != nil {
+		return nil, err
+	}
+
+	mx := make(map[string]int64)
+
+	c.collectServerMetrics(mx, mfs)
+
+	if err := c.collectCluster(mx); err != nil {
+		c.Warning(err)
+	}
+
+	return mx, nil
+}
+
+func (c *Collector) queryStatus(baseURL string) (*statusResponse, error) {
+	req, err := c.createAPIRequest(baseURL, urlPathMaintenanceStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp statusResponse
+	if err := web.DoHTTP(c.httpClient).RequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Collector) queryMemberList() (*memberListResponse, error) {
+	req, err := c.createAPIRequest(c.URL, urlPathMemberList)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp memberListResponse
+	if err := web.DoHTTP(c.httpClient).RequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Collector) queryHealth(baseURL string) (*healthResponse, error) {
+	cfg := c.RequestConfig.Copy()
+	cfg.URL = baseURL
+	cfg.Method = ""
+	cfg.Body = ""
+
+	req, err := web.NewHTTPRequestWithPath(cfg, urlPathHealth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create '%s' request: %w", urlPathHealth, err)
+	}
+
+	var resp healthResponse
+	// an unhealthy member responds with 503 and still reports the reason in the body
+	client := web.DoHTTP(c.httpClient).OnNokCode(func(resp *http.Response) (bool, error) {
+		return resp.StatusCode == http.StatusServiceUnavailable, nil
+	})
+	if err := client.RequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Collector) createAPIRequest(baseURL, urlPath string) (*http.Request, error) {
+	cfg := c.RequestConfig.Copy()
+	cfg.URL = baseURL
+	cfg.Method = http.MethodPost
+	cfg.Body = "{}"
+
+	req, err := web.NewHTTPRequestWithPath(cfg, urlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create '%s' request: %w", urlPath, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+)
+
+type memberInfo struct {
+	id        string
+	name      string
+	clientURL string
+	isLearner bool
+	seen      bool
+	hasCharts bool
+	// status charts are created on the first successful status query, an unreachable member has none
+	hasStatusCharts bool
+}
+
+func (c *Collector) collectCluster(mx map[string]int64) error {
+	status, err := c.queryStatus(c.URL)
+	if err != nil {
+		return fmt.Errorf("failed to query maintenance status: %v", err)
+	}
+
+	members, err := c.queryMemberList()
+	if err != nil {
+		return fmt.Errorf("failed to query member list: %v", err)
+	}
+
+	localID := memberID(status.Header.MemberID)
+	leaderID := memberID(status.Leader)
+	hasLeader := status.Leader != 0
+
+	for _, m := range c.members {
+		m.seen = false
+	}
+
+	var voting, learners int64
+	for _, v := range members.Members {
+		id := memberID(v.ID)
+		m, ok := c.members[id]
+		if !ok {
+			m = &memberInfo{id: id}
+			c.members[id] = m
+		}
+		m.seen = true
+		m.name = v.Name
+		m.isLearner = v.IsLearner
+		m.clientURL = ""
+		if id == localID {
+			m.clientURL = c.URL
+		} else if len(v.ClientURLs) > 0 {
+			m.clientURL = v.ClientURLs[0]
+		}
+
+		if v.IsLearner {
+			learners++
+		} else {
+			voting++
+		}
+	}
+
+	c.updateMembers(c.CollectMembersHealth)
+
+	quorum := voting/2 + 1
+
+	if !c.clusterChartsAdded {
+		c.clusterChartsAdded = true
+		c.addClusterCharts()
+	}
+
+	mx["cluster_members_voting"] = voting
+	mx["cluster_members_learner"] = learners
+
+	if !c.CollectMembersHealth {
+		quorumOK := hasLeader
+		mx["cluster_quorum_ok"] = metrix.Bool(quorumOK)
+		mx["cluster_quorum_lost"] = metrix.Bool(!quorumOK)
+		mx["cluster_failure_tolerance"] = 0
+		if quorumOK {
+			mx["cluster_failure_tolerance"] = max(0, voting-quorum)
+		}
+		return nil
+	}
+
+	statuses := map[string]*statusResponse{localID: status}
+	var maxRaftIndex uint64
+	var healthyVoting int64
+
+	for _, m := range c.members {
+		px := fmt.Sprintf("member_%s_", m.id)
+
+		healthy := c.isMemberHealthy(m)
+		mx[px+"health_status_healthy"] = metrix.Bool(healthy)
+		mx[px+"health_status_unhealthy"] = metrix.Bool(!healthy)
+		if healthy && !m.isLearner {
+			healthyVoting++
+		}
+
+		mx[px+"role_leader"] = metrix.Bool(hasLeader && m.id == leaderID)
+		mx[px+"role_follower"] = metrix.Bool(!m.isLearner && (!hasLeader || m.id != leaderID))
+		mx[px+"role_learner"] = metrix.Bool(m.isLearner)
+
+		st, ok := statuses[m.id]
+		if !ok && m.clientURL != "" {
+			if st, err = c.queryStatus(m.clientURL); err != nil {
+				c.Debugf("failed to query member '%s' (%s) status: %v", m.name, m.id, err)
+			} else {
+				statuses[m.id] = st
+			}
+		}
+		if st != nil {
+			if !m.hasStatusCharts {
+				m.hasStatusCharts = true
+				c.addMemberStatusCharts(m)
+			}
+			mx[px+"db_total_size"] = int64(st.DBSize)
+			mx[px+"db_in_use_size"] = int64(st.DBSizeInUse)
+			maxRaftIndex = max(maxRaftIndex, uint64(st.RaftIndex))
+		}
+	}
+
+	for id, st := range statuses {
+		if st.RaftAppliedIndex > 0 {
+			mx[fmt.Sprintf("member_%s_raft_applied_index_lag", id)] = int64(maxRaftIndex - min(maxRaftIndex, uint64(st.RaftAppliedIndex)))
+		}
+	}
+
+	// etcd uses raft CheckQuorum: a leader steps down once it cannot reach a majority,
+	// so quorum is considered lost if there is no leader or not enough voting members are healthy.
+	quorumOK := hasLeader && healthyVoting >= quorum
+
+	mx["cluster_quorum_ok"] = metrix.Bool(quorumOK)
+	mx["cluster_quorum_lost"] = metrix.Bool(!quorumOK)
+	mx["cluster_failure_tolerance"] = max(0, healthyVoting-quorum)
+	mx["cluster_voting_members_healthy"] = healthyVoting
+	mx["cluster_voting_members_unhealthy"] = voting - healthyVoting
+
+	return nil
+}
+
+func (c *Collector) isMemberHealthy(m *memberInfo) bool {
+	if m.clientURL == "" {
+		return false
+	}
+
+	resp, err := c.queryHealth(m.clientURL)
+	if err != nil {
+		c.Debugf("failed to query member '%s' (%s) health: %v", m.name, m.id, err)
+		return false
+	}
+
+	return resp.Health == "true"
+}
+
+func (c *Collector) updateMembers(withCharts bool) {
+	maps.DeleteFunc(c.members, func(_ string, m *memberInfo) bool {
+		if !m.seen {
+			if m.hasCharts {
+				c.removeMemberCharts(m)
+			}
+			return true
+		}
+		if withCharts && !m.hasCharts {
+			m.hasCharts = true
+			c.addMemberCharts(m)
+		}
+		return false
+	})
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"fmt"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus"
+)
+
+const (
+	metricServerHasLeader             = "etcd_server_has_leader"
+	metricServerIsLeader              = "etcd_server_is_leader"
+	metricServerLeaderChangesSeen     = "etcd_server_leader_changes_seen_total"
+	metricServerProposalsCommitted    = "etcd_server_proposals_committed_total"
+	metricServerProposalsApplied      = "etcd_server_proposals_applied_total"
+	metricServerProposalsPending      = "etcd_server_proposals_pending"
+	metricServerProposalsFailed       = "etcd_server_proposals_failed_total"
+	metricServerQuotaBackendBytes     = "etcd_server_quota_backend_bytes"
+	metricServerSlowApply             = "etcd_server_slow_apply_total"
+	metricServerSlowReadIndexes       = "etcd_server_slow_read_indexes_total"
+	metricServerHeartbeatSendFailures = "etcd_server_heartbeat_send_failures_total"
+	metricMVCCDBTotalSize             = "etcd_mvcc_db_total_size_in_bytes"
+	metricMVCCDBTotalSizeInUse        = "etcd_mvcc_db_total_size_in_use_in_bytes"
+	metricDiskWALFsyncDuration        = "etcd_disk_wal_fsync_duration_seconds"
+	metricDiskBackendCommitDuration   = "etcd_disk_backend_commit_duration_seconds"
+)
+
+func (c *Collector) collectServerMetrics(mx map[string]int64, mfs prometheus.MetricFamilies) {
+	for key, name := range map[string]string{
+		"server_has_leader":          metricServerHasLeader,
+		"server_is_leader":           metricServerIsLeader,
+		"server_leader_changes_seen": metricServerLeaderChangesSeen,
+		"proposals_committed":        metricServerProposalsCommitted,
+		"proposals_applied":          metricServerProposalsApplied,
+		"proposals_pending":          metricServerProposalsPending,
+		"proposals_failed":           metricServerProposalsFailed,
+		"db_quota":                   metricServerQuotaBackendBytes,
+		"db_total_size":              metricMVCCDBTotalSize,
+		"db_in_use_size":             metricMVCCDBTotalSizeInUse,
+		"slow_applies":               metricServerSlowApply,
+		"slow_read_indexes":          metricServerSlowReadIndexes,
+		"heartbeat_send_failures":    metricServerHeartbeatSendFailures,
+	} {
+		if v, ok := metricValue(mfs, name); ok {
+			mx[key] = int64(v)
+		}
+	}
+
+	if committed, ok := mx["proposals_committed"]; ok {
+		if applied, ok := mx["proposals_applied"]; ok {
+			mx["proposals_apply_lag"] = max(0, committed-applied)
+		}
+	}
+	if quota := mx["db_quota"]; quota > 0 {
+		mx["db_quota_utilization"] = int64(float64(mx["db_total_size"]) / float64(quota) * 100 * precision)
+	}
+
+	c.collectHistogram(mx, mfs, metricDiskWALFsyncDuration, "wal_fsync_duration", walFsyncDurationChartTmpl)
+	c.collectHistogram(mx, mfs, metricDiskBackendCommitDuration, "backend_commit_duration", backendCommitDurationChartTmpl)
+}
+
+func (c *Collector) collectHistogram(mx map[string]int64, mfs prometheus.MetricFamilies, metric, name string, tmpl module.Chart) {
+	mf := mfs.GetHistogram(metric)
+	if mf == nil || len(mf.Metrics()) == 0 {
+		return
+	}
+
+	buckets := mf.Metrics()[0].Histogram().Buckets()
+
+	if !c.seenHists[name] {
+		c.seenHists[name] = true
+		c.addHistogramChart(tmpl, name, buckets)
+	}
+
+	// buckets are cumulative; charting each bucket separately shows the actual latency distribution
+	var prev float64
+	for _, b := range buckets {
+		mx[fmt.Sprintf("%s_bucket_%s", name, formatBucket(b.UpperBound()))] = int64(b.CumulativeCount() - prev)
+		prev = b.CumulativeCount()
+	}
+}
+
+func metricValue(mfs prometheus.MetricFamilies, name string) (float64, bool) {
+	mf := mfs.Get(name)
+	if mf == nil || len(mf.Metrics()) == 0 {
+		return 0, false
+	}
+
+	m := mf.Metrics()[0]
+
+	switch mf.Type() {
+	case model.MetricTypeGauge:
+		return m.Gauge().Value(), true
+	case model.MetricTypeCounter:
+		return m.Counter().Value(), true
+	case model.MetricTypeUnknown:
+		return m.Untyped().Value(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+//go:embed "config_schema.json"
+var configSchema string
+
+func init() {
+	module.Register("etcd", module.Creator{
+		JobConfigSchema: configSchema,
+		Create:          func() module.Module { return New() },
+		Config:          func() any { return &Config{} },
+	})
+}
+
+func New() *Collector {
+	return &Collector{
+		Config: Config{
+			HTTPConfig: web.HTTPConfig{
+				RequestConfig: web.RequestConfig{
+					URL: "http://127.0.0.1:2379",
+				},
+				ClientConfig: web.ClientConfig{
+					Timeout: confopt.Duration(time.Second),
+				},
+			},
+			CollectMembersHealth: true,
+		},
+		charts:    serverCharts.Copy(),
+		seenHists: make(map[string]bool),
+		members:   make(map[string]*memberInfo),
+	}
+}
+
+type Config struct {
+	Vnode                string `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery          int    `yaml:"update_every,omitempty" json:"update_every"`
+	web.HTTPConfig       `yaml:",inline" json:""`
+	CollectMembersHealth bool `yaml:"collect_members_health" json:"collect_members_health"`
+}
+
+type Collector struct {
+	module.Base
+	Config `yaml:",inline" json:""`
+
+	charts             *module.Charts
+	clusterChartsAdded bool
+	seenHists          map[string]bool
+
+	httpClient *http.Client
+	prom       prometheus.Prometheus
+
+	members map[string]*memberInfo
+}
+
+func (c *Collector) Configuration() any {
+	return c.Config
+}
+
+func (c *Collector) Init(context.Context) error {
+	if err := c.validateConfig(); err != nil {
+		return fmt.Errorf("config validation: %v", err)
+	}
+
+	httpClient, err := web.NewHTTPClient(c.ClientConfig)
+	if err != nil {
+		return fmt.Errorf("init HTTP client: %v", err)
+	}
+	c.httpClient = httpClient
+
+	prom, err := c.initPrometheusClient(httpClient)
+	if err != nil {
+		return fmt.Errorf("init prometheus client: %v", err)
+	}
+	c.prom = prom
+
+	return nil
+}
+
+func (c *Collector) Check(context.Context) error {
+	mx, err := c.collect()
+	if err != nil {
+		return err
+	}
+
+	if len(mx) == 0 {
+		return errors.New("no metrics collected")
+	}
+
+	return nil
+}
+
+func (c *Collector) Charts() *module.Charts {
+	return c.charts
+}
+
+func (c *Collector) Collect(context.Context) map[string]int64 {
+	mx, err := c.collect()
+	if err != nil {
+		c.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+
+	return mx
+}
+
+func (c *Collector) Cleanup(context.Context) {
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
+}
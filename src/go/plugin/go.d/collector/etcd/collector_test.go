@@ -0,0 +1,533 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
+	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
+
+	dataVer3517Metrics, _    = os.ReadFile("testdata/v3.5.17/metrics.txt")
+	dataVer3517Status, _     = os.ReadFile("testdata/v3.5.17/status.json")
+	dataVer3517MemberList, _ = os.ReadFile("testdata/v3.5.17/member_list.json")
+)
+
+func Test_testDataIsValid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"dataConfigJSON":        dataConfigJSON,
+		"dataConfigYAML":        dataConfigYAML,
+		"dataVer3517Metrics":    dataVer3517Metrics,
+		"dataVer3517Status":     dataVer3517Status,
+		"dataVer3517MemberList": dataVer3517MemberList,
+	} {
+		require.NotNil(t, data, name)
+	}
+}
+
+func TestCollector_ConfigurationSerialize(t *testing.T) {
+	module.TestConfigurationSerialize(t, &Collector{}, dataConfigJSON, dataConfigYAML)
+}
+
+func TestCollector_Init(t *testing.T) {
+	tests := map[string]struct {
+		wantFail bool
+		config   Config
+	}{
+		"success with default": {
+			wantFail: false,
+			config:   New().Config,
+		},
+		"fail when URL not set": {
+			wantFail: true,
+			config: Config{
+				HTTPConfig: web.HTTPConfig{
+					RequestConfig: web.RequestConfig{URL: ""},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Config = test.config
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				assert.NoError(t, collr.Init(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestCollector_Cleanup(t *testing.T) {
+	assert.NotPanics(t, func() { New().Cleanup(context.Background()) })
+}
+
+func TestCollector_Check(t *testing.T) {
+	tests := map[string]struct {
+		wantFail bool
+		prepare  func(t *testing.T) (collr *Collector, cleanup func())
+	}{
+		"success on valid response": {
+			wantFail: false,
+			prepare:  caseOkCluster,
+		},
+		"fail on unexpected Prometheus": {
+			wantFail: true,
+			prepare:  caseUnexpectedPrometheusMetrics,
+		},
+		"fail on invalid data response": {
+			wantFail: true,
+			prepare:  caseInvalidDataResponse,
+		},
+		"fail on connection refused": {
+			wantFail: true,
+			prepare:  caseConnectionRefused,
+		},
+		"fail on 404 response": {
+			wantFail: true,
+			prepare:  case404,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr, cleanup := test.prepare(t)
+			defer cleanup()
+
+			if test.wantFail {
+				assert.Error(t, collr.Check(context.Background()))
+			} else {
+				assert.NoError(t, collr.Check(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	serverMetrics := map[string]int64{
+		"backend_commit_duration_bucket_+Inf":  0,
+		"backend_commit_duration_bucket_0.001": 5,
+		"backend_commit_duration_bucket_0.002": 1525,
+		"backend_commit_duration_bucket_0.004": 870,
+		"backend_commit_duration_bucket_0.008": 300,
+		"backend_commit_duration_bucket_0.016": 60,
+		"backend_commit_duration_bucket_0.032": 10,
+		"backend_commit_duration_bucket_0.064": 2,
+		"backend_commit_duration_bucket_0.128": 0,
+		"backend_commit_duration_bucket_0.256": 0,
+		"backend_commit_duration_bucket_0.512": 0,
+		"backend_commit_duration_bucket_1.024": 0,
+		"backend_commit_duration_bucket_2.048": 0,
+		"backend_commit_duration_bucket_4.096": 0,
+		"backend_commit_duration_bucket_8.192": 0,
+		"db_in_use_size":                       16777216,
+		"db_quota":                             2147483648,
+		"db_quota_utilization":                 1171,
+		"db_total_size":                        25165824,
+		"heartbeat_send_failures":              3,
+		"proposals_applied":                    48213,
+		"proposals_apply_lag":                  2,
+		"proposals_committed":                  48215,
+		"proposals_failed":                     1,
+		"proposals_pending":                    0,
+		"server_has_leader":                    1,
+		"server_is_leader":                     1,
+		"server_leader_changes_seen":           2,
+		"slow_applies":                         7,
+		"slow_read_indexes":                    0,
+		"wal_fsync_duration_bucket_+Inf":       0,
+		"wal_fsync_duration_bucket_0.001":      3100,
+		"wal_fsync_duration_bucket_0.002":      1100,
+		"wal_fsync_duration_bucket_0.004":      200,
+		"wal_fsync_duration_bucket_0.008":      50,
+		"wal_fsync_duration_bucket_0.016":      10,
+		"wal_fsync_duration_bucket_0.032":      1,
+		"wal_fsync_duration_bucket_0.064":      0,
+		"wal_fsync_duration_bucket_0.128":      0,
+		"wal_fsync_duration_bucket_0.256":      0,
+		"wal_fsync_duration_bucket_0.512":      0,
+		"wal_fsync_duration_bucket_1.024":      0,
+		"wal_fsync_duration_bucket_2.048":      0,
+		"wal_fsync_duration_bucket_4.096":      0,
+		"wal_fsync_duration_bucket_8.192":      0,
+	}
+	merge := func(ms ...map[string]int64) map[string]int64 {
+		mx := make(map[string]int64)
+		for _, m := range ms {
+			for k, v := range m {
+				mx[k] = v
+			}
+		}
+		return mx
+	}
+
+	tests := map[string]struct {
+		prepare         func(t *testing.T) (collr *Collector, cleanup func())
+		wantNumOfCharts int
+		wantMetrics     map[string]int64
+	}{
+		"success on healthy cluster": {
+			prepare:         caseOkCluster,
+			wantNumOfCharts: len(serverCharts) + len(clusterCharts) + 1 + 2 + len(memberChartsTmpl)*4 + len(memberStatusChartsTmpl)*3,
+			wantMetrics: merge(serverMetrics, map[string]int64{
+				"cluster_failure_tolerance":                       0,
+				"cluster_members_learner":                         1,
+				"cluster_members_voting":                          3,
+				"cluster_quorum_lost":                             0,
+				"cluster_quorum_ok":                               1,
+				"cluster_voting_members_healthy":                  2,
+				"cluster_voting_members_unhealthy":                1,
+				"member_2c7f8adc0a41354d_db_in_use_size":          16700000,
+				"member_2c7f8adc0a41354d_db_total_size":           25100000,
+				"member_2c7f8adc0a41354d_health_status_healthy":   1,
+				"member_2c7f8adc0a41354d_health_status_unhealthy": 0,
+				"member_2c7f8adc0a41354d_raft_applied_index_lag":  0,
+				"member_2c7f8adc0a41354d_role_follower":           1,
+				"member_2c7f8adc0a41354d_role_leader":             0,
+				"member_2c7f8adc0a41354d_role_learner":            0,
+				"member_8211f1d0f64f3269_db_in_use_size":          16000000,
+				"member_8211f1d0f64f3269_db_total_size":           25165824,
+				"member_8211f1d0f64f3269_health_status_healthy":   0,
+				"member_8211f1d0f64f3269_health_status_unhealthy": 1,
+				"member_8211f1d0f64f3269_raft_applied_index_lag":  215,
+				"member_8211f1d0f64f3269_role_follower":           1,
+				"member_8211f1d0f64f3269_role_leader":             0,
+				"member_8211f1d0f64f3269_role_learner":            0,
+				"member_8e9e05c52164694d_db_in_use_size":          16777216,
+				"member_8e9e05c52164694d_db_total_size":           25165824,
+				"member_8e9e05c52164694d_health_status_healthy":   1,
+				"member_8e9e05c52164694d_health_status_unhealthy": 0,
+				"member_8e9e05c52164694d_raft_applied_index_lag":  2,
+				"member_8e9e05c52164694d_role_follower":           0,
+				"member_8e9e05c52164694d_role_leader":             1,
+				"member_8e9e05c52164694d_role_learner":            0,
+				"member_ee77710dfbcd5095_health_status_healthy":   0,
+				"member_ee77710dfbcd5095_health_status_unhealthy": 1,
+				"member_ee77710dfbcd5095_role_follower":           0,
+				"member_ee77710dfbcd5095_role_leader":             0,
+				"member_ee77710dfbcd5095_role_learner":            1,
+			}),
+		},
+		"success on cluster without quorum": {
+			prepare:         caseClusterQuorumLost,
+			wantNumOfCharts: len(serverCharts) + len(clusterCharts) + 1 + 2 + len(memberChartsTmpl)*4 + len(memberStatusChartsTmpl),
+			wantMetrics: merge(serverMetrics, map[string]int64{
+				"cluster_failure_tolerance":                       0,
+				"cluster_members_learner":                         1,
+				"cluster_members_voting":                          3,
+				"cluster_quorum_lost":                             1,
+				"cluster_quorum_ok":                               0,
+				"cluster_voting_members_healthy":                  0,
+				"cluster_voting_members_unhealthy":                3,
+				"member_2c7f8adc0a41354d_health_status_healthy":   0,
+				"member_2c7f8adc0a41354d_health_status_unhealthy": 1,
+				"member_2c7f8adc0a41354d_role_follower":           1,
+				"member_2c7f8adc0a41354d_role_leader":             0,
+				"member_2c7f8adc0a41354d_role_learner":            0,
+				"member_8211f1d0f64f3269_health_status_healthy":   0,
+				"member_8211f1d0f64f3269_health_status_unhealthy": 1,
+				"member_8211f1d0f64f3269_role_follower":           1,
+				"member_8211f1d0f64f3269_role_leader":             0,
+				"member_8211f1d0f64f3269_role_learner":            0,
+				"member_8e9e05c52164694d_db_in_use_size":          16777216,
+				"member_8e9e05c52164694d_db_total_size":           25165824,
+				"member_8e9e05c52164694d_health_status_healthy":   0,
+				"member_8e9e05c52164694d_health_status_unhealthy": 1,
+				"member_8e9e05c52164694d_raft_applied_index_lag":  2,
+				"member_8e9e05c52164694d_role_follower":           1,
+				"member_8e9e05c52164694d_role_leader":             0,
+				"member_8e9e05c52164694d_role_learner":            0,
+				"member_ee77710dfbcd5095_health_status_healthy":   0,
+				"member_ee77710dfbcd5095_health_status_unhealthy": 1,
+				"member_ee77710dfbcd5095_role_follower":           0,
+				"member_ee77710dfbcd5095_role_leader":             0,
+				"member_ee77710dfbcd5095_role_learner":            1,
+			}),
+		},
+		"success without members health": {
+			prepare: func(t *testing.T) (*Collector, func()) {
+				collr, cleanup := caseOkCluster(t)
+				collr.CollectMembersHealth = false
+				return collr, cleanup
+			},
+			wantNumOfCharts: len(serverCharts) + len(clusterCharts) + 2,
+			wantMetrics: merge(serverMetrics, map[string]int64{
+				"cluster_failure_tolerance": 1,
+				"cluster_members_learner":   1,
+				"cluster_members_voting":    3,
+				"cluster_quorum_lost":       0,
+				"cluster_quorum_ok":         1,
+			}),
+		},
+		"success on metrics only (API unavailable)": {
+			prepare:         caseMetricsOnly,
+			wantNumOfCharts: len(serverCharts) + 2,
+			wantMetrics:     serverMetrics,
+		},
+		"fails on unexpected Prometheus response": {
+			prepare:     caseUnexpectedPrometheusMetrics,
+			wantMetrics: nil,
+		},
+		"fails on invalid data response": {
+			prepare:     caseInvalidDataResponse,
+			wantMetrics: nil,
+		},
+		"fails on connection refused": {
+			prepare:     caseConnectionRefused,
+			wantMetrics: nil,
+		},
+		"fails on 404 response": {
+			prepare:     case404,
+			wantMetrics: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr, cleanup := test.prepare(t)
+			defer cleanup()
+
+			mx := collr.Collect(context.Background())
+
+			require.Equal(t, test.wantMetrics, mx)
+
+			if len(test.wantMetrics) > 0 {
+				assert.Equal(t, test.wantNumOfCharts, len(*collr.Charts()), "want charts")
+
+				module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+			}
+		})
+	}
+}
+
+func TestCollector_Collect_RemovedMember(t *testing.T) {
+	var removed bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case urlPathMetrics:
+			_, _ = w.Write(dataVer3517Metrics)
+		case urlPathHealth:
+			_, _ = w.Write([]byte(`{"health":"true","reason":""}`))
+		case urlPathMaintenanceStatus:
+			_, _ = w.Write(dataVer3517Status)
+		case urlPathMemberList:
+			data := string(dataVer3517MemberList)
+			if removed {
+				i := strings.Index(data, `,{"ID":"17183327208128467093"`)
+				data = data[:i] + "]}"
+			}
+			_, _ = w.Write([]byte(data))
+		}
+	}))
+	defer srv.Close()
+
+	collr := New()
+	collr.URL = srv.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	require.NotEmpty(t, collr.Collect(context.Background()))
+	require.NotNil(t, collr.Charts().Get("member_ee77710dfbcd5095_health_status"))
+
+	removed = true
+	mx := collr.Collect(context.Background())
+	require.NotEmpty(t, mx)
+
+	assert.Equal(t, int64(0), mx["cluster_members_learner"])
+	assert.NotContains(t, mx, "member_ee77710dfbcd5095_role_learner")
+	for _, tmpl := range memberChartsTmpl {
+		chart := collr.Charts().Get(strings.Replace(tmpl.ID, "%s", "ee77710dfbcd5095", 1))
+		require.NotNil(t, chart)
+		assert.True(t, chart.Obsolete)
+	}
+}
+
+type mockMember struct {
+	status    string
+	health    string
+	unhealthy bool
+}
+
+func newMockMember(m mockMember) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case urlPathHealth:
+			if m.unhealthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			_, _ = w.Write([]byte(m.health))
+		case urlPathMaintenanceStatus:
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			_, _ = w.Write([]byte(m.status))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newMockLocalMember(status, health string, unhealthy bool, peers map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case urlPathMetrics:
+			_, _ = w.Write(dataVer3517Metrics)
+		case urlPathHealth:
+			if unhealthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			_, _ = w.Write([]byte(health))
+		case urlPathMaintenanceStatus:
+			_, _ = w.Write([]byte(status))
+		case urlPathMemberList:
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			data := string(dataVer3517MemberList)
+			for name, u := range peers {
+				data = strings.ReplaceAll(data, "http://"+name+":2379", u)
+			}
+			_, _ = w.Write([]byte(data))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func caseOkCluster(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	etcd1 := newMockMember(mockMember{
+		status: `{"header":{"member_id":"3206434137380566349"},"version":"3.5.17","dbSize":"25100000","leader":"10276657743932975437","raftIndex":"48215","raftTerm":"4","raftAppliedIndex":"48215","dbSizeInUse":"16700000"}`,
+		health: `{"health":"true","reason":""}`,
+	})
+	etcd2 := newMockMember(mockMember{
+		status:    `{"header":{"member_id":"9372538179322589801"},"version":"3.5.17","dbSize":"25165824","leader":"10276657743932975437","raftIndex":"48100","raftTerm":"4","raftAppliedIndex":"48000","dbSizeInUse":"16000000","errors":["NOSPACE"]}`,
+		health:    `{"health":"false","reason":"ALARM NOSPACE"}`,
+		unhealthy: true,
+	})
+	etcd0 := newMockLocalMember(string(dataVer3517Status), `{"health":"true","reason":""}`, false, map[string]string{
+		"etcd-1": etcd1.URL,
+		"etcd-2": etcd2.URL,
+	})
+
+	collr := New()
+	collr.URL = etcd0.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr, func() { etcd0.Close(); etcd1.Close(); etcd2.Close() }
+}
+
+func caseClusterQuorumLost(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	// the leader is unknown and peers are unreachable
+	status := strings.Replace(string(dataVer3517Status), `"leader":"10276657743932975437",`, "", 1)
+	etcd0 := newMockLocalMember(status, `{"health":"false","reason":"RAFT NO LEADER"}`, true, map[string]string{
+		"etcd-1": "http://127.0.0.1:65001",
+		"etcd-2": "http://127.0.0.1:65002",
+	})
+
+	collr := New()
+	collr.URL = etcd0.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr, etcd0.Close
+}
+
+func caseMetricsOnly(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != urlPathMetrics {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(dataVer3517Metrics)
+		}))
+
+	collr := New()
+	collr.URL = srv.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr, srv.Close
+}
+
+func caseUnexpectedPrometheusMetrics(t *testing.T) (*Collector, func()) {
+	data := `
+# HELP wmi_os_process_memory_limix_bytes OperatingSystem.MaxProcessMemorySize
+# TYPE wmi_os_process_memory_limix_bytes gauge
+wmi_os_process_memory_limix_bytes 1.40737488224256e+14
+# HELP wmi_os_processes OperatingSystem.NumberOfProcesses
+# TYPE wmi_os_processes gauge
+wmi_os_processes 124
+`
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(data))
+		}))
+
+	collr := New()
+	collr.URL = srv.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr, srv.Close
+}
+
+func caseInvalidDataResponse(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("hello and\n goodbye"))
+		}))
+
+	collr := New()
+	collr.URL = srv.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr, srv.Close
+}
+
+func caseConnectionRefused(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	collr := New()
+	collr.URL = "http://127.0.0.1:65001"
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr, func() {}
+}
+
+func case404(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+
+	collr := New()
+	collr.URL = srv.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	return collr, srv.Close
+}
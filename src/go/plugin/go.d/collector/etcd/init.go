@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package etcd
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+func (c *Collector) validateConfig() error {
+	if c.URL == "" {
+		return errors.New("url is required but not set")
+	}
+	return nil
+}
+
+func (c *Collector) initPrometheusClient(httpClient *http.Client) (prometheus.Prometheus, error) {
+	req, err := web.NewHTTPRequestWithPath(c.RequestConfig, urlPathMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := c.RequestConfig.Copy()
+	cfg.URL = req.URL.String()
+
+	return prometheus.New(httpClient, cfg), nil
+}
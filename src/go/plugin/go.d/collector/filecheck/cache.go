@@ -2,6 +2,8 @@
 
 package filecheck
 
+import "time"
+
 func newSeenItems() *seenItems {
 	return &seenItems{
 		items: make(map[string]*seenItem),
@@ -15,6 +17,19 @@ type (
 	seenItem struct {
 		hasExistenceCharts bool
 		hasOtherCharts     bool
+		sha256             string
+
+		// the result of the last content read, reused until the file changes
+		content       *fileContent
+		contentKey    fileContentKey
+		contentWrites int64
+		tooLarge      bool
+	}
+	// fileContentKey identifies a file version: the content is re-read only when any of these changes.
+	fileContentKey struct {
+		mtime time.Time
+		size  int64
+		inode uint64
 	}
 )
 
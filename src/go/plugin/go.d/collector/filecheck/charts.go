@@ -13,6 +13,11 @@ const (
 	prioFileExistenceStatus = module.Priority + iota
 	prioFileModificationTimeAgo
 	prioFileSize
+	prioFileContentChanged
+	prioFileAttrsDrift
+	prioFileLines
+	prioFilePatternMatches
+	prioFileChangeEvents
 
 	prioDirExistenceStatus
 	prioDirModificationTimeAgo
@@ -56,6 +61,60 @@ var (
 			{ID: "file_%s_size_bytes", Name: "size"},
 		},
 	}
+	fileContentChangedChartTmpl = module.Chart{
+		ID:       "file_%s_content_changed",
+		Title:    "File content change",
+		Units:    "status",
+		Fam:      "file content",
+		Ctx:      "filecheck.file_content_changed",
+		Priority: prioFileContentChanged,
+		Dims: module.Dims{
+			{ID: "file_%s_content_changed", Name: "changed"},
+		},
+	}
+	fileAttrsDriftChartTmpl = module.Chart{
+		ID:       "file_%s_attrs_drift",
+		Title:    "File ownership and permissions drift",
+		Units:    "status",
+		Fam:      "file permissions",
+		Ctx:      "filecheck.file_attrs_drift",
+		Priority: prioFileAttrsDrift,
+	}
+	fileLinesChartTmpl = module.Chart{
+		ID:       "file_%s_lines",
+		Title:    "File lines",
+		Units:    "lines",
+		Fam:      "file content",
+		Ctx:      "filecheck.file_lines",
+		Priority: prioFileLines,
+		Dims: module.Dims{
+			{ID: "file_%s_lines", Name: "lines"},
+		},
+	}
+	filePatternMatchesChartTmpl = module.Chart{
+		ID:       "file_%s_pattern_matches",
+		Title:    "File lines matching patterns",
+		Units:    "lines",
+		Fam:      "file content",
+		Ctx:      "filecheck.file_pattern_matches",
+		Priority: prioFilePatternMatches,
+	}
+	fileChangeEventsChartTmpl = module.Chart{
+		ID:       "file_%s_change_events",
+		Title:    "File change events",
+		Units:    "events/s",
+		Fam:      "file events",
+		Ctx:      "filecheck.file_change_events",
+		Priority: prioFileChangeEvents,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "file_%s_events_write", Name: "write", Algo: module.Incremental},
+			{ID: "file_%s_events_create", Name: "create", Algo: module.Incremental},
+			{ID: "file_%s_events_remove", Name: "remove", Algo: module.Incremental},
+			{ID: "file_%s_events_rename", Name: "rename", Algo: module.Incremental},
+			{ID: "file_%s_events_chmod", Name: "chmod", Algo: module.Incremental},
+		},
+	}
 )
 
 var (
@@ -128,6 +187,7 @@ func (c *Collector) updateFileCharts(infos []*statInfo) {
 				fileModificationTimeAgoChartTmpl.Copy(),
 				fileSizeChartTmpl.Copy(),
 			)
+			c.addFileCharts(info.path, c.fileOptionalCharts()...)
 
 		} else if sf.hasOtherCharts && info.fi == nil {
 			sf.hasOtherCharts = false
@@ -203,6 +263,42 @@ func (c *Collector) addFileCharts(filePath string, chartsTmpl ...*module.Chart)
 	}
 }
 
+func (c *Collector) fileOptionalCharts() []*module.Chart {
+	var charts []*module.Chart
+
+	if c.Files.Hash {
+		charts = append(charts, fileContentChangedChartTmpl.Copy())
+	}
+	if exp := c.expectedAttrs; exp != nil {
+		chart := fileAttrsDriftChartTmpl.Copy()
+		if exp.uid >= 0 {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "file_%s_owner_drift", Name: "owner"})
+		}
+		if exp.gid >= 0 {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "file_%s_group_drift", Name: "group"})
+		}
+		if exp.mode >= 0 {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "file_%s_mode_drift", Name: "mode"})
+		}
+		charts = append(charts, chart)
+	}
+	if c.Files.CountLines {
+		charts = append(charts, fileLinesChartTmpl.Copy())
+	}
+	if len(c.filePatterns) > 0 {
+		chart := filePatternMatchesChartTmpl.Copy()
+		for _, p := range c.filePatterns {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "file_%s_pattern_" + p.name + "_matches", Name: p.name})
+		}
+		charts = append(charts, chart)
+	}
+	if c.watcher != nil {
+		charts = append(charts, fileChangeEventsChartTmpl.Copy())
+	}
+
+	return charts
+}
+
 func (c *Collector) addDirCharts(dirPath string, chartsTmpl ...*module.Chart) {
 	cs := append(module.Charts{}, chartsTmpl...)
 	charts := cs.Copy()
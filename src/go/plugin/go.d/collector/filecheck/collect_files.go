@@ -11,9 +11,12 @@ import (
 func (c *Collector) collectFiles(mx map[string]int64) {
 	now := time.Now()
 
-	if c.isTimeToDiscoverFiles(now) {
+	if c.isTimeToDiscoverFiles(now) || (c.watcher != nil && c.watcher.needRediscovery()) {
 		c.lastDiscFilesTime = now
 		c.curFiles = c.discoverFiles()
+		if c.watcher != nil {
+			c.watcher.update(c.curFiles, c.Files.Include)
+		}
 	}
 
 	var infos []*statInfo
@@ -46,6 +49,99 @@ func (c *Collector) collectFile(mx map[string]int64, si *statInfo, now time.Time
 
 	mx[px+"mtime_ago"] = int64(now.Sub(si.fi.ModTime()).Seconds())
 	mx[px+"size_bytes"] = si.fi.Size()
+
+	if c.expectedAttrs != nil {
+		c.collectFileAttrsDrift(mx, si, px)
+	}
+
+	if c.needFileContent() {
+		c.collectFileContent(mx, si, px)
+	}
+
+	if c.watcher != nil {
+		if ev, ok := c.watcher.events(si.path); ok {
+			mx[px+"events_write"] = ev.write
+			mx[px+"events_create"] = ev.create
+			mx[px+"events_remove"] = ev.remove
+			mx[px+"events_rename"] = ev.rename
+			mx[px+"events_chmod"] = ev.chmod
+		}
+	}
+}
+
+func (c *Collector) collectFileAttrsDrift(mx map[string]int64, si *statInfo, px string) {
+	attrs, ok := getFileAttrs(si.fi)
+	if !ok {
+		return
+	}
+
+	exp := c.expectedAttrs
+	if exp.uid >= 0 {
+		mx[px+"owner_drift"] = boolToInt(attrs.uid != exp.uid)
+	}
+	if exp.gid >= 0 {
+		mx[px+"group_drift"] = boolToInt(attrs.gid != exp.gid)
+	}
+	if exp.mode >= 0 {
+		mx[px+"mode_drift"] = boolToInt(attrs.mode != exp.mode)
+	}
+}
+
+func (c *Collector) collectFileContent(mx map[string]int64, si *statInfo, px string) {
+	sf := c.seenFiles.getp(si.path)
+
+	if limit := c.Files.MaxFileSize; limit > 0 && si.fi.Size() > limit {
+		if !sf.tooLarge {
+			c.Warningf("file '%s' size (%d bytes) exceeds max_file_size (%d bytes), skipping content checks", si.path, si.fi.Size(), limit)
+		}
+		sf.tooLarge = true
+		sf.content = nil
+		return
+	}
+	sf.tooLarge = false
+
+	key := fileContentKey{mtime: si.fi.ModTime(), size: si.fi.Size(), inode: getFileInode(si.fi)}
+	var writes int64
+	if c.watcher != nil {
+		if ev, ok := c.watcher.events(si.path); ok {
+			writes = ev.write
+		}
+	}
+
+	changed := false
+	if sf.content == nil || sf.contentKey != key || sf.contentWrites != writes {
+		content, err := c.readFileContent(si.path)
+		if err != nil {
+			c.Debugf("failed to read file '%s': %v", si.path, err)
+			return
+		}
+		if c.Files.Hash {
+			changed = sf.sha256 != "" && sf.sha256 != content.sha256
+			if changed {
+				c.Infof("file '%s' content has changed (sha256 %s => %s)", si.path, sf.sha256, content.sha256)
+			}
+			sf.sha256 = content.sha256
+		}
+		sf.content, sf.contentKey, sf.contentWrites = content, key, writes
+	}
+
+	content := sf.content
+	if c.Files.Hash {
+		mx[px+"content_changed"] = boolToInt(changed)
+	}
+	if c.Files.CountLines {
+		mx[px+"lines"] = content.lines
+	}
+	for _, p := range c.filePatterns {
+		mx[px+"pattern_"+p.name+"_matches"] = content.matches[p.name]
+	}
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
 }
 
 func (c *Collector) discoverFiles() (files []string) {
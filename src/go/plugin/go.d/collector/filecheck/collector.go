@@ -31,7 +31,7 @@ func New() *Collector {
 	return &Collector{
 		Config: Config{
 			DiscoveryEvery: confopt.Duration(time.Minute * 1),
-			Files:          filesConfig{},
+			Files:          filesConfig{MaxFileSize: 100 * 1024 * 1024},
 			Dirs:           dirsConfig{CollectDirSize: false},
 		},
 		charts:    &module.Charts{},
@@ -48,8 +48,20 @@ type (
 		Dirs           dirsConfig       `yaml:"dirs" json:"dirs"`
 	}
 	filesConfig struct {
-		Include []string `yaml:"include" json:"include"`
-		Exclude []string `yaml:"exclude,omitempty" json:"exclude"`
+		Include       []string        `yaml:"include" json:"include"`
+		Exclude       []string        `yaml:"exclude,omitempty" json:"exclude"`
+		Hash          bool            `yaml:"hash,omitempty" json:"hash"`
+		CountLines    bool            `yaml:"count_lines,omitempty" json:"count_lines"`
+		Patterns      []patternConfig `yaml:"patterns,omitempty" json:"patterns"`
+		ExpectedOwner string          `yaml:"expected_owner,omitempty" json:"expected_owner"`
+		ExpectedGroup string          `yaml:"expected_group,omitempty" json:"expected_group"`
+		ExpectedMode  string          `yaml:"expected_mode,omitempty" json:"expected_mode"`
+		Watch         bool            `yaml:"watch,omitempty" json:"watch"`
+		MaxFileSize   int64           `yaml:"max_file_size,omitempty" json:"max_file_size"`
+	}
+	patternConfig struct {
+		Name  string `yaml:"name" json:"name"`
+		Regex string `yaml:"regex" json:"regex"`
 	}
 	dirsConfig struct {
 		Include        []string `yaml:"include" json:"include"`
@@ -68,6 +80,9 @@ type Collector struct {
 	lastDiscFilesTime time.Time
 	curFiles          []string
 	seenFiles         *seenItems
+	filePatterns      []*filePattern
+	expectedAttrs     *fileAttrs
+	watcher           *fileWatcher
 
 	dirsFilter       matcher.Matcher
 	lastDiscDirsTime time.Time
@@ -97,6 +112,26 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.dirsFilter = df
 
+	fp, err := c.initFilePatterns()
+	if err != nil {
+		return fmt.Errorf("file patterns initialization: %v", err)
+	}
+	c.filePatterns = fp
+
+	attrs, err := c.initExpectedFileAttrs()
+	if err != nil {
+		return fmt.Errorf("expected file attributes initialization: %v", err)
+	}
+	c.expectedAttrs = attrs
+
+	if c.Files.Watch && len(c.Files.Include) > 0 {
+		w, err := newFileWatcher(c.Logger)
+		if err != nil {
+			return fmt.Errorf("file watcher initialization: %v", err)
+		}
+		c.watcher = w
+	}
+
 	c.Debugf("monitored files: %v", c.Files.Include)
 	c.Debugf("monitored dirs: %v", c.Dirs.Include)
 
@@ -124,4 +159,9 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return mx
 }
 
-func (c *Collector) Cleanup(context.Context) {}
+func (c *Collector) Cleanup(context.Context) {
+	if c.watcher != nil {
+		c.watcher.close()
+		c.watcher = nil
+	}
+}
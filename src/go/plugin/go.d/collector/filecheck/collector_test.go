@@ -5,8 +5,12 @@ package filecheck
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 
@@ -81,6 +85,64 @@ func TestCollector_Init(t *testing.T) {
 				},
 			},
 		},
+		"files content checks": {
+			wantFail: false,
+			config: Config{
+				Files: filesConfig{
+					Include:      []string{"/path/to/file1"},
+					Hash:         true,
+					CountLines:   true,
+					Patterns:     []patternConfig{{Name: "errors", Regex: "(?i)error"}},
+					ExpectedMode: "0644",
+					Watch:        true,
+				},
+			},
+		},
+		"invalid pattern regex": {
+			wantFail: true,
+			config: Config{
+				Files: filesConfig{
+					Include:  []string{"/path/to/file1"},
+					Patterns: []patternConfig{{Name: "errors", Regex: "error("}},
+				},
+			},
+		},
+		"invalid pattern name": {
+			wantFail: true,
+			config: Config{
+				Files: filesConfig{
+					Include:  []string{"/path/to/file1"},
+					Patterns: []patternConfig{{Name: "my errors", Regex: "error"}},
+				},
+			},
+		},
+		"duplicate pattern name": {
+			wantFail: true,
+			config: Config{
+				Files: filesConfig{
+					Include:  []string{"/path/to/file1"},
+					Patterns: []patternConfig{{Name: "errors", Regex: "error"}, {Name: "errors", Regex: "fail"}},
+				},
+			},
+		},
+		"invalid expected mode": {
+			wantFail: true,
+			config: Config{
+				Files: filesConfig{
+					Include:      []string{"/path/to/file1"},
+					ExpectedMode: "rw-r--r--",
+				},
+			},
+		},
+		"unknown expected owner": {
+			wantFail: true,
+			config: Config{
+				Files: filesConfig{
+					Include:       []string{"/path/to/file1"},
+					ExpectedOwner: "no-such-user-netdata-test",
+				},
+			},
+		},
 		"only dirs->include": {
 			wantFail: false,
 			config: Config{
@@ -339,3 +401,167 @@ func copyModTime(dst, src map[string]int64) {
 		}
 	}
 }
+
+func TestCollector_Collect_FileContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("line1\nerror: one\nline3\nERROR: two\n"), 0600))
+
+	collr := New()
+	collr.Config.Files.Include = []string{path}
+	collr.Config.Files.Hash = true
+	collr.Config.Files.CountLines = true
+	collr.Config.Files.Patterns = []patternConfig{
+		{Name: "errors", Regex: "(?i)error"},
+		{Name: "warnings", Regex: "warn"},
+	}
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	px := "file_" + path + "_"
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(0), mx[px+"content_changed"])
+	assert.Equal(t, int64(4), mx[px+"lines"])
+	assert.Equal(t, int64(2), mx[px+"pattern_errors_matches"])
+	assert.Equal(t, int64(0), mx[px+"pattern_warnings_matches"])
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(0), mx[px+"content_changed"])
+
+	require.NoError(t, os.WriteFile(path, []byte("warn: one\n"), 0600))
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(1), mx[px+"content_changed"])
+	assert.Equal(t, int64(1), mx[px+"lines"])
+	assert.Equal(t, int64(0), mx[px+"pattern_errors_matches"])
+	assert.Equal(t, int64(1), mx[px+"pattern_warnings_matches"])
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(0), mx[px+"content_changed"])
+}
+
+func TestCollector_Collect_FileContentCached(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("error: one\n"), 0600))
+	mtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+
+	collr := New()
+	collr.Config.Files.Include = []string{path}
+	collr.Config.Files.Hash = true
+	collr.Config.Files.Patterns = []patternConfig{{Name: "errors", Regex: "error"}}
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	px := "file_" + path + "_"
+
+	mx := collr.Collect(context.Background())
+	assert.Equal(t, int64(1), mx[px+"pattern_errors_matches"])
+
+	// same size and mtime: the cached results are reused
+	require.NoError(t, os.WriteFile(path, []byte("valid: one\n"), 0600))
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(0), mx[px+"content_changed"])
+	assert.Equal(t, int64(1), mx[px+"pattern_errors_matches"])
+
+	// the mtime changes: the file is re-read
+	require.NoError(t, os.Chtimes(path, mtime.Add(time.Second), mtime.Add(time.Second)))
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(1), mx[px+"content_changed"])
+	assert.Equal(t, int64(0), mx[px+"pattern_errors_matches"])
+}
+
+func TestCollector_Collect_FileContentMaxFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(path, []byte("line1\nline2\n"), 0600))
+
+	collr := New()
+	collr.Config.Files.Include = []string{path}
+	collr.Config.Files.CountLines = true
+	collr.Config.Files.MaxFileSize = 8
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	px := "file_" + path + "_"
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(12), mx[px+"size_bytes"])
+	assert.NotContains(t, mx, px+"lines")
+
+	require.NoError(t, os.WriteFile(path, []byte("line1\n"), 0600))
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, int64(1), mx[px+"lines"])
+}
+
+func TestCollector_Collect_FileAttrsDrift(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file ownership and mode are not supported on Windows")
+	}
+
+	dir := t.TempDir()
+	okPath := filepath.Join(dir, "ok.conf")
+	driftPath := filepath.Join(dir, "drift.conf")
+	require.NoError(t, os.WriteFile(okPath, nil, 0600))
+	require.NoError(t, os.WriteFile(driftPath, nil, 0600))
+	require.NoError(t, os.Chmod(okPath, 0640))
+	require.NoError(t, os.Chmod(driftPath, 0666))
+
+	collr := New()
+	collr.Config.Files.Include = []string{okPath, driftPath}
+	collr.Config.Files.ExpectedOwner = strconv.Itoa(os.Getuid())
+	collr.Config.Files.ExpectedMode = "0640"
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(0), mx["file_"+okPath+"_owner_drift"])
+	assert.Equal(t, int64(0), mx["file_"+okPath+"_mode_drift"])
+	assert.Equal(t, int64(0), mx["file_"+driftPath+"_owner_drift"])
+	assert.Equal(t, int64(1), mx["file_"+driftPath+"_mode_drift"])
+	assert.NotContains(t, mx, "file_"+okPath+"_group_drift")
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_Collect_FileEvents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.conf")
+	require.NoError(t, os.WriteFile(path, []byte("a=1\n"), 0600))
+
+	collr := New()
+	collr.Config.Files.Include = []string{path}
+	collr.Config.Files.Watch = true
+	require.NoError(t, collr.Init(context.Background()))
+	defer collr.Cleanup(context.Background())
+
+	px := "file_" + path + "_"
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(0), mx[px+"events_write"])
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	require.NoError(t, os.WriteFile(path, []byte("a=2\n"), 0600))
+
+	// replace the file the way editors do
+	tmp := filepath.Join(dir, "app.conf.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("a=3\n"), 0600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	require.Eventually(t, func() bool {
+		mx = collr.Collect(context.Background())
+		return mx[px+"events_write"] > 0 && mx[px+"events_create"] > 0
+	}, time.Second*5, time.Millisecond*50)
+
+	assert.Equal(t, int64(1), mx[px+"existence_status_exist"])
+}
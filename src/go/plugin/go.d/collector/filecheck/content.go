@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filecheck
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"regexp"
+)
+
+// maxLineSize is the maximum line length considered when counting lines and pattern matches.
+const maxLineSize = 1024 * 1024
+
+type (
+	filePattern struct {
+		name string
+		re   *regexp.Regexp
+	}
+	fileAttrs struct {
+		uid  int
+		gid  int
+		mode int64
+	}
+	fileContent struct {
+		sha256  string
+		lines   int64
+		matches map[string]int64
+	}
+)
+
+func (c *Collector) needFileContent() bool {
+	return c.Files.Hash || c.Files.CountLines || len(c.filePatterns) > 0
+}
+
+// readFileContent reads the file once, calculating the SHA-256 digest and counting lines and pattern matches.
+func (c *Collector) readFileContent(path string) (*fileContent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	content := &fileContent{matches: make(map[string]int64)}
+
+	var r io.Reader = f
+	h := sha256.New()
+	if c.Files.Hash {
+		r = io.TeeReader(f, h)
+	}
+
+	if c.Files.CountLines || len(c.filePatterns) > 0 {
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64*1024), maxLineSize)
+
+		for sc.Scan() {
+			content.lines++
+			line := sc.Bytes()
+			for _, p := range c.filePatterns {
+				if p.re.Match(line) {
+					content.matches[p.name]++
+				}
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Files.Hash {
+		// consume the rest if the content was not scanned
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return nil, err
+		}
+		content.sha256 = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return content, nil
+}
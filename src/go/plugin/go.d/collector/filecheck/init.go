@@ -4,6 +4,10 @@ package filecheck
 
 import (
 	"errors"
+	"fmt"
+	"os/user"
+	"regexp"
+	"strconv"
 
 	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 )
@@ -36,3 +40,74 @@ func newFilter(patterns []string) (matcher.Matcher, error) {
 
 	return filter, nil
 }
+
+var reValidPatternName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+func (c *Collector) initFilePatterns() ([]*filePattern, error) {
+	var patterns []*filePattern
+	seen := make(map[string]bool)
+
+	for i, cfg := range c.Files.Patterns {
+		if !reValidPatternName.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("pattern %d: invalid name '%s' (allowed characters: letters, digits, '_', '-')", i+1, cfg.Name)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("pattern %d: duplicate name '%s'", i+1, cfg.Name)
+		}
+		seen[cfg.Name] = true
+
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("pattern '%s': %v", cfg.Name, err)
+		}
+		patterns = append(patterns, &filePattern{name: cfg.Name, re: re})
+	}
+
+	return patterns, nil
+}
+
+func (c *Collector) initExpectedFileAttrs() (*fileAttrs, error) {
+	if c.Files.ExpectedOwner == "" && c.Files.ExpectedGroup == "" && c.Files.ExpectedMode == "" {
+		return nil, nil
+	}
+
+	attrs := &fileAttrs{uid: -1, gid: -1, mode: -1}
+
+	if v := c.Files.ExpectedOwner; v != "" {
+		uid, err := strconv.Atoi(v)
+		if err != nil {
+			u, err := user.Lookup(v)
+			if err != nil {
+				return nil, fmt.Errorf("expected owner: %v", err)
+			}
+			if uid, err = strconv.Atoi(u.Uid); err != nil {
+				return nil, fmt.Errorf("expected owner: user '%s' has non-numeric uid '%s'", v, u.Uid)
+			}
+		}
+		attrs.uid = uid
+	}
+
+	if v := c.Files.ExpectedGroup; v != "" {
+		gid, err := strconv.Atoi(v)
+		if err != nil {
+			g, err := user.LookupGroup(v)
+			if err != nil {
+				return nil, fmt.Errorf("expected group: %v", err)
+			}
+			if gid, err = strconv.Atoi(g.Gid); err != nil {
+				return nil, fmt.Errorf("expected group: group '%s' has non-numeric gid '%s'", v, g.Gid)
+			}
+		}
+		attrs.gid = gid
+	}
+
+	if v := c.Files.ExpectedMode; v != "" {
+		mode, err := strconv.ParseUint(v, 8, 32)
+		if err != nil || mode > 0o7777 {
+			return nil, fmt.Errorf("expected mode: invalid octal permissions '%s'", v)
+		}
+		attrs.mode = int64(mode)
+	}
+
+	return attrs, nil
+}
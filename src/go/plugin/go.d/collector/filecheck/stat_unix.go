@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !windows
+
+package filecheck
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// getFileAttrs returns the owner, group and permission bits (including setuid, setgid and sticky) of the file.
+func getFileAttrs(fi fs.FileInfo) (fileAttrs, bool) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileAttrs{}, false
+	}
+	return fileAttrs{
+		uid:  int(st.Uid),
+		gid:  int(st.Gid),
+		mode: int64(st.Mode & 0o7777),
+	}, true
+}
+
+// getFileInode returns the inode number of the file, it changes when the file is replaced (e.g. rotated).
+func getFileInode(fi fs.FileInfo) uint64 {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0
+	}
+	return uint64(st.Ino)
+}
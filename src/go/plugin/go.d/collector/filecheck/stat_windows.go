@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build windows
+
+package filecheck
+
+import "io/fs"
+
+// getFileAttrs is not supported on Windows: there are no POSIX owners and permission bits.
+func getFileAttrs(fs.FileInfo) (fileAttrs, bool) {
+	return fileAttrs{}, false
+}
+
+// getFileInode is not supported on Windows, files are identified by the modification time and size only.
+func getFileInode(fs.FileInfo) uint64 {
+	return 0
+}
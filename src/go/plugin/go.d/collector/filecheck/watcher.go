@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package filecheck
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type fileEvents struct {
+	write  int64
+	create int64
+	remove int64
+	rename int64
+	chmod  int64
+}
+
+// fileWatcher counts inotify events of the monitored files. It watches the parent directories rather than the files
+// themselves, so that files replaced using rename (as most editors and configuration management tools do) are still tracked.
+type fileWatcher struct {
+	*logger.Logger
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+
+	mu          sync.Mutex
+	dirs        map[string]bool
+	files       map[string]*fileEvents
+	rediscovery bool
+}
+
+func newFileWatcher(log *logger.Logger) (*fileWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fileWatcher{
+		Logger:  log,
+		watcher: w,
+		done:    make(chan struct{}),
+		dirs:    make(map[string]bool),
+		files:   make(map[string]*fileEvents),
+	}
+
+	go fw.run()
+
+	return fw, nil
+}
+
+// update sets the monitored files, watching their parent directories and the directories of the include patterns.
+func (w *fileWatcher) update(files []string, includes []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dirs := make(map[string]bool)
+	for _, path := range includes {
+		if dir := filepath.Dir(path); !hasMeta(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range files {
+		path = filepath.Clean(path)
+		seen[path] = true
+		dirs[filepath.Dir(path)] = true
+		if _, ok := w.files[path]; !ok {
+			w.files[path] = &fileEvents{}
+		}
+	}
+	for path := range w.files {
+		if !seen[path] {
+			delete(w.files, path)
+		}
+	}
+
+	for dir := range dirs {
+		if w.dirs[dir] {
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			w.Debugf("watch directory '%s': %v", dir, err)
+			continue
+		}
+		w.dirs[dir] = true
+	}
+	for dir := range w.dirs {
+		if !dirs[dir] {
+			_ = w.watcher.Remove(dir)
+			delete(w.dirs, dir)
+		}
+	}
+}
+
+// events returns the accumulated event counters of the file.
+func (w *fileWatcher) events(path string) (fileEvents, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ev, ok := w.files[filepath.Clean(path)]
+	if !ok {
+		return fileEvents{}, false
+	}
+	return *ev, true
+}
+
+// needRediscovery reports whether files were created, removed or renamed in the watched directories since the last call.
+func (w *fileWatcher) needRediscovery() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	v := w.rediscovery
+	w.rediscovery = false
+	return v
+}
+
+func (w *fileWatcher) close() {
+	_ = w.watcher.Close()
+	<-w.done
+}
+
+func (w *fileWatcher) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(event)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.Debugf("file watcher: %v", err)
+		}
+	}
+}
+
+func (w *fileWatcher) handleEvent(event fsnotify.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if event.Has(fsnotify.Create) || event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		w.rediscovery = true
+	}
+
+	ev, ok := w.files[event.Name]
+	if !ok {
+		return
+	}
+
+	if event.Has(fsnotify.Write) {
+		ev.write++
+	}
+	if event.Has(fsnotify.Create) {
+		ev.create++
+	}
+	if event.Has(fsnotify.Remove) {
+		ev.remove++
+	}
+	if event.Has(fsnotify.Rename) {
+		ev.rename++
+	}
+	if event.Has(fsnotify.Chmod) {
+		ev.chmod++
+	}
+}
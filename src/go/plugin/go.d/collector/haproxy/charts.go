@@ -110,3 +110,406 @@ func newBackendChartFromTemplate(tpl module.Chart, proxy string) *module.Chart {
 	}
 	return c
 }
+
+const (
+	prioProcessConnections = module.Priority + iota
+	prioProcessConnectionsRate
+	prioProcessRequestsRate
+	prioProcessIdle
+
+	prioFrontendCurrentSessions
+	prioFrontendSessions
+	prioFrontendRequests
+	prioFrontendHTTPResponses
+	prioFrontendDenied
+	prioFrontendRequestErrors
+	prioFrontendNetworkIO
+
+	prioServerStatus
+	prioServerCheckStatus
+	prioServerCheckFailures
+	prioServerWeight
+	prioServerCurrentSessions
+	prioServerSessions
+	prioServerCurrentQueue
+	prioServerQueueTimeAverage
+	prioServerResponseTimeAverage
+	prioServerNetworkIO
+
+	prioStickTableUsage
+	prioStickTableUtilization
+)
+
+var processCharts = module.Charts{
+	chartProcessConnections.Copy(),
+	chartProcessConnectionsRate.Copy(),
+	chartProcessRequestsRate.Copy(),
+	chartProcessIdle.Copy(),
+}
+
+var (
+	chartProcessConnections = module.Chart{
+		ID:       "process_connections",
+		Title:    "Current connections",
+		Units:    "connections",
+		Fam:      "process",
+		Ctx:      "haproxy.process_connections",
+		Priority: prioProcessConnections,
+		Dims: module.Dims{
+			{ID: "info_currconns", Name: "active"},
+			{ID: "info_maxconn", Name: "limit"},
+		},
+	}
+	chartProcessConnectionsRate = module.Chart{
+		ID:       "process_connections_rate",
+		Title:    "Connections rate",
+		Units:    "connections/s",
+		Fam:      "process",
+		Ctx:      "haproxy.process_connections_rate",
+		Priority: prioProcessConnectionsRate,
+		Dims: module.Dims{
+			{ID: "info_cumconns", Name: "connections", Algo: module.Incremental},
+		},
+	}
+	chartProcessRequestsRate = module.Chart{
+		ID:       "process_requests_rate",
+		Title:    "HTTP requests rate",
+		Units:    "requests/s",
+		Fam:      "process",
+		Ctx:      "haproxy.process_requests_rate",
+		Priority: prioProcessRequestsRate,
+		Dims: module.Dims{
+			{ID: "info_cumreq", Name: "requests", Algo: module.Incremental},
+		},
+	}
+	chartProcessIdle = module.Chart{
+		ID:       "process_idle",
+		Title:    "Idle time",
+		Units:    "percentage",
+		Fam:      "process",
+		Ctx:      "haproxy.process_idle",
+		Priority: prioProcessIdle,
+		Dims: module.Dims{
+			{ID: "info_idle_pct", Name: "idle"},
+		},
+	}
+)
+
+var frontendChartsTmpl = module.Charts{
+	frontendCurrentSessionsChartTmpl.Copy(),
+	frontendSessionsChartTmpl.Copy(),
+	frontendRequestsChartTmpl.Copy(),
+	frontendHTTPResponsesChartTmpl.Copy(),
+	frontendDeniedChartTmpl.Copy(),
+	frontendRequestErrorsChartTmpl.Copy(),
+	frontendNetworkIOChartTmpl.Copy(),
+}
+
+var (
+	frontendCurrentSessionsChartTmpl = module.Chart{
+		ID:       "frontend_%s_current_sessions",
+		Title:    "Frontend current sessions",
+		Units:    "sessions",
+		Fam:      "frontend sessions",
+		Ctx:      "haproxy.frontend_current_sessions",
+		Priority: prioFrontendCurrentSessions,
+		Dims: module.Dims{
+			{ID: "frontend_%s_scur", Name: "active"},
+			{ID: "frontend_%s_slim", Name: "limit"},
+		},
+	}
+	frontendSessionsChartTmpl = module.Chart{
+		ID:       "frontend_%s_sessions",
+		Title:    "Frontend sessions rate",
+		Units:    "sessions/s",
+		Fam:      "frontend sessions",
+		Ctx:      "haproxy.frontend_sessions",
+		Priority: prioFrontendSessions,
+		Dims: module.Dims{
+			{ID: "frontend_%s_stot", Name: "sessions", Algo: module.Incremental},
+		},
+	}
+	frontendRequestsChartTmpl = module.Chart{
+		ID:       "frontend_%s_requests",
+		Title:    "Frontend HTTP requests rate",
+		Units:    "requests/s",
+		Fam:      "frontend requests",
+		Ctx:      "haproxy.frontend_requests",
+		Priority: prioFrontendRequests,
+		Dims: module.Dims{
+			{ID: "frontend_%s_req_tot", Name: "requests", Algo: module.Incremental},
+		},
+	}
+	frontendHTTPResponsesChartTmpl = module.Chart{
+		ID:       "frontend_%s_http_responses",
+		Title:    "Frontend HTTP responses by code class",
+		Units:    "responses/s",
+		Fam:      "frontend requests",
+		Ctx:      "haproxy.frontend_http_responses",
+		Type:     module.Stacked,
+		Priority: prioFrontendHTTPResponses,
+		Dims: module.Dims{
+			{ID: "frontend_%s_hrsp_1xx", Name: "1xx", Algo: module.Incremental},
+			{ID: "frontend_%s_hrsp_2xx", Name: "2xx", Algo: module.Incremental},
+			{ID: "frontend_%s_hrsp_3xx", Name: "3xx", Algo: module.Incremental},
+			{ID: "frontend_%s_hrsp_4xx", Name: "4xx", Algo: module.Incremental},
+			{ID: "frontend_%s_hrsp_5xx", Name: "5xx", Algo: module.Incremental},
+			{ID: "frontend_%s_hrsp_other", Name: "other", Algo: module.Incremental},
+		},
+	}
+	frontendDeniedChartTmpl = module.Chart{
+		ID:       "frontend_%s_denied",
+		Title:    "Frontend denied requests and responses",
+		Units:    "denials/s",
+		Fam:      "frontend errors",
+		Ctx:      "haproxy.frontend_denied",
+		Priority: prioFrontendDenied,
+		Dims: module.Dims{
+			{ID: "frontend_%s_dreq", Name: "requests", Algo: module.Incremental},
+			{ID: "frontend_%s_dresp", Name: "responses", Algo: module.Incremental},
+		},
+	}
+	frontendRequestErrorsChartTmpl = module.Chart{
+		ID:       "frontend_%s_request_errors",
+		Title:    "Frontend request errors",
+		Units:    "errors/s",
+		Fam:      "frontend errors",
+		Ctx:      "haproxy.frontend_request_errors",
+		Priority: prioFrontendRequestErrors,
+		Dims: module.Dims{
+			{ID: "frontend_%s_ereq", Name: "errors", Algo: module.Incremental},
+		},
+	}
+	frontendNetworkIOChartTmpl = module.Chart{
+		ID:       "frontend_%s_network_io",
+		Title:    "Frontend network traffic",
+		Units:    "bytes/s",
+		Fam:      "frontend network",
+		Ctx:      "haproxy.frontend_network_io",
+		Type:     module.Area,
+		Priority: prioFrontendNetworkIO,
+		Dims: module.Dims{
+			{ID: "frontend_%s_bin", Name: "in", Algo: module.Incremental},
+			{ID: "frontend_%s_bout", Name: "out", Algo: module.Incremental, Mul: -1},
+		},
+	}
+)
+
+var serverChartsTmpl = module.Charts{
+	serverStatusChartTmpl.Copy(),
+	serverCheckStatusChartTmpl.Copy(),
+	serverCheckFailuresChartTmpl.Copy(),
+	serverWeightChartTmpl.Copy(),
+	serverCurrentSessionsChartTmpl.Copy(),
+	serverSessionsChartTmpl.Copy(),
+	serverCurrentQueueChartTmpl.Copy(),
+	serverQueueTimeAverageChartTmpl.Copy(),
+	serverResponseTimeAverageChartTmpl.Copy(),
+	serverNetworkIOChartTmpl.Copy(),
+}
+
+var (
+	serverStatusChartTmpl = module.Chart{
+		ID:       "server_%s_status",
+		Title:    "Server status",
+		Units:    "status",
+		Fam:      "server status",
+		Ctx:      "haproxy.server_status",
+		Priority: prioServerStatus,
+		Dims: module.Dims{
+			{ID: "server_%s_status_up", Name: "up"},
+			{ID: "server_%s_status_down", Name: "down"},
+			{ID: "server_%s_status_nolb", Name: "nolb"},
+			{ID: "server_%s_status_maint", Name: "maint"},
+			{ID: "server_%s_status_drain", Name: "drain"},
+			{ID: "server_%s_status_no_check", Name: "no_check"},
+		},
+	}
+	serverCheckStatusChartTmpl = module.Chart{
+		ID:       "server_%s_check_status",
+		Title:    "Server last health check status",
+		Units:    "status",
+		Fam:      "server status",
+		Ctx:      "haproxy.server_check_status",
+		Priority: prioServerCheckStatus,
+		Dims: module.Dims{
+			{ID: "server_%s_check_status_passed", Name: "passed"},
+			{ID: "server_%s_check_status_failed", Name: "failed"},
+			{ID: "server_%s_check_status_initializing", Name: "initializing"},
+			{ID: "server_%s_check_status_none", Name: "none"},
+		},
+	}
+	serverCheckFailuresChartTmpl = module.Chart{
+		ID:       "server_%s_check_failures",
+		Title:    "Server health check failures",
+		Units:    "events/s",
+		Fam:      "server status",
+		Ctx:      "haproxy.server_check_failures",
+		Priority: prioServerCheckFailures,
+		Dims: module.Dims{
+			{ID: "server_%s_chkfail", Name: "failed_checks", Algo: module.Incremental},
+			{ID: "server_%s_chkdown", Name: "up_to_down", Algo: module.Incremental},
+		},
+	}
+	serverWeightChartTmpl = module.Chart{
+		ID:       "server_%s_weight",
+		Title:    "Server effective weight",
+		Units:    "weight",
+		Fam:      "server status",
+		Ctx:      "haproxy.server_weight",
+		Priority: prioServerWeight,
+		Dims: module.Dims{
+			{ID: "server_%s_weight", Name: "weight"},
+		},
+	}
+	serverCurrentSessionsChartTmpl = module.Chart{
+		ID:       "server_%s_current_sessions",
+		Title:    "Server current sessions",
+		Units:    "sessions",
+		Fam:      "server sessions",
+		Ctx:      "haproxy.server_current_sessions",
+		Priority: prioServerCurrentSessions,
+		Dims: module.Dims{
+			{ID: "server_%s_scur", Name: "active"},
+		},
+	}
+	serverSessionsChartTmpl = module.Chart{
+		ID:       "server_%s_sessions",
+		Title:    "Server sessions rate",
+		Units:    "sessions/s",
+		Fam:      "server sessions",
+		Ctx:      "haproxy.server_sessions",
+		Priority: prioServerSessions,
+		Dims: module.Dims{
+			{ID: "server_%s_stot", Name: "sessions", Algo: module.Incremental},
+		},
+	}
+	serverCurrentQueueChartTmpl = module.Chart{
+		ID:       "server_%s_current_queue",
+		Title:    "Server current number of queued requests",
+		Units:    "requests",
+		Fam:      "server queue",
+		Ctx:      "haproxy.server_current_queue",
+		Priority: prioServerCurrentQueue,
+		Dims: module.Dims{
+			{ID: "server_%s_qcur", Name: "queued"},
+		},
+	}
+	serverQueueTimeAverageChartTmpl = module.Chart{
+		ID:       "server_%s_queue_time_average",
+		Title:    "Server average queue time for last 1024 successful connections",
+		Units:    "milliseconds",
+		Fam:      "server queue",
+		Ctx:      "haproxy.server_queue_time_average",
+		Priority: prioServerQueueTimeAverage,
+		Dims: module.Dims{
+			{ID: "server_%s_qtime", Name: "time"},
+		},
+	}
+	serverResponseTimeAverageChartTmpl = module.Chart{
+		ID:       "server_%s_response_time_average",
+		Title:    "Server average response time for last 1024 successful connections",
+		Units:    "milliseconds",
+		Fam:      "server responses",
+		Ctx:      "haproxy.server_response_time_average",
+		Priority: prioServerResponseTimeAverage,
+		Dims: module.Dims{
+			{ID: "server_%s_rtime", Name: "time"},
+		},
+	}
+	serverNetworkIOChartTmpl = module.Chart{
+		ID:       "server_%s_network_io",
+		Title:    "Server network traffic",
+		Units:    "bytes/s",
+		Fam:      "server network",
+		Ctx:      "haproxy.server_network_io",
+		Type:     module.Area,
+		Priority: prioServerNetworkIO,
+		Dims: module.Dims{
+			{ID: "server_%s_bin", Name: "in", Algo: module.Incremental},
+			{ID: "server_%s_bout", Name: "out", Algo: module.Incremental, Mul: -1},
+		},
+	}
+)
+
+var stickTableChartsTmpl = module.Charts{
+	stickTableUsageChartTmpl.Copy(),
+	stickTableUtilizationChartTmpl.Copy(),
+}
+
+var (
+	stickTableUsageChartTmpl = module.Chart{
+		ID:       "stick_table_%s_usage",
+		Title:    "Stick table entries",
+		Units:    "entries",
+		Fam:      "stick tables",
+		Ctx:      "haproxy.stick_table_usage",
+		Type:     module.Stacked,
+		Priority: prioStickTableUsage,
+		Dims: module.Dims{
+			{ID: "stick_table_%s_used", Name: "used"},
+			{ID: "stick_table_%s_free", Name: "free"},
+		},
+	}
+	stickTableUtilizationChartTmpl = module.Chart{
+		ID:       "stick_table_%s_utilization",
+		Title:    "Stick table utilization",
+		Units:    "percentage",
+		Fam:      "stick tables",
+		Ctx:      "haproxy.stick_table_utilization",
+		Type:     module.Area,
+		Priority: prioStickTableUtilization,
+		Dims: module.Dims{
+			{ID: "stick_table_%s_utilization", Name: "utilization", Div: 100},
+		},
+	}
+)
+
+func (c *Collector) addFrontendCharts(name string) []string {
+	return c.addChartsFromTemplates(frontendChartsTmpl, name, []module.Label{
+		{Key: "frontend", Value: name},
+	})
+}
+
+func (c *Collector) addServerCharts(id, backend, server string) []string {
+	return c.addChartsFromTemplates(serverChartsTmpl, id, []module.Label{
+		{Key: "backend", Value: backend},
+		{Key: "server", Value: server},
+	})
+}
+
+func (c *Collector) addStickTableCharts(name, typ string) []string {
+	return c.addChartsFromTemplates(stickTableChartsTmpl, name, []module.Label{
+		{Key: "table", Value: name},
+		{Key: "type", Value: typ},
+	})
+}
+
+func (c *Collector) addChartsFromTemplates(tmpl module.Charts, id string, labels []module.Label) []string {
+	charts := tmpl.Copy()
+
+	var ids []string
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		chart.Labels = labels
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+		ids = append(ids, chart.ID)
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+
+	return ids
+}
+
+func (c *Collector) removeCharts(ids []string) {
+	for _, id := range ids {
+		if chart := c.Charts().Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
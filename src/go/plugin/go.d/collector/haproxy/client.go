@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haproxy
+
+import (
+	"bytes"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/socket"
+)
+
+type haproxyConn interface {
+	showInfo() ([]byte, error)
+	showStat() ([]byte, error)
+	showTable() ([]byte, error)
+}
+
+func newHaproxyConn(cfg Config) haproxyConn {
+	return &haproxyClient{
+		cfg: socket.Config{
+			Address: cfg.StatsSocket,
+			Timeout: cfg.Timeout.Duration(),
+		},
+		master: cfg.MasterSocket,
+	}
+}
+
+// haproxyClient talks to the HAProxy runtime API in non-interactive mode:
+// every command uses a new connection that HAProxy closes after the response.
+type haproxyClient struct {
+	cfg    socket.Config
+	master bool
+}
+
+func (c *haproxyClient) showInfo() ([]byte, error) {
+	return c.command("show info")
+}
+
+func (c *haproxyClient) showStat() ([]byte, error) {
+	return c.command("show stat")
+}
+
+func (c *haproxyClient) showTable() ([]byte, error) {
+	return c.command("show table")
+}
+
+func (c *haproxyClient) command(cmd string) ([]byte, error) {
+	if c.master {
+		// the master CLI forwards commands prefixed with '@<relative pid>' to the worker
+		cmd = "@1 " + cmd
+	}
+
+	conn := socket.New(c.cfg)
+	if err := conn.Connect(); err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Disconnect() }()
+
+	var b bytes.Buffer
+
+	if err := conn.Command(cmd+"\n", func(bs []byte) (bool, error) {
+		b.Write(bs)
+		b.WriteByte('\n')
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
@@ -31,6 +31,10 @@ func isHaproxyMetrics(pms prometheus.Series) bool {
 }
 
 func (c *Collector) collect() (map[string]int64, error) {
+	if c.conn != nil {
+		return c.collectStatsSocket()
+	}
+
 	pms, err := c.prom.ScrapeSeries()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haproxy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 'show stat' proxy types
+const (
+	statTypeFrontend = "0"
+	statTypeBackend  = "1"
+	statTypeServer   = "2"
+)
+
+var serverStates = []string{
+	"up",
+	"down",
+	"nolb",
+	"maint",
+	"drain",
+	"no_check",
+}
+
+var serverCheckStates = []string{
+	"passed",
+	"failed",
+	"initializing",
+	"none",
+}
+
+func (c *Collector) collectStatsSocket() (map[string]int64, error) {
+	mx := make(map[string]int64)
+
+	if err := c.collectShowInfo(mx); err != nil {
+		return nil, err
+	}
+	if err := c.collectShowStat(mx); err != nil {
+		return mx, err
+	}
+	if !c.skipStickTables {
+		if err := c.collectShowTable(mx); err != nil {
+			c.Warningf("stick tables are not available ('show table': %v), skipping.", err)
+			c.skipStickTables = true
+		}
+	}
+
+	return mx, nil
+}
+
+func (c *Collector) collectShowInfo(mx map[string]int64) error {
+	bs, err := c.conn.showInfo()
+	if err != nil {
+		return err
+	}
+
+	info, err := parseShowInfo(bs)
+	if err != nil {
+		return fmt.Errorf("bad 'show info' response: %v", err)
+	}
+
+	for _, key := range []string{"CurrConns", "Maxconn", "CumConns", "CumReq", "Idle_pct"} {
+		if v, err := strconv.ParseInt(info[key], 10, 64); err == nil {
+			mx["info_"+strings.ToLower(key)] = v
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectShowStat(mx map[string]int64) error {
+	bs, err := c.conn.showStat()
+	if err != nil {
+		return err
+	}
+
+	stats, err := parseShowStat(bs)
+	if err != nil {
+		return fmt.Errorf("bad 'show stat' response: %v", err)
+	}
+
+	seenFrontends, seenServers := make(map[string]bool), make(map[string]bool)
+
+	for _, st := range stats {
+		pxname, svname := st["pxname"], st["svname"]
+
+		switch st["type"] {
+		case statTypeFrontend:
+			seenFrontends[pxname] = true
+			if _, ok := c.frontends[pxname]; !ok {
+				c.frontends[pxname] = c.addFrontendCharts(pxname)
+			}
+
+			px := "frontend_" + pxname + "_"
+			for _, field := range []string{
+				"scur", "slim", "stot", "req_tot", "bin", "bout", "dreq", "dresp", "ereq",
+				"hrsp_1xx", "hrsp_2xx", "hrsp_3xx", "hrsp_4xx", "hrsp_5xx", "hrsp_other",
+			} {
+				mx[px+field] = parseStatValue(st[field])
+			}
+		case statTypeBackend:
+			if !c.proxies[pxname] {
+				c.proxies[pxname] = true
+				c.addProxyToCharts(pxname)
+			}
+
+			// same dimensions as the Prometheus endpoint, so backend charts don't depend on the source
+			for metric, field := range map[string]string{
+				metricBackendCurrentSessions:            "scur",
+				metricBackendSessionsTotal:              "stot",
+				metricBackendResponseTimeAverageSeconds: "rtime",
+				metricBackendCurrentQueue:               "qcur",
+				metricBackendQueueTimeAverageSeconds:    "qtime",
+				metricBackendBytesInTotal:               "bin",
+				metricBackendBytesOutTotal:              "bout",
+			} {
+				mx[proxyDimID(metric, pxname)] = parseStatValue(st[field])
+			}
+			for _, code := range []string{"1xx", "2xx", "3xx", "4xx", "5xx", "other"} {
+				metric := cleanMetricName(metricBackendHTTPResponsesTotal) + "_" + code
+				mx[proxyDimID(metric, pxname)] = parseStatValue(st["hrsp_"+code])
+			}
+		case statTypeServer:
+			// '/' is not allowed in proxy and server names, the key is unambiguous
+			id := pxname + "/" + svname
+			seenServers[id] = true
+			if _, ok := c.servers[id]; !ok {
+				c.servers[id] = c.addServerCharts(id, pxname, svname)
+			}
+
+			px := "server_" + id + "_"
+			for _, field := range []string{
+				"weight", "scur", "stot", "qcur", "qtime", "rtime", "bin", "bout", "chkfail", "chkdown",
+			} {
+				mx[px+field] = parseStatValue(st[field])
+			}
+			for _, s := range serverStates {
+				mx[px+"status_"+s] = 0
+			}
+			if s := serverState(st["status"]); s != "" {
+				mx[px+"status_"+s] = 1
+			}
+			for _, s := range serverCheckStates {
+				mx[px+"check_status_"+s] = 0
+			}
+			mx[px+"check_status_"+serverCheckState(st["check_status"])] = 1
+		}
+	}
+
+	for name, charts := range c.frontends {
+		if !seenFrontends[name] {
+			delete(c.frontends, name)
+			c.removeCharts(charts)
+		}
+	}
+	for id, charts := range c.servers {
+		if !seenServers[id] {
+			delete(c.servers, id)
+			c.removeCharts(charts)
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectShowTable(mx map[string]int64) error {
+	bs, err := c.conn.showTable()
+	if err != nil {
+		return err
+	}
+
+	tables := parseShowTable(bs)
+
+	seen := make(map[string]bool)
+
+	for _, tbl := range tables {
+		name := tbl["table"]
+		seen[name] = true
+		if _, ok := c.stickTables[name]; !ok {
+			c.stickTables[name] = c.addStickTableCharts(name, tbl["type"])
+		}
+
+		size, used := parseStatValue(tbl["size"]), parseStatValue(tbl["used"])
+
+		px := "stick_table_" + name + "_"
+		mx[px+"used"] = used
+		mx[px+"free"] = max(size-used, 0)
+		mx[px+"utilization"] = 0
+		if size > 0 {
+			mx[px+"utilization"] = used * 100 * 100 / size
+		}
+	}
+
+	for name, charts := range c.stickTables {
+		if !seen[name] {
+			delete(c.stickTables, name)
+			c.removeCharts(charts)
+		}
+	}
+
+	return nil
+}
+
+func parseShowInfo(bs []byte) (map[string]string, error) {
+	/*
+	   Name: HAProxy
+	   Version: 2.0.33
+	   ...
+	   CurrConns: 15
+	*/
+
+	info := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), ":")
+		if !ok {
+			continue
+		}
+		info[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	if info["Name"] == "" || info["CurrConns"] == "" {
+		return nil, errors.New("unexpected response (not HAProxy)")
+	}
+
+	return info, nil
+}
+
+func parseShowStat(bs []byte) ([]map[string]string, error) {
+	/*
+	   # pxname,svname,qcur,qmax,scur,smax,slim,stot,bin,bout,...
+	   fe_http,FRONTEND,,,12,48,2000,50321,...
+	*/
+
+	var headers []string
+	var stats []map[string]string
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+
+		if len(headers) == 0 {
+			if !strings.HasPrefix(line, "# pxname") {
+				return nil, fmt.Errorf("missing headers (line '%s')", line)
+			}
+			headers = strings.Split(strings.TrimPrefix(line, "# "), ",")
+			continue
+		}
+
+		values := strings.Split(line, ",")
+		st := make(map[string]string, len(headers))
+		for i, v := range values {
+			if i >= len(headers) {
+				break
+			}
+			st[headers[i]] = v
+		}
+		stats = append(stats, st)
+	}
+
+	if len(stats) == 0 {
+		return nil, errors.New("no proxies found")
+	}
+
+	return stats, nil
+}
+
+func parseShowTable(bs []byte) []map[string]string {
+	/*
+	   # table: be_stick, type: ip, size:1048576, used:3
+	*/
+
+	var tables []map[string]string
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if !strings.HasPrefix(line, "# table:") {
+			continue
+		}
+
+		tbl := make(map[string]string)
+		for _, part := range strings.Split(strings.TrimPrefix(line, "# "), ",") {
+			key, value, ok := strings.Cut(part, ":")
+			if !ok {
+				continue
+			}
+			tbl[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		if tbl["table"] != "" {
+			tables = append(tables, tbl)
+		}
+	}
+
+	return tables
+}
+
+func serverState(status string) string {
+	// e.g. "UP", "UP 1/3", "DOWN 1/2", "MAINT (via be/srv)", "no check"
+	if status == "no check" {
+		return "no_check"
+	}
+	s, _, _ := strings.Cut(strings.ToLower(status), " ")
+	for _, state := range serverStates {
+		if s == state {
+			return s
+		}
+	}
+	return ""
+}
+
+func serverCheckState(status string) string {
+	// "* " means the check is currently running
+	switch strings.TrimPrefix(status, "* ") {
+	case "", "UNK":
+		return "none"
+	case "INI":
+		return "initializing"
+	case "L4OK", "L6OK", "L7OK", "L7OKC":
+		return "passed"
+	default:
+		return "failed"
+	}
+}
+
+func parseStatValue(s string) int64 {
+	// empty values mean "not applicable" (e.g. HTTP responses in TCP mode)
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
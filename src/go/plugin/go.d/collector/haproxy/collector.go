@@ -41,13 +41,18 @@ func New() *Collector {
 
 		charts:          charts.Copy(),
 		proxies:         make(map[string]bool),
+		frontends:       make(map[string][]string),
+		servers:         make(map[string][]string),
+		stickTables:     make(map[string][]string),
 		validateMetrics: true,
 	}
 }
 
 type Config struct {
 	web.HTTPConfig `yaml:",inline" json:""`
-	UpdateEvery    int `yaml:"update_every" json:"update_every"`
+	UpdateEvery    int    `yaml:"update_every" json:"update_every"`
+	StatsSocket    string `yaml:"stats_socket,omitempty" json:"stats_socket"`
+	MasterSocket   bool   `yaml:"master_socket,omitempty" json:"master_socket"`
 }
 
 type Collector struct {
@@ -57,9 +62,16 @@ type Collector struct {
 	charts *module.Charts
 
 	prom prometheus.Prometheus
+	conn haproxyConn
 
 	validateMetrics bool
 	proxies         map[string]bool
+	frontends       map[string][]string // name => chart IDs
+	servers         map[string][]string // backend/server => chart IDs
+	stickTables     map[string][]string // name => chart IDs
+
+	// 'show table' is not available in all setups (e.g. restricted socket level)
+	skipStickTables bool
 }
 
 func (c *Collector) Configuration() any {
@@ -71,6 +83,14 @@ func (c *Collector) Init(context.Context) error {
 		return fmt.Errorf("config validation: %v", err)
 	}
 
+	if c.StatsSocket != "" {
+		c.conn = newHaproxyConn(c.Config)
+		if err := c.Charts().Add(*processCharts.Copy()...); err != nil {
+			return err
+		}
+		return nil
+	}
+
 	prom, err := c.initPrometheusClient()
 	if err != nil {
 		return fmt.Errorf("prometheus client initialization: %v", err)
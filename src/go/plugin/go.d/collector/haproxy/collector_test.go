@@ -4,9 +4,11 @@ package haproxy
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
@@ -22,6 +24,10 @@ var (
 	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
 
 	dataVer2310Metrics, _ = os.ReadFile("testdata/v2.3.10/metrics.txt")
+
+	dataVer2033ShowInfo, _  = os.ReadFile("testdata/v2.0.33/show-info.txt")
+	dataVer2033ShowStat, _  = os.ReadFile("testdata/v2.0.33/show-stat.txt")
+	dataVer2033ShowTable, _ = os.ReadFile("testdata/v2.0.33/show-table.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -29,6 +35,10 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataConfigJSON":     dataConfigJSON,
 		"dataConfigYAML":     dataConfigYAML,
 		"dataVer2310Metrics": dataVer2310Metrics,
+
+		"dataVer2033ShowInfo":  dataVer2033ShowInfo,
+		"dataVer2033ShowStat":  dataVer2033ShowStat,
+		"dataVer2033ShowTable": dataVer2033ShowTable,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -46,6 +56,9 @@ func TestCollector_Init(t *testing.T) {
 		"success on default config": {
 			config: New().Config,
 		},
+		"success on 'stats_socket' without 'url'": {
+			config: Config{StatsSocket: "/run/haproxy/admin.sock"},
+		},
 		"fails on unset 'url'": {
 			wantFail: true,
 			config: Config{HTTPConfig: web.HTTPConfig{
@@ -94,6 +107,18 @@ func TestCollector_Check(t *testing.T) {
 			wantFail: false,
 			prepare:  prepareCaseHaproxyV231Metrics,
 		},
+		"success on stats socket v2.0.33": {
+			wantFail: false,
+			prepare:  prepareCaseStatsSocketV2033,
+		},
+		"fails on stats socket unexpected response": {
+			wantFail: true,
+			prepare:  prepareCaseStatsSocketUnexpectedResponse,
+		},
+		"fails on stats socket error": {
+			wantFail: true,
+			prepare:  prepareCaseStatsSocketError,
+		},
 		"fails on response with unexpected metrics (not HAProxy)": {
 			wantFail: true,
 			prepare:  prepareCaseNotHaproxyMetrics,
@@ -158,6 +183,164 @@ func TestCollector_Collect(t *testing.T) {
 				"haproxy_backend_sessions_proxy_proxy2":              4131723,
 			},
 		},
+		"success on stats socket v2.0.33": {
+			prepare: prepareCaseStatsSocketV2033,
+			wantCollected: map[string]int64{
+				"frontend_fe_http_bin":                               81234567,
+				"frontend_fe_http_bout":                              912345678,
+				"frontend_fe_http_dreq":                              3,
+				"frontend_fe_http_dresp":                             1,
+				"frontend_fe_http_ereq":                              7,
+				"frontend_fe_http_hrsp_1xx":                          0,
+				"frontend_fe_http_hrsp_2xx":                          48012,
+				"frontend_fe_http_hrsp_3xx":                          1023,
+				"frontend_fe_http_hrsp_4xx":                          1187,
+				"frontend_fe_http_hrsp_5xx":                          42,
+				"frontend_fe_http_hrsp_other":                        57,
+				"frontend_fe_http_req_tot":                           50321,
+				"frontend_fe_http_scur":                              12,
+				"frontend_fe_http_slim":                              2000,
+				"frontend_fe_http_stot":                              50321,
+				"frontend_fe_tcp_bin":                                1234567,
+				"frontend_fe_tcp_bout":                               7654321,
+				"frontend_fe_tcp_dreq":                               0,
+				"frontend_fe_tcp_dresp":                              0,
+				"frontend_fe_tcp_ereq":                               0,
+				"frontend_fe_tcp_hrsp_1xx":                           0,
+				"frontend_fe_tcp_hrsp_2xx":                           0,
+				"frontend_fe_tcp_hrsp_3xx":                           0,
+				"frontend_fe_tcp_hrsp_4xx":                           0,
+				"frontend_fe_tcp_hrsp_5xx":                           0,
+				"frontend_fe_tcp_hrsp_other":                         0,
+				"frontend_fe_tcp_req_tot":                            0,
+				"frontend_fe_tcp_scur":                               3,
+				"frontend_fe_tcp_slim":                               1000,
+				"frontend_fe_tcp_stot":                               1200,
+				"haproxy_backend_bytes_in_proxy_be_app":              80234567,
+				"haproxy_backend_bytes_in_proxy_be_db":               1234567,
+				"haproxy_backend_bytes_out_proxy_be_app":             912690356,
+				"haproxy_backend_bytes_out_proxy_be_db":              7654321,
+				"haproxy_backend_current_queue_proxy_be_app":         1,
+				"haproxy_backend_current_queue_proxy_be_db":          0,
+				"haproxy_backend_current_sessions_proxy_be_app":      5,
+				"haproxy_backend_current_sessions_proxy_be_db":       3,
+				"haproxy_backend_http_responses_1xx_proxy_be_app":    0,
+				"haproxy_backend_http_responses_1xx_proxy_be_db":     0,
+				"haproxy_backend_http_responses_2xx_proxy_be_app":    48012,
+				"haproxy_backend_http_responses_2xx_proxy_be_db":     0,
+				"haproxy_backend_http_responses_3xx_proxy_be_app":    1023,
+				"haproxy_backend_http_responses_3xx_proxy_be_db":     0,
+				"haproxy_backend_http_responses_4xx_proxy_be_app":    1187,
+				"haproxy_backend_http_responses_4xx_proxy_be_db":     0,
+				"haproxy_backend_http_responses_5xx_proxy_be_app":    42,
+				"haproxy_backend_http_responses_5xx_proxy_be_db":     0,
+				"haproxy_backend_http_responses_other_proxy_be_app":  57,
+				"haproxy_backend_http_responses_other_proxy_be_db":   0,
+				"haproxy_backend_queue_time_average_proxy_be_app":    2,
+				"haproxy_backend_queue_time_average_proxy_be_db":     0,
+				"haproxy_backend_response_time_average_proxy_be_app": 27,
+				"haproxy_backend_response_time_average_proxy_be_db":  0,
+				"haproxy_backend_sessions_proxy_be_app":              50300,
+				"haproxy_backend_sessions_proxy_be_db":               1200,
+				"info_cumconns":                                      51522,
+				"info_cumreq":                                        103211,
+				"info_currconns":                                     15,
+				"info_idle_pct":                                      97,
+				"info_maxconn":                                       4000,
+				"server_be_app/app1_bin":                             41234567,
+				"server_be_app/app1_bout":                            512345678,
+				"server_be_app/app1_check_status_failed":             0,
+				"server_be_app/app1_check_status_initializing":       0,
+				"server_be_app/app1_check_status_none":               0,
+				"server_be_app/app1_check_status_passed":             1,
+				"server_be_app/app1_chkdown":                         0,
+				"server_be_app/app1_chkfail":                         1,
+				"server_be_app/app1_qcur":                            0,
+				"server_be_app/app1_qtime":                           1,
+				"server_be_app/app1_rtime":                           23,
+				"server_be_app/app1_scur":                            5,
+				"server_be_app/app1_status_down":                     0,
+				"server_be_app/app1_status_drain":                    0,
+				"server_be_app/app1_status_maint":                    0,
+				"server_be_app/app1_status_no_check":                 0,
+				"server_be_app/app1_status_nolb":                     0,
+				"server_be_app/app1_status_up":                       1,
+				"server_be_app/app1_stot":                            30100,
+				"server_be_app/app1_weight":                          100,
+				"server_be_app/app2_bin":                             39000000,
+				"server_be_app/app2_bout":                            400345678,
+				"server_be_app/app2_check_status_failed":             1,
+				"server_be_app/app2_check_status_initializing":       0,
+				"server_be_app/app2_check_status_none":               0,
+				"server_be_app/app2_check_status_passed":             0,
+				"server_be_app/app2_chkdown":                         3,
+				"server_be_app/app2_chkfail":                         12,
+				"server_be_app/app2_qcur":                            1,
+				"server_be_app/app2_qtime":                           4,
+				"server_be_app/app2_rtime":                           31,
+				"server_be_app/app2_scur":                            0,
+				"server_be_app/app2_status_down":                     1,
+				"server_be_app/app2_status_drain":                    0,
+				"server_be_app/app2_status_maint":                    0,
+				"server_be_app/app2_status_no_check":                 0,
+				"server_be_app/app2_status_nolb":                     0,
+				"server_be_app/app2_status_up":                       0,
+				"server_be_app/app2_stot":                            20200,
+				"server_be_app/app2_weight":                          100,
+				"server_be_app/app3_bin":                             0,
+				"server_be_app/app3_bout":                            0,
+				"server_be_app/app3_check_status_failed":             0,
+				"server_be_app/app3_check_status_initializing":       1,
+				"server_be_app/app3_check_status_none":               0,
+				"server_be_app/app3_check_status_passed":             0,
+				"server_be_app/app3_chkdown":                         0,
+				"server_be_app/app3_chkfail":                         0,
+				"server_be_app/app3_qcur":                            0,
+				"server_be_app/app3_qtime":                           0,
+				"server_be_app/app3_rtime":                           0,
+				"server_be_app/app3_scur":                            0,
+				"server_be_app/app3_status_down":                     0,
+				"server_be_app/app3_status_drain":                    0,
+				"server_be_app/app3_status_maint":                    1,
+				"server_be_app/app3_status_no_check":                 0,
+				"server_be_app/app3_status_nolb":                     0,
+				"server_be_app/app3_status_up":                       0,
+				"server_be_app/app3_stot":                            0,
+				"server_be_app/app3_weight":                          0,
+				"server_be_db/db1_bin":                               1234567,
+				"server_be_db/db1_bout":                              7654321,
+				"server_be_db/db1_check_status_failed":               0,
+				"server_be_db/db1_check_status_initializing":         0,
+				"server_be_db/db1_check_status_none":                 1,
+				"server_be_db/db1_check_status_passed":               0,
+				"server_be_db/db1_chkdown":                           0,
+				"server_be_db/db1_chkfail":                           0,
+				"server_be_db/db1_qcur":                              0,
+				"server_be_db/db1_qtime":                             0,
+				"server_be_db/db1_rtime":                             0,
+				"server_be_db/db1_scur":                              3,
+				"server_be_db/db1_status_down":                       0,
+				"server_be_db/db1_status_drain":                      0,
+				"server_be_db/db1_status_maint":                      0,
+				"server_be_db/db1_status_no_check":                   1,
+				"server_be_db/db1_status_nolb":                       0,
+				"server_be_db/db1_status_up":                         0,
+				"server_be_db/db1_stot":                              1200,
+				"server_be_db/db1_weight":                            1,
+				"stick_table_be_app_free":                            24,
+				"stick_table_be_app_used":                            1000,
+				"stick_table_be_app_utilization":                     9765,
+				"stick_table_fe_http_free":                           100557,
+				"stick_table_fe_http_used":                           1843,
+				"stick_table_fe_http_utilization":                    179,
+			},
+		},
+		"fails on stats socket unexpected response": {
+			prepare: prepareCaseStatsSocketUnexpectedResponse,
+		},
+		"fails on stats socket error": {
+			prepare: prepareCaseStatsSocketError,
+		},
 		"fails on response with unexpected metrics (not HAProxy)": {
 			prepare: prepareCaseNotHaproxyMetrics,
 		},
@@ -184,6 +367,55 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_StatsSocketRemovedProxies(t *testing.T) {
+	collr := New()
+	collr.StatsSocket = "/run/haproxy/admin.sock"
+	require.NoError(t, collr.Init(context.Background()))
+	conn := &mockHaproxyConn{
+		infoData: dataVer2033ShowInfo,
+		statData: []byte(`# pxname,svname,scur,type,status,check_status
+fe,FRONTEND,1,0,OPEN,
+fe_api,FRONTEND,2,0,OPEN,
+be,app_1,3,2,UP,L7OK
+be_app,1,4,2,UP,L7OK
+`),
+		tableData: dataVer2033ShowTable,
+	}
+	collr.conn = conn
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(3), mx["server_be/app_1_scur"])
+	assert.Equal(t, int64(4), mx["server_be_app/1_scur"])
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	conn.statData = []byte(`# pxname,svname,scur,type,status,check_status
+fe_api,FRONTEND,2,0,OPEN,
+be_app,1,4,2,UP,L7OK
+`)
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	for _, chart := range *collr.Charts() {
+		removed := strings.HasPrefix(chart.ID, "frontend_fe_") && !strings.HasPrefix(chart.ID, "frontend_fe_api_") ||
+			strings.HasPrefix(chart.ID, "server_be/")
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s'", chart.ID)
+	}
+}
+
+func TestCollector_Collect_StatsSocketShowTableError(t *testing.T) {
+	collr, cleanup := prepareCaseStatsSocketV2033(t)
+	defer cleanup()
+	collr.conn.(*mockHaproxyConn).errOnShowTable = true
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.True(t, collr.skipStickTables)
+	assert.Contains(t, mx, "server_be_app/app1_scur")
+	assert.NotContains(t, mx, "stick_table_be_app_used")
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
 func prepareCaseHaproxyV231Metrics(t *testing.T) (*Collector, func()) {
 	t.Helper()
 	srv := httptest.NewServer(http.HandlerFunc(
@@ -246,3 +478,68 @@ func prepareCaseConnectionRefused(t *testing.T) (*Collector, func()) {
 
 	return collr, func() {}
 }
+
+func prepareCaseStatsSocketV2033(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	collr := New()
+	collr.StatsSocket = "/run/haproxy/admin.sock"
+	require.NoError(t, collr.Init(context.Background()))
+	collr.conn = &mockHaproxyConn{
+		infoData:  dataVer2033ShowInfo,
+		statData:  dataVer2033ShowStat,
+		tableData: dataVer2033ShowTable,
+	}
+
+	return collr, func() {}
+}
+
+func prepareCaseStatsSocketUnexpectedResponse(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	collr := New()
+	collr.StatsSocket = "/run/haproxy/admin.sock"
+	require.NoError(t, collr.Init(context.Background()))
+	collr.conn = &mockHaproxyConn{
+		infoData: []byte(`
+Lorem ipsum dolor sit amet, consectetur adipiscing elit.
+Nulla malesuada erat id magna mattis, eu viverra tellus rhoncus.
+`),
+	}
+
+	return collr, func() {}
+}
+
+func prepareCaseStatsSocketError(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	collr := New()
+	collr.StatsSocket = "/run/haproxy/admin.sock"
+	require.NoError(t, collr.Init(context.Background()))
+	collr.conn = &mockHaproxyConn{errOnShowInfo: true}
+
+	return collr, func() {}
+}
+
+type mockHaproxyConn struct {
+	errOnShowInfo  bool
+	errOnShowTable bool
+	infoData       []byte
+	statData       []byte
+	tableData      []byte
+}
+
+func (m *mockHaproxyConn) showInfo() ([]byte, error) {
+	if m.errOnShowInfo {
+		return nil, errors.New("mock.showInfo() error")
+	}
+	return m.infoData, nil
+}
+
+func (m *mockHaproxyConn) showStat() ([]byte, error) {
+	return m.statData, nil
+}
+
+func (m *mockHaproxyConn) showTable() ([]byte, error) {
+	if m.errOnShowTable {
+		return nil, errors.New("mock.showTable() error")
+	}
+	return m.tableData, nil
+}
@@ -11,6 +11,9 @@ import (
 )
 
 func (c *Collector) validateConfig() error {
+	if c.StatsSocket != "" {
+		return nil
+	}
 	if c.URL == "" {
 		return errors.New("'url' is not set")
 	}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
 )
 
 func (c *Collector) collect() (map[string]int64, error) {
@@ -23,7 +25,17 @@ func (c *Collector) collect() (map[string]int64, error) {
 
 	c.collectControllers(mx, controllers)
 
-	c.updateCharts(controllers)
+	if !c.RAIDCharts {
+		c.updateCharts(controllers)
+	}
+
+	raidControllers := raidControllers(controllers)
+	hwraid.WriteMetrics(mx, raidControllers)
+	if c.RAIDCharts {
+		if err := c.raidCharts.Update(raidControllers); err != nil {
+			c.Warning(err)
+		}
+	}
 
 	return mx, nil
 }
@@ -18,10 +18,11 @@ var (
 	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
 	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
 
-	dataP212andP410i, _    = os.ReadFile("testdata/ssacli-P212_P410i.txt")
-	dataP400ar, _          = os.ReadFile("testdata/ssacli-P400ar.txt")
-	dataP408ia, _          = os.ReadFile("testdata/ssacli-P408i-a.txt")
-	dataP400iUnassigned, _ = os.ReadFile("testdata/ssacli-P400i-unassigned.txt")
+	dataP212andP410i, _     = os.ReadFile("testdata/ssacli-P212_P410i.txt")
+	dataP400ar, _           = os.ReadFile("testdata/ssacli-P400ar.txt")
+	dataP400arRecovering, _ = os.ReadFile("testdata/ssacli-P400ar-recovering.txt")
+	dataP408ia, _           = os.ReadFile("testdata/ssacli-P408i-a.txt")
+	dataP400iUnassigned, _  = os.ReadFile("testdata/ssacli-P400i-unassigned.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -29,10 +30,11 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataConfigJSON": dataConfigJSON,
 		"dataConfigYAML": dataConfigYAML,
 
-		"dataP212andP410i":    dataP212andP410i,
-		"dataP400ar":          dataP400ar,
-		"dataP408ia":          dataP408ia,
-		"dataP400iUnassigned": dataP400iUnassigned,
+		"dataP212andP410i":     dataP212andP410i,
+		"dataP400ar":           dataP400ar,
+		"dataP400arRecovering": dataP400arRecovering,
+		"dataP408ia":           dataP408ia,
+		"dataP400iUnassigned":  dataP400iUnassigned,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -150,9 +152,10 @@ func TestCollector_Check(t *testing.T) {
 
 func TestCollector_Collect(t *testing.T) {
 	tests := map[string]struct {
-		prepareMock func() *mockSsacliExec
-		wantMetrics map[string]int64
-		wantCharts  int
+		prepareMock    func() *mockSsacliExec
+		wantMetrics    map[string]int64
+		wantCharts     int
+		wantRAIDCharts int
 	}{
 		"success P212 and P410i": {
 			prepareMock: prepareMockOkP212andP410i,
@@ -160,6 +163,7 @@ func TestCollector_Collect(t *testing.T) {
 				len(arrayChartsTmpl)*3 +
 				len(logicalDriveChartsTmpl)*3 +
 				len(physicalDriveChartsTmpl)*18,
+			wantRAIDCharts: 67,
 			wantMetrics: map[string]int64{
 				"array_A_cntrl_P212_slot_5_status_nok":                    0,
 				"array_A_cntrl_P212_slot_5_status_ok":                     1,
@@ -243,6 +247,236 @@ func TestCollector_Collect(t *testing.T) {
 				"pd_2I:1:6_ld_1_array_A_cntrl_P410i_slot_0_status_nok":    0,
 				"pd_2I:1:6_ld_1_array_A_cntrl_P410i_slot_0_status_ok":     1,
 				"pd_2I:1:6_ld_1_array_A_cntrl_P410i_slot_0_temperature":   36,
+
+				"raid_cntrl_0_cache_status_degraded":                         0,
+				"raid_cntrl_0_cache_status_failed":                           0,
+				"raid_cntrl_0_cache_status_ok":                               1,
+				"raid_cntrl_0_cache_status_unknown":                          0,
+				"raid_cntrl_0_enc_1I:1_status_degraded":                      0,
+				"raid_cntrl_0_enc_1I:1_status_failed":                        0,
+				"raid_cntrl_0_enc_1I:1_status_ok":                            1,
+				"raid_cntrl_0_enc_1I:1_status_unknown":                       0,
+				"raid_cntrl_0_enc_2I:1_status_degraded":                      0,
+				"raid_cntrl_0_enc_2I:1_status_failed":                        0,
+				"raid_cntrl_0_enc_2I:1_status_ok":                            1,
+				"raid_cntrl_0_enc_2I:1_status_unknown":                       0,
+				"raid_cntrl_0_ld_1_rebuild_progress":                         0,
+				"raid_cntrl_0_ld_1_state_degraded":                           0,
+				"raid_cntrl_0_ld_1_state_failed":                             0,
+				"raid_cntrl_0_ld_1_state_offline":                            0,
+				"raid_cntrl_0_ld_1_state_optimal":                            1,
+				"raid_cntrl_0_ld_1_state_rebuilding":                         0,
+				"raid_cntrl_0_ld_1_state_unknown":                            0,
+				"raid_cntrl_0_ld_2_rebuild_progress":                         0,
+				"raid_cntrl_0_ld_2_state_degraded":                           0,
+				"raid_cntrl_0_ld_2_state_failed":                             0,
+				"raid_cntrl_0_ld_2_state_offline":                            0,
+				"raid_cntrl_0_ld_2_state_optimal":                            1,
+				"raid_cntrl_0_ld_2_state_rebuilding":                         0,
+				"raid_cntrl_0_ld_2_state_unknown":                            0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_1I:1:1_state_failed":                        0,
+				"raid_cntrl_0_pd_1I:1:1_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_1I:1:1_state_offline":                       0,
+				"raid_cntrl_0_pd_1I:1:1_state_online":                        1,
+				"raid_cntrl_0_pd_1I:1:1_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_1I:1:1_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_1I:1:1_state_unknown":                       0,
+				"raid_cntrl_0_pd_1I:1:1_temperature":                         37,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_1I:1:2_state_failed":                        0,
+				"raid_cntrl_0_pd_1I:1:2_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_1I:1:2_state_offline":                       0,
+				"raid_cntrl_0_pd_1I:1:2_state_online":                        1,
+				"raid_cntrl_0_pd_1I:1:2_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_1I:1:2_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_1I:1:2_state_unknown":                       0,
+				"raid_cntrl_0_pd_1I:1:2_temperature":                         37,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_1I:1:3_state_failed":                        0,
+				"raid_cntrl_0_pd_1I:1:3_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_1I:1:3_state_offline":                       0,
+				"raid_cntrl_0_pd_1I:1:3_state_online":                        1,
+				"raid_cntrl_0_pd_1I:1:3_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_1I:1:3_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_1I:1:3_state_unknown":                       0,
+				"raid_cntrl_0_pd_1I:1:3_temperature":                         43,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_1I:1:4_state_failed":                        0,
+				"raid_cntrl_0_pd_1I:1:4_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_1I:1:4_state_offline":                       0,
+				"raid_cntrl_0_pd_1I:1:4_state_online":                        1,
+				"raid_cntrl_0_pd_1I:1:4_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_1I:1:4_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_1I:1:4_state_unknown":                       0,
+				"raid_cntrl_0_pd_1I:1:4_temperature":                         44,
+				"raid_cntrl_0_pd_2I:1:5_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_2I:1:5_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_2I:1:5_state_failed":                        0,
+				"raid_cntrl_0_pd_2I:1:5_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_2I:1:5_state_offline":                       0,
+				"raid_cntrl_0_pd_2I:1:5_state_online":                        1,
+				"raid_cntrl_0_pd_2I:1:5_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_2I:1:5_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_2I:1:5_state_unknown":                       0,
+				"raid_cntrl_0_pd_2I:1:5_temperature":                         38,
+				"raid_cntrl_0_pd_2I:1:6_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_2I:1:6_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_2I:1:6_state_failed":                        0,
+				"raid_cntrl_0_pd_2I:1:6_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_2I:1:6_state_offline":                       0,
+				"raid_cntrl_0_pd_2I:1:6_state_online":                        1,
+				"raid_cntrl_0_pd_2I:1:6_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_2I:1:6_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_2I:1:6_state_unknown":                       0,
+				"raid_cntrl_0_pd_2I:1:6_temperature":                         36,
+				"raid_cntrl_0_status_degraded":                               0,
+				"raid_cntrl_0_status_failed":                                 0,
+				"raid_cntrl_0_status_ok":                                     1,
+				"raid_cntrl_0_status_unknown":                                0,
+				"raid_cntrl_5_cache_status_degraded":                         0,
+				"raid_cntrl_5_cache_status_failed":                           0,
+				"raid_cntrl_5_cache_status_ok":                               1,
+				"raid_cntrl_5_cache_status_unknown":                          0,
+				"raid_cntrl_5_enc_2E:1_status_degraded":                      0,
+				"raid_cntrl_5_enc_2E:1_status_failed":                        0,
+				"raid_cntrl_5_enc_2E:1_status_ok":                            1,
+				"raid_cntrl_5_enc_2E:1_status_unknown":                       0,
+				"raid_cntrl_5_ld_1_rebuild_progress":                         0,
+				"raid_cntrl_5_ld_1_state_degraded":                           0,
+				"raid_cntrl_5_ld_1_state_failed":                             0,
+				"raid_cntrl_5_ld_1_state_offline":                            0,
+				"raid_cntrl_5_ld_1_state_optimal":                            1,
+				"raid_cntrl_5_ld_1_state_rebuilding":                         0,
+				"raid_cntrl_5_ld_1_state_unknown":                            0,
+				"raid_cntrl_5_pd_2E:1:10_predictive_failure_status_active":   0,
+				"raid_cntrl_5_pd_2E:1:10_predictive_failure_status_inactive": 1,
+				"raid_cntrl_5_pd_2E:1:10_state_failed":                       0,
+				"raid_cntrl_5_pd_2E:1:10_state_hot_spare":                    0,
+				"raid_cntrl_5_pd_2E:1:10_state_offline":                      0,
+				"raid_cntrl_5_pd_2E:1:10_state_online":                       0,
+				"raid_cntrl_5_pd_2E:1:10_state_rebuilding":                   0,
+				"raid_cntrl_5_pd_2E:1:10_state_unconfigured":                 1,
+				"raid_cntrl_5_pd_2E:1:10_state_unknown":                      0,
+				"raid_cntrl_5_pd_2E:1:10_temperature":                        35,
+				"raid_cntrl_5_pd_2E:1:11_predictive_failure_status_active":   0,
+				"raid_cntrl_5_pd_2E:1:11_predictive_failure_status_inactive": 1,
+				"raid_cntrl_5_pd_2E:1:11_state_failed":                       0,
+				"raid_cntrl_5_pd_2E:1:11_state_hot_spare":                    0,
+				"raid_cntrl_5_pd_2E:1:11_state_offline":                      0,
+				"raid_cntrl_5_pd_2E:1:11_state_online":                       0,
+				"raid_cntrl_5_pd_2E:1:11_state_rebuilding":                   0,
+				"raid_cntrl_5_pd_2E:1:11_state_unconfigured":                 1,
+				"raid_cntrl_5_pd_2E:1:11_state_unknown":                      0,
+				"raid_cntrl_5_pd_2E:1:11_temperature":                        34,
+				"raid_cntrl_5_pd_2E:1:12_predictive_failure_status_active":   0,
+				"raid_cntrl_5_pd_2E:1:12_predictive_failure_status_inactive": 1,
+				"raid_cntrl_5_pd_2E:1:12_state_failed":                       0,
+				"raid_cntrl_5_pd_2E:1:12_state_hot_spare":                    0,
+				"raid_cntrl_5_pd_2E:1:12_state_offline":                      0,
+				"raid_cntrl_5_pd_2E:1:12_state_online":                       0,
+				"raid_cntrl_5_pd_2E:1:12_state_rebuilding":                   0,
+				"raid_cntrl_5_pd_2E:1:12_state_unconfigured":                 1,
+				"raid_cntrl_5_pd_2E:1:12_state_unknown":                      0,
+				"raid_cntrl_5_pd_2E:1:12_temperature":                        31,
+				"raid_cntrl_5_pd_2E:1:1_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:1_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:1_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:1_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:1_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:1_state_online":                        1,
+				"raid_cntrl_5_pd_2E:1:1_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:1_state_unconfigured":                  0,
+				"raid_cntrl_5_pd_2E:1:1_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:1_temperature":                         33,
+				"raid_cntrl_5_pd_2E:1:2_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:2_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:2_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:2_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:2_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:2_state_online":                        1,
+				"raid_cntrl_5_pd_2E:1:2_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:2_state_unconfigured":                  0,
+				"raid_cntrl_5_pd_2E:1:2_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:2_temperature":                         34,
+				"raid_cntrl_5_pd_2E:1:3_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:3_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:3_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:3_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:3_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:3_state_online":                        1,
+				"raid_cntrl_5_pd_2E:1:3_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:3_state_unconfigured":                  0,
+				"raid_cntrl_5_pd_2E:1:3_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:3_temperature":                         35,
+				"raid_cntrl_5_pd_2E:1:4_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:4_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:4_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:4_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:4_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:4_state_online":                        1,
+				"raid_cntrl_5_pd_2E:1:4_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:4_state_unconfigured":                  0,
+				"raid_cntrl_5_pd_2E:1:4_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:4_temperature":                         35,
+				"raid_cntrl_5_pd_2E:1:5_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:5_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:5_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:5_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:5_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:5_state_online":                        1,
+				"raid_cntrl_5_pd_2E:1:5_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:5_state_unconfigured":                  0,
+				"raid_cntrl_5_pd_2E:1:5_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:5_temperature":                         34,
+				"raid_cntrl_5_pd_2E:1:6_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:6_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:6_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:6_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:6_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:6_state_online":                        1,
+				"raid_cntrl_5_pd_2E:1:6_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:6_state_unconfigured":                  0,
+				"raid_cntrl_5_pd_2E:1:6_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:6_temperature":                         33,
+				"raid_cntrl_5_pd_2E:1:7_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:7_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:7_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:7_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:7_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:7_state_online":                        0,
+				"raid_cntrl_5_pd_2E:1:7_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:7_state_unconfigured":                  1,
+				"raid_cntrl_5_pd_2E:1:7_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:7_temperature":                         30,
+				"raid_cntrl_5_pd_2E:1:8_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:8_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:8_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:8_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:8_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:8_state_online":                        0,
+				"raid_cntrl_5_pd_2E:1:8_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:8_state_unconfigured":                  1,
+				"raid_cntrl_5_pd_2E:1:8_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:8_temperature":                         33,
+				"raid_cntrl_5_pd_2E:1:9_predictive_failure_status_active":    0,
+				"raid_cntrl_5_pd_2E:1:9_predictive_failure_status_inactive":  1,
+				"raid_cntrl_5_pd_2E:1:9_state_failed":                        0,
+				"raid_cntrl_5_pd_2E:1:9_state_hot_spare":                     0,
+				"raid_cntrl_5_pd_2E:1:9_state_offline":                       0,
+				"raid_cntrl_5_pd_2E:1:9_state_online":                        0,
+				"raid_cntrl_5_pd_2E:1:9_state_rebuilding":                    0,
+				"raid_cntrl_5_pd_2E:1:9_state_unconfigured":                  1,
+				"raid_cntrl_5_pd_2E:1:9_state_unknown":                       0,
+				"raid_cntrl_5_pd_2E:1:9_temperature":                         30,
+				"raid_cntrl_5_status_degraded":                               0,
+				"raid_cntrl_5_status_failed":                                 0,
+				"raid_cntrl_5_status_ok":                                     1,
+				"raid_cntrl_5_status_unknown":                                0,
 			},
 		},
 		"success P400ar": {
@@ -251,6 +485,7 @@ func TestCollector_Collect(t *testing.T) {
 				len(arrayChartsTmpl)*2 +
 				len(logicalDriveChartsTmpl)*2 +
 				len(physicalDriveChartsTmpl)*8,
+			wantRAIDCharts: 35,
 			wantMetrics: map[string]int64{
 				"array_A_cntrl_P440ar_slot_0_status_nok":                 0,
 				"array_A_cntrl_P440ar_slot_0_status_ok":                  1,
@@ -294,6 +529,291 @@ func TestCollector_Collect(t *testing.T) {
 				"pd_2I:1:8_ld_2_array_B_cntrl_P440ar_slot_0_status_nok":  0,
 				"pd_2I:1:8_ld_2_array_B_cntrl_P440ar_slot_0_status_ok":   1,
 				"pd_2I:1:8_ld_2_array_B_cntrl_P440ar_slot_0_temperature": 29,
+
+				"raid_cntrl_0_bbu_status_degraded":                          0,
+				"raid_cntrl_0_bbu_status_failed":                            0,
+				"raid_cntrl_0_bbu_status_ok":                                1,
+				"raid_cntrl_0_bbu_status_unknown":                           0,
+				"raid_cntrl_0_cache_status_degraded":                        0,
+				"raid_cntrl_0_cache_status_failed":                          0,
+				"raid_cntrl_0_cache_status_ok":                              1,
+				"raid_cntrl_0_cache_status_unknown":                         0,
+				"raid_cntrl_0_cache_temperature":                            41,
+				"raid_cntrl_0_enc_1I:1_status_degraded":                     0,
+				"raid_cntrl_0_enc_1I:1_status_failed":                       0,
+				"raid_cntrl_0_enc_1I:1_status_ok":                           1,
+				"raid_cntrl_0_enc_1I:1_status_unknown":                      0,
+				"raid_cntrl_0_enc_2I:1_status_degraded":                     0,
+				"raid_cntrl_0_enc_2I:1_status_failed":                       0,
+				"raid_cntrl_0_enc_2I:1_status_ok":                           1,
+				"raid_cntrl_0_enc_2I:1_status_unknown":                      0,
+				"raid_cntrl_0_ld_1_rebuild_progress":                        0,
+				"raid_cntrl_0_ld_1_state_degraded":                          0,
+				"raid_cntrl_0_ld_1_state_failed":                            0,
+				"raid_cntrl_0_ld_1_state_offline":                           0,
+				"raid_cntrl_0_ld_1_state_optimal":                           1,
+				"raid_cntrl_0_ld_1_state_rebuilding":                        0,
+				"raid_cntrl_0_ld_1_state_unknown":                           0,
+				"raid_cntrl_0_ld_2_rebuild_progress":                        0,
+				"raid_cntrl_0_ld_2_state_degraded":                          0,
+				"raid_cntrl_0_ld_2_state_failed":                            0,
+				"raid_cntrl_0_ld_2_state_offline":                           0,
+				"raid_cntrl_0_ld_2_state_optimal":                           1,
+				"raid_cntrl_0_ld_2_state_rebuilding":                        0,
+				"raid_cntrl_0_ld_2_state_unknown":                           0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:1_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:1_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:1_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:1_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:1_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:1_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:1_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:1_temperature":                        27,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:2_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:2_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:2_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:2_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:2_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:2_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:2_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:2_temperature":                        28,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:3_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:3_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:3_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:3_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:3_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:3_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:3_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:3_temperature":                        27,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:4_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:4_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:4_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:4_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:4_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:4_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:4_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:4_temperature":                        30,
+				"raid_cntrl_0_pd_2I:1:5_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:5_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:5_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:5_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:5_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:5_state_online":                       1,
+				"raid_cntrl_0_pd_2I:1:5_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_2I:1:5_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:5_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:5_temperature":                        26,
+				"raid_cntrl_0_pd_2I:1:6_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:6_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:6_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:6_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:6_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:6_state_online":                       1,
+				"raid_cntrl_0_pd_2I:1:6_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_2I:1:6_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:6_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:6_temperature":                        28,
+				"raid_cntrl_0_pd_2I:1:7_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:7_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:7_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:7_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:7_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:7_state_online":                       1,
+				"raid_cntrl_0_pd_2I:1:7_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_2I:1:7_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:7_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:7_temperature":                        27,
+				"raid_cntrl_0_pd_2I:1:8_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:8_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:8_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:8_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:8_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:8_state_online":                       1,
+				"raid_cntrl_0_pd_2I:1:8_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_2I:1:8_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:8_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:8_temperature":                        29,
+				"raid_cntrl_0_status_degraded":                              0,
+				"raid_cntrl_0_status_failed":                                0,
+				"raid_cntrl_0_status_ok":                                    1,
+				"raid_cntrl_0_status_unknown":                               0,
+				"raid_cntrl_0_temperature":                                  47,
+			},
+		},
+		"success P400ar recovering": {
+			prepareMock: prepareMockOkP400arRecovering,
+			wantCharts: len(controllerChartsTmpl)*1 +
+				len(arrayChartsTmpl)*2 +
+				len(logicalDriveChartsTmpl)*2 +
+				len(physicalDriveChartsTmpl)*8,
+			wantRAIDCharts: 35,
+			wantMetrics: map[string]int64{
+				"array_A_cntrl_P440ar_slot_0_status_nok":                    0,
+				"array_A_cntrl_P440ar_slot_0_status_ok":                     1,
+				"array_B_cntrl_P440ar_slot_0_status_nok":                    1,
+				"array_B_cntrl_P440ar_slot_0_status_ok":                     0,
+				"cntrl_P440ar_slot_0_cache_battery_status_nok":              0,
+				"cntrl_P440ar_slot_0_cache_battery_status_ok":               1,
+				"cntrl_P440ar_slot_0_cache_presence_status_not_present":     0,
+				"cntrl_P440ar_slot_0_cache_presence_status_present":         1,
+				"cntrl_P440ar_slot_0_cache_status_nok":                      0,
+				"cntrl_P440ar_slot_0_cache_status_ok":                       1,
+				"cntrl_P440ar_slot_0_cache_temperature":                     41,
+				"cntrl_P440ar_slot_0_status_nok":                            0,
+				"cntrl_P440ar_slot_0_status_ok":                             1,
+				"cntrl_P440ar_slot_0_temperature":                           47,
+				"ld_1_array_A_cntrl_P440ar_slot_0_status_nok":               0,
+				"ld_1_array_A_cntrl_P440ar_slot_0_status_ok":                1,
+				"ld_2_array_B_cntrl_P440ar_slot_0_status_nok":               1,
+				"ld_2_array_B_cntrl_P440ar_slot_0_status_ok":                0,
+				"pd_1I:1:1_ld_1_array_A_cntrl_P440ar_slot_0_status_nok":     0,
+				"pd_1I:1:1_ld_1_array_A_cntrl_P440ar_slot_0_status_ok":      1,
+				"pd_1I:1:1_ld_1_array_A_cntrl_P440ar_slot_0_temperature":    27,
+				"pd_1I:1:2_ld_1_array_A_cntrl_P440ar_slot_0_status_nok":     0,
+				"pd_1I:1:2_ld_1_array_A_cntrl_P440ar_slot_0_status_ok":      1,
+				"pd_1I:1:2_ld_1_array_A_cntrl_P440ar_slot_0_temperature":    28,
+				"pd_1I:1:3_ld_1_array_A_cntrl_P440ar_slot_0_status_nok":     0,
+				"pd_1I:1:3_ld_1_array_A_cntrl_P440ar_slot_0_status_ok":      1,
+				"pd_1I:1:3_ld_1_array_A_cntrl_P440ar_slot_0_temperature":    27,
+				"pd_1I:1:4_ld_2_array_B_cntrl_P440ar_slot_0_status_nok":     1,
+				"pd_1I:1:4_ld_2_array_B_cntrl_P440ar_slot_0_status_ok":      0,
+				"pd_1I:1:4_ld_2_array_B_cntrl_P440ar_slot_0_temperature":    30,
+				"pd_2I:1:5_ld_1_array_A_cntrl_P440ar_slot_0_status_nok":     0,
+				"pd_2I:1:5_ld_1_array_A_cntrl_P440ar_slot_0_status_ok":      1,
+				"pd_2I:1:5_ld_1_array_A_cntrl_P440ar_slot_0_temperature":    26,
+				"pd_2I:1:6_ld_1_array_A_cntrl_P440ar_slot_0_status_nok":     0,
+				"pd_2I:1:6_ld_1_array_A_cntrl_P440ar_slot_0_status_ok":      1,
+				"pd_2I:1:6_ld_1_array_A_cntrl_P440ar_slot_0_temperature":    28,
+				"pd_2I:1:7_ld_1_array_A_cntrl_P440ar_slot_0_status_nok":     0,
+				"pd_2I:1:7_ld_1_array_A_cntrl_P440ar_slot_0_status_ok":      1,
+				"pd_2I:1:7_ld_1_array_A_cntrl_P440ar_slot_0_temperature":    27,
+				"pd_2I:1:8_ld_2_array_B_cntrl_P440ar_slot_0_status_nok":     1,
+				"pd_2I:1:8_ld_2_array_B_cntrl_P440ar_slot_0_status_ok":      0,
+				"pd_2I:1:8_ld_2_array_B_cntrl_P440ar_slot_0_temperature":    29,
+				"raid_cntrl_0_bbu_status_degraded":                          0,
+				"raid_cntrl_0_bbu_status_failed":                            0,
+				"raid_cntrl_0_bbu_status_ok":                                1,
+				"raid_cntrl_0_bbu_status_unknown":                           0,
+				"raid_cntrl_0_cache_status_degraded":                        0,
+				"raid_cntrl_0_cache_status_failed":                          0,
+				"raid_cntrl_0_cache_status_ok":                              1,
+				"raid_cntrl_0_cache_status_unknown":                         0,
+				"raid_cntrl_0_cache_temperature":                            41,
+				"raid_cntrl_0_enc_1I:1_status_degraded":                     0,
+				"raid_cntrl_0_enc_1I:1_status_failed":                       0,
+				"raid_cntrl_0_enc_1I:1_status_ok":                           1,
+				"raid_cntrl_0_enc_1I:1_status_unknown":                      0,
+				"raid_cntrl_0_enc_2I:1_status_degraded":                     0,
+				"raid_cntrl_0_enc_2I:1_status_failed":                       0,
+				"raid_cntrl_0_enc_2I:1_status_ok":                           1,
+				"raid_cntrl_0_enc_2I:1_status_unknown":                      0,
+				"raid_cntrl_0_ld_1_rebuild_progress":                        0,
+				"raid_cntrl_0_ld_1_state_degraded":                          0,
+				"raid_cntrl_0_ld_1_state_failed":                            0,
+				"raid_cntrl_0_ld_1_state_offline":                           0,
+				"raid_cntrl_0_ld_1_state_optimal":                           1,
+				"raid_cntrl_0_ld_1_state_rebuilding":                        0,
+				"raid_cntrl_0_ld_1_state_unknown":                           0,
+				"raid_cntrl_0_ld_2_rebuild_progress":                        37,
+				"raid_cntrl_0_ld_2_state_degraded":                          0,
+				"raid_cntrl_0_ld_2_state_failed":                            0,
+				"raid_cntrl_0_ld_2_state_offline":                           0,
+				"raid_cntrl_0_ld_2_state_optimal":                           0,
+				"raid_cntrl_0_ld_2_state_rebuilding":                        1,
+				"raid_cntrl_0_ld_2_state_unknown":                           0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:1_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:1_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:1_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:1_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:1_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:1_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:1_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:1_temperature":                        27,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:2_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:2_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:2_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:2_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:2_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:2_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:2_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:2_temperature":                        28,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:3_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:3_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:3_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:3_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:3_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:3_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:3_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:3_temperature":                        27,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_active":   1,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_inactive": 0,
+				"raid_cntrl_0_pd_1I:1:4_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:4_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:4_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:4_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:4_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:4_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:4_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:4_temperature":                        30,
+				"raid_cntrl_0_pd_2I:1:5_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:5_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:5_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:5_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:5_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:5_state_online":                       1,
+				"raid_cntrl_0_pd_2I:1:5_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_2I:1:5_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:5_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:5_temperature":                        26,
+				"raid_cntrl_0_pd_2I:1:6_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:6_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:6_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:6_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:6_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:6_state_online":                       1,
+				"raid_cntrl_0_pd_2I:1:6_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_2I:1:6_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:6_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:6_temperature":                        28,
+				"raid_cntrl_0_pd_2I:1:7_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:7_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:7_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:7_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:7_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:7_state_online":                       1,
+				"raid_cntrl_0_pd_2I:1:7_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_2I:1:7_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:7_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:7_temperature":                        27,
+				"raid_cntrl_0_pd_2I:1:8_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_2I:1:8_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_2I:1:8_state_failed":                       0,
+				"raid_cntrl_0_pd_2I:1:8_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_2I:1:8_state_offline":                      0,
+				"raid_cntrl_0_pd_2I:1:8_state_online":                       0,
+				"raid_cntrl_0_pd_2I:1:8_state_rebuilding":                   1,
+				"raid_cntrl_0_pd_2I:1:8_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_2I:1:8_state_unknown":                      0,
+				"raid_cntrl_0_pd_2I:1:8_temperature":                        29,
+				"raid_cntrl_0_status_degraded":                              0,
+				"raid_cntrl_0_status_failed":                                0,
+				"raid_cntrl_0_status_ok":                                    1,
+				"raid_cntrl_0_status_unknown":                               0,
+				"raid_cntrl_0_temperature":                                  47,
 			},
 		},
 		"success P408i-a": {
@@ -302,6 +822,7 @@ func TestCollector_Collect(t *testing.T) {
 				len(arrayChartsTmpl)*1 +
 				len(logicalDriveChartsTmpl)*1 +
 				len(physicalDriveChartsTmpl)*4,
+			wantRAIDCharts: 20,
 			wantMetrics: map[string]int64{
 				"array_A_cntrl_HPE_slot_P408i-a_status_nok":                 0,
 				"array_A_cntrl_HPE_slot_P408i-a_status_ok":                  1,
@@ -328,6 +849,75 @@ func TestCollector_Collect(t *testing.T) {
 				"pd_2I:1:4_ld_1_array_A_cntrl_HPE_slot_P408i-a_status_nok":  0,
 				"pd_2I:1:4_ld_1_array_A_cntrl_HPE_slot_P408i-a_status_ok":   1,
 				"pd_2I:1:4_ld_1_array_A_cntrl_HPE_slot_P408i-a_temperature": 30,
+
+				"raid_cntrl_P408i-a_bbu_status_degraded":                          0,
+				"raid_cntrl_P408i-a_bbu_status_failed":                            0,
+				"raid_cntrl_P408i-a_bbu_status_ok":                                1,
+				"raid_cntrl_P408i-a_bbu_status_unknown":                           0,
+				"raid_cntrl_P408i-a_cache_status_degraded":                        0,
+				"raid_cntrl_P408i-a_cache_status_failed":                          0,
+				"raid_cntrl_P408i-a_cache_status_ok":                              1,
+				"raid_cntrl_P408i-a_cache_status_unknown":                         0,
+				"raid_cntrl_P408i-a_enc_1I:0_status_degraded":                     0,
+				"raid_cntrl_P408i-a_enc_1I:0_status_failed":                       0,
+				"raid_cntrl_P408i-a_enc_1I:0_status_ok":                           1,
+				"raid_cntrl_P408i-a_enc_1I:0_status_unknown":                      0,
+				"raid_cntrl_P408i-a_enc_2I:1_status_degraded":                     0,
+				"raid_cntrl_P408i-a_enc_2I:1_status_failed":                       0,
+				"raid_cntrl_P408i-a_enc_2I:1_status_ok":                           1,
+				"raid_cntrl_P408i-a_enc_2I:1_status_unknown":                      0,
+				"raid_cntrl_P408i-a_ld_1_rebuild_progress":                        0,
+				"raid_cntrl_P408i-a_ld_1_state_degraded":                          0,
+				"raid_cntrl_P408i-a_ld_1_state_failed":                            0,
+				"raid_cntrl_P408i-a_ld_1_state_offline":                           0,
+				"raid_cntrl_P408i-a_ld_1_state_optimal":                           1,
+				"raid_cntrl_P408i-a_ld_1_state_rebuilding":                        0,
+				"raid_cntrl_P408i-a_ld_1_state_unknown":                           0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_predictive_failure_status_active":   0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_P408i-a_pd_2I:1:1_state_failed":                       0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_state_hot_spare":                    0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_state_offline":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_state_online":                       1,
+				"raid_cntrl_P408i-a_pd_2I:1:1_state_rebuilding":                   0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_state_unconfigured":                 0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_state_unknown":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:1_temperature":                        34,
+				"raid_cntrl_P408i-a_pd_2I:1:2_predictive_failure_status_active":   0,
+				"raid_cntrl_P408i-a_pd_2I:1:2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_P408i-a_pd_2I:1:2_state_failed":                       0,
+				"raid_cntrl_P408i-a_pd_2I:1:2_state_hot_spare":                    0,
+				"raid_cntrl_P408i-a_pd_2I:1:2_state_offline":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:2_state_online":                       1,
+				"raid_cntrl_P408i-a_pd_2I:1:2_state_rebuilding":                   0,
+				"raid_cntrl_P408i-a_pd_2I:1:2_state_unconfigured":                 0,
+				"raid_cntrl_P408i-a_pd_2I:1:2_state_unknown":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:2_temperature":                        34,
+				"raid_cntrl_P408i-a_pd_2I:1:3_predictive_failure_status_active":   0,
+				"raid_cntrl_P408i-a_pd_2I:1:3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_P408i-a_pd_2I:1:3_state_failed":                       0,
+				"raid_cntrl_P408i-a_pd_2I:1:3_state_hot_spare":                    0,
+				"raid_cntrl_P408i-a_pd_2I:1:3_state_offline":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:3_state_online":                       1,
+				"raid_cntrl_P408i-a_pd_2I:1:3_state_rebuilding":                   0,
+				"raid_cntrl_P408i-a_pd_2I:1:3_state_unconfigured":                 0,
+				"raid_cntrl_P408i-a_pd_2I:1:3_state_unknown":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:3_temperature":                        28,
+				"raid_cntrl_P408i-a_pd_2I:1:4_predictive_failure_status_active":   0,
+				"raid_cntrl_P408i-a_pd_2I:1:4_predictive_failure_status_inactive": 1,
+				"raid_cntrl_P408i-a_pd_2I:1:4_state_failed":                       0,
+				"raid_cntrl_P408i-a_pd_2I:1:4_state_hot_spare":                    0,
+				"raid_cntrl_P408i-a_pd_2I:1:4_state_offline":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:4_state_online":                       1,
+				"raid_cntrl_P408i-a_pd_2I:1:4_state_rebuilding":                   0,
+				"raid_cntrl_P408i-a_pd_2I:1:4_state_unconfigured":                 0,
+				"raid_cntrl_P408i-a_pd_2I:1:4_state_unknown":                      0,
+				"raid_cntrl_P408i-a_pd_2I:1:4_temperature":                        30,
+				"raid_cntrl_P408i-a_status_degraded":                              0,
+				"raid_cntrl_P408i-a_status_failed":                                0,
+				"raid_cntrl_P408i-a_status_ok":                                    1,
+				"raid_cntrl_P408i-a_status_unknown":                               0,
+				"raid_cntrl_P408i-a_temperature":                                  52,
 			},
 		},
 		"success P400i with Unassigned": {
@@ -336,6 +926,7 @@ func TestCollector_Collect(t *testing.T) {
 				len(arrayChartsTmpl)*1 +
 				len(logicalDriveChartsTmpl)*1 +
 				len(physicalDriveChartsTmpl)*4,
+			wantRAIDCharts: 19,
 			wantMetrics: map[string]int64{
 				"array_A_cntrl_P400i_slot_0_status_nok":                   0,
 				"array_A_cntrl_P400i_slot_0_status_ok":                    1,
@@ -361,6 +952,74 @@ func TestCollector_Collect(t *testing.T) {
 				"pd_1I:1:4_ld_1_array_A_cntrl_P400i_slot_0_status_nok":    0,
 				"pd_1I:1:4_ld_1_array_A_cntrl_P400i_slot_0_status_ok":     1,
 				"pd_1I:1:4_ld_1_array_A_cntrl_P400i_slot_0_temperature":   23,
+
+				"raid_cntrl_0_bbu_status_degraded":                          0,
+				"raid_cntrl_0_bbu_status_failed":                            1,
+				"raid_cntrl_0_bbu_status_ok":                                0,
+				"raid_cntrl_0_bbu_status_unknown":                           0,
+				"raid_cntrl_0_cache_status_degraded":                        1,
+				"raid_cntrl_0_cache_status_failed":                          0,
+				"raid_cntrl_0_cache_status_ok":                              0,
+				"raid_cntrl_0_cache_status_unknown":                         0,
+				"raid_cntrl_0_enc_1I:1_status_degraded":                     0,
+				"raid_cntrl_0_enc_1I:1_status_failed":                       0,
+				"raid_cntrl_0_enc_1I:1_status_ok":                           1,
+				"raid_cntrl_0_enc_1I:1_status_unknown":                      0,
+				"raid_cntrl_0_enc_2I:1_status_degraded":                     0,
+				"raid_cntrl_0_enc_2I:1_status_failed":                       0,
+				"raid_cntrl_0_enc_2I:1_status_ok":                           1,
+				"raid_cntrl_0_enc_2I:1_status_unknown":                      0,
+				"raid_cntrl_0_ld_1_rebuild_progress":                        0,
+				"raid_cntrl_0_ld_1_state_degraded":                          0,
+				"raid_cntrl_0_ld_1_state_failed":                            0,
+				"raid_cntrl_0_ld_1_state_offline":                           0,
+				"raid_cntrl_0_ld_1_state_optimal":                           1,
+				"raid_cntrl_0_ld_1_state_rebuilding":                        0,
+				"raid_cntrl_0_ld_1_state_unknown":                           0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:1_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:1_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:1_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:1_state_online":                       0,
+				"raid_cntrl_0_pd_1I:1:1_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:1_state_unconfigured":                 1,
+				"raid_cntrl_0_pd_1I:1:1_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:1_temperature":                        28,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:2_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:2_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:2_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:2_state_online":                       0,
+				"raid_cntrl_0_pd_1I:1:2_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:2_state_unconfigured":                 1,
+				"raid_cntrl_0_pd_1I:1:2_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:2_temperature":                        28,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:3_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:3_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:3_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:3_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:3_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:3_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:3_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:3_temperature":                        23,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_1I:1:4_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_1I:1:4_state_failed":                       0,
+				"raid_cntrl_0_pd_1I:1:4_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_1I:1:4_state_offline":                      0,
+				"raid_cntrl_0_pd_1I:1:4_state_online":                       1,
+				"raid_cntrl_0_pd_1I:1:4_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_1I:1:4_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_1I:1:4_state_unknown":                      0,
+				"raid_cntrl_0_pd_1I:1:4_temperature":                        23,
+				"raid_cntrl_0_status_degraded":                              0,
+				"raid_cntrl_0_status_failed":                                0,
+				"raid_cntrl_0_status_ok":                                    1,
+				"raid_cntrl_0_status_unknown":                               0,
 			},
 		},
 		"fails if error on controllersInfo()": {
@@ -393,6 +1052,16 @@ func TestCollector_Collect(t *testing.T) {
 			assert.Len(t, *collr.Charts(), test.wantCharts, "wantCharts")
 
 			module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+			collr = New()
+			collr.RAIDCharts = true
+			collr.exec = test.prepareMock()
+
+			mx = collr.Collect(context.Background())
+
+			assert.Len(t, *collr.Charts(), test.wantRAIDCharts, "wantRAIDCharts")
+
+			module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 		})
 	}
 }
@@ -412,6 +1081,12 @@ func prepareMockOkP400ar() *mockSsacliExec {
 		infoData: dataP400ar,
 	}
 }
+func prepareMockOkP400arRecovering() *mockSsacliExec {
+	return &mockSsacliExec{
+		infoData: dataP400arRecovering,
+	}
+}
+
 func prepareMockOkP408ia() *mockSsacliExec {
 	return &mockSsacliExec{
 		infoData: dataP408ia,
@@ -23,6 +23,7 @@ type hpssaController struct {
 	cacheModuleTemperatureC string
 	numberOfPorts           string
 	driverName              string
+	enclosures              []*hpssaEnclosure
 	arrays                  map[string]*hpssaArray
 	unassignedDrives        map[string]*hpssaPhysicalDrive
 }
@@ -31,6 +32,12 @@ func (c *hpssaController) uniqueKey() string {
 	return fmt.Sprintf("%s/%s", c.model, c.slot)
 }
 
+type hpssaEnclosure struct {
+	port   string
+	box    string
+	status string
+}
+
 type hpssaArray struct {
 	cntrl *hpssaController
 
@@ -58,6 +65,7 @@ type hpssaLogicalDrive struct {
 	uniqueIdentifier  string
 	logicalDriveLabel string
 	driveType         string
+	faultTolerance    string
 	physicalDrives    map[string]*hpssaPhysicalDrive
 }
 
@@ -176,6 +184,15 @@ func parseSsacliControllersInfo(data []byte) (map[string]*hpssaController, error
 			continue
 		case strings.HasPrefix(line, "   Unassigned"):
 			unassigned = true
+			continue
+		case strings.HasPrefix(line, "   ") && strings.Contains(line, " at Port ") && cntrl != nil:
+			// Internal Drive Cage at Port 1I, Box 1, OK
+			section = ""
+
+			if enc := parseEnclosureLine(line); enc != nil {
+				cntrl.enclosures = append(cntrl.enclosures, enc)
+			}
+
 			continue
 		}
 
@@ -236,6 +253,20 @@ func parseControllerLine(line string) (*hpssaController, error) {
 	return cntrl, nil
 }
 
+func parseEnclosureLine(line string) *hpssaEnclosure {
+	_, v, _ := strings.Cut(line, " at Port ")
+	parts := strings.Split(v, ",")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	return &hpssaEnclosure{
+		port:   strings.TrimSpace(parts[0]),
+		box:    strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(parts[1]), "Box")),
+		status: strings.TrimSpace(parts[2]),
+	}
+}
+
 func parseArrayLine(line string) *hpssaArray {
 	arr := &hpssaArray{
 		id:            getColonSepValue(line),
@@ -329,6 +360,8 @@ func parseLogicalDriveSectionLine(line string, ld *hpssaLogicalDrive) {
 		ld.logicalDriveLabel = getColonSepValue(line)
 	case strings.HasPrefix(line, indent+"Drive Type:"):
 		ld.driveType = getColonSepValue(line)
+	case strings.HasPrefix(line, indent+"Fault Tolerance:"):
+		ld.faultTolerance = getColonSepValue(line)
 	}
 }
 
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hpssa
+
+import (
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
+)
+
+func raidControllers(controllers map[string]*hpssaController) []*hwraid.Controller {
+	var rcs []*hwraid.Controller
+
+	for _, cntrl := range controllers {
+		rc := &hwraid.Controller{
+			ID:     cntrl.slot,
+			Model:  cntrl.model,
+			Status: okRAIDStatus(cntrl.controllerStatus),
+		}
+		if v, ok := parseNumber(cntrl.controllerTemperatureC); ok {
+			rc.Temperature = &v
+		}
+
+		if cntrl.cacheBoardPresent == "True" {
+			rc.Cache = &hwraid.Cache{
+				Status:    cacheRAIDStatus(cntrl.cacheStatus),
+				BBUStatus: batteryRAIDStatus(cntrl.batteryCapacitorStatus),
+			}
+			if v, ok := parseNumber(cntrl.cacheModuleTemperatureC); ok {
+				rc.Cache.Temperature = &v
+			}
+		}
+
+		for _, enc := range cntrl.enclosures {
+			rc.Enclosures = append(rc.Enclosures, &hwraid.Enclosure{
+				ID:     enc.port + ":" + enc.box,
+				Status: okRAIDStatus(enc.status),
+			})
+		}
+
+		for _, pd := range cntrl.unassignedDrives {
+			rc.PhysicalDrives = append(rc.PhysicalDrives, newRAIDPhysicalDrive(pd))
+		}
+
+		for _, arr := range cntrl.arrays {
+			for _, ld := range arr.logicalDrives {
+				rld := &hwraid.LogicalDrive{
+					ID:              ld.id,
+					Name:            ld.diskName,
+					RAIDLevel:       ldRAIDLevel(ld.faultTolerance),
+					RebuildProgress: ptr(int64(0)),
+				}
+				rld.State, *rld.RebuildProgress = ldRAIDState(ld.status)
+				rc.LogicalDrives = append(rc.LogicalDrives, rld)
+
+				for _, pd := range ld.physicalDrives {
+					rc.PhysicalDrives = append(rc.PhysicalDrives, newRAIDPhysicalDrive(pd))
+				}
+			}
+		}
+
+		rcs = append(rcs, rc)
+	}
+
+	return rcs
+}
+
+func newRAIDPhysicalDrive(pd *hpssaPhysicalDrive) *hwraid.PhysicalDrive {
+	// port:box:bay
+	parts := strings.Split(pd.location, ":")
+
+	rpd := &hwraid.PhysicalDrive{
+		ID:    pd.location,
+		Model: strings.Join(strings.Fields(pd.model), " "),
+		State: pdRAIDState(pd),
+		// 'Predictive Failure' is reported as the drive status
+		PredictiveFailure: strings.EqualFold(pd.status, "Predictive Failure"),
+	}
+	if len(parts) == 3 {
+		rpd.Enclosure, rpd.Slot = parts[0]+":"+parts[1], parts[2]
+	}
+	if pd.ld != nil {
+		rpd.LogicalDrive = pd.ld.id
+	}
+	if strings.HasPrefix(pd.interfaceType, "Solid State") {
+		rpd.MediaType = "SSD"
+	} else if pd.interfaceType != "" {
+		rpd.MediaType = "HDD"
+	}
+	if v, ok := parseNumber(pd.currentTemperatureC); ok {
+		rpd.Temperature = &v
+	}
+
+	return rpd
+}
+
+func okRAIDStatus(status string) string {
+	switch status {
+	case "":
+		return ""
+	case "OK":
+		return hwraid.StatusOK
+	default:
+		return hwraid.StatusFailed
+	}
+}
+
+func cacheRAIDStatus(status string) string {
+	// 'OK', 'Temporarily Disabled', 'Permanently Disabled', 'Not Configured'
+	switch s := strings.ToLower(status); {
+	case s == "":
+		return ""
+	case s == "ok":
+		return hwraid.StatusOK
+	case strings.HasPrefix(s, "temporarily disabled"), s == "not configured":
+		return hwraid.StatusDegraded
+	default:
+		return hwraid.StatusFailed
+	}
+}
+
+func batteryRAIDStatus(status string) string {
+	// 'OK', 'Recharging', 'Failed (Replace Batteries)'
+	switch s := strings.ToLower(status); {
+	case s == "", s == "not present":
+		return ""
+	case s == "ok":
+		return hwraid.StatusOK
+	case s == "recharging", s == "charging":
+		return hwraid.StatusDegraded
+	case strings.HasPrefix(s, "failed"):
+		return hwraid.StatusFailed
+	default:
+		return hwraid.StatusUnknown
+	}
+}
+
+func ldRAIDLevel(faultTolerance string) string {
+	if faultTolerance == "" {
+		return ""
+	}
+	return "RAID" + faultTolerance
+}
+
+// ldRAIDState returns the logical drive state and its rebuild progress.
+func ldRAIDState(status string) (string, int64) {
+	// 'OK', 'Interim Recovery Mode', 'Ready for Rebuild', 'Recovering, 45% complete', 'Failed'
+	switch s := strings.ToLower(status); {
+	case s == "ok":
+		return hwraid.LDStateOptimal, 0
+	case s == "interim recovery mode", s == "ready for rebuild":
+		return hwraid.LDStateDegraded, 0
+	case strings.HasPrefix(s, "recovering"):
+		var progress int64
+		if _, v, ok := strings.Cut(s, ","); ok {
+			v, _, _ = strings.Cut(strings.TrimSpace(v), "%")
+			progress, _ = parseNumber(v)
+		}
+		return hwraid.LDStateRebuilding, progress
+	case s == "failed":
+		return hwraid.LDStateFailed, 0
+	case s == "disabled", s == "offline":
+		return hwraid.LDStateOffline, 0
+	default:
+		return hwraid.LDStateUnknown, 0
+	}
+}
+
+func pdRAIDState(pd *hpssaPhysicalDrive) string {
+	switch s := strings.ToLower(pd.status); {
+	case s == "ok", s == "predictive failure":
+		switch pd.driveType {
+		case "Unassigned Drive":
+			return hwraid.PDStateUnconfigured
+		case "Spare Drive":
+			return hwraid.PDStateHotSpare
+		default:
+			return hwraid.PDStateOnline
+		}
+	case strings.HasPrefix(s, "rebuilding"):
+		return hwraid.PDStateRebuilding
+	case s == "failed":
+		return hwraid.PDStateFailed
+	case s == "offline":
+		return hwraid.PDStateOffline
+	default:
+		return hwraid.PDStateUnknown
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
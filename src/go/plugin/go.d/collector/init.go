@@ -31,6 +31,7 @@ import (
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/dovecot"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/elasticsearch"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/envoy"
+	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/etcd"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/ethtool"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/exim"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/fail2ban"
@@ -48,9 +49,11 @@ import (
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/intelgpu"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/ipfs"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/isc_dhcpd"
+	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/journallog"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/k8s_kubelet"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/k8s_kubeproxy"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/k8s_state"
+	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/kafka"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/lighttpd"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/litespeed"
 	_ "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/logind"
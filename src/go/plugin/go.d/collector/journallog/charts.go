@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journallog
+
+import (
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+const (
+	prioEntries = module.Priority + iota
+	prioEntriesByPriority
+	prioEntriesByUnit
+	prioCounterMatches
+)
+
+var baseCharts = module.Charts{
+	entriesChart.Copy(),
+	entriesByPriorityChart.Copy(),
+	entriesByUnitChart.Copy(),
+}
+
+var (
+	entriesChart = module.Chart{
+		ID:       "entries",
+		Title:    "Journal entries",
+		Units:    "entries/s",
+		Fam:      "entries",
+		Ctx:      "journallog.entries",
+		Priority: prioEntries,
+		Dims: module.Dims{
+			{ID: "entries_read", Name: "read", Algo: module.Incremental},
+			{ID: "entries_unparsed", Name: "unparsed", Algo: module.Incremental},
+			{ID: "entries_dropped", Name: "dropped", Algo: module.Incremental},
+		},
+	}
+	entriesByPriorityChart = module.Chart{
+		ID:       "entries_by_priority",
+		Title:    "Journal entries by priority",
+		Units:    "entries/s",
+		Fam:      "entries",
+		Ctx:      "journallog.entries_by_priority",
+		Type:     module.Stacked,
+		Priority: prioEntriesByPriority,
+		Dims: module.Dims{
+			{ID: "priority_emerg", Name: "emerg", Algo: module.Incremental},
+			{ID: "priority_alert", Name: "alert", Algo: module.Incremental},
+			{ID: "priority_crit", Name: "crit", Algo: module.Incremental},
+			{ID: "priority_err", Name: "err", Algo: module.Incremental},
+			{ID: "priority_warning", Name: "warning", Algo: module.Incremental},
+			{ID: "priority_notice", Name: "notice", Algo: module.Incremental},
+			{ID: "priority_info", Name: "info", Algo: module.Incremental},
+			{ID: "priority_debug", Name: "debug", Algo: module.Incremental},
+		},
+	}
+	entriesByUnitChart = module.Chart{
+		ID:       "entries_by_unit",
+		Title:    "Journal entries by unit",
+		Units:    "entries/s",
+		Fam:      "entries",
+		Ctx:      "journallog.entries_by_unit",
+		Type:     module.Stacked,
+		Priority: prioEntriesByUnit,
+	}
+)
+
+var counterMatchesChartTmpl = module.Chart{
+	ID:       "counter_%s_matches",
+	Title:    "Journal entries matching counter by unit",
+	Units:    "entries/s",
+	Fam:      "counters",
+	Ctx:      "journallog.counter_matches",
+	Type:     module.Stacked,
+	Priority: prioCounterMatches,
+}
+
+func (c *Collector) addCounterCharts() {
+	for _, cn := range c.counters {
+		chart := counterMatchesChartTmpl.Copy()
+		chart.ID = fmt.Sprintf(chart.ID, cn.name)
+		chart.Labels = []module.Label{
+			{Key: "counter", Value: cn.name},
+		}
+
+		if err := c.Charts().Add(chart); err != nil {
+			c.Warning(err)
+		}
+	}
+}
+
+func (c *Collector) addUnitDimension(unit string) {
+	c.addDimension(entriesByUnitChart.ID, &module.Dim{ID: "unit_" + unit, Name: unit, Algo: module.Incremental})
+	c.mx["unit_"+unit] = 0
+}
+
+func (c *Collector) addCounterDimension(name, unit string) {
+	id := counterMetricKey(name, unit)
+	c.addDimension(fmt.Sprintf(counterMatchesChartTmpl.ID, name), &module.Dim{ID: id, Name: unit, Algo: module.Incremental})
+	c.mx[id] = 0
+}
+
+func (c *Collector) removeUnitDimensions(unit string, ue *unitEntry) {
+	c.removeDimension(entriesByUnitChart.ID, "unit_"+unit)
+	for name := range ue.counters {
+		c.removeDimension(fmt.Sprintf(counterMatchesChartTmpl.ID, name), counterMetricKey(name, unit))
+	}
+}
+
+func (c *Collector) addDimension(chartID string, dim *module.Dim) {
+	chart := c.Charts().Get(chartID)
+	if chart == nil {
+		c.Warningf("chart '%s' is not found", chartID)
+		return
+	}
+	if err := chart.AddDim(dim); err != nil {
+		c.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (c *Collector) removeDimension(chartID, dimID string) {
+	delete(c.mx, dimID)
+
+	chart := c.Charts().Get(chartID)
+	if chart == nil {
+		return
+	}
+	if err := chart.MarkDimRemove(dimID, true); err != nil {
+		c.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
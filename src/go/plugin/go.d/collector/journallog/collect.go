@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journallog
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"strconv"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/logs"
+)
+
+// priorities are syslog priority names indexed by the PRIORITY field value.
+var priorities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+func (c *Collector) collect() (map[string]int64, error) {
+	if c.parser == nil {
+		return nil, errors.New("journal parser is not created")
+	}
+
+	err := c.collectEntries()
+
+	c.mx["entries_dropped"] = c.journal.Dropped()
+
+	c.removeStaleUnits()
+
+	return maps.Clone(c.mx), err
+}
+
+func (c *Collector) collectEntries() error {
+	for {
+		c.line.reset()
+
+		if err := c.parser.ReadLine(c.line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if !logs.IsParseError(err) {
+				return err
+			}
+			c.mx["entries_unparsed"]++
+			continue
+		}
+
+		c.collectEntry()
+	}
+}
+
+func (c *Collector) collectEntry() {
+	c.mx["entries_read"]++
+
+	if v, ok := c.line.field(fieldPriority); ok {
+		if p, err := strconv.Atoi(v); err == nil && p >= 0 && p < len(priorities) {
+			c.mx["priority_"+priorities[p]]++
+		}
+	}
+
+	unit := c.line.unit()
+	ue, ok := c.seenUnits[unit]
+	if !ok {
+		ue = &unitEntry{counters: make(map[string]bool)}
+		c.seenUnits[unit] = ue
+		c.addUnitDimension(unit)
+	}
+	ue.lastSeen = c.now()
+	c.mx["unit_"+unit]++
+
+	for _, cn := range c.counters {
+		if v, ok := c.line.field(cn.field); ok && cn.match.MatchString(v) {
+			if !ue.counters[cn.name] {
+				ue.counters[cn.name] = true
+				c.addCounterDimension(cn.name, unit)
+			}
+			c.mx[counterMetricKey(cn.name, unit)]++
+		}
+	}
+}
+
+func (c *Collector) removeStaleUnits() {
+	ttl := c.UnitTTL.Duration()
+	if ttl <= 0 {
+		return
+	}
+
+	now := c.now()
+	for unit, ue := range c.seenUnits {
+		if now.Sub(ue.lastSeen) < ttl {
+			continue
+		}
+		c.Debugf("removing unit '%s': no entries for %s", unit, ttl)
+		delete(c.seenUnits, unit)
+		c.removeUnitDimensions(unit, ue)
+	}
+}
+
+func counterMetricKey(name, unit string) string {
+	return "counter_" + name + ":" + unit
+}
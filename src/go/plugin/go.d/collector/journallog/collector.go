@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journallog
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/logs"
+)
+
+//go:embed "config_schema.json"
+var configSchema string
+
+func init() {
+	module.Register("journallog", module.Creator{
+		JobConfigSchema: configSchema,
+		Create:          func() module.Module { return New() },
+		Config:          func() any { return &Config{} },
+	})
+}
+
+func New() *Collector {
+	return &Collector{
+		Config: Config{
+			UnitTTL: confopt.Duration(time.Minute * 10),
+		},
+		charts:    baseCharts.Copy(),
+		now:       time.Now,
+		mx:        make(map[string]int64),
+		seenUnits: make(map[string]*unitEntry),
+	}
+}
+
+type (
+	Config struct {
+		UpdateEvery              int `yaml:"update_every,omitempty" json:"update_every"`
+		logs.JournalReaderConfig `yaml:",inline" json:""`
+		Counters                 []CounterConfig  `yaml:"counters,omitempty" json:"counters"`
+		UnitTTL                  confopt.Duration `yaml:"unit_ttl,omitempty" json:"unit_ttl"`
+	}
+	CounterConfig struct {
+		// Name is the counter name, used in the chart ID and the 'counter' label.
+		Name string `yaml:"name" json:"name"`
+		// Field is the journal field the pattern is matched against, MESSAGE if not set.
+		Field string `yaml:"field,omitempty" json:"field"`
+		// Match is a matcher expression (https://github.com/netdata/netdata/tree/master/src/go/pkg/matcher#supported-format).
+		Match string `yaml:"match" json:"match"`
+	}
+)
+
+type Collector struct {
+	module.Base
+	Config `yaml:",inline" json:""`
+
+	charts *module.Charts
+
+	journal *logs.JournalReader
+	parser  logs.Parser
+	line    *logLine
+
+	now func() time.Time
+
+	counters  []*counter
+	mx        map[string]int64
+	seenUnits map[string]*unitEntry
+}
+
+// unitEntry tracks the dimensions of a unit, units without entries within the TTL are removed
+// (transient units like session-N.scope and run-*.service would grow the charts without limit).
+type unitEntry struct {
+	lastSeen time.Time
+	// counters that matched an entry of the unit, only they have a dimension for it
+	counters map[string]bool
+}
+
+func (c *Collector) Configuration() any {
+	return c.Config
+}
+
+func (c *Collector) Init(context.Context) error {
+	counters, err := c.initCounters()
+	if err != nil {
+		return fmt.Errorf("init counters: %v", err)
+	}
+	c.counters = counters
+
+	c.line = newLogLine(c.counters)
+
+	for _, dim := range entriesChart.Dims {
+		c.mx[dim.ID] = 0
+	}
+	for _, dim := range entriesByPriorityChart.Dims {
+		c.mx[dim.ID] = 0
+	}
+	c.addCounterCharts()
+
+	return nil
+}
+
+func (c *Collector) Check(context.Context) error {
+	// Note: these inits are here to make auto-detection retry working
+	if err := c.openJournal(); err != nil {
+		return fmt.Errorf("failed to open journal: %v", err)
+	}
+
+	if err := c.createParser(); err != nil {
+		return fmt.Errorf("failed to create journal parser: %v", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) Charts() *module.Charts {
+	return c.charts
+}
+
+func (c *Collector) Collect(context.Context) map[string]int64 {
+	mx, err := c.collect()
+	if err != nil {
+		c.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (c *Collector) Cleanup(context.Context) {
+	if c.journal != nil {
+		_ = c.journal.Close()
+		c.journal = nil
+	}
+}
@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journallog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/logs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
+	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
+
+	dataJournalExport, _ = os.ReadFile("testdata/journal.export")
+)
+
+func Test_testDataIsValid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"dataConfigJSON":    dataConfigJSON,
+		"dataConfigYAML":    dataConfigYAML,
+		"dataJournalExport": dataJournalExport,
+	} {
+		require.NotNil(t, data, name)
+	}
+}
+
+func TestCollector_ConfigurationSerialize(t *testing.T) {
+	module.TestConfigurationSerialize(t, &Collector{}, dataConfigJSON, dataConfigYAML)
+}
+
+func TestCollector_Init(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		wantFail bool
+	}{
+		"success with default config": {
+			config: New().Config,
+		},
+		"success with counters": {
+			config: Config{
+				Counters: []CounterConfig{
+					{Name: "sshd_auth_failures", Match: "~ ^Failed password"},
+					{Name: "oom_kills", Field: "MESSAGE", Match: "* Out of memory: Killed process*"},
+				},
+			},
+		},
+		"fails on counter without name": {
+			wantFail: true,
+			config:   Config{Counters: []CounterConfig{{Match: "* *"}}},
+		},
+		"fails on counter with bad name": {
+			wantFail: true,
+			config:   Config{Counters: []CounterConfig{{Name: "auth:failures", Match: "* *"}}},
+		},
+		"fails on duplicate counter names": {
+			wantFail: true,
+			config: Config{Counters: []CounterConfig{
+				{Name: "failures", Match: "* *"},
+				{Name: "failures", Match: "* *"},
+			}},
+		},
+		"fails on counter without match": {
+			wantFail: true,
+			config:   Config{Counters: []CounterConfig{{Name: "failures"}}},
+		},
+		"fails on counter with bad match": {
+			wantFail: true,
+			config:   Config{Counters: []CounterConfig{{Name: "failures", Match: "~ ("}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Config = test.config
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				assert.NoError(t, collr.Init(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Charts(t *testing.T) {
+	collr := New()
+	collr.Counters = []CounterConfig{{Name: "sshd_auth_failures", Match: "~ ^Failed password"}}
+	require.NoError(t, collr.Init(context.Background()))
+
+	assert.Len(t, *collr.Charts(), len(baseCharts)+1)
+	assert.True(t, collr.Charts().Has("counter_sshd_auth_failures_matches"))
+}
+
+func TestCollector_Check(t *testing.T) {
+	tests := map[string]struct {
+		prepare  func(t *testing.T) *Collector
+		wantFail bool
+	}{
+		"success on journal": {
+			prepare: func(t *testing.T) *Collector {
+				prepareFakeJournalctl(t, dataJournalExport)
+				return New()
+			},
+		},
+		"fails if journalctl not found": {
+			wantFail: true,
+			prepare: func(t *testing.T) *Collector {
+				t.Setenv("PATH", t.TempDir())
+				return New()
+			},
+		},
+		"fails on bad journal config": {
+			wantFail: true,
+			prepare: func(t *testing.T) *Collector {
+				prepareFakeJournalctl(t, dataJournalExport)
+				collr := New()
+				collr.Priority = "error"
+				return collr
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := test.prepare(t)
+			defer collr.Cleanup(context.Background())
+
+			require.NoError(t, collr.Init(context.Background()))
+
+			if test.wantFail {
+				assert.Error(t, collr.Check(context.Background()))
+			} else {
+				assert.NoError(t, collr.Check(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	argsFile := prepareFakeJournalctl(t, dataJournalExport)
+
+	collr := New()
+	collr.JournalReaderConfig = logs.JournalReaderConfig{
+		Units:    []string{"sshd.service", "nginx.service"},
+		Priority: "0..6",
+	}
+	collr.Counters = []CounterConfig{
+		{Name: "sshd_auth_failures", Match: "~ ^Failed password"},
+		{Name: "oom_kills", Match: "* Out of memory: Killed process*"},
+		{Name: "killed_by_signal", Match: "~ exited on signal 9$"},
+	}
+	defer collr.Cleanup(context.Background())
+
+	require.NoError(t, collr.Init(context.Background()))
+	require.NoError(t, collr.Check(context.Background()))
+
+	var mx map[string]int64
+	require.Eventually(t, func() bool {
+		mx = collr.Collect(context.Background())
+		return mx["entries_read"] == 6
+	}, time.Second*5, time.Millisecond*50)
+
+	expected := map[string]int64{
+		"counter_killed_by_signal:nginx.service":  1,
+		"counter_oom_kills:kernel":                1,
+		"counter_sshd_auth_failures:sshd.service": 2,
+		"entries_dropped":                         0,
+		"entries_read":                            6,
+		"entries_unparsed":                        0,
+		"priority_alert":                          0,
+		"priority_crit":                           0,
+		"priority_debug":                          0,
+		"priority_emerg":                          0,
+		"priority_err":                            2,
+		"priority_info":                           2,
+		"priority_notice":                         1,
+		"priority_warning":                        0,
+		"unit_kernel":                             1,
+		"unit_nginx.service":                      1,
+		"unit_sshd.service":                       3,
+		"unit_unknown":                            1,
+	}
+
+	assert.Equal(t, expected, mx)
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	// no new entries, the counters stay the same
+	assert.Equal(t, expected, collr.Collect(context.Background()))
+
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(args), "--unit=sshd.service --unit=nginx.service --priority=0..6")
+}
+
+func TestCollector_Collect_RemovesStaleUnits(t *testing.T) {
+	prepareFakeJournalctl(t, dataJournalExport)
+
+	collr := New()
+	collr.Counters = []CounterConfig{
+		{Name: "sshd_auth_failures", Match: "~ ^Failed password"},
+	}
+	now := time.Now()
+	collr.now = func() time.Time { return now }
+	defer collr.Cleanup(context.Background())
+
+	require.NoError(t, collr.Init(context.Background()))
+	require.NoError(t, collr.Check(context.Background()))
+
+	require.Eventually(t, func() bool {
+		return collr.Collect(context.Background())["entries_read"] == 6
+	}, time.Second*5, time.Millisecond*50)
+
+	byUnit := collr.Charts().Get("entries_by_unit")
+	counter := collr.Charts().Get("counter_sshd_auth_failures_matches")
+	require.NotNil(t, byUnit)
+	require.NotNil(t, counter)
+
+	assert.Len(t, byUnit.Dims, 4)
+	// only units with matching entries have a counter dimension
+	require.Len(t, counter.Dims, 1)
+	assert.Equal(t, "counter_sshd_auth_failures:sshd.service", counter.Dims[0].ID)
+
+	now = now.Add(collr.UnitTTL.Duration())
+	mx := collr.Collect(context.Background())
+
+	assert.NotContains(t, mx, "unit_sshd.service")
+	assert.NotContains(t, mx, "counter_sshd_auth_failures:sshd.service")
+	assert.Empty(t, collr.seenUnits)
+	for _, dim := range byUnit.Dims {
+		assert.Truef(t, dim.Obsolete, "dim '%s' obsolete", dim.ID)
+	}
+	assert.True(t, counter.Dims[0].Obsolete)
+}
+
+func TestCollector_Cleanup(t *testing.T) {
+	prepareFakeJournalctl(t, dataJournalExport)
+
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+	require.NoError(t, collr.Check(context.Background()))
+
+	assert.NotPanics(t, func() { collr.Cleanup(context.Background()) })
+	assert.Nil(t, collr.journal)
+	assert.NotPanics(t, func() { collr.Cleanup(context.Background()) })
+}
+
+// prepareFakeJournalctl puts a journalctl script that outputs the export data and keeps following
+// in front of PATH. It returns the path of the file the script writes its arguments to.
+func prepareFakeJournalctl(t *testing.T, export []byte) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "journal.export")
+	argsFile := filepath.Join(dir, "args")
+	script := filepath.Join(dir, "journalctl")
+
+	require.NoError(t, os.WriteFile(dataFile, export, 0644))
+	content := "#!/bin/sh\necho \"$@\" >> " + argsFile + "\ncat " + dataFile + "\nexec sleep 30\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return argsFile
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journallog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/logs"
+)
+
+const defaultCounterField = "MESSAGE"
+
+// counter names are used in metric keys, ':' separates the counter name from the unit name there
+var reCounterName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+type counter struct {
+	name  string
+	field string
+	match matcher.Matcher
+}
+
+func (c *Collector) initCounters() ([]*counter, error) {
+	var counters []*counter
+	seen := make(map[string]bool)
+
+	for i, cfg := range c.Counters {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("counter %d: 'name' not set", i+1)
+		}
+		if !reCounterName.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("counter '%s': bad name (allowed characters: a-z, A-Z, 0-9, '_', '-')", cfg.Name)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("counter '%s': duplicate name", cfg.Name)
+		}
+		seen[cfg.Name] = true
+
+		if cfg.Match == "" {
+			return nil, fmt.Errorf("counter '%s': 'match' not set", cfg.Name)
+		}
+		m, err := matcher.Parse(cfg.Match)
+		if err != nil {
+			return nil, fmt.Errorf("counter '%s': bad match: %v", cfg.Name, err)
+		}
+
+		field := cfg.Field
+		if field == "" {
+			field = defaultCounterField
+		}
+
+		counters = append(counters, &counter{name: cfg.Name, field: field, match: m})
+	}
+
+	return counters, nil
+}
+
+func (c *Collector) openJournal() error {
+	c.Cleanup(context.Background())
+
+	journal, err := logs.OpenJournal(c.JournalReaderConfig, c.Logger)
+	if err != nil {
+		return err
+	}
+	c.journal = journal
+
+	return nil
+}
+
+func (c *Collector) createParser() error {
+	if c.journal == nil {
+		return errors.New("journal is not opened")
+	}
+
+	parser, err := logs.NewParser(logs.ParserConfig{LogType: logs.TypeJournal}, c.journal)
+	if err != nil {
+		return err
+	}
+	c.parser = parser
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package journallog
+
+const (
+	fieldPriority   = "PRIORITY"
+	fieldUnit       = "_SYSTEMD_UNIT"
+	fieldTransport  = "_TRANSPORT"
+	transportKernel = "kernel"
+
+	unitKernel  = "kernel"
+	unitUnknown = "unknown"
+)
+
+// logLine keeps the values of the journal entry fields used by the collector, other fields are ignored.
+type logLine struct {
+	wanted map[string]bool
+	fields map[string]string
+}
+
+func newLogLine(counters []*counter) *logLine {
+	wanted := map[string]bool{
+		fieldPriority:  true,
+		fieldUnit:      true,
+		fieldTransport: true,
+	}
+	for _, cn := range counters {
+		wanted[cn.field] = true
+	}
+	return &logLine{
+		wanted: wanted,
+		fields: make(map[string]string),
+	}
+}
+
+func (l *logLine) Assign(name, value string) error {
+	if l.wanted[name] {
+		l.fields[name] = value
+	}
+	return nil
+}
+
+func (l *logLine) reset() {
+	clear(l.fields)
+}
+
+func (l *logLine) field(name string) (string, bool) {
+	v, ok := l.fields[name]
+	return v, ok
+}
+
+// unit returns the systemd unit that logged the entry.
+// Kernel messages have no unit, they are attributed to the "kernel" pseudo unit.
+func (l *logLine) unit() string {
+	if v := l.fields[fieldUnit]; v != "" {
+		return v
+	}
+	if l.fields[fieldTransport] == transportKernel {
+		return unitKernel
+	}
+	return unitUnknown
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+func newCache() *cache {
+	return &cache{
+		brokers:    make(map[int32]*brokerCacheItem),
+		topics:     make(map[string]*topicCacheItem),
+		partitions: make(map[string]*partitionCacheItem),
+		groups:     make(map[string]*groupCacheItem),
+	}
+}
+
+type (
+	cache struct {
+		brokers    map[int32]*brokerCacheItem
+		topics     map[string]*topicCacheItem
+		partitions map[string]*partitionCacheItem
+		groups     map[string]*groupCacheItem
+	}
+	brokerCacheItem struct {
+		id        int32
+		host      string
+		rack      string
+		seen      bool
+		hasCharts bool
+		relabel   bool
+	}
+	topicCacheItem struct {
+		name      string
+		seen      bool
+		hasCharts bool
+	}
+	partitionCacheItem struct {
+		topic     string
+		partition int32
+		seen      bool
+		hasCharts bool
+	}
+	groupCacheItem struct {
+		name      string
+		seen      bool
+		hasCharts bool
+		topics    map[string]*groupTopicCacheItem
+	}
+	groupTopicCacheItem struct {
+		group      string
+		topic      string
+		seen       bool
+		hasCharts  bool
+		partitions map[int32]*groupPartitionCacheItem
+	}
+	groupPartitionCacheItem struct {
+		partition int32
+		seen      bool
+		hasDim    bool
+	}
+)
+
+func (c *cache) resetSeen() {
+	for _, v := range c.brokers {
+		v.seen = false
+	}
+	for _, v := range c.topics {
+		v.seen = false
+	}
+	for _, v := range c.partitions {
+		v.seen = false
+	}
+	for _, v := range c.groups {
+		v.seen = false
+		for _, t := range v.topics {
+			t.seen = false
+			for _, p := range t.partitions {
+				p.seen = false
+			}
+		}
+	}
+}
+
+func (c *cache) getBroker(b brokerMetadata) *brokerCacheItem {
+	v, ok := c.brokers[b.NodeID]
+	if !ok {
+		v = &brokerCacheItem{id: b.NodeID, host: b.Host, rack: b.Rack}
+		c.brokers[b.NodeID] = v
+	}
+	if v.host != b.Host || v.rack != b.Rack {
+		// the broker was moved, its charts must be recreated with the new labels
+		v.host, v.rack = b.Host, b.Rack
+		v.relabel = v.hasCharts
+	}
+	return v
+}
+
+func (c *cache) getTopic(name string) *topicCacheItem {
+	v, ok := c.topics[name]
+	if !ok {
+		v = &topicCacheItem{name: name}
+		c.topics[name] = v
+	}
+	return v
+}
+
+func (c *cache) getPartition(key, topic string, partition int32) *partitionCacheItem {
+	v, ok := c.partitions[key]
+	if !ok {
+		v = &partitionCacheItem{topic: topic, partition: partition}
+		c.partitions[key] = v
+	}
+	return v
+}
+
+func (c *cache) getGroup(name string) *groupCacheItem {
+	v, ok := c.groups[name]
+	if !ok {
+		v = &groupCacheItem{name: name, topics: make(map[string]*groupTopicCacheItem)}
+		c.groups[name] = v
+	}
+	return v
+}
+
+func (g *groupCacheItem) getTopic(topic string) *groupTopicCacheItem {
+	v, ok := g.topics[topic]
+	if !ok {
+		v = &groupTopicCacheItem{group: g.name, topic: topic, partitions: make(map[int32]*groupPartitionCacheItem)}
+		g.topics[topic] = v
+	}
+	return v
+}
+
+func (t *groupTopicCacheItem) getPartition(partition int32) *groupPartitionCacheItem {
+	v, ok := t.partitions[partition]
+	if !ok {
+		v = &groupPartitionCacheItem{partition: partition}
+		t.partitions[partition] = v
+	}
+	return v
+}
@@ -0,0 +1,675 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+const (
+	prioBrokers = module.Priority + iota
+	prioTopics
+	prioPartitions
+	prioPartitionsHealth
+	prioReplicas
+	prioLogDirsOffline
+	prioConsumerGroups
+	prioConsumerGroupsByState
+
+	prioBrokerPartitions
+	prioBrokerLogSize
+	prioBrokerLogDirsOffline
+	prioBrokerController
+
+	prioTopicMessagesRate
+	prioTopicRetainedMessages
+	prioTopicLogSize
+	prioTopicPartitions
+	prioTopicPartitionsHealth
+	prioTopicReplicas
+
+	prioPartitionMessagesRate
+	prioPartitionRetainedMessages
+	prioPartitionLogSize
+	prioPartitionReplicas
+	prioPartitionStatus
+
+	prioConsumerGroupLag
+	prioConsumerGroupState
+	prioConsumerGroupMembers
+
+	prioConsumerGroupTopicLag
+	prioConsumerGroupTopicPartitionLag
+)
+
+var clusterCharts = module.Charts{
+	chartBrokers.Copy(),
+	chartTopics.Copy(),
+	chartPartitions.Copy(),
+	chartPartitionsHealth.Copy(),
+	chartReplicas.Copy(),
+	chartLogDirsOffline.Copy(),
+	chartConsumerGroups.Copy(),
+	chartConsumerGroupsByState.Copy(),
+}
+
+var (
+	chartBrokers = module.Chart{
+		ID:       "brokers",
+		Title:    "Live brokers",
+		Units:    "brokers",
+		Fam:      "cluster",
+		Ctx:      "kafka.brokers",
+		Priority: prioBrokers,
+		Dims: module.Dims{
+			{ID: "brokers"},
+		},
+	}
+	chartTopics = module.Chart{
+		ID:       "topics",
+		Title:    "Topics",
+		Units:    "topics",
+		Fam:      "cluster",
+		Ctx:      "kafka.topics",
+		Priority: prioTopics,
+		Dims: module.Dims{
+			{ID: "topics"},
+		},
+	}
+	chartPartitions = module.Chart{
+		ID:       "partitions",
+		Title:    "Partitions",
+		Units:    "partitions",
+		Fam:      "cluster",
+		Ctx:      "kafka.partitions",
+		Priority: prioPartitions,
+		Dims: module.Dims{
+			{ID: "partitions"},
+		},
+	}
+	chartPartitionsHealth = module.Chart{
+		ID:       "partitions_health",
+		Title:    "Unhealthy partitions",
+		Units:    "partitions",
+		Fam:      "cluster",
+		Ctx:      "kafka.partitions_health",
+		Priority: prioPartitionsHealth,
+		Dims: module.Dims{
+			{ID: "partitions_under_replicated", Name: "under_replicated"},
+			{ID: "partitions_offline", Name: "offline"},
+		},
+	}
+	chartReplicas = module.Chart{
+		ID:       "replicas",
+		Title:    "Replicas",
+		Units:    "replicas",
+		Fam:      "cluster",
+		Ctx:      "kafka.replicas",
+		Priority: prioReplicas,
+		Dims: module.Dims{
+			{ID: "replicas", Name: "total"},
+			{ID: "replicas_offline", Name: "offline"},
+		},
+	}
+	chartLogDirsOffline = module.Chart{
+		ID:       "log_dirs_offline",
+		Title:    "Offline log directories",
+		Units:    "directories",
+		Fam:      "cluster",
+		Ctx:      "kafka.log_dirs_offline",
+		Priority: prioLogDirsOffline,
+		Dims: module.Dims{
+			{ID: "log_dirs_offline", Name: "offline"},
+		},
+	}
+	chartConsumerGroups = module.Chart{
+		ID:       "consumer_groups",
+		Title:    "Consumer groups",
+		Units:    "groups",
+		Fam:      "cluster",
+		Ctx:      "kafka.consumer_groups",
+		Priority: prioConsumerGroups,
+		Dims: module.Dims{
+			{ID: "consumer_groups", Name: "groups"},
+		},
+	}
+	chartConsumerGroupsByState = module.Chart{
+		ID:       "consumer_groups_by_state",
+		Title:    "Consumer groups by state",
+		Units:    "groups",
+		Fam:      "cluster",
+		Ctx:      "kafka.consumer_groups_by_state",
+		Type:     module.Stacked,
+		Priority: prioConsumerGroupsByState,
+		Dims:     groupStateDims("consumer_groups_state_"),
+	}
+)
+
+var brokerChartsTmpl = module.Charts{
+	chartTmplBrokerPartitions.Copy(),
+	chartTmplBrokerLogSize.Copy(),
+	chartTmplBrokerLogDirsOffline.Copy(),
+	chartTmplBrokerController.Copy(),
+}
+
+var (
+	chartTmplBrokerPartitions = module.Chart{
+		ID:       "broker_%d_partitions",
+		Title:    "Broker partitions",
+		Units:    "partitions",
+		Fam:      "brokers",
+		Ctx:      "kafka.broker_partitions",
+		Priority: prioBrokerPartitions,
+		Dims: module.Dims{
+			{ID: "broker_%d_leader_partitions", Name: "leader"},
+			{ID: "broker_%d_replicas", Name: "replicas"},
+		},
+	}
+	chartTmplBrokerLogSize = module.Chart{
+		ID:       "broker_%d_log_size",
+		Title:    "Broker log size",
+		Units:    "bytes",
+		Fam:      "brokers",
+		Ctx:      "kafka.broker_log_size",
+		Type:     module.Area,
+		Priority: prioBrokerLogSize,
+		Dims: module.Dims{
+			{ID: "broker_%d_log_size", Name: "size"},
+		},
+	}
+	chartTmplBrokerLogDirsOffline = module.Chart{
+		ID:       "broker_%d_log_dirs_offline",
+		Title:    "Broker offline log directories",
+		Units:    "directories",
+		Fam:      "brokers",
+		Ctx:      "kafka.broker_log_dirs_offline",
+		Priority: prioBrokerLogDirsOffline,
+		Dims: module.Dims{
+			{ID: "broker_%d_log_dirs_offline", Name: "offline"},
+		},
+	}
+	chartTmplBrokerController = module.Chart{
+		ID:       "broker_%d_controller",
+		Title:    "Broker is the active controller",
+		Units:    "status",
+		Fam:      "brokers",
+		Ctx:      "kafka.broker_controller",
+		Priority: prioBrokerController,
+		Dims: module.Dims{
+			{ID: "broker_%d_controller", Name: "controller"},
+		},
+	}
+)
+
+var topicChartsTmpl = module.Charts{
+	chartTmplTopicMessagesRate.Copy(),
+	chartTmplTopicRetainedMessages.Copy(),
+	chartTmplTopicLogSize.Copy(),
+	chartTmplTopicPartitions.Copy(),
+	chartTmplTopicPartitionsHealth.Copy(),
+	chartTmplTopicReplicas.Copy(),
+}
+
+var (
+	chartTmplTopicMessagesRate = module.Chart{
+		ID:       "topic_%s_messages_rate",
+		Title:    "Topic incoming messages",
+		Units:    "messages/s",
+		Fam:      "topics",
+		Ctx:      "kafka.topic_messages_rate",
+		Priority: prioTopicMessagesRate,
+		Dims: module.Dims{
+			{ID: "topic_%s/messages", Name: "messages", Algo: module.Incremental},
+		},
+	}
+	chartTmplTopicRetainedMessages = module.Chart{
+		ID:       "topic_%s_retained_messages",
+		Title:    "Topic retained messages",
+		Units:    "messages",
+		Fam:      "topics",
+		Ctx:      "kafka.topic_retained_messages",
+		Priority: prioTopicRetainedMessages,
+		Dims: module.Dims{
+			{ID: "topic_%s/retained_messages", Name: "retained"},
+		},
+	}
+	chartTmplTopicLogSize = module.Chart{
+		ID:       "topic_%s_log_size",
+		Title:    "Topic log size",
+		Units:    "bytes",
+		Fam:      "topics",
+		Ctx:      "kafka.topic_log_size",
+		Type:     module.Area,
+		Priority: prioTopicLogSize,
+		Dims: module.Dims{
+			{ID: "topic_%s/log_size", Name: "size"},
+		},
+	}
+	chartTmplTopicPartitions = module.Chart{
+		ID:       "topic_%s_partitions",
+		Title:    "Topic partitions",
+		Units:    "partitions",
+		Fam:      "topics",
+		Ctx:      "kafka.topic_partitions",
+		Priority: prioTopicPartitions,
+		Dims: module.Dims{
+			{ID: "topic_%s/partitions", Name: "partitions"},
+		},
+	}
+	chartTmplTopicPartitionsHealth = module.Chart{
+		ID:       "topic_%s_partitions_health",
+		Title:    "Topic unhealthy partitions",
+		Units:    "partitions",
+		Fam:      "topics",
+		Ctx:      "kafka.topic_partitions_health",
+		Priority: prioTopicPartitionsHealth,
+		Dims: module.Dims{
+			{ID: "topic_%s/partitions_under_replicated", Name: "under_replicated"},
+			{ID: "topic_%s/partitions_offline", Name: "offline"},
+		},
+	}
+	chartTmplTopicReplicas = module.Chart{
+		ID:       "topic_%s_replicas",
+		Title:    "Topic replicas",
+		Units:    "replicas",
+		Fam:      "topics",
+		Ctx:      "kafka.topic_replicas",
+		Priority: prioTopicReplicas,
+		Dims: module.Dims{
+			{ID: "topic_%s/replicas", Name: "replicas"},
+			{ID: "topic_%s/in_sync_replicas", Name: "in_sync"},
+		},
+	}
+)
+
+var partitionChartsTmpl = module.Charts{
+	chartTmplPartitionMessagesRate.Copy(),
+	chartTmplPartitionRetainedMessages.Copy(),
+	chartTmplPartitionLogSize.Copy(),
+	chartTmplPartitionReplicas.Copy(),
+	chartTmplPartitionStatus.Copy(),
+}
+
+var (
+	chartTmplPartitionMessagesRate = module.Chart{
+		ID:       "partition_%s_%d_messages_rate",
+		Title:    "Partition incoming messages",
+		Units:    "messages/s",
+		Fam:      "partitions",
+		Ctx:      "kafka.partition_messages_rate",
+		Priority: prioPartitionMessagesRate,
+		Dims: module.Dims{
+			{ID: "partition_%s/%d/messages", Name: "messages", Algo: module.Incremental},
+		},
+	}
+	chartTmplPartitionRetainedMessages = module.Chart{
+		ID:       "partition_%s_%d_retained_messages",
+		Title:    "Partition retained messages",
+		Units:    "messages",
+		Fam:      "partitions",
+		Ctx:      "kafka.partition_retained_messages",
+		Priority: prioPartitionRetainedMessages,
+		Dims: module.Dims{
+			{ID: "partition_%s/%d/retained_messages", Name: "retained"},
+		},
+	}
+	chartTmplPartitionLogSize = module.Chart{
+		ID:       "partition_%s_%d_log_size",
+		Title:    "Partition log size",
+		Units:    "bytes",
+		Fam:      "partitions",
+		Ctx:      "kafka.partition_log_size",
+		Type:     module.Area,
+		Priority: prioPartitionLogSize,
+		Dims: module.Dims{
+			{ID: "partition_%s/%d/log_size", Name: "size"},
+		},
+	}
+	chartTmplPartitionReplicas = module.Chart{
+		ID:       "partition_%s_%d_replicas",
+		Title:    "Partition replicas",
+		Units:    "replicas",
+		Fam:      "partitions",
+		Ctx:      "kafka.partition_replicas",
+		Priority: prioPartitionReplicas,
+		Dims: module.Dims{
+			{ID: "partition_%s/%d/replicas", Name: "replicas"},
+			{ID: "partition_%s/%d/in_sync_replicas", Name: "in_sync"},
+		},
+	}
+	chartTmplPartitionStatus = module.Chart{
+		ID:       "partition_%s_%d_status",
+		Title:    "Partition status",
+		Units:    "status",
+		Fam:      "partitions",
+		Ctx:      "kafka.partition_status",
+		Priority: prioPartitionStatus,
+		Dims: module.Dims{
+			{ID: "partition_%s/%d/under_replicated", Name: "under_replicated"},
+			{ID: "partition_%s/%d/offline", Name: "offline"},
+		},
+	}
+)
+
+var groupChartsTmpl = module.Charts{
+	chartTmplConsumerGroupLag.Copy(),
+	chartTmplConsumerGroupState.Copy(),
+	chartTmplConsumerGroupMembers.Copy(),
+}
+
+var (
+	chartTmplConsumerGroupLag = module.Chart{
+		ID:       "group_%s_lag",
+		Title:    "Consumer group lag",
+		Units:    "messages",
+		Fam:      "consumer groups",
+		Ctx:      "kafka.consumer_group_lag",
+		Priority: prioConsumerGroupLag,
+		Dims: module.Dims{
+			{ID: "group_%s/lag", Name: "lag"},
+		},
+	}
+	chartTmplConsumerGroupState = module.Chart{
+		ID:       "group_%s_state",
+		Title:    "Consumer group state",
+		Units:    "state",
+		Fam:      "consumer groups",
+		Ctx:      "kafka.consumer_group_state",
+		Priority: prioConsumerGroupState,
+		Dims:     groupStateDims("group_%s/state_"),
+	}
+	chartTmplConsumerGroupMembers = module.Chart{
+		ID:       "group_%s_members",
+		Title:    "Consumer group members",
+		Units:    "members",
+		Fam:      "consumer groups",
+		Ctx:      "kafka.consumer_group_members",
+		Priority: prioConsumerGroupMembers,
+		Dims: module.Dims{
+			{ID: "group_%s/members", Name: "members"},
+		},
+	}
+)
+
+var groupTopicChartsTmpl = module.Charts{
+	chartTmplConsumerGroupTopicLag.Copy(),
+	chartTmplConsumerGroupTopicPartitionLag.Copy(),
+}
+
+var (
+	chartTmplConsumerGroupTopicLag = module.Chart{
+		ID:       "group_%s_topic_%s_lag",
+		Title:    "Consumer group topic lag",
+		Units:    "messages",
+		Fam:      "consumer groups",
+		Ctx:      "kafka.consumer_group_topic_lag",
+		Priority: prioConsumerGroupTopicLag,
+		Dims: module.Dims{
+			{ID: "topic_%[2]s/group_%[1]s/lag", Name: "lag"},
+		},
+	}
+	chartTmplConsumerGroupTopicPartitionLag = module.Chart{
+		ID:       "group_%s_topic_%s_partition_lag",
+		Title:    "Consumer group topic lag by partition",
+		Units:    "messages",
+		Fam:      "consumer groups",
+		Ctx:      "kafka.consumer_group_topic_partition_lag",
+		Type:     module.Stacked,
+		Priority: prioConsumerGroupTopicPartitionLag,
+	}
+)
+
+func groupStateDims(px string) module.Dims {
+	var dims module.Dims
+	for _, st := range groupStates {
+		dims = append(dims, &module.Dim{ID: px + st, Name: st})
+	}
+	return dims
+}
+
+func (c *Collector) updateCharts() {
+	maps.DeleteFunc(c.cache.brokers, func(_ int32, b *brokerCacheItem) bool {
+		if !b.seen {
+			c.removeBrokerCharts(b)
+			return true
+		}
+		if b.relabel {
+			b.relabel = false
+			c.removeBrokerCharts(b)
+			b.hasCharts = false
+		}
+		if !b.hasCharts {
+			b.hasCharts = true
+			c.addBrokerCharts(b)
+		}
+		return false
+	})
+
+	maps.DeleteFunc(c.cache.topics, func(_ string, t *topicCacheItem) bool {
+		if !t.seen {
+			c.removeTopicCharts(t)
+			return true
+		}
+		if !t.hasCharts {
+			t.hasCharts = true
+			c.addTopicCharts(t)
+		}
+		return false
+	})
+
+	maps.DeleteFunc(c.cache.partitions, func(_ string, p *partitionCacheItem) bool {
+		if !p.seen {
+			c.removePartitionCharts(p)
+			return true
+		}
+		if !p.hasCharts {
+			p.hasCharts = true
+			c.addPartitionCharts(p)
+		}
+		return false
+	})
+
+	maps.DeleteFunc(c.cache.groups, func(_ string, g *groupCacheItem) bool {
+		if !g.seen {
+			c.removeGroupCharts(g)
+			return true
+		}
+		if !g.hasCharts {
+			g.hasCharts = true
+			c.addGroupCharts(g)
+		}
+		maps.DeleteFunc(g.topics, func(_ string, t *groupTopicCacheItem) bool {
+			if !t.seen {
+				c.removeGroupTopicCharts(t)
+				return true
+			}
+			if !t.hasCharts {
+				t.hasCharts = true
+				c.addGroupTopicCharts(t)
+			}
+			maps.DeleteFunc(t.partitions, func(_ int32, p *groupPartitionCacheItem) bool {
+				if !p.seen {
+					c.removeGroupPartitionDim(t, p)
+					return true
+				}
+				if !p.hasDim {
+					p.hasDim = true
+					c.addGroupPartitionDim(t, p)
+				}
+				return false
+			})
+			return false
+		})
+		return false
+	})
+}
+
+func (c *Collector) addBrokerCharts(b *brokerCacheItem) {
+	charts := brokerChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, b.id)
+		chart.Labels = []module.Label{
+			{Key: "broker_id", Value: strconv.Itoa(int(b.id))},
+			{Key: "broker_host", Value: b.host},
+			{Key: "rack", Value: b.rack},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, b.id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeBrokerCharts(b *brokerCacheItem) {
+	c.removeCharts(brokerChartsTmpl, b.id)
+}
+
+func (c *Collector) addTopicCharts(t *topicCacheItem) {
+	charts := topicChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = cleanChartID(fmt.Sprintf(chart.ID, t.name))
+		chart.Labels = []module.Label{
+			{Key: "topic", Value: t.name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, t.name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeTopicCharts(t *topicCacheItem) {
+	c.removeCharts(topicChartsTmpl, t.name)
+}
+
+func (c *Collector) addPartitionCharts(p *partitionCacheItem) {
+	charts := partitionChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = cleanChartID(fmt.Sprintf(chart.ID, p.topic, p.partition))
+		chart.Labels = []module.Label{
+			{Key: "topic", Value: p.topic},
+			{Key: "partition", Value: strconv.Itoa(int(p.partition))},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, p.topic, p.partition)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removePartitionCharts(p *partitionCacheItem) {
+	c.removeCharts(partitionChartsTmpl, p.topic, p.partition)
+}
+
+func (c *Collector) addGroupCharts(g *groupCacheItem) {
+	charts := groupChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = cleanChartID(fmt.Sprintf(chart.ID, g.name))
+		chart.Labels = []module.Label{
+			{Key: "consumer_group", Value: g.name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, g.name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeGroupCharts(g *groupCacheItem) {
+	c.removeCharts(groupChartsTmpl, g.name)
+	for _, t := range g.topics {
+		c.removeGroupTopicCharts(t)
+	}
+}
+
+func (c *Collector) addGroupTopicCharts(t *groupTopicCacheItem) {
+	charts := groupTopicChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = cleanChartID(fmt.Sprintf(chart.ID, t.group, t.topic))
+		chart.Labels = []module.Label{
+			{Key: "consumer_group", Value: t.group},
+			{Key: "topic", Value: t.topic},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, t.group, t.topic)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeGroupTopicCharts(t *groupTopicCacheItem) {
+	c.removeCharts(groupTopicChartsTmpl, t.group, t.topic)
+}
+
+func (c *Collector) addGroupPartitionDim(t *groupTopicCacheItem, p *groupPartitionCacheItem) {
+	id := cleanChartID(fmt.Sprintf(chartTmplConsumerGroupTopicPartitionLag.ID, t.group, t.topic))
+	chart := c.Charts().Get(id)
+	if chart == nil {
+		return
+	}
+
+	dim := &module.Dim{
+		ID:   groupPartitionDimID(t.group, t.topic, p.partition),
+		Name: strconv.Itoa(int(p.partition)),
+	}
+	if err := chart.AddDim(dim); err != nil {
+		c.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (c *Collector) removeGroupPartitionDim(t *groupTopicCacheItem, p *groupPartitionCacheItem) {
+	id := cleanChartID(fmt.Sprintf(chartTmplConsumerGroupTopicPartitionLag.ID, t.group, t.topic))
+	chart := c.Charts().Get(id)
+	if chart == nil {
+		return
+	}
+
+	if err := chart.MarkDimRemove(groupPartitionDimID(t.group, t.topic, p.partition), true); err != nil {
+		c.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+// removeCharts removes charts created from the templates. Names are not matched by prefix
+// because topic and group names may be prefixes of each other ("orders" and "orders_v2").
+func (c *Collector) removeCharts(tmpl module.Charts, args ...any) {
+	for _, t := range tmpl {
+		if chart := c.Charts().Get(cleanChartID(fmt.Sprintf(t.ID, args...))); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func cleanChartID(id string) string {
+	r := strings.NewReplacer(" ", "_", ".", "_")
+	return r.Replace(id)
+}
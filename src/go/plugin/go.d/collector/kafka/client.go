@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"time"
+)
+
+const clientID = "netdata"
+
+// maxResponseSize protects from allocating huge buffers when talking to something that is not a Kafka broker.
+const maxResponseSize = 100 << 20
+
+type kafkaConn interface {
+	metadata() (*metadataResponse, error)
+	listOffsets(timestamp int64, partitions map[string][]int32) (map[string]map[int32]int64, error)
+	describeLogDirs() (*logDirsResponse, error)
+	listGroups() ([]groupListing, error)
+	describeGroups(groups []string) ([]groupDescription, error)
+	offsetFetch(group string) (map[string]map[int32]int64, error)
+	close() error
+}
+
+type connConfig struct {
+	address  string
+	timeout  time.Duration
+	tlsConf  *tls.Config
+	username string
+	password string
+}
+
+func newKafkaConn(cfg connConfig) (kafkaConn, error) {
+	dialer := &net.Dialer{Timeout: cfg.timeout}
+
+	var conn net.Conn
+	var err error
+	if cfg.tlsConf != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.address, cfg.tlsConf)
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bc := &brokerConn{conn: conn, timeout: cfg.timeout}
+
+	if cfg.username != "" {
+		if err := bc.saslPlain(cfg.username, cfg.password); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("SASL authentication: %v", err)
+		}
+	}
+
+	return bc, nil
+}
+
+type brokerConn struct {
+	conn          net.Conn
+	timeout       time.Duration
+	correlationID int32
+}
+
+func (c *brokerConn) metadata() (*metadataResponse, error) {
+	d, err := c.request(apiKeyMetadata, apiVersionMetadata, encodeMetadataRequest)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMetadataResponse(d)
+}
+
+func (c *brokerConn) listOffsets(timestamp int64, partitions map[string][]int32) (map[string]map[int32]int64, error) {
+	d, err := c.request(apiKeyListOffsets, apiVersionListOffsets, func(e *encoder) {
+		encodeListOffsetsRequest(e, timestamp, partitions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeListOffsetsResponse(d)
+}
+
+func (c *brokerConn) describeLogDirs() (*logDirsResponse, error) {
+	d, err := c.request(apiKeyDescribeLogDirs, apiVersionDescribeLogDirs, encodeDescribeLogDirsRequest)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDescribeLogDirsResponse(d)
+}
+
+func (c *brokerConn) listGroups() ([]groupListing, error) {
+	d, err := c.request(apiKeyListGroups, apiVersionListGroups, func(*encoder) {})
+	if err != nil {
+		return nil, err
+	}
+	return decodeListGroupsResponse(d)
+}
+
+func (c *brokerConn) describeGroups(groups []string) ([]groupDescription, error) {
+	d, err := c.request(apiKeyDescribeGroups, apiVersionDescribeGroups, func(e *encoder) {
+		encodeDescribeGroupsRequest(e, groups)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeDescribeGroupsResponse(d)
+}
+
+func (c *brokerConn) offsetFetch(group string) (map[string]map[int32]int64, error) {
+	d, err := c.request(apiKeyOffsetFetch, apiVersionOffsetFetch, func(e *encoder) {
+		encodeOffsetFetchRequest(e, group)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeOffsetFetchResponse(d)
+}
+
+func (c *brokerConn) saslPlain(username, password string) error {
+	d, err := c.request(apiKeySaslHandshake, apiVersionSaslHandshake, func(e *encoder) {
+		encodeSaslHandshakeRequest(e, "PLAIN")
+	})
+	if err != nil {
+		return err
+	}
+	mechanisms, err := decodeSaslHandshakeResponse(d)
+	if err != nil {
+		return fmt.Errorf("handshake: %v (enabled mechanisms: %v)", err, mechanisms)
+	}
+	if !slices.Contains(mechanisms, "PLAIN") {
+		return fmt.Errorf("PLAIN mechanism is not enabled (enabled mechanisms: %v)", mechanisms)
+	}
+
+	token := []byte("\x00" + username + "\x00" + password)
+
+	d, err = c.request(apiKeySaslAuthenticate, apiVersionSaslAuthenticate, func(e *encoder) {
+		encodeSaslAuthenticateRequest(e, token)
+	})
+	if err != nil {
+		return err
+	}
+
+	return decodeSaslAuthenticateResponse(d)
+}
+
+func (c *brokerConn) request(apiKey, apiVersion int16, encodeBody func(*encoder)) (*decoder, error) {
+	c.correlationID++
+
+	e := &encoder{buf: make([]byte, 4, 64)} // reserve space for the message size
+	e.int16(apiKey)
+	e.int16(apiVersion)
+	e.int32(c.correlationID)
+	id := clientID
+	e.nullableString(&id)
+	encodeBody(e)
+	binary.BigEndian.PutUint32(e.buf[:4], uint32(len(e.buf)-4))
+
+	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.conn.Write(e.buf); err != nil {
+		return nil, fmt.Errorf("write request (api key %d): %v", apiKey, err)
+	}
+
+	var hdr [8]byte
+	if _, err := io.ReadFull(c.conn, hdr[:]); err != nil {
+		return nil, fmt.Errorf("read response (api key %d): %v", apiKey, err)
+	}
+
+	size := int32(binary.BigEndian.Uint32(hdr[:4]))
+	if size < 4 || size > maxResponseSize {
+		return nil, fmt.Errorf("read response (api key %d): invalid message size %d", apiKey, size)
+	}
+	if id := int32(binary.BigEndian.Uint32(hdr[4:])); id != c.correlationID {
+		return nil, fmt.Errorf("read response (api key %d): unexpected correlation id %d (want %d)", apiKey, id, c.correlationID)
+	}
+
+	body := make([]byte, size-4)
+	if _, err := io.ReadFull(c.conn, body); err != nil {
+		return nil, fmt.Errorf("read response (api key %d): %v", apiKey, err)
+	}
+
+	return &decoder{buf: body}, nil
+}
+
+func (c *brokerConn) close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,493 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+)
+
+func (c *Collector) collect() (map[string]int64, error) {
+	meta, err := c.getMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.resetSeen()
+
+	mx := make(map[string]int64)
+
+	c.collectMetadata(mx, meta)
+
+	latest := c.collectOffsets(mx, meta)
+
+	c.collectLogDirs(mx, meta)
+
+	c.collectConsumerGroups(mx, meta, latest)
+
+	c.updateCharts()
+
+	return mx, nil
+}
+
+func (c *Collector) getMetadata() (*metadataResponse, error) {
+	if c.bootstrap == nil {
+		conn, err := c.connectBootstrap()
+		if err != nil {
+			return nil, err
+		}
+		c.bootstrap = conn
+	}
+
+	meta, err := c.bootstrap.metadata()
+	if err != nil {
+		_ = c.bootstrap.close()
+		c.bootstrap = nil
+		return nil, fmt.Errorf("metadata request: %v", err)
+	}
+
+	return meta, nil
+}
+
+func (c *Collector) connectBootstrap() (kafkaConn, error) {
+	// try the configured brokers first, then the brokers known from the previous metadata responses
+	addrs := append([]string{}, c.Brokers...)
+	for _, item := range c.conns {
+		addrs = append(addrs, item.address)
+	}
+
+	var errs []error
+	for _, addr := range addrs {
+		conn, err := c.newConn(c.connConfig(addr))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, fmt.Errorf("broker '%s': %v", addr, err))
+	}
+
+	return nil, fmt.Errorf("failed to connect to any broker: %v", errors.Join(errs...))
+}
+
+func (c *Collector) getBrokerConn(b brokerMetadata) (kafkaConn, error) {
+	addr := net.JoinHostPort(b.Host, strconv.Itoa(int(b.Port)))
+
+	if item, ok := c.conns[b.NodeID]; ok {
+		if item.address == addr {
+			return item.conn, nil
+		}
+		c.closeBrokerConn(b.NodeID)
+	}
+
+	conn, err := c.newConn(c.connConfig(addr))
+	if err != nil {
+		return nil, err
+	}
+	c.conns[b.NodeID] = &brokerConnItem{address: addr, conn: conn}
+
+	return conn, nil
+}
+
+func (c *Collector) closeBrokerConn(id int32) {
+	if item, ok := c.conns[id]; ok {
+		_ = item.conn.close()
+		delete(c.conns, id)
+	}
+}
+
+func (c *Collector) connConfig(addr string) connConfig {
+	return connConfig{
+		address:  addr,
+		timeout:  c.Timeout.Duration(),
+		tlsConf:  c.tlsConf,
+		username: c.Username,
+		password: c.Password,
+	}
+}
+
+func (c *Collector) collectMetadata(mx map[string]int64, meta *metadataResponse) {
+	mx["brokers"] = int64(len(meta.Brokers))
+
+	live := make(map[int32]bool)
+	for _, b := range meta.Brokers {
+		live[b.NodeID] = true
+
+		c.cache.getBroker(b).seen = true
+
+		px := brokerPx(b.NodeID)
+		mx[px+"leader_partitions"] = 0
+		mx[px+"replicas"] = 0
+		mx[px+"controller"] = metrix.Bool(b.NodeID == meta.ControllerID)
+	}
+
+	// the metadata response has only live brokers
+	for id := range c.conns {
+		if !live[id] {
+			c.closeBrokerConn(id)
+		}
+	}
+
+	for _, name := range []string{"topics", "partitions", "replicas", "partitions_under_replicated", "partitions_offline", "replicas_offline"} {
+		mx[name] = 0
+	}
+
+	for _, t := range meta.Topics {
+		if t.Err != 0 {
+			continue
+		}
+
+		mx["topics"]++
+
+		// internal topics (__consumer_offsets, __transaction_state) count towards the cluster and broker totals only
+		selected := !t.IsInternal && c.topicSr.MatchString(t.Name)
+		tpx := topicPx(t.Name)
+		if selected {
+			c.cache.getTopic(t.Name).seen = true
+			for _, v := range []string{"partitions", "partitions_under_replicated", "partitions_offline", "replicas", "in_sync_replicas"} {
+				mx[tpx+v] = 0
+			}
+		}
+
+		for _, p := range t.Partitions {
+			mx["partitions"]++
+			mx["replicas"] += int64(len(p.Replicas))
+			mx["replicas_offline"] += int64(len(p.OfflineReplicas))
+
+			underReplicated := len(p.ISR) < len(p.Replicas)
+			offline := p.Leader < 0
+
+			mx["partitions_under_replicated"] += metrix.Bool(underReplicated)
+			mx["partitions_offline"] += metrix.Bool(offline)
+
+			if !offline && live[p.Leader] {
+				mx[brokerPx(p.Leader)+"leader_partitions"]++
+			}
+			for _, r := range p.Replicas {
+				if live[r] {
+					mx[brokerPx(r)+"replicas"]++
+				}
+			}
+
+			if !selected {
+				continue
+			}
+
+			mx[tpx+"partitions"]++
+			mx[tpx+"partitions_under_replicated"] += metrix.Bool(underReplicated)
+			mx[tpx+"partitions_offline"] += metrix.Bool(offline)
+			mx[tpx+"replicas"] += int64(len(p.Replicas))
+			mx[tpx+"in_sync_replicas"] += int64(len(p.ISR))
+
+			if c.CollectPartitionMetrics {
+				ppx := partitionPx(t.Name, p.Index)
+				c.cache.getPartition(ppx, t.Name, p.Index).seen = true
+				mx[ppx+"replicas"] = int64(len(p.Replicas))
+				mx[ppx+"in_sync_replicas"] = int64(len(p.ISR))
+				mx[ppx+"under_replicated"] = metrix.Bool(underReplicated)
+				mx[ppx+"offline"] = metrix.Bool(offline)
+			}
+		}
+	}
+}
+
+// collectOffsets queries the earliest and latest offsets of every partition from its leader.
+// It returns the latest offsets (high watermarks), they are used for consumer lag calculation.
+func (c *Collector) collectOffsets(mx map[string]int64, meta *metadataResponse) map[string]map[int32]int64 {
+	byLeader := make(map[int32]map[string][]int32)
+	for _, t := range meta.Topics {
+		if t.Err != 0 {
+			continue
+		}
+		for _, p := range t.Partitions {
+			if p.Leader < 0 {
+				continue
+			}
+			if byLeader[p.Leader] == nil {
+				byLeader[p.Leader] = make(map[string][]int32)
+			}
+			byLeader[p.Leader][t.Name] = append(byLeader[p.Leader][t.Name], p.Index)
+		}
+	}
+
+	latest := make(map[string]map[int32]int64)
+	earliest := make(map[string]map[int32]int64)
+
+	for _, b := range meta.Brokers {
+		parts, ok := byLeader[b.NodeID]
+		if !ok {
+			continue
+		}
+
+		conn, err := c.getBrokerConn(b)
+		if err != nil {
+			c.Warningf("broker %d (%s): failed to connect: %v", b.NodeID, b.Host, err)
+			continue
+		}
+
+		hw, err := conn.listOffsets(offsetLatest, parts)
+		if err != nil {
+			c.Warningf("broker %d (%s): list latest offsets: %v", b.NodeID, b.Host, err)
+			c.closeBrokerConn(b.NodeID)
+			continue
+		}
+		lw, err := conn.listOffsets(offsetEarliest, parts)
+		if err != nil {
+			c.Warningf("broker %d (%s): list earliest offsets: %v", b.NodeID, b.Host, err)
+			c.closeBrokerConn(b.NodeID)
+			continue
+		}
+
+		mergeOffsets(latest, hw)
+		mergeOffsets(earliest, lw)
+	}
+
+	for topic, parts := range latest {
+		tc, ok := c.cache.topics[topic]
+		if !ok || !tc.seen {
+			continue
+		}
+
+		tpx := topicPx(topic)
+		mx[tpx+"messages"] = 0
+		mx[tpx+"retained_messages"] = 0
+
+		for idx, hw := range parts {
+			retained := int64(0)
+			if lw, ok := earliest[topic][idx]; ok && hw >= lw {
+				retained = hw - lw
+			}
+
+			mx[tpx+"messages"] += hw
+			mx[tpx+"retained_messages"] += retained
+
+			if c.CollectPartitionMetrics {
+				ppx := partitionPx(topic, idx)
+				mx[ppx+"messages"] = hw
+				mx[ppx+"retained_messages"] = retained
+			}
+		}
+	}
+
+	return latest
+}
+
+// collectLogDirs collects the on-disk size of partitions. A partition size is taken from its leader replica.
+func (c *Collector) collectLogDirs(mx map[string]int64, meta *metadataResponse) {
+	leaders := make(map[string]map[int32]int32)
+	for _, t := range meta.Topics {
+		leaders[t.Name] = make(map[int32]int32)
+		for _, p := range t.Partitions {
+			leaders[t.Name][p.Index] = p.Leader
+		}
+	}
+
+	mx["log_dirs_offline"] = 0
+
+	for _, b := range meta.Brokers {
+		conn, err := c.getBrokerConn(b)
+		if err != nil {
+			c.Warningf("broker %d (%s): failed to connect: %v", b.NodeID, b.Host, err)
+			continue
+		}
+
+		resp, err := conn.describeLogDirs()
+		if err != nil {
+			c.Warningf("broker %d (%s): describe log dirs: %v", b.NodeID, b.Host, err)
+			c.closeBrokerConn(b.NodeID)
+			continue
+		}
+
+		px := brokerPx(b.NodeID)
+		mx[px+"log_size"] = 0
+		mx[px+"log_dirs_offline"] = int64(resp.OfflineDirs)
+		mx["log_dirs_offline"] += int64(resp.OfflineDirs)
+
+		for topic, parts := range resp.Sizes {
+			tc, selected := c.cache.topics[topic]
+			selected = selected && tc.seen
+			tpx := topicPx(topic)
+
+			for idx, size := range parts {
+				mx[px+"log_size"] += size
+
+				if !selected || leaders[topic][idx] != b.NodeID {
+					continue
+				}
+
+				mx[tpx+"log_size"] += size
+				if c.CollectPartitionMetrics {
+					mx[partitionPx(topic, idx)+"log_size"] = size
+				}
+			}
+		}
+	}
+}
+
+func (c *Collector) collectConsumerGroups(mx map[string]int64, meta *metadataResponse, latest map[string]map[int32]int64) {
+	mx["consumer_groups"] = 0
+	for _, st := range groupStates {
+		mx["consumer_groups_state_"+st] = 0
+	}
+
+	for _, b := range meta.Brokers {
+		conn, err := c.getBrokerConn(b)
+		if err != nil {
+			c.Warningf("broker %d (%s): failed to connect: %v", b.NodeID, b.Host, err)
+			continue
+		}
+
+		// every broker lists only the groups it coordinates
+		listing, err := conn.listGroups()
+		if err != nil {
+			c.Warningf("broker %d (%s): list groups: %v", b.NodeID, b.Host, err)
+			c.closeBrokerConn(b.NodeID)
+			continue
+		}
+
+		mx["consumer_groups"] += int64(len(listing))
+
+		var groups []string
+		for _, g := range listing {
+			if c.groupSr.MatchString(g.GroupID) {
+				groups = append(groups, g.GroupID)
+			}
+		}
+		if len(groups) == 0 {
+			continue
+		}
+
+		descs, err := conn.describeGroups(groups)
+		if err != nil {
+			c.Warningf("broker %d (%s): describe groups: %v", b.NodeID, b.Host, err)
+			c.closeBrokerConn(b.NodeID)
+			continue
+		}
+
+		for _, desc := range descs {
+			state := groupState(desc.State)
+			if desc.Err != 0 {
+				c.Debugf("broker %d (%s): describe group '%s': %v", b.NodeID, b.Host, desc.GroupID, errorFromCode(desc.Err))
+				state = "unknown"
+			}
+			mx["consumer_groups_state_"+state]++
+
+			gc := c.cache.getGroup(desc.GroupID)
+			gc.seen = true
+
+			px := groupPx(desc.GroupID)
+			for _, st := range groupStates {
+				mx[px+"state_"+st] = metrix.Bool(st == state)
+			}
+			mx[px+"members"] = int64(desc.Members)
+			mx[px+"lag"] = 0
+
+			committed, err := conn.offsetFetch(desc.GroupID)
+			if err != nil {
+				c.Warningf("broker %d (%s): fetch offsets of group '%s': %v", b.NodeID, b.Host, desc.GroupID, err)
+				continue
+			}
+
+			for topic, parts := range committed {
+				gtc := gc.getTopic(topic)
+				gtc.seen = true
+
+				tpx := groupTopicPx(desc.GroupID, topic)
+				mx[tpx+"lag"] = 0
+
+				for idx, offset := range parts {
+					hw, ok := latest[topic][idx]
+					if !ok {
+						continue
+					}
+					lag := max(hw-offset, 0)
+
+					gtc.getPartition(idx).seen = true
+					mx[tpx+"lag"] += lag
+					mx[px+"lag"] += lag
+					mx[groupPartitionDimID(desc.GroupID, topic, idx)] = lag
+				}
+			}
+		}
+	}
+}
+
+func mergeOffsets(dst, src map[string]map[int32]int64) {
+	for topic, parts := range src {
+		if dst[topic] == nil {
+			dst[topic] = make(map[int32]int64)
+		}
+		for idx, v := range parts {
+			dst[topic][idx] = v
+		}
+	}
+}
+
+var groupStates = []string{
+	"stable",
+	"preparing_rebalance",
+	"completing_rebalance",
+	"assigning",
+	"reconciling",
+	"empty",
+	"dead",
+	"unknown",
+}
+
+// groupState converts a group state ("PreparingRebalance") to a dimension suffix ("preparing_rebalance").
+func groupState(state string) string {
+	if state == "AwaitingSync" {
+		// the name used by brokers prior to 2.0
+		state = "CompletingRebalance"
+	}
+
+	var sb strings.Builder
+	for i, r := range state {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+
+	s := sb.String()
+	for _, st := range groupStates {
+		if s == st {
+			return s
+		}
+	}
+	return "unknown"
+}
+
+func brokerPx(id int32) string {
+	return fmt.Sprintf("broker_%d_", id)
+}
+
+// Metric keys use '/' as the separator: it is not allowed in topic names, and metric names
+// never contain it. Group ids may contain anything, so a group id is always the last name in a key.
+
+func topicPx(topic string) string {
+	return fmt.Sprintf("topic_%s/", topic)
+}
+
+func partitionPx(topic string, partition int32) string {
+	return fmt.Sprintf("partition_%s/%d/", topic, partition)
+}
+
+func groupPx(group string) string {
+	return fmt.Sprintf("group_%s/", group)
+}
+
+func groupTopicPx(group, topic string) string {
+	return fmt.Sprintf("topic_%s/group_%s/", topic, group)
+}
+
+func groupPartitionDimID(group, topic string, partition int32) string {
+	return fmt.Sprintf("topic_%s/group_%s/partition_%d_lag", topic, group, partition)
+}
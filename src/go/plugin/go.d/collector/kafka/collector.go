@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
+)
+
+//go:embed "config_schema.json"
+var configSchema string
+
+func init() {
+	module.Register("kafka", module.Creator{
+		JobConfigSchema: configSchema,
+		Defaults: module.Defaults{
+			UpdateEvery: 10,
+		},
+		Create: func() module.Module { return New() },
+		Config: func() any { return &Config{} },
+	})
+}
+
+func New() *Collector {
+	return &Collector{
+		Config: Config{
+			Brokers:               []string{"127.0.0.1:9092"},
+			Timeout:               confopt.Duration(time.Second * 2),
+			TopicSelector:         "*",
+			ConsumerGroupSelector: "*",
+		},
+		charts:  clusterCharts.Copy(),
+		newConn: newKafkaConn,
+		conns:   make(map[int32]*brokerConnItem),
+		cache:   newCache(),
+	}
+}
+
+type Config struct {
+	Vnode                   string           `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery             int              `yaml:"update_every,omitempty" json:"update_every"`
+	Brokers                 []string         `yaml:"brokers" json:"brokers"`
+	Timeout                 confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	Username                string           `yaml:"username,omitempty" json:"username"`
+	Password                string           `yaml:"password,omitempty" json:"password"`
+	UseTLS                  bool             `yaml:"use_tls,omitempty" json:"use_tls"`
+	tlscfg.TLSConfig        `yaml:",inline" json:""`
+	TopicSelector           string `yaml:"topic_selector,omitempty" json:"topic_selector"`
+	ConsumerGroupSelector   string `yaml:"consumer_group_selector,omitempty" json:"consumer_group_selector"`
+	CollectPartitionMetrics bool   `yaml:"collect_partition_metrics" json:"collect_partition_metrics"`
+}
+
+type (
+	Collector struct {
+		module.Base
+		Config `yaml:",inline" json:""`
+
+		charts *module.Charts
+
+		newConn   func(connConfig) (kafkaConn, error)
+		tlsConf   *tls.Config
+		bootstrap kafkaConn
+		conns     map[int32]*brokerConnItem
+
+		topicSr matcher.Matcher
+		groupSr matcher.Matcher
+
+		cache *cache
+	}
+	brokerConnItem struct {
+		address string
+		conn    kafkaConn
+	}
+)
+
+func (c *Collector) Configuration() any {
+	return c.Config
+}
+
+func (c *Collector) Init(context.Context) error {
+	if err := c.validateConfig(); err != nil {
+		return fmt.Errorf("config validation: %v", err)
+	}
+
+	if c.UseTLS {
+		tlsConf, err := tlscfg.NewTLSConfig(c.TLSConfig)
+		if err != nil {
+			return fmt.Errorf("creating tls config: %v", err)
+		}
+		if tlsConf == nil {
+			tlsConf = &tls.Config{}
+		}
+		c.tlsConf = tlsConf
+	}
+
+	topicSr, err := initSelector(c.TopicSelector)
+	if err != nil {
+		return fmt.Errorf("failed to init topic selector: %v", err)
+	}
+	c.topicSr = topicSr
+
+	groupSr, err := initSelector(c.ConsumerGroupSelector)
+	if err != nil {
+		return fmt.Errorf("failed to init consumer group selector: %v", err)
+	}
+	c.groupSr = groupSr
+
+	return nil
+}
+
+func (c *Collector) Check(context.Context) error {
+	mx, err := c.collect()
+	if err != nil {
+		return err
+	}
+
+	if len(mx) == 0 {
+		return errors.New("no metrics collected")
+	}
+
+	return nil
+}
+
+func (c *Collector) Charts() *module.Charts {
+	return c.charts
+}
+
+func (c *Collector) Collect(context.Context) map[string]int64 {
+	mx, err := c.collect()
+	if err != nil {
+		c.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+
+	return mx
+}
+
+func (c *Collector) Cleanup(context.Context) {
+	if c.bootstrap != nil {
+		_ = c.bootstrap.close()
+		c.bootstrap = nil
+	}
+	for id, item := range c.conns {
+		_ = item.conn.close()
+		delete(c.conns, id)
+	}
+}
@@ -0,0 +1,778 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
+	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
+)
+
+func Test_testDataIsValid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"dataConfigJSON": dataConfigJSON,
+		"dataConfigYAML": dataConfigYAML,
+	} {
+		require.NotNil(t, data, name)
+	}
+}
+
+func TestCollector_ConfigurationSerialize(t *testing.T) {
+	module.TestConfigurationSerialize(t, &Collector{}, dataConfigJSON, dataConfigYAML)
+}
+
+func TestCollector_Init(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		wantFail bool
+	}{
+		"success with default config": {
+			wantFail: false,
+			config:   New().Config,
+		},
+		"fails if brokers not set": {
+			wantFail: true,
+			config: func() Config {
+				conf := New().Config
+				conf.Brokers = nil
+				return conf
+			}(),
+		},
+		"fails if password set without username": {
+			wantFail: true,
+			config: func() Config {
+				conf := New().Config
+				conf.Password = "secret"
+				return conf
+			}(),
+		},
+		"fails on invalid topic selector": {
+			wantFail: true,
+			config: func() Config {
+				conf := New().Config
+				conf.TopicSelector = "[a-"
+				return conf
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Config = test.config
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				assert.NoError(t, collr.Init(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestCollector_Check(t *testing.T) {
+	tests := map[string]struct {
+		prepare  func(t *testing.T) *Collector
+		wantFail bool
+	}{
+		"success on valid response": {
+			wantFail: false,
+			prepare:  prepareCaseOk,
+		},
+		"success with SASL/PLAIN": {
+			wantFail: false,
+			prepare:  prepareCaseSASL("netdata", "secret"),
+		},
+		"fails on wrong SASL credentials": {
+			wantFail: true,
+			prepare:  prepareCaseSASL("netdata", "wrong"),
+		},
+		"fails on unexpected response": {
+			wantFail: true,
+			prepare:  prepareCaseUnexpectedResponse,
+		},
+		"fails on connection refused": {
+			wantFail: true,
+			prepare:  prepareCaseConnectionRefused,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := test.prepare(t)
+			defer collr.Cleanup(context.Background())
+
+			require.NoError(t, collr.Init(context.Background()))
+
+			if test.wantFail {
+				assert.Error(t, collr.Check(context.Background()))
+			} else {
+				assert.NoError(t, collr.Check(context.Background()))
+			}
+		})
+	}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	tests := map[string]struct {
+		prepare     func(t *testing.T) *Collector
+		wantMetrics map[string]int64
+		wantCharts  int
+	}{
+		"success on valid response": {
+			prepare: prepareCaseOk,
+			wantMetrics: map[string]int64{
+				"broker_1_controller":                          1,
+				"broker_1_leader_partitions":                   4,
+				"broker_1_log_dirs_offline":                    0,
+				"broker_1_log_size":                            6600,
+				"broker_1_replicas":                            5,
+				"brokers":                                      1,
+				"consumer_groups":                              2,
+				"consumer_groups_state_assigning":              0,
+				"consumer_groups_state_completing_rebalance":   0,
+				"consumer_groups_state_dead":                   0,
+				"consumer_groups_state_empty":                  1,
+				"consumer_groups_state_preparing_rebalance":    0,
+				"consumer_groups_state_reconciling":            0,
+				"consumer_groups_state_stable":                 1,
+				"consumer_groups_state_unknown":                0,
+				"group_analytics/lag":                          0,
+				"group_analytics/members":                      0,
+				"group_analytics/state_assigning":              0,
+				"group_analytics/state_completing_rebalance":   0,
+				"group_analytics/state_dead":                   0,
+				"group_analytics/state_empty":                  1,
+				"group_analytics/state_preparing_rebalance":    0,
+				"group_analytics/state_reconciling":            0,
+				"group_analytics/state_stable":                 0,
+				"group_analytics/state_unknown":                0,
+				"topic_events/group_analytics/lag":             0,
+				"topic_orders/group_analytics/lag":             0,
+				"topic_orders/group_analytics/partition_0_lag": 0,
+				"group_billing/lag":                            30,
+				"group_billing/members":                        2,
+				"group_billing/state_assigning":                0,
+				"group_billing/state_completing_rebalance":     0,
+				"group_billing/state_dead":                     0,
+				"group_billing/state_empty":                    0,
+				"group_billing/state_preparing_rebalance":      0,
+				"group_billing/state_reconciling":              0,
+				"group_billing/state_stable":                   1,
+				"group_billing/state_unknown":                  0,
+				"topic_orders/group_billing/lag":               30,
+				"topic_orders/group_billing/partition_0_lag":   10,
+				"topic_orders/group_billing/partition_1_lag":   20,
+				"log_dirs_offline":                             0,
+				"partitions":                                   5,
+				"partitions_offline":                           1,
+				"partitions_under_replicated":                  2,
+				"replicas":                                     6,
+				"replicas_offline":                             1,
+				"topic_events/in_sync_replicas":                0,
+				"topic_events/partitions":                      1,
+				"topic_events/partitions_offline":              1,
+				"topic_events/partitions_under_replicated":     1,
+				"topic_events/replicas":                        1,
+				"topic_orders/in_sync_replicas":                3,
+				"topic_orders/log_size":                        6000,
+				"topic_orders/messages":                        310,
+				"topic_orders/partitions":                      3,
+				"topic_orders/partitions_offline":              0,
+				"topic_orders/partitions_under_replicated":     1,
+				"topic_orders/replicas":                        4,
+				"topic_orders/retained_messages":               250,
+				"topics":                                       3,
+			},
+			wantCharts: len(clusterCharts) +
+				len(brokerChartsTmpl)*1 +
+				len(topicChartsTmpl)*2 +
+				len(groupChartsTmpl)*2 +
+				len(groupTopicChartsTmpl)*3,
+		},
+		"success with partition metrics": {
+			prepare: func(t *testing.T) *Collector {
+				collr := prepareCaseOk(t)
+				collr.TopicSelector = "orders"
+				collr.ConsumerGroupSelector = "billing"
+				collr.CollectPartitionMetrics = true
+				return collr
+			},
+			wantMetrics: map[string]int64{
+				"broker_1_controller":                        1,
+				"broker_1_leader_partitions":                 4,
+				"broker_1_log_dirs_offline":                  0,
+				"broker_1_log_size":                          6600,
+				"broker_1_replicas":                          5,
+				"brokers":                                    1,
+				"consumer_groups":                            2,
+				"consumer_groups_state_assigning":            0,
+				"consumer_groups_state_completing_rebalance": 0,
+				"consumer_groups_state_dead":                 0,
+				"consumer_groups_state_empty":                0,
+				"consumer_groups_state_preparing_rebalance":  0,
+				"consumer_groups_state_reconciling":          0,
+				"consumer_groups_state_stable":               1,
+				"consumer_groups_state_unknown":              0,
+				"group_billing/lag":                          30,
+				"group_billing/members":                      2,
+				"group_billing/state_assigning":              0,
+				"group_billing/state_completing_rebalance":   0,
+				"group_billing/state_dead":                   0,
+				"group_billing/state_empty":                  0,
+				"group_billing/state_preparing_rebalance":    0,
+				"group_billing/state_reconciling":            0,
+				"group_billing/state_stable":                 1,
+				"group_billing/state_unknown":                0,
+				"topic_orders/group_billing/lag":             30,
+				"topic_orders/group_billing/partition_0_lag": 10,
+				"topic_orders/group_billing/partition_1_lag": 20,
+				"log_dirs_offline":                           0,
+				"partition_orders/0/in_sync_replicas":        1,
+				"partition_orders/0/log_size":                1000,
+				"partition_orders/0/messages":                100,
+				"partition_orders/0/offline":                 0,
+				"partition_orders/0/replicas":                1,
+				"partition_orders/0/retained_messages":       100,
+				"partition_orders/0/under_replicated":        0,
+				"partition_orders/1/in_sync_replicas":        1,
+				"partition_orders/1/log_size":                2000,
+				"partition_orders/1/messages":                200,
+				"partition_orders/1/offline":                 0,
+				"partition_orders/1/replicas":                1,
+				"partition_orders/1/retained_messages":       150,
+				"partition_orders/1/under_replicated":        0,
+				"partition_orders/2/in_sync_replicas":        1,
+				"partition_orders/2/log_size":                3000,
+				"partition_orders/2/messages":                10,
+				"partition_orders/2/offline":                 0,
+				"partition_orders/2/replicas":                2,
+				"partition_orders/2/retained_messages":       0,
+				"partition_orders/2/under_replicated":        1,
+				"partitions":                                 5,
+				"partitions_offline":                         1,
+				"partitions_under_replicated":                2,
+				"replicas":                                   6,
+				"replicas_offline":                           1,
+				"topic_orders/in_sync_replicas":              3,
+				"topic_orders/log_size":                      6000,
+				"topic_orders/messages":                      310,
+				"topic_orders/partitions":                    3,
+				"topic_orders/partitions_offline":            0,
+				"topic_orders/partitions_under_replicated":   1,
+				"topic_orders/replicas":                      4,
+				"topic_orders/retained_messages":             250,
+				"topics":                                     3,
+			},
+			wantCharts: len(clusterCharts) +
+				len(brokerChartsTmpl)*1 +
+				len(topicChartsTmpl)*1 +
+				len(partitionChartsTmpl)*3 +
+				len(groupChartsTmpl)*1 +
+				len(groupTopicChartsTmpl)*1,
+		},
+		"fails on unexpected response": {
+			prepare:    prepareCaseUnexpectedResponse,
+			wantCharts: len(clusterCharts),
+		},
+		"fails on connection refused": {
+			prepare:    prepareCaseConnectionRefused,
+			wantCharts: len(clusterCharts),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := test.prepare(t)
+			defer collr.Cleanup(context.Background())
+
+			require.NoError(t, collr.Init(context.Background()))
+
+			mx := collr.Collect(context.Background())
+
+			require.Equal(t, test.wantMetrics, mx)
+
+			assert.Equal(t, test.wantCharts, len(*collr.Charts()), "want charts")
+
+			if len(test.wantMetrics) > 0 {
+				module.TestMetricsHasAllChartsDimsSkip(t, collr.Charts(), mx, func(chart *module.Chart, _ *module.Dim) bool {
+					// offline partitions have no offsets and log size
+					return chart.ID == "topic_events_messages_rate" ||
+						chart.ID == "topic_events_retained_messages" ||
+						chart.ID == "topic_events_log_size"
+				})
+			}
+		})
+	}
+}
+
+func TestCollector_Collect_RemovesStaleCharts(t *testing.T) {
+	broker := newMockKafkaBroker(t)
+	collr := New()
+	collr.Brokers = []string{broker.addr()}
+	defer collr.Cleanup(context.Background())
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	_ = collr.Collect(context.Background())
+
+	broker.mux.Lock()
+	broker.groups = broker.groups[:1] // "analytics" group is deleted
+	broker.mux.Unlock()
+
+	_ = collr.Collect(context.Background())
+
+	for _, id := range []string{"group_analytics_lag", "group_analytics_topic_orders_lag", "group_analytics_topic_events_partition_lag"} {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s'", id)
+		assert.Truef(t, chart.Obsolete, "chart '%s' is not obsolete", id)
+	}
+	assert.False(t, collr.Charts().Get("group_billing_lag").Obsolete)
+}
+
+func TestMetricKeys_Unambiguous(t *testing.T) {
+	keys := map[string]string{
+		"topic orders_retained, messages": topicPx("orders_retained") + "messages",
+		"topic orders, retained_messages": topicPx("orders") + "retained_messages",
+		"group a_topic_b, topic c":        groupTopicPx("a_topic_b", "c") + "lag",
+		"group a, topic b_topic_c":        groupTopicPx("a", "b_topic_c") + "lag",
+		"group x/topic_y/lag":             groupPx("x/topic_y") + "lag",
+		"group x, topic y":                groupTopicPx("x", "y") + "lag",
+		"group g/group_h, topic t":        groupTopicPx("g/group_h", "t") + "lag",
+		"group h, topic t":                groupTopicPx("h", "t") + "lag",
+		"group g, topic t, partition 1":   groupPartitionDimID("g", "t", 1),
+		"group g, topic t_partition_1":    groupTopicPx("g", "t_partition_1") + "lag",
+	}
+
+	seen := make(map[string]string)
+	for name, key := range keys {
+		other, ok := seen[key]
+		assert.Falsef(t, ok, "'%s' and '%s' have the same key '%s'", name, other, key)
+		seen[key] = name
+	}
+}
+
+func TestDecoder_MalformedInput(t *testing.T) {
+	e := &encoder{}
+	e.int32(0)         // throttle_time_ms
+	e.arrayLen(100500) // brokers, but no data
+
+	_, err := decodeMetadataResponse(&decoder{buf: e.buf})
+	assert.True(t, errors.Is(err, errMalformed))
+}
+
+func prepareCaseOk(t *testing.T) *Collector {
+	broker := newMockKafkaBroker(t)
+
+	collr := New()
+	collr.Brokers = []string{broker.addr()}
+
+	return collr
+}
+
+func prepareCaseSASL(username, password string) func(t *testing.T) *Collector {
+	return func(t *testing.T) *Collector {
+		// credentials are set before the broker starts accepting connections
+		broker := newMockKafkaBroker(t, func(m *mockKafkaBroker) {
+			m.username = "netdata"
+			m.password = "secret"
+		})
+
+		collr := New()
+		collr.Brokers = []string{broker.addr()}
+		collr.Username = username
+		collr.Password = password
+
+		return collr
+	}
+}
+
+func prepareCaseUnexpectedResponse(t *testing.T) *Collector {
+	srv, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Close() })
+
+	go func() {
+		for {
+			conn, err := srv.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_, _ = conn.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			}()
+		}
+	}()
+
+	collr := New()
+	collr.Brokers = []string{srv.Addr().String()}
+
+	return collr
+}
+
+func prepareCaseConnectionRefused(t *testing.T) *Collector {
+	srv, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := srv.Addr().String()
+	require.NoError(t, srv.Close())
+
+	collr := New()
+	collr.Brokers = []string{addr}
+
+	return collr
+}
+
+type (
+	mockKafkaBroker struct {
+		srv      net.Listener
+		username string
+		password string
+
+		mux    sync.Mutex
+		topics []mockTopic
+		groups []mockGroup
+	}
+	mockTopic struct {
+		name       string
+		internal   bool
+		partitions []mockPartition
+	}
+	mockPartition struct {
+		leader   int32
+		replicas []int32
+		isr      []int32
+		offline  []int32
+		earliest int64
+		latest   int64
+		size     int64
+	}
+	mockGroup struct {
+		id        string
+		state     string
+		members   int
+		committed map[string]map[int32]int64
+	}
+)
+
+// newMockKafkaBroker starts a single broker (node id 1) cluster that is the controller and the coordinator of all groups.
+func newMockKafkaBroker(t *testing.T, opts ...func(*mockKafkaBroker)) *mockKafkaBroker {
+	srv, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	m := &mockKafkaBroker{
+		srv: srv,
+		topics: []mockTopic{
+			{name: "orders", partitions: []mockPartition{
+				{leader: 1, replicas: []int32{1}, isr: []int32{1}, earliest: 0, latest: 100, size: 1000},
+				{leader: 1, replicas: []int32{1}, isr: []int32{1}, earliest: 50, latest: 200, size: 2000},
+				{leader: 1, replicas: []int32{1, 2}, isr: []int32{1}, earliest: 10, latest: 10, size: 3000},
+			}},
+			{name: "events", partitions: []mockPartition{
+				{leader: -1, replicas: []int32{1}, isr: []int32{}, offline: []int32{1}, size: 500},
+			}},
+			{name: "__consumer_offsets", internal: true, partitions: []mockPartition{
+				{leader: 1, replicas: []int32{1}, isr: []int32{1}, earliest: 0, latest: 7, size: 100},
+			}},
+		},
+		groups: []mockGroup{
+			{id: "billing", state: "Stable", members: 2, committed: map[string]map[int32]int64{
+				"orders": {0: 90, 1: 180},
+			}},
+			{id: "analytics", state: "Empty", committed: map[string]map[int32]int64{
+				"orders": {0: 100},
+				"events": {0: 5},
+			}},
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	t.Cleanup(func() { _ = srv.Close() })
+
+	go func() {
+		for {
+			conn, err := srv.Accept()
+			if err != nil {
+				return
+			}
+			go m.handleConn(conn)
+		}
+	}()
+
+	return m
+}
+
+func (m *mockKafkaBroker) addr() string { return m.srv.Addr().String() }
+
+func (m *mockKafkaBroker) handleConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	authenticated := m.username == ""
+
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+
+		d := &decoder{buf: req}
+		apiKey := d.int16()
+		_ = d.int16() // api version
+		corrID := d.int32()
+		_ = d.string() // client id
+
+		if !authenticated && apiKey != apiKeySaslHandshake && apiKey != apiKeySaslAuthenticate {
+			return
+		}
+
+		e := &encoder{buf: make([]byte, 4)}
+		e.int32(corrID)
+
+		m.mux.Lock()
+		switch apiKey {
+		case apiKeySaslHandshake:
+			e.int16(0)
+			e.arrayLen(1)
+			e.string("PLAIN")
+		case apiKeySaslAuthenticate:
+			token := string(d.bytes())
+			if token == "\x00"+m.username+"\x00"+m.password {
+				authenticated = true
+				e.int16(0)
+				e.nullableString(nil)
+			} else {
+				msg := "Authentication failed: Invalid username or password"
+				e.int16(58)
+				e.nullableString(&msg)
+			}
+			e.bytes(nil)
+		case apiKeyMetadata:
+			m.encodeMetadata(e)
+		case apiKeyListOffsets:
+			m.encodeListOffsets(d, e)
+		case apiKeyDescribeLogDirs:
+			m.encodeDescribeLogDirs(e)
+		case apiKeyListGroups:
+			e.int32(0) // throttle_time_ms
+			e.int16(0)
+			e.arrayLen(len(m.groups))
+			for _, g := range m.groups {
+				e.string(g.id)
+				e.string("consumer")
+			}
+		case apiKeyDescribeGroups:
+			m.encodeDescribeGroups(d, e)
+		case apiKeyOffsetFetch:
+			m.encodeOffsetFetch(d, e)
+		default:
+			m.mux.Unlock()
+			return
+		}
+		m.mux.Unlock()
+
+		binary.BigEndian.PutUint32(e.buf[:4], uint32(len(e.buf)-4))
+		if _, err := conn.Write(e.buf); err != nil {
+			return
+		}
+	}
+}
+
+func (m *mockKafkaBroker) encodeMetadata(e *encoder) {
+	host, port, _ := net.SplitHostPort(m.addr())
+	p, _ := strconv.Atoi(port)
+
+	e.int32(0) // throttle_time_ms
+	e.arrayLen(1)
+	e.int32(1)
+	e.string(host)
+	e.int32(int32(p))
+	e.nullableString(nil)
+	clusterID := "test"
+	e.nullableString(&clusterID)
+	e.int32(1) // controller_id
+
+	encodeInt32s := func(vs []int32) {
+		e.arrayLen(len(vs))
+		for _, v := range vs {
+			e.int32(v)
+		}
+	}
+
+	e.arrayLen(len(m.topics))
+	for _, t := range m.topics {
+		e.int16(0)
+		e.string(t.name)
+		e.bool(t.internal)
+		e.arrayLen(len(t.partitions))
+		for i, p := range t.partitions {
+			code := int16(0)
+			if p.leader < 0 {
+				code = 5 // LEADER_NOT_AVAILABLE
+			}
+			e.int16(code)
+			e.int32(int32(i))
+			e.int32(p.leader)
+			encodeInt32s(p.replicas)
+			encodeInt32s(p.isr)
+			encodeInt32s(p.offline)
+		}
+	}
+}
+
+func (m *mockKafkaBroker) encodeListOffsets(d *decoder, e *encoder) {
+	_ = d.int32() // replica_id
+	_ = d.int8()  // isolation_level
+
+	e.int32(0) // throttle_time_ms
+
+	nt := d.arrayLen()
+	e.arrayLen(nt)
+	for i := 0; i < nt; i++ {
+		name := d.string()
+		e.string(name)
+
+		np := d.arrayLen()
+		e.arrayLen(np)
+		for j := 0; j < np; j++ {
+			idx := d.int32()
+			ts := d.int64()
+
+			p, ok := m.partition(name, idx)
+			e.int32(idx)
+			if !ok || p.leader != 1 {
+				e.int16(6) // NOT_LEADER_OR_FOLLOWER
+				e.int64(-1)
+				e.int64(-1)
+				continue
+			}
+			e.int16(0)
+			e.int64(-1)
+			if ts == offsetEarliest {
+				e.int64(p.earliest)
+			} else {
+				e.int64(p.latest)
+			}
+		}
+	}
+}
+
+func (m *mockKafkaBroker) encodeDescribeLogDirs(e *encoder) {
+	e.int32(0) // throttle_time_ms
+	e.arrayLen(1)
+	e.int16(0)
+	e.string("/var/lib/kafka/data")
+	e.arrayLen(len(m.topics))
+	for _, t := range m.topics {
+		e.string(t.name)
+		e.arrayLen(len(t.partitions))
+		for i, p := range t.partitions {
+			e.int32(int32(i))
+			e.int64(p.size)
+			e.int64(0)
+			e.bool(false)
+		}
+	}
+}
+
+func (m *mockKafkaBroker) encodeDescribeGroups(d *decoder, e *encoder) {
+	e.int32(0) // throttle_time_ms
+
+	n := d.arrayLen()
+	e.arrayLen(n)
+	for i := 0; i < n; i++ {
+		id := d.string()
+		g, ok := m.group(id)
+		if !ok {
+			e.int16(69) // GROUP_ID_NOT_FOUND
+			e.string(id)
+			e.string("Dead")
+			e.string("")
+			e.string("")
+			e.arrayLen(0)
+			continue
+		}
+		e.int16(0)
+		e.string(g.id)
+		e.string(g.state)
+		e.string("consumer")
+		e.string("range")
+		e.arrayLen(g.members)
+		for j := 0; j < g.members; j++ {
+			e.string("member-" + strconv.Itoa(j))
+			e.string("client-" + strconv.Itoa(j))
+			e.string("/127.0.0.1")
+			e.bytes([]byte{0, 1})
+			e.bytes([]byte{0, 1})
+		}
+	}
+}
+
+func (m *mockKafkaBroker) encodeOffsetFetch(d *decoder, e *encoder) {
+	id := d.string()
+
+	e.int32(0) // throttle_time_ms
+
+	g, ok := m.group(id)
+	if !ok {
+		e.arrayLen(0)
+		e.int16(0)
+		return
+	}
+
+	e.arrayLen(len(g.committed))
+	for topic, parts := range g.committed {
+		e.string(topic)
+		e.arrayLen(len(parts))
+		for idx, offset := range parts {
+			e.int32(idx)
+			e.int64(offset)
+			e.nullableString(nil)
+			e.int16(0)
+		}
+	}
+	e.int16(0)
+}
+
+func (m *mockKafkaBroker) partition(topic string, idx int32) (mockPartition, bool) {
+	for _, t := range m.topics {
+		if t.name == topic && int(idx) < len(t.partitions) {
+			return t.partitions[idx], true
+		}
+	}
+	return mockPartition{}, false
+}
+
+func (m *mockKafkaBroker) group(id string) (mockGroup, bool) {
+	for _, g := range m.groups {
+		if g.id == id {
+			return g, true
+		}
+	}
+	return mockGroup{}, false
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+import (
+	"errors"
+
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+)
+
+func (c *Collector) validateConfig() error {
+	if len(c.Brokers) == 0 {
+		return errors.New("no brokers set")
+	}
+	for _, addr := range c.Brokers {
+		if addr == "" {
+			return errors.New("empty broker address")
+		}
+	}
+	if c.Password != "" && c.Username == "" {
+		return errors.New("password set without username")
+	}
+	return nil
+}
+
+func initSelector(expr string) (matcher.Matcher, error) {
+	if expr == "" {
+		return matcher.TRUE(), nil
+	}
+	return matcher.NewSimplePatternsMatcher(expr)
+}
@@ -0,0 +1,504 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Only non-flexible API versions are used. They are supported by all brokers since Kafka 2.1,
+// which is the minimum baseline kept by Kafka 4.0 (KIP-896).
+// https://kafka.apache.org/protocol.html
+
+const (
+	apiKeyListOffsets      int16 = 2
+	apiKeyMetadata         int16 = 3
+	apiKeyOffsetFetch      int16 = 9
+	apiKeyDescribeGroups   int16 = 15
+	apiKeyListGroups       int16 = 16
+	apiKeySaslHandshake    int16 = 17
+	apiKeyDescribeLogDirs  int16 = 35
+	apiKeySaslAuthenticate int16 = 36
+)
+
+const (
+	apiVersionListOffsets      int16 = 2
+	apiVersionMetadata         int16 = 5
+	apiVersionOffsetFetch      int16 = 3
+	apiVersionDescribeGroups   int16 = 1
+	apiVersionListGroups       int16 = 1
+	apiVersionSaslHandshake    int16 = 1
+	apiVersionDescribeLogDirs  int16 = 1
+	apiVersionSaslAuthenticate int16 = 0
+)
+
+const (
+	offsetLatest   int64 = -1
+	offsetEarliest int64 = -2
+)
+
+var errMalformed = errors.New("malformed response")
+
+type kafkaError int16
+
+func (e kafkaError) Error() string {
+	if s, ok := kafkaErrors[int16(e)]; ok {
+		return fmt.Sprintf("kafka error %d (%s)", int16(e), s)
+	}
+	return fmt.Sprintf("kafka error %d", int16(e))
+}
+
+var kafkaErrors = map[int16]string{
+	3:  "UNKNOWN_TOPIC_OR_PARTITION",
+	5:  "LEADER_NOT_AVAILABLE",
+	6:  "NOT_LEADER_OR_FOLLOWER",
+	14: "COORDINATOR_LOAD_IN_PROGRESS",
+	15: "COORDINATOR_NOT_AVAILABLE",
+	16: "NOT_COORDINATOR",
+	29: "TOPIC_AUTHORIZATION_FAILED",
+	30: "GROUP_AUTHORIZATION_FAILED",
+	31: "CLUSTER_AUTHORIZATION_FAILED",
+	33: "UNSUPPORTED_SASL_MECHANISM",
+	34: "ILLEGAL_SASL_STATE",
+	35: "UNSUPPORTED_VERSION",
+	58: "SASL_AUTHENTICATION_FAILED",
+	69: "GROUP_ID_NOT_FOUND",
+}
+
+func errorFromCode(code int16) error {
+	if code == 0 {
+		return nil
+	}
+	return kafkaError(code)
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) int8(v int8)   { e.buf = append(e.buf, byte(v)) }
+func (e *encoder) int16(v int16) { e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(v)) }
+func (e *encoder) int32(v int32) { e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v)) }
+func (e *encoder) int64(v int64) { e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v)) }
+
+func (e *encoder) bool(v bool) {
+	if v {
+		e.int8(1)
+	} else {
+		e.int8(0)
+	}
+}
+
+func (e *encoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *encoder) nullableString(s *string) {
+	if s == nil {
+		e.int16(-1)
+		return
+	}
+	e.string(*s)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) arrayLen(n int) { e.int32(int32(n)) }
+
+func (e *encoder) nullArray() { e.int32(-1) }
+
+type decoder struct {
+	buf []byte
+	off int
+	err error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || d.off+n > len(d.buf) {
+		d.err = errMalformed
+		return nil
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b
+}
+
+func (d *decoder) int8() int8 {
+	if b := d.next(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *decoder) int16() int16 {
+	if b := d.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *decoder) int32() int32 {
+	if b := d.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *decoder) int64() int64 {
+	if b := d.next(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *decoder) bool() bool { return d.int8() != 0 }
+
+func (d *decoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.next(int(n)))
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return d.next(int(n))
+}
+
+// arrayLen returns the number of elements, null arrays are treated as empty.
+func (d *decoder) arrayLen() int {
+	n := d.int32()
+	if d.err != nil || n < 0 {
+		return 0
+	}
+	// every element takes at least one byte, it protects from allocating huge slices on malformed input
+	if int(n) > len(d.buf)-d.off {
+		d.err = errMalformed
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) int32Array() []int32 {
+	n := d.arrayLen()
+	vs := make([]int32, 0, n)
+	for i := 0; i < n && d.err == nil; i++ {
+		vs = append(vs, d.int32())
+	}
+	return vs
+}
+
+type (
+	metadataResponse struct {
+		Brokers      []brokerMetadata
+		ClusterID    string
+		ControllerID int32
+		Topics       []topicMetadata
+	}
+	brokerMetadata struct {
+		NodeID int32
+		Host   string
+		Port   int32
+		Rack   string
+	}
+	topicMetadata struct {
+		Err        int16
+		Name       string
+		IsInternal bool
+		Partitions []partitionMetadata
+	}
+	partitionMetadata struct {
+		Err             int16
+		Index           int32
+		Leader          int32
+		Replicas        []int32
+		ISR             []int32
+		OfflineReplicas []int32
+	}
+)
+
+func encodeMetadataRequest(e *encoder) {
+	e.nullArray() // all topics
+	e.bool(false) // allow_auto_topic_creation
+}
+
+func decodeMetadataResponse(d *decoder) (*metadataResponse, error) {
+	var resp metadataResponse
+
+	_ = d.int32() // throttle_time_ms
+
+	n := d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		var b brokerMetadata
+		b.NodeID = d.int32()
+		b.Host = d.string()
+		b.Port = d.int32()
+		b.Rack = d.string()
+		resp.Brokers = append(resp.Brokers, b)
+	}
+
+	resp.ClusterID = d.string()
+	resp.ControllerID = d.int32()
+
+	n = d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		var t topicMetadata
+		t.Err = d.int16()
+		t.Name = d.string()
+		t.IsInternal = d.bool()
+		np := d.arrayLen()
+		for j := 0; j < np && d.err == nil; j++ {
+			var p partitionMetadata
+			p.Err = d.int16()
+			p.Index = d.int32()
+			p.Leader = d.int32()
+			p.Replicas = d.int32Array()
+			p.ISR = d.int32Array()
+			p.OfflineReplicas = d.int32Array()
+			t.Partitions = append(t.Partitions, p)
+		}
+		resp.Topics = append(resp.Topics, t)
+	}
+
+	return &resp, d.err
+}
+
+func encodeListOffsetsRequest(e *encoder, timestamp int64, partitions map[string][]int32) {
+	e.int32(-1) // replica_id
+	e.int8(0)   // isolation_level: READ_UNCOMMITTED, the latest offset is the high watermark
+	e.arrayLen(len(partitions))
+	for topic, parts := range partitions {
+		e.string(topic)
+		e.arrayLen(len(parts))
+		for _, p := range parts {
+			e.int32(p)
+			e.int64(timestamp)
+		}
+	}
+}
+
+// decodeListOffsetsResponse returns offsets by topic and partition, partitions with errors are skipped.
+func decodeListOffsetsResponse(d *decoder) (map[string]map[int32]int64, error) {
+	offsets := make(map[string]map[int32]int64)
+
+	_ = d.int32() // throttle_time_ms
+
+	n := d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		topic := d.string()
+		np := d.arrayLen()
+		for j := 0; j < np && d.err == nil; j++ {
+			idx := d.int32()
+			code := d.int16()
+			_ = d.int64() // timestamp
+			offset := d.int64()
+			if code != 0 || d.err != nil {
+				continue
+			}
+			if offsets[topic] == nil {
+				offsets[topic] = make(map[int32]int64)
+			}
+			offsets[topic][idx] = offset
+		}
+	}
+
+	return offsets, d.err
+}
+
+type logDirsResponse struct {
+	OfflineDirs int
+	// partition sizes by topic and partition, only for the current (non-future) replicas
+	Sizes map[string]map[int32]int64
+}
+
+func encodeDescribeLogDirsRequest(e *encoder) {
+	e.nullArray() // all topics
+}
+
+func decodeDescribeLogDirsResponse(d *decoder) (*logDirsResponse, error) {
+	resp := &logDirsResponse{Sizes: make(map[string]map[int32]int64)}
+
+	_ = d.int32() // throttle_time_ms
+
+	n := d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		code := d.int16()
+		_ = d.string() // log_dir
+		if code != 0 {
+			resp.OfflineDirs++
+		}
+		nt := d.arrayLen()
+		for j := 0; j < nt && d.err == nil; j++ {
+			topic := d.string()
+			np := d.arrayLen()
+			for k := 0; k < np && d.err == nil; k++ {
+				idx := d.int32()
+				size := d.int64()
+				_ = d.int64() // offset_lag
+				future := d.bool()
+				if future {
+					continue
+				}
+				if resp.Sizes[topic] == nil {
+					resp.Sizes[topic] = make(map[int32]int64)
+				}
+				resp.Sizes[topic][idx] += size
+			}
+		}
+	}
+
+	return resp, d.err
+}
+
+type groupListing struct {
+	GroupID      string
+	ProtocolType string
+}
+
+func decodeListGroupsResponse(d *decoder) ([]groupListing, error) {
+	_ = d.int32() // throttle_time_ms
+	if err := errorFromCode(d.int16()); err != nil && d.err == nil {
+		return nil, err
+	}
+
+	var groups []groupListing
+	n := d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		var g groupListing
+		g.GroupID = d.string()
+		g.ProtocolType = d.string()
+		groups = append(groups, g)
+	}
+
+	return groups, d.err
+}
+
+type groupDescription struct {
+	Err          int16
+	GroupID      string
+	State        string
+	ProtocolType string
+	Members      int
+}
+
+func encodeDescribeGroupsRequest(e *encoder, groups []string) {
+	e.arrayLen(len(groups))
+	for _, g := range groups {
+		e.string(g)
+	}
+}
+
+func decodeDescribeGroupsResponse(d *decoder) ([]groupDescription, error) {
+	_ = d.int32() // throttle_time_ms
+
+	var groups []groupDescription
+	n := d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		var g groupDescription
+		g.Err = d.int16()
+		g.GroupID = d.string()
+		g.State = d.string()
+		g.ProtocolType = d.string()
+		_ = d.string() // protocol_data
+		nm := d.arrayLen()
+		for j := 0; j < nm && d.err == nil; j++ {
+			_ = d.string() // member_id
+			_ = d.string() // client_id
+			_ = d.string() // client_host
+			_ = d.bytes()  // member_metadata
+			_ = d.bytes()  // member_assignment
+		}
+		g.Members = nm
+		groups = append(groups, g)
+	}
+
+	return groups, d.err
+}
+
+func encodeOffsetFetchRequest(e *encoder, group string) {
+	e.string(group)
+	e.nullArray() // all topics
+}
+
+// decodeOffsetFetchResponse returns committed offsets by topic and partition.
+// Partitions without a committed offset (-1) or with errors are skipped.
+func decodeOffsetFetchResponse(d *decoder) (map[string]map[int32]int64, error) {
+	offsets := make(map[string]map[int32]int64)
+
+	_ = d.int32() // throttle_time_ms
+
+	n := d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		topic := d.string()
+		np := d.arrayLen()
+		for j := 0; j < np && d.err == nil; j++ {
+			idx := d.int32()
+			offset := d.int64()
+			_ = d.string() // metadata
+			code := d.int16()
+			if code != 0 || offset < 0 || d.err != nil {
+				continue
+			}
+			if offsets[topic] == nil {
+				offsets[topic] = make(map[int32]int64)
+			}
+			offsets[topic][idx] = offset
+		}
+	}
+
+	if code := d.int16(); code != 0 && d.err == nil {
+		return nil, errorFromCode(code)
+	}
+
+	return offsets, d.err
+}
+
+func encodeSaslHandshakeRequest(e *encoder, mechanism string) {
+	e.string(mechanism)
+}
+
+func decodeSaslHandshakeResponse(d *decoder) ([]string, error) {
+	code := d.int16()
+	var mechanisms []string
+	n := d.arrayLen()
+	for i := 0; i < n && d.err == nil; i++ {
+		mechanisms = append(mechanisms, d.string())
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return mechanisms, errorFromCode(code)
+}
+
+func encodeSaslAuthenticateRequest(e *encoder, authBytes []byte) {
+	e.bytes(authBytes)
+}
+
+func decodeSaslAuthenticateResponse(d *decoder) error {
+	code := d.int16()
+	msg := d.string()
+	_ = d.bytes() // auth_bytes
+	if d.err != nil {
+		return d.err
+	}
+	if err := errorFromCode(code); err != nil {
+		if msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
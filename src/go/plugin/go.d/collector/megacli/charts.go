@@ -135,6 +135,10 @@ var (
 )
 
 func (c *Collector) addAdapterCharts(ad *megaAdapter) {
+	if c.RAIDCharts {
+		return
+	}
+
 	charts := adapterChartsTmpl.Copy()
 
 	for _, chart := range *charts {
@@ -153,6 +157,10 @@ func (c *Collector) addAdapterCharts(ad *megaAdapter) {
 }
 
 func (c *Collector) addPhysDriveCharts(pd *megaPhysDrive) {
+	if c.RAIDCharts {
+		return
+	}
+
 	charts := physDriveChartsTmpl.Copy()
 
 	for _, chart := range *charts {
@@ -175,6 +183,10 @@ func (c *Collector) addPhysDriveCharts(pd *megaPhysDrive) {
 }
 
 func (c *Collector) addBBUCharts(bbu *megaBBU) {
+	if c.RAIDCharts {
+		return
+	}
+
 	charts := bbuChartsTmpl.Copy()
 
 	if _, ok := calcCapDegradationPerc(bbu); !ok {
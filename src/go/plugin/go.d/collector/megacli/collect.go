@@ -7,18 +7,30 @@ package megacli
 import (
 	"strconv"
 	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
 )
 
 func (c *Collector) collect() (map[string]int64, error) {
 	mx := make(map[string]int64)
 
-	if err := c.collectPhysDrives(mx); err != nil {
+	adapters, err := c.collectPhysDrives(mx)
+	if err != nil {
 		return nil, err
 	}
-	if err := c.collectBBU(mx); err != nil {
+	bbus, err := c.collectBBU(mx)
+	if err != nil {
 		return nil, err
 	}
 
+	raidControllers := c.raidControllers(adapters, bbus)
+	hwraid.WriteMetrics(mx, raidControllers)
+	if c.RAIDCharts {
+		if err := c.raidCharts.Update(raidControllers); err != nil {
+			c.Warning(err)
+		}
+	}
+
 	return mx, nil
 }
 
@@ -15,6 +15,9 @@ import (
 type megaBBU struct {
 	adapterNumber string
 	batteryType   string
+	batteryState  string
+	replacement   string // "Battery Replacement required"
+	packFailing   string // "Pack is about to fail & should be replaced"
 	temperature   string
 	rsoc          string
 	asoc          string // apparently can be 0 while relative > 0 (e.g. relative 91%, absolute 0%)
@@ -23,20 +26,20 @@ type megaBBU struct {
 	designCap     string
 }
 
-func (c *Collector) collectBBU(mx map[string]int64) error {
+func (c *Collector) collectBBU(mx map[string]int64) (map[string]*megaBBU, error) {
 	bs, err := c.exec.bbuInfo()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	bbus, err := parseBBUInfo(bs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(bbus) == 0 {
 		c.Debugf("no BBUs found")
-		return nil
+		return nil, nil
 	}
 
 	for _, bbu := range bbus {
@@ -58,7 +61,7 @@ func (c *Collector) collectBBU(mx map[string]int64) error {
 
 	c.Debugf("found %d BBUs", len(c.bbu))
 
-	return nil
+	return bbus, nil
 }
 
 func parseBBUInfo(bs []byte) (map[string]*megaBBU, error) {
@@ -87,8 +90,10 @@ func parseBBUInfo(bs []byte) (map[string]*megaBBU, error) {
 		case strings.HasPrefix(line, "BBU Design Info for Adapter"):
 			section = "design"
 			continue
-		case strings.HasPrefix(line, "BBU Firmware Status"),
-			strings.HasPrefix(line, "BBU GasGauge Status"),
+		case strings.HasPrefix(line, "BBU Firmware Status"):
+			section = "firmware"
+			continue
+		case strings.HasPrefix(line, "BBU GasGauge Status"),
 			strings.HasPrefix(line, "BBU Properties for Adapter"):
 			section = ""
 			continue
@@ -103,9 +108,18 @@ func parseBBUInfo(bs []byte) (map[string]*megaBBU, error) {
 			switch {
 			case strings.HasPrefix(line, "BatteryType:"):
 				bbu.batteryType = getColonSepValue(line)
+			case strings.HasPrefix(line, "Battery State:"):
+				bbu.batteryState = getColonSepValue(line)
 			case strings.HasPrefix(line, "Temperature:"):
 				bbu.temperature = getColonSepNumValue(line)
 			}
+		case "firmware":
+			switch {
+			case strings.HasPrefix(line, "Battery Replacement required"):
+				bbu.replacement = getColonSepValue(line)
+			case strings.HasPrefix(line, "Pack is about to fail"):
+				bbu.packFailing = getColonSepValue(line)
+			}
 		case "capacity":
 			switch {
 			case strings.HasPrefix(line, "Relative State of Charge:"):
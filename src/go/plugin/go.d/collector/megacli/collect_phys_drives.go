@@ -14,20 +14,35 @@ import (
 
 type (
 	megaAdapter struct {
-		number     string
-		name       string
-		state      string
-		physDrives map[string]*megaPhysDrive
+		number        string
+		name          string
+		state         string
+		logicalDrives map[string]*megaLogicalDrive
+		physDrives    map[string]*megaPhysDrive
+	}
+	megaLogicalDrive struct {
+		number    string
+		name      string
+		raidLevel string
+		state     string
 	}
 	megaPhysDrive struct {
 		adapterNumber          string
+		ldNumber               string
 		number                 string
 		wwn                    string
+		enclosureID            string
 		slotNumber             string
 		drivePosition          string
 		pdType                 string
+		inquiryData            string
+		mediaType              string
+		firmwareState          string
 		mediaErrorCount        string
+		otherErrorCount        string
 		predictiveFailureCount string
+		temperature            string
+		smartAlert             string
 	}
 )
 
@@ -38,18 +53,18 @@ var adapterStates = []string{
 	"failed",
 }
 
-func (c *Collector) collectPhysDrives(mx map[string]int64) error {
+func (c *Collector) collectPhysDrives(mx map[string]int64) (map[string]*megaAdapter, error) {
 	bs, err := c.exec.physDrivesInfo()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	adapters, err := parsePhysDrivesInfo(bs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(adapters) == 0 {
-		return errors.New("no adapters found")
+		return nil, errors.New("no adapters found")
 	}
 
 	var drives int
@@ -83,13 +98,14 @@ func (c *Collector) collectPhysDrives(mx map[string]int64) error {
 
 	c.Debugf("found %d adapters, %d physical drives", len(c.adapters), drives)
 
-	return nil
+	return adapters, nil
 }
 
 func parsePhysDrivesInfo(bs []byte) (map[string]*megaAdapter, error) {
 	adapters := make(map[string]*megaAdapter)
 
 	var ad *megaAdapter
+	var ld *megaLogicalDrive
 	var pd *megaPhysDrive
 
 	sc := bufio.NewScanner(bytes.NewReader(bs))
@@ -100,18 +116,43 @@ func parsePhysDrivesInfo(bs []byte) (map[string]*megaAdapter, error) {
 		switch {
 		case strings.HasPrefix(line, "Adapter #"):
 			idx := strings.TrimPrefix(line, "Adapter #")
-			ad = &megaAdapter{number: idx, physDrives: make(map[string]*megaPhysDrive)}
+			ad = &megaAdapter{
+				number:        idx,
+				logicalDrives: make(map[string]*megaLogicalDrive),
+				physDrives:    make(map[string]*megaPhysDrive),
+			}
 			adapters[idx] = ad
+			ld, pd = nil, nil
+		case strings.HasPrefix(line, "Virtual Drive:") && ad != nil:
+			// Virtual Drive: 0 (Target Id: 0)
+			if parts := strings.Fields(line); len(parts) >= 3 {
+				idx := parts[2]
+				ld = &megaLogicalDrive{number: idx}
+				ad.logicalDrives[idx] = ld
+			}
 		case strings.HasPrefix(line, "Name") && ad != nil:
 			ad.name = getColonSepValue(line)
+			if ld != nil {
+				ld.name = ad.name
+			}
+		case strings.HasPrefix(line, "RAID Level") && ld != nil:
+			ld.raidLevel = getColonSepValue(line)
 		case strings.HasPrefix(line, "State") && ad != nil:
 			ad.state = getColonSepValue(line)
+			if ld != nil {
+				ld.state = ad.state
+			}
 		case strings.HasPrefix(line, "PD:") && ad != nil:
 			if parts := strings.Fields(line); len(parts) == 3 {
 				idx := parts[1]
 				pd = &megaPhysDrive{number: idx, adapterNumber: ad.number}
+				if ld != nil {
+					pd.ldNumber = ld.number
+				}
 				ad.physDrives[idx] = pd
 			}
+		case strings.HasPrefix(line, "Enclosure Device ID:") && pd != nil:
+			pd.enclosureID = getColonSepValue(line)
 		case strings.HasPrefix(line, "Slot Number:") && pd != nil:
 			pd.slotNumber = getColonSepValue(line)
 		case strings.HasPrefix(line, "Drive's position:") && pd != nil:
@@ -122,8 +163,21 @@ func parsePhysDrivesInfo(bs []byte) (map[string]*megaAdapter, error) {
 			pd.pdType = getColonSepValue(line)
 		case strings.HasPrefix(line, "Media Error Count:") && pd != nil:
 			pd.mediaErrorCount = getColonSepNumValue(line)
+		case strings.HasPrefix(line, "Other Error Count:") && pd != nil:
+			pd.otherErrorCount = getColonSepNumValue(line)
 		case strings.HasPrefix(line, "Predictive Failure Count:") && pd != nil:
 			pd.predictiveFailureCount = getColonSepNumValue(line)
+		case strings.HasPrefix(line, "Firmware state:") && pd != nil:
+			pd.firmwareState = getColonSepValue(line)
+		case strings.HasPrefix(line, "Inquiry Data:") && pd != nil:
+			pd.inquiryData = getColonSepValue(line)
+		case strings.HasPrefix(line, "Media Type:") && pd != nil:
+			pd.mediaType = getColonSepValue(line)
+		case strings.HasPrefix(line, "Drive Temperature") && pd != nil:
+			// Drive Temperature :33C (91.40 F)
+			pd.temperature = strings.TrimSuffix(getColonSepNumValue(line), "C")
+		case strings.HasPrefix(line, "Drive has flagged a S.M.A.R.T alert") && pd != nil:
+			pd.smartAlert = getColonSepValue(line)
 		}
 	}
 
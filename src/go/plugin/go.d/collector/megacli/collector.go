@@ -13,6 +13,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
 )
 
 //go:embed "config_schema.json"
@@ -30,30 +31,40 @@ func init() {
 }
 
 func New() *Collector {
+	charts := &module.Charts{}
+
 	return &Collector{
 		Config: Config{
 			Timeout: confopt.Duration(time.Second * 2),
 		},
-		charts:   &module.Charts{},
-		adapters: make(map[string]bool),
-		drives:   make(map[string]bool),
-		bbu:      make(map[string]bool),
+		charts:     charts,
+		raidCharts: hwraid.NewCharts(charts),
+		adapters:   make(map[string]bool),
+		drives:     make(map[string]bool),
+		bbu:        make(map[string]bool),
 	}
 }
 
 type Config struct {
 	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
 	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	RAIDCharts  bool             `yaml:"raid_charts,omitempty" json:"raid_charts"`
 }
 
 type Collector struct {
 	module.Base
 	Config `yaml:",inline" json:""`
 
-	charts *module.Charts
+	charts     *module.Charts
+	raidCharts *hwraid.Charts
 
 	exec megaCli
 
+	// patrol read info is not available on all controllers and firmware versions,
+	// failed queries are retried with a backoff
+	patrolReadRetryTime  time.Time
+	patrolReadRetryDelay time.Duration
+
 	adapters map[string]bool
 	drives   map[string]bool
 	bbu      map[string]bool
@@ -23,6 +23,7 @@ var (
 	dataBBUInfoOld, _     = os.ReadFile("testdata/mega-bbu-info-old.txt")
 	dataBBUInfoRecent, _  = os.ReadFile("testdata/mega-bbu-info-recent.txt")
 	dataPhysDrivesInfo, _ = os.ReadFile("testdata/mega-phys-drives-info.txt")
+	dataPatrolReadInfo, _ = os.ReadFile("testdata/mega-patrolread-info.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -33,6 +34,7 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataBBUInfoOld":     dataBBUInfoOld,
 		"dataBBUInfoRecent":  dataBBUInfoRecent,
 		"dataPhysDrivesInfo": dataPhysDrivesInfo,
+		"dataPatrolReadInfo": dataPatrolReadInfo,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -150,13 +152,16 @@ func TestCollector_Check(t *testing.T) {
 
 func TestCollector_Collect(t *testing.T) {
 	tests := map[string]struct {
-		prepareMock func() *mockMegaCliExec
-		wantMetrics map[string]int64
-		wantCharts  int
+		prepareMock    func() *mockMegaCliExec
+		wantMetrics    map[string]int64
+		wantCharts     int
+		wantRAIDCharts int
 	}{
 		"success case": {
 			prepareMock: prepareMockOK,
 			wantCharts:  len(adapterChartsTmpl)*1 + len(physDriveChartsTmpl)*8 + len(bbuChartsTmpl)*1,
+			// controller status, patrol read, bbu status/temperature/charge + 1 ld + 8 pds * 5
+			wantRAIDCharts: 5 + 1 + 8*5,
 			wantMetrics: map[string]int64{
 				"adapter_0_health_state_degraded":                      0,
 				"adapter_0_health_state_failed":                        0,
@@ -183,11 +188,138 @@ func TestCollector_Collect(t *testing.T) {
 				"phys_drive_5002538c4002e712_predictive_failure_count": 0,
 				"phys_drive_5002538c4002e713_media_error_count":        0,
 				"phys_drive_5002538c4002e713_predictive_failure_count": 0,
+
+				"raid_cntrl_0_bbu_charge":                                 71,
+				"raid_cntrl_0_bbu_status_degraded":                        0,
+				"raid_cntrl_0_bbu_status_failed":                          0,
+				"raid_cntrl_0_bbu_status_ok":                              1,
+				"raid_cntrl_0_bbu_status_unknown":                         0,
+				"raid_cntrl_0_bbu_temperature":                            33,
+				"raid_cntrl_0_ld_0_state_degraded":                        0,
+				"raid_cntrl_0_ld_0_state_failed":                          0,
+				"raid_cntrl_0_ld_0_state_offline":                         0,
+				"raid_cntrl_0_ld_0_state_optimal":                         1,
+				"raid_cntrl_0_ld_0_state_rebuilding":                      0,
+				"raid_cntrl_0_ld_0_state_unknown":                         0,
+				"raid_cntrl_0_patrol_read_state_active":                   0,
+				"raid_cntrl_0_patrol_read_state_disabled":                 0,
+				"raid_cntrl_0_patrol_read_state_paused":                   0,
+				"raid_cntrl_0_patrol_read_state_stopped":                  1,
+				"raid_cntrl_0_pd_32:0_media_errors":                       0,
+				"raid_cntrl_0_pd_32:0_other_errors":                       0,
+				"raid_cntrl_0_pd_32:0_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:0_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:0_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:0_state_failed":                       0,
+				"raid_cntrl_0_pd_32:0_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:0_state_offline":                      0,
+				"raid_cntrl_0_pd_32:0_state_online":                       1,
+				"raid_cntrl_0_pd_32:0_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:0_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:0_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:0_temperature":                        33,
+				"raid_cntrl_0_pd_32:1_media_errors":                       0,
+				"raid_cntrl_0_pd_32:1_other_errors":                       0,
+				"raid_cntrl_0_pd_32:1_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:1_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:1_state_failed":                       0,
+				"raid_cntrl_0_pd_32:1_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:1_state_offline":                      0,
+				"raid_cntrl_0_pd_32:1_state_online":                       1,
+				"raid_cntrl_0_pd_32:1_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:1_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:1_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:1_temperature":                        34,
+				"raid_cntrl_0_pd_32:2_media_errors":                       0,
+				"raid_cntrl_0_pd_32:2_other_errors":                       0,
+				"raid_cntrl_0_pd_32:2_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:2_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:2_state_failed":                       0,
+				"raid_cntrl_0_pd_32:2_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:2_state_offline":                      0,
+				"raid_cntrl_0_pd_32:2_state_online":                       1,
+				"raid_cntrl_0_pd_32:2_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:2_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:2_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:2_temperature":                        33,
+				"raid_cntrl_0_pd_32:3_media_errors":                       0,
+				"raid_cntrl_0_pd_32:3_other_errors":                       0,
+				"raid_cntrl_0_pd_32:3_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:3_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:3_state_failed":                       0,
+				"raid_cntrl_0_pd_32:3_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:3_state_offline":                      0,
+				"raid_cntrl_0_pd_32:3_state_online":                       1,
+				"raid_cntrl_0_pd_32:3_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:3_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:3_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:3_temperature":                        34,
+				"raid_cntrl_0_pd_32:4_media_errors":                       0,
+				"raid_cntrl_0_pd_32:4_other_errors":                       0,
+				"raid_cntrl_0_pd_32:4_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:4_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:4_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:4_state_failed":                       0,
+				"raid_cntrl_0_pd_32:4_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:4_state_offline":                      0,
+				"raid_cntrl_0_pd_32:4_state_online":                       1,
+				"raid_cntrl_0_pd_32:4_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:4_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:4_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:4_temperature":                        33,
+				"raid_cntrl_0_pd_32:5_media_errors":                       0,
+				"raid_cntrl_0_pd_32:5_other_errors":                       0,
+				"raid_cntrl_0_pd_32:5_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:5_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:5_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:5_state_failed":                       0,
+				"raid_cntrl_0_pd_32:5_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:5_state_offline":                      0,
+				"raid_cntrl_0_pd_32:5_state_online":                       1,
+				"raid_cntrl_0_pd_32:5_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:5_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:5_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:5_temperature":                        34,
+				"raid_cntrl_0_pd_32:6_media_errors":                       0,
+				"raid_cntrl_0_pd_32:6_other_errors":                       0,
+				"raid_cntrl_0_pd_32:6_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:6_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:6_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:6_state_failed":                       0,
+				"raid_cntrl_0_pd_32:6_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:6_state_offline":                      0,
+				"raid_cntrl_0_pd_32:6_state_online":                       1,
+				"raid_cntrl_0_pd_32:6_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:6_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:6_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:6_temperature":                        33,
+				"raid_cntrl_0_pd_32:7_media_errors":                       0,
+				"raid_cntrl_0_pd_32:7_other_errors":                       0,
+				"raid_cntrl_0_pd_32:7_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:7_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:7_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:7_state_failed":                       0,
+				"raid_cntrl_0_pd_32:7_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:7_state_offline":                      0,
+				"raid_cntrl_0_pd_32:7_state_online":                       1,
+				"raid_cntrl_0_pd_32:7_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:7_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:7_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:7_temperature":                        34,
+				"raid_cntrl_0_status_degraded":                            0,
+				"raid_cntrl_0_status_failed":                              0,
+				"raid_cntrl_0_status_ok":                                  1,
+				"raid_cntrl_0_status_unknown":                             0,
 			},
 		},
 		"success case old bbu": {
 			prepareMock: prepareMockOldBbuOK,
 			wantCharts:  len(adapterChartsTmpl)*1 + len(physDriveChartsTmpl)*8 + len(bbuChartsTmpl)*1,
+			// controller status, patrol read, bbu status/temperature/charge + 1 ld + 8 pds * 5
+			wantRAIDCharts: 5 + 1 + 8*5,
 			wantMetrics: map[string]int64{
 				"adapter_0_health_state_degraded":                      0,
 				"adapter_0_health_state_failed":                        0,
@@ -214,6 +346,131 @@ func TestCollector_Collect(t *testing.T) {
 				"phys_drive_5002538c4002e712_predictive_failure_count": 0,
 				"phys_drive_5002538c4002e713_media_error_count":        0,
 				"phys_drive_5002538c4002e713_predictive_failure_count": 0,
+
+				"raid_cntrl_0_bbu_charge":                                 100,
+				"raid_cntrl_0_bbu_status_degraded":                        0,
+				"raid_cntrl_0_bbu_status_failed":                          0,
+				"raid_cntrl_0_bbu_status_ok":                              1,
+				"raid_cntrl_0_bbu_status_unknown":                         0,
+				"raid_cntrl_0_bbu_temperature":                            31,
+				"raid_cntrl_0_ld_0_state_degraded":                        0,
+				"raid_cntrl_0_ld_0_state_failed":                          0,
+				"raid_cntrl_0_ld_0_state_offline":                         0,
+				"raid_cntrl_0_ld_0_state_optimal":                         1,
+				"raid_cntrl_0_ld_0_state_rebuilding":                      0,
+				"raid_cntrl_0_ld_0_state_unknown":                         0,
+				"raid_cntrl_0_patrol_read_state_active":                   0,
+				"raid_cntrl_0_patrol_read_state_disabled":                 0,
+				"raid_cntrl_0_patrol_read_state_paused":                   0,
+				"raid_cntrl_0_patrol_read_state_stopped":                  1,
+				"raid_cntrl_0_pd_32:0_media_errors":                       0,
+				"raid_cntrl_0_pd_32:0_other_errors":                       0,
+				"raid_cntrl_0_pd_32:0_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:0_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:0_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:0_state_failed":                       0,
+				"raid_cntrl_0_pd_32:0_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:0_state_offline":                      0,
+				"raid_cntrl_0_pd_32:0_state_online":                       1,
+				"raid_cntrl_0_pd_32:0_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:0_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:0_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:0_temperature":                        33,
+				"raid_cntrl_0_pd_32:1_media_errors":                       0,
+				"raid_cntrl_0_pd_32:1_other_errors":                       0,
+				"raid_cntrl_0_pd_32:1_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:1_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:1_state_failed":                       0,
+				"raid_cntrl_0_pd_32:1_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:1_state_offline":                      0,
+				"raid_cntrl_0_pd_32:1_state_online":                       1,
+				"raid_cntrl_0_pd_32:1_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:1_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:1_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:1_temperature":                        34,
+				"raid_cntrl_0_pd_32:2_media_errors":                       0,
+				"raid_cntrl_0_pd_32:2_other_errors":                       0,
+				"raid_cntrl_0_pd_32:2_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:2_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:2_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:2_state_failed":                       0,
+				"raid_cntrl_0_pd_32:2_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:2_state_offline":                      0,
+				"raid_cntrl_0_pd_32:2_state_online":                       1,
+				"raid_cntrl_0_pd_32:2_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:2_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:2_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:2_temperature":                        33,
+				"raid_cntrl_0_pd_32:3_media_errors":                       0,
+				"raid_cntrl_0_pd_32:3_other_errors":                       0,
+				"raid_cntrl_0_pd_32:3_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:3_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:3_state_failed":                       0,
+				"raid_cntrl_0_pd_32:3_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:3_state_offline":                      0,
+				"raid_cntrl_0_pd_32:3_state_online":                       1,
+				"raid_cntrl_0_pd_32:3_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:3_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:3_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:3_temperature":                        34,
+				"raid_cntrl_0_pd_32:4_media_errors":                       0,
+				"raid_cntrl_0_pd_32:4_other_errors":                       0,
+				"raid_cntrl_0_pd_32:4_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:4_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:4_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:4_state_failed":                       0,
+				"raid_cntrl_0_pd_32:4_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:4_state_offline":                      0,
+				"raid_cntrl_0_pd_32:4_state_online":                       1,
+				"raid_cntrl_0_pd_32:4_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:4_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:4_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:4_temperature":                        33,
+				"raid_cntrl_0_pd_32:5_media_errors":                       0,
+				"raid_cntrl_0_pd_32:5_other_errors":                       0,
+				"raid_cntrl_0_pd_32:5_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:5_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:5_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:5_state_failed":                       0,
+				"raid_cntrl_0_pd_32:5_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:5_state_offline":                      0,
+				"raid_cntrl_0_pd_32:5_state_online":                       1,
+				"raid_cntrl_0_pd_32:5_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:5_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:5_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:5_temperature":                        34,
+				"raid_cntrl_0_pd_32:6_media_errors":                       0,
+				"raid_cntrl_0_pd_32:6_other_errors":                       0,
+				"raid_cntrl_0_pd_32:6_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:6_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:6_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:6_state_failed":                       0,
+				"raid_cntrl_0_pd_32:6_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:6_state_offline":                      0,
+				"raid_cntrl_0_pd_32:6_state_online":                       1,
+				"raid_cntrl_0_pd_32:6_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:6_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:6_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:6_temperature":                        33,
+				"raid_cntrl_0_pd_32:7_media_errors":                       0,
+				"raid_cntrl_0_pd_32:7_other_errors":                       0,
+				"raid_cntrl_0_pd_32:7_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_32:7_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_32:7_predictive_failures":                0,
+				"raid_cntrl_0_pd_32:7_state_failed":                       0,
+				"raid_cntrl_0_pd_32:7_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_32:7_state_offline":                      0,
+				"raid_cntrl_0_pd_32:7_state_online":                       1,
+				"raid_cntrl_0_pd_32:7_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_32:7_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_32:7_state_unknown":                      0,
+				"raid_cntrl_0_pd_32:7_temperature":                        34,
+				"raid_cntrl_0_status_degraded":                            0,
+				"raid_cntrl_0_status_failed":                              0,
+				"raid_cntrl_0_status_ok":                                  1,
+				"raid_cntrl_0_status_unknown":                             0,
 			},
 		},
 		"err on exec": {
@@ -243,6 +500,17 @@ func TestCollector_Collect(t *testing.T) {
 			if len(test.wantMetrics) > 0 {
 				module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 			}
+
+			collr = New()
+			collr.RAIDCharts = true
+			collr.exec = test.prepareMock()
+
+			mx = collr.Collect(context.Background())
+
+			assert.Len(t, *collr.Charts(), test.wantRAIDCharts)
+			if len(test.wantMetrics) > 0 {
+				module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+			}
 		})
 	}
 }
@@ -251,6 +519,7 @@ func prepareMockOK() *mockMegaCliExec {
 	return &mockMegaCliExec{
 		physDrivesInfoData: dataPhysDrivesInfo,
 		bbuInfoData:        dataBBUInfoRecent,
+		patrolReadInfoData: dataPatrolReadInfo,
 	}
 }
 
@@ -258,6 +527,7 @@ func prepareMockOldBbuOK() *mockMegaCliExec {
 	return &mockMegaCliExec{
 		physDrivesInfoData: dataPhysDrivesInfo,
 		bbuInfoData:        dataBBUInfoOld,
+		patrolReadInfoData: dataPatrolReadInfo,
 	}
 }
 
@@ -287,6 +557,7 @@ type mockMegaCliExec struct {
 	errOnInfo          bool
 	physDrivesInfoData []byte
 	bbuInfoData        []byte
+	patrolReadInfoData []byte
 }
 
 func (m *mockMegaCliExec) physDrivesInfo() ([]byte, error) {
@@ -302,3 +573,10 @@ func (m *mockMegaCliExec) bbuInfo() ([]byte, error) {
 	}
 	return m.bbuInfoData, nil
 }
+
+func (m *mockMegaCliExec) patrolReadInfo() ([]byte, error) {
+	if m.errOnInfo {
+		return nil, errors.New("mock.patrolReadInfo() error")
+	}
+	return m.patrolReadInfoData, nil
+}
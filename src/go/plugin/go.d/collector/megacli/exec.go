@@ -16,6 +16,7 @@ import (
 type megaCli interface {
 	physDrivesInfo() ([]byte, error)
 	bbuInfo() ([]byte, error)
+	patrolReadInfo() ([]byte, error)
 }
 
 func newMegaCliExec(ndsudoPath string, timeout time.Duration, log *logger.Logger) *megaCliExec {
@@ -41,6 +42,10 @@ func (e *megaCliExec) bbuInfo() ([]byte, error) {
 	return e.execute("megacli-battery-info")
 }
 
+func (e *megaCliExec) patrolReadInfo() ([]byte, error) {
+	return e.execute("megacli-patrolread-info")
+}
+
 func (e *megaCliExec) execute(args ...string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
 	defer cancel()
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package megacli
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
+)
+
+const (
+	patrolReadRetryMinDelay = time.Minute
+	patrolReadRetryMaxDelay = time.Hour
+)
+
+type megaPatrolRead struct {
+	adapterNumber string
+	mode          string
+	state         string
+}
+
+func (c *Collector) raidControllers(adapters map[string]*megaAdapter, bbus map[string]*megaBBU) []*hwraid.Controller {
+	patrolRead := c.queryPatrolReadStates()
+
+	var controllers []*hwraid.Controller
+
+	for _, ad := range adapters {
+		rc := &hwraid.Controller{
+			ID:         ad.number,
+			Status:     adapterRAIDStatus(ad.state),
+			PatrolRead: patrolRead[ad.number],
+		}
+
+		if bbu, ok := bbus[ad.number]; ok {
+			rc.Cache = &hwraid.Cache{BBUStatus: bbuRAIDStatus(bbu)}
+			if v, err := strconv.ParseInt(bbu.temperature, 10, 64); err == nil {
+				rc.Cache.BBUTemperature = &v
+			}
+			if v, err := strconv.ParseInt(bbu.rsoc, 10, 64); err == nil {
+				rc.Cache.BBUChargePercentage = &v
+			}
+		}
+
+		for _, ld := range ad.logicalDrives {
+			rc.LogicalDrives = append(rc.LogicalDrives, &hwraid.LogicalDrive{
+				ID:        ld.number,
+				Name:      ld.name,
+				RAIDLevel: ldRAIDLevel(ld.raidLevel),
+				State:     ldRAIDState(ld.state),
+			})
+		}
+
+		for _, pd := range ad.physDrives {
+			rc.PhysicalDrives = append(rc.PhysicalDrives, newRAIDPhysicalDrive(pd))
+		}
+
+		controllers = append(controllers, rc)
+	}
+
+	return controllers
+}
+
+func newRAIDPhysicalDrive(pd *megaPhysDrive) *hwraid.PhysicalDrive {
+	id := pd.wwn
+	if pd.enclosureID != "" && pd.slotNumber != "" {
+		id = pd.enclosureID + ":" + pd.slotNumber
+	}
+
+	rpd := &hwraid.PhysicalDrive{
+		ID:                id,
+		Enclosure:         pd.enclosureID,
+		Slot:              pd.slotNumber,
+		LogicalDrive:      pd.ldNumber,
+		Model:             pdModel(pd.inquiryData),
+		MediaType:         pdMediaType(pd.mediaType),
+		State:             pdRAIDState(pd.firmwareState),
+		PredictiveFailure: strings.EqualFold(pd.smartAlert, "yes"),
+	}
+
+	if v, err := strconv.ParseInt(pd.mediaErrorCount, 10, 64); err == nil {
+		rpd.MediaErrors = &v
+	}
+	if v, err := strconv.ParseInt(pd.otherErrorCount, 10, 64); err == nil {
+		rpd.OtherErrors = &v
+	}
+	if v, err := strconv.ParseInt(pd.predictiveFailureCount, 10, 64); err == nil {
+		rpd.PredictiveFailures = &v
+		rpd.PredictiveFailure = rpd.PredictiveFailure || v > 0
+	}
+	if v, err := strconv.ParseInt(pd.temperature, 10, 64); err == nil {
+		rpd.Temperature = &v
+	}
+
+	return rpd
+}
+
+// queryPatrolReadStates returns the patrol read states keyed by adapter number.
+func (c *Collector) queryPatrolReadStates() map[string]string {
+	if time.Now().Before(c.patrolReadRetryTime) {
+		return nil
+	}
+
+	bs, err := c.exec.patrolReadInfo()
+	if err != nil {
+		c.backoffPatrolRead(err)
+		return nil
+	}
+	c.patrolReadRetryDelay = 0
+
+	states := make(map[string]string)
+
+	for _, pr := range parsePatrolReadInfo(bs) {
+		if st := patrolReadRAIDState(pr.mode, pr.state); st != "" {
+			states[pr.adapterNumber] = st
+		}
+	}
+
+	return states
+}
+
+func (c *Collector) backoffPatrolRead(err error) {
+	c.patrolReadRetryDelay = min(max(c.patrolReadRetryDelay*2, patrolReadRetryMinDelay), patrolReadRetryMaxDelay)
+	c.patrolReadRetryTime = time.Now().Add(c.patrolReadRetryDelay)
+	c.Warningf("patrol read info is not available, retrying in %s: %v", c.patrolReadRetryDelay, err)
+}
+
+func parsePatrolReadInfo(bs []byte) []*megaPatrolRead {
+	var prs []*megaPatrolRead
+	var pr *megaPatrolRead
+
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+
+		switch {
+		case strings.HasPrefix(line, "Adapter") && strings.Contains(line, "Patrol Read Information"):
+			// Adapter 0: Patrol Read Information:
+			num, _, _ := strings.Cut(strings.TrimPrefix(line, "Adapter"), ":")
+			pr = &megaPatrolRead{adapterNumber: strings.TrimSpace(num)}
+			prs = append(prs, pr)
+		case strings.HasPrefix(line, "Patrol Read Mode:") && pr != nil:
+			pr.mode = getColonSepValue(line)
+		case strings.HasPrefix(line, "Current State:") && pr != nil:
+			pr.state = getColonSepValue(line)
+		}
+	}
+
+	return prs
+}
+
+func adapterRAIDStatus(state string) string {
+	switch strings.ToLower(state) {
+	case "optimal":
+		return hwraid.StatusOK
+	case "degraded", "partially degraded":
+		return hwraid.StatusDegraded
+	case "failed", "offline":
+		return hwraid.StatusFailed
+	default:
+		return hwraid.StatusUnknown
+	}
+}
+
+func bbuRAIDStatus(bbu *megaBBU) string {
+	if strings.EqualFold(bbu.replacement, "yes") || strings.EqualFold(bbu.packFailing, "yes") {
+		return hwraid.StatusFailed
+	}
+	// 'Optimal', 'Degraded(Charging)', 'Learning', 'Failed'
+	switch s := strings.ToLower(bbu.batteryState); {
+	case s == "optimal", s == "operational":
+		return hwraid.StatusOK
+	case strings.HasPrefix(s, "degraded"), s == "learning", s == "charging":
+		return hwraid.StatusDegraded
+	case strings.HasPrefix(s, "failed"):
+		return hwraid.StatusFailed
+	default:
+		return hwraid.StatusUnknown
+	}
+}
+
+func ldRAIDLevel(level string) string {
+	// 'Primary-1, Secondary-0, RAID Level Qualifier-0'
+	primary, _, _ := strings.Cut(level, ",")
+	if v, ok := strings.CutPrefix(strings.TrimSpace(primary), "Primary-"); ok {
+		return "RAID" + v
+	}
+	return level
+}
+
+func ldRAIDState(state string) string {
+	switch strings.ToLower(state) {
+	case "optimal":
+		return hwraid.LDStateOptimal
+	case "degraded", "partially degraded":
+		return hwraid.LDStateDegraded
+	case "failed":
+		return hwraid.LDStateFailed
+	case "offline":
+		return hwraid.LDStateOffline
+	default:
+		return hwraid.LDStateUnknown
+	}
+}
+
+func pdRAIDState(state string) string {
+	// 'Online, Spun Up', 'Hotspare, Spun down', 'Unconfigured(good), Spun Up', 'Rebuild'
+	s, _, _ := strings.Cut(strings.ToLower(state), ",")
+	switch strings.TrimSpace(s) {
+	case "online", "jbod", "copyback":
+		return hwraid.PDStateOnline
+	case "hotspare":
+		return hwraid.PDStateHotSpare
+	case "unconfigured(good)":
+		return hwraid.PDStateUnconfigured
+	case "rebuild":
+		return hwraid.PDStateRebuilding
+	case "failed", "unconfigured(bad)":
+		return hwraid.PDStateFailed
+	case "offline":
+		return hwraid.PDStateOffline
+	default:
+		return hwraid.PDStateUnknown
+	}
+}
+
+func pdModel(inquiryData string) string {
+	// SATA drives: '<serial> <vendor> <model> <firmware>'
+	parts := strings.Fields(inquiryData)
+	if len(parts) < 3 {
+		return strings.Join(parts, " ")
+	}
+	return strings.Join(parts[1:len(parts)-1], " ")
+}
+
+func pdMediaType(typ string) string {
+	switch typ {
+	case "Solid State Device":
+		return "SSD"
+	case "Hard Disk Device":
+		return "HDD"
+	default:
+		return typ
+	}
+}
+
+func patrolReadRAIDState(mode, state string) string {
+	if strings.HasPrefix(strings.ToLower(mode), "disable") {
+		return hwraid.PatrolReadDisabled
+	}
+	// 'Stopped', 'Active', 'Paused'
+	s, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(state)), " ")
+	switch s {
+	case "active":
+		return hwraid.PatrolReadActive
+	case "paused":
+		return hwraid.PatrolReadPaused
+	case "stopped":
+		return hwraid.PatrolReadStopped
+	default:
+		return ""
+	}
+}
@@ -17,6 +17,11 @@ const (
 	prioActiveClientsCount
 	prioQueuedOperationsCount
 
+	prioCurrentOperationsByType
+	prioCurrentOperationsWaitingForLock
+	prioCurrentOperationsLongRunning
+	prioCurrentOperationsMaxRunningTime
+
 	prioGlobalLockAcquisitionsRate
 	prioDatabaseLockAcquisitionsRate
 	prioCollectionLockAcquisitionsRate
@@ -76,6 +81,12 @@ const (
 	prioDatabaseStorageSize
 	prioDatabaseIndexSize
 
+	prioCollectionDocumentsCount
+	prioCollectionIndexesCount
+	prioCollectionSize
+	prioCollectionIndexSize
+	prioCollectionIndexAccessesRate
+
 	prioReplSetMemberState
 	prioReplSetMemberHealthStatus
 	prioReplSetMemberReplicationLagTime
@@ -93,6 +104,7 @@ const (
 	chartPxDatabase      = "database_"
 	chartPxReplSetMember = "replica_set_member_"
 	chartPxShard         = "sharding_shard_"
+	chartPxCollection    = "collection_"
 )
 
 // these charts are expected to be available in many versions
@@ -126,6 +138,21 @@ var chartsTmplDatabase = module.Charts{
 	chartTmplDatabaseIndexSize.Copy(),
 }
 
+var chartsTmplCollection = module.Charts{
+	chartTmplCollectionDocumentsCount.Copy(),
+	chartTmplCollectionIndexesCount.Copy(),
+	chartTmplCollectionSize.Copy(),
+	chartTmplCollectionIndexSize.Copy(),
+	chartTmplCollectionIndexAccessesRate.Copy(),
+}
+
+var chartsCurrentOp = module.Charts{
+	chartCurrentOperationsByType.Copy(),
+	chartCurrentOperationsWaitingForLock.Copy(),
+	chartCurrentOperationsLongRunning.Copy(),
+	chartCurrentOperationsMaxRunningTime.Copy(),
+}
+
 var chartsTmplReplSetMember = module.Charts{
 	chartTmplReplSetMemberState.Copy(),
 	chartTmplReplSetMemberHealthStatus.Copy(),
@@ -901,6 +928,117 @@ var (
 	}
 )
 
+var (
+	chartCurrentOperationsByType = &module.Chart{
+		ID:       "current_operations_by_type",
+		Title:    "Active operations by type",
+		Units:    "operations",
+		Fam:      "current operations",
+		Ctx:      "mongodb.current_operations_by_type",
+		Type:     module.Stacked,
+		Priority: prioCurrentOperationsByType,
+		Dims: module.Dims{
+			{ID: "current_op_active_query", Name: "query"},
+			{ID: "current_op_active_insert", Name: "insert"},
+			{ID: "current_op_active_update", Name: "update"},
+			{ID: "current_op_active_remove", Name: "remove"},
+			{ID: "current_op_active_getmore", Name: "getmore"},
+			{ID: "current_op_active_command", Name: "command"},
+			{ID: "current_op_active_killcursors", Name: "killcursors"},
+		},
+	}
+	chartCurrentOperationsWaitingForLock = &module.Chart{
+		ID:       "current_operations_waiting_for_lock",
+		Title:    "Active operations waiting for a lock",
+		Units:    "operations",
+		Fam:      "current operations",
+		Ctx:      "mongodb.current_operations_waiting_for_lock",
+		Priority: prioCurrentOperationsWaitingForLock,
+		Dims: module.Dims{
+			{ID: "current_op_waiting_for_lock", Name: "waiting"},
+		},
+	}
+	chartCurrentOperationsLongRunning = &module.Chart{
+		ID:       "current_operations_long_running",
+		Title:    "Long-running operations",
+		Units:    "operations",
+		Fam:      "current operations",
+		Ctx:      "mongodb.current_operations_long_running",
+		Priority: prioCurrentOperationsLongRunning,
+		Dims: module.Dims{
+			{ID: "current_op_long_running", Name: "long_running"},
+		},
+	}
+	chartCurrentOperationsMaxRunningTime = &module.Chart{
+		ID:       "current_operations_max_running_time",
+		Title:    "Longest running active operation",
+		Units:    "seconds",
+		Fam:      "current operations",
+		Ctx:      "mongodb.current_operations_max_running_time",
+		Priority: prioCurrentOperationsMaxRunningTime,
+		Dims: module.Dims{
+			{ID: "current_op_max_running_time", Name: "max", Div: 1e6},
+		},
+	}
+)
+
+var (
+	chartTmplCollectionDocumentsCount = &module.Chart{
+		ID:       chartPxCollection + "%s_documents_count",
+		Title:    "Collection documents",
+		Units:    "documents",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_documents_count",
+		Priority: prioCollectionDocumentsCount,
+		Dims: module.Dims{
+			{ID: "collection_%s.%s$documents", Name: "documents"},
+		},
+	}
+	chartTmplCollectionIndexesCount = &module.Chart{
+		ID:       chartPxCollection + "%s_indexes_count",
+		Title:    "Collection indexes",
+		Units:    "indexes",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_indexes_count",
+		Priority: prioCollectionIndexesCount,
+		Dims: module.Dims{
+			{ID: "collection_%s.%s$indexes", Name: "indexes"},
+		},
+	}
+	chartTmplCollectionSize = &module.Chart{
+		ID:       chartPxCollection + "%s_size",
+		Title:    "Collection size",
+		Units:    "bytes",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_size",
+		Priority: prioCollectionSize,
+		Dims: module.Dims{
+			{ID: "collection_%s.%s$size", Name: "data_size"},
+			{ID: "collection_%s.%s$storage_size", Name: "storage_size"},
+		},
+	}
+	chartTmplCollectionIndexSize = &module.Chart{
+		ID:       chartPxCollection + "%s_index_size",
+		Title:    "Collection index size",
+		Units:    "bytes",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_index_size",
+		Priority: prioCollectionIndexSize,
+		Dims: module.Dims{
+			{ID: "collection_%s.%s$index_size", Name: "index_size"},
+		},
+	}
+	chartTmplCollectionIndexAccessesRate = &module.Chart{
+		ID:       chartPxCollection + "%s_index_accesses_rate",
+		Title:    "Collection index accesses",
+		Units:    "accesses/s",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_index_accesses_rate",
+		Type:     module.Stacked,
+		Priority: prioCollectionIndexAccessesRate,
+	}
+)
+
 var (
 	chartTmplReplSetMemberState = &module.Chart{
 		ID:       chartPxReplSetMember + "%s_state",
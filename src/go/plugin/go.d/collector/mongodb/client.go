@@ -14,6 +14,8 @@ import (
 
 const (
 	mongos = "mongos"
+
+	currentOpComment = "netdata: current operations"
 )
 
 type mongoConn interface {
@@ -27,6 +29,11 @@ type mongoConn interface {
 	shardDatabasesPartitioning() (*documentPartitionedResult, error)
 	shardCollectionsPartitioning() (*documentPartitionedResult, error)
 	shardChunks() (map[string]int64, error)
+	hello() (*documentHello, error)
+	currentOps() ([]documentCurrentOp, error)
+	listCollectionNames(db string) ([]string, error)
+	collStats(db, collection string) (*documentCollStats, error)
+	indexStats(db, collection string) ([]documentIndexStats, error)
 	initClient(uri string, timeout time.Duration) error
 	close() error
 }
@@ -235,6 +242,133 @@ func (c *mongoClient) shardChunks() (map[string]int64, error) {
 	return result, err
 }
 
+func (c *mongoClient) hello() (*documentHello, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var doc documentHello
+	if err := c.client.Database("admin").RunCommand(ctx, bson.M{"hello": 1}).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+func (c *mongoClient) currentOps() ([]documentCurrentOp, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.D{{Key: "allUsers", Value: true}}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "active", Value: true},
+			// the aggregation reports itself as an active command, it is tagged with a comment to skip it
+			{Key: "command.comment", Value: bson.D{{Key: "$ne", Value: currentOpComment}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "op", Value: 1},
+			{Key: "microsecs_running", Value: 1},
+			{Key: "waitingForLock", Value: 1},
+		}}},
+	}
+
+	opts := options.Aggregate().SetComment(currentOpComment)
+
+	cursor, err := c.client.Database("admin").Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var ops []documentCurrentOp
+	if err := cursor.All(ctx, &ops); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+func (c *mongoClient) listCollectionNames(db string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	// views and time series collections do not support $collStats storageStats
+	return c.client.Database(db).ListCollectionNames(ctx, bson.M{"type": "collection"})
+}
+
+func (c *mongoClient) collStats(db, collection string) (*documentCollStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$collStats", Value: bson.D{{Key: "storageStats", Value: bson.D{}}}}},
+	}
+
+	cursor, err := c.client.Database(db).Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = cursor.Close(ctx) }()
+
+	// on mongos there is a document per shard
+	var docs []documentCollStats
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	var stats documentCollStats
+	for _, doc := range docs {
+		stats.StorageStats.Size += doc.StorageStats.Size
+		stats.StorageStats.Count += doc.StorageStats.Count
+		stats.StorageStats.StorageSize += doc.StorageStats.StorageSize
+		stats.StorageStats.TotalIndexSize += doc.StorageStats.TotalIndexSize
+		stats.StorageStats.NIndexes = max(stats.StorageStats.NIndexes, doc.StorageStats.NIndexes)
+	}
+
+	return &stats, nil
+}
+
+func (c *mongoClient) indexStats(db, collection string) ([]documentIndexStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$indexStats", Value: bson.D{}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "name", Value: 1},
+			{Key: "accesses.ops", Value: 1},
+		}}},
+	}
+
+	cursor, err := c.client.Database(db).Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = cursor.Close(ctx) }()
+
+	// on mongos there is a document per index per shard
+	var docs []documentIndexStats
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	var stats []documentIndexStats
+	idx := make(map[string]int)
+	for _, doc := range docs {
+		if i, ok := idx[doc.Name]; ok {
+			stats[i].Accesses.Ops += doc.Accesses.Ops
+			continue
+		}
+		idx[doc.Name] = len(stats)
+		stats = append(stats, doc)
+	}
+
+	return stats, nil
+}
+
 func (c *mongoClient) initClient(uri string, timeout time.Duration) error {
 	if c.client != nil {
 		return nil
@@ -15,10 +15,22 @@ func (c *Collector) collect() (map[string]int64, error) {
 		return nil, fmt.Errorf("couldn't collect server status metrics: %v", err)
 	}
 
+	if c.CreateVnode && c.vnode == nil {
+		if err := c.setupVnode(); err != nil {
+			c.Warningf("couldn't create virtual node: %v", err)
+		}
+	}
+
+	c.collectCurrentOp(mx)
+
 	if err := c.collectDbStats(mx); err != nil {
 		return mx, fmt.Errorf("couldn't collect dbstats metrics: %v", err)
 	}
 
+	if err := c.collectCollStats(mx); err != nil {
+		return mx, fmt.Errorf("couldn't collect collection stats metrics: %v", err)
+	}
+
 	if c.conn.isReplicaSet() {
 		if err := c.collectReplSetStatus(mx); err != nil {
 			return mx, fmt.Errorf("couldn't collect documentReplSetStatus metrics: %v", err)
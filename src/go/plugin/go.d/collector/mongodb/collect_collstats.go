@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+type collectionCacheItem struct {
+	db        string
+	name      string
+	seen      bool
+	hasCharts bool
+	charts    []string
+	indexes   map[string]bool
+}
+
+// collectionMetricPx returns the collection metrics key prefix. Database names can't contain '.',
+// and neither database nor collection names can contain '$', so the prefix is unambiguous.
+func collectionMetricPx(db, name string) string {
+	return "collection_" + db + "." + name + "$"
+}
+
+func (c *Collector) collectCollStats(mx map[string]int64) error {
+	if c.collectionSelector == nil {
+		c.Debug("'collections' selector not set, skip collecting collection statistics")
+		return nil
+	}
+
+	dbs, err := c.conn.listDatabaseNames()
+	if err != nil {
+		return fmt.Errorf("cannot get database names: %v", err)
+	}
+
+	for _, coll := range c.collections {
+		coll.seen = false
+	}
+
+	for _, db := range dbs {
+		if c.dbSelector != nil && !c.dbSelector.MatchString(db) {
+			continue
+		}
+
+		names, err := c.conn.listCollectionNames(db)
+		if err != nil {
+			return fmt.Errorf("cannot get collection names of database '%s': %v", db, err)
+		}
+
+		for _, name := range names {
+			// the selector matches against the collection namespace: "<database>.<collection>"
+			ns := db + "." + name
+			if !c.collectionSelector.MatchString(ns) {
+				continue
+			}
+
+			stats, err := c.conn.collStats(db, name)
+			if err != nil {
+				c.Warningf("$collStats aggregation failed for '%s': %v", ns, err)
+				continue
+			}
+
+			coll, ok := c.collections[ns]
+			if !ok {
+				coll = &collectionCacheItem{db: db, name: name, indexes: make(map[string]bool)}
+				c.collections[ns] = coll
+			}
+			coll.seen = true
+			if !coll.hasCharts {
+				coll.hasCharts = true
+				c.Debugf("new collection '%s': creating charts", ns)
+				c.addCollectionCharts(coll)
+			}
+
+			px := collectionMetricPx(db, name)
+			mx[px+"documents"] = stats.StorageStats.Count
+			mx[px+"size"] = stats.StorageStats.Size
+			mx[px+"storage_size"] = stats.StorageStats.StorageSize
+			mx[px+"index_size"] = stats.StorageStats.TotalIndexSize
+			mx[px+"indexes"] = stats.StorageStats.NIndexes
+
+			indexes, err := c.conn.indexStats(db, name)
+			if err != nil {
+				c.Warningf("$indexStats aggregation failed for '%s': %v", ns, err)
+				continue
+			}
+
+			for _, idx := range indexes {
+				mx[px+"index_"+idx.Name+"_accesses"] = idx.Accesses.Ops
+				if !coll.indexes[idx.Name] {
+					coll.indexes[idx.Name] = true
+					c.addCollectionIndexDim(coll, idx.Name)
+				}
+			}
+		}
+	}
+
+	for ns, coll := range c.collections {
+		if !coll.seen {
+			delete(c.collections, ns)
+			c.Debugf("stale collection '%s': removing charts", ns)
+			c.removeCollectionCharts(coll)
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) addCollectionCharts(coll *collectionCacheItem) {
+	charts := chartsTmplCollection.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, collectionChartName(coll.db, coll.name))
+		coll.charts = append(coll.charts, chart.ID)
+		chart.Labels = []module.Label{
+			{Key: "database", Value: coll.db},
+			{Key: "collection", Value: coll.name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, coll.db, coll.name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) addCollectionIndexDim(coll *collectionCacheItem, index string) {
+	id := fmt.Sprintf(chartTmplCollectionIndexAccessesRate.ID, collectionChartName(coll.db, coll.name))
+
+	chart := c.Charts().Get(id)
+	if chart == nil {
+		return
+	}
+
+	dim := &module.Dim{
+		ID:   collectionMetricPx(coll.db, coll.name) + "index_" + index + "_accesses",
+		Name: index,
+		Algo: module.Incremental,
+	}
+	if err := chart.AddDim(dim); err != nil {
+		c.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (c *Collector) removeCollectionCharts(coll *collectionCacheItem) {
+	// chart IDs are not unique prefixes ('db.foo' and 'db.foo_bar'), remove the exact ones
+	for _, id := range coll.charts {
+		if chart := c.Charts().Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+// collectionChartName returns the collection part of chart IDs: "<database>_<collection>".
+// It is ambiguous when the names have underscores ('a_b.c' and 'a.b_c') or dots and spaces, which
+// are replaced with underscores ('fs.chunks' and 'fs_chunks'), so then a hash of the namespace is appended.
+func collectionChartName(db, name string) string {
+	if !strings.ContainsAny(db, "_. ") && !strings.ContainsAny(name, "_. ") {
+		return db + "_" + name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(db + "." + name))
+	s := strings.NewReplacer(" ", "_", ".", "_").Replace(db + "_" + name)
+	return fmt.Sprintf("%s_%08x", s, h.Sum32())
+}
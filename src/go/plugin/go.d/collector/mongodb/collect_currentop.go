@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongo
+
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/currentOp/#output-fields (op)
+var currentOpTypes = []string{
+	"query",
+	"insert",
+	"update",
+	"remove",
+	"getmore",
+	"command",
+	"killcursors",
+}
+
+func (c *Collector) collectCurrentOp(mx map[string]int64) {
+	if c.noCurrentOp {
+		return
+	}
+
+	ops, err := c.conn.currentOps()
+	if err != nil {
+		// requires the 'inprog' privilege
+		c.noCurrentOp = true
+		c.Warningf("disabling current operations collection: $currentOp aggregation failed: %v", err)
+		return
+	}
+
+	c.addCurrentOpChartsOnce.Do(c.addCurrentOpCharts)
+
+	for _, typ := range currentOpTypes {
+		mx["current_op_active_"+typ] = 0
+	}
+	mx["current_op_waiting_for_lock"] = 0
+	mx["current_op_long_running"] = 0
+	mx["current_op_max_running_time"] = 0
+
+	threshold := c.LongRunningOpThreshold.Duration().Microseconds()
+
+	for _, op := range ops {
+		if op.Op == "" || op.Op == "none" {
+			continue
+		}
+		mx["current_op_active_"+op.Op]++
+		if op.WaitingForLock {
+			mx["current_op_waiting_for_lock"]++
+		}
+		if threshold > 0 && op.MicrosecsRunning >= threshold {
+			mx["current_op_long_running"]++
+		}
+		mx["current_op_max_running_time"] = max(mx["current_op_max_running_time"], op.MicrosecsRunning)
+	}
+}
+
+func (c *Collector) addCurrentOpCharts() {
+	if err := c.Charts().Add(*chartsCurrentOp.Copy()...); err != nil {
+		c.Warning(err)
+	}
+}
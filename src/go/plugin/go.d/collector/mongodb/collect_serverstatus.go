@@ -20,6 +20,8 @@ func (c *Collector) collectServerStatus(mx map[string]int64) error {
 		return fmt.Errorf("serverStatus command failed: %s", err)
 	}
 
+	c.process, c.version = s.Process, s.Version
+
 	c.addOptionalCharts(s)
 
 	for k, v := range stm.ToMap(s) {
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/vnodes"
+
+	"github.com/google/uuid"
+)
+
+// setupVnode creates a virtual node for the monitored instance (a replica set member, a shard member or a mongos).
+// It is used when jobs are created per topology member (e.g. by the MongoDB service discovery).
+func (c *Collector) setupVnode() error {
+	h, err := c.conn.hello()
+	if err != nil {
+		return fmt.Errorf("hello command failed: %v", err)
+	}
+
+	host := h.Me
+	if host == "" {
+		u, err := url.Parse(c.URI)
+		if err != nil {
+			return fmt.Errorf("parse URI: %v", err)
+		}
+		host = u.Host
+	}
+	if host == "" {
+		return errors.New("can not determine instance address")
+	}
+
+	labels := map[string]string{
+		"role": helloRole(h),
+	}
+	if h.SetName != "" {
+		labels["replica_set"] = h.SetName
+	}
+	if c.process != "" {
+		labels["process"] = c.process
+	}
+	if c.version != "" {
+		labels["version"] = c.version
+	}
+
+	c.vnode = &vnodes.VirtualNode{
+		GUID:     uuid.NewSHA1(uuid.NameSpaceDNS, []byte("mongodb:"+host)).String(),
+		Hostname: host,
+		Labels:   labels,
+	}
+
+	return nil
+}
+
+func helloRole(h *documentHello) string {
+	switch {
+	case h.Msg == "isdbgrid":
+		return "mongos"
+	case h.ArbiterOnly:
+		return "arbiter"
+	case h.SetName == "":
+		return "standalone"
+	case h.IsWritablePrimary:
+		return "primary"
+	case h.Secondary:
+		return "secondary"
+	default:
+		return "other"
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/vnodes"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 )
 
@@ -35,6 +36,11 @@ func New() *Collector {
 				Includes: []string{},
 				Excludes: []string{},
 			},
+			Collections: matcher.SimpleExpr{
+				Includes: []string{},
+				Excludes: []string{},
+			},
+			LongRunningOpThreshold: confopt.Duration(time.Second),
 		},
 
 		conn: &mongoClient{},
@@ -46,31 +52,43 @@ func New() *Collector {
 		replSetMembers: make(map[string]bool),
 		databases:      make(map[string]bool),
 		shards:         make(map[string]bool),
+		collections:    make(map[string]*collectionCacheItem),
 	}
 }
 
 type Config struct {
-	Vnode       string             `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery int                `yaml:"update_every,omitempty" json:"update_every"`
-	URI         string             `yaml:"uri" json:"uri"`
-	Timeout     confopt.Duration   `yaml:"timeout,omitempty" json:"timeout"`
-	Databases   matcher.SimpleExpr `yaml:"databases,omitempty" json:"databases"`
+	Vnode                  string             `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery            int                `yaml:"update_every,omitempty" json:"update_every"`
+	URI                    string             `yaml:"uri" json:"uri"`
+	Timeout                confopt.Duration   `yaml:"timeout,omitempty" json:"timeout"`
+	Databases              matcher.SimpleExpr `yaml:"databases,omitempty" json:"databases"`
+	Collections            matcher.SimpleExpr `yaml:"collections,omitempty" json:"collections"`
+	LongRunningOpThreshold confopt.Duration   `yaml:"long_running_op_threshold,omitempty" json:"long_running_op_threshold"`
+	CreateVnode            bool               `yaml:"create_vnode,omitempty" json:"create_vnode"`
 }
 
 type Collector struct {
 	module.Base
 	Config `yaml:",inline" json:""`
 
-	charts                *module.Charts
-	addShardingChartsOnce *sync.Once
+	charts                 *module.Charts
+	addShardingChartsOnce  *sync.Once
+	addCurrentOpChartsOnce sync.Once
 
 	conn mongoConn
 
-	dbSelector     matcher.Matcher
-	optionalCharts map[string]bool
-	databases      map[string]bool
-	replSetMembers map[string]bool
-	shards         map[string]bool
+	vnode   *vnodes.VirtualNode
+	process string
+	version string
+
+	dbSelector         matcher.Matcher
+	collectionSelector matcher.Matcher
+	optionalCharts     map[string]bool
+	databases          map[string]bool
+	replSetMembers     map[string]bool
+	shards             map[string]bool
+	collections        map[string]*collectionCacheItem
+	noCurrentOp        bool
 }
 
 func (c *Collector) Configuration() any {
@@ -86,6 +104,10 @@ func (c *Collector) Init(context.Context) error {
 		return fmt.Errorf("init database selector: %v", err)
 	}
 
+	if err := c.initCollectionSelector(); err != nil {
+		return fmt.Errorf("init collection selector: %v", err)
+	}
+
 	return nil
 }
 
@@ -118,6 +140,10 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 	return mx
 }
 
+func (c *Collector) VirtualNode() *vnodes.VirtualNode {
+	return c.vnode
+}
+
 func (c *Collector) Cleanup(context.Context) {
 	if c.conn == nil {
 		return
@@ -7,11 +7,13 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -610,6 +612,213 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_CurrentOp(t *testing.T) {
+	collr := prepareMongo()
+	defer collr.Cleanup(context.Background())
+	collr.conn = &mockMongoClient{withCurrentOp: true}
+	collr.LongRunningOpThreshold = confopt.Duration(time.Second)
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	expected := map[string]int64{
+		"current_op_active_query":       2,
+		"current_op_active_insert":      0,
+		"current_op_active_update":      1,
+		"current_op_active_remove":      0,
+		"current_op_active_getmore":     0,
+		"current_op_active_command":     1,
+		"current_op_active_killcursors": 0,
+		"current_op_waiting_for_lock":   1,
+		"current_op_long_running":       2,
+		"current_op_max_running_time":   3_500_000,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+
+	for _, chart := range chartsCurrentOp {
+		assert.Truef(t, collr.Charts().Has(chart.ID), "chart '%s'", chart.ID)
+	}
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_Collect_CurrentOpNotAuthorized(t *testing.T) {
+	collr := prepareMongo()
+	defer collr.Cleanup(context.Background())
+	collr.conn = caseMongod()
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.True(t, collr.noCurrentOp)
+	assert.NotContains(t, mx, "current_op_long_running")
+	assert.False(t, collr.Charts().Has(chartCurrentOperationsByType.ID))
+}
+
+func TestCollector_Collect_CollStats(t *testing.T) {
+	collr := prepareMongo()
+	defer collr.Cleanup(context.Background())
+	collr.conn = caseMongod()
+	collr.Databases = matcher.SimpleExpr{Includes: []string{"* *"}, Excludes: []string{"= local"}}
+	collr.Collections = matcher.SimpleExpr{Includes: []string{"* *"}, Excludes: []string{"* *.system.*"}}
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	for _, db := range []string{"admin", "config"} {
+		px := "collection_" + db + ".users$"
+		assert.Equal(t, int64(100), mx[px+"documents"])
+		assert.Equal(t, int64(4096), mx[px+"size"])
+		assert.Equal(t, int64(8192), mx[px+"storage_size"])
+		assert.Equal(t, int64(2048), mx[px+"index_size"])
+		assert.Equal(t, int64(2), mx[px+"indexes"])
+		assert.Equal(t, int64(10), mx[px+"index__id__accesses"])
+		assert.Equal(t, int64(25), mx[px+"index_name_1_accesses"])
+
+		chart := collr.Charts().Get("collection_" + db + "_users_index_accesses_rate")
+		require.NotNil(t, chart)
+		assert.Len(t, chart.Dims, 2)
+	}
+	assert.Len(t, collr.collections, 2)
+	assert.NotContains(t, mx, "collection_admin.system.version$documents")
+	assert.NotContains(t, mx, "collection_local.oplog.rs$documents")
+
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	sr, err := (&matcher.SimpleExpr{Includes: []string{"* admin.users"}}).Parse()
+	require.NoError(t, err)
+	collr.collectionSelector = sr
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Len(t, collr.collections, 1)
+	assert.NotContains(t, mx, "collection_config.users$documents")
+	chart := collr.Charts().Get("collection_config_users_documents_count")
+	require.NotNil(t, chart)
+	assert.True(t, chart.Obsolete)
+}
+
+func TestCollector_Collect_CollStatsRemovedCollectionSharedPrefix(t *testing.T) {
+	collr := prepareMongo()
+	defer collr.Cleanup(context.Background())
+	collr.conn = &mockMongoClient{collections: []string{"users", "users_archive"}}
+	collr.Databases = matcher.SimpleExpr{Includes: []string{"= admin"}}
+	collr.Collections = matcher.SimpleExpr{Includes: []string{"* admin.*"}}
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Contains(t, mx, "collection_admin.users$documents")
+	assert.Contains(t, mx, "collection_admin.users_archive$documents")
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	sr, err := (&matcher.SimpleExpr{Includes: []string{"* admin.users_archive"}}).Parse()
+	require.NoError(t, err)
+	collr.collectionSelector = sr
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	for _, id := range []string{"collection_admin_users_documents_count", "collection_admin_users_index_accesses_rate"} {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s'", id)
+		assert.Truef(t, chart.Obsolete, "chart '%s'", id)
+	}
+	name := collectionChartName("admin", "users_archive")
+	for _, id := range []string{"collection_" + name + "_documents_count", "collection_" + name + "_index_accesses_rate"} {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s'", id)
+		assert.Falsef(t, chart.Obsolete, "chart '%s'", id)
+	}
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_Collect_CollStatsChartIDsUnique(t *testing.T) {
+	collr := prepareMongo()
+	defer collr.Cleanup(context.Background())
+	collr.conn = &mockMongoClient{collections: []string{"fs.chunks", "fs_chunks", "users", "users_index"}}
+	collr.Databases = matcher.SimpleExpr{Includes: []string{"= admin"}}
+	collr.Collections = matcher.SimpleExpr{Includes: []string{"* admin.*"}}
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	// the 'users_index' size chart must not clash with the 'users' index size chart
+	var n int
+	for _, chart := range *collr.Charts() {
+		if strings.HasPrefix(chart.Ctx, "mongodb.collection_") && len(chart.Labels) > 0 {
+			n++
+		}
+	}
+	assert.Equal(t, 4*len(chartsTmplCollection), n)
+	assert.NotEqual(t, collectionChartName("admin", "fs.chunks"), collectionChartName("admin", "fs_chunks"))
+	assert.NotEqual(t, collectionChartName("a_b", "c"), collectionChartName("a", "b_c"))
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_VirtualNode(t *testing.T) {
+	tests := map[string]struct {
+		prepare     func() *mockMongoClient
+		createVnode bool
+		wantVnode   bool
+		wantHost    string
+		wantLabels  map[string]string
+	}{
+		"not created by default": {
+			prepare: caseMongodReplicaSet,
+		},
+		"replica set member": {
+			prepare:     caseMongodReplicaSet,
+			createVnode: true,
+			wantVnode:   true,
+			wantHost:    "mongodb-primary:27017",
+			wantLabels:  map[string]string{"role": "primary", "replica_set": "replicaset", "process": "mongod"},
+		},
+		"mongos": {
+			prepare:     caseMongos,
+			createVnode: true,
+			wantVnode:   true,
+			wantHost:    "localhost:27017",
+			wantLabels:  map[string]string{"role": "mongos", "process": "mongos"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := prepareMongo()
+			defer collr.Cleanup(context.Background())
+			collr.conn = test.prepare()
+			collr.CreateVnode = test.createVnode
+
+			require.NoError(t, collr.Init(context.Background()))
+			require.NotNil(t, collr.Collect(context.Background()))
+
+			if !test.wantVnode {
+				assert.Nil(t, collr.VirtualNode())
+				return
+			}
+
+			vnode := collr.VirtualNode()
+			require.NotNil(t, vnode)
+			assert.NotEmpty(t, vnode.GUID)
+			assert.Equal(t, test.wantHost, vnode.Hostname)
+			assert.Equal(t, test.wantLabels, vnode.Labels)
+		})
+	}
+}
+
 func prepareMongo() *Collector {
 	collr := New()
 	collr.Databases = matcher.SimpleExpr{Includes: []string{"* *"}}
@@ -640,6 +849,9 @@ type mockMongoClient struct {
 	errOnShardCollectionsPartitioning bool
 	errOnShardChunks                  bool
 	errOnInitClient                   bool
+	errOnCollStats                    bool
+	collections                       []string
+	withCurrentOp                     bool
 	clientInited                      bool
 	closeCalled                       bool
 }
@@ -801,6 +1013,75 @@ func (m *mockMongoClient) shardChunks() (map[string]int64, error) {
 	}, nil
 }
 
+func (m *mockMongoClient) hello() (*documentHello, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.hello() error: mongo client not inited")
+	}
+	switch {
+	case m.mongos:
+		return &documentHello{Msg: "isdbgrid", IsWritablePrimary: true}, nil
+	case m.replicaSet:
+		return &documentHello{SetName: "replicaset", Me: "mongodb-primary:27017", IsWritablePrimary: true}, nil
+	default:
+		return &documentHello{IsWritablePrimary: true}, nil
+	}
+}
+
+func (m *mockMongoClient) currentOps() ([]documentCurrentOp, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.currentOps() error: mongo client not inited")
+	}
+	if !m.withCurrentOp {
+		return nil, errors.New("mock.currentOps() error: not authorized on admin to execute command")
+	}
+	return []documentCurrentOp{
+		{Op: "query", MicrosecsRunning: 1500},
+		{Op: "query", MicrosecsRunning: 3_500_000},
+		{Op: "update", MicrosecsRunning: 2_000_000, WaitingForLock: true},
+		{Op: "command", MicrosecsRunning: 300},
+		{Op: "none", MicrosecsRunning: 9_000_000},
+	}, nil
+}
+
+func (m *mockMongoClient) listCollectionNames(db string) ([]string, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.listCollectionNames() error: mongo client not inited")
+	}
+	if db == "local" {
+		return []string{"oplog.rs", "startup_log"}, nil
+	}
+	if m.collections != nil {
+		return m.collections, nil
+	}
+	return []string{"users", "system.version"}, nil
+}
+
+func (m *mockMongoClient) collStats(_, _ string) (*documentCollStats, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.collStats() error: mongo client not inited")
+	}
+	if m.errOnCollStats {
+		return nil, errors.New("mock.collStats() error")
+	}
+	var s documentCollStats
+	s.StorageStats.Count = 100
+	s.StorageStats.Size = 4096
+	s.StorageStats.StorageSize = 8192
+	s.StorageStats.TotalIndexSize = 2048
+	s.StorageStats.NIndexes = 2
+	return &s, nil
+}
+
+func (m *mockMongoClient) indexStats(_, _ string) ([]documentIndexStats, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.indexStats() error: mongo client not inited")
+	}
+	var id, name documentIndexStats
+	id.Name, id.Accesses.Ops = "_id_", 10
+	name.Name, name.Accesses.Ops = "name_1", 25
+	return []documentIndexStats{id, name}, nil
+}
+
 func (m *mockMongoClient) initClient(_ string, _ time.Duration) error {
 	if m.errOnInitClient {
 		return errors.New("mock.initClient() error")
@@ -7,6 +7,7 @@ import "time"
 // https://www.mongodb.com/docs/manual/reference/command/serverStatus
 type documentServerStatus struct {
 	Process      string                  `bson:"process"` // mongod|mongos
+	Version      string                  `bson:"version"`
 	OpCounters   documentOpCounters      `bson:"opcounters" stm:"operations"`
 	OpLatencies  *documentOpLatencies    `bson:"opLatencies" stm:"operations_latencies"` // mongod only
 	Connections  documentConnections     `bson:"connections" stm:"connections"`
@@ -274,3 +275,39 @@ type documentShardNodesResult struct {
 	ShardAware   int64
 	ShardUnaware int64
 }
+
+// https://www.mongodb.com/docs/manual/reference/command/hello/
+type documentHello struct {
+	IsWritablePrimary bool   `bson:"isWritablePrimary"`
+	Secondary         bool   `bson:"secondary"`
+	ArbiterOnly       bool   `bson:"arbiterOnly"`
+	Msg               string `bson:"msg"` // "isdbgrid" on mongos
+	SetName           string `bson:"setName"`
+	Me                string `bson:"me"`
+}
+
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/currentOp/
+type documentCurrentOp struct {
+	Op               string `bson:"op"`
+	MicrosecsRunning int64  `bson:"microsecs_running"`
+	WaitingForLock   bool   `bson:"waitingForLock"`
+}
+
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/collStats/#storagestats-document
+type documentCollStats struct {
+	StorageStats struct {
+		Size           int64 `bson:"size"`
+		Count          int64 `bson:"count"`
+		StorageSize    int64 `bson:"storageSize"`
+		TotalIndexSize int64 `bson:"totalIndexSize"`
+		NIndexes       int64 `bson:"nindexes"`
+	} `bson:"storageStats"`
+}
+
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/indexStats/
+type documentIndexStats struct {
+	Name     string `bson:"name"`
+	Accesses struct {
+		Ops int64 `bson:"ops"`
+	} `bson:"accesses"`
+}
@@ -27,3 +27,17 @@ func (c *Collector) initDatabaseSelector() error {
 
 	return nil
 }
+
+func (c *Collector) initCollectionSelector() error {
+	if c.Collections.Empty() {
+		return nil
+	}
+
+	sr, err := c.Collections.Parse()
+	if err != nil {
+		return err
+	}
+	c.collectionSelector = sr
+
+	return nil
+}
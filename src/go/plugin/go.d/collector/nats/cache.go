@@ -13,6 +13,8 @@ func newCache() *cache {
 		inGateways:  make(gwCache),
 		outGateways: make(gwCache),
 		leafs:       make(leafCache),
+		streams:     make(streamCache),
+		consumers:   make(consumerCache),
 	}
 }
 
@@ -22,6 +24,8 @@ type cache struct {
 	inGateways  gwCache
 	outGateways gwCache
 	leafs       leafCache
+	streams     streamCache
+	consumers   consumerCache
 }
 
 func (c *cache) resetUpdated() {
@@ -37,6 +41,12 @@ func (c *cache) resetUpdated() {
 			conn.updated = false
 		}
 	}
+	for _, stream := range c.streams {
+		stream.updated = false
+	}
+	for _, cons := range c.consumers {
+		cons.updated = false
+	}
 }
 
 type (
@@ -136,3 +146,48 @@ func (c *leafCache) put(li leafInfo) {
 	}
 	leaf.updated = true
 }
+
+type (
+	streamCache      map[string]*streamCacheEntry
+	streamCacheEntry struct {
+		hasCharts bool
+		updated   bool
+		charts    []string
+
+		accName    string
+		streamName string
+	}
+)
+
+func (c *streamCache) put(accName, streamName string) {
+	key := accName + "/" + streamName
+	stream, ok := (*c)[key]
+	if !ok {
+		stream = &streamCacheEntry{accName: accName, streamName: streamName}
+		(*c)[key] = stream
+	}
+	stream.updated = true
+}
+
+type (
+	consumerCache      map[string]*consumerCacheEntry
+	consumerCacheEntry struct {
+		hasCharts bool
+		updated   bool
+		charts    []string
+
+		accName      string
+		streamName   string
+		consumerName string
+	}
+)
+
+func (c *consumerCache) put(accName, streamName, consumerName string) {
+	key := accName + "/" + streamName + "/" + consumerName
+	cons, ok := (*c)[key]
+	if !ok {
+		cons = &consumerCacheEntry{accName: accName, streamName: streamName, consumerName: consumerName}
+		(*c)[key] = cons
+	}
+	cons.updated = true
+}
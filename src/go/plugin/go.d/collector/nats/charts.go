@@ -4,6 +4,7 @@ package nats
 
 import (
 	"fmt"
+	"hash/fnv"
 	"maps"
 	"strconv"
 	"strings"
@@ -36,6 +37,16 @@ const (
 	prioJetStreamMemoryUsed
 	prioJetStreamStorageUsed
 
+	prioJetStreamStreamMessages
+	prioJetStreamStreamBytes
+	prioJetStreamStreamSubjects
+	prioJetStreamStreamSequence
+
+	prioJetStreamConsumerPending
+	prioJetStreamConsumerAckPending
+	prioJetStreamConsumerRedelivered
+	prioJetStreamConsumerWaiting
+
 	prioAccountTraffic
 	prioAccountMessages
 	prioAccountConnections
@@ -332,6 +343,116 @@ var (
 	}
 )
 
+var jetStreamStreamChartsTmpl = module.Charts{
+	jetStreamStreamMessagesTmpl.Copy(),
+	jetStreamStreamBytesTmpl.Copy(),
+	jetStreamStreamSubjectsTmpl.Copy(),
+	jetStreamStreamSequenceTmpl.Copy(),
+}
+
+var (
+	jetStreamStreamMessagesTmpl = module.Chart{
+		ID:       "jetstream_stream_%s_messages",
+		Title:    "JetStream Stream Messages",
+		Units:    "messages",
+		Fam:      "jstream stream",
+		Ctx:      "nats.jetstream_stream_messages",
+		Priority: prioJetStreamStreamMessages,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/messages", Name: "messages"},
+		},
+	}
+	jetStreamStreamBytesTmpl = module.Chart{
+		ID:       "jetstream_stream_%s_bytes",
+		Title:    "JetStream Stream Size",
+		Units:    "bytes",
+		Fam:      "jstream stream",
+		Ctx:      "nats.jetstream_stream_bytes",
+		Priority: prioJetStreamStreamBytes,
+		Type:     module.Area,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/bytes", Name: "size"},
+		},
+	}
+	jetStreamStreamSubjectsTmpl = module.Chart{
+		ID:       "jetstream_stream_%s_subjects",
+		Title:    "JetStream Stream Subjects",
+		Units:    "subjects",
+		Fam:      "jstream stream",
+		Ctx:      "nats.jetstream_stream_subjects",
+		Priority: prioJetStreamStreamSubjects,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/subjects", Name: "subjects"},
+		},
+	}
+	jetStreamStreamSequenceTmpl = module.Chart{
+		ID:       "jetstream_stream_%s_sequence",
+		Title:    "JetStream Stream Sequence",
+		Units:    "sequence",
+		Fam:      "jstream stream",
+		Ctx:      "nats.jetstream_stream_sequence",
+		Priority: prioJetStreamStreamSequence,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/first_seq", Name: "first"},
+			{ID: "jsz_acc_%s/stream_%s/last_seq", Name: "last"},
+		},
+	}
+)
+
+var jetStreamConsumerChartsTmpl = module.Charts{
+	jetStreamConsumerPendingTmpl.Copy(),
+	jetStreamConsumerAckPendingTmpl.Copy(),
+	jetStreamConsumerRedeliveredTmpl.Copy(),
+	jetStreamConsumerWaitingTmpl.Copy(),
+}
+
+var (
+	jetStreamConsumerPendingTmpl = module.Chart{
+		ID:       "jetstream_consumer_%s_pending",
+		Title:    "JetStream Consumer Pending Messages",
+		Units:    "messages",
+		Fam:      "jstream consumer",
+		Ctx:      "nats.jetstream_consumer_pending",
+		Priority: prioJetStreamConsumerPending,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/consumer_%s/num_pending", Name: "pending"},
+		},
+	}
+	jetStreamConsumerAckPendingTmpl = module.Chart{
+		ID:       "jetstream_consumer_%s_ack_pending",
+		Title:    "JetStream Consumer Messages Pending Acknowledgement",
+		Units:    "messages",
+		Fam:      "jstream consumer",
+		Ctx:      "nats.jetstream_consumer_ack_pending",
+		Priority: prioJetStreamConsumerAckPending,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/consumer_%s/num_ack_pending", Name: "ack_pending"},
+		},
+	}
+	jetStreamConsumerRedeliveredTmpl = module.Chart{
+		ID:       "jetstream_consumer_%s_redelivered",
+		Title:    "JetStream Consumer Redelivered Messages",
+		Units:    "messages",
+		Fam:      "jstream consumer",
+		Ctx:      "nats.jetstream_consumer_redelivered",
+		Priority: prioJetStreamConsumerRedelivered,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/consumer_%s/num_redelivered", Name: "redelivered"},
+		},
+	}
+	jetStreamConsumerWaitingTmpl = module.Chart{
+		ID:       "jetstream_consumer_%s_waiting",
+		Title:    "JetStream Consumer Waiting Pull Requests",
+		Units:    "requests",
+		Fam:      "jstream consumer",
+		Ctx:      "nats.jetstream_consumer_waiting",
+		Priority: prioJetStreamConsumerWaiting,
+		Dims: module.Dims{
+			{ID: "jsz_acc_%s/stream_%s/consumer_%s/num_waiting", Name: "waiting"},
+		},
+	}
+)
+
 var accountChartsTmpl = module.Charts{
 	accountTrafficTmpl.Copy(),
 	accountMessagesTmpl.Copy(),
@@ -660,6 +781,28 @@ func (c *Collector) updateCharts() {
 		}
 		return false
 	})
+	maps.DeleteFunc(c.cache.streams, func(_ string, stream *streamCacheEntry) bool {
+		if !stream.updated {
+			c.removeStreamCharts(stream)
+			return true
+		}
+		if !stream.hasCharts {
+			stream.hasCharts = true
+			c.addStreamCharts(stream)
+		}
+		return false
+	})
+	maps.DeleteFunc(c.cache.consumers, func(_ string, cons *consumerCacheEntry) bool {
+		if !cons.updated {
+			c.removeConsumerCharts(cons)
+			return true
+		}
+		if !cons.hasCharts {
+			cons.hasCharts = true
+			c.addConsumerCharts(cons)
+		}
+		return false
+	})
 }
 
 func (c *Collector) addServerCharts() {
@@ -801,6 +944,62 @@ func (c *Collector) removeLeafCharts(leaf *leafCacheEntry) {
 	c.removeCharts(px)
 }
 
+func (c *Collector) addStreamCharts(stream *streamCacheEntry) {
+	charts := jetStreamStreamChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, jetStreamChartIDName(stream.accName, stream.streamName))
+		chart.Labels = []module.Label{
+			{Key: "cluster_name", Value: c.srvMeta.clusterName},
+			{Key: "server_id", Value: c.srvMeta.id},
+			{Key: "server_name", Value: c.srvMeta.name},
+			{Key: "account", Value: stream.accName},
+			{Key: "stream", Value: stream.streamName},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, stream.accName, stream.streamName)
+		}
+		stream.charts = append(stream.charts, chart.ID)
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warningf("failed to add charts for stream %s/%s: %s", stream.accName, stream.streamName, err)
+	}
+}
+
+func (c *Collector) removeStreamCharts(stream *streamCacheEntry) {
+	// by ID: a prefix would also match streams sharing it (e.g. "orders" and "orders_archive")
+	c.removeChartsByID(stream.charts)
+}
+
+func (c *Collector) addConsumerCharts(cons *consumerCacheEntry) {
+	charts := jetStreamConsumerChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, jetStreamChartIDName(cons.accName, cons.streamName, cons.consumerName))
+		chart.Labels = []module.Label{
+			{Key: "cluster_name", Value: c.srvMeta.clusterName},
+			{Key: "server_id", Value: c.srvMeta.id},
+			{Key: "server_name", Value: c.srvMeta.name},
+			{Key: "account", Value: cons.accName},
+			{Key: "stream", Value: cons.streamName},
+			{Key: "consumer", Value: cons.consumerName},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, cons.accName, cons.streamName, cons.consumerName)
+		}
+		cons.charts = append(cons.charts, chart.ID)
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warningf("failed to add charts for consumer %s/%s/%s: %s", cons.accName, cons.streamName, cons.consumerName, err)
+	}
+}
+
+func (c *Collector) removeConsumerCharts(cons *consumerCacheEntry) {
+	c.removeChartsByID(cons.charts)
+}
+
 func (c *Collector) removeCharts(prefix string) {
 	for _, chart := range *c.Charts() {
 		if strings.HasPrefix(chart.ID, prefix) {
@@ -810,6 +1009,41 @@ func (c *Collector) removeCharts(prefix string) {
 	}
 }
 
+func (c *Collector) removeChartsByID(ids []string) {
+	for _, id := range ids {
+		if chart := c.Charts().Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+// jetStreamChartIDName joins account, stream and consumer names with "_" for chart IDs. Names
+// with underscores make it ambiguous ("A_B"+"C" and "A"+"B_C"), and other characters are not
+// allowed in chart IDs, so unless the names are letters, digits and "-" only, they are sanitized
+// and a hash of the names is appended.
+func jetStreamChartIDName(names ...string) string {
+	id := strings.Join(names, "_")
+
+	isPlain := func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-'
+	}
+	if !strings.ContainsFunc(strings.Join(names, ""), func(r rune) bool { return !isPlain(r) }) {
+		return id
+	}
+
+	id = strings.Map(func(r rune) rune {
+		if isPlain(r) {
+			return r
+		}
+		return '_'
+	}, id)
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.Join(names, "\x00")))
+
+	return fmt.Sprintf("%s_%08x", id, h.Sum32())
+}
+
 func cleanChartID(id string) string {
 	r := strings.NewReplacer(".", "_", " ", "_")
 	return strings.ToLower(r.Replace(id))
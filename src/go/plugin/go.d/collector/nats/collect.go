@@ -273,6 +273,8 @@ func (c *Collector) collectJsz(mx map[string]int64) error {
 		return err
 	}
 
+	req.URL.RawQuery = urlQueryJsz
+
 	var resp jszResponse
 	if err := web.DoHTTP(c.httpClient).RequestJSON(req, &resp); err != nil {
 		return err
@@ -290,6 +292,38 @@ func (c *Collector) collectJsz(mx map[string]int64) error {
 	mx["jsz_api_errors"] = int64(resp.Api.Errors)
 	mx["jsz_api_inflight"] = int64(resp.Api.Inflight)
 
+	for _, acc := range resp.AccountDetails {
+		for _, stream := range acc.Streams {
+			if c.streamsSr.MatchString(acc.Name + "/" + stream.Name) {
+				c.cache.streams.put(acc.Name, stream.Name)
+
+				// stream and consumer names can't contain '/', so the key is unambiguous for any account name
+				px := fmt.Sprintf("jsz_acc_%s/stream_%s/", acc.Name, stream.Name)
+
+				mx[px+"messages"] = int64(stream.State.Msgs)
+				mx[px+"bytes"] = int64(stream.State.Bytes)
+				mx[px+"subjects"] = int64(stream.State.NumSubjects)
+				mx[px+"first_seq"] = int64(stream.State.FirstSeq)
+				mx[px+"last_seq"] = int64(stream.State.LastSeq)
+			}
+
+			for _, cons := range stream.Consumers {
+				if !c.consumersSr.MatchString(acc.Name + "/" + stream.Name + "/" + cons.Name) {
+					continue
+				}
+
+				c.cache.consumers.put(acc.Name, stream.Name, cons.Name)
+
+				px := fmt.Sprintf("jsz_acc_%s/stream_%s/consumer_%s/", acc.Name, stream.Name, cons.Name)
+
+				mx[px+"num_pending"] = int64(cons.NumPending)
+				mx[px+"num_ack_pending"] = int64(cons.NumAckPending)
+				mx[px+"num_redelivered"] = int64(cons.NumRedelivered)
+				mx[px+"num_waiting"] = int64(cons.NumWaiting)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
@@ -38,7 +39,9 @@ func New() *Collector {
 					Timeout: confopt.Duration(time.Second),
 				},
 			},
-			HealthzCheck: "default",
+			HealthzCheck:      "default",
+			StreamsSelector:   "*",
+			ConsumersSelector: "*",
 		},
 		charts:           &module.Charts{},
 		cache:            newCache(),
@@ -47,10 +50,12 @@ func New() *Collector {
 }
 
 type Config struct {
-	Vnode          string `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery    int    `yaml:"update_every,omitempty" json:"update_every"`
-	HealthzCheck   string `yaml:"healthz_check,omitempty" json:"healthz_check"`
-	web.HTTPConfig `yaml:",inline" json:""`
+	Vnode             string `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery       int    `yaml:"update_every,omitempty" json:"update_every"`
+	HealthzCheck      string `yaml:"healthz_check,omitempty" json:"healthz_check"`
+	web.HTTPConfig    `yaml:",inline" json:""`
+	StreamsSelector   string `yaml:"streams_selector,omitempty" json:"streams_selector"`
+	ConsumersSelector string `yaml:"consumers_selector,omitempty" json:"consumers_selector"`
 }
 
 type Collector struct {
@@ -61,6 +66,9 @@ type Collector struct {
 
 	httpClient *http.Client
 
+	streamsSr   matcher.Matcher
+	consumersSr matcher.Matcher
+
 	cache *cache
 
 	srvMeta struct {
@@ -86,6 +94,18 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.httpClient = httpClient
 
+	streamsSr, err := c.initStreamsSelector()
+	if err != nil {
+		return fmt.Errorf("init streams selector: %v", err)
+	}
+	c.streamsSr = streamsSr
+
+	consumersSr, err := c.initConsumersSelector()
+	if err != nil {
+		return fmt.Errorf("init consumers selector: %v", err)
+	}
+	c.consumersSr = consumersSr
+
 	c.Debugf("using URL %s", c.URL)
 	c.Debugf("using timeout: %s", c.Timeout)
 
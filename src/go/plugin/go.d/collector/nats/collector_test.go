@@ -4,6 +4,8 @@ package nats
 
 import (
 	"context"
+	"encoding/json"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -139,116 +141,140 @@ func TestCollector_Collect(t *testing.T) {
 				len(accountChartsTmpl)*3 +
 				len(routeChartsTmpl)*1 +
 				len(gatewayConnChartsTmpl)*5 +
-				len(leafConnChartsTmpl)*1,
+				len(leafConnChartsTmpl)*1 +
+				len(jetStreamStreamChartsTmpl)*2 +
+				len(jetStreamConsumerChartsTmpl)*3,
 			wantMetrics: map[string]int64{
-				"accstatz_acc_$G_conns":                        0,
-				"accstatz_acc_$G_leaf_nodes":                   0,
-				"accstatz_acc_$G_num_subs":                     5,
-				"accstatz_acc_$G_received_bytes":               0,
-				"accstatz_acc_$G_received_msgs":                0,
-				"accstatz_acc_$G_sent_bytes":                   0,
-				"accstatz_acc_$G_sent_msgs":                    0,
-				"accstatz_acc_$G_slow_consumers":               0,
-				"accstatz_acc_$G_total_conns":                  0,
-				"accstatz_acc_$SYS_conns":                      0,
-				"accstatz_acc_$SYS_leaf_nodes":                 0,
-				"accstatz_acc_$SYS_num_subs":                   220,
-				"accstatz_acc_$SYS_received_bytes":             0,
-				"accstatz_acc_$SYS_received_msgs":              0,
-				"accstatz_acc_$SYS_sent_bytes":                 0,
-				"accstatz_acc_$SYS_sent_msgs":                  0,
-				"accstatz_acc_$SYS_slow_consumers":             0,
-				"accstatz_acc_$SYS_total_conns":                0,
-				"accstatz_acc_default_conns":                   44,
-				"accstatz_acc_default_leaf_nodes":              0,
-				"accstatz_acc_default_num_subs":                1133,
-				"accstatz_acc_default_received_bytes":          62023455,
-				"accstatz_acc_default_received_msgs":           916392,
-				"accstatz_acc_default_sent_bytes":              529749990,
-				"accstatz_acc_default_sent_msgs":               2546732,
-				"accstatz_acc_default_slow_consumers":          1,
-				"accstatz_acc_default_total_conns":             44,
-				"gatewayz_inbound_gw_region2_cid_9_in_bytes":   0,
-				"gatewayz_inbound_gw_region2_cid_9_in_msgs":    0,
-				"gatewayz_inbound_gw_region2_cid_9_num_subs":   0,
-				"gatewayz_inbound_gw_region2_cid_9_out_bytes":  0,
-				"gatewayz_inbound_gw_region2_cid_9_out_msgs":   0,
-				"gatewayz_inbound_gw_region2_cid_9_uptime":     6,
-				"gatewayz_inbound_gw_region3_cid_4_in_bytes":   0,
-				"gatewayz_inbound_gw_region3_cid_4_in_msgs":    0,
-				"gatewayz_inbound_gw_region3_cid_4_num_subs":   0,
-				"gatewayz_inbound_gw_region3_cid_4_out_bytes":  0,
-				"gatewayz_inbound_gw_region3_cid_4_out_msgs":   0,
-				"gatewayz_inbound_gw_region3_cid_4_uptime":     6,
-				"gatewayz_inbound_gw_region3_cid_8_in_bytes":   0,
-				"gatewayz_inbound_gw_region3_cid_8_in_msgs":    0,
-				"gatewayz_inbound_gw_region3_cid_8_num_subs":   0,
-				"gatewayz_inbound_gw_region3_cid_8_out_bytes":  0,
-				"gatewayz_inbound_gw_region3_cid_8_out_msgs":   0,
-				"gatewayz_inbound_gw_region3_cid_8_uptime":     6,
-				"gatewayz_outbound_gw_region2_cid_7_in_bytes":  0,
-				"gatewayz_outbound_gw_region2_cid_7_in_msgs":   0,
-				"gatewayz_outbound_gw_region2_cid_7_num_subs":  0,
-				"gatewayz_outbound_gw_region2_cid_7_out_bytes": 0,
-				"gatewayz_outbound_gw_region2_cid_7_out_msgs":  0,
-				"gatewayz_outbound_gw_region2_cid_7_uptime":    6,
-				"gatewayz_outbound_gw_region3_cid_5_in_bytes":  0,
-				"gatewayz_outbound_gw_region3_cid_5_in_msgs":   0,
-				"gatewayz_outbound_gw_region3_cid_5_num_subs":  0,
-				"gatewayz_outbound_gw_region3_cid_5_out_bytes": 0,
-				"gatewayz_outbound_gw_region3_cid_5_out_msgs":  0,
-				"gatewayz_outbound_gw_region3_cid_5_uptime":    6,
-				"jsz_api_errors":                               588,
-				"jsz_api_inflight":                             0,
-				"jsz_api_total":                                936916,
-				"jsz_bytes":                                    114419224,
-				"jsz_consumers":                                9,
-				"jsz_disabled":                                 0,
-				"jsz_enabled":                                  1,
-				"jsz_memory_used":                              128,
-				"jsz_messages":                                 5670,
-				"jsz_store_used":                               114419224,
-				"jsz_streams":                                  198,
-				"leafz_leaf__$G_127.0.0.1_6223_in_bytes":       0,
-				"leafz_leaf__$G_127.0.0.1_6223_in_msgs":        0,
-				"leafz_leaf__$G_127.0.0.1_6223_num_subs":       1,
-				"leafz_leaf__$G_127.0.0.1_6223_out_bytes":      1280000,
-				"leafz_leaf__$G_127.0.0.1_6223_out_msgs":       10000,
-				"leafz_leaf__$G_127.0.0.1_6223_rtt":            200,
-				"routez_route_id_1_in_bytes":                   4,
-				"routez_route_id_1_in_msgs":                    1,
-				"routez_route_id_1_num_subs":                   1,
-				"routez_route_id_1_out_bytes":                  4,
-				"routez_route_id_1_out_msgs":                   1,
-				"varz_http_endpoint_/_req":                     5710,
-				"varz_http_endpoint_/accountz_req":             2201,
-				"varz_http_endpoint_/accstatz_req":             6,
-				"varz_http_endpoint_/connz_req":                3649,
-				"varz_http_endpoint_/gatewayz_req":             2204,
-				"varz_http_endpoint_/healthz_req":              3430,
-				"varz_http_endpoint_/ipqueuesz_req":            0,
-				"varz_http_endpoint_/jsz_req":                  2958,
-				"varz_http_endpoint_/leafz_req":                9,
-				"varz_http_endpoint_/raftz_req":                0,
-				"varz_http_endpoint_/routez_req":               2202,
-				"varz_http_endpoint_/stacksz_req":              0,
-				"varz_http_endpoint_/subsz_req":                4412,
-				"varz_http_endpoint_/varz_req":                 7114,
-				"varz_srv_connections":                         44,
-				"varz_srv_cpu":                                 10,
-				"varz_srv_healthz_status_error":                0,
-				"varz_srv_healthz_status_ok":                   1,
-				"varz_srv_in_bytes":                            62024985,
-				"varz_srv_in_msgs":                             916475,
-				"varz_srv_mem":                                 95731712,
-				"varz_srv_out_bytes":                           529775656,
-				"varz_srv_out_msgs":                            2546840,
-				"varz_srv_remotes":                             0,
-				"varz_srv_routes":                              0,
-				"varz_srv_slow_consumers":                      1,
-				"varz_srv_subscriptions":                       1358,
-				"varz_srv_total_connections":                   74932,
-				"varz_srv_uptime":                              339394,
+				"accstatz_acc_$G_conns":                                           0,
+				"accstatz_acc_$G_leaf_nodes":                                      0,
+				"accstatz_acc_$G_num_subs":                                        5,
+				"accstatz_acc_$G_received_bytes":                                  0,
+				"accstatz_acc_$G_received_msgs":                                   0,
+				"accstatz_acc_$G_sent_bytes":                                      0,
+				"accstatz_acc_$G_sent_msgs":                                       0,
+				"accstatz_acc_$G_slow_consumers":                                  0,
+				"accstatz_acc_$G_total_conns":                                     0,
+				"accstatz_acc_$SYS_conns":                                         0,
+				"accstatz_acc_$SYS_leaf_nodes":                                    0,
+				"accstatz_acc_$SYS_num_subs":                                      220,
+				"accstatz_acc_$SYS_received_bytes":                                0,
+				"accstatz_acc_$SYS_received_msgs":                                 0,
+				"accstatz_acc_$SYS_sent_bytes":                                    0,
+				"accstatz_acc_$SYS_sent_msgs":                                     0,
+				"accstatz_acc_$SYS_slow_consumers":                                0,
+				"accstatz_acc_$SYS_total_conns":                                   0,
+				"accstatz_acc_default_conns":                                      44,
+				"accstatz_acc_default_leaf_nodes":                                 0,
+				"accstatz_acc_default_num_subs":                                   1133,
+				"accstatz_acc_default_received_bytes":                             62023455,
+				"accstatz_acc_default_received_msgs":                              916392,
+				"accstatz_acc_default_sent_bytes":                                 529749990,
+				"accstatz_acc_default_sent_msgs":                                  2546732,
+				"accstatz_acc_default_slow_consumers":                             1,
+				"accstatz_acc_default_total_conns":                                44,
+				"gatewayz_inbound_gw_region2_cid_9_in_bytes":                      0,
+				"gatewayz_inbound_gw_region2_cid_9_in_msgs":                       0,
+				"gatewayz_inbound_gw_region2_cid_9_num_subs":                      0,
+				"gatewayz_inbound_gw_region2_cid_9_out_bytes":                     0,
+				"gatewayz_inbound_gw_region2_cid_9_out_msgs":                      0,
+				"gatewayz_inbound_gw_region2_cid_9_uptime":                        6,
+				"gatewayz_inbound_gw_region3_cid_4_in_bytes":                      0,
+				"gatewayz_inbound_gw_region3_cid_4_in_msgs":                       0,
+				"gatewayz_inbound_gw_region3_cid_4_num_subs":                      0,
+				"gatewayz_inbound_gw_region3_cid_4_out_bytes":                     0,
+				"gatewayz_inbound_gw_region3_cid_4_out_msgs":                      0,
+				"gatewayz_inbound_gw_region3_cid_4_uptime":                        6,
+				"gatewayz_inbound_gw_region3_cid_8_in_bytes":                      0,
+				"gatewayz_inbound_gw_region3_cid_8_in_msgs":                       0,
+				"gatewayz_inbound_gw_region3_cid_8_num_subs":                      0,
+				"gatewayz_inbound_gw_region3_cid_8_out_bytes":                     0,
+				"gatewayz_inbound_gw_region3_cid_8_out_msgs":                      0,
+				"gatewayz_inbound_gw_region3_cid_8_uptime":                        6,
+				"gatewayz_outbound_gw_region2_cid_7_in_bytes":                     0,
+				"gatewayz_outbound_gw_region2_cid_7_in_msgs":                      0,
+				"gatewayz_outbound_gw_region2_cid_7_num_subs":                     0,
+				"gatewayz_outbound_gw_region2_cid_7_out_bytes":                    0,
+				"gatewayz_outbound_gw_region2_cid_7_out_msgs":                     0,
+				"gatewayz_outbound_gw_region2_cid_7_uptime":                       6,
+				"gatewayz_outbound_gw_region3_cid_5_in_bytes":                     0,
+				"gatewayz_outbound_gw_region3_cid_5_in_msgs":                      0,
+				"gatewayz_outbound_gw_region3_cid_5_num_subs":                     0,
+				"gatewayz_outbound_gw_region3_cid_5_out_bytes":                    0,
+				"gatewayz_outbound_gw_region3_cid_5_out_msgs":                     0,
+				"gatewayz_outbound_gw_region3_cid_5_uptime":                       6,
+				"jsz_api_errors":                                                  588,
+				"jsz_api_inflight":                                                0,
+				"jsz_api_total":                                                   936916,
+				"jsz_bytes":                                                       114419224,
+				"jsz_consumers":                                                   9,
+				"jsz_acc_default/stream_EVENTS/bytes":                             19224,
+				"jsz_acc_default/stream_EVENTS/consumer_audit/num_ack_pending":    5,
+				"jsz_acc_default/stream_EVENTS/consumer_audit/num_pending":        12,
+				"jsz_acc_default/stream_EVENTS/consumer_audit/num_redelivered":    0,
+				"jsz_acc_default/stream_EVENTS/consumer_audit/num_waiting":        0,
+				"jsz_acc_default/stream_EVENTS/first_seq":                         1,
+				"jsz_acc_default/stream_EVENTS/last_seq":                          70,
+				"jsz_acc_default/stream_EVENTS/messages":                          70,
+				"jsz_acc_default/stream_EVENTS/subjects":                          3,
+				"jsz_acc_default/stream_ORDERS/bytes":                             114400000,
+				"jsz_acc_default/stream_ORDERS/consumer_billing/num_ack_pending":  20,
+				"jsz_acc_default/stream_ORDERS/consumer_billing/num_pending":      350,
+				"jsz_acc_default/stream_ORDERS/consumer_billing/num_redelivered":  3,
+				"jsz_acc_default/stream_ORDERS/consumer_billing/num_waiting":      1,
+				"jsz_acc_default/stream_ORDERS/consumer_shipping/num_ack_pending": 0,
+				"jsz_acc_default/stream_ORDERS/consumer_shipping/num_pending":     0,
+				"jsz_acc_default/stream_ORDERS/consumer_shipping/num_redelivered": 0,
+				"jsz_acc_default/stream_ORDERS/consumer_shipping/num_waiting":     2,
+				"jsz_acc_default/stream_ORDERS/first_seq":                         1201,
+				"jsz_acc_default/stream_ORDERS/last_seq":                          6800,
+				"jsz_acc_default/stream_ORDERS/messages":                          5600,
+				"jsz_acc_default/stream_ORDERS/subjects":                          12,
+				"jsz_disabled":                                                    0,
+				"jsz_enabled":                                                     1,
+				"jsz_memory_used":                                                 128,
+				"jsz_messages":                                                    5670,
+				"jsz_store_used":                                                  114419224,
+				"jsz_streams":                                                     198,
+				"leafz_leaf__$G_127.0.0.1_6223_in_bytes":                          0,
+				"leafz_leaf__$G_127.0.0.1_6223_in_msgs":                           0,
+				"leafz_leaf__$G_127.0.0.1_6223_num_subs":                          1,
+				"leafz_leaf__$G_127.0.0.1_6223_out_bytes":                         1280000,
+				"leafz_leaf__$G_127.0.0.1_6223_out_msgs":                          10000,
+				"leafz_leaf__$G_127.0.0.1_6223_rtt":                               200,
+				"routez_route_id_1_in_bytes":                                      4,
+				"routez_route_id_1_in_msgs":                                       1,
+				"routez_route_id_1_num_subs":                                      1,
+				"routez_route_id_1_out_bytes":                                     4,
+				"routez_route_id_1_out_msgs":                                      1,
+				"varz_http_endpoint_/_req":                                        5710,
+				"varz_http_endpoint_/accountz_req":                                2201,
+				"varz_http_endpoint_/accstatz_req":                                6,
+				"varz_http_endpoint_/connz_req":                                   3649,
+				"varz_http_endpoint_/gatewayz_req":                                2204,
+				"varz_http_endpoint_/healthz_req":                                 3430,
+				"varz_http_endpoint_/ipqueuesz_req":                               0,
+				"varz_http_endpoint_/jsz_req":                                     2958,
+				"varz_http_endpoint_/leafz_req":                                   9,
+				"varz_http_endpoint_/raftz_req":                                   0,
+				"varz_http_endpoint_/routez_req":                                  2202,
+				"varz_http_endpoint_/stacksz_req":                                 0,
+				"varz_http_endpoint_/subsz_req":                                   4412,
+				"varz_http_endpoint_/varz_req":                                    7114,
+				"varz_srv_connections":                                            44,
+				"varz_srv_cpu":                                                    10,
+				"varz_srv_healthz_status_error":                                   0,
+				"varz_srv_healthz_status_ok":                                      1,
+				"varz_srv_in_bytes":                                               62024985,
+				"varz_srv_in_msgs":                                                916475,
+				"varz_srv_mem":                                                    95731712,
+				"varz_srv_out_bytes":                                              529775656,
+				"varz_srv_out_msgs":                                               2546840,
+				"varz_srv_remotes":                                                0,
+				"varz_srv_routes":                                                 0,
+				"varz_srv_slow_consumers":                                         1,
+				"varz_srv_subscriptions":                                          1358,
+				"varz_srv_total_connections":                                      74932,
+				"varz_srv_uptime":                                                 339394,
 			},
 		},
 		"fail on unexpected JSON response": {
@@ -289,7 +315,130 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_JetStreamSelectors(t *testing.T) {
+	collr, cleanup := caseOk(t)
+	defer cleanup()
+
+	collr.StreamsSelector = "default/ORDERS"
+	collr.ConsumersSelector = "!*/*/shipping *"
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	for _, k := range []string{
+		"jsz_acc_default/stream_ORDERS/messages",
+		"jsz_acc_default/stream_ORDERS/consumer_billing/num_pending",
+		"jsz_acc_default/stream_EVENTS/consumer_audit/num_pending",
+	} {
+		assert.Containsf(t, mx, k, "metric '%s'", k)
+	}
+	for _, k := range []string{
+		"jsz_acc_default/stream_EVENTS/messages",
+		"jsz_acc_default/stream_ORDERS/consumer_shipping/num_pending",
+	} {
+		assert.NotContainsf(t, mx, k, "metric '%s'", k)
+	}
+
+	assert.NotNil(t, collr.Charts().Get("jetstream_stream_default_ORDERS_messages"))
+	assert.Nil(t, collr.Charts().Get("jetstream_stream_default_EVENTS_messages"))
+	assert.NotNil(t, collr.Charts().Get("jetstream_consumer_default_EVENTS_audit_pending"))
+	assert.Nil(t, collr.Charts().Get("jetstream_consumer_default_ORDERS_shipping_pending"))
+
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_Collect_JetStreamStreamRemoved(t *testing.T) {
+	collr, cleanup := caseOk(t)
+	defer cleanup()
+
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	collr.StreamsSelector = "!*/EVENTS *"
+	collr.ConsumersSelector = "!*/EVENTS/* *"
+	require.NoError(t, collr.Init(context.Background()))
+
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	for _, chart := range *collr.Charts() {
+		removed := chartLabel(chart, "stream") == "EVENTS"
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", chart.ID)
+	}
+}
+
+func TestCollector_Collect_JetStreamStreamRemovedSharedPrefix(t *testing.T) {
+	// add the ORDERS_ARCHIVE stream, its chart IDs share the ORDERS stream charts prefix
+	var jsz map[string]any
+	require.NoError(t, json.Unmarshal(dataVer210Jsz, &jsz))
+	acc := jsz["account_details"].([]any)[0].(map[string]any)
+	streams := acc["stream_detail"].([]any)
+	archive := maps.Clone(streams[0].(map[string]any))
+	require.Equal(t, "ORDERS", archive["name"])
+	archive["name"] = "ORDERS_ARCHIVE"
+	acc["stream_detail"] = append(streams, archive)
+	data, err := json.Marshal(jsz)
+	require.NoError(t, err)
+
+	collr, cleanup := caseOkWithJsz(t, data)
+	defer cleanup()
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	require.Contains(t, mx, "jsz_acc_default/stream_ORDERS_ARCHIVE/messages")
+	require.Contains(t, mx, "jsz_acc_default/stream_ORDERS_ARCHIVE/consumer_billing/num_pending")
+
+	collr.StreamsSelector = "!*/ORDERS *"
+	collr.ConsumersSelector = "!*/ORDERS/* *"
+	require.NoError(t, collr.Init(context.Background()))
+
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	for _, chart := range *collr.Charts() {
+		removed := chartLabel(chart, "stream") == "ORDERS"
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", chart.ID)
+	}
+}
+
+func TestCollector_Collect_JetStreamChartIDsUnique(t *testing.T) {
+	// add the "orders" stream, its chart IDs must differ from the ORDERS stream chart IDs
+	var jsz map[string]any
+	require.NoError(t, json.Unmarshal(dataVer210Jsz, &jsz))
+	acc := jsz["account_details"].([]any)[0].(map[string]any)
+	streams := acc["stream_detail"].([]any)
+	lower := maps.Clone(streams[0].(map[string]any))
+	require.Equal(t, "ORDERS", lower["name"])
+	lower["name"] = "orders"
+	acc["stream_detail"] = append(streams, lower)
+	data, err := json.Marshal(jsz)
+	require.NoError(t, err)
+
+	collr, cleanup := caseOkWithJsz(t, data)
+	defer cleanup()
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.NotNil(t, collr.Charts().Get("jetstream_stream_default_ORDERS_messages"))
+	assert.NotNil(t, collr.Charts().Get("jetstream_stream_default_orders_messages"))
+	assert.NotEqual(t, jetStreamChartIDName("A_B", "C"), jetStreamChartIDName("A", "B_C"))
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func chartLabel(chart *module.Chart, key string) string {
+	for _, l := range chart.Labels {
+		if l.Key == key {
+			return l.Value
+		}
+	}
+	return ""
+}
+
 func caseOk(t *testing.T) (*Collector, func()) {
+	t.Helper()
+	return caseOkWithJsz(t, dataVer210Jsz)
+}
+
+func caseOkWithJsz(t *testing.T, jsz []byte) (*Collector, func()) {
 	t.Helper()
 	srv := httptest.NewServer(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -311,7 +460,11 @@ func caseOk(t *testing.T) (*Collector, func()) {
 			case urlPathLeafz:
 				_, _ = w.Write(dataVer210Leafz)
 			case urlPathJsz:
-				_, _ = w.Write(dataVer210Jsz)
+				if r.URL.RawQuery != urlQueryJsz {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write(jsz)
 			default:
 				w.WriteHeader(http.StatusNotFound)
 			}
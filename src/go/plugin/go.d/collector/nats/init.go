@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nats
+
+import (
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+)
+
+func (c *Collector) initStreamsSelector() (matcher.Matcher, error) {
+	if c.StreamsSelector == "" {
+		return matcher.TRUE(), nil
+	}
+	return matcher.NewSimplePatternsMatcher(c.StreamsSelector)
+}
+
+func (c *Collector) initConsumersSelector() (matcher.Matcher, error) {
+	if c.ConsumersSelector == "" {
+		return matcher.TRUE(), nil
+	}
+	return matcher.NewSimplePatternsMatcher(c.ConsumersSelector)
+}
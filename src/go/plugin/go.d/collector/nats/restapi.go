@@ -3,6 +3,7 @@
 package nats
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
@@ -31,6 +32,11 @@ var (
 	urlQueryHealthzJsEnabledOnly = web.URLQuery("js-enabled-only", "true")
 	urlQueryHealthzJsServerOnly  = web.URLQuery("js-server-only", "true")
 	urlQueryAccstatz             = web.URLQuery("unused", "1")
+	urlQueryJsz                  = url.Values{
+		"accounts":  []string{"true"},
+		"streams":   []string{"true"},
+		"consumers": []string{"true"},
+	}.Encode()
 )
 
 // //https://github.com/nats-io/nats-server/blob/v2.10.24/server/server.go#L2851
@@ -183,7 +189,8 @@ type (
 			Errors   uint64 `json:"errors"`
 			Inflight uint64 `json:"inflight"`
 		} `json:"api"`
-		Meta *jszMetaClusterInfo `json:"meta_cluster"`
+		Meta           *jszMetaClusterInfo `json:"meta_cluster"`
+		AccountDetails []jszAccountDetail  `json:"account_details"`
 	}
 	jszMetaClusterInfo struct {
 		Name     string         `json:"name"`
@@ -201,4 +208,29 @@ type (
 		Lag     uint64        `json:"lag"`
 		Peer    string        `json:"peer"`
 	}
+	// https://github.com/nats-io/nats-server/blob/v2.10.24/server/monitor.go (AccountDetail, StreamDetail)
+	jszAccountDetail struct {
+		Name    string            `json:"name"`
+		Streams []jszStreamDetail `json:"stream_detail"`
+	}
+	jszStreamDetail struct {
+		Name  string `json:"name"`
+		State struct {
+			Msgs        uint64 `json:"messages"`
+			Bytes       uint64 `json:"bytes"`
+			FirstSeq    uint64 `json:"first_seq"`
+			LastSeq     uint64 `json:"last_seq"`
+			NumSubjects int    `json:"num_subjects"`
+		} `json:"state"`
+		Consumers []jszConsumerInfo `json:"consumer_detail"`
+	}
+	// https://github.com/nats-io/nats-server/blob/v2.10.24/server/consumer.go (ConsumerInfo)
+	jszConsumerInfo struct {
+		Stream         string `json:"stream_name"`
+		Name           string `json:"name"`
+		NumAckPending  int    `json:"num_ack_pending"`
+		NumRedelivered int    `json:"num_redelivered"`
+		NumWaiting     int    `json:"num_waiting"`
+		NumPending     uint64 `json:"num_pending"`
+	}
 )
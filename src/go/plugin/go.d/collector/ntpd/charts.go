@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/timesync"
 )
 
 const (
@@ -37,6 +38,7 @@ const (
 
 var (
 	systemCharts = module.Charts{
+		timesync.Chart(),
 		systemOffsetChart.Copy(),
 		systemJitterChart.Copy(),
 		systemFrequencyChart.Copy(),
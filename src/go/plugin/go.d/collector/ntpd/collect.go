@@ -7,6 +7,8 @@ import (
 	"net"
 	"strconv"
 	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/timesync"
 )
 
 const (
@@ -66,9 +68,30 @@ func (c *Collector) collectInfo(mx map[string]int64) error {
 			}
 		}
 	}
+
+	if leap, ok := parseLeap(info["leap"]); ok {
+		// stratum 16 means unsynchronized
+		stratum, err := strconv.ParseInt(info["stratum"], 10, 64)
+		synced := err == nil && stratum > 0 && stratum < 16
+		timesync.WriteMetrics(mx, timesync.State(leap, synced))
+	}
+
 	return nil
 }
 
+func parseLeap(s string) (int, bool) {
+	// decimal ('0'..'3') or the leap indicator bits ('00'..'11') as shown by ntpq
+	base := 10
+	if len(s) == 2 {
+		base = 2
+	}
+	v, err := strconv.ParseInt(s, base, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(v), true
+}
+
 func (c *Collector) collectPeersInfo(mx map[string]int64) {
 	for _, id := range c.peerIDs {
 		info, err := c.client.peerInfo(id)
@@ -159,55 +159,59 @@ func TestCollector_Collect(t *testing.T) {
 		"system: success, peers: success": {
 			prepare: func() *Collector { return prepareNTPdWithMock(&mockClient{}, true) },
 			expected: map[string]int64{
-				"clk_jitter":                  626000,
-				"clk_wander":                  81000,
-				"mintc":                       3000000,
-				"offset":                      -149638,
-				"peer_203.0.113.1_delay":      10464000,
-				"peer_203.0.113.1_dispersion": 5376000,
-				"peer_203.0.113.1_hmode":      3000000,
-				"peer_203.0.113.1_hpoll":      7000000,
-				"peer_203.0.113.1_jitter":     5204000,
-				"peer_203.0.113.1_offset":     312000,
-				"peer_203.0.113.1_pmode":      4000000,
-				"peer_203.0.113.1_ppoll":      7000000,
-				"peer_203.0.113.1_precision":  -21000000,
-				"peer_203.0.113.1_rootdelay":  198000,
-				"peer_203.0.113.1_rootdisp":   14465000,
-				"peer_203.0.113.1_stratum":    2000000,
-				"peer_203.0.113.1_xleave":     95000,
-				"peer_203.0.113.2_delay":      10464000,
-				"peer_203.0.113.2_dispersion": 5376000,
-				"peer_203.0.113.2_hmode":      3000000,
-				"peer_203.0.113.2_hpoll":      7000000,
-				"peer_203.0.113.2_jitter":     5204000,
-				"peer_203.0.113.2_offset":     312000,
-				"peer_203.0.113.2_pmode":      4000000,
-				"peer_203.0.113.2_ppoll":      7000000,
-				"peer_203.0.113.2_precision":  -21000000,
-				"peer_203.0.113.2_rootdelay":  198000,
-				"peer_203.0.113.2_rootdisp":   14465000,
-				"peer_203.0.113.2_stratum":    2000000,
-				"peer_203.0.113.2_xleave":     95000,
-				"peer_203.0.113.3_delay":      10464000,
-				"peer_203.0.113.3_dispersion": 5376000,
-				"peer_203.0.113.3_hmode":      3000000,
-				"peer_203.0.113.3_hpoll":      7000000,
-				"peer_203.0.113.3_jitter":     5204000,
-				"peer_203.0.113.3_offset":     312000,
-				"peer_203.0.113.3_pmode":      4000000,
-				"peer_203.0.113.3_ppoll":      7000000,
-				"peer_203.0.113.3_precision":  -21000000,
-				"peer_203.0.113.3_rootdelay":  198000,
-				"peer_203.0.113.3_rootdisp":   14465000,
-				"peer_203.0.113.3_stratum":    2000000,
-				"peer_203.0.113.3_xleave":     95000,
-				"precision":                   -24000000,
-				"rootdelay":                   10385000,
-				"rootdisp":                    23404000,
-				"stratum":                     2000000,
-				"sys_jitter":                  1648010,
-				"tc":                          7000000,
+				"clk_jitter":                                626000,
+				"clk_wander":                                81000,
+				"mintc":                                     3000000,
+				"offset":                                    -149638,
+				"peer_203.0.113.1_delay":                    10464000,
+				"peer_203.0.113.1_dispersion":               5376000,
+				"peer_203.0.113.1_hmode":                    3000000,
+				"peer_203.0.113.1_hpoll":                    7000000,
+				"peer_203.0.113.1_jitter":                   5204000,
+				"peer_203.0.113.1_offset":                   312000,
+				"peer_203.0.113.1_pmode":                    4000000,
+				"peer_203.0.113.1_ppoll":                    7000000,
+				"peer_203.0.113.1_precision":                -21000000,
+				"peer_203.0.113.1_rootdelay":                198000,
+				"peer_203.0.113.1_rootdisp":                 14465000,
+				"peer_203.0.113.1_stratum":                  2000000,
+				"peer_203.0.113.1_xleave":                   95000,
+				"peer_203.0.113.2_delay":                    10464000,
+				"peer_203.0.113.2_dispersion":               5376000,
+				"peer_203.0.113.2_hmode":                    3000000,
+				"peer_203.0.113.2_hpoll":                    7000000,
+				"peer_203.0.113.2_jitter":                   5204000,
+				"peer_203.0.113.2_offset":                   312000,
+				"peer_203.0.113.2_pmode":                    4000000,
+				"peer_203.0.113.2_ppoll":                    7000000,
+				"peer_203.0.113.2_precision":                -21000000,
+				"peer_203.0.113.2_rootdelay":                198000,
+				"peer_203.0.113.2_rootdisp":                 14465000,
+				"peer_203.0.113.2_stratum":                  2000000,
+				"peer_203.0.113.2_xleave":                   95000,
+				"peer_203.0.113.3_delay":                    10464000,
+				"peer_203.0.113.3_dispersion":               5376000,
+				"peer_203.0.113.3_hmode":                    3000000,
+				"peer_203.0.113.3_hpoll":                    7000000,
+				"peer_203.0.113.3_jitter":                   5204000,
+				"peer_203.0.113.3_offset":                   312000,
+				"peer_203.0.113.3_pmode":                    4000000,
+				"peer_203.0.113.3_ppoll":                    7000000,
+				"peer_203.0.113.3_precision":                -21000000,
+				"peer_203.0.113.3_rootdelay":                198000,
+				"peer_203.0.113.3_rootdisp":                 14465000,
+				"peer_203.0.113.3_stratum":                  2000000,
+				"peer_203.0.113.3_xleave":                   95000,
+				"precision":                                 -24000000,
+				"rootdelay":                                 10385000,
+				"rootdisp":                                  23404000,
+				"stratum":                                   2000000,
+				"sys_jitter":                                1648010,
+				"tc":                                        7000000,
+				"timesync_health_state_leap_delete_pending": 0,
+				"timesync_health_state_leap_insert_pending": 0,
+				"timesync_health_state_synchronized":        1,
+				"timesync_health_state_unsynchronized":      0,
 			},
 			expectedCharts: len(systemCharts) + len(peerChartsTmpl)*3,
 		},
@@ -224,6 +228,10 @@ func TestCollector_Collect(t *testing.T) {
 				"stratum":    2000000,
 				"sys_jitter": 1648010,
 				"tc":         7000000,
+				"timesync_health_state_leap_delete_pending": 0,
+				"timesync_health_state_leap_insert_pending": 0,
+				"timesync_health_state_synchronized":        1,
+				"timesync_health_state_unsynchronized":      0,
 			},
 			expectedCharts: len(systemCharts),
 		},
@@ -240,6 +248,10 @@ func TestCollector_Collect(t *testing.T) {
 				"stratum":    2000000,
 				"sys_jitter": 1648010,
 				"tc":         7000000,
+				"timesync_health_state_leap_delete_pending": 0,
+				"timesync_health_state_leap_insert_pending": 0,
+				"timesync_health_state_synchronized":        1,
+				"timesync_health_state_unsynchronized":      0,
 			},
 			expectedCharts: len(systemCharts),
 		},
@@ -271,6 +283,30 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func Test_parseLeap(t *testing.T) {
+	tests := map[string]struct {
+		value  string
+		want   int
+		wantOK bool
+	}{
+		"decimal":         {value: "3", want: 3, wantOK: true},
+		"leap bits":       {value: "01", want: 1, wantOK: true},
+		"leap bits alarm": {value: "11", want: 3, wantOK: true},
+		"empty":           {value: "", wantOK: false},
+		"not a number":    {value: "x", wantOK: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, ok := parseLeap(test.value)
+			assert.Equal(t, test.wantOK, ok)
+			if ok {
+				assert.Equal(t, test.want, v)
+			}
+		})
+	}
+}
+
 func prepareNTPdWithMock(m *mockClient, collectPeers bool) *Collector {
 	collr := New()
 	collr.CollectPeers = collectPeers
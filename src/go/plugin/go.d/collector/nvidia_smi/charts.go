@@ -29,8 +29,32 @@ const (
 	prioGPUClockFreq
 	prioGPUPowerDraw
 	prioGPUPerformanceState
+	prioGPUClocksReasons
+	prioGPUECCErrorsVolatile
+	prioGPUECCErrorsAggregate
+	prioGPURetiredPages
+	prioGPURemappedRows
+	prioGPUMIGECCErrors
+	prioGPUMIGProcesses
+	prioGPUProcessMemoryUsage
+	prioGPUProcessUtilization
+	prioGPUProcessMemUtilization
 )
 
+// gpuClocksReasons are the reasons for the GPU clocks being reduced ("clocks_throttle_reasons",
+// renamed to "clocks_event_reasons" in driver 535).
+var gpuClocksReasons = []string{
+	"gpu_idle",
+	"applications_clocks_setting",
+	"sw_power_cap",
+	"hw_slowdown",
+	"hw_thermal_slowdown",
+	"hw_power_brake_slowdown",
+	"sync_boost",
+	"sw_thermal_slowdown",
+	"display_clocks_setting",
+}
+
 var (
 	gpuXMLCharts = module.Charts{
 		gpuPCIBandwidthUsageChartTmpl.Copy(),
@@ -49,10 +73,24 @@ var (
 		gpuClockFreqChartTmpl.Copy(),
 		gpuPowerDrawChartTmpl.Copy(),
 		gpuPerformanceStateChartTmpl.Copy(),
+		gpuClocksReasonsChartTmpl.Copy(),
+		gpuECCErrorsVolatileChartTmpl.Copy(),
+		gpuECCErrorsAggregateChartTmpl.Copy(),
+		gpuRetiredPagesChartTmpl.Copy(),
+		gpuRemappedRowsChartTmpl.Copy(),
 	}
 	migDeviceXMLCharts = module.Charts{
 		migDeviceFrameBufferMemoryUsageChartTmpl.Copy(),
 		migDeviceBAR1MemoryUsageChartTmpl.Copy(),
+		migDeviceECCErrorsChartTmpl.Copy(),
+		migDeviceProcessesChartTmpl.Copy(),
+	}
+	processXMLCharts = module.Charts{
+		processMemoryUsageChartTmpl.Copy(),
+	}
+	processUtilizationXMLCharts = module.Charts{
+		processUtilizationChartTmpl.Copy(),
+		processMemUtilizationChartTmpl.Copy(),
 	}
 )
 
@@ -262,6 +300,71 @@ var (
 	}
 )
 
+var (
+	gpuClocksReasonsChartTmpl = func() module.Chart {
+		chart := module.Chart{
+			ID:       "gpu_%s_clocks_reasons",
+			Title:    "Clocks reduction reasons",
+			Units:    "status",
+			Fam:      "clocks",
+			Ctx:      "nvidia_smi.gpu_clocks_reasons",
+			Priority: prioGPUClocksReasons,
+		}
+		for _, r := range gpuClocksReasons {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "gpu_%s_clocks_reason_" + r, Name: r})
+		}
+		return chart
+	}()
+	gpuECCErrorsVolatileChartTmpl = module.Chart{
+		ID:       "gpu_%s_ecc_errors_volatile",
+		Title:    "ECC errors since the last driver reload",
+		Units:    "errors",
+		Fam:      "ecc errors",
+		Ctx:      "nvidia_smi.gpu_ecc_errors_volatile",
+		Priority: prioGPUECCErrorsVolatile,
+		Dims: module.Dims{
+			{ID: "gpu_%s_ecc_errors_volatile_correctable", Name: "correctable"},
+			{ID: "gpu_%s_ecc_errors_volatile_uncorrectable", Name: "uncorrectable"},
+		},
+	}
+	gpuECCErrorsAggregateChartTmpl = module.Chart{
+		ID:       "gpu_%s_ecc_errors_aggregate",
+		Title:    "ECC errors over the GPU lifetime",
+		Units:    "errors",
+		Fam:      "ecc errors",
+		Ctx:      "nvidia_smi.gpu_ecc_errors_aggregate",
+		Priority: prioGPUECCErrorsAggregate,
+		Dims: module.Dims{
+			{ID: "gpu_%s_ecc_errors_aggregate_correctable", Name: "correctable"},
+			{ID: "gpu_%s_ecc_errors_aggregate_uncorrectable", Name: "uncorrectable"},
+		},
+	}
+	gpuRetiredPagesChartTmpl = module.Chart{
+		ID:       "gpu_%s_retired_pages",
+		Title:    "Retired memory pages",
+		Units:    "pages",
+		Fam:      "ecc errors",
+		Ctx:      "nvidia_smi.gpu_retired_pages",
+		Priority: prioGPURetiredPages,
+		Dims: module.Dims{
+			{ID: "gpu_%s_retired_pages_single_bit", Name: "single_bit"},
+			{ID: "gpu_%s_retired_pages_double_bit", Name: "double_bit"},
+		},
+	}
+	gpuRemappedRowsChartTmpl = module.Chart{
+		ID:       "gpu_%s_remapped_rows",
+		Title:    "Remapped memory rows",
+		Units:    "rows",
+		Fam:      "ecc errors",
+		Ctx:      "nvidia_smi.gpu_remapped_rows",
+		Priority: prioGPURemappedRows,
+		Dims: module.Dims{
+			{ID: "gpu_%s_remapped_rows_correctable", Name: "correctable"},
+			{ID: "gpu_%s_remapped_rows_uncorrectable", Name: "uncorrectable"},
+		},
+	}
+)
+
 func (c *Collector) addGpuCharts(gpu gpuInfo, index int) {
 	charts := gpuXMLCharts.Copy()
 
@@ -295,6 +398,26 @@ func (c *Collector) addGpuCharts(gpu gpuInfo, index int) {
 		_ = charts.Remove(gpuVoltageChartTmpl.ID)
 	}
 
+	mx = make(map[string]int64)
+	addGPUClocksReasonsMetrics(mx, "", gpu)
+	addGPUECCErrorsMetrics(mx, "", gpu)
+
+	if !hasMetricWithPrefix(mx, "clocks_reason_") {
+		_ = charts.Remove(gpuClocksReasonsChartTmpl.ID)
+	}
+	if !hasMetricWithPrefix(mx, "ecc_errors_volatile_") {
+		_ = charts.Remove(gpuECCErrorsVolatileChartTmpl.ID)
+	}
+	if !hasMetricWithPrefix(mx, "ecc_errors_aggregate_") {
+		_ = charts.Remove(gpuECCErrorsAggregateChartTmpl.ID)
+	}
+	if !isValidValue(gpu.RetiredPages.MultipleSingleBitRetirement.RetiredCount) {
+		_ = charts.Remove(gpuRetiredPagesChartTmpl.ID)
+	}
+	if !isValidValue(gpu.RemappedRows.Correctable) {
+		_ = charts.Remove(gpuRemappedRowsChartTmpl.ID)
+	}
+
 	for _, c := range *charts {
 		c.ID = fmt.Sprintf(c.ID, strings.ToLower(gpu.UUID))
 		c.Labels = []module.Label{
@@ -342,9 +465,38 @@ var (
 	}
 )
 
+var (
+	migDeviceECCErrorsChartTmpl = module.Chart{
+		ID:       "mig_instance_%s_gpu_%s_ecc_errors",
+		Title:    "MIG ECC errors since the last driver reload",
+		Units:    "errors",
+		Fam:      "ecc errors",
+		Ctx:      "nvidia_smi.gpu_mig_ecc_errors",
+		Priority: prioGPUMIGECCErrors,
+		Dims: module.Dims{
+			{ID: "mig_instance_%s_gpu_%s_ecc_error_sram_uncorrectable", Name: "sram_uncorrectable"},
+		},
+	}
+	migDeviceProcessesChartTmpl = module.Chart{
+		ID:       "mig_instance_%s_gpu_%s_processes",
+		Title:    "MIG processes",
+		Units:    "processes",
+		Fam:      "mig",
+		Ctx:      "nvidia_smi.gpu_mig_processes",
+		Priority: prioGPUMIGProcesses,
+		Dims: module.Dims{
+			{ID: "mig_instance_%s_gpu_%s_processes", Name: "processes"},
+		},
+	}
+)
+
 func (c *Collector) addMIGDeviceCharts(gpu gpuInfo, mig gpuMIGDeviceInfo) {
 	charts := migDeviceXMLCharts.Copy()
 
+	if !isValidValue(mig.ECCErrorCount.VolatileCount.SRAMUncorrectable) {
+		_ = charts.Remove(migDeviceECCErrorsChartTmpl.ID)
+	}
+
 	for _, c := range *charts {
 		c.ID = fmt.Sprintf(c.ID, strings.ToLower(mig.GPUInstanceID), strings.ToLower(gpu.UUID))
 		c.Labels = []module.Label{
@@ -362,6 +514,87 @@ func (c *Collector) addMIGDeviceCharts(gpu gpuInfo, mig gpuMIGDeviceInfo) {
 	}
 }
 
+var (
+	processMemoryUsageChartTmpl = module.Chart{
+		ID:       "gpu_%s_process_%s_memory_usage",
+		Title:    "Process GPU memory usage",
+		Units:    "B",
+		Fam:      "processes",
+		Ctx:      "nvidia_smi.gpu_process_memory_usage",
+		Priority: prioGPUProcessMemoryUsage,
+		Dims: module.Dims{
+			{ID: "gpu_%s_process_%s_used_memory", Name: "used"},
+		},
+	}
+	processUtilizationChartTmpl = module.Chart{
+		ID:       "gpu_%s_process_%s_utilization",
+		Title:    "Process GPU utilization",
+		Units:    "%",
+		Fam:      "processes",
+		Ctx:      "nvidia_smi.gpu_process_utilization",
+		Priority: prioGPUProcessUtilization,
+		Dims: module.Dims{
+			{ID: "gpu_%s_process_%s_gpu_utilization", Name: "gpu"},
+		},
+	}
+	processMemUtilizationChartTmpl = module.Chart{
+		ID:       "gpu_%s_process_%s_utilization_memory",
+		Title:    "Process GPU memory utilization",
+		Units:    "%",
+		Fam:      "processes",
+		Ctx:      "nvidia_smi.gpu_process_memory_utilization",
+		Priority: prioGPUProcessMemUtilization,
+		Dims: module.Dims{
+			{ID: "gpu_%s_process_%s_mem_utilization", Name: "memory"},
+		},
+	}
+)
+
+func (c *Collector) addProcessCharts(gpu gpuInfo, proc gpuProcessInfo) {
+	charts := processXMLCharts.Copy()
+
+	if !isValidValue(proc.UsedMemory) {
+		_ = charts.Remove(processMemoryUsageChartTmpl.ID)
+	}
+
+	c.addProcessChartsFromTemplates(charts, gpu, proc)
+}
+
+func (c *Collector) addProcessUtilizationCharts(gpu gpuInfo, proc gpuProcessInfo) {
+	c.addProcessChartsFromTemplates(processUtilizationXMLCharts.Copy(), gpu, proc)
+}
+
+func (c *Collector) addProcessChartsFromTemplates(charts *module.Charts, gpu gpuInfo, proc gpuProcessInfo) {
+	cgroup, containerID := c.processCgroup(proc.PID)
+	id := processID(proc)
+
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, strings.ToLower(gpu.UUID), id)
+		c.Labels = []module.Label{
+			{Key: "gpu_uuid", Value: gpu.UUID},
+			{Key: "gpu_product_name", Value: gpu.ProductName},
+			{Key: "pid", Value: proc.PID},
+			{Key: "process_name", Value: proc.ProcessName},
+			{Key: "type", Value: proc.Type},
+			{Key: "cgroup", Value: cgroup},
+			{Key: "container_id", Value: containerID},
+		}
+		if isValidValue(proc.GPUInstanceID) {
+			c.Labels = append(c.Labels, module.Label{Key: "gpu_instance_id", Value: proc.GPUInstanceID})
+		}
+		if isValidValue(proc.ComputeInstanceID) {
+			c.Labels = append(c.Labels, module.Label{Key: "compute_instance_id", Value: proc.ComputeInstanceID})
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, gpu.UUID, id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
 func (c *Collector) removeCharts(prefix string) {
 	prefix = strings.ToLower(prefix)
 
@@ -372,3 +605,12 @@ func (c *Collector) removeCharts(prefix string) {
 		}
 	}
 }
+
+func hasMetricWithPrefix(mx map[string]int64, prefix string) bool {
+	for k := range mx {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
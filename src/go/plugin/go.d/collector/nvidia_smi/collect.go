@@ -3,9 +3,11 @@
 package nvidia_smi
 
 import (
+	"cmp"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -39,6 +41,8 @@ func (c *Collector) collectGPUInfo(mx map[string]int64) error {
 
 	seenGPU := make(map[string]bool)
 	seenMIG := make(map[string]bool)
+	seenProcs := make(map[string]bool)
+	seenProcsUtil := make(map[string]bool)
 
 	for i, gpu := range info.GPUs {
 		if !isValidValue(gpu.UUID) {
@@ -89,6 +93,16 @@ func (c *Collector) collectGPUInfo(mx map[string]int64) error {
 			mx[px+"mig_current_mode_disabled"] = metrix.Bool(mode == "disabled")
 			mx[px+"mig_devices_count"] = int64(len(gpu.MIGDevices.MIGDevice))
 		}
+		addGPUClocksReasonsMetrics(mx, px, gpu)
+		addGPUECCErrorsMetrics(mx, px, gpu)
+		addMetric(mx, px+"retired_pages_single_bit", gpu.RetiredPages.MultipleSingleBitRetirement.RetiredCount, 0)
+		addMetric(mx, px+"retired_pages_double_bit", gpu.RetiredPages.DoubleBitRetirement.RetiredCount, 0)
+		addMetric(mx, px+"remapped_rows_correctable", gpu.RemappedRows.Correctable, 0)
+		addMetric(mx, px+"remapped_rows_uncorrectable", gpu.RemappedRows.Uncorrectable, 0)
+
+		if c.CollectProcesses {
+			c.collectGPUProcesses(mx, px, gpu, seenProcs, seenProcsUtil)
+		}
 
 		for _, mig := range gpu.MIGDevices.MIGDevice {
 			if !isValidValue(mig.GPUInstanceID) {
@@ -110,6 +124,13 @@ func (c *Collector) collectGPUInfo(mx map[string]int64) error {
 			addMetric(mx, px+"frame_buffer_memory_usage_reserved", mig.FBMemoryUsage.Reserved, 1024*1024) // MiB => bytes
 			addMetric(mx, px+"bar1_memory_usage_free", mig.BAR1MemoryUsage.Free, 1024*1024)               // MiB => bytes
 			addMetric(mx, px+"bar1_memory_usage_used", mig.BAR1MemoryUsage.Used, 1024*1024)               // MiB => bytes
+
+			mx[px+"processes"] = 0
+			for _, proc := range gpu.Processes.ProcessInfo {
+				if proc.GPUInstanceID == mig.GPUInstanceID {
+					mx[px+"processes"]++
+				}
+			}
 		}
 	}
 
@@ -127,9 +148,149 @@ func (c *Collector) collectGPUInfo(mx map[string]int64) error {
 		}
 	}
 
+	for px := range c.procs {
+		if !seenProcs[px] {
+			delete(c.procs, px)
+			delete(c.procsUtil, px)
+			c.removeCharts(px)
+		}
+	}
+	for px := range c.procsUtil {
+		if !seenProcsUtil[px] {
+			delete(c.procsUtil, px)
+			c.removeCharts(px + "utilization")
+		}
+	}
+
 	return nil
 }
 
+func (c *Collector) collectGPUProcesses(mx map[string]int64, gpuPx string, gpu gpuInfo, seen, seenUtil map[string]bool) {
+	accounted := make(map[string]gpuAccountedProcessInfo)
+	for _, proc := range gpu.AccountedProcesses.AccountedProcessInfo {
+		if proc.IsRunning == "1" {
+			accounted[proc.PID] = proc
+		}
+	}
+
+	for _, proc := range c.selectGPUProcesses(gpu.Processes.ProcessInfo) {
+		px := gpuPx + "process_" + processID(proc) + "_"
+
+		seen[px] = true
+
+		if !c.procs[px] {
+			c.procs[px] = true
+			c.addProcessCharts(gpu, proc)
+		}
+
+		addMetric(mx, px+"used_memory", proc.UsedMemory, 1024*1024) // MiB => bytes
+
+		acc, ok := accounted[proc.PID]
+		if !ok || !isValidValue(acc.GPUUtil) {
+			continue
+		}
+
+		seenUtil[px] = true
+
+		if !c.procsUtil[px] {
+			c.procsUtil[px] = true
+			c.addProcessUtilizationCharts(gpu, proc)
+		}
+
+		addMetric(mx, px+"gpu_utilization", acc.GPUUtil, 0)
+		addMetric(mx, px+"mem_utilization", acc.MemoryUtil, 0)
+	}
+}
+
+// processID returns the process key, the same PID can run on several MIG instances.
+func processID(proc gpuProcessInfo) string {
+	if !isValidValue(proc.GPUInstanceID) || !isValidValue(proc.ComputeInstanceID) {
+		return proc.PID
+	}
+	return "gi" + proc.GPUInstanceID + "_ci" + proc.ComputeInstanceID + "_" + proc.PID
+}
+
+// selectGPUProcesses applies the processes selector (matched against the process name) and keeps
+// the top N processes by used GPU memory.
+func (c *Collector) selectGPUProcesses(procs []gpuProcessInfo) []gpuProcessInfo {
+	procs = slices.DeleteFunc(slices.Clone(procs), func(p gpuProcessInfo) bool {
+		return !isValidValue(p.PID) || (c.procsSr != nil && !c.procsSr.MatchString(p.ProcessName))
+	})
+
+	if c.ProcessesTopN <= 0 || len(procs) <= c.ProcessesTopN {
+		return procs
+	}
+
+	slices.SortFunc(procs, func(a, b gpuProcessInfo) int {
+		if v := cmp.Compare(parseFloat(b.UsedMemory), parseFloat(a.UsedMemory)); v != 0 {
+			return v
+		}
+		return cmp.Compare(a.PID, b.PID)
+	})
+
+	return procs[:c.ProcessesTopN]
+}
+
+func addGPUClocksReasonsMetrics(mx map[string]int64, px string, gpu gpuInfo) {
+	reasons := gpu.ClocksEventReasons
+	if reasons == nil {
+		reasons = gpu.ClocksThrottleReasons
+	}
+	if reasons == nil || len(reasons.Reasons) == 0 {
+		return
+	}
+
+	for _, r := range gpuClocksReasons {
+		mx[px+"clocks_reason_"+r] = 0
+	}
+	for _, r := range reasons.Reasons {
+		// e.g. "clocks_throttle_reason_hw_slowdown" or "clocks_event_reason_hw_slowdown"
+		name := strings.TrimPrefix(r.XMLName.Local, "clocks_throttle_reason_")
+		name = strings.TrimPrefix(name, "clocks_event_reason_")
+		if _, ok := mx[px+"clocks_reason_"+name]; ok {
+			mx[px+"clocks_reason_"+name] = metrix.Bool(strings.TrimSpace(r.Value) == "Active")
+		}
+	}
+}
+
+func addGPUECCErrorsMetrics(mx map[string]int64, px string, gpu gpuInfo) {
+	for name, ecc := range map[string]gpuECCErrorsCount{
+		"volatile":  gpu.ECCErrors.Volatile,
+		"aggregate": gpu.ECCErrors.Aggregate,
+	} {
+		if corr, ok := sumValidValues(ecc.SingleBit.Total, ecc.SRAMCorrectable, ecc.DRAMCorrectable); ok {
+			mx[px+"ecc_errors_"+name+"_correctable"] = corr
+		}
+		uncorr, ok := sumValidValues(ecc.DoubleBit.Total, ecc.SRAMUncorrectable, ecc.DRAMUncorrectable)
+		if !isValidValue(ecc.SRAMUncorrectable) {
+			// driver 550+ splits SRAM uncorrectable errors by the detection mechanism
+			if v, ok2 := sumValidValues(ecc.SRAMUncorrectableParity, ecc.SRAMUncorrectableSECDED); ok2 {
+				uncorr, ok = uncorr+v, true
+			}
+		}
+		if ok {
+			mx[px+"ecc_errors_"+name+"_uncorrectable"] = uncorr
+		}
+	}
+}
+
+func sumValidValues(values ...string) (int64, bool) {
+	var sum int64
+	var found bool
+	for _, v := range values {
+		if !isValidValue(v) {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			continue
+		}
+		sum += n
+		found = true
+	}
+	return sum, found
+}
+
 func addGPUPowerMetricsSwitch(mx map[string]int64, px string, gpu gpuInfo) {
 	switch true {
 	case gpu.PowerReadings != nil && gpu.PowerReadings.PowerDraw != nil:
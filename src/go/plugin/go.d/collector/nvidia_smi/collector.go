@@ -6,9 +6,12 @@ import (
 	"context"
 	_ "embed"
 	"errors"
+	"fmt"
 	"runtime"
 	"time"
 
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 )
@@ -35,10 +38,13 @@ func New() *Collector {
 			// which can leave `nvidia_smi` processes running indefinitely.
 			LoopMode: !(runtime.GOOS == "windows"),
 		},
-		binName: "nvidia-smi",
-		charts:  &module.Charts{},
-		gpus:    make(map[string]bool),
-		migs:    make(map[string]bool),
+		binName:   "nvidia-smi",
+		procDir:   "/proc",
+		charts:    &module.Charts{},
+		gpus:      make(map[string]bool),
+		migs:      make(map[string]bool),
+		procs:     make(map[string]bool),
+		procsUtil: make(map[string]bool),
 	}
 
 }
@@ -48,6 +54,11 @@ type Config struct {
 	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
 	BinaryPath  string           `yaml:"binary_path" json:"binary_path"`
 	LoopMode    bool             `yaml:"loop_mode,omitempty" json:"loop_mode"`
+	// CollectProcesses enables per-process GPU memory and utilization charts.
+	CollectProcesses  bool   `yaml:"collect_processes_metrics,omitempty" json:"collect_processes_metrics"`
+	ProcessesSelector string `yaml:"processes_selector,omitempty" json:"processes_selector"`
+	ProcessesTopN     int    `yaml:"processes_top_n,omitempty" json:"processes_top_n"`
+	ProcDir           string `yaml:"proc_dir,omitempty" json:"proc_dir"`
 }
 
 type Collector struct {
@@ -59,8 +70,14 @@ type Collector struct {
 	exec    nvidiaSmiBinary
 	binName string
 
-	gpus map[string]bool
-	migs map[string]bool
+	// procDir is used to map GPU processes to their cgroups
+	procDir string
+	procsSr matcher.Matcher
+
+	gpus      map[string]bool
+	migs      map[string]bool
+	procs     map[string]bool
+	procsUtil map[string]bool
 }
 
 func (c *Collector) Configuration() any {
@@ -79,6 +96,14 @@ func (c *Collector) Init(context.Context) error {
 		c.exec = smi
 	}
 
+	c.procDir = c.initProcDir()
+
+	sr, err := c.initProcessesSelector()
+	if err != nil {
+		return fmt.Errorf("init processes selector: %v", err)
+	}
+	c.procsSr = sr
+
 	return nil
 }
 
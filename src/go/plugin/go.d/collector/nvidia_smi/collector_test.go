@@ -3,10 +3,12 @@
 package nvidia_smi
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
@@ -24,7 +26,8 @@ var (
 	dataXMLRTX3060, _          = os.ReadFile("testdata/rtx-3060.xml")
 	dataXMLTeslaP100, _        = os.ReadFile("testdata/tesla-p100.xml")
 
-	dataXMLA100SXM4MIG, _ = os.ReadFile("testdata/a100-sxm4-mig.xml")
+	dataXMLA100SXM4MIG, _          = os.ReadFile("testdata/a100-sxm4-mig.xml")
+	dataXMLA100SXM4MIGProcesses, _ = os.ReadFile("testdata/a100-sxm4-mig-processes.xml")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -36,6 +39,8 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataXMLRTX3060":          dataXMLRTX3060,
 		"dataXMLTeslaP100":        dataXMLTeslaP100,
 		"dataXMLA100SXM4MIG":      dataXMLA100SXM4MIG,
+
+		"dataXMLA100SXM4MIGProcesses": dataXMLA100SXM4MIGProcesses,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -179,12 +184,107 @@ func TestCollector_Collect(t *testing.T) {
 						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_free":     20916994048,
 						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_reserved": 0,
 						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_used":     19922944,
+
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_applications_clocks_setting": 0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_display_clocks_setting":      0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_gpu_idle":                    0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_hw_power_brake_slowdown":     0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_hw_slowdown":                 0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_hw_thermal_slowdown":         0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_sw_power_cap":                0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_sw_thermal_slowdown":         0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_sync_boost":                  0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_ecc_errors_aggregate_correctable":          0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_ecc_errors_aggregate_uncorrectable":        0,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_processes":                  0,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_processes":                  0,
 					}
 
 					assert.Equal(t, expected, mx)
 				},
 			},
 		},
+		"success A100-SXM4 MIG with processes": {
+			{
+				prepare: prepareCaseMIGA100Processes,
+				check: func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+
+					expected := map[string]int64{
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_bar1_memory_usage_free":                            68718428160,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_bar1_memory_usage_used":                            1048576,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_applications_clocks_setting":         0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_display_clocks_setting":              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_gpu_idle":                            0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_hw_power_brake_slowdown":             0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_hw_slowdown":                         0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_hw_thermal_slowdown":                 0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_sw_power_cap":                        1,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_sw_thermal_slowdown":                 0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_clocks_reason_sync_boost":                          0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_ecc_errors_aggregate_correctable":                  0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_ecc_errors_aggregate_uncorrectable":                0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_free":                    42273341440,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_reserved":                634388480,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_used":                    39845888,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_graphics_clock":                                    1410,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_mem_clock":                                         1215,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_mig_current_mode_disabled":                         0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_mig_current_mode_enabled":                          1,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_mig_devices_count":                                 2,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_pcie_bandwidth_usage_rx":                           0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_pcie_bandwidth_usage_tx":                           0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_pcie_bandwidth_utilization_rx":                     0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_pcie_bandwidth_utilization_tx":                     0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P0":                              1,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P1":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P10":                             0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P11":                             0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P12":                             0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P13":                             0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P14":                             0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P15":                             0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P2":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P3":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P4":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P5":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P6":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P7":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P8":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_performance_state_P9":                              0,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_power_draw":                                        66,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_process_gi1_ci0_41230_gpu_utilization":             87,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_process_gi1_ci0_41230_mem_utilization":             41,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_process_gi1_ci0_41230_used_memory":                 15741222912,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_process_gi2_ci0_41877_used_memory":                 9563013120,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_sm_clock":                                          1410,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_temperature":                                       36,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_video_clock":                                       1275,
+						"gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_voltage":                                           881,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_bar1_memory_usage_free":             34358689792,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_bar1_memory_usage_used":             0,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_ecc_error_sram_uncorrectable":       0,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_free":     20916994048,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_reserved": 0,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_used":     19922944,
+						"mig_instance_1_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_processes":                          1,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_bar1_memory_usage_free":             34358689792,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_bar1_memory_usage_used":             0,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_ecc_error_sram_uncorrectable":       0,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_free":     20916994048,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_reserved": 0,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_frame_buffer_memory_usage_used":     19922944,
+						"mig_instance_2_gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_processes":                          1,
+					}
+
+					assert.Equal(t, expected, mx)
+
+					chart := collr.Charts().Get("gpu_gpu-27b94a00-ed54-5c24-b1fd-1054085de32a_process_gi1_ci0_41230_memory_usage")
+					require.NotNil(t, chart)
+					assert.Contains(t, chart.Labels, module.Label{Key: "container_id", Value: "3f4e9c2b7a1d8e6f5c4b3a2918273645f6e7d8c9b0a1f2e3d4c5b6a7980123ab"})
+				},
+			},
+		},
 		"success RTX 4090 Driver 535": {
 			{
 				prepare: prepareCaseRTX4090Driver535,
@@ -229,6 +329,18 @@ func TestCollector_Collect(t *testing.T) {
 						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_temperature":                        40,
 						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_video_clock":                        1185,
 						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_voltage":                            880,
+
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_applications_clocks_setting": 0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_display_clocks_setting":      0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_gpu_idle":                    1,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_hw_power_brake_slowdown":     0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_hw_slowdown":                 0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_hw_thermal_slowdown":         0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_sw_power_cap":                0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_sw_thermal_slowdown":         0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_clocks_reason_sync_boost":                  0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_remapped_rows_correctable":                 0,
+						"gpu_GPU-71d1acc2-662d-2166-bf9f-65272d2fc437_remapped_rows_uncorrectable":               0,
 					}
 
 					assert.Equal(t, expected, mx)
@@ -278,6 +390,16 @@ func TestCollector_Collect(t *testing.T) {
 						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_temperature":                        45,
 						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_video_clock":                        555,
 						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_voltage":                            631,
+
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_applications_clocks_setting": 0,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_display_clocks_setting":      0,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_gpu_idle":                    1,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_hw_power_brake_slowdown":     0,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_hw_slowdown":                 0,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_hw_thermal_slowdown":         0,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_sw_power_cap":                0,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_sw_thermal_slowdown":         0,
+						"gpu_GPU-473d8d0f-d462-185c-6b36-6fc23e23e571_clocks_reason_sync_boost":                  0,
 					}
 
 					assert.Equal(t, expected, mx)
@@ -326,6 +448,22 @@ func TestCollector_Collect(t *testing.T) {
 						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_sm_clock":                           405,
 						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_temperature":                        38,
 						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_video_clock":                        835,
+
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_applications_clocks_setting": 0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_display_clocks_setting":      0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_gpu_idle":                    1,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_hw_power_brake_slowdown":     0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_hw_slowdown":                 0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_hw_thermal_slowdown":         0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_sw_power_cap":                0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_sw_thermal_slowdown":         0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_clocks_reason_sync_boost":                  0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_ecc_errors_aggregate_correctable":          3,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_ecc_errors_aggregate_uncorrectable":        0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_ecc_errors_volatile_correctable":           0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_ecc_errors_volatile_uncorrectable":         0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_retired_pages_double_bit":                  0,
+						"gpu_GPU-d3da8716-eaab-75db-efc1-60e88e1cd55e_retired_pages_single_bit":                  0,
 					}
 
 					assert.Equal(t, expected, mx)
@@ -375,6 +513,16 @@ func TestCollector_Collect(t *testing.T) {
 						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_sm_clock":                           193,
 						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_temperature":                        29,
 						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_video_clock":                        539,
+
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_applications_clocks_setting": 0,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_display_clocks_setting":      0,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_gpu_idle":                    1,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_hw_power_brake_slowdown":     0,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_hw_slowdown":                 0,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_hw_thermal_slowdown":         0,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_sw_power_cap":                0,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_sw_thermal_slowdown":         0,
+						"gpu_GPU-fbd55ed4-1eec-4423-0a47-ad594b4333e3_clocks_reason_sync_boost":                  0,
 					}
 
 					assert.Equal(t, expected, mx)
@@ -407,6 +555,76 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_ProcessesSelectorTopN(t *testing.T) {
+	const px = "gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_process_"
+
+	tests := map[string]struct {
+		selector  string
+		topN      int
+		wantProcs []string
+	}{
+		"all processes": {
+			wantProcs: []string{"gi1_ci0_41230", "gi2_ci0_41877"},
+		},
+		"top 1 by used memory": {
+			topN:      1,
+			wantProcs: []string{"gi1_ci0_41230"},
+		},
+		"selector": {
+			selector:  "!python3 *",
+			wantProcs: []string{"gi2_ci0_41877"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			prepareCaseMIGA100Processes(collr)
+			collr.ProcDir = collr.procDir
+			collr.ProcessesSelector = test.selector
+			collr.ProcessesTopN = test.topN
+			require.NoError(t, collr.Init(context.Background()))
+
+			mx := collr.Collect(context.Background())
+			require.NotNil(t, mx)
+
+			var procs []string
+			for k := range mx {
+				if pid, ok := strings.CutSuffix(strings.TrimPrefix(k, px), "_used_memory"); ok && strings.HasPrefix(k, px) {
+					procs = append(procs, pid)
+				}
+			}
+
+			assert.ElementsMatch(t, test.wantProcs, procs)
+			assert.Equal(t, "testdata/proc", collr.procDir)
+			module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+		})
+	}
+}
+
+func TestCollector_Collect_SamePIDOnSeveralMIGInstances(t *testing.T) {
+	collr := New()
+	prepareCaseMIGA100Processes(collr)
+	collr.exec = &mockNvidiaSmi{
+		gpuInfo: bytes.ReplaceAll(dataXMLA100SXM4MIGProcesses, []byte("<pid>41877</pid>"), []byte("<pid>41230</pid>")),
+	}
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	const px = "gpu_GPU-27b94a00-ed54-5c24-b1fd-1054085de32a_process_"
+	assert.Equal(t, int64(15741222912), mx[px+"gi1_ci0_41230_used_memory"])
+	assert.Equal(t, int64(9563013120), mx[px+"gi2_ci0_41230_used_memory"])
+
+	for _, id := range []string{"gi1_ci0_41230", "gi2_ci0_41230"} {
+		chart := collr.Charts().Get("gpu_gpu-27b94a00-ed54-5c24-b1fd-1054085de32a_process_" + id + "_memory_usage")
+		require.NotNilf(t, chart, "chart for %s", id)
+		assert.Contains(t, chart.Labels, module.Label{Key: "compute_instance_id", Value: "0"})
+	}
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
 type mockNvidiaSmi struct {
 	gpuInfo           []byte
 	errOnQueryGPUInfo bool
@@ -427,6 +645,12 @@ func prepareCaseMIGA100(collr *Collector) {
 	collr.exec = &mockNvidiaSmi{gpuInfo: dataXMLA100SXM4MIG}
 }
 
+func prepareCaseMIGA100Processes(collr *Collector) {
+	collr.CollectProcesses = true
+	collr.procDir = "testdata/proc"
+	collr.exec = &mockNvidiaSmi{gpuInfo: dataXMLA100SXM4MIGProcesses}
+}
+
 func prepareCaseRTX3060(collr *Collector) {
 	collr.exec = &mockNvidiaSmi{gpuInfo: dataXMLRTX3060}
 }
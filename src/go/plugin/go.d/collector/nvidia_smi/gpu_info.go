@@ -2,6 +2,8 @@
 
 package nvidia_smi
 
+import "encoding/xml"
+
 type gpusInfo struct {
 	GPUs []gpuInfo `xml:"gpu"`
 }
@@ -70,13 +72,68 @@ type (
 		Voltage          struct {
 			GraphicsVolt string `xml:"graphics_volt"`
 		} `xml:"voltage"`
+		ClocksThrottleReasons *gpuClocksReasonsInfo `xml:"clocks_throttle_reasons"`
+		ClocksEventReasons    *gpuClocksReasonsInfo `xml:"clocks_event_reasons"` // driver 535+
+		ECCErrors             struct {
+			Volatile  gpuECCErrorsCount `xml:"volatile"`
+			Aggregate gpuECCErrorsCount `xml:"aggregate"`
+		} `xml:"ecc_errors"`
+		RetiredPages struct {
+			MultipleSingleBitRetirement struct {
+				RetiredCount string `xml:"retired_count"`
+			} `xml:"multiple_single_bit_retirement"`
+			DoubleBitRetirement struct {
+				RetiredCount string `xml:"retired_count"`
+			} `xml:"double_bit_retirement"`
+		} `xml:"retired_pages"`
+		RemappedRows struct {
+			Correctable   string `xml:"remapped_row_corr"`
+			Uncorrectable string `xml:"remapped_row_unc"`
+		} `xml:"remapped_rows"`
 		Processes struct {
-			ProcessInfo []struct {
-				PID         string `xml:"pid"`
-				ProcessName string `xml:"process_name"`
-				UsedMemory  string `xml:"used_memory"`
-			} `sml:"process_info"`
+			ProcessInfo []gpuProcessInfo `xml:"process_info"`
 		} `xml:"processes"`
+		AccountedProcesses struct {
+			AccountedProcessInfo []gpuAccountedProcessInfo `xml:"accounted_process_info"`
+		} `xml:"accounted_processes"`
+	}
+	gpuClocksReasonsInfo struct {
+		Reasons []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	}
+	gpuECCErrorsCount struct {
+		// before Ampere
+		SingleBit struct {
+			Total string `xml:"total"`
+		} `xml:"single_bit"`
+		DoubleBit struct {
+			Total string `xml:"total"`
+		} `xml:"double_bit"`
+		// Ampere and later
+		SRAMCorrectable         string `xml:"sram_correctable"`
+		SRAMUncorrectable       string `xml:"sram_uncorrectable"`
+		SRAMUncorrectableParity string `xml:"sram_uncorrectable_parity"`
+		SRAMUncorrectableSECDED string `xml:"sram_uncorrectable_secded"`
+		DRAMCorrectable         string `xml:"dram_correctable"`
+		DRAMUncorrectable       string `xml:"dram_uncorrectable"`
+	}
+	gpuProcessInfo struct {
+		GPUInstanceID     string `xml:"gpu_instance_id"`
+		ComputeInstanceID string `xml:"compute_instance_id"`
+		PID               string `xml:"pid"`
+		Type              string `xml:"type"`
+		ProcessName       string `xml:"process_name"`
+		UsedMemory        string `xml:"used_memory"`
+	}
+	// available only if accounting mode is enabled ('nvidia-smi --accounting-mode=1')
+	gpuAccountedProcessInfo struct {
+		PID            string `xml:"pid"`
+		GPUUtil        string `xml:"gpu_util"`
+		MemoryUtil     string `xml:"memory_util"`
+		MaxMemoryUsage string `xml:"max_memory_usage"`
+		IsRunning      string `xml:"is_running"`
 	}
 	gpuPowerReadings struct {
 		//PowerState         string `xml:"power_state"`
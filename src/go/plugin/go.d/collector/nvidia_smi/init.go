@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 )
 
 func (c *Collector) initNvidiaSmiExec() (nvidiaSmiBinary, error) {
@@ -20,3 +22,21 @@ func (c *Collector) initNvidiaSmiExec() (nvidiaSmiBinary, error) {
 
 	return newNvidiaSmiBinary(binPath, c.Config, c.Logger)
 }
+
+func (c *Collector) initProcDir() string {
+	if c.ProcDir != "" {
+		return c.ProcDir
+	}
+	// Netdata Docker image mounts the host's /proc at /host/proc
+	if _, err := os.Stat("/host/proc"); err == nil {
+		return "/host/proc"
+	}
+	return "/proc"
+}
+
+func (c *Collector) initProcessesSelector() (matcher.Matcher, error) {
+	if c.ProcessesSelector == "" {
+		return matcher.TRUE(), nil
+	}
+	return matcher.NewSimplePatternsMatcher(c.ProcessesSelector)
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nvidia_smi
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var reContainerID = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// processCgroup returns the cgroup path of a process and, if the process runs in a container,
+// the container ID (Docker, containerd, CRI-O and Podman all use 64 hex characters IDs).
+func (c *Collector) processCgroup(pid string) (cgroup, containerID string) {
+	bs, err := os.ReadFile(filepath.Join(c.procDir, pid, "cgroup"))
+	if err != nil {
+		return "", ""
+	}
+
+	cgroup = parseProcCgroup(bs)
+
+	if ids := reContainerID.FindAllString(cgroup, -1); len(ids) > 0 {
+		containerID = ids[len(ids)-1]
+	}
+
+	return cgroup, containerID
+}
+
+func parseProcCgroup(bs []byte) string {
+	/*
+	   cgroup v2:
+	   0::/system.slice/docker-0123...cdef.scope
+
+	   cgroup v1:
+	   12:memory:/kubepods/burstable/pod1234/0123...cdef
+	   1:name=systemd:/kubepods/burstable/pod1234/0123...cdef
+	*/
+
+	var unified, systemd, memory string
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		parts := strings.SplitN(sc.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			unified = parts[2]
+		case parts[1] == "name=systemd":
+			systemd = parts[2]
+		case strings.Contains(","+parts[1]+",", ",memory,"):
+			memory = parts[2]
+		}
+	}
+
+	// hybrid hierarchy: v1 controllers take precedence over the (mostly empty) unified one
+	switch {
+	case memory != "":
+		return memory
+	case unified != "":
+		return unified
+	default:
+		return systemd
+	}
+}
@@ -4,6 +4,7 @@ package ping
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
@@ -12,6 +13,9 @@ import (
 const (
 	prioHostRTT = module.Priority + iota
 	prioHostStdDevRTT
+	prioHostRTTJitter
+	prioHostRTTHistogram
+	prioHostMOS
 	prioHostPingPacketLoss
 	prioHostPingPackets
 )
@@ -19,6 +23,7 @@ const (
 var hostChartsTmpl = module.Charts{
 	hostRTTChartTmpl.Copy(),
 	hostStdDevRTTChartTmpl.Copy(),
+	hostRTTJitterChartTmpl.Copy(),
 	hostPacketLossChartTmpl.Copy(),
 	hostPacketsChartTmpl.Copy(),
 }
@@ -49,8 +54,39 @@ var (
 			{ID: "host_%s_std_dev_rtt", Name: "std_dev", Div: 1e3},
 		},
 	}
+	hostRTTJitterChartTmpl = module.Chart{
+		ID:       "host_%s_rtt_jitter",
+		Title:    "Ping round-trip time jitter",
+		Units:    "milliseconds",
+		Fam:      "latency",
+		Ctx:      "ping.host_rtt_jitter",
+		Priority: prioHostRTTJitter,
+		Dims: module.Dims{
+			{ID: "host_%s_rtt_jitter", Name: "jitter", Div: 1e3},
+		},
+	}
+	hostRTTHistogramChartTmpl = module.Chart{
+		ID:       "host_%s_rtt_histogram",
+		Title:    "Ping round-trip time histogram",
+		Units:    "packets/s",
+		Fam:      "latency",
+		Ctx:      "ping.host_rtt_histogram",
+		Priority: prioHostRTTHistogram,
+	}
 )
 
+var hostMOSChartTmpl = module.Chart{
+	ID:       "host_%s_mos",
+	Title:    "Ping estimated Mean Opinion Score",
+	Units:    "score",
+	Fam:      "quality",
+	Ctx:      "ping.host_mos",
+	Priority: prioHostMOS,
+	Dims: module.Dims{
+		{ID: "host_%s_mos", Name: "mos", Div: 100},
+	},
+}
+
 var hostPacketLossChartTmpl = module.Chart{
 	ID:       "host_%s_packet_loss",
 	Title:    "Ping packet loss",
@@ -76,13 +112,35 @@ var hostPacketsChartTmpl = module.Chart{
 	},
 }
 
-func newHostCharts(host string) *module.Charts {
+func (c *Collector) newHostCharts(host string) *module.Charts {
 	charts := hostChartsTmpl.Copy()
 
+	if len(c.RTTHistogram) > 0 {
+		chart := hostRTTHistogramChartTmpl.Copy()
+		for i, v := range c.RTTHistogram {
+			chart.Dims = append(chart.Dims, &module.Dim{
+				ID:   fmt.Sprintf("host_%%s_rtt_hist_bucket_%d", i+1),
+				Name: strconv.FormatFloat(v, 'f', -1, 64),
+				Algo: module.Incremental,
+			})
+		}
+		chart.Dims = append(chart.Dims, &module.Dim{
+			ID:   "host_%s_rtt_hist_count",
+			Name: "+Inf",
+			Algo: module.Incremental,
+		})
+		_ = charts.Add(chart)
+	}
+	if c.MOS {
+		_ = charts.Add(hostMOSChartTmpl.Copy())
+	}
+
 	for _, chart := range *charts {
 		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(host, ".", "_"))
 		chart.Labels = []module.Label{
 			{Key: "host", Value: host},
+			{Key: "dscp", Value: strconv.Itoa(int(c.trafficClass() >> 2))},
+			{Key: "payload_size", Value: strconv.Itoa(c.payloadSize())},
 		}
 		for _, dim := range chart.Dims {
 			dim.ID = fmt.Sprintf(dim.ID, host)
@@ -93,7 +151,7 @@ func newHostCharts(host string) *module.Charts {
 }
 
 func (c *Collector) addHostCharts(host string) {
-	charts := newHostCharts(host)
+	charts := c.newHostCharts(host)
 
 	if err := c.Charts().Add(*charts...); err != nil {
 		c.Warning(err)
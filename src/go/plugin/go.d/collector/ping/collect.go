@@ -4,9 +4,23 @@ package ping
 
 import (
 	"fmt"
+	"math"
 	"sync"
+	"time"
+
+	probing "github.com/prometheus-community/pro-bing"
 )
 
+// hostStats holds the state kept between data collections for a host.
+type hostStats struct {
+	hasPrevRTT bool
+	prevRTT    time.Duration
+	jitter     float64 // microseconds
+
+	histBuckets []int64 // cumulative
+	histCount   int64
+}
+
 func (c *Collector) collect() (map[string]int64, error) {
 	mu := &sync.Mutex{}
 	mx := make(map[string]int64)
@@ -31,11 +45,15 @@ func (c *Collector) pingHost(host string, mx map[string]int64, mu *sync.Mutex) {
 	mu.Lock()
 	defer mu.Unlock()
 
-	if !c.hosts[host] {
-		c.hosts[host] = true
+	hs, ok := c.hosts[host]
+	if !ok {
+		hs = &hostStats{histBuckets: make([]int64, len(c.RTTHistogram))}
+		c.hosts[host] = hs
 		c.addHostCharts(host)
 	}
 
+	hs.update(stats.Rtts, c.RTTHistogram)
+
 	px := fmt.Sprintf("host_%s_", host)
 	if stats.PacketsRecv != 0 {
 		mx[px+"min_rtt"] = stats.MinRtt.Microseconds()
@@ -46,4 +64,61 @@ func (c *Collector) pingHost(host string, mx map[string]int64, mu *sync.Mutex) {
 	mx[px+"packets_recv"] = int64(stats.PacketsRecv)
 	mx[px+"packets_sent"] = int64(stats.PacketsSent)
 	mx[px+"packet_loss"] = int64(stats.PacketLoss * 1000)
+
+	if hs.hasPrevRTT {
+		mx[px+"rtt_jitter"] = int64(hs.jitter)
+	}
+
+	if len(c.RTTHistogram) > 0 {
+		for i, v := range hs.histBuckets {
+			mx[fmt.Sprintf("%srtt_hist_bucket_%d", px, i+1)] = v
+		}
+		mx[px+"rtt_hist_count"] = hs.histCount
+	}
+
+	if c.MOS {
+		mx[px+"mos"] = int64(estimateMOS(stats, hs.jitter) * 100)
+	}
+}
+
+func (hs *hostStats) update(rtts []time.Duration, buckets []float64) {
+	for _, rtt := range rtts {
+		// RFC 3550 (6.4.1) interarrival jitter estimator applied to consecutive round-trip times.
+		if hs.hasPrevRTT {
+			d := math.Abs(float64((rtt - hs.prevRTT).Microseconds()))
+			hs.jitter += (d - hs.jitter) / 16
+		}
+		hs.prevRTT, hs.hasPrevRTT = rtt, true
+
+		ms := float64(rtt.Microseconds()) / 1e3
+		for i, upper := range buckets {
+			if ms <= upper {
+				hs.histBuckets[i]++
+			}
+		}
+		hs.histCount++
+	}
+}
+
+// estimateMOS calculates the Mean Opinion Score (1-4.5) using a simplified ITU-T G.107 E-model.
+func estimateMOS(stats *probing.Statistics, jitter float64) float64 {
+	if stats.PacketsRecv == 0 {
+		return 1
+	}
+
+	// effective latency in milliseconds: latency + jitter impact + codec delay
+	latency := float64(stats.AvgRtt.Microseconds())/1e3 + 2*jitter/1e3 + 10
+
+	var r float64
+	if latency < 160 {
+		r = 93.2 - latency/40
+	} else {
+		r = 93.2 - (latency-120)/10
+	}
+	r -= 2.5 * stats.PacketLoss
+	r = max(0, min(100, r))
+
+	mos := 1 + 0.035*r + 0.000007*r*(r-60)*(100-r)
+
+	return max(1, min(4.5, mos))
 }
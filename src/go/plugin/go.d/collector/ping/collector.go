@@ -38,20 +38,25 @@ func New() *Collector {
 		},
 
 		charts:    &module.Charts{},
-		hosts:     make(map[string]bool),
+		hosts:     make(map[string]*hostStats),
 		newProber: newPingProber,
 	}
 }
 
 type Config struct {
-	Vnode       string           `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
-	Hosts       []string         `yaml:"hosts" json:"hosts"`
-	Network     string           `yaml:"network,omitempty" json:"network"`
-	Privileged  bool             `yaml:"privileged" json:"privileged"`
-	SendPackets int              `yaml:"packets,omitempty" json:"packets"`
-	Interval    confopt.Duration `yaml:"interval,omitempty" json:"interval"`
-	Interface   string           `yaml:"interface,omitempty" json:"interface"`
+	Vnode        string           `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery  int              `yaml:"update_every,omitempty" json:"update_every"`
+	Hosts        []string         `yaml:"hosts" json:"hosts"`
+	Network      string           `yaml:"network,omitempty" json:"network"`
+	Privileged   bool             `yaml:"privileged" json:"privileged"`
+	SendPackets  int              `yaml:"packets,omitempty" json:"packets"`
+	Interval     confopt.Duration `yaml:"interval,omitempty" json:"interval"`
+	Interface    string           `yaml:"interface,omitempty" json:"interface"`
+	PayloadSize  int              `yaml:"payload_size,omitempty" json:"payload_size"`
+	TOS          int              `yaml:"tos,omitempty" json:"tos"`
+	DSCP         int              `yaml:"dscp,omitempty" json:"dscp"`
+	RTTHistogram []float64        `yaml:"rtt_histogram,omitempty" json:"rtt_histogram"`
+	MOS          bool             `yaml:"mos" json:"mos"`
 }
 
 type Collector struct {
@@ -63,7 +68,7 @@ type Collector struct {
 	prober    prober
 	newProber func(pingProberConfig, *logger.Logger) prober
 
-	hosts map[string]bool
+	hosts map[string]*hostStats
 }
 
 func (c *Collector) Configuration() any {
@@ -51,6 +51,50 @@ func TestCollector_Init(t *testing.T) {
 				Hosts:       []string{"192.0.2.0"},
 			},
 		},
+		"success with QoS and histogram options": {
+			wantFail: false,
+			config: Config{
+				SendPackets:  1,
+				Hosts:        []string{"192.0.2.0"},
+				PayloadSize:  1400,
+				DSCP:         46,
+				RTTHistogram: []float64{1, 5, 10},
+				MOS:          true,
+			},
+		},
+		"fail when 'payload_size' is too small": {
+			wantFail: true,
+			config: Config{
+				SendPackets: 1,
+				Hosts:       []string{"192.0.2.0"},
+				PayloadSize: 8,
+			},
+		},
+		"fail when 'dscp' is out of range": {
+			wantFail: true,
+			config: Config{
+				SendPackets: 1,
+				Hosts:       []string{"192.0.2.0"},
+				DSCP:        64,
+			},
+		},
+		"fail when both 'tos' and 'dscp' set": {
+			wantFail: true,
+			config: Config{
+				SendPackets: 1,
+				Hosts:       []string{"192.0.2.0"},
+				TOS:         184,
+				DSCP:        46,
+			},
+		},
+		"fail when 'rtt_histogram' is not sorted": {
+			wantFail: true,
+			config: Config{
+				SendPackets:  1,
+				Hosts:        []string{"192.0.2.0"},
+				RTTHistogram: []float64{10, 5},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -137,6 +181,25 @@ func TestCollector_Collect(t *testing.T) {
 			},
 			wantNumCharts: 3 * len(hostChartsTmpl),
 		},
+		"success with RTT histogram and MOS": {
+			prepare: casePingSuccessWithRTTs,
+			wantMetrics: map[string]int64{
+				"host_192.0.2.1_avg_rtt":           15000,
+				"host_192.0.2.1_max_rtt":           20000,
+				"host_192.0.2.1_min_rtt":           10000,
+				"host_192.0.2.1_mos":               439,
+				"host_192.0.2.1_packet_loss":       0,
+				"host_192.0.2.1_packets_recv":      5,
+				"host_192.0.2.1_packets_sent":      5,
+				"host_192.0.2.1_rtt_hist_bucket_1": 2,
+				"host_192.0.2.1_rtt_hist_bucket_2": 3,
+				"host_192.0.2.1_rtt_hist_bucket_3": 5,
+				"host_192.0.2.1_rtt_hist_count":    5,
+				"host_192.0.2.1_rtt_jitter":        1962,
+				"host_192.0.2.1_std_dev_rtt":       5000,
+			},
+			wantNumCharts: len(hostChartsTmpl) + 2,
+		},
 		"fail when ping returns an error": {
 			prepare:       casePingError,
 			wantMetrics:   nil,
@@ -159,6 +222,40 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestEstimateMOS(t *testing.T) {
+	tests := map[string]struct {
+		stats   probing.Statistics
+		jitter  float64
+		wantMin float64
+		wantMax float64
+	}{
+		"good path": {
+			stats:   probing.Statistics{PacketsRecv: 5, AvgRtt: time.Millisecond * 20},
+			wantMin: 4.3, wantMax: 4.5,
+		},
+		"high latency": {
+			stats:   probing.Statistics{PacketsRecv: 5, AvgRtt: time.Millisecond * 400},
+			wantMin: 2.5, wantMax: 3.5,
+		},
+		"packet loss": {
+			stats:   probing.Statistics{PacketsRecv: 4, PacketLoss: 20, AvgRtt: time.Millisecond * 20},
+			wantMin: 1, wantMax: 2.5,
+		},
+		"no packets received": {
+			stats:   probing.Statistics{PacketsRecv: 0, PacketLoss: 100},
+			wantMin: 1, wantMax: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mos := estimateMOS(&test.stats, test.jitter)
+			assert.GreaterOrEqual(t, mos, test.wantMin)
+			assert.LessOrEqual(t, mos, test.wantMax)
+		})
+	}
+}
+
 func casePingSuccess(t *testing.T) *Collector {
 	collr := New()
 	collr.UpdateEvery = 1
@@ -170,6 +267,20 @@ func casePingSuccess(t *testing.T) *Collector {
 	return collr
 }
 
+func casePingSuccessWithRTTs(t *testing.T) *Collector {
+	collr := New()
+	collr.UpdateEvery = 1
+	collr.Hosts = []string{"192.0.2.1"}
+	collr.RTTHistogram = []float64{10, 15, 50}
+	collr.MOS = true
+	collr.newProber = func(_ pingProberConfig, _ *logger.Logger) prober {
+		ms := time.Millisecond
+		return &mockProber{rtts: []time.Duration{10 * ms, 20 * ms, 10 * ms, 20 * ms, 15 * ms}}
+	}
+	require.NoError(t, collr.Init(context.Background()))
+	return collr
+}
+
 func casePingError(t *testing.T) *Collector {
 	collr := New()
 	collr.UpdateEvery = 1
@@ -183,6 +294,7 @@ func casePingError(t *testing.T) *Collector {
 
 type mockProber struct {
 	errOnPing bool
+	rtts      []time.Duration
 }
 
 func (m *mockProber) ping(host string) (*probing.Statistics, error) {
@@ -196,7 +308,7 @@ func (m *mockProber) ping(host string) (*probing.Statistics, error) {
 		PacketsRecvDuplicates: 0,
 		PacketLoss:            0,
 		Addr:                  host,
-		Rtts:                  nil,
+		Rtts:                  m.rtts,
 		MinRtt:                time.Millisecond * 10,
 		MaxRtt:                time.Millisecond * 20,
 		AvgRtt:                time.Millisecond * 15,
@@ -4,9 +4,16 @@ package ping
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 )
 
+const (
+	minPayloadSize = 24 // timestamp and tracker UUID
+	maxPayloadSize = 65500
+)
+
 func (c *Collector) validateConfig() error {
 	if len(c.Hosts) == 0 {
 		return errors.New("'hosts' can't be empty")
@@ -14,9 +21,44 @@ func (c *Collector) validateConfig() error {
 	if c.SendPackets <= 0 {
 		return errors.New("'send_packets' can't be <= 0")
 	}
+	if c.PayloadSize != 0 && (c.PayloadSize < minPayloadSize || c.PayloadSize > maxPayloadSize) {
+		return fmt.Errorf("'payload_size' must be between %d and %d", minPayloadSize, maxPayloadSize)
+	}
+	if c.TOS < 0 || c.TOS > 255 {
+		return errors.New("'tos' must be between 0 and 255")
+	}
+	if c.DSCP < 0 || c.DSCP > 63 {
+		return errors.New("'dscp' must be between 0 and 63")
+	}
+	if c.TOS != 0 && c.DSCP != 0 {
+		return errors.New("'tos' and 'dscp' are mutually exclusive")
+	}
+	for _, v := range c.RTTHistogram {
+		if v <= 0 {
+			return errors.New("'rtt_histogram' buckets must be positive")
+		}
+	}
+	if !slices.IsSorted(c.RTTHistogram) || len(slices.Compact(slices.Clone(c.RTTHistogram))) != len(c.RTTHistogram) {
+		return errors.New("'rtt_histogram' buckets must be in increasing order")
+	}
 	return nil
 }
 
+// trafficClass returns the value of the IPv4 TOS / IPv6 traffic class field.
+func (c *Collector) trafficClass() uint8 {
+	if c.DSCP != 0 {
+		return uint8(c.DSCP << 2)
+	}
+	return uint8(c.TOS)
+}
+
+func (c *Collector) payloadSize() int {
+	if c.PayloadSize == 0 {
+		return minPayloadSize
+	}
+	return c.PayloadSize
+}
+
 func (c *Collector) initProber() (prober, error) {
 	mul := 0.9
 	if c.UpdateEvery > 1 {
@@ -33,6 +75,8 @@ func (c *Collector) initProber() (prober, error) {
 		ifaceName:  c.Interface,
 		interval:   c.Interval.Duration(),
 		deadline:   deadline,
+		size:       c.payloadSize(),
+		tclass:     c.trafficClass(),
 	}
 
 	return c.newProber(conf, c.Logger), nil
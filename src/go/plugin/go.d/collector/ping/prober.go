@@ -23,6 +23,8 @@ func newPingProber(conf pingProberConfig, log *logger.Logger) prober {
 		packets:       conf.packets,
 		interval:      conf.interval,
 		deadline:      conf.deadline,
+		size:          conf.size,
+		tclass:        conf.tclass,
 		Logger:        log,
 	}
 }
@@ -34,6 +36,8 @@ type pingProberConfig struct {
 	packets    int
 	interval   time.Duration
 	deadline   time.Duration
+	size       int
+	tclass     uint8
 }
 
 type pingProber struct {
@@ -45,6 +49,8 @@ type pingProber struct {
 	packets       int
 	interval      time.Duration
 	deadline      time.Duration
+	size          int
+	tclass        uint8
 }
 
 func (p *pingProber) ping(host string) (*probing.Statistics, error) {
@@ -56,7 +62,9 @@ func (p *pingProber) ping(host string) (*probing.Statistics, error) {
 		return nil, fmt.Errorf("DNS lookup '%s' : %v", host, err)
 	}
 
-	pr.RecordRtts = false
+	pr.RecordRtts = true
+	pr.Size = p.size
+	pr.SetTrafficClass(p.tclass)
 	pr.Interval = p.interval
 	pr.Count = p.packets
 	pr.Timeout = p.deadline
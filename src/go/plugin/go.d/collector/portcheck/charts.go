@@ -13,6 +13,13 @@ const (
 	prioCheckStatus = module.Priority + iota
 	prioCheckInStatusDuration
 	prioCheckLatency
+	prioCheckLatencyPercentiles
+	prioCheckConnectAttempts
+	prioTLSCheckStatus
+	prioTLSHandshakeTime
+	prioTLSCertTimeUntilExpiration
+	prioBannerCheckStatus
+	prioBannerResponseTime
 
 	prioUDPCheckStatus
 	prioUDPCheckInStatusDuration
@@ -24,6 +31,22 @@ var tcpPortChartsTmpl = module.Charts{
 	tcpPortCheckConnectionLatencyChartTmpl.Copy(),
 }
 
+var tcpPortAttemptsChartsTmpl = module.Charts{
+	tcpPortCheckLatencyPercentilesChartTmpl.Copy(),
+	tcpPortCheckConnectAttemptsChartTmpl.Copy(),
+}
+
+var tcpPortTLSChartsTmpl = module.Charts{
+	tcpPortTLSCheckStatusChartTmpl.Copy(),
+	tcpPortTLSHandshakeTimeChartTmpl.Copy(),
+	tcpPortTLSCertTimeUntilExpirationChartTmpl.Copy(),
+}
+
+var tcpPortBannerChartsTmpl = module.Charts{
+	tcpPortBannerCheckStatusChartTmpl.Copy(),
+	tcpPortBannerResponseTimeChartTmpl.Copy(),
+}
+
 var udpPortChartsTmpl = module.Charts{
 	udpPortCheckStatusChartTmpl.Copy(),
 	udpPortCheckInStatusDurationChartTmpl.Copy(),
@@ -67,6 +90,99 @@ var (
 	}
 )
 
+var (
+	tcpPortCheckLatencyPercentilesChartTmpl = module.Chart{
+		ID:       "port_%d_connection_latency_percentiles",
+		Title:    "TCP Connection Latency Percentiles",
+		Units:    "ms",
+		Fam:      "latency",
+		Ctx:      "portcheck.latency_percentiles",
+		Priority: prioCheckLatencyPercentiles,
+		Dims: module.Dims{
+			{ID: "tcp_port_%d_latency_p50", Name: "p50", Div: 1000},
+			{ID: "tcp_port_%d_latency_p90", Name: "p90", Div: 1000},
+			{ID: "tcp_port_%d_latency_p99", Name: "p99", Div: 1000},
+		},
+	}
+	tcpPortCheckConnectAttemptsChartTmpl = module.Chart{
+		ID:       "port_%d_connect_attempts",
+		Title:    "TCP Connection Attempts",
+		Units:    "attempts",
+		Fam:      "status",
+		Ctx:      "portcheck.connect_attempts",
+		Type:     module.Stacked,
+		Priority: prioCheckConnectAttempts,
+		Dims: module.Dims{
+			{ID: "tcp_port_%d_connect_attempts_success", Name: "success"},
+			{ID: "tcp_port_%d_connect_attempts_failed", Name: "failed"},
+		},
+	}
+)
+
+var (
+	tcpPortTLSCheckStatusChartTmpl = module.Chart{
+		ID:       "port_%d_tls_status",
+		Title:    "TLS Handshake Check Status",
+		Units:    "status",
+		Fam:      "tls",
+		Ctx:      "portcheck.tls_status",
+		Priority: prioTLSCheckStatus,
+		Dims: module.Dims{
+			{ID: "tcp_port_%d_tls_success", Name: "success"},
+			{ID: "tcp_port_%d_tls_failed", Name: "failed"},
+		},
+	}
+	tcpPortTLSHandshakeTimeChartTmpl = module.Chart{
+		ID:       "port_%d_tls_handshake_time",
+		Title:    "TLS Handshake Time",
+		Units:    "ms",
+		Fam:      "tls",
+		Ctx:      "portcheck.tls_handshake_time",
+		Priority: prioTLSHandshakeTime,
+		Dims: module.Dims{
+			{ID: "tcp_port_%d_tls_handshake_time", Name: "time", Div: 1000},
+		},
+	}
+	tcpPortTLSCertTimeUntilExpirationChartTmpl = module.Chart{
+		ID:       "port_%d_tls_cert_time_until_expiration",
+		Title:    "TLS Certificate Time Until Expiration",
+		Units:    "seconds",
+		Fam:      "tls",
+		Ctx:      "portcheck.tls_cert_time_until_expiration",
+		Priority: prioTLSCertTimeUntilExpiration,
+		Dims: module.Dims{
+			{ID: "tcp_port_%d_tls_cert_time_until_expiration", Name: "expiry"},
+		},
+	}
+)
+
+var (
+	tcpPortBannerCheckStatusChartTmpl = module.Chart{
+		ID:       "port_%d_banner_status",
+		Title:    "Banner Check Status",
+		Units:    "status",
+		Fam:      "banner",
+		Ctx:      "portcheck.banner_status",
+		Priority: prioBannerCheckStatus,
+		Dims: module.Dims{
+			{ID: "tcp_port_%d_banner_match", Name: "match"},
+			{ID: "tcp_port_%d_banner_no_match", Name: "no_match"},
+			{ID: "tcp_port_%d_banner_error", Name: "error"},
+		},
+	}
+	tcpPortBannerResponseTimeChartTmpl = module.Chart{
+		ID:       "port_%d_banner_response_time",
+		Title:    "Banner Response Time",
+		Units:    "ms",
+		Fam:      "banner",
+		Ctx:      "portcheck.banner_response_time",
+		Priority: prioBannerResponseTime,
+		Dims: module.Dims{
+			{ID: "tcp_port_%d_banner_response_time", Name: "time", Div: 1000},
+		},
+	}
+)
+
 var (
 	udpPortCheckStatusChartTmpl = module.Chart{
 		ID:       "udp_port_%d_check_status",
@@ -94,7 +210,19 @@ var (
 )
 
 func (c *Collector) addTCPPortCharts(port *tcpPort) {
-	charts := newPortCharts(c.Host, port.number, tcpPortChartsTmpl.Copy())
+	charts := tcpPortChartsTmpl.Copy()
+
+	if c.ConnectAttempts > 1 {
+		_ = charts.Add(*tcpPortAttemptsChartsTmpl.Copy()...)
+	}
+	if port.probe != nil && port.probe.tls {
+		_ = charts.Add(*tcpPortTLSChartsTmpl.Copy()...)
+	}
+	if port.probe != nil && port.probe.expect != nil {
+		_ = charts.Add(*tcpPortBannerChartsTmpl.Copy()...)
+	}
+
+	charts = newPortCharts(c.Host, port.number, charts)
 
 	if err := c.Charts().Add(*charts...); err != nil {
 		c.Warning(err)
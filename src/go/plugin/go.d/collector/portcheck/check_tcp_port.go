@@ -3,6 +3,8 @@
 package portcheck
 
 import (
+	"math"
+	"slices"
 	"time"
 )
 
@@ -17,9 +19,47 @@ type tcpPort struct {
 	status         string
 	statusChangeTs time.Time
 	latency        int
+
+	probe *tcpPortProbe
+
+	// results of the last check
+	attemptsFailed int
+	connectTimes   []time.Duration
+	tls            *tlsProbeResult
+	banner         *bannerProbeResult
 }
 
 func (c *Collector) checkTCPPort(port *tcpPort) {
+	port.attemptsFailed = 0
+	port.connectTimes = port.connectTimes[:0]
+	port.tls, port.banner = nil, nil
+
+	// the port is considered available if any of the attempts succeeded
+	var state string
+
+	for i := 0; i < max(c.ConnectAttempts, 1); i++ {
+		// probes run on the first established connection only
+		st := c.connectTCPPort(port, port.probe != nil && len(port.connectTimes) == 0)
+		if st != tcpPortCheckStateSuccess {
+			port.attemptsFailed++
+		}
+		if state != tcpPortCheckStateSuccess {
+			state = st
+		}
+		// don't wait for more timeouts within the same interval
+		if st == tcpPortCheckStateTimeout {
+			break
+		}
+	}
+
+	c.setTcpPortCheckState(port, state)
+
+	if len(port.connectTimes) > 0 {
+		port.latency = durationToMs(percentile(port.connectTimes, 0.5))
+	}
+}
+
+func (c *Collector) connectTCPPort(port *tcpPort, doProbe bool) string {
 	start := time.Now()
 
 	addr := c.address(port.number)
@@ -35,15 +75,21 @@ func (c *Collector) checkTCPPort(port *tcpPort) {
 
 	if err != nil {
 		if v, ok := err.(interface{ Timeout() bool }); ok && v.Timeout() {
-			c.setTcpPortCheckState(port, tcpPortCheckStateTimeout)
-		} else {
-			c.setTcpPortCheckState(port, tcpPortCheckStateFailed)
+			return tcpPortCheckStateTimeout
 		}
-		return
+		return tcpPortCheckStateFailed
+	}
+
+	port.connectTimes = append(port.connectTimes, dur)
+
+	if doProbe {
+		c.probeTCPPort(port, conn)
+	} else if port.probe != nil && port.probe.proxyProtocol != "" {
+		_ = conn.SetDeadline(time.Now().Add(c.Timeout.Duration()))
+		_ = writeProxyHeader(conn, port.probe.proxyProtocol)
 	}
 
-	c.setTcpPortCheckState(port, tcpPortCheckStateSuccess)
-	port.latency = durationToMs(dur)
+	return tcpPortCheckStateSuccess
 }
 
 func (c *Collector) setTcpPortCheckState(port *tcpPort, state string) {
@@ -54,3 +100,15 @@ func (c *Collector) setTcpPortCheckState(port *tcpPort, state string) {
 		port.statusChangeTs = time.Now()
 	}
 }
+
+// percentile returns the nearest-rank percentile of the durations.
+func percentile(durations []time.Duration, q float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	idx := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(idx, 0)]
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
 )
 
 func (c *Collector) collect() (map[string]int64, error) {
@@ -49,6 +51,43 @@ func (c *Collector) collect() (map[string]int64, error) {
 		mx[px+tcpPortCheckStateTimeout] = 0
 		mx[px+tcpPortCheckStateFailed] = 0
 		mx[px+p.status] = 1
+
+		if c.ConnectAttempts > 1 {
+			mx[px+"connect_attempts_success"] = int64(len(p.connectTimes))
+			mx[px+"connect_attempts_failed"] = int64(p.attemptsFailed)
+			if len(p.connectTimes) > 0 {
+				mx[px+"latency_p50"] = percentile(p.connectTimes, 0.5).Microseconds()
+				mx[px+"latency_p90"] = percentile(p.connectTimes, 0.9).Microseconds()
+				mx[px+"latency_p99"] = percentile(p.connectTimes, 0.99).Microseconds()
+			}
+		}
+
+		if p.probe != nil && p.probe.tls {
+			mx[px+"tls_success"] = 0
+			mx[px+"tls_failed"] = 0
+			if p.tls != nil {
+				mx[px+"tls_success"] = metrix.Bool(p.tls.ok)
+				mx[px+"tls_failed"] = metrix.Bool(!p.tls.ok)
+				if p.tls.ok {
+					mx[px+"tls_handshake_time"] = p.tls.handshakeTime.Microseconds()
+				}
+				if !p.tls.certNotAfter.IsZero() {
+					mx[px+"tls_cert_time_until_expiration"] = int64(p.tls.certNotAfter.Sub(now).Seconds())
+				}
+			}
+		}
+
+		if p.probe != nil && p.probe.expect != nil {
+			mx[px+"banner_"+bannerStatusMatch] = 0
+			mx[px+"banner_"+bannerStatusNoMatch] = 0
+			mx[px+"banner_"+bannerStatusError] = 0
+			if p.banner != nil {
+				mx[px+"banner_"+p.banner.status] = 1
+				if p.banner.status == bannerStatusMatch {
+					mx[px+"banner_response_time"] = p.banner.responseTime.Microseconds()
+				}
+			}
+		}
 	}
 
 	if c.doUdpPorts {
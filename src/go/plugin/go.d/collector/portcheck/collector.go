@@ -12,6 +12,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
 )
 
 //go:embed "config_schema.json"
@@ -46,12 +47,25 @@ func New() *Collector {
 }
 
 type Config struct {
-	Vnode       string           `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
-	Host        string           `yaml:"host" json:"host"`
-	Ports       []int            `yaml:"ports" json:"ports"`
-	UDPPorts    []int            `yaml:"udp_ports,omitempty" json:"udp_ports"`
-	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	Vnode           string           `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery     int              `yaml:"update_every,omitempty" json:"update_every"`
+	Host            string           `yaml:"host" json:"host"`
+	Ports           []int            `yaml:"ports" json:"ports"`
+	UDPPorts        []int            `yaml:"udp_ports,omitempty" json:"udp_ports"`
+	Timeout         confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	ConnectAttempts int              `yaml:"connect_attempts,omitempty" json:"connect_attempts"`
+	Probes          []ProbeConfig    `yaml:"probes,omitempty" json:"probes"`
+}
+
+type ProbeConfig struct {
+	Port             int    `yaml:"port" json:"port"`
+	TLS              bool   `yaml:"tls,omitempty" json:"tls"`
+	ServerName       string `yaml:"server_name,omitempty" json:"server_name"`
+	tlscfg.TLSConfig `yaml:",inline" json:""`
+	ProxyProtocol    string `yaml:"proxy_protocol,omitempty" json:"proxy_protocol"`
+	Protocol         string `yaml:"protocol,omitempty" json:"protocol"`
+	Send             string `yaml:"send,omitempty" json:"send"`
+	Expect           string `yaml:"expect,omitempty" json:"expect"`
 }
 
 type Collector struct {
@@ -80,7 +94,11 @@ func (c *Collector) Init(context.Context) error {
 		return fmt.Errorf("config validation: %v", err)
 	}
 
-	c.tcpPorts, c.udpPorts = c.initPorts()
+	tcpPorts, udpPorts, err := c.initPorts()
+	if err != nil {
+		return fmt.Errorf("init ports: %v", err)
+	}
+	c.tcpPorts, c.udpPorts = tcpPorts, udpPorts
 
 	c.Debugf("using host: %s", c.Host)
 	c.Debugf("using ports: tcp %v udp %v", c.Ports, c.UDPPorts)
@@ -3,15 +3,23 @@
 package portcheck
 
 import (
+	"bufio"
 	"context"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,6 +61,43 @@ func TestCollector_InitNG(t *testing.T) {
 	assert.NoError(t, collr.Init(context.Background()))
 }
 
+func TestCollector_Init_Probes(t *testing.T) {
+	tests := map[string]struct {
+		probes   []ProbeConfig
+		wantFail bool
+	}{
+		"valid probes": {
+			probes: []ProbeConfig{
+				{Port: 443, TLS: true},
+				{Port: 25, Protocol: "smtp", ProxyProtocol: "v2"},
+				{Port: 8080, Send: "PING\r\n", Expect: "^PONG"},
+			},
+		},
+		"no port":                {wantFail: true, probes: []ProbeConfig{{TLS: true}}},
+		"duplicate port":         {wantFail: true, probes: []ProbeConfig{{Port: 443, TLS: true}, {Port: 443}}},
+		"unknown protocol":       {wantFail: true, probes: []ProbeConfig{{Port: 25, Protocol: "gopher"}}},
+		"unknown proxy protocol": {wantFail: true, probes: []ProbeConfig{{Port: 25, ProxyProtocol: "v3"}}},
+		"send without expect":    {wantFail: true, probes: []ProbeConfig{{Port: 25, Send: "HELO"}}},
+		"invalid expect regex":   {wantFail: true, probes: []ProbeConfig{{Port: 25, Expect: "[a-"}}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Host = "127.0.0.1"
+			collr.Ports = []int{443}
+			collr.Probes = test.probes
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				require.NoError(t, collr.Init(context.Background()))
+				assert.Len(t, collr.tcpPorts, len(test.probes))
+			}
+		})
+	}
+}
+
 func TestCollector_Check(t *testing.T) {
 	assert.Error(t, New().Check(context.Background()))
 }
@@ -159,6 +204,191 @@ func TestCollector_Collect(t *testing.T) {
 	assert.Equal(t, expected, mx)
 }
 
+func TestCollector_Collect_Probes(t *testing.T) {
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsSrv.Close()
+
+	smtpAddr, stopSMTP := startTestTCPServer(t, func(conn net.Conn) {
+		_, _ = conn.Write([]byte("220 smtp.example.com ESMTP\r\n"))
+	})
+	defer stopSMTP()
+
+	proxyAddr, stopProxy := startTestTCPServer(t, func(conn net.Conn) {
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err == nil && strings.HasPrefix(line, "PROXY TCP4 127.0.0.1 127.0.0.1 ") {
+			_, _ = conn.Write([]byte("+OK ready\r\n"))
+		} else {
+			_, _ = conn.Write([]byte("-ERR no PROXY header\r\n"))
+		}
+	})
+	defer stopProxy()
+
+	tlsPort, smtpPort, proxyPort := addrPort(t, tlsSrv.Listener.Addr()), addrPort(t, smtpAddr), addrPort(t, proxyAddr)
+
+	collr := New()
+	collr.Host = "127.0.0.1"
+	collr.ConnectAttempts = 3
+	collr.Probes = []ProbeConfig{
+		{Port: tlsPort, TLS: true, TLSConfig: tlscfg.TLSConfig{InsecureSkipVerify: true}},
+		{Port: smtpPort, Protocol: "smtp"},
+		{Port: proxyPort, ProxyProtocol: "v1", Expect: `^\+OK`},
+	}
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+	assert.Len(t, *collr.Charts(), 3*5+3+2+2)
+
+	for _, port := range []int{tlsPort, smtpPort, proxyPort} {
+		px := fmt.Sprintf("tcp_port_%d_", port)
+		assert.Equal(t, int64(1), mx[px+"success"], px)
+		assert.Equal(t, int64(3), mx[px+"connect_attempts_success"], px)
+		assert.Equal(t, int64(0), mx[px+"connect_attempts_failed"], px)
+	}
+
+	px := fmt.Sprintf("tcp_port_%d_", tlsPort)
+	assert.Equal(t, int64(1), mx[px+"tls_success"])
+	assert.Greater(t, mx[px+"tls_cert_time_until_expiration"], int64(0))
+
+	px = fmt.Sprintf("tcp_port_%d_", smtpPort)
+	assert.Equal(t, int64(1), mx[px+"banner_match"])
+
+	px = fmt.Sprintf("tcp_port_%d_", proxyPort)
+	assert.Equal(t, int64(1), mx[px+"banner_match"])
+}
+
+func TestCollector_Collect_ProbesFail(t *testing.T) {
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsSrv.Close()
+
+	smtpAddr, stopSMTP := startTestTCPServer(t, func(conn net.Conn) {
+		_, _ = conn.Write([]byte("220 smtp.example.com ESMTP\r\n"))
+	})
+	defer stopSMTP()
+
+	tlsPort, smtpPort := addrPort(t, tlsSrv.Listener.Addr()), addrPort(t, smtpAddr)
+
+	collr := New()
+	collr.Host = "127.0.0.1"
+	collr.Timeout = confopt.Duration(time.Millisecond * 500)
+	collr.Probes = []ProbeConfig{
+		// self-signed certificate
+		{Port: tlsPort, TLS: true},
+		{Port: smtpPort, Protocol: "ssh"},
+	}
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	px := fmt.Sprintf("tcp_port_%d_", tlsPort)
+	assert.Equal(t, int64(1), mx[px+"success"])
+	assert.Equal(t, int64(1), mx[px+"tls_failed"])
+	assert.NotContains(t, mx, px+"tls_handshake_time")
+	// the certificate is known even though it failed verification
+	assert.Greater(t, mx[px+"tls_cert_time_until_expiration"], int64(0))
+
+	px = fmt.Sprintf("tcp_port_%d_", smtpPort)
+	assert.Equal(t, int64(1), mx[px+"success"])
+	assert.Equal(t, int64(1), mx[px+"banner_no_match"])
+	assert.NotContains(t, mx, px+"banner_response_time")
+}
+
+func TestCollector_Collect_ProbeTLSCustomCA(t *testing.T) {
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsSrv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsSrv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0644))
+
+	tlsPort := addrPort(t, tlsSrv.Listener.Addr())
+
+	collr := New()
+	// the test server certificate is issued for 127.0.0.1 and example.com
+	collr.Host = "127.0.0.1"
+	collr.Timeout = confopt.Duration(time.Millisecond * 500)
+	collr.Probes = []ProbeConfig{
+		{Port: tlsPort, TLS: true, TLSConfig: tlscfg.TLSConfig{TLSCA: caFile}},
+	}
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	px := fmt.Sprintf("tcp_port_%d_", tlsPort)
+	assert.Equal(t, int64(1), mx[px+"tls_success"])
+
+	collr = New()
+	collr.Host = "127.0.0.1"
+	collr.Timeout = confopt.Duration(time.Millisecond * 500)
+	collr.Probes = []ProbeConfig{
+		{Port: tlsPort, TLS: true, ServerName: "netdata.cloud", TLSConfig: tlscfg.TLSConfig{TLSCA: caFile}},
+	}
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(1), mx[px+"tls_failed"])
+	assert.Greater(t, mx[px+"tls_cert_time_until_expiration"], int64(0))
+}
+
+func TestProxyHeaderV2(t *testing.T) {
+	src := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 56324}
+	dst := &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 443}
+
+	header := proxyHeaderV2(src, dst)
+
+	require.Len(t, header, 16+12)
+	assert.Equal(t, proxyV2Signature, header[:12])
+	assert.Equal(t, []byte{0x21, 0x11, 0x00, 0x0c}, header[12:16])
+	assert.Equal(t, []byte{192, 0, 2, 1, 192, 0, 2, 2, 0xdc, 0x04, 0x01, 0xbb}, header[16:])
+
+	src = &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 56324}
+	dst = &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 443}
+	assert.Len(t, proxyHeaderV2(src, dst), 16+36)
+	assert.Equal(t, "PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n", string(proxyHeaderV1(src, dst)))
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+
+	assert.Equal(t, time.Duration(5), percentile(durations, 0.5))
+	assert.Equal(t, time.Duration(9), percentile(durations, 0.9))
+	assert.Equal(t, time.Duration(10), percentile(durations, 0.99))
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.5))
+}
+
+func startTestTCPServer(t *testing.T, handle func(conn net.Conn)) (net.Addr, func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_ = conn.SetDeadline(time.Now().Add(time.Second))
+				handle(conn)
+			}()
+		}
+	}()
+
+	return ln.Addr(), func() { _ = ln.Close() }
+}
+
+func addrPort(t *testing.T, addr net.Addr) int {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	require.True(t, ok)
+	return tcpAddr.Port
+}
+
 func testDial(err error) dialTCPFunc {
 	return func(_, _ string, _ time.Duration) (net.Conn, error) { return &net.TCPConn{}, err }
 }
@@ -3,9 +3,16 @@
 package portcheck
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"net"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
 )
 
 type dialTCPFunc func(network, address string, timeout time.Duration) (net.Conn, error)
@@ -14,19 +21,105 @@ func (c *Collector) validateConfig() error {
 	if c.Host == "" {
 		return errors.New("missing required parameter: 'host' must be specified")
 	}
-	if len(c.Ports) == 0 && len(c.UDPPorts) == 0 {
-		return errors.New("missing required parameters: at least one of 'ports' (TCP) or 'udp_ports' (UDP) must be specified")
+	if len(c.Ports) == 0 && len(c.UDPPorts) == 0 && len(c.Probes) == 0 {
+		return errors.New("missing required parameters: at least one of 'ports' (TCP), 'udp_ports' (UDP) or 'probes' must be specified")
+	}
+	if c.ConnectAttempts < 0 {
+		return errors.New("'connect_attempts' can not be negative")
 	}
 	return nil
 }
 
-func (c *Collector) initPorts() (tcpPorts []*tcpPort, udpPorts []*udpPort) {
+func (c *Collector) initPorts() (tcpPorts []*tcpPort, udpPorts []*udpPort, err error) {
+	probes := make(map[int]*tcpPortProbe)
+
+	for _, cfg := range c.Probes {
+		if _, ok := probes[cfg.Port]; ok {
+			return nil, nil, fmt.Errorf("port %d: duplicate probe", cfg.Port)
+		}
+		probe, err := c.initTCPPortProbe(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("port %d: %v", cfg.Port, err)
+		}
+		probes[cfg.Port] = probe
+	}
+
 	for _, p := range c.Ports {
-		tcpPorts = append(tcpPorts, &tcpPort{number: p})
+		tcpPorts = append(tcpPorts, &tcpPort{number: p, probe: probes[p]})
+	}
+	// ports with probes are checked even if not listed in 'ports'
+	for _, cfg := range c.Probes {
+		if !slices.Contains(c.Ports, cfg.Port) {
+			tcpPorts = append(tcpPorts, &tcpPort{number: cfg.Port, probe: probes[cfg.Port]})
+		}
 	}
 	for _, p := range c.UDPPorts {
 		udpPorts = append(udpPorts, &udpPort{number: p})
 	}
 
-	return tcpPorts, udpPorts
+	return tcpPorts, udpPorts, nil
+}
+
+func (c *Collector) initTCPPortProbe(cfg ProbeConfig) (*tcpPortProbe, error) {
+	if cfg.Port <= 0 {
+		return nil, errors.New("'port' must be specified")
+	}
+
+	probe := &tcpPortProbe{tls: cfg.TLS}
+
+	if cfg.TLS {
+		tlsConfig, err := tlscfg.NewTLSConfig(cfg.TLSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("init TLS config: %v", err)
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+
+		host := strings.Trim(c.Host, "[]")
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else if net.ParseIP(host) == nil {
+			tlsConfig.ServerName = host
+		}
+
+		probe.tlsConfig = tlsConfig
+		probe.tlsVerifyName = tlsConfig.ServerName
+		if probe.tlsVerifyName == "" {
+			// an IP address host is verified against the certificate IP SANs
+			probe.tlsVerifyName = host
+		}
+	}
+
+	switch v := strings.ToLower(cfg.ProxyProtocol); v {
+	case "":
+	case proxyProtocolV1, proxyProtocolV2:
+		probe.proxyProtocol = v
+	default:
+		return nil, fmt.Errorf("unknown 'proxy_protocol' version '%s' (supported: v1, v2)", cfg.ProxyProtocol)
+	}
+
+	expect := cfg.Expect
+	if cfg.Protocol != "" {
+		v, ok := protocolExpect[strings.ToLower(cfg.Protocol)]
+		if !ok {
+			return nil, fmt.Errorf("unknown 'protocol' '%s'", cfg.Protocol)
+		}
+		if expect == "" {
+			expect = v
+		}
+	}
+	if cfg.Send != "" && expect == "" {
+		return nil, errors.New("'send' requires 'expect' or 'protocol'")
+	}
+	if expect != "" {
+		re, err := regexp.Compile(expect)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'expect' regex: %v", err)
+		}
+		probe.expect = re
+		probe.send = []byte(cfg.Send)
+	}
+
+	return probe, nil
 }
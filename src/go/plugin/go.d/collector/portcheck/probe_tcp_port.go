@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package portcheck
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	bannerStatusMatch   = "match"
+	bannerStatusNoMatch = "no_match"
+	bannerStatusError   = "error"
+)
+
+const (
+	proxyProtocolV1 = "v1"
+	proxyProtocolV2 = "v2"
+)
+
+// maxBannerSize is the maximum number of bytes read while waiting for the expected response.
+const maxBannerSize = 4096
+
+var protocolExpect = map[string]string{
+	"smtp": `^220[ -]`,
+	"ftp":  `^220[ -]`,
+	"ssh":  `^SSH-`,
+	"pop3": `^\+OK`,
+	"imap": `^\* OK`,
+}
+
+type (
+	tcpPortProbe struct {
+		tls           bool
+		tlsConfig     *tls.Config
+		tlsVerifyName string
+		proxyProtocol string
+		send          []byte
+		expect        *regexp.Regexp
+	}
+
+	tlsProbeResult struct {
+		ok            bool
+		handshakeTime time.Duration
+		certNotAfter  time.Time
+	}
+
+	bannerProbeResult struct {
+		status       string
+		responseTime time.Duration
+	}
+)
+
+func (c *Collector) probeTCPPort(port *tcpPort, conn net.Conn) {
+	probe := port.probe
+	_ = conn.SetDeadline(time.Now().Add(c.Timeout.Duration()))
+
+	if probe.proxyProtocol != "" {
+		if err := writeProxyHeader(conn, probe.proxyProtocol); err != nil {
+			c.Debugf("port %d: sending PROXY protocol header: %v", port.number, err)
+			if probe.tls {
+				port.tls = &tlsProbeResult{}
+			}
+			if probe.expect != nil {
+				port.banner = &bannerProbeResult{status: bannerStatusError}
+			}
+			return
+		}
+	}
+
+	if probe.tls {
+		var tlsConn *tls.Conn
+		tlsConn, port.tls = probeTLS(conn, probe.tlsConfig, probe.tlsVerifyName)
+		if !port.tls.ok {
+			c.Debugf("port %d: TLS handshake failed", port.number)
+			if probe.expect != nil {
+				port.banner = &bannerProbeResult{status: bannerStatusError}
+			}
+			return
+		}
+		conn = tlsConn
+	}
+
+	if probe.expect != nil {
+		port.banner = probeBanner(conn, probe.send, probe.expect)
+	}
+}
+
+// probeTLS performs the TLS handshake. The peer certificate is verified in VerifyConnection instead of
+// the standard verification, so its expiration time is known even if the handshake fails (e.g. it is expired).
+func probeTLS(conn net.Conn, config *tls.Config, verifyName string) (*tls.Conn, *tlsProbeResult) {
+	res := &tlsProbeResult{}
+
+	cfg := config.Clone()
+	cfg.InsecureSkipVerify = true
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("no peer certificates")
+		}
+		res.certNotAfter = cs.PeerCertificates[0].NotAfter
+		if config.InsecureSkipVerify {
+			return nil
+		}
+		return verifyPeerCertificates(cs.PeerCertificates, config.RootCAs, verifyName)
+	}
+
+	tlsConn := tls.Client(conn, cfg)
+	start := time.Now()
+
+	if err := tlsConn.Handshake(); err != nil {
+		return tlsConn, res
+	}
+
+	res.ok = true
+	res.handshakeTime = time.Since(start)
+
+	return tlsConn, res
+}
+
+func verifyPeerCertificates(certs []*x509.Certificate, roots *x509.CertPool, name string) error {
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       name,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+func probeBanner(conn net.Conn, send []byte, expect *regexp.Regexp) *bannerProbeResult {
+	start := time.Now()
+
+	if len(send) > 0 {
+		if _, err := conn.Write(send); err != nil {
+			return &bannerProbeResult{status: bannerStatusError}
+		}
+	}
+
+	var resp bytes.Buffer
+	buf := make([]byte, 512)
+
+	for resp.Len() < maxBannerSize {
+		n, err := conn.Read(buf)
+		resp.Write(buf[:n])
+
+		if expect.Match(resp.Bytes()) {
+			return &bannerProbeResult{status: bannerStatusMatch, responseTime: time.Since(start)}
+		}
+		if err != nil {
+			if resp.Len() > 0 {
+				return &bannerProbeResult{status: bannerStatusNoMatch}
+			}
+			return &bannerProbeResult{status: bannerStatusError}
+		}
+	}
+
+	return &bannerProbeResult{status: bannerStatusNoMatch}
+}
+
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// writeProxyHeader sends the HAProxy PROXY protocol header describing the connection itself.
+// https://www.haproxy.org/download/2.9/doc/proxy-protocol.txt
+func writeProxyHeader(conn net.Conn, version string) error {
+	src, ok1 := conn.LocalAddr().(*net.TCPAddr)
+	dst, ok2 := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok1 || !ok2 {
+		return errors.New("not a TCP connection")
+	}
+
+	var header []byte
+	switch version {
+	case proxyProtocolV1:
+		header = proxyHeaderV1(src, dst)
+	case proxyProtocolV2:
+		header = proxyHeaderV2(src, dst)
+	default:
+		return fmt.Errorf("unknown PROXY protocol version '%s'", version)
+	}
+
+	_, err := conn.Write(header)
+	return err
+}
+
+func proxyHeaderV1(src, dst *net.TCPAddr) []byte {
+	proto := "TCP4"
+	if src.IP.To4() == nil {
+		proto = "TCP6"
+	}
+	return []byte(fmt.Sprintf("PROXY %s %s %s %s %s\r\n",
+		proto, src.IP.String(), dst.IP.String(), strconv.Itoa(src.Port), strconv.Itoa(dst.Port)))
+}
+
+func proxyHeaderV2(src, dst *net.TCPAddr) []byte {
+	var buf bytes.Buffer
+	buf.Write(proxyV2Signature)
+	// version 2, PROXY command
+	buf.WriteByte(0x21)
+
+	srcIP, dstIP := src.IP.To4(), dst.IP.To4()
+	if srcIP != nil && dstIP != nil {
+		// TCP over IPv4
+		buf.WriteByte(0x11)
+		_ = binary.Write(&buf, binary.BigEndian, uint16(12))
+	} else {
+		srcIP, dstIP = src.IP.To16(), dst.IP.To16()
+		// TCP over IPv6
+		buf.WriteByte(0x21)
+		_ = binary.Write(&buf, binary.BigEndian, uint16(36))
+	}
+
+	buf.Write(srcIP)
+	buf.Write(dstIP)
+	_ = binary.Write(&buf, binary.BigEndian, uint16(src.Port))
+	_ = binary.Write(&buf, binary.BigEndian, uint16(dst.Port))
+
+	return buf.Bytes()
+}
@@ -19,6 +19,12 @@ type (
 		seen         bool
 		notSeenTimes int
 		charts       []*module.Chart
+		dims         map[string]*cacheDim // dynamic dimensions of grouped charts
+	}
+
+	cacheDim struct {
+		seen         bool
+		notSeenTimes int
 	}
 )
 
@@ -39,3 +45,23 @@ func (c *cache) addChart(key string, chart *module.Chart) {
 		v.charts = append(v.charts, chart)
 	}
 }
+
+func (c *cache) hasDimP(key, dimID string) bool {
+	v, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	if v.dims == nil {
+		v.dims = make(map[string]*cacheDim)
+	}
+
+	d, ok := v.dims[dimID]
+	if !ok {
+		d = &cacheDim{}
+		v.dims[dimID] = d
+	}
+	d.seen = true
+	d.notSeenTimes = 0
+
+	return ok
+}
@@ -47,6 +47,8 @@ func (c *Collector) addGaugeChart(id, name, help string, labels labels.Labels) {
 		)
 	}
 
+	c.findChartOverride(name, labels).apply(chart, true)
+
 	if err := c.Charts().Add(chart); err != nil {
 		c.Warning(err)
 		return
@@ -90,6 +92,8 @@ func (c *Collector) addCounterChart(id, name, help string, labels labels.Labels)
 		)
 	}
 
+	c.findChartOverride(name, labels).apply(chart, true)
+
 	if err := c.Charts().Add(chart); err != nil {
 		c.Warning(err)
 		return
@@ -151,13 +155,17 @@ func (c *Collector) addSummaryCharts(id, name, help string, labels labels.Labels
 		},
 	}
 
-	for _, chart := range charts {
+	ov := c.findChartOverride(name, labels)
+
+	for i, chart := range charts {
 		for _, lbl := range labels {
 			chart.Labels = append(chart.Labels, module.Label{
 				Key:   c.labelName(lbl.Name),
 				Value: apostropheReplacer.Replace(lbl.Value),
 			})
 		}
+		// the count chart units are always "events/s"
+		ov.apply(chart, i != 2)
 		if err := c.Charts().Add(chart); err != nil {
 			c.Warning(err)
 			continue
@@ -219,13 +227,17 @@ func (c *Collector) addHistogramCharts(id, name, help string, labels labels.Labe
 		},
 	}
 
-	for _, chart := range charts {
+	ov := c.findChartOverride(name, labels)
+
+	for i, chart := range charts {
 		for _, lbl := range labels {
 			chart.Labels = append(chart.Labels, module.Label{
 				Key:   c.labelName(lbl.Name),
 				Value: apostropheReplacer.Replace(lbl.Value),
 			})
 		}
+		// only the sum chart units are derived from the metric name
+		ov.apply(chart, i == 1)
 		if err := c.Charts().Add(chart); err != nil {
 			c.Warning(err)
 			continue
@@ -234,6 +246,74 @@ func (c *Collector) addHistogramCharts(id, name, help string, labels labels.Labe
 	}
 }
 
+func (c *Collector) addGroupedChart(id, name, help string, labels labels.Labels, isCounter bool) {
+	units := getChartUnits(name)
+	if isCounter {
+		switch units {
+		case "seconds", "time":
+		default:
+			units += "/s"
+		}
+	}
+
+	chart := &module.Chart{
+		ID:       id,
+		Title:    getChartTitle(name, help),
+		Units:    units,
+		Fam:      getChartFamily(name),
+		Ctx:      getChartContext(c.application(), name),
+		Type:     module.Line,
+		Priority: getChartPriority(name),
+	}
+	for _, lbl := range labels {
+		chart.Labels = append(chart.Labels,
+			module.Label{
+				Key:   c.labelName(lbl.Name),
+				Value: apostropheReplacer.Replace(lbl.Value),
+			},
+		)
+	}
+
+	c.findChartOverride(name, labels).apply(chart, true)
+
+	if err := c.Charts().Add(chart); err != nil {
+		c.Warning(err)
+		return
+	}
+
+	c.cache.addChart(id, chart)
+}
+
+func (c *Collector) addGroupedChartDim(id, dimID, dimName string, isCounter bool) {
+	chart := c.Charts().Get(id)
+	if chart == nil {
+		return
+	}
+
+	dim := &module.Dim{ID: dimID, Name: dimName, Div: precision}
+	if isCounter {
+		dim.Algo = module.Incremental
+	}
+
+	if err := chart.AddDim(dim); err != nil {
+		c.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (o chartOverride) apply(chart *module.Chart, withUnits bool) {
+	if o.title != "" {
+		chart.Title = o.title
+	}
+	if o.family != "" {
+		chart.Fam = o.family
+	}
+	if o.units != "" && withUnits {
+		chart.Units = o.units
+	}
+}
+
 func (c *Collector) application() string {
 	if c.Application != "" {
 		return c.Application
@@ -83,13 +83,12 @@ func (c *Collector) collectGauge(mx map[string]int64, mf *prometheus.MetricFamil
 			continue
 		}
 
-		id := mf.Name() + c.joinLabels(m.Labels())
-
-		if !c.cache.hasP(id) {
-			c.addGaugeChart(id, mf.Name(), mf.Help(), m.Labels())
+		name, lbs, ok := c.relabelSeries(mf.Name(), m.Labels())
+		if !ok {
+			continue
 		}
 
-		mx[id] = int64(m.Gauge().Value() * precision)
+		c.collectValue(mx, name, mf.Help(), lbs, m.Gauge().Value(), false)
 	}
 }
 
@@ -99,13 +98,12 @@ func (c *Collector) collectCounter(mx map[string]int64, mf *prometheus.MetricFam
 			continue
 		}
 
-		id := mf.Name() + c.joinLabels(m.Labels())
-
-		if !c.cache.hasP(id) {
-			c.addCounterChart(id, mf.Name(), mf.Help(), m.Labels())
+		name, lbs, ok := c.relabelSeries(mf.Name(), m.Labels())
+		if !ok {
+			continue
 		}
 
-		mx[id] = int64(m.Counter().Value() * precision)
+		c.collectValue(mx, name, mf.Help(), lbs, m.Counter().Value(), true)
 	}
 }
 
@@ -115,10 +113,15 @@ func (c *Collector) collectSummary(mx map[string]int64, mf *prometheus.MetricFam
 			continue
 		}
 
-		id := mf.Name() + c.joinLabels(m.Labels())
+		name, lbs, ok := c.relabelSeries(mf.Name(), m.Labels())
+		if !ok {
+			continue
+		}
+
+		id := name + c.joinLabels(lbs)
 
 		if !c.cache.hasP(id) {
-			c.addSummaryCharts(id, mf.Name(), mf.Help(), m.Labels(), m.Summary().Quantiles())
+			c.addSummaryCharts(id, name, mf.Help(), lbs, m.Summary().Quantiles())
 		}
 
 		for _, v := range m.Summary().Quantiles() {
@@ -139,10 +142,15 @@ func (c *Collector) collectHistogram(mx map[string]int64, mf *prometheus.MetricF
 			continue
 		}
 
-		id := mf.Name() + c.joinLabels(m.Labels())
+		name, lbs, ok := c.relabelSeries(mf.Name(), m.Labels())
+		if !ok {
+			continue
+		}
+
+		id := name + c.joinLabels(lbs)
 
 		if !c.cache.hasP(id) {
-			c.addHistogramCharts(id, mf.Name(), mf.Help(), m.Labels(), m.Histogram().Buckets())
+			c.addHistogramCharts(id, name, mf.Help(), lbs, m.Histogram().Buckets())
 		}
 
 		for _, v := range m.Histogram().Buckets() {
@@ -163,26 +171,60 @@ func (c *Collector) collectUntyped(mx map[string]int64, mf *prometheus.MetricFam
 			continue
 		}
 
-		if c.isFallbackTypeGauge(mf.Name()) {
-			id := mf.Name() + c.joinLabels(m.Labels())
+		isGauge := c.isFallbackTypeGauge(mf.Name())
+		isCounter := c.isFallbackTypeCounter(mf.Name()) || strings.HasSuffix(mf.Name(), "_total")
+		if !isGauge && !isCounter {
+			continue
+		}
 
-			if !c.cache.hasP(id) {
-				c.addGaugeChart(id, mf.Name(), mf.Help(), m.Labels())
-			}
+		name, lbs, ok := c.relabelSeries(mf.Name(), m.Labels())
+		if !ok {
+			continue
+		}
 
-			mx[id] = int64(m.Untyped().Value() * precision)
+		if isGauge {
+			c.collectValue(mx, name, mf.Help(), lbs, m.Untyped().Value(), false)
+		}
+		if isCounter {
+			c.collectValue(mx, name, mf.Help(), lbs, m.Untyped().Value(), true)
 		}
+	}
+}
 
-		if c.isFallbackTypeCounter(mf.Name()) || strings.HasSuffix(mf.Name(), "_total") {
-			id := mf.Name() + c.joinLabels(m.Labels())
+func (c *Collector) collectValue(mx map[string]int64, name, help string, lbs labels.Labels, value float64, isCounter bool) {
+	if grp := c.findChartGroup(name, lbs); grp != nil {
+		c.collectGroupedValue(mx, grp, name, help, lbs, value, isCounter)
+		return
+	}
 
-			if !c.cache.hasP(id) {
-				c.addCounterChart(id, mf.Name(), mf.Help(), m.Labels())
-			}
+	id := name + c.joinLabels(lbs)
 
-			mx[id] = int64(m.Untyped().Value() * precision)
+	if !c.cache.hasP(id) {
+		if isCounter {
+			c.addCounterChart(id, name, help, lbs)
+		} else {
+			c.addGaugeChart(id, name, help, lbs)
 		}
 	}
+
+	mx[id] = int64(value * precision)
+}
+
+func (c *Collector) collectGroupedValue(mx map[string]int64, grp *chartGroup, name, help string, lbs labels.Labels, value float64, isCounter bool) {
+	dimLbl := labels.Label{Name: grp.dimLabel, Value: lbs.Get(grp.dimLabel)}
+	chartLbs := labels.NewBuilder(lbs).Del(grp.dimLabel).Labels()
+
+	id := name + c.joinLabels(chartLbs) + "_by_" + grp.dimLabel
+	dimID := id + c.joinLabels(labels.Labels{dimLbl})
+
+	if !c.cache.hasP(id) {
+		c.addGroupedChart(id, name, help, chartLbs, isCounter)
+	}
+	if !c.cache.hasDimP(id, dimID) {
+		c.addGroupedChartDim(id, dimID, apostropheReplacer.Replace(dimLbl.Value), isCounter)
+	}
+
+	mx[dimID] = int64(value * precision)
 }
 
 func (c *Collector) isFallbackTypeGauge(name string) bool {
@@ -221,6 +263,9 @@ func (c *Collector) joinLabels(labels labels.Labels) string {
 func (c *Collector) resetCache() {
 	for _, v := range c.cache.entries {
 		v.seen = false
+		for _, d := range v.dims {
+			d.seen = false
+		}
 	}
 }
 
@@ -229,6 +274,7 @@ const maxNotSeenTimes = 10
 func (c *Collector) removeStaleCharts() {
 	for k, v := range c.cache.entries {
 		if v.seen {
+			c.removeStaleDims(v)
 			continue
 		}
 		if v.notSeenTimes++; v.notSeenTimes >= maxNotSeenTimes {
@@ -241,6 +287,24 @@ func (c *Collector) removeStaleCharts() {
 	}
 }
 
+func (c *Collector) removeStaleDims(entry *cacheEntry) {
+	for dimID, d := range entry.dims {
+		if d.seen {
+			continue
+		}
+		if d.notSeenTimes++; d.notSeenTimes >= maxNotSeenTimes {
+			for _, chart := range entry.charts {
+				if err := chart.MarkDimRemove(dimID, true); err != nil {
+					c.Warning(err)
+					continue
+				}
+				chart.MarkNotCreated()
+			}
+			delete(entry.dims, dimID)
+		}
+	}
+}
+
 func decodeLabelValue(value string) string {
 	v, err := strconv.Unquote("\"" + value + "\"")
 	if err != nil {
@@ -15,6 +15,8 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus/selector"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+
+	"github.com/prometheus/prometheus/model/relabel"
 )
 
 //go:embed "config_schema.json"
@@ -63,8 +65,33 @@ type Config struct {
 		Gauge   []string `yaml:"gauge,omitempty" json:"gauge"`
 		Counter []string `yaml:"counter,omitempty" json:"counter"`
 	} `yaml:"fallback_type,omitempty" json:"fallback_type"`
+	RelabelConfigs []RelabelConfig       `yaml:"relabel_configs,omitempty" json:"relabel_configs"`
+	ChartGroups    []ChartGroupConfig    `yaml:"chart_groups,omitempty" json:"chart_groups"`
+	ChartOverrides []ChartOverrideConfig `yaml:"chart_overrides,omitempty" json:"chart_overrides"`
 }
 
+type (
+	RelabelConfig struct {
+		SourceLabels []string `yaml:"source_labels,omitempty" json:"source_labels"`
+		Separator    string   `yaml:"separator,omitempty" json:"separator"`
+		Regex        string   `yaml:"regex,omitempty" json:"regex"`
+		Modulus      uint64   `yaml:"modulus,omitempty" json:"modulus"`
+		TargetLabel  string   `yaml:"target_label,omitempty" json:"target_label"`
+		Replacement  string   `yaml:"replacement,omitempty" json:"replacement"`
+		Action       string   `yaml:"action,omitempty" json:"action"`
+	}
+	ChartGroupConfig struct {
+		Selector       string `yaml:"selector" json:"selector"`
+		DimensionLabel string `yaml:"dimension_label" json:"dimension_label"`
+	}
+	ChartOverrideConfig struct {
+		Selector string `yaml:"selector" json:"selector"`
+		Title    string `yaml:"title,omitempty" json:"title"`
+		Units    string `yaml:"units,omitempty" json:"units"`
+		Family   string `yaml:"family,omitempty" json:"family"`
+	}
+)
+
 type Collector struct {
 	module.Base
 	Config `yaml:",inline" json:""`
@@ -78,6 +105,9 @@ type Collector struct {
 		counter matcher.Matcher
 		gauge   matcher.Matcher
 	}
+	relabelConfigs []*relabel.Config
+	chartGroups    []*chartGroup
+	chartOverrides []*chartOverride
 }
 
 func (c *Collector) Configuration() any {
@@ -107,6 +137,24 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.fallbackType.gauge = m
 
+	rcs, err := c.initRelabelConfigs()
+	if err != nil {
+		return fmt.Errorf("init relabel configs: %v", err)
+	}
+	c.relabelConfigs = rcs
+
+	groups, err := c.initChartGroups()
+	if err != nil {
+		return fmt.Errorf("init chart groups: %v", err)
+	}
+	c.chartGroups = groups
+
+	overrides, err := c.initChartOverrides()
+	if err != nil {
+		return fmt.Errorf("init chart overrides: %v", err)
+	}
+	c.chartOverrides = overrides
+
 	return nil
 }
 
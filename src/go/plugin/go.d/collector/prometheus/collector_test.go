@@ -52,6 +52,34 @@ func TestCollector_Init(t *testing.T) {
 				Selector:   selector.Expr{Allow: []string{`name{label=#"value"}`}},
 			},
 		},
+		"invalid relabel action": {
+			wantFail: true,
+			config: Config{
+				HTTPConfig:     web.HTTPConfig{RequestConfig: web.RequestConfig{URL: "http://127.0.0.1:9090/metric"}},
+				RelabelConfigs: []RelabelConfig{{Action: "rename", SourceLabels: []string{"label1"}}},
+			},
+		},
+		"hashmod relabel without modulus": {
+			wantFail: true,
+			config: Config{
+				HTTPConfig:     web.HTTPConfig{RequestConfig: web.RequestConfig{URL: "http://127.0.0.1:9090/metric"}},
+				RelabelConfigs: []RelabelConfig{{Action: "hashmod", SourceLabels: []string{"label1"}, TargetLabel: "shard"}},
+			},
+		},
+		"chart group without dimension label": {
+			wantFail: true,
+			config: Config{
+				HTTPConfig:  web.HTTPConfig{RequestConfig: web.RequestConfig{URL: "http://127.0.0.1:9090/metric"}},
+				ChartGroups: []ChartGroupConfig{{Selector: "test_gauge_metric_1"}},
+			},
+		},
+		"invalid chart override selector": {
+			wantFail: true,
+			config: Config{
+				HTTPConfig:     web.HTTPConfig{RequestConfig: web.RequestConfig{URL: "http://127.0.0.1:9090/metric"}},
+				ChartOverrides: []ChartOverrideConfig{{Selector: `name{label=#"value"}`, Units: "requests"}},
+			},
+		},
 		"default": {
 			wantFail: true,
 			config:   New().Config,
@@ -564,6 +592,96 @@ test_gauge_no_meta_metric_1{label1="value2"} 12
 				},
 			},
 		},
+		"Relabel configs": {
+			prepare: func() *Collector {
+				collr := New()
+				collr.RelabelConfigs = []RelabelConfig{
+					{Action: "drop", SourceLabels: []string{"__name__", "label1"}, Regex: "test_gauge_metric_1;value2"},
+					{Action: "keep", SourceLabels: []string{"__name__"}, Regex: "test_(gauge|counter)_metric_1.*"},
+					{SourceLabels: []string{"__name__"}, Regex: "test_counter_(.+)", TargetLabel: "__name__", Replacement: "renamed_$1"},
+					{Action: "labelmap", Regex: "label(\\d)", Replacement: "lbl$1"},
+					{Action: "labeldrop", Regex: "label\\d"},
+					{Action: "hashmod", SourceLabels: []string{"lbl1"}, Modulus: 1, TargetLabel: "shard"},
+				}
+				return collr
+			},
+			steps: []testCaseStep{
+				{
+					desc: "Series dropped, kept, renamed and relabeled",
+					input: `
+# HELP test_gauge_metric_1 Test Gauge Metric 1
+# TYPE test_gauge_metric_1 gauge
+test_gauge_metric_1{label1="value1"} 11
+test_gauge_metric_1{label1="value2"} 12
+# HELP test_gauge_metric_2 Test Gauge Metric 2
+# TYPE test_gauge_metric_2 gauge
+test_gauge_metric_2{label1="value1"} 11
+# HELP test_counter_metric_1_total Test Counter Metric 1
+# TYPE test_counter_metric_1_total counter
+test_counter_metric_1_total{label1="value1"} 11
+`,
+					wantCollected: map[string]int64{
+						"test_gauge_metric_1-lbl1=value1-shard=0":    11000,
+						"renamed_metric_1_total-lbl1=value1-shard=0": 11000,
+					},
+					wantCharts: 2,
+				},
+			},
+		},
+		"Chart groups": {
+			prepare: func() *Collector {
+				collr := New()
+				collr.ChartGroups = []ChartGroupConfig{
+					{Selector: "test_counter_metric_1_total", DimensionLabel: "code"},
+					{Selector: `test_gauge_metric_1{label1="value1"}`, DimensionLabel: "label2"},
+				}
+				return collr
+			},
+			steps: []testCaseStep{
+				{
+					desc: "Series folded into grouped charts",
+					input: `
+# HELP test_counter_metric_1_total Test Counter Metric 1
+# TYPE test_counter_metric_1_total counter
+test_counter_metric_1_total{code="200",method="GET"} 11
+test_counter_metric_1_total{code="500",method="GET"} 12
+test_counter_metric_1_total{code="200",method="POST"} 13
+test_counter_metric_1_total{method="PUT"} 14
+# HELP test_gauge_metric_1 Test Gauge Metric 1
+# TYPE test_gauge_metric_1 gauge
+test_gauge_metric_1{label1="value1",label2="a"} 11
+test_gauge_metric_1{label1="value1",label2="b"} 12
+test_gauge_metric_1{label1="value2",label2="a"} 13
+`,
+					wantCollected: map[string]int64{
+						"test_counter_metric_1_total-method=GET_by_code-code=200":  11000,
+						"test_counter_metric_1_total-method=GET_by_code-code=500":  12000,
+						"test_counter_metric_1_total-method=POST_by_code-code=200": 13000,
+						"test_counter_metric_1_total-method=PUT":                   14000,
+						"test_gauge_metric_1-label1=value1_by_label2-label2=a":     11000,
+						"test_gauge_metric_1-label1=value1_by_label2-label2=b":     12000,
+						"test_gauge_metric_1-label1=value2-label2=a":               13000,
+					},
+					wantCharts: 5,
+				},
+				{
+					desc: "Dimensions and grouped charts removed",
+					input: `
+# HELP test_counter_metric_1_total Test Counter Metric 1
+# TYPE test_counter_metric_1_total counter
+test_counter_metric_1_total{code="200",method="GET"} 11
+# HELP test_gauge_metric_1 Test Gauge Metric 1
+# TYPE test_gauge_metric_1 gauge
+test_gauge_metric_1{label1="value1",label2="a"} 11
+`,
+					wantCollected: map[string]int64{
+						"test_counter_metric_1_total-method=GET_by_code-code=200": 11000,
+						"test_gauge_metric_1-label1=value1_by_label2-label2=a":    11000,
+					},
+					wantCharts: 2,
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -600,6 +718,94 @@ test_gauge_no_meta_metric_1{label1="value2"} 12
 	}
 }
 
+func TestCollector_Collect_ChartGroupDims(t *testing.T) {
+	collr := New()
+	collr.ChartGroups = []ChartGroupConfig{{Selector: "test_counter_metric_1_total", DimensionLabel: "code"}}
+
+	metrics := []byte(`
+# HELP test_counter_metric_1_total Test Counter Metric 1
+# TYPE test_counter_metric_1_total counter
+test_counter_metric_1_total{code="200"} 11
+test_counter_metric_1_total{code="500"} 12
+`)
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(metrics)
+		}))
+	defer srv.Close()
+
+	collr.URL = srv.URL
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	chart := collr.Charts().Get("test_counter_metric_1_total_by_code")
+	require.NotNil(t, chart)
+	require.Len(t, chart.Dims, 2)
+	assert.Equal(t, "200", chart.Dims[0].Name)
+	assert.Equal(t, module.Incremental, chart.Dims[0].Algo)
+	assert.Equal(t, "1/s", chart.Units)
+
+	metrics = []byte(`
+# HELP test_counter_metric_1_total Test Counter Metric 1
+# TYPE test_counter_metric_1_total counter
+test_counter_metric_1_total{code="200"} 11
+`)
+	for i := 0; i < maxNotSeenTimes; i++ {
+		_ = collr.Collect(context.Background())
+	}
+
+	assert.False(t, chart.Obsolete)
+	assert.True(t, chart.GetDim("test_counter_metric_1_total_by_code-code=500").Obsolete)
+}
+
+func TestCollector_Collect_ChartOverrides(t *testing.T) {
+	collr := New()
+	collr.ChartOverrides = []ChartOverrideConfig{
+		{Selector: "test_counter_*", Title: "Requests", Family: "http"},
+		{Selector: `test_counter_metric_1_total{label1="value1"}`, Units: "requests/s"},
+		{Selector: "test_histogram_1_duration_seconds", Units: "ms", Family: "latency"},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`
+# HELP test_counter_metric_1_total Test Counter Metric 1
+# TYPE test_counter_metric_1_total counter
+test_counter_metric_1_total{label1="value1"} 11
+test_counter_metric_1_total{label1="value2"} 12
+# HELP test_histogram_1_duration_seconds Test Histogram Metric 1
+# TYPE test_histogram_1_duration_seconds histogram
+test_histogram_1_duration_seconds_bucket{le="0.1"} 4
+test_histogram_1_duration_seconds_bucket{le="+Inf"} 6
+test_histogram_1_duration_seconds_sum 0.00147889
+test_histogram_1_duration_seconds_count 6
+`))
+		}))
+	defer srv.Close()
+
+	collr.URL = srv.URL
+	require.NoError(t, collr.Init(context.Background()))
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	tests := map[string]struct{ title, units, fam string }{
+		"test_counter_metric_1_total-label1=value1": {title: "Requests", units: "requests/s", fam: "http"},
+		"test_counter_metric_1_total-label1=value2": {title: "Requests", units: "1/s", fam: "http"},
+		"test_histogram_1_duration_seconds":         {title: "Test Histogram Metric 1", units: "observations/s", fam: "latency"},
+		"test_histogram_1_duration_seconds_sum":     {title: "Test Histogram Metric 1", units: "ms", fam: "latency"},
+		"test_histogram_1_duration_seconds_count":   {title: "Test Histogram Metric 1", units: "events/s", fam: "latency"},
+	}
+
+	for id, want := range tests {
+		chart := collr.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s'", id)
+		assert.Equalf(t, want.title, chart.Title, "chart '%s' title", id)
+		assert.Equalf(t, want.units, chart.Units, "chart '%s' units", id)
+		assert.Equalf(t, want.fam, chart.Fam, "chart '%s' family", id)
+	}
+}
+
 func removeObsoleteCharts(charts *module.Charts) {
 	var i int
 	for _, chart := range *charts {
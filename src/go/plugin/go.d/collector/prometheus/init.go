@@ -6,10 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus/selector"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/relabel"
 )
 
 func (c *Collector) validateConfig() error {
@@ -62,3 +67,91 @@ func (c *Collector) initFallbackTypeMatcher(expr []string) (matcher.Matcher, err
 
 	return m, nil
 }
+
+func (c *Collector) initRelabelConfigs() ([]*relabel.Config, error) {
+	var cfgs []*relabel.Config
+
+	for i, rc := range c.RelabelConfigs {
+		cfg := relabel.DefaultRelabelConfig
+
+		if rc.Action != "" {
+			cfg.Action = relabel.Action(strings.ToLower(rc.Action))
+		}
+		switch cfg.Action {
+		case relabel.Replace, relabel.Keep, relabel.Drop, relabel.KeepEqual, relabel.DropEqual,
+			relabel.HashMod, relabel.LabelMap, relabel.LabelDrop, relabel.LabelKeep,
+			relabel.Lowercase, relabel.Uppercase:
+		default:
+			return nil, fmt.Errorf("relabel config %d: unknown action '%s'", i+1, rc.Action)
+		}
+
+		for _, name := range rc.SourceLabels {
+			cfg.SourceLabels = append(cfg.SourceLabels, model.LabelName(name))
+		}
+		if rc.Separator != "" {
+			cfg.Separator = rc.Separator
+		}
+		if rc.Regex != "" {
+			re, err := relabel.NewRegexp(rc.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("relabel config %d: invalid regex '%s': %v", i+1, rc.Regex, err)
+			}
+			cfg.Regex = re
+		}
+		if rc.Replacement != "" {
+			cfg.Replacement = rc.Replacement
+		}
+		cfg.Modulus = rc.Modulus
+		cfg.TargetLabel = rc.TargetLabel
+
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("relabel config %d: %v", i+1, err)
+		}
+
+		cfgs = append(cfgs, &cfg)
+	}
+
+	return cfgs, nil
+}
+
+func (c *Collector) initChartGroups() ([]*chartGroup, error) {
+	var groups []*chartGroup
+
+	for i, cfg := range c.ChartGroups {
+		if cfg.Selector == "" {
+			return nil, fmt.Errorf("chart group %d: 'selector' can not be empty", i+1)
+		}
+		if cfg.DimensionLabel == "" {
+			return nil, fmt.Errorf("chart group %d: 'dimension_label' can not be empty", i+1)
+		}
+		sr, err := selector.Parse(cfg.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("chart group %d: parsing selector: %v", i+1, err)
+		}
+		groups = append(groups, &chartGroup{sr: sr, dimLabel: cfg.DimensionLabel})
+	}
+
+	return groups, nil
+}
+
+func (c *Collector) initChartOverrides() ([]*chartOverride, error) {
+	var overrides []*chartOverride
+
+	for i, cfg := range c.ChartOverrides {
+		if cfg.Selector == "" {
+			return nil, fmt.Errorf("chart override %d: 'selector' can not be empty", i+1)
+		}
+		sr, err := selector.Parse(cfg.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("chart override %d: parsing selector: %v", i+1, err)
+		}
+		overrides = append(overrides, &chartOverride{
+			sr:     sr,
+			title:  cfg.Title,
+			units:  cfg.Units,
+			family: cfg.Family,
+		})
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package prometheus
+
+import (
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/prometheus/selector"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+)
+
+type (
+	chartGroup struct {
+		sr       selector.Selector
+		dimLabel string
+	}
+	chartOverride struct {
+		sr     selector.Selector
+		title  string
+		units  string
+		family string
+	}
+)
+
+// relabelSeries applies the relabeling rules to a time series.
+// It returns the (possibly renamed) metric name, the resulting labels and false if the series was dropped.
+func (c *Collector) relabelSeries(name string, lbs labels.Labels) (string, labels.Labels, bool) {
+	if len(c.relabelConfigs) == 0 {
+		return name, lbs, true
+	}
+
+	lb := labels.NewBuilder(lbs)
+	lb.Set(labels.MetricName, name)
+
+	if !relabel.ProcessBuilder(lb, c.relabelConfigs...) {
+		return "", nil, false
+	}
+
+	if name = lb.Get(labels.MetricName); name == "" {
+		return "", nil, false
+	}
+	lb.Del(labels.MetricName)
+
+	return name, lb.Labels(), true
+}
+
+// findChartGroup returns the first chart group that matches the time series and has its dimension label.
+func (c *Collector) findChartGroup(name string, lbs labels.Labels) *chartGroup {
+	if len(c.chartGroups) == 0 {
+		return nil
+	}
+
+	series := seriesLabels(name, lbs)
+
+	for _, grp := range c.chartGroups {
+		if lbs.Get(grp.dimLabel) != "" && grp.sr.Matches(series) {
+			return grp
+		}
+	}
+	return nil
+}
+
+// findChartOverride merges all chart overrides that match the time series, later rules take precedence.
+func (c *Collector) findChartOverride(name string, lbs labels.Labels) chartOverride {
+	var ov chartOverride
+	if len(c.chartOverrides) == 0 {
+		return ov
+	}
+
+	series := seriesLabels(name, lbs)
+
+	for _, v := range c.chartOverrides {
+		if !v.sr.Matches(series) {
+			continue
+		}
+		if v.title != "" {
+			ov.title = v.title
+		}
+		if v.units != "" {
+			ov.units = v.units
+		}
+		if v.family != "" {
+			ov.family = v.family
+		}
+	}
+	return ov
+}
+
+// seriesLabels returns the time series labels with the metric name first, as expected by selectors.
+func seriesLabels(name string, lbs labels.Labels) labels.Labels {
+	series := make(labels.Labels, 0, len(lbs)+1)
+	series = append(series, labels.Label{Name: labels.MetricName, Value: name})
+	return append(series, lbs...)
+}
@@ -4,18 +4,34 @@ package rabbitmq
 
 func newCache() *cache {
 	return &cache{
-		nodes:  make(map[string]*nodeCacheItem),
-		vhosts: make(map[string]*vhostCacheItem),
-		queues: make(map[string]*queueCacheItem),
+		nodes:           make(map[string]*nodeCacheItem),
+		vhosts:          make(map[string]*vhostCacheItem),
+		queues:          make(map[string]*queueCacheItem),
+		shovels:         make(map[shovelKey]*shovelCacheItem),
+		federationLinks: make(map[federationLinkKey]*federationLinkCacheItem),
 	}
 }
 
 type (
 	cache struct {
-		overview struct{ hasCharts bool }
-		nodes    map[string]*nodeCacheItem
-		vhosts   map[string]*vhostCacheItem
-		queues   map[string]*queueCacheItem
+		overview        struct{ hasCharts bool }
+		nodes           map[string]*nodeCacheItem
+		vhosts          map[string]*vhostCacheItem
+		queues          map[string]*queueCacheItem
+		shovels         map[shovelKey]*shovelCacheItem
+		federationLinks map[federationLinkKey]*federationLinkCacheItem
+	}
+	// vhost, shovel, upstream and exchange/queue names can contain any character,
+	// so composite keys are structs rather than joined strings
+	shovelKey struct {
+		vhost string
+		name  string
+	}
+	federationLinkKey struct {
+		vhost    string
+		upstream string
+		typ      string
+		target   string
 	}
 	nodeCacheItem struct {
 		name      string
@@ -40,6 +56,22 @@ type (
 		vhost     string
 		typ       string
 		seen      bool
+		missed    int // consecutive collections the queue was not among the top N
+		hasCharts bool
+	}
+	shovelCacheItem struct {
+		name      string
+		vhost     string
+		typ       string
+		seen      bool
+		hasCharts bool
+	}
+	federationLinkCacheItem struct {
+		upstream  string
+		vhost     string
+		typ       string // "exchange" or "queue"
+		target    string // federated exchange or queue name
+		seen      bool
 		hasCharts bool
 	}
 )
@@ -57,6 +89,12 @@ func (c *cache) resetSeen() {
 	for _, v := range c.queues {
 		v.seen = false
 	}
+	for _, v := range c.shovels {
+		v.seen = false
+	}
+	for _, v := range c.federationLinks {
+		v.seen = false
+	}
 }
 
 func (c *cache) getNode(node apiNodeResp) *nodeCacheItem {
@@ -96,3 +134,27 @@ func (c *cache) getVhost(vhost string) *vhostCacheItem {
 	}
 	return v
 }
+
+func (c *cache) getShovel(sh apiShovelResp) *shovelCacheItem {
+	key := shovelKey{vhost: sh.Vhost, name: sh.Name}
+	v, ok := c.shovels[key]
+	if !ok {
+		v = &shovelCacheItem{name: sh.Name, vhost: sh.Vhost, typ: sh.Type}
+		c.shovels[key] = v
+	}
+	return v
+}
+
+func (c *cache) getFederationLink(link apiFederationLinkResp) *federationLinkCacheItem {
+	target := link.Exchange
+	if link.Type == "queue" {
+		target = link.Queue
+	}
+	key := federationLinkKey{vhost: link.Vhost, upstream: link.Upstream, typ: link.Type, target: target}
+	v, ok := c.federationLinks[key]
+	if !ok {
+		v = &federationLinkCacheItem{upstream: link.Upstream, vhost: link.Vhost, typ: link.Type, target: target}
+		c.federationLinks[key] = v
+	}
+	return v
+}
@@ -38,6 +38,13 @@ const (
 	prioQueueStatus
 	prioQueueMessagesCount
 	prioQueueMessagesRate
+	prioQueueStreamCommittedOffset
+	prioQueueStreamConsumers
+	prioQueueStreamConsumerLag
+
+	prioShovelStatus
+
+	prioFederationLinkStatus
 )
 
 var overviewCharts = module.Charts{
@@ -430,6 +437,97 @@ var (
 	}
 )
 
+var queueStreamChartsTmpl = module.Charts{
+	queueStreamCommittedOffsetChartTmpl.Copy(),
+}
+
+var queueStreamConsumerChartsTmpl = module.Charts{
+	queueStreamConsumersChartTmpl.Copy(),
+	queueStreamConsumerLagChartTmpl.Copy(),
+}
+
+var (
+	queueStreamCommittedOffsetChartTmpl = module.Chart{
+		ID:       "queue_%s_vhost_%s_node_%s_stream_committed_offset",
+		Title:    "Stream committed offset",
+		Units:    "offset",
+		Fam:      "queue stream",
+		Ctx:      "rabbitmq.queue_stream_committed_offset",
+		Type:     module.Line,
+		Priority: prioQueueStreamCommittedOffset,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_node_%s_stream_committed_offset", Name: "committed"},
+		},
+	}
+	queueStreamConsumersChartTmpl = module.Chart{
+		ID:       "queue_%s_vhost_%s_node_%s_stream_consumers",
+		Title:    "Stream consumers",
+		Units:    "consumers",
+		Fam:      "queue stream",
+		Ctx:      "rabbitmq.queue_stream_consumers",
+		Type:     module.Line,
+		Priority: prioQueueStreamConsumers,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_node_%s_stream_consumers", Name: "consumers"},
+		},
+	}
+	queueStreamConsumerLagChartTmpl = module.Chart{
+		ID:       "queue_%s_vhost_%s_node_%s_stream_consumer_lag",
+		Title:    "Stream consumer lag",
+		Units:    "messages",
+		Fam:      "queue stream",
+		Ctx:      "rabbitmq.queue_stream_consumer_lag",
+		Type:     module.Line,
+		Priority: prioQueueStreamConsumerLag,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_node_%s_stream_consumer_max_offset_lag", Name: "max_lag"},
+		},
+	}
+)
+
+var shovelChartsTmpl = module.Charts{
+	shovelStatusChartTmpl.Copy(),
+}
+
+var (
+	shovelStatusChartTmpl = module.Chart{
+		ID:       "shovel_%s_vhost_%s_status",
+		Title:    "Shovel status",
+		Units:    "status",
+		Fam:      "shovel status",
+		Ctx:      "rabbitmq.shovel_status",
+		Type:     module.Line,
+		Priority: prioShovelStatus,
+		Dims: module.Dims{
+			{ID: "shovel_%s_vhost_%s_status_running", Name: "running"},
+			{ID: "shovel_%s_vhost_%s_status_starting", Name: "starting"},
+			{ID: "shovel_%s_vhost_%s_status_terminated", Name: "terminated"},
+		},
+	}
+)
+
+var federationLinkChartsTmpl = module.Charts{
+	federationLinkStatusChartTmpl.Copy(),
+}
+
+var (
+	federationLinkStatusChartTmpl = module.Chart{
+		ID:       "federation_link_%s_vhost_%s_upstream_%s_status",
+		Title:    "Federation link status",
+		Units:    "status",
+		Fam:      "federation status",
+		Ctx:      "rabbitmq.federation_link_status",
+		Type:     module.Line,
+		Priority: prioFederationLinkStatus,
+		Dims: module.Dims{
+			{ID: "federation_link_%s_vhost_%s_upstream_%s_status_running", Name: "running"},
+			{ID: "federation_link_%s_vhost_%s_upstream_%s_status_starting", Name: "starting"},
+			{ID: "federation_link_%s_vhost_%s_upstream_%s_status_error", Name: "error"},
+			{ID: "federation_link_%s_vhost_%s_upstream_%s_status_shutdown", Name: "shutdown"},
+		},
+	}
+)
+
 func (c *Collector) updateCharts() {
 	if !c.cache.overview.hasCharts {
 		c.cache.overview.hasCharts = true
@@ -473,15 +571,45 @@ func (c *Collector) updateCharts() {
 
 	maps.DeleteFunc(c.cache.queues, func(_ string, queue *queueCacheItem) bool {
 		if !queue.seen {
+			// with top N, queues near the cutoff move in and out of it, keep their charts for a while
+			queue.missed++
+			if c.QueuesTopN > 0 && queue.missed < queuesTopNRemoveGrace {
+				return false
+			}
 			c.removeQueueCharts(queue)
 			return true
 		}
+		queue.missed = 0
 		if !queue.hasCharts {
 			queue.hasCharts = true
 			c.addQueueCharts(queue)
 		}
 		return false
 	})
+
+	maps.DeleteFunc(c.cache.shovels, func(_ shovelKey, shovel *shovelCacheItem) bool {
+		if !shovel.seen {
+			c.removeShovelCharts(shovel)
+			return true
+		}
+		if !shovel.hasCharts {
+			shovel.hasCharts = true
+			c.addShovelCharts(shovel)
+		}
+		return false
+	})
+
+	maps.DeleteFunc(c.cache.federationLinks, func(_ federationLinkKey, link *federationLinkCacheItem) bool {
+		if !link.seen {
+			c.removeFederationLinkCharts(link)
+			return true
+		}
+		if !link.hasCharts {
+			link.hasCharts = true
+			c.addFederationLinkCharts(link)
+		}
+		return false
+	})
 }
 
 func (c *Collector) addOverviewCharts() {
@@ -579,6 +707,13 @@ func (c *Collector) removeVhostCharts(vhost *vhostCacheItem) {
 func (c *Collector) addQueueCharts(q *queueCacheItem) {
 	charts := queueChartsTmpl.Copy()
 
+	if q.typ == "stream" {
+		charts = append(*charts, *queueStreamChartsTmpl.Copy()...).Copy()
+		if !c.skipStreamConsumers {
+			charts = append(*charts, *queueStreamConsumerChartsTmpl.Copy()...).Copy()
+		}
+	}
+
 	for _, chart := range *charts {
 		chart.ID = fmt.Sprintf(chart.ID, q.name, q.vhost, q.node)
 		chart.ID = cleanChartId(chart.ID)
@@ -605,6 +740,61 @@ func (c *Collector) removeQueueCharts(q *queueCacheItem) {
 	c.removeCharts(px)
 }
 
+func (c *Collector) addShovelCharts(sh *shovelCacheItem) {
+	charts := shovelChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = cleanChartId(fmt.Sprintf(chart.ID, sh.name, sh.vhost))
+		chart.Labels = []module.Label{
+			{Key: "cluster_id", Value: c.clusterId},
+			{Key: "cluster_name", Value: c.clusterName},
+			{Key: "shovel", Value: sh.name},
+			{Key: "vhost", Value: sh.vhost},
+			{Key: "type", Value: sh.typ},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, sh.name, sh.vhost)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warningf("failed to add shovel charts: %v", err)
+	}
+}
+
+func (c *Collector) removeShovelCharts(sh *shovelCacheItem) {
+	px := fmt.Sprintf("shovel_%s_vhost_%s_", sh.name, sh.vhost)
+	c.removeCharts(px)
+}
+
+func (c *Collector) addFederationLinkCharts(link *federationLinkCacheItem) {
+	charts := federationLinkChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = cleanChartId(fmt.Sprintf(chart.ID, link.target, link.vhost, link.upstream))
+		chart.Labels = []module.Label{
+			{Key: "cluster_id", Value: c.clusterId},
+			{Key: "cluster_name", Value: c.clusterName},
+			{Key: "upstream", Value: link.upstream},
+			{Key: "vhost", Value: link.vhost},
+			{Key: "type", Value: link.typ},
+			{Key: link.typ, Value: link.target},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, link.target, link.vhost, link.upstream)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warningf("failed to add federation link charts: %v", err)
+	}
+}
+
+func (c *Collector) removeFederationLinkCharts(link *federationLinkCacheItem) {
+	px := fmt.Sprintf("federation_link_%s_vhost_%s_upstream_%s_", link.target, link.vhost, link.upstream)
+	c.removeCharts(px)
+}
+
 func (c *Collector) removeCharts(prefix string) {
 	prefix = cleanChartId(prefix)
 	for _, chart := range *c.Charts() {
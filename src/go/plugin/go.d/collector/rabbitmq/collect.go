@@ -41,6 +41,16 @@ func (c *Collector) collect() (map[string]int64, error) {
 			return mx, err
 		}
 	}
+	if !c.skipShovels {
+		if err := c.collectShovels(mx); err != nil {
+			return mx, err
+		}
+	}
+	if !c.skipFederationLinks {
+		if err := c.collectFederationLinks(mx); err != nil {
+			return mx, err
+		}
+	}
 
 	c.updateCharts()
 
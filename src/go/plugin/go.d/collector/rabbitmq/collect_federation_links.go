@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package rabbitmq
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+func (c *Collector) collectFederationLinks(mx map[string]int64) error {
+	req, err := web.NewHTTPRequestWithPath(c.RequestConfig, urlPathAPIFederationLinks)
+	if err != nil {
+		return fmt.Errorf("failed to create federation links request: %w", err)
+	}
+
+	var resp []apiFederationLinkResp
+
+	if err := c.webClient().RequestJSON(req, &resp); err != nil {
+		if !web.IsStatusCode(err, http.StatusNotFound) {
+			return err
+		}
+		c.Info("federation links are not available (rabbitmq_federation_management plugin is not enabled), skipping.")
+		c.skipFederationLinks = true
+		return nil
+	}
+
+	for _, link := range resp {
+		item := c.cache.getFederationLink(link)
+		item.seen = true
+
+		px := fmt.Sprintf("federation_link_%s_vhost_%s_upstream_%s_", item.target, link.Vhost, link.Upstream)
+
+		// https://github.com/rabbitmq/rabbitmq-server/blob/main/deps/rabbitmq_federation/src/rabbit_federation_status.erl
+		for _, v := range []string{"running", "starting", "error", "shutdown"} {
+			mx[px+"status_"+v] = metrix.Bool(v == link.Status)
+		}
+	}
+
+	return nil
+}
@@ -3,26 +3,46 @@
 package rabbitmq
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/stm"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
 )
 
+// queuesMaxPageSize is the largest page size the management API accepts.
+const queuesMaxPageSize = 500
+
+// queuesTopNRemoveGrace is the number of consecutive collections a queue can be out of the top N
+// before its charts are removed.
+const queuesTopNRemoveGrace = 10
+
+// queuesColumns are the queue fields the collector uses, the rest (consumer details, arguments, etc.) are not requested.
+var queuesColumns = strings.Join([]string{
+	"name", "node", "vhost", "type", "state", "idle_since", "committed_offset",
+	"messages", "messages_ready", "messages_unacknowledged", "messages_paged_out", "messages_persistent",
+	"message_stats",
+}, ",")
+
 func (c *Collector) collectQueues(mx map[string]int64) error {
-	req, err := web.NewHTTPRequestWithPath(c.RequestConfig, urlPathAPIQueues)
+	resp, filtered, err := c.queryQueues()
 	if err != nil {
-		return fmt.Errorf("failed to create queues stats request: %w", err)
+		return err
 	}
 
-	var resp []apiQueueResp
+	queues := c.selectQueues(resp, filtered)
 
-	if err := c.webClient().RequestJSON(req, &resp); err != nil {
-		return err
-	}
+	var streams []apiQueueResp
 
-	for _, q := range resp {
+	for _, q := range queues {
 		c.cache.getQueue(q).seen = true
 
 		px := fmt.Sprintf("queue_%s_vhost_%s_node_%s_", q.Name, q.Vhost, q.Node)
@@ -39,6 +59,152 @@ func (c *Collector) collectQueues(mx map[string]int64) error {
 		for _, v := range []string{"running", "idle", "terminated", "down", "crashed", "stopped", "minority"} {
 			mx[px+"status_"+v] = metrix.Bool(v == st)
 		}
+
+		if q.Type == "stream" {
+			streams = append(streams, q)
+		}
+	}
+
+	if len(streams) > 0 {
+		if err := c.collectStreams(mx, streams); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryQueues requests the queues page by page, letting the server filter them by name and sort them by backlog.
+// If the queues selector matches all queues, only the first queues_top_n queues are requested.
+// Versions without pagination ignore the query parameters and return all queues at once,
+// the returned flag reports whether the queues were filtered by the server.
+func (c *Collector) queryQueues() ([]apiQueueResp, bool, error) {
+	topN := c.QueuesTopN > 0 && (c.QueuesSelector == "" || c.QueuesSelector == "*")
+
+	pageSize := queuesMaxPageSize
+	if topN {
+		pageSize = min(c.QueuesTopN, queuesMaxPageSize)
+	}
+
+	var queues []apiQueueResp
+
+	for page := 1; ; page++ {
+		req, err := web.NewHTTPRequestWithPath(c.RequestConfig, urlPathAPIQueues)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to create queues stats request: %w", err)
+		}
+
+		q := url.Values{
+			"page":         {strconv.Itoa(page)},
+			"page_size":    {strconv.Itoa(pageSize)},
+			"sort":         {"messages"},
+			"sort_reverse": {"true"},
+			"columns":      {queuesColumns},
+		}
+		if c.QueuesNameRegex != "" {
+			q.Set("name", c.QueuesNameRegex)
+			q.Set("use_regex", "true")
+		}
+		req.URL.RawQuery = q.Encode()
+
+		var raw json.RawMessage
+		if err := c.webClient().RequestJSON(req, &raw); err != nil {
+			return nil, false, err
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+			// no pagination support: all queues, unfiltered
+			var resp []apiQueueResp
+			if err := json.Unmarshal(raw, &resp); err != nil {
+				return nil, false, fmt.Errorf("failed to decode queues response: %w", err)
+			}
+			return resp, false, nil
+		}
+
+		var resp apiQueuesPageResp
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, false, fmt.Errorf("failed to decode queues response: %w", err)
+		}
+		queues = append(queues, resp.Items...)
+
+		if (topN && len(queues) >= c.QueuesTopN) || page >= resp.PageCount {
+			return queues, true, nil
+		}
+	}
+}
+
+// selectQueues applies the queues selector (matched against "vhost/name") and keeps
+// the top N queues by message backlog. The name regex is applied only if the server did not filter the queues.
+func (c *Collector) selectQueues(queues []apiQueueResp, filtered bool) []apiQueueResp {
+	queues = slices.DeleteFunc(queues, func(q apiQueueResp) bool {
+		if !filtered && c.queuesNameRe != nil && !c.queuesNameRe.MatchString(q.Name) {
+			return true
+		}
+		return c.queuesSr != nil && !c.queuesSr.MatchString(q.Vhost+"/"+q.Name)
+	})
+
+	if c.QueuesTopN <= 0 || len(queues) <= c.QueuesTopN {
+		return queues
+	}
+
+	slices.SortFunc(queues, func(a, b apiQueueResp) int {
+		if v := cmp.Compare(b.Messages, a.Messages); v != 0 {
+			return v
+		}
+		return cmp.Compare(a.Vhost+"/"+a.Name, b.Vhost+"/"+b.Name)
+	})
+
+	return queues[:c.QueuesTopN]
+}
+
+func (c *Collector) collectStreams(mx map[string]int64, streams []apiQueueResp) error {
+	type lag struct{ consumers, maxLag int64 }
+	lags := make(map[string]*lag)
+
+	if !c.skipStreamConsumers {
+		req, err := web.NewHTTPRequestWithPath(c.RequestConfig, urlPathAPIStreamConsumers)
+		if err != nil {
+			return fmt.Errorf("failed to create stream consumers request: %w", err)
+		}
+
+		var resp []apiStreamConsumerResp
+
+		if err := c.webClient().RequestJSON(req, &resp); err != nil {
+			if !web.IsStatusCode(err, http.StatusNotFound) {
+				return err
+			}
+			c.Warning("stream consumers are not available (rabbitmq_stream_management plugin is not enabled), stream consumer lag will not be collected.")
+			c.skipStreamConsumers = true
+		}
+
+		for _, cons := range resp {
+			key := cons.Queue.Vhost + "/" + cons.Queue.Name
+			v, ok := lags[key]
+			if !ok {
+				v = &lag{}
+				lags[key] = v
+			}
+			v.consumers++
+			v.maxLag = max(v.maxLag, cons.OffsetLag)
+		}
+	}
+
+	for _, q := range streams {
+		px := fmt.Sprintf("queue_%s_vhost_%s_node_%s_", q.Name, q.Vhost, q.Node)
+
+		mx[px+"stream_committed_offset"] = 0
+		if q.CommittedOffset != nil {
+			mx[px+"stream_committed_offset"] = *q.CommittedOffset
+		}
+		if c.skipStreamConsumers {
+			continue
+		}
+		mx[px+"stream_consumers"] = 0
+		mx[px+"stream_consumer_max_offset_lag"] = 0
+		if v, ok := lags[q.Vhost+"/"+q.Name]; ok {
+			mx[px+"stream_consumers"] = v.consumers
+			mx[px+"stream_consumer_max_offset_lag"] = v.maxLag
+		}
 	}
 
 	return nil
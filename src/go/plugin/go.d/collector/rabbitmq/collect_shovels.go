@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package rabbitmq
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+func (c *Collector) collectShovels(mx map[string]int64) error {
+	req, err := web.NewHTTPRequestWithPath(c.RequestConfig, urlPathAPIShovels)
+	if err != nil {
+		return fmt.Errorf("failed to create shovels request: %w", err)
+	}
+
+	var resp []apiShovelResp
+
+	if err := c.webClient().RequestJSON(req, &resp); err != nil {
+		if !web.IsStatusCode(err, http.StatusNotFound) {
+			return err
+		}
+		c.Info("shovels are not available (rabbitmq_shovel_management plugin is not enabled), skipping.")
+		c.skipShovels = true
+		return nil
+	}
+
+	for _, sh := range resp {
+		c.cache.getShovel(sh).seen = true
+
+		px := fmt.Sprintf("shovel_%s_vhost_%s_", sh.Name, sh.Vhost)
+
+		// https://github.com/rabbitmq/rabbitmq-server/blob/main/deps/rabbitmq_shovel/src/rabbit_shovel_status.erl
+		for _, v := range []string{"running", "starting", "terminated"} {
+			mx[px+"status_"+v] = metrix.Bool(v == sh.State)
+		}
+	}
+
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"time"
 
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
@@ -39,7 +41,8 @@ func New() *Collector {
 					Timeout: confopt.Duration(time.Second),
 				},
 			},
-			CollectQueues: false,
+			CollectQueues:  false,
+			QueuesSelector: "*",
 		},
 
 		charts:           &module.Charts{},
@@ -49,10 +52,13 @@ func New() *Collector {
 }
 
 type Config struct {
-	Vnode          string `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery    int    `yaml:"update_every,omitempty" json:"update_every"`
-	web.HTTPConfig `yaml:",inline" json:""`
-	CollectQueues  bool `yaml:"collect_queues_metrics" json:"collect_queues_metrics"`
+	Vnode           string `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery     int    `yaml:"update_every,omitempty" json:"update_every"`
+	web.HTTPConfig  `yaml:",inline" json:""`
+	CollectQueues   bool   `yaml:"collect_queues_metrics" json:"collect_queues_metrics"`
+	QueuesSelector  string `yaml:"queues_selector,omitempty" json:"queues_selector"`
+	QueuesNameRegex string `yaml:"queues_name_regex,omitempty" json:"queues_name_regex"`
+	QueuesTopN      int    `yaml:"queues_top_n,omitempty" json:"queues_top_n"`
 }
 
 type Collector struct {
@@ -63,10 +69,19 @@ type Collector struct {
 
 	httpClient *http.Client
 
+	queuesSr     matcher.Matcher
+	queuesNameRe *regexp.Regexp
+
 	queryClusterMeta bool
 	clusterName      string
 	clusterId        string
 	cache            *cache
+
+	// the shovel, federation and stream management plugins are optional,
+	// their endpoints return 404 if the plugin is not enabled.
+	skipShovels         bool
+	skipFederationLinks bool
+	skipStreamConsumers bool
 }
 
 func (c *Collector) Configuration() any {
@@ -84,6 +99,18 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.httpClient = client
 
+	sr, err := c.initQueuesSelector()
+	if err != nil {
+		return fmt.Errorf("init queues selector: %v", err)
+	}
+	c.queuesSr = sr
+
+	re, err := c.initQueuesNameRegex()
+	if err != nil {
+		return fmt.Errorf("init queues name regex: %v", err)
+	}
+	c.queuesNameRe = re
+
 	c.Debugf("using URL %s", c.URL)
 	c.Debugf("using timeout: %s", c.Timeout)
 
@@ -3,10 +3,17 @@
 package rabbitmq
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
@@ -26,6 +33,10 @@ var (
 	dataClusterNodes, _       = os.ReadFile("testdata/v4.0.3/cluster/nodes.json")
 	dataClusterVhosts, _      = os.ReadFile("testdata/v4.0.3/cluster/vhosts.json")
 	dataClusterQueues, _      = os.ReadFile("testdata/v4.0.3/cluster/queues.json")
+
+	dataClusterShovels, _         = os.ReadFile("testdata/v4.0.3/cluster/shovels.json")
+	dataClusterFederationLinks, _ = os.ReadFile("testdata/v4.0.3/cluster/federation-links.json")
+	dataClusterStreamConsumers, _ = os.ReadFile("testdata/v4.0.3/cluster/stream-consumers.json")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -38,6 +49,10 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataClusterNodes":       dataClusterNodes,
 		"dataClusterVhosts":      dataClusterVhosts,
 		"dataClusterQueues":      dataClusterQueues,
+
+		"dataClusterShovels":         dataClusterShovels,
+		"dataClusterFederationLinks": dataClusterFederationLinks,
+		"dataClusterStreamConsumers": dataClusterStreamConsumers,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -131,7 +146,11 @@ func TestCollector_Collect(t *testing.T) {
 				len(nodeClusterPeerChartsTmpl)*2 +
 				len(nodeChartsTmpl)*2 +
 				len(vhostChartsTmpl)*2 +
-				len(queueChartsTmpl)*4,
+				len(queueChartsTmpl)*5 +
+				len(queueStreamChartsTmpl) +
+				len(queueStreamConsumerChartsTmpl) +
+				len(shovelChartsTmpl)*2 +
+				len(federationLinkChartsTmpl)*2,
 			wantCollected: map[string]int64{
 				"churn_rates_channel_closed":                                                                         7,
 				"churn_rates_channel_created":                                                                        7,
@@ -341,6 +360,51 @@ func TestCollector_Collect(t *testing.T) {
 				"vhost_myFirstVhost_status_partial":                  0,
 				"vhost_myFirstVhost_status_running":                  1,
 				"vhost_myFirstVhost_status_stopped":                  0,
+
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_ack":               0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_confirm":           0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_deliver":           0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_deliver_get":       0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_deliver_no_ack":    0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_get":               0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_get_empty":         0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_get_no_ack":        0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_publish":           0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_publish_in":        0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_publish_out":       0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_redeliver":         0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_message_stats_return_unroutable": 0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_messages":                        15000,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_messages_paged_out":              0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_messages_persistent":             0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_messages_ready":                  15000,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_messages_unacknowledged":         0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_status_crashed":                  0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_status_down":                     0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_status_idle":                     0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_status_minority":                 0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_status_running":                  1,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_status_stopped":                  0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_status_terminated":               0,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_stream_committed_offset":         14998,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_stream_consumer_max_offset_lag":  2998,
+				"queue_MyStream_vhost_/_node_rabbit@pve-deb-work_stream_consumers":                2,
+
+				"shovel_my-broken-shovel_vhost_myFirstVhost_status_running":    0,
+				"shovel_my-broken-shovel_vhost_myFirstVhost_status_starting":   0,
+				"shovel_my-broken-shovel_vhost_myFirstVhost_status_terminated": 1,
+				"shovel_my-shovel_vhost_/_status_running":                      1,
+				"shovel_my-shovel_vhost_/_status_starting":                     0,
+				"shovel_my-shovel_vhost_/_status_terminated":                   0,
+
+				"federation_link_fed.exchange_vhost_/_upstream_dc2_status_error":            0,
+				"federation_link_fed.exchange_vhost_/_upstream_dc2_status_running":          1,
+				"federation_link_fed.exchange_vhost_/_upstream_dc2_status_shutdown":         0,
+				"federation_link_fed.exchange_vhost_/_upstream_dc2_status_starting":         0,
+				"federation_link_fed.queue_vhost_myFirstVhost_upstream_dc3_status_error":    1,
+				"federation_link_fed.queue_vhost_myFirstVhost_upstream_dc3_status_running":  0,
+				"federation_link_fed.queue_vhost_myFirstVhost_upstream_dc3_status_shutdown": 0,
+				"federation_link_fed.queue_vhost_myFirstVhost_upstream_dc3_status_starting": 0,
 			},
 		},
 		"fails on unexpected JSON response": {
@@ -376,7 +440,160 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_QueuesSelectorTopN(t *testing.T) {
+	collr, cleanup := caseClusterOkQueuesSelectorTopN()
+	defer cleanup()
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	var queues []string
+	for _, q := range collr.cache.queues {
+		queues = append(queues, q.vhost+"/"+q.name)
+	}
+
+	assert.ElementsMatch(t, []string{"//MyStream", "//MyFirstQueue"}, queues)
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_Collect_QueuesTopNRemoveGrace(t *testing.T) {
+	collr, cleanup := caseClusterOkQueuesSelectorTopN()
+	defer cleanup()
+
+	require.NoError(t, collr.Init(context.Background()))
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	isObsolete := func() bool {
+		for _, chart := range *collr.Charts() {
+			if slices.Contains(chart.Labels, module.Label{Key: "vhost", Value: "/"}) &&
+				slices.Contains(chart.Labels, module.Label{Key: "queue", Value: "MyFirstQueue"}) {
+				return chart.Obsolete
+			}
+		}
+		t.Fatal("no MyFirstQueue charts")
+		return false
+	}
+
+	// MyFirstQueue drops out of the top N
+	collr.QueuesTopN = 1
+
+	for i := 1; i < queuesTopNRemoveGrace; i++ {
+		require.NotNil(t, collr.Collect(context.Background()))
+		require.Falsef(t, isObsolete(), "collection %d", i)
+	}
+	require.NotNil(t, collr.Collect(context.Background()))
+	assert.True(t, isObsolete())
+	assert.Len(t, collr.cache.queues, 1)
+}
+
+func TestCollector_Collect_QueuesServerSideFiltering(t *testing.T) {
+	tests := map[string]struct {
+		selector   string
+		nameRegex  string
+		topN       int
+		wantQuery  url.Values
+		wantQueues []string
+	}{
+		"top N is requested from the server": {
+			topN: 1,
+			wantQuery: url.Values{
+				"page": {"1"}, "page_size": {"1"}, "sort": {"messages"}, "sort_reverse": {"true"}, "columns": {queuesColumns},
+			},
+			wantQueues: []string{"//MyStream"},
+		},
+		"name regex is applied by the server": {
+			selector:  "!myFirstVhost/* *",
+			nameRegex: "^My(First|Second)Queue$",
+			topN:      1,
+			wantQuery: url.Values{
+				"page": {"1"}, "page_size": {"500"}, "sort": {"messages"}, "sort_reverse": {"true"}, "columns": {queuesColumns},
+				"name": {"^My(First|Second)Queue$"}, "use_regex": {"true"},
+			},
+			wantQueues: []string{"//MyFirstQueue"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr, cleanup, queries := prepareClusterCasePaginated(t)
+			defer cleanup()
+			collr.QueuesSelector = test.selector
+			collr.QueuesNameRegex = test.nameRegex
+			collr.QueuesTopN = test.topN
+
+			require.NoError(t, collr.Init(context.Background()))
+
+			mx := collr.Collect(context.Background())
+			require.NotNil(t, mx)
+
+			var queues []string
+			for _, q := range collr.cache.queues {
+				queues = append(queues, q.vhost+"/"+q.name)
+			}
+
+			assert.ElementsMatch(t, test.wantQueues, queues)
+			assert.Equal(t, []url.Values{test.wantQuery}, *queries)
+			module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+		})
+	}
+}
+
+func TestCollector_Collect_QueuesNameRegexNoPagination(t *testing.T) {
+	collr, cleanup := prepareClusterCase(true)
+	defer cleanup()
+	collr.QueuesNameRegex = "^MyFirst"
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	var queues []string
+	for _, q := range collr.cache.queues {
+		queues = append(queues, q.vhost+"/"+q.name)
+	}
+
+	assert.ElementsMatch(t, []string{"//MyFirstQueue", "myFirstVhost/MyFirstQueue"}, queues)
+}
+
+func TestCollector_Collect_NoManagementPlugins(t *testing.T) {
+	collr, cleanup := caseClusterOkNoManagementPlugins()
+	defer cleanup()
+
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.True(t, collr.skipShovels)
+	assert.True(t, collr.skipFederationLinks)
+	assert.True(t, collr.skipStreamConsumers)
+	assert.Empty(t, collr.cache.shovels)
+	assert.Empty(t, collr.cache.federationLinks)
+	assert.NotContains(t, mx, "queue_MyStream_vhost_/_node_rabbit@pve-deb-work_stream_consumers")
+	assert.Contains(t, mx, "queue_MyStream_vhost_/_node_rabbit@pve-deb-work_stream_committed_offset")
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
 func caseClusterOk() (*Collector, func()) {
+	return prepareClusterCase(true)
+}
+
+func caseClusterOkQueuesSelectorTopN() (*Collector, func()) {
+	collr, cleanup := prepareClusterCase(true)
+	collr.QueuesSelector = "!myFirstVhost/* *"
+	collr.QueuesTopN = 2
+
+	return collr, cleanup
+}
+
+func caseClusterOkNoManagementPlugins() (*Collector, func()) {
+	return prepareClusterCase(false)
+}
+
+func prepareClusterCase(withPlugins bool) (*Collector, func()) {
 	srv := httptest.NewServer(
 		http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
@@ -393,6 +610,24 @@ func caseClusterOk() (*Collector, func()) {
 					_, _ = w.Write(dataClusterVhosts)
 				case urlPathAPIQueues:
 					_, _ = w.Write(dataClusterQueues)
+				case urlPathAPIShovels:
+					if !withPlugins {
+						w.WriteHeader(404)
+						return
+					}
+					_, _ = w.Write(dataClusterShovels)
+				case urlPathAPIFederationLinks:
+					if !withPlugins {
+						w.WriteHeader(404)
+						return
+					}
+					_, _ = w.Write(dataClusterFederationLinks)
+				case urlPathAPIStreamConsumers:
+					if !withPlugins {
+						w.WriteHeader(404)
+						return
+					}
+					_, _ = w.Write(dataClusterStreamConsumers)
 				default:
 					w.WriteHeader(404)
 				}
@@ -404,6 +639,58 @@ func caseClusterOk() (*Collector, func()) {
 	return collr, srv.Close
 }
 
+// prepareClusterCasePaginated serves /api/queues like RabbitMQ 3.6+ (pagination, name filter and sorting),
+// other requests are proxied to the cluster case server. It returns the queries of the queues requests.
+func prepareClusterCasePaginated(t *testing.T) (*Collector, func(), *[]url.Values) {
+	t.Helper()
+
+	cluster, cleanup := prepareClusterCase(true)
+	target, err := url.Parse(cluster.URL)
+	require.NoError(t, err)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	var queues []apiQueueResp
+	require.NoError(t, json.Unmarshal(dataClusterQueues, &queues))
+
+	var queries []url.Values
+
+	srv := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != urlPathAPIQueues {
+					proxy.ServeHTTP(w, r)
+					return
+				}
+
+				q := r.URL.Query()
+				queries = append(queries, q)
+
+				items := slices.Clone(queues)
+				if q.Get("use_regex") == "true" {
+					re := regexp.MustCompile(q.Get("name"))
+					items = slices.DeleteFunc(items, func(v apiQueueResp) bool { return !re.MatchString(v.Name) })
+				}
+				slices.SortStableFunc(items, func(a, b apiQueueResp) int { return cmp.Compare(b.Messages, a.Messages) })
+
+				page, _ := strconv.Atoi(q.Get("page"))
+				size, _ := strconv.Atoi(q.Get("page_size"))
+				from, to := min((page-1)*size, len(items)), min(page*size, len(items))
+
+				bs, _ := json.Marshal(apiQueuesPageResp{
+					Items:     items[from:to],
+					Page:      page,
+					PageCount: (len(items) + size - 1) / size,
+				})
+				_, _ = w.Write(bs)
+			}))
+
+	collr := New()
+	collr.URL = srv.URL
+	collr.CollectQueues = true
+
+	return collr, func() { srv.Close(); cleanup() }, &queries
+}
+
 func caseUnexpectedJsonResponse() (*Collector, func()) {
 	resp := `
 {
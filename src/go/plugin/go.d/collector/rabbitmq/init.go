@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package rabbitmq
+
+import (
+	"regexp"
+
+	"github.com/netdata/netdata/go/plugins/pkg/matcher"
+)
+
+func (c *Collector) initQueuesSelector() (matcher.Matcher, error) {
+	if c.QueuesSelector == "" {
+		return matcher.TRUE(), nil
+	}
+	return matcher.NewSimplePatternsMatcher(c.QueuesSelector)
+}
+
+func (c *Collector) initQueuesNameRegex() (*regexp.Regexp, error) {
+	if c.QueuesNameRegex == "" {
+		return nil, nil
+	}
+	return regexp.Compile(c.QueuesNameRegex)
+}
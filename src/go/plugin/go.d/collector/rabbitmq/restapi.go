@@ -9,6 +9,10 @@ const (
 	urlPathAPINodes       = "/api/nodes"
 	urlPathAPIVhosts      = "/api/vhosts"
 	urlPathAPIQueues      = "/api/queues"
+
+	urlPathAPIShovels         = "/api/shovels"
+	urlPathAPIFederationLinks = "/api/federation-links"
+	urlPathAPIStreamConsumers = "/api/stream/consumers"
 )
 
 type apiWhoamiResp struct {
@@ -90,6 +94,13 @@ type apiVhostResp struct {
 }
 
 // https://www.rabbitmq.com/monitoring.html#queue-metrics
+// apiQueuesPageResp is the paginated /api/queues response (the 'page' query parameter is set).
+type apiQueuesPageResp struct {
+	Items     []apiQueueResp `json:"items"`
+	Page      int            `json:"page"`
+	PageCount int            `json:"page_count"`
+}
+
 type apiQueueResp struct {
 	Name                   string          `json:"name"`
 	Node                   string          `json:"node"`
@@ -97,6 +108,7 @@ type apiQueueResp struct {
 	Type                   string          `json:"type"`
 	State                  string          `json:"state"`
 	IdleSince              *any            `json:"idle_since"`
+	CommittedOffset        *int64          `json:"committed_offset"` // stream queues only
 	Messages               int64           `json:"messages" stm:"messages"`
 	MessagesReady          int64           `json:"messages_ready" stm:"messages_ready"`
 	MessagesUnacknowledged int64           `json:"messages_unacknowledged" stm:"messages_unacknowledged"`
@@ -105,6 +117,36 @@ type apiQueueResp struct {
 	MessageStats           apiMessageStats `json:"message_stats" stm:"message_stats"`
 }
 
+// https://www.rabbitmq.com/docs/shovel-dynamic#status
+type apiShovelResp struct {
+	Name  string `json:"name"`
+	Vhost string `json:"vhost"`
+	Node  string `json:"node"`
+	Type  string `json:"type"`
+	State string `json:"state"`
+}
+
+// https://www.rabbitmq.com/docs/federation-reference#status
+type apiFederationLinkResp struct {
+	Upstream string `json:"upstream"`
+	Vhost    string `json:"vhost"`
+	Node     string `json:"node"`
+	Type     string `json:"type"`
+	Exchange string `json:"exchange"`
+	Queue    string `json:"queue"`
+	Status   string `json:"status"`
+}
+
+// https://www.rabbitmq.com/docs/stream#monitoring
+type apiStreamConsumerResp struct {
+	Queue struct {
+		Name  string `json:"name"`
+		Vhost string `json:"vhost"`
+	} `json:"queue"`
+	Offset    int64 `json:"offset"`
+	OffsetLag int64 `json:"offset_lag"`
+}
+
 // https://rawcdn.githack.com/rabbitmq/rabbitmq-server/v3.11.5/deps/rabbitmq_management/priv/www/api/index.html
 type apiMessageStats struct {
 	Ack              int64 `json:"ack" stm:"ack"`
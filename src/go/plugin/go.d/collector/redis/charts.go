@@ -2,7 +2,13 @@
 
 package redis
 
-import "github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
 
 const (
 	prioConnections = module.Priority + iota
@@ -37,6 +43,37 @@ const (
 	prioKeys
 	prioExpiresKeys
 
+	prioSlowlogEntries
+	prioSlowlogLength
+	prioSlowlogMaxDuration
+	prioLatencyEventsLatest
+	prioLatencyEventsMax
+
+	prioClusterState
+	prioClusterSlots
+	prioClusterKnownNodes
+	prioClusterNodesByRole
+	prioClusterNodesByState
+	prioClusterEpoch
+	prioClusterMessages
+
+	prioClusterNodeState
+	prioClusterNodeCommands
+	prioClusterNodeClients
+	prioClusterNodeMemory
+	prioClusterNodeNet
+	prioClusterNodeKeyspaceLookups
+	prioClusterNodeKeys
+
+	prioSentinelMasters
+	prioSentinelTilt
+	prioSentinelScripts
+
+	prioSentinelMasterStatus
+	prioSentinelMasterQuorum
+	prioSentinelMasterReplicas
+	prioSentinelMasterFailovers
+
 	prioUptime
 )
 
@@ -381,3 +418,472 @@ var (
 		},
 	}
 )
+
+var (
+	chartSlowlogEntries = module.Chart{
+		ID:       "slowlog_entries",
+		Title:    "Commands logged to the slow log",
+		Units:    "entries/s",
+		Fam:      "slowlog",
+		Ctx:      "redis.slowlog_entries",
+		Priority: prioSlowlogEntries,
+		Dims: module.Dims{
+			{ID: "slowlog_entries", Name: "logged", Algo: module.Incremental},
+		},
+	}
+	chartSlowlogLength = module.Chart{
+		ID:       "slowlog_length",
+		Title:    "Slow log length",
+		Units:    "entries",
+		Fam:      "slowlog",
+		Ctx:      "redis.slowlog_length",
+		Priority: prioSlowlogLength,
+		Dims: module.Dims{
+			{ID: "slowlog_length", Name: "length"},
+		},
+	}
+	chartSlowlogMaxDuration = module.Chart{
+		ID:       "slowlog_max_duration",
+		Title:    "Longest execution time of the newly logged slow commands",
+		Units:    "seconds",
+		Fam:      "slowlog",
+		Ctx:      "redis.slowlog_max_duration",
+		Priority: prioSlowlogMaxDuration,
+		Dims: module.Dims{
+			{ID: "slowlog_max_duration", Name: "max", Div: 1e6},
+		},
+	}
+)
+
+var (
+	chartLatencyEventsLatest = module.Chart{
+		ID:       "latency_events_latest",
+		Title:    "Latest latency spike per event",
+		Units:    "milliseconds",
+		Fam:      "latency monitor",
+		Ctx:      "redis.latency_events_latest",
+		Priority: prioLatencyEventsLatest,
+	}
+	chartLatencyEventsMax = module.Chart{
+		ID:       "latency_events_max",
+		Title:    "All-time maximum latency spike per event",
+		Units:    "milliseconds",
+		Fam:      "latency monitor",
+		Ctx:      "redis.latency_events_max",
+		Priority: prioLatencyEventsMax,
+	}
+)
+
+var clusterCharts = module.Charts{
+	chartClusterState.Copy(),
+	chartClusterSlots.Copy(),
+	chartClusterKnownNodes.Copy(),
+	chartClusterNodesByRole.Copy(),
+	chartClusterNodesByState.Copy(),
+	chartClusterEpoch.Copy(),
+	chartClusterMessages.Copy(),
+}
+
+var (
+	chartClusterState = module.Chart{
+		ID:       "cluster_state",
+		Title:    "Cluster state",
+		Units:    "state",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_state",
+		Priority: prioClusterState,
+		Dims: module.Dims{
+			{ID: "cluster_state_ok", Name: "ok"},
+			{ID: "cluster_state_fail", Name: "fail"},
+		},
+	}
+	chartClusterSlots = module.Chart{
+		ID:       "cluster_slots",
+		Title:    "Cluster hash slots",
+		Units:    "slots",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_slots",
+		Type:     module.Stacked,
+		Priority: prioClusterSlots,
+		Dims: module.Dims{
+			{ID: "cluster_slots_ok", Name: "ok"},
+			{ID: "cluster_slots_pfail", Name: "pfail"},
+			{ID: "cluster_slots_fail", Name: "fail"},
+			{ID: "cluster_slots_unassigned", Name: "unassigned"},
+		},
+	}
+	chartClusterKnownNodes = module.Chart{
+		ID:       "cluster_known_nodes",
+		Title:    "Cluster known nodes and size",
+		Units:    "nodes",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_known_nodes",
+		Priority: prioClusterKnownNodes,
+		Dims: module.Dims{
+			{ID: "cluster_known_nodes", Name: "known"},
+			{ID: "cluster_size", Name: "serving_slots"},
+		},
+	}
+	chartClusterNodesByRole = module.Chart{
+		ID:       "cluster_nodes_by_role",
+		Title:    "Cluster nodes by role",
+		Units:    "nodes",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_nodes_by_role",
+		Type:     module.Stacked,
+		Priority: prioClusterNodesByRole,
+		Dims: module.Dims{
+			{ID: "cluster_nodes_role_master", Name: "master"},
+			{ID: "cluster_nodes_role_replica", Name: "replica"},
+		},
+	}
+	chartClusterNodesByState = module.Chart{
+		ID:       "cluster_nodes_by_state",
+		Title:    "Cluster nodes by state",
+		Units:    "nodes",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_nodes_by_state",
+		Type:     module.Stacked,
+		Priority: prioClusterNodesByState,
+		Dims: module.Dims{
+			{ID: "cluster_nodes_state_ok", Name: "ok"},
+			{ID: "cluster_nodes_state_pfail", Name: "pfail"},
+			{ID: "cluster_nodes_state_fail", Name: "fail"},
+			{ID: "cluster_nodes_state_disconnected", Name: "disconnected"},
+		},
+	}
+	chartClusterEpoch = module.Chart{
+		ID:       "cluster_epoch",
+		Title:    "Cluster configuration epoch",
+		Units:    "epoch",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_epoch",
+		Priority: prioClusterEpoch,
+		Dims: module.Dims{
+			{ID: "cluster_current_epoch", Name: "current"},
+			{ID: "cluster_my_epoch", Name: "my"},
+		},
+	}
+	chartClusterMessages = module.Chart{
+		ID:       "cluster_messages",
+		Title:    "Cluster bus messages",
+		Units:    "messages/s",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_messages",
+		Priority: prioClusterMessages,
+		Dims: module.Dims{
+			{ID: "cluster_stats_messages_received", Name: "received", Algo: module.Incremental},
+			{ID: "cluster_stats_messages_sent", Name: "sent", Mul: -1, Algo: module.Incremental},
+		},
+	}
+)
+
+var clusterNodeChartsTmpl = module.Charts{
+	chartClusterNodeStateTmpl.Copy(),
+	chartClusterNodeCommandsTmpl.Copy(),
+	chartClusterNodeClientsTmpl.Copy(),
+	chartClusterNodeMemoryTmpl.Copy(),
+	chartClusterNodeNetTmpl.Copy(),
+	chartClusterNodeKeyspaceLookupsTmpl.Copy(),
+	chartClusterNodeKeysTmpl.Copy(),
+}
+
+var (
+	chartClusterNodeStateTmpl = module.Chart{
+		ID:       "cluster_node_%s_state",
+		Title:    "Cluster node state",
+		Units:    "state",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_state",
+		Priority: prioClusterNodeState,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_state_ok", Name: "ok"},
+			{ID: "cluster_node_%s_state_pfail", Name: "pfail"},
+			{ID: "cluster_node_%s_state_fail", Name: "fail"},
+			{ID: "cluster_node_%s_state_disconnected", Name: "disconnected"},
+		},
+	}
+	chartClusterNodeCommandsTmpl = module.Chart{
+		ID:       "cluster_node_%s_commands",
+		Title:    "Cluster node processed commands",
+		Units:    "commands/s",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_commands",
+		Priority: prioClusterNodeCommands,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_total_commands_processed", Name: "processed", Algo: module.Incremental},
+		},
+	}
+	chartClusterNodeClientsTmpl = module.Chart{
+		ID:       "cluster_node_%s_clients",
+		Title:    "Cluster node clients",
+		Units:    "clients",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_clients",
+		Priority: prioClusterNodeClients,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_connected_clients", Name: "connected"},
+			{ID: "cluster_node_%s_blocked_clients", Name: "blocked"},
+		},
+	}
+	chartClusterNodeMemoryTmpl = module.Chart{
+		ID:       "cluster_node_%s_memory",
+		Title:    "Cluster node memory usage",
+		Units:    "bytes",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_memory",
+		Type:     module.Area,
+		Priority: prioClusterNodeMemory,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_maxmemory", Name: "max"},
+			{ID: "cluster_node_%s_used_memory", Name: "used"},
+			{ID: "cluster_node_%s_used_memory_rss", Name: "rss"},
+		},
+	}
+	chartClusterNodeNetTmpl = module.Chart{
+		ID:       "cluster_node_%s_net",
+		Title:    "Cluster node bandwidth",
+		Units:    "kilobits/s",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_net",
+		Type:     module.Area,
+		Priority: prioClusterNodeNet,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_total_net_input_bytes", Name: "received", Mul: 8, Div: 1024, Algo: module.Incremental},
+			{ID: "cluster_node_%s_total_net_output_bytes", Name: "sent", Mul: -8, Div: 1024, Algo: module.Incremental},
+		},
+	}
+	chartClusterNodeKeyspaceLookupsTmpl = module.Chart{
+		ID:       "cluster_node_%s_keyspace_lookups",
+		Title:    "Cluster node keyspace lookups",
+		Units:    "lookups/s",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_keyspace_lookups",
+		Type:     module.Stacked,
+		Priority: prioClusterNodeKeyspaceLookups,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_keyspace_hits", Name: "hits", Algo: module.Incremental},
+			{ID: "cluster_node_%s_keyspace_misses", Name: "misses", Algo: module.Incremental},
+		},
+	}
+	chartClusterNodeKeysTmpl = module.Chart{
+		ID:       "cluster_node_%s_keys",
+		Title:    "Cluster node keys",
+		Units:    "keys",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_keys",
+		Priority: prioClusterNodeKeys,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_db0_keys", Name: "keys"},
+			{ID: "cluster_node_%s_db0_expires_keys", Name: "expires"},
+		},
+	}
+)
+
+var sentinelCharts = module.Charts{
+	chartSentinelMasters.Copy(),
+	chartSentinelTilt.Copy(),
+	chartSentinelScripts.Copy(),
+}
+
+var (
+	chartSentinelMasters = module.Chart{
+		ID:       "sentinel_masters",
+		Title:    "Masters monitored by the Sentinel",
+		Units:    "masters",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_masters",
+		Priority: prioSentinelMasters,
+		Dims: module.Dims{
+			{ID: "sentinel_masters", Name: "monitored"},
+		},
+	}
+	chartSentinelTilt = module.Chart{
+		ID:       "sentinel_tilt",
+		Title:    "Sentinel TILT mode",
+		Units:    "status",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_tilt",
+		Priority: prioSentinelTilt,
+		Dims: module.Dims{
+			{ID: "sentinel_tilt", Name: "tilt"},
+		},
+	}
+	chartSentinelScripts = module.Chart{
+		ID:       "sentinel_scripts",
+		Title:    "Sentinel scripts",
+		Units:    "scripts",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_scripts",
+		Priority: prioSentinelScripts,
+		Dims: module.Dims{
+			{ID: "sentinel_running_scripts", Name: "running"},
+			{ID: "sentinel_scripts_queue_length", Name: "queued"},
+		},
+	}
+)
+
+var sentinelMasterChartsTmpl = module.Charts{
+	chartSentinelMasterStatusTmpl.Copy(),
+	chartSentinelMasterQuorumTmpl.Copy(),
+	chartSentinelMasterReplicasTmpl.Copy(),
+	chartSentinelMasterFailoversTmpl.Copy(),
+}
+
+var (
+	chartSentinelMasterStatusTmpl = module.Chart{
+		ID:       "sentinel_master_%s_status",
+		Title:    "Sentinel monitored master status",
+		Units:    "status",
+		Fam:      "sentinel masters",
+		Ctx:      "redis.sentinel_master_status",
+		Priority: prioSentinelMasterStatus,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_status_ok", Name: "ok"},
+			{ID: "sentinel_master_%s_status_sdown", Name: "sdown"},
+			{ID: "sentinel_master_%s_status_odown", Name: "odown"},
+			{ID: "sentinel_master_%s_status_failover_in_progress", Name: "failover_in_progress"},
+		},
+	}
+	chartSentinelMasterQuorumTmpl = module.Chart{
+		ID:       "sentinel_master_%s_quorum",
+		Title:    "Sentinel monitored master quorum",
+		Units:    "sentinels",
+		Fam:      "sentinel masters",
+		Ctx:      "redis.sentinel_master_quorum",
+		Priority: prioSentinelMasterQuorum,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_sentinels", Name: "sentinels"},
+			{ID: "sentinel_master_%s_quorum", Name: "quorum"},
+		},
+	}
+	chartSentinelMasterReplicasTmpl = module.Chart{
+		ID:       "sentinel_master_%s_replicas",
+		Title:    "Sentinel monitored master replicas",
+		Units:    "replicas",
+		Fam:      "sentinel masters",
+		Ctx:      "redis.sentinel_master_replicas",
+		Priority: prioSentinelMasterReplicas,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_num_slaves", Name: "replicas"},
+		},
+	}
+	chartSentinelMasterFailoversTmpl = module.Chart{
+		ID:       "sentinel_master_%s_failovers",
+		Title:    "Sentinel monitored master failovers",
+		Units:    "failovers/s",
+		Fam:      "sentinel masters",
+		Ctx:      "redis.sentinel_master_failovers",
+		Priority: prioSentinelMasterFailovers,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_config_epoch", Name: "failovers", Algo: module.Incremental},
+		},
+	}
+)
+
+// sentinelInfoCharts lists the base charts that still make sense for a Sentinel instance.
+var sentinelInfoCharts = map[string]bool{
+	chartConnections.ID:    true,
+	chartClients.ID:        true,
+	pingLatencyCommands.ID: true,
+	chartCommands.ID:       true,
+	chartNet.ID:            true,
+	chartUptime.ID:         true,
+}
+
+func (c *Collector) addClusterCharts() {
+	if err := c.Charts().Add(*clusterCharts.Copy()...); err != nil {
+		c.Warningf("failed to add cluster charts: %v", err)
+	}
+}
+
+func (c *Collector) addClusterNodeCharts(node *clusterNode) {
+	charts := clusterNodeChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, node.id)
+		chart.Labels = []module.Label{
+			{Key: "node_id", Value: node.id},
+			{Key: "address", Value: node.addr},
+			{Key: "role", Value: node.role},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, node.id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warningf("failed to add cluster node '%s' charts: %v", node.addr, err)
+	}
+}
+
+func (c *Collector) removeClusterNodeCharts(node *clusterNode) {
+	c.removeCharts(fmt.Sprintf("cluster_node_%s_", node.id))
+}
+
+func (c *Collector) setupSentinelCharts() {
+	charts := (*c.charts)[:0]
+	for _, chart := range *c.charts {
+		if sentinelInfoCharts[chart.ID] {
+			charts = append(charts, chart)
+		}
+	}
+	*c.charts = charts
+
+	if err := c.Charts().Add(*sentinelCharts.Copy()...); err != nil {
+		c.Warningf("failed to add sentinel charts: %v", err)
+	}
+}
+
+func (c *Collector) addSentinelMasterCharts(m *sentinelMaster) {
+	charts := sentinelMasterChartsTmpl.Copy()
+
+	m.charts = m.charts[:0]
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, chartIDName(m.name))
+		m.charts = append(m.charts, chart.ID)
+		chart.Labels = []module.Label{
+			{Key: "master_name", Value: m.name},
+			{Key: "master_address", Value: m.addr},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, m.name)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warningf("failed to add sentinel master '%s' charts: %v", m.name, err)
+	}
+}
+
+func (c *Collector) removeSentinelMasterCharts(m *sentinelMaster) {
+	// a master name can be a prefix of another master name ("cache" and "cache_eu")
+	for _, id := range m.charts {
+		if chart := c.Charts().Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+	m.charts = nil
+}
+
+func (c *Collector) removeCharts(prefix string) {
+	for _, chart := range *c.Charts() {
+		if strings.HasPrefix(chart.ID, prefix) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+// chartIDName makes a name usable in chart IDs. A name with spaces or dots gets them replaced
+// with underscores and a hash of the original name appended, so "a.b" and "a_b" get different IDs.
+func chartIDName(name string) string {
+	s := strings.NewReplacer(" ", "_", ".", "_").Replace(name)
+	if s == name {
+		return s
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return fmt.Sprintf("%s_%08x", s, h.Sum32())
+}
@@ -34,13 +34,52 @@ func (c *Collector) collect() (map[string]int64, error) {
 		return nil, fmt.Errorf("unsupported server app, want=redis, got=%s", c.server)
 	}
 
+	if c.mode == "" {
+		c.mode = extractServerMode(info)
+		c.Debugf(`mode="%s"`, c.mode)
+		switch c.mode {
+		case modeCluster:
+			c.addClusterCharts()
+		case modeSentinel:
+			c.setupSentinelCharts()
+		}
+	}
+
 	mx := make(map[string]int64)
 	c.collectInfo(mx, info)
 	c.collectPingLatency(mx)
 
+	switch c.mode {
+	case modeSentinel:
+		c.collectSentinel(mx)
+	case modeCluster:
+		c.collectCluster(mx)
+		fallthrough
+	default:
+		c.collectSlowlog(mx)
+		c.collectLatency(mx)
+	}
+
 	return mx, nil
 }
 
+const (
+	modeStandalone = "standalone"
+	modeCluster    = "cluster"
+	modeSentinel   = "sentinel"
+)
+
+// redis_mode:standalone
+var reMode = regexp.MustCompile(`(?m)^redis_mode:(\w+)`)
+
+func extractServerMode(info string) string {
+	// 'redis_mode' is not there before v3.0 (no cluster support)
+	if match := reMode.FindStringSubmatch(info); match != nil {
+		return match[1]
+	}
+	return modeStandalone
+}
+
 // redis_version:6.0.9
 var reVersion = regexp.MustCompile(`([a-z]+)_version:(\d+\.\d+\.\d+)`)
 
@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+)
+
+const clusterHashSlots = 16384
+
+type clusterNode struct {
+	id     string
+	addr   string
+	role   string
+	state  string
+	myself bool
+
+	seen      bool
+	hasCharts bool
+	rdb       redisClient
+}
+
+func (n *clusterNode) closeClient() {
+	if n.rdb != nil && !n.myself {
+		_ = n.rdb.Close()
+	}
+	n.rdb = nil
+}
+
+const (
+	clusterNodeStateOK           = "ok"
+	clusterNodeStatePFail        = "pfail"
+	clusterNodeStateFail         = "fail"
+	clusterNodeStateDisconnected = "disconnected"
+)
+
+var clusterNodeStates = []string{
+	clusterNodeStateOK,
+	clusterNodeStatePFail,
+	clusterNodeStateFail,
+	clusterNodeStateDisconnected,
+}
+
+func (c *Collector) collectCluster(mx map[string]int64) {
+	if err := c.collectClusterInfo(mx); err != nil {
+		c.Warning(err)
+	}
+	if err := c.collectClusterNodes(mx); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) collectClusterInfo(mx map[string]int64) error {
+	// https://redis.io/docs/latest/commands/cluster-info/
+	info, err := c.rdb.Do(context.Background(), "cluster", "info").Text()
+	if err != nil {
+		return fmt.Errorf("CLUSTER INFO: %v", err)
+	}
+
+	sc := bufio.NewScanner(strings.NewReader(info))
+	for sc.Scan() {
+		field, value, ok := parseProperty(strings.TrimSpace(sc.Text()))
+		if !ok {
+			continue
+		}
+
+		switch field {
+		case "cluster_state":
+			mx["cluster_state_ok"] = metrix.Bool(value == "ok")
+			mx["cluster_state_fail"] = metrix.Bool(value != "ok")
+		default:
+			collectNumericValue(mx, field, value)
+		}
+	}
+
+	if v, ok := mx["cluster_slots_assigned"]; ok {
+		mx["cluster_slots_unassigned"] = clusterHashSlots - v
+	}
+
+	return nil
+}
+
+func (c *Collector) collectClusterNodes(mx map[string]int64) error {
+	// https://redis.io/docs/latest/commands/cluster-nodes/
+	resp, err := c.rdb.Do(context.Background(), "cluster", "nodes").Text()
+	if err != nil {
+		return fmt.Errorf("CLUSTER NODES: %v", err)
+	}
+
+	nodes := parseClusterNodes(resp)
+
+	mx["cluster_nodes_role_master"] = 0
+	mx["cluster_nodes_role_replica"] = 0
+	for _, st := range clusterNodeStates {
+		mx["cluster_nodes_state_"+st] = 0
+	}
+
+	for _, node := range nodes {
+		mx["cluster_nodes_role_"+node.role]++
+		mx["cluster_nodes_state_"+node.state]++
+	}
+
+	if !c.DiscoverClusterNodes {
+		return nil
+	}
+
+	for _, node := range c.clusterNodes {
+		node.seen = false
+	}
+
+	for _, n := range nodes {
+		node, ok := c.clusterNodes[n.id]
+		if !ok || node.addr != n.addr || node.role != n.role {
+			if ok {
+				c.removeClusterNodeCharts(node)
+				node.closeClient()
+			}
+			node = n
+			c.clusterNodes[node.id] = node
+		}
+		node.seen = true
+		node.state = n.state
+
+		if !node.hasCharts {
+			node.hasCharts = true
+			c.addClusterNodeCharts(node)
+		}
+
+		c.collectClusterNode(mx, node)
+	}
+
+	for id, node := range c.clusterNodes {
+		if !node.seen {
+			c.removeClusterNodeCharts(node)
+			node.closeClient()
+			delete(c.clusterNodes, id)
+		}
+	}
+
+	return nil
+}
+
+var clusterNodeInfoFields = map[string]bool{
+	"total_commands_processed": true,
+	"connected_clients":        true,
+	"blocked_clients":          true,
+	"maxmemory":                true,
+	"used_memory":              true,
+	"used_memory_rss":          true,
+	"total_net_input_bytes":    true,
+	"total_net_output_bytes":   true,
+	"keyspace_hits":            true,
+	"keyspace_misses":          true,
+}
+
+func (c *Collector) collectClusterNode(mx map[string]int64, node *clusterNode) {
+	px := fmt.Sprintf("cluster_node_%s_", node.id)
+
+	for _, st := range clusterNodeStates {
+		mx[px+"state_"+st] = metrix.Bool(node.state == st)
+	}
+
+	if node.state == clusterNodeStateFail || node.state == clusterNodeStateDisconnected {
+		return
+	}
+
+	if node.rdb == nil {
+		if node.myself {
+			node.rdb = c.rdb
+		} else {
+			rdb, err := c.newNodeClient(node.addr)
+			if err != nil {
+				c.Warningf("cluster node '%s': failed to create client: %v", node.addr, err)
+				return
+			}
+			node.rdb = rdb
+		}
+	}
+
+	info, err := node.rdb.Info(context.Background()).Result()
+	if err != nil {
+		c.Warningf("cluster node '%s': INFO: %v", node.addr, err)
+		return
+	}
+
+	sc := bufio.NewScanner(strings.NewReader(info))
+	for sc.Scan() {
+		field, value, ok := parseProperty(strings.TrimSpace(sc.Text()))
+		if !ok {
+			continue
+		}
+		switch {
+		case clusterNodeInfoFields[field]:
+			collectNumericValue(mx, px+field, value)
+		case field == "db0":
+			if match := reKeyspaceValue.FindStringSubmatch(value); match != nil {
+				collectNumericValue(mx, px+"db0_keys", match[1])
+				collectNumericValue(mx, px+"db0_expires_keys", match[2])
+			}
+		}
+	}
+	if !has(mx, px+"db0_keys") {
+		mx[px+"db0_keys"] = 0
+		mx[px+"db0_expires_keys"] = 0
+	}
+}
+
+func parseClusterNodes(resp string) []*clusterNode {
+	// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+	var nodes []*clusterNode
+
+	sc := bufio.NewScanner(strings.NewReader(resp))
+	for sc.Scan() {
+		parts := strings.Fields(sc.Text())
+		if len(parts) < 8 {
+			continue
+		}
+
+		flags := make(map[string]bool)
+		for _, f := range strings.Split(parts[2], ",") {
+			flags[f] = true
+		}
+		if flags["handshake"] || flags["noaddr"] {
+			continue
+		}
+
+		addr, _, _ := strings.Cut(parts[1], "@")
+		if strings.HasPrefix(addr, ":") {
+			continue
+		}
+
+		node := &clusterNode{
+			id:     parts[0],
+			addr:   addr,
+			myself: flags["myself"],
+		}
+
+		switch {
+		case flags["master"]:
+			node.role = "master"
+		case flags["slave"]:
+			node.role = "replica"
+		default:
+			continue
+		}
+
+		switch {
+		case flags["fail"]:
+			node.state = clusterNodeStateFail
+		case flags["fail?"]:
+			node.state = clusterNodeStatePFail
+		case parts[7] == "disconnected":
+			node.state = clusterNodeStateDisconnected
+		default:
+			node.state = clusterNodeStateOK
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+func (c *Collector) collectLatency(mx map[string]int64) {
+	if c.noLatency {
+		return
+	}
+
+	// https://redis.io/docs/latest/commands/latency-latest/
+	// Each event: name, timestamp of the latest spike, latest spike (ms), all-time max spike (ms).
+	// The reply is empty unless 'latency-monitor-threshold' is set.
+	resp, err := c.rdb.Do(context.Background(), "latency", "latest").Slice()
+	if err != nil {
+		c.noLatency = true
+		c.Warningf("disabling latency monitor collection: LATENCY LATEST: %v", err)
+		return
+	}
+
+	for _, v := range resp {
+		fields, ok := v.([]any)
+		if !ok || len(fields) < 4 {
+			continue
+		}
+		event, ok := fields[0].(string)
+		if !ok {
+			continue
+		}
+		latest, ok1 := fields[2].(int64)
+		maxLat, ok2 := fields[3].(int64)
+		if !ok1 || !ok2 {
+			continue
+		}
+
+		mx["latency_event_"+event+"_latest"] = latest
+		mx["latency_event_"+event+"_max"] = maxLat
+
+		if !c.collectedLatencyEvents[event] {
+			c.collectedLatencyEvents[event] = true
+			c.addLatencyEventToCharts(event)
+		}
+	}
+}
+
+func (c *Collector) addLatencyEventToCharts(event string) {
+	if len(c.collectedLatencyEvents) == 1 {
+		for _, chart := range []module.Chart{chartLatencyEventsLatest, chartLatencyEventsMax} {
+			if err := c.Charts().Add(chart.Copy()); err != nil {
+				c.Warningf("error on adding '%s' chart: %v", chart.ID, err)
+			}
+		}
+	}
+
+	c.addDimToChart(chartLatencyEventsLatest.ID, &module.Dim{
+		ID:   "latency_event_" + event + "_latest",
+		Name: event,
+	})
+	c.addDimToChart(chartLatencyEventsMax.ID, &module.Dim{
+		ID:   "latency_event_" + event + "_max",
+		Name: event,
+	})
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+)
+
+type sentinelMaster struct {
+	name      string
+	addr      string
+	seen      bool
+	hasCharts bool
+	charts    []string
+}
+
+func (c *Collector) collectSentinel(mx map[string]int64) {
+	// https://redis.io/docs/latest/operate/oss_and_stack/management/sentinel/#sentinel-commands
+	resp, err := c.rdb.Do(context.Background(), "sentinel", "masters").Slice()
+	if err != nil {
+		c.Warningf("SENTINEL MASTERS: %v", err)
+		return
+	}
+
+	for _, m := range c.sentinelMasters {
+		m.seen = false
+	}
+
+	for _, v := range resp {
+		props := replyToStringMap(v)
+		name := props["name"]
+		if name == "" {
+			continue
+		}
+
+		addr := net.JoinHostPort(props["ip"], props["port"])
+
+		m, ok := c.sentinelMasters[name]
+		if !ok || m.addr != addr {
+			if ok {
+				c.removeSentinelMasterCharts(m)
+			}
+			m = &sentinelMaster{name: name, addr: addr}
+			c.sentinelMasters[name] = m
+		}
+		m.seen = true
+		if !m.hasCharts {
+			m.hasCharts = true
+			c.addSentinelMasterCharts(m)
+		}
+
+		px := fmt.Sprintf("sentinel_master_%s_", name)
+
+		flags := make(map[string]bool)
+		for _, f := range strings.Split(props["flags"], ",") {
+			flags[f] = true
+		}
+		mx[px+"status_sdown"] = metrix.Bool(flags["s_down"])
+		mx[px+"status_odown"] = metrix.Bool(flags["o_down"])
+		mx[px+"status_failover_in_progress"] = metrix.Bool(flags["failover_in_progress"])
+		mx[px+"status_ok"] = metrix.Bool(!flags["s_down"] && !flags["o_down"] && !flags["failover_in_progress"] && !flags["disconnected"])
+
+		// 'num-other-sentinels' doesn't include the sentinel being queried
+		if v, err := strconv.ParseInt(props["num-other-sentinels"], 10, 64); err == nil {
+			mx[px+"sentinels"] = v + 1
+		}
+		if v, err := strconv.ParseInt(props["quorum"], 10, 64); err == nil {
+			mx[px+"quorum"] = v
+		}
+		if v, err := strconv.ParseInt(props["num-slaves"], 10, 64); err == nil {
+			mx[px+"num_slaves"] = v
+		}
+		// the configuration epoch is incremented on every failover of the master
+		if v, err := strconv.ParseInt(props["config-epoch"], 10, 64); err == nil {
+			mx[px+"config_epoch"] = v
+		}
+	}
+
+	for name, m := range c.sentinelMasters {
+		if !m.seen {
+			c.removeSentinelMasterCharts(m)
+			delete(c.sentinelMasters, name)
+		}
+	}
+}
+
+// replyToStringMap converts a key/value reply (RESP2 flat array or RESP3 map) into a map.
+func replyToStringMap(v any) map[string]string {
+	props := make(map[string]string)
+
+	switch v := v.(type) {
+	case []any:
+		for i := 0; i+1 < len(v); i += 2 {
+			props[fmt.Sprint(v[i])] = fmt.Sprint(v[i+1])
+		}
+	case map[any]any:
+		for k, v := range v {
+			props[fmt.Sprint(k)] = fmt.Sprint(v)
+		}
+	}
+
+	return props
+}
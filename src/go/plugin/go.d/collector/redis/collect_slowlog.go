@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+const slowlogFetchEntries = 128
+
+func (c *Collector) collectSlowlog(mx map[string]int64) {
+	if c.noSlowlog {
+		return
+	}
+
+	if err := c.doCollectSlowlog(mx); err != nil {
+		// the command can be disabled or renamed (e.g. in managed Redis offerings)
+		c.noSlowlog = true
+		c.Warningf("disabling slowlog collection: %v", err)
+	}
+}
+
+func (c *Collector) doCollectSlowlog(mx map[string]int64) error {
+	length, err := c.rdb.Do(context.Background(), "slowlog", "len").Int64()
+	if err != nil {
+		return fmt.Errorf("SLOWLOG LEN: %v", err)
+	}
+
+	resp, err := c.rdb.Do(context.Background(), "slowlog", "get", slowlogFetchEntries).Slice()
+	if err != nil {
+		return fmt.Errorf("SLOWLOG GET: %v", err)
+	}
+
+	entries := parseSlowlogEntries(resp)
+
+	var newEntries, maxDuration int64
+
+	// Entries logged before the first collection are not counted.
+	if !c.slowlogSeen {
+		c.slowlogSeen = true
+		c.addSlowlogCharts()
+	} else if len(entries) > 0 {
+		// IDs are assigned sequentially and reset only on server restart (not on SLOWLOG RESET),
+		// so the number of new entries is exact even if more than the fetched entries were logged.
+		lastID := c.slowlogLastID
+		switch {
+		case lastID < 0:
+			// the slowlog was empty, all its entries are new
+			newEntries = max(length, int64(len(entries)))
+		case entries[0].id < lastID:
+			// server restart
+			lastID = -1
+			newEntries = entries[0].id + 1
+		default:
+			newEntries = entries[0].id - lastID
+		}
+		for _, e := range entries {
+			if e.id <= lastID {
+				break
+			}
+			maxDuration = max(maxDuration, e.durationUsec)
+		}
+		if newEntries > int64(len(entries)) {
+			c.Debugf("slowlog: %d new entries since the last collection, max duration is of the latest %d",
+				newEntries, len(entries))
+		}
+	}
+
+	if len(entries) > 0 {
+		c.slowlogLastID = entries[0].id
+	}
+	c.slowlogTotal += newEntries
+
+	mx["slowlog_length"] = length
+	mx["slowlog_entries"] = c.slowlogTotal
+	mx["slowlog_max_duration"] = maxDuration
+
+	return nil
+}
+
+type slowlogEntry struct {
+	id           int64
+	durationUsec int64
+}
+
+func parseSlowlogEntries(resp []any) []slowlogEntry {
+	// https://redis.io/docs/latest/commands/slowlog-get/
+	// Each entry: id, timestamp, execution time (microseconds), arguments, [client ip:port, client name].
+	// Entries are returned newest first.
+	var entries []slowlogEntry
+
+	for _, v := range resp {
+		fields, ok := v.([]any)
+		if !ok || len(fields) < 3 {
+			continue
+		}
+		id, ok1 := fields[0].(int64)
+		dur, ok2 := fields[2].(int64)
+		if !ok1 || !ok2 {
+			continue
+		}
+		entries = append(entries, slowlogEntry{id: id, durationUsec: dur})
+	}
+
+	return entries
+}
+
+func (c *Collector) addSlowlogCharts() {
+	for _, chart := range []module.Chart{chartSlowlogEntries, chartSlowlogLength, chartSlowlogMaxDuration} {
+		if err := c.Charts().Add(chart.Copy()); err != nil {
+			c.Warningf("error on adding '%s' chart: %v", chart.ID, err)
+		}
+	}
+}
@@ -43,18 +43,23 @@ func New() *Collector {
 		pingSummary:            metrix.NewSummary(),
 		collectedCommands:      make(map[string]bool),
 		collectedDbs:           make(map[string]bool),
+		collectedLatencyEvents: make(map[string]bool),
+		slowlogLastID:          -1,
+		clusterNodes:           make(map[string]*clusterNode),
+		sentinelMasters:        make(map[string]*sentinelMaster),
 	}
 }
 
 type Config struct {
-	Vnode            string           `yaml:"vnode,omitempty" json:"vnode"`
-	UpdateEvery      int              `yaml:"update_every,omitempty" json:"update_every"`
-	Address          string           `yaml:"address" json:"address"`
-	Timeout          confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
-	Username         string           `yaml:"username,omitempty" json:"username"`
-	Password         string           `yaml:"password,omitempty" json:"password"`
-	tlscfg.TLSConfig `yaml:",inline" json:""`
-	PingSamples      int `yaml:"ping_samples" json:"ping_samples"`
+	Vnode                string           `yaml:"vnode,omitempty" json:"vnode"`
+	UpdateEvery          int              `yaml:"update_every,omitempty" json:"update_every"`
+	Address              string           `yaml:"address" json:"address"`
+	Timeout              confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	Username             string           `yaml:"username,omitempty" json:"username"`
+	Password             string           `yaml:"password,omitempty" json:"password"`
+	tlscfg.TLSConfig     `yaml:",inline" json:""`
+	PingSamples          int  `yaml:"ping_samples" json:"ping_samples"`
+	DiscoverClusterNodes bool `yaml:"discover_cluster_nodes" json:"discover_cluster_nodes"`
 }
 
 type (
@@ -66,17 +71,30 @@ type (
 		addAOFChartsOnce       *sync.Once
 		addReplSlaveChartsOnce *sync.Once
 
-		rdb redisClient
-
-		server            string
-		version           *semver.Version
-		pingSummary       metrix.Summary
-		collectedCommands map[string]bool
-		collectedDbs      map[string]bool
+		rdb           redisClient
+		newNodeClient func(addr string) (redisClient, error)
+
+		server                 string
+		mode                   string
+		version                *semver.Version
+		pingSummary            metrix.Summary
+		collectedCommands      map[string]bool
+		collectedDbs           map[string]bool
+		collectedLatencyEvents map[string]bool
+
+		noSlowlog     bool
+		noLatency     bool
+		slowlogSeen   bool
+		slowlogLastID int64
+		slowlogTotal  int64
+
+		clusterNodes    map[string]*clusterNode
+		sentinelMasters map[string]*sentinelMaster
 	}
 	redisClient interface {
 		Info(ctx context.Context, section ...string) *redis.StringCmd
 		Ping(context.Context) *redis.StatusCmd
+		Do(ctx context.Context, args ...any) *redis.Cmd
 		Close() error
 	}
 )
@@ -96,6 +114,7 @@ func (c *Collector) Init(context.Context) error {
 		return fmt.Errorf("init redis client: %v", err)
 	}
 	c.rdb = rdb
+	c.newNodeClient = c.initNodeClient
 
 	charts, err := c.initCharts()
 	if err != nil {
@@ -134,6 +153,9 @@ func (c *Collector) Collect(context.Context) map[string]int64 {
 }
 
 func (c *Collector) Cleanup(context.Context) {
+	for _, node := range c.clusterNodes {
+		node.closeClient()
+	}
 	if c.rdb == nil {
 		return
 	}
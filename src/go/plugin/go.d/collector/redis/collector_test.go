@@ -5,6 +5,7 @@ package redis
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -23,6 +24,11 @@ var (
 
 	dataPikaInfoAll, _   = os.ReadFile("testdata/pika/info_all.txt")
 	dataVer609InfoAll, _ = os.ReadFile("testdata/v6.0.9/info_all.txt")
+
+	dataClusterInfoAll, _  = os.ReadFile("testdata/cluster/info_all.txt")
+	dataClusterInfo, _     = os.ReadFile("testdata/cluster/cluster_info.txt")
+	dataClusterNodes, _    = os.ReadFile("testdata/cluster/cluster_nodes.txt")
+	dataSentinelInfoAll, _ = os.ReadFile("testdata/sentinel/info_all.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -31,6 +37,11 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataConfigYAML":    dataConfigYAML,
 		"dataPikaInfoAll":   dataPikaInfoAll,
 		"dataVer609InfoAll": dataVer609InfoAll,
+
+		"dataClusterInfoAll":  dataClusterInfoAll,
+		"dataClusterInfo":     dataClusterInfo,
+		"dataClusterNodes":    dataClusterNodes,
+		"dataSentinelInfoAll": dataSentinelInfoAll,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -210,6 +221,8 @@ func TestCollector_Collect(t *testing.T) {
 				"keyspace_hit_rate":               100000,
 				"keyspace_hits":                   2,
 				"keyspace_misses":                 0,
+				"latency_event_command_latest":    250,
+				"latency_event_command_max":       1000,
 				"latest_fork_usec":                810,
 				"lazyfree_pending_objects":        0,
 				"loading":                         0,
@@ -254,6 +267,9 @@ func TestCollector_Collect(t *testing.T) {
 				"rss_overhead_ratio":              1070,
 				"second_repl_offset":              -1,
 				"slave_expires_tracked_keys":      0,
+				"slowlog_entries":                 0,
+				"slowlog_length":                  2,
+				"slowlog_max_duration":            0,
 				"sync_full":                       0,
 				"sync_partial_err":                0,
 				"sync_partial_ok":                 0,
@@ -312,12 +328,228 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
-func prepareRedisV609(t *testing.T) *Collector {
+func TestCollector_Collect_Slowlog(t *testing.T) {
+	collr := prepareRedisV609(t)
+	m := collr.rdb.(*mockRedisClient)
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(0), mx["slowlog_entries"])
+
+	m.do["slowlog len"] = int64(4)
+	m.do["slowlog get"] = []any{
+		[]any{int64(5), int64(1700000090), int64(52000), []any{"keys", "*"}},
+		[]any{int64(4), int64(1700000080), int64(31000), []any{"keys", "*"}},
+		[]any{int64(3), int64(1700000070), int64(20000), []any{"hgetall", "x"}},
+		[]any{int64(2), int64(1700000060), int64(15000), []any{"keys", "*"}},
+	}
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(4), mx["slowlog_length"])
+	assert.Equal(t, int64(4), mx["slowlog_entries"])
+	assert.Equal(t, int64(52000), mx["slowlog_max_duration"])
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(4), mx["slowlog_entries"])
+	assert.Equal(t, int64(0), mx["slowlog_max_duration"])
+
+	// a burst of 200 entries, only the latest 128 are fetched
+	var entries []any
+	for id := int64(205); len(entries) < slowlogFetchEntries; id-- {
+		entries = append(entries, []any{id, int64(1700000100), id * 100, []any{"keys", "*"}})
+	}
+	m.do["slowlog len"] = int64(128)
+	m.do["slowlog get"] = entries
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(204), mx["slowlog_entries"])
+	assert.Equal(t, int64(20500), mx["slowlog_max_duration"])
+}
+
+func TestCollector_Collect_SlowlogAndLatencyDisabled(t *testing.T) {
+	collr := prepareRedisV609(t)
+	m := collr.rdb.(*mockRedisClient)
+	delete(m.do, "slowlog len")
+	delete(m.do, "latency latest")
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.NotContains(t, mx, "slowlog_length")
+	assert.NotContains(t, mx, "latency_event_command_latest")
+	assert.True(t, collr.noSlowlog)
+	assert.True(t, collr.noLatency)
+	assert.False(t, collr.Charts().Has(chartSlowlogEntries.ID))
+}
+
+func TestCollector_Collect_Cluster(t *testing.T) {
 	collr := New()
+	collr.DiscoverClusterNodes = true
 	require.NoError(t, collr.Init(context.Background()))
-	collr.rdb = &mockRedisClient{
-		result: dataVer609InfoAll,
+
+	seed := newMockRedisClient(dataClusterInfoAll)
+	seed.do["cluster info"] = string(dataClusterInfo)
+	seed.do["cluster nodes"] = string(dataClusterNodes)
+	collr.rdb = seed
+
+	nodeClients := make(map[string]*mockRedisClient)
+	collr.newNodeClient = func(addr string) (redisClient, error) {
+		m := newMockRedisClient(dataVer609InfoAll)
+		nodeClients[addr] = m
+		return m, nil
+	}
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	expected := map[string]int64{
+		"cluster_state_ok":                 1,
+		"cluster_state_fail":               0,
+		"cluster_slots_assigned":           16384,
+		"cluster_slots_ok":                 16380,
+		"cluster_slots_pfail":              4,
+		"cluster_slots_fail":               0,
+		"cluster_slots_unassigned":         0,
+		"cluster_known_nodes":              6,
+		"cluster_size":                     3,
+		"cluster_current_epoch":            6,
+		"cluster_my_epoch":                 2,
+		"cluster_stats_messages_sent":      1483972,
+		"cluster_stats_messages_received":  1483968,
+		"cluster_nodes_role_master":        3,
+		"cluster_nodes_role_replica":       3,
+		"cluster_nodes_state_ok":           4,
+		"cluster_nodes_state_pfail":        1,
+		"cluster_nodes_state_fail":         0,
+		"cluster_nodes_state_disconnected": 1,
+
+		"cluster_node_67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1_state_ok":                 1,
+		"cluster_node_67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1_used_memory":              867160,
+		"cluster_node_67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1_db0_keys":                 4,
+		"cluster_node_67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1_total_commands_processed": 161,
+		"cluster_node_824fe116063bc5fcf9f4ffd895bc17aee7731ac3_state_disconnected":       1,
+		"cluster_node_e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca_state_ok":                 1,
+		"cluster_node_e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca_used_memory":              867160,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+
+	// the seed node (myself) is queried via the seed client, disconnected nodes are not queried
+	assert.Len(t, nodeClients, 4)
+	assert.NotContains(t, nodeClients, "127.0.0.1:30001")
+	assert.NotContains(t, nodeClients, "127.0.0.1:30006")
+	assert.NotContains(t, mx, "cluster_node_824fe116063bc5fcf9f4ffd895bc17aee7731ac3_used_memory")
+
+	module.TestMetricsHasAllChartsDimsSkip(t, collr.Charts(), mx, func(chart *module.Chart, _ *module.Dim) bool {
+		return strings.HasPrefix(chart.ID, "cluster_node_824fe116063bc5fcf9f4ffd895bc17aee7731ac3")
+	})
+
+	// node removed from the cluster
+	seed.do["cluster nodes"] = strings.Join(strings.Split(string(dataClusterNodes), "\n")[1:], "\n")
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.True(t, nodeClients["127.0.0.1:30004"].calledClose)
+	chart := collr.Charts().Get("cluster_node_07c37dfeb235213a872192d90877d0cd55635b91_memory")
+	require.NotNil(t, chart)
+	assert.True(t, chart.Obsolete)
+}
+
+func TestCollector_Collect_Sentinel(t *testing.T) {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+
+	m := newMockRedisClient(dataSentinelInfoAll)
+	m.do["sentinel masters"] = []any{
+		[]any{
+			"name", "mymaster", "ip", "10.0.0.10", "port", "6379", "flags", "master",
+			"num-slaves", "2", "num-other-sentinels", "2", "quorum", "2", "config-epoch", "3",
+		},
+		map[any]any{
+			"name": "cache", "ip": "10.0.0.20", "port": "6379", "flags": "master,s_down,o_down",
+			"num-slaves": "1", "num-other-sentinels": "2", "quorum": "2", "config-epoch": "0",
+		},
+	}
+	collr.rdb = m
+
+	require.NoError(t, collr.Check(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	expected := map[string]int64{
+		"sentinel_masters":              2,
+		"sentinel_tilt":                 0,
+		"sentinel_running_scripts":      0,
+		"sentinel_scripts_queue_length": 0,
+
+		"sentinel_master_mymaster_status_ok":                   1,
+		"sentinel_master_mymaster_status_sdown":                0,
+		"sentinel_master_mymaster_status_odown":                0,
+		"sentinel_master_mymaster_status_failover_in_progress": 0,
+		"sentinel_master_mymaster_sentinels":                   3,
+		"sentinel_master_mymaster_quorum":                      2,
+		"sentinel_master_mymaster_num_slaves":                  2,
+		"sentinel_master_mymaster_config_epoch":                3,
+
+		"sentinel_master_cache_status_ok":                   0,
+		"sentinel_master_cache_status_sdown":                1,
+		"sentinel_master_cache_status_odown":                1,
+		"sentinel_master_cache_status_failover_in_progress": 0,
+		"sentinel_master_cache_sentinels":                   3,
+		"sentinel_master_cache_quorum":                      2,
+		"sentinel_master_cache_num_slaves":                  1,
+		"sentinel_master_cache_config_epoch":                0,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+
+	assert.False(t, collr.Charts().Has(chartMemory.ID))
+	assert.False(t, collr.Charts().Has(chartSlowlogEntries.ID))
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_Collect_SentinelMasterRemoved(t *testing.T) {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+
+	master := func(name string) []any {
+		return []any{"name", name, "ip", "10.0.0.10", "port", "6379", "flags", "master"}
+	}
+
+	m := newMockRedisClient(dataSentinelInfoAll)
+	m.do["sentinel masters"] = []any{master("cache"), master("cache_eu"), master("a.b"), master("a_b")}
+	collr.rdb = m
+
+	require.NoError(t, collr.Check(context.Background()))
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	// "a.b" and "a_b" have their own charts
+	assert.Len(t, *collr.Charts(), len(sentinelInfoCharts)+len(sentinelCharts)+4*len(sentinelMasterChartsTmpl))
+
+	m.do["sentinel masters"] = []any{master("cache_eu"), master("a_b")}
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	for _, name := range []string{"cache", "cache_eu", "a.b", "a_b"} {
+		removed := name == "cache" || name == "a.b"
+		for _, tmpl := range sentinelMasterChartsTmpl {
+			id := fmt.Sprintf(tmpl.ID, chartIDName(name))
+			chart := collr.Charts().Get(id)
+			require.NotNilf(t, chart, "chart '%s'", id)
+			assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", id)
+		}
 	}
+}
+
+func prepareRedisV609(t *testing.T) *Collector {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+	collr.rdb = newMockRedisClient(dataVer609InfoAll)
 	return collr
 }
 
@@ -376,9 +608,26 @@ func copyTimeRelatedMetrics(dst, src map[string]int64) {
 	}
 }
 
+func newMockRedisClient(info []byte) *mockRedisClient {
+	return &mockRedisClient{
+		result: info,
+		do: map[string]any{
+			"slowlog len": int64(2),
+			"slowlog get": []any{
+				[]any{int64(1), int64(1700000000), int64(12000), []any{"keys", "*"}, "127.0.0.1:52144", ""},
+				[]any{int64(0), int64(1699999000), int64(10500), []any{"keys", "*"}, "127.0.0.1:52144", ""},
+			},
+			"latency latest": []any{
+				[]any{"command", int64(1700000000), int64(250), int64(1000)},
+			},
+		},
+	}
+}
+
 type mockRedisClient struct {
 	errOnInfo   bool
 	result      []byte
+	do          map[string]any
 	calledClose bool
 }
 
@@ -391,6 +640,18 @@ func (m *mockRedisClient) Info(_ context.Context, _ ...string) (cmd *redis.Strin
 	return cmd
 }
 
+func (m *mockRedisClient) Do(_ context.Context, args ...any) *redis.Cmd {
+	var parts []string
+	for _, arg := range args[:min(2, len(args))] {
+		parts = append(parts, strings.ToLower(fmt.Sprint(arg)))
+	}
+	v, ok := m.do[strings.Join(parts, " ")]
+	if !ok {
+		return redis.NewCmdResult(nil, errors.New("ERR unknown command"))
+	}
+	return redis.NewCmdResult(v, nil)
+}
+
 func (m *mockRedisClient) Ping(_ context.Context) (cmd *redis.StatusCmd) {
 	return redis.NewStatusResult("PONG", nil)
 }
@@ -4,6 +4,7 @@ package redis
 
 import (
 	"errors"
+	"net"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
@@ -19,6 +20,32 @@ func (c *Collector) validateConfig() error {
 }
 
 func (c *Collector) initRedisClient() (*redis.Client, error) {
+	opts, err := c.initRedisOptions()
+	if err != nil {
+		return nil, err
+	}
+	return redis.NewClient(opts), nil
+}
+
+// initNodeClient creates a client for a cluster node discovered via 'CLUSTER NODES'.
+// It reuses the seed connection settings (credentials, TLS, timeouts) with the node address.
+func (c *Collector) initNodeClient(addr string) (redisClient, error) {
+	opts, err := c.initRedisOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts.Network = "tcp"
+	opts.Addr = addr
+	if opts.TLSConfig != nil {
+		opts.TLSConfig = opts.TLSConfig.Clone()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			opts.TLSConfig.ServerName = host
+		}
+	}
+	return redis.NewClient(opts), nil
+}
+
+func (c *Collector) initRedisOptions() (*redis.Options, error) {
 	opts, err := redis.ParseURL(c.Address)
 	if err != nil {
 		return nil, err
@@ -46,7 +73,7 @@ func (c *Collector) initRedisClient() (*redis.Client, error) {
 	opts.ReadTimeout = c.Timeout.Duration()
 	opts.WriteTimeout = c.Timeout.Duration()
 
-	return redis.NewClient(opts), nil
+	return opts, nil
 }
 
 func (c *Collector) initCharts() (*module.Charts, error) {
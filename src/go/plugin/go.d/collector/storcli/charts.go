@@ -174,6 +174,10 @@ var (
 )
 
 func (c *Collector) addControllerCharts(cntrl controllerInfo) {
+	if c.RAIDCharts {
+		return
+	}
+
 	var charts *module.Charts
 
 	switch cntrl.Version.DriverName {
@@ -208,6 +212,10 @@ func (c *Collector) addControllerCharts(cntrl controllerInfo) {
 }
 
 func (c *Collector) addPhysDriveCharts(cntrlNum int, di *driveInfo, ds *driveState, da *driveAttrs) {
+	if c.RAIDCharts {
+		return
+	}
+
 	charts := physDriveChartsTmpl.Copy()
 
 	if _, ok := parseInt(getTemperature(ds.DriveTemperature)); !ok {
@@ -240,6 +248,10 @@ func (c *Collector) addPhysDriveCharts(cntrlNum int, di *driveInfo, ds *driveSta
 }
 
 func (c *Collector) addBBUCharts(cntrlNum, bbuNum, model string) {
+	if c.RAIDCharts {
+		return
+	}
+
 	charts := bbuChartsTmpl.Copy()
 
 	for _, chart := range *charts {
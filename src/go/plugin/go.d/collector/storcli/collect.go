@@ -4,7 +4,11 @@
 
 package storcli
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
+)
 
 const (
 	driverNameMegaraid = "megaraid_sas"
@@ -19,6 +23,8 @@ func (c *Collector) collect() (map[string]int64, error) {
 
 	mx := make(map[string]int64)
 
+	var raidControllers []*hwraid.Controller
+
 	driver := cntrlResp.Controllers[0].ResponseData.Version.DriverName
 
 	switch driver {
@@ -26,8 +32,9 @@ func (c *Collector) collect() (map[string]int64, error) {
 		if err := c.collectMegaraidControllersInfo(mx, cntrlResp); err != nil {
 			return nil, fmt.Errorf("failed to collect megaraid controller info: %s", err)
 		}
+		var drivesResp *drivesInfoResponse
 		if len(cntrlResp.Controllers[0].ResponseData.PDList) > 0 {
-			drivesResp, err := c.queryDrivesInfo()
+			drivesResp, err = c.queryDrivesInfo()
 			if err != nil {
 				return nil, fmt.Errorf("failed to collect megaraid drive info: %s", err)
 			}
@@ -35,13 +42,22 @@ func (c *Collector) collect() (map[string]int64, error) {
 				return nil, err
 			}
 		}
+		raidControllers = c.megaraidRAIDControllers(cntrlResp, drivesResp)
 	case driverNameSas:
 		if err := c.collectMpt3sasControllersInfo(mx, cntrlResp); err != nil {
 			return nil, fmt.Errorf("failed to collect mpt3sas controller info: %s", err)
 		}
+		raidControllers = mpt3sasRAIDControllers(cntrlResp)
 	default:
 		return nil, fmt.Errorf("unknown driver: %s", driver)
 	}
 
+	hwraid.WriteMetrics(mx, raidControllers)
+	if c.RAIDCharts {
+		if err := c.raidCharts.Update(raidControllers); err != nil {
+			c.Warning(err)
+		}
+	}
+
 	return mx, nil
 }
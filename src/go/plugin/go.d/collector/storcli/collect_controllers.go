@@ -44,8 +44,19 @@ type (
 			State string `json:"State"`
 			Temp  string `json:"Temp"`
 		} `json:"BBU_Info"`
-		PDList []struct {
-		} `json:"PD LIST"`
+		VDList []struct {
+			DGVD  string `json:"DG/VD"`
+			Type  string `json:"TYPE"`
+			State string `json:"State"`
+			Name  string `json:"Name"`
+		} `json:"VD LIST"`
+		PDList        []driveInfo `json:"PD LIST"`
+		EnclosureList []struct {
+			EID   storNumber `json:"EID"`
+			State string     `json:"State"`
+		} `json:"Enclosure LIST"`
+		// mpt3sas only
+		PhysicalDeviceInformation map[string]json.RawMessage `json:"Physical Device Information"`
 	}
 )
 
@@ -26,15 +26,15 @@ type (
 	driveInfo struct {
 		EIDSlt string `json:"EID:Slt"`
 		//DID    int    `json:"DID"`
-		//State  string `json:"State"`
-		//DG     int    `json:"DG"` // FIX: can be integer or "-"
+		State string     `json:"State"`
+		DG    storNumber `json:"DG"` // can be integer or "-"
 		//Size   string `json:"Size"`
 		//Intf   string `json:"Intf"`
 		Med string `json:"Med"`
 		//SED    string `json:"SED"`
 		//PI     string `json:"PI"`
 		//SeSz   string `json:"SeSz"`
-		//Model  string `json:"Model"`
+		Model string `json:"Model"`
 		//Sp     string `json:"Sp"`
 		//Type   string `json:"Type"`
 	}
@@ -56,6 +56,8 @@ type storNumber string // some int values can be 'N/A'
 
 func (n *storNumber) UnmarshalJSON(b []byte) error { *n = storNumber(b); return nil }
 
+func (n storNumber) String() string { return strings.Trim(string(n), `"`) }
+
 func (c *Collector) collectMegaRaidDrives(mx map[string]int64, resp *drivesInfoResponse) error {
 	if resp == nil {
 		return nil
@@ -13,6 +13,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
 )
 
 //go:embed "config_schema.json"
@@ -30,11 +31,14 @@ func init() {
 }
 
 func New() *Collector {
+	charts := &module.Charts{}
+
 	return &Collector{
 		Config: Config{
 			Timeout: confopt.Duration(time.Second * 2),
 		},
-		charts:      &module.Charts{},
+		charts:      charts,
+		raidCharts:  hwraid.NewCharts(charts),
 		controllers: make(map[string]bool),
 		drives:      make(map[string]bool),
 		bbu:         make(map[string]bool),
@@ -44,16 +48,23 @@ func New() *Collector {
 type Config struct {
 	UpdateEvery int              `yaml:"update_every,omitempty" json:"update_every"`
 	Timeout     confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
+	RAIDCharts  bool             `yaml:"raid_charts,omitempty" json:"raid_charts"`
 }
 
 type Collector struct {
 	module.Base
 	Config `yaml:",inline" json:""`
 
-	charts *module.Charts
+	charts     *module.Charts
+	raidCharts *hwraid.Charts
 
 	exec storCli
 
+	// patrol read info is not available on all controllers and firmware versions,
+	// failed queries are retried with a backoff
+	patrolReadRetryTime  time.Time
+	patrolReadRetryDelay time.Duration
+
 	controllers map[string]bool
 	drives      map[string]bool
 	bbu         map[string]bool
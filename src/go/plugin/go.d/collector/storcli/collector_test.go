@@ -9,6 +9,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 
@@ -23,6 +24,10 @@ var (
 	dataMegaControllerInfo, _ = os.ReadFile("testdata/megaraid-controllers-info.json")
 	dataMegaDrivesInfo, _     = os.ReadFile("testdata/megaraid-drives-info.json")
 
+	dataMegaControllerInfoRebuild, _ = os.ReadFile("testdata/megaraid-controllers-info-rebuild.json")
+	dataMegaPatrolReadInfo, _        = os.ReadFile("testdata/megaraid-patrolread-info.json")
+	dataMegaRebuildInfo, _           = os.ReadFile("testdata/megaraid-rebuild-info.json")
+
 	dataSasControllerInfo, _ = os.ReadFile("testdata/mpt3sas-controllers-info.json")
 )
 
@@ -32,7 +37,12 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataConfigYAML":         dataConfigYAML,
 		"dataMegaControllerInfo": dataMegaControllerInfo,
 		"dataMegaDrivesInfo":     dataMegaDrivesInfo,
-		"dataSasControllerInfo":  dataSasControllerInfo,
+
+		"dataMegaControllerInfoRebuild": dataMegaControllerInfoRebuild,
+		"dataMegaPatrolReadInfo":        dataMegaPatrolReadInfo,
+		"dataMegaRebuildInfo":           dataMegaRebuildInfo,
+
+		"dataSasControllerInfo": dataSasControllerInfo,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -146,13 +156,16 @@ func TestCollector_Check(t *testing.T) {
 
 func TestCollector_Collect(t *testing.T) {
 	tests := map[string]struct {
-		prepareMock func() *mockStorCliExec
-		wantMetrics map[string]int64
-		wantCharts  int
+		prepareMock    func() *mockStorCliExec
+		wantMetrics    map[string]int64
+		wantCharts     int
+		wantRAIDCharts int
 	}{
 		"success MegaRAID controller": {
 			prepareMock: prepareMockMegaRaidOK,
-			wantCharts:  len(controllerMegaraidChartsTmpl)*1 + len(physDriveChartsTmpl)*6 + len(bbuChartsTmpl)*1,
+			// RAID charts: controller 4, enclosure 1, logical drive 1, physical drives 6*6
+			wantCharts:     len(controllerMegaraidChartsTmpl)*1 + len(physDriveChartsTmpl)*6 + len(bbuChartsTmpl)*1,
+			wantRAIDCharts: 4 + 1 + 1 + 6*6,
 			wantMetrics: map[string]int64{
 				"bbu_0_cntrl_0_temperature":                                       34,
 				"cntrl_0_bbu_status_healthy":                                      1,
@@ -200,15 +213,553 @@ func TestCollector_Collect(t *testing.T) {
 				"phys_drive_5000C500E5659BA7_cntrl_0_smart_alert_status_active":   0,
 				"phys_drive_5000C500E5659BA7_cntrl_0_smart_alert_status_inactive": 1,
 				"phys_drive_5000C500E5659BA7_cntrl_0_temperature":                 27,
+
+				"raid_cntrl_0_bbu_status_degraded":                         0,
+				"raid_cntrl_0_bbu_status_failed":                           0,
+				"raid_cntrl_0_bbu_status_ok":                               1,
+				"raid_cntrl_0_bbu_status_unknown":                          0,
+				"raid_cntrl_0_bbu_temperature":                             34,
+				"raid_cntrl_0_enc_252_status_degraded":                     0,
+				"raid_cntrl_0_enc_252_status_failed":                       0,
+				"raid_cntrl_0_enc_252_status_ok":                           1,
+				"raid_cntrl_0_enc_252_status_unknown":                      0,
+				"raid_cntrl_0_ld_0_state_degraded":                         0,
+				"raid_cntrl_0_ld_0_state_failed":                           0,
+				"raid_cntrl_0_ld_0_state_offline":                          0,
+				"raid_cntrl_0_ld_0_state_optimal":                          1,
+				"raid_cntrl_0_ld_0_state_rebuilding":                       0,
+				"raid_cntrl_0_ld_0_state_unknown":                          0,
+				"raid_cntrl_0_patrol_read_state_active":                    0,
+				"raid_cntrl_0_patrol_read_state_disabled":                  0,
+				"raid_cntrl_0_patrol_read_state_paused":                    0,
+				"raid_cntrl_0_patrol_read_state_stopped":                   1,
+				"raid_cntrl_0_pd_252:0_media_errors":                       0,
+				"raid_cntrl_0_pd_252:0_other_errors":                       0,
+				"raid_cntrl_0_pd_252:0_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_252:0_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_252:0_predictive_failures":                0,
+				"raid_cntrl_0_pd_252:0_rebuild_progress":                   0,
+				"raid_cntrl_0_pd_252:0_state_failed":                       0,
+				"raid_cntrl_0_pd_252:0_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_252:0_state_offline":                      0,
+				"raid_cntrl_0_pd_252:0_state_online":                       1,
+				"raid_cntrl_0_pd_252:0_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_252:0_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_252:0_state_unknown":                      0,
+				"raid_cntrl_0_pd_252:0_temperature":                        28,
+				"raid_cntrl_0_pd_252:1_media_errors":                       0,
+				"raid_cntrl_0_pd_252:1_other_errors":                       0,
+				"raid_cntrl_0_pd_252:1_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_252:1_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_252:1_predictive_failures":                0,
+				"raid_cntrl_0_pd_252:1_rebuild_progress":                   0,
+				"raid_cntrl_0_pd_252:1_state_failed":                       0,
+				"raid_cntrl_0_pd_252:1_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_252:1_state_offline":                      0,
+				"raid_cntrl_0_pd_252:1_state_online":                       1,
+				"raid_cntrl_0_pd_252:1_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_252:1_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_252:1_state_unknown":                      0,
+				"raid_cntrl_0_pd_252:1_temperature":                        27,
+				"raid_cntrl_0_pd_252:3_media_errors":                       0,
+				"raid_cntrl_0_pd_252:3_other_errors":                       0,
+				"raid_cntrl_0_pd_252:3_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_252:3_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_252:3_predictive_failures":                0,
+				"raid_cntrl_0_pd_252:3_rebuild_progress":                   0,
+				"raid_cntrl_0_pd_252:3_state_failed":                       0,
+				"raid_cntrl_0_pd_252:3_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_252:3_state_offline":                      0,
+				"raid_cntrl_0_pd_252:3_state_online":                       1,
+				"raid_cntrl_0_pd_252:3_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_252:3_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_252:3_state_unknown":                      0,
+				"raid_cntrl_0_pd_252:3_temperature":                        28,
+				"raid_cntrl_0_pd_252:4_media_errors":                       0,
+				"raid_cntrl_0_pd_252:4_other_errors":                       0,
+				"raid_cntrl_0_pd_252:4_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_252:4_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_252:4_predictive_failures":                0,
+				"raid_cntrl_0_pd_252:4_rebuild_progress":                   0,
+				"raid_cntrl_0_pd_252:4_state_failed":                       0,
+				"raid_cntrl_0_pd_252:4_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_252:4_state_offline":                      0,
+				"raid_cntrl_0_pd_252:4_state_online":                       1,
+				"raid_cntrl_0_pd_252:4_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_252:4_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_252:4_state_unknown":                      0,
+				"raid_cntrl_0_pd_252:4_temperature":                        28,
+				"raid_cntrl_0_pd_252:5_media_errors":                       0,
+				"raid_cntrl_0_pd_252:5_other_errors":                       0,
+				"raid_cntrl_0_pd_252:5_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_252:5_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_252:5_predictive_failures":                0,
+				"raid_cntrl_0_pd_252:5_rebuild_progress":                   0,
+				"raid_cntrl_0_pd_252:5_state_failed":                       0,
+				"raid_cntrl_0_pd_252:5_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_252:5_state_offline":                      0,
+				"raid_cntrl_0_pd_252:5_state_online":                       1,
+				"raid_cntrl_0_pd_252:5_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_252:5_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_252:5_state_unknown":                      0,
+				"raid_cntrl_0_pd_252:5_temperature":                        28,
+				"raid_cntrl_0_pd_252:7_media_errors":                       0,
+				"raid_cntrl_0_pd_252:7_other_errors":                       0,
+				"raid_cntrl_0_pd_252:7_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_252:7_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_252:7_predictive_failures":                0,
+				"raid_cntrl_0_pd_252:7_rebuild_progress":                   0,
+				"raid_cntrl_0_pd_252:7_state_failed":                       0,
+				"raid_cntrl_0_pd_252:7_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_252:7_state_offline":                      0,
+				"raid_cntrl_0_pd_252:7_state_online":                       1,
+				"raid_cntrl_0_pd_252:7_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_252:7_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_252:7_state_unknown":                      0,
+				"raid_cntrl_0_pd_252:7_temperature":                        28,
+				"raid_cntrl_0_status_degraded":                             0,
+				"raid_cntrl_0_status_failed":                               0,
+				"raid_cntrl_0_status_ok":                                   1,
+				"raid_cntrl_0_status_unknown":                              0,
+			},
+		},
+		"success MegaRAID controller rebuilding": {
+			prepareMock: prepareMockMegaRaidRebuildOK,
+			// RAID charts: controller 4, enclosure 1, logical drive 1, physical drives 6*6
+			wantCharts:     len(controllerMegaraidChartsTmpl)*1 + len(physDriveChartsTmpl)*6 + len(bbuChartsTmpl)*1,
+			wantRAIDCharts: 4 + 1 + 1 + 6*6,
+			wantMetrics: map[string]int64{
+				"bbu_0_cntrl_0_temperature":                                       34,
+				"cntrl_0_bbu_status_healthy":                                      1,
+				"cntrl_0_bbu_status_na":                                           0,
+				"cntrl_0_bbu_status_unhealthy":                                    0,
+				"cntrl_0_health_status_healthy":                                   0,
+				"cntrl_0_health_status_unhealthy":                                 1,
+				"cntrl_0_status_degraded":                                         1,
+				"cntrl_0_status_failed":                                           0,
+				"cntrl_0_status_optimal":                                          0,
+				"cntrl_0_status_partially_degraded":                               0,
+				"phys_drive_5000C500C36C8BCD_cntrl_0_media_error_count":           0,
+				"phys_drive_5000C500C36C8BCD_cntrl_0_other_error_count":           0,
+				"phys_drive_5000C500C36C8BCD_cntrl_0_predictive_failure_count":    0,
+				"phys_drive_5000C500C36C8BCD_cntrl_0_smart_alert_status_active":   0,
+				"phys_drive_5000C500C36C8BCD_cntrl_0_smart_alert_status_inactive": 1,
+				"phys_drive_5000C500C36C8BCD_cntrl_0_temperature":                 28,
+				"phys_drive_5000C500D59840FE_cntrl_0_media_error_count":           0,
+				"phys_drive_5000C500D59840FE_cntrl_0_other_error_count":           0,
+				"phys_drive_5000C500D59840FE_cntrl_0_predictive_failure_count":    0,
+				"phys_drive_5000C500D59840FE_cntrl_0_smart_alert_status_active":   0,
+				"phys_drive_5000C500D59840FE_cntrl_0_smart_alert_status_inactive": 1,
+				"phys_drive_5000C500D59840FE_cntrl_0_temperature":                 28,
+				"phys_drive_5000C500D6061539_cntrl_0_media_error_count":           0,
+				"phys_drive_5000C500D6061539_cntrl_0_other_error_count":           0,
+				"phys_drive_5000C500D6061539_cntrl_0_predictive_failure_count":    0,
+				"phys_drive_5000C500D6061539_cntrl_0_smart_alert_status_active":   0,
+				"phys_drive_5000C500D6061539_cntrl_0_smart_alert_status_inactive": 1,
+				"phys_drive_5000C500D6061539_cntrl_0_temperature":                 28,
+				"phys_drive_5000C500DC79B194_cntrl_0_media_error_count":           0,
+				"phys_drive_5000C500DC79B194_cntrl_0_other_error_count":           0,
+				"phys_drive_5000C500DC79B194_cntrl_0_predictive_failure_count":    0,
+				"phys_drive_5000C500DC79B194_cntrl_0_smart_alert_status_active":   0,
+				"phys_drive_5000C500DC79B194_cntrl_0_smart_alert_status_inactive": 1,
+				"phys_drive_5000C500DC79B194_cntrl_0_temperature":                 28,
+				"phys_drive_5000C500E54F4EBB_cntrl_0_media_error_count":           0,
+				"phys_drive_5000C500E54F4EBB_cntrl_0_other_error_count":           0,
+				"phys_drive_5000C500E54F4EBB_cntrl_0_predictive_failure_count":    0,
+				"phys_drive_5000C500E54F4EBB_cntrl_0_smart_alert_status_active":   0,
+				"phys_drive_5000C500E54F4EBB_cntrl_0_smart_alert_status_inactive": 1,
+				"phys_drive_5000C500E54F4EBB_cntrl_0_temperature":                 28,
+				"phys_drive_5000C500E5659BA7_cntrl_0_media_error_count":           0,
+				"phys_drive_5000C500E5659BA7_cntrl_0_other_error_count":           0,
+				"phys_drive_5000C500E5659BA7_cntrl_0_predictive_failure_count":    0,
+				"phys_drive_5000C500E5659BA7_cntrl_0_smart_alert_status_active":   0,
+				"phys_drive_5000C500E5659BA7_cntrl_0_smart_alert_status_inactive": 1,
+				"phys_drive_5000C500E5659BA7_cntrl_0_temperature":                 27,
+				"raid_cntrl_0_bbu_status_degraded":                                0,
+				"raid_cntrl_0_bbu_status_failed":                                  0,
+				"raid_cntrl_0_bbu_status_ok":                                      1,
+				"raid_cntrl_0_bbu_status_unknown":                                 0,
+				"raid_cntrl_0_bbu_temperature":                                    34,
+				"raid_cntrl_0_enc_252_status_degraded":                            0,
+				"raid_cntrl_0_enc_252_status_failed":                              0,
+				"raid_cntrl_0_enc_252_status_ok":                                  1,
+				"raid_cntrl_0_enc_252_status_unknown":                             0,
+				"raid_cntrl_0_ld_0_state_degraded":                                1,
+				"raid_cntrl_0_ld_0_state_failed":                                  0,
+				"raid_cntrl_0_ld_0_state_offline":                                 0,
+				"raid_cntrl_0_ld_0_state_optimal":                                 0,
+				"raid_cntrl_0_ld_0_state_rebuilding":                              0,
+				"raid_cntrl_0_ld_0_state_unknown":                                 0,
+				"raid_cntrl_0_patrol_read_state_active":                           0,
+				"raid_cntrl_0_patrol_read_state_disabled":                         0,
+				"raid_cntrl_0_patrol_read_state_paused":                           0,
+				"raid_cntrl_0_patrol_read_state_stopped":                          1,
+				"raid_cntrl_0_pd_252:0_media_errors":                              0,
+				"raid_cntrl_0_pd_252:0_other_errors":                              0,
+				"raid_cntrl_0_pd_252:0_predictive_failure_status_active":          0,
+				"raid_cntrl_0_pd_252:0_predictive_failure_status_inactive":        1,
+				"raid_cntrl_0_pd_252:0_predictive_failures":                       0,
+				"raid_cntrl_0_pd_252:0_rebuild_progress":                          0,
+				"raid_cntrl_0_pd_252:0_state_failed":                              0,
+				"raid_cntrl_0_pd_252:0_state_hot_spare":                           0,
+				"raid_cntrl_0_pd_252:0_state_offline":                             0,
+				"raid_cntrl_0_pd_252:0_state_online":                              1,
+				"raid_cntrl_0_pd_252:0_state_rebuilding":                          0,
+				"raid_cntrl_0_pd_252:0_state_unconfigured":                        0,
+				"raid_cntrl_0_pd_252:0_state_unknown":                             0,
+				"raid_cntrl_0_pd_252:0_temperature":                               28,
+				"raid_cntrl_0_pd_252:1_media_errors":                              0,
+				"raid_cntrl_0_pd_252:1_other_errors":                              0,
+				"raid_cntrl_0_pd_252:1_predictive_failure_status_active":          0,
+				"raid_cntrl_0_pd_252:1_predictive_failure_status_inactive":        1,
+				"raid_cntrl_0_pd_252:1_predictive_failures":                       0,
+				"raid_cntrl_0_pd_252:1_rebuild_progress":                          0,
+				"raid_cntrl_0_pd_252:1_state_failed":                              0,
+				"raid_cntrl_0_pd_252:1_state_hot_spare":                           0,
+				"raid_cntrl_0_pd_252:1_state_offline":                             0,
+				"raid_cntrl_0_pd_252:1_state_online":                              1,
+				"raid_cntrl_0_pd_252:1_state_rebuilding":                          0,
+				"raid_cntrl_0_pd_252:1_state_unconfigured":                        0,
+				"raid_cntrl_0_pd_252:1_state_unknown":                             0,
+				"raid_cntrl_0_pd_252:1_temperature":                               27,
+				"raid_cntrl_0_pd_252:3_media_errors":                              0,
+				"raid_cntrl_0_pd_252:3_other_errors":                              0,
+				"raid_cntrl_0_pd_252:3_predictive_failure_status_active":          0,
+				"raid_cntrl_0_pd_252:3_predictive_failure_status_inactive":        1,
+				"raid_cntrl_0_pd_252:3_predictive_failures":                       0,
+				"raid_cntrl_0_pd_252:3_rebuild_progress":                          37,
+				"raid_cntrl_0_pd_252:3_state_failed":                              0,
+				"raid_cntrl_0_pd_252:3_state_hot_spare":                           0,
+				"raid_cntrl_0_pd_252:3_state_offline":                             0,
+				"raid_cntrl_0_pd_252:3_state_online":                              0,
+				"raid_cntrl_0_pd_252:3_state_rebuilding":                          1,
+				"raid_cntrl_0_pd_252:3_state_unconfigured":                        0,
+				"raid_cntrl_0_pd_252:3_state_unknown":                             0,
+				"raid_cntrl_0_pd_252:3_temperature":                               28,
+				"raid_cntrl_0_pd_252:4_media_errors":                              0,
+				"raid_cntrl_0_pd_252:4_other_errors":                              0,
+				"raid_cntrl_0_pd_252:4_predictive_failure_status_active":          0,
+				"raid_cntrl_0_pd_252:4_predictive_failure_status_inactive":        1,
+				"raid_cntrl_0_pd_252:4_predictive_failures":                       0,
+				"raid_cntrl_0_pd_252:4_rebuild_progress":                          0,
+				"raid_cntrl_0_pd_252:4_state_failed":                              0,
+				"raid_cntrl_0_pd_252:4_state_hot_spare":                           0,
+				"raid_cntrl_0_pd_252:4_state_offline":                             0,
+				"raid_cntrl_0_pd_252:4_state_online":                              1,
+				"raid_cntrl_0_pd_252:4_state_rebuilding":                          0,
+				"raid_cntrl_0_pd_252:4_state_unconfigured":                        0,
+				"raid_cntrl_0_pd_252:4_state_unknown":                             0,
+				"raid_cntrl_0_pd_252:4_temperature":                               28,
+				"raid_cntrl_0_pd_252:5_media_errors":                              0,
+				"raid_cntrl_0_pd_252:5_other_errors":                              0,
+				"raid_cntrl_0_pd_252:5_predictive_failure_status_active":          0,
+				"raid_cntrl_0_pd_252:5_predictive_failure_status_inactive":        1,
+				"raid_cntrl_0_pd_252:5_predictive_failures":                       0,
+				"raid_cntrl_0_pd_252:5_rebuild_progress":                          0,
+				"raid_cntrl_0_pd_252:5_state_failed":                              0,
+				"raid_cntrl_0_pd_252:5_state_hot_spare":                           0,
+				"raid_cntrl_0_pd_252:5_state_offline":                             0,
+				"raid_cntrl_0_pd_252:5_state_online":                              1,
+				"raid_cntrl_0_pd_252:5_state_rebuilding":                          0,
+				"raid_cntrl_0_pd_252:5_state_unconfigured":                        0,
+				"raid_cntrl_0_pd_252:5_state_unknown":                             0,
+				"raid_cntrl_0_pd_252:5_temperature":                               28,
+				"raid_cntrl_0_pd_252:7_media_errors":                              0,
+				"raid_cntrl_0_pd_252:7_other_errors":                              0,
+				"raid_cntrl_0_pd_252:7_predictive_failure_status_active":          0,
+				"raid_cntrl_0_pd_252:7_predictive_failure_status_inactive":        1,
+				"raid_cntrl_0_pd_252:7_predictive_failures":                       0,
+				"raid_cntrl_0_pd_252:7_rebuild_progress":                          0,
+				"raid_cntrl_0_pd_252:7_state_failed":                              0,
+				"raid_cntrl_0_pd_252:7_state_hot_spare":                           0,
+				"raid_cntrl_0_pd_252:7_state_offline":                             0,
+				"raid_cntrl_0_pd_252:7_state_online":                              1,
+				"raid_cntrl_0_pd_252:7_state_rebuilding":                          0,
+				"raid_cntrl_0_pd_252:7_state_unconfigured":                        0,
+				"raid_cntrl_0_pd_252:7_state_unknown":                             0,
+				"raid_cntrl_0_pd_252:7_temperature":                               28,
+				"raid_cntrl_0_status_degraded":                                    1,
+				"raid_cntrl_0_status_failed":                                      0,
+				"raid_cntrl_0_status_ok":                                          0,
+				"raid_cntrl_0_status_unknown":                                     0,
 			},
 		},
 		"success SAS controller": {
 			prepareMock: prepareMockSasOK,
-			wantCharts:  len(controllerMpt3sasChartsTmpl) * 1,
+			// RAID charts: controller 2, physical drives 29*2
+			wantCharts:     len(controllerMpt3sasChartsTmpl) * 1,
+			wantRAIDCharts: 2 + 29*2,
 			wantMetrics: map[string]int64{
 				"cntrl_0_health_status_healthy":   1,
 				"cntrl_0_health_status_unhealthy": 0,
 				"cntrl_0_roc_temperature_celsius": 44,
+
+				"raid_cntrl_0_pd_23:0_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:0_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:0_state_failed":                        0,
+				"raid_cntrl_0_pd_23:0_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:0_state_offline":                       0,
+				"raid_cntrl_0_pd_23:0_state_online":                        1,
+				"raid_cntrl_0_pd_23:0_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:0_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:0_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:10_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:10_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:10_state_failed":                       0,
+				"raid_cntrl_0_pd_23:10_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:10_state_offline":                      0,
+				"raid_cntrl_0_pd_23:10_state_online":                       1,
+				"raid_cntrl_0_pd_23:10_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:10_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:10_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:11_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:11_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:11_state_failed":                       0,
+				"raid_cntrl_0_pd_23:11_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:11_state_offline":                      0,
+				"raid_cntrl_0_pd_23:11_state_online":                       1,
+				"raid_cntrl_0_pd_23:11_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:11_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:11_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:12_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:12_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:12_state_failed":                       0,
+				"raid_cntrl_0_pd_23:12_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:12_state_offline":                      0,
+				"raid_cntrl_0_pd_23:12_state_online":                       1,
+				"raid_cntrl_0_pd_23:12_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:12_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:12_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:14_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:14_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:14_state_failed":                       0,
+				"raid_cntrl_0_pd_23:14_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:14_state_offline":                      0,
+				"raid_cntrl_0_pd_23:14_state_online":                       1,
+				"raid_cntrl_0_pd_23:14_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:14_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:14_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:15_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:15_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:15_state_failed":                       0,
+				"raid_cntrl_0_pd_23:15_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:15_state_offline":                      0,
+				"raid_cntrl_0_pd_23:15_state_online":                       1,
+				"raid_cntrl_0_pd_23:15_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:15_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:15_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:16_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:16_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:16_state_failed":                       0,
+				"raid_cntrl_0_pd_23:16_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:16_state_offline":                      0,
+				"raid_cntrl_0_pd_23:16_state_online":                       1,
+				"raid_cntrl_0_pd_23:16_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:16_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:16_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:17_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:17_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:17_state_failed":                       0,
+				"raid_cntrl_0_pd_23:17_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:17_state_offline":                      0,
+				"raid_cntrl_0_pd_23:17_state_online":                       1,
+				"raid_cntrl_0_pd_23:17_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:17_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:17_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:18_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:18_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:18_state_failed":                       0,
+				"raid_cntrl_0_pd_23:18_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:18_state_offline":                      0,
+				"raid_cntrl_0_pd_23:18_state_online":                       1,
+				"raid_cntrl_0_pd_23:18_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:18_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:18_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:19_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:19_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:19_state_failed":                       0,
+				"raid_cntrl_0_pd_23:19_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:19_state_offline":                      0,
+				"raid_cntrl_0_pd_23:19_state_online":                       1,
+				"raid_cntrl_0_pd_23:19_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:19_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:19_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:1_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:1_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:1_state_failed":                        0,
+				"raid_cntrl_0_pd_23:1_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:1_state_offline":                       0,
+				"raid_cntrl_0_pd_23:1_state_online":                        1,
+				"raid_cntrl_0_pd_23:1_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:1_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:1_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:20_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:20_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:20_state_failed":                       0,
+				"raid_cntrl_0_pd_23:20_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:20_state_offline":                      0,
+				"raid_cntrl_0_pd_23:20_state_online":                       1,
+				"raid_cntrl_0_pd_23:20_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:20_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:20_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:21_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:21_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:21_state_failed":                       0,
+				"raid_cntrl_0_pd_23:21_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:21_state_offline":                      0,
+				"raid_cntrl_0_pd_23:21_state_online":                       1,
+				"raid_cntrl_0_pd_23:21_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:21_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:21_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:22_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:22_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:22_state_failed":                       0,
+				"raid_cntrl_0_pd_23:22_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:22_state_offline":                      0,
+				"raid_cntrl_0_pd_23:22_state_online":                       1,
+				"raid_cntrl_0_pd_23:22_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:22_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:22_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:23_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_23:23_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_23:23_state_failed":                       0,
+				"raid_cntrl_0_pd_23:23_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_23:23_state_offline":                      0,
+				"raid_cntrl_0_pd_23:23_state_online":                       1,
+				"raid_cntrl_0_pd_23:23_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_23:23_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_23:23_state_unknown":                      0,
+				"raid_cntrl_0_pd_23:2_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:2_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:2_state_failed":                        0,
+				"raid_cntrl_0_pd_23:2_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:2_state_offline":                       0,
+				"raid_cntrl_0_pd_23:2_state_online":                        1,
+				"raid_cntrl_0_pd_23:2_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:2_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:2_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:3_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:3_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:3_state_failed":                        0,
+				"raid_cntrl_0_pd_23:3_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:3_state_offline":                       0,
+				"raid_cntrl_0_pd_23:3_state_online":                        1,
+				"raid_cntrl_0_pd_23:3_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:3_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:3_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:4_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:4_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:4_state_failed":                        0,
+				"raid_cntrl_0_pd_23:4_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:4_state_offline":                       0,
+				"raid_cntrl_0_pd_23:4_state_online":                        1,
+				"raid_cntrl_0_pd_23:4_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:4_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:4_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:5_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:5_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:5_state_failed":                        0,
+				"raid_cntrl_0_pd_23:5_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:5_state_offline":                       0,
+				"raid_cntrl_0_pd_23:5_state_online":                        1,
+				"raid_cntrl_0_pd_23:5_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:5_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:5_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:6_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:6_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:6_state_failed":                        0,
+				"raid_cntrl_0_pd_23:6_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:6_state_offline":                       0,
+				"raid_cntrl_0_pd_23:6_state_online":                        1,
+				"raid_cntrl_0_pd_23:6_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:6_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:6_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:7_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:7_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:7_state_failed":                        0,
+				"raid_cntrl_0_pd_23:7_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:7_state_offline":                       0,
+				"raid_cntrl_0_pd_23:7_state_online":                        1,
+				"raid_cntrl_0_pd_23:7_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:7_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:7_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:8_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:8_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:8_state_failed":                        0,
+				"raid_cntrl_0_pd_23:8_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:8_state_offline":                       0,
+				"raid_cntrl_0_pd_23:8_state_online":                        1,
+				"raid_cntrl_0_pd_23:8_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:8_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:8_state_unknown":                       0,
+				"raid_cntrl_0_pd_23:9_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_23:9_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_23:9_state_failed":                        0,
+				"raid_cntrl_0_pd_23:9_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_23:9_state_offline":                       0,
+				"raid_cntrl_0_pd_23:9_state_online":                        1,
+				"raid_cntrl_0_pd_23:9_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_23:9_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_23:9_state_unknown":                       0,
+				"raid_cntrl_0_pd_30:11_predictive_failure_status_active":   0,
+				"raid_cntrl_0_pd_30:11_predictive_failure_status_inactive": 1,
+				"raid_cntrl_0_pd_30:11_state_failed":                       0,
+				"raid_cntrl_0_pd_30:11_state_hot_spare":                    0,
+				"raid_cntrl_0_pd_30:11_state_offline":                      0,
+				"raid_cntrl_0_pd_30:11_state_online":                       1,
+				"raid_cntrl_0_pd_30:11_state_rebuilding":                   0,
+				"raid_cntrl_0_pd_30:11_state_unconfigured":                 0,
+				"raid_cntrl_0_pd_30:11_state_unknown":                      0,
+				"raid_cntrl_0_pd_30:3_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_30:3_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_30:3_state_failed":                        0,
+				"raid_cntrl_0_pd_30:3_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_30:3_state_offline":                       0,
+				"raid_cntrl_0_pd_30:3_state_online":                        1,
+				"raid_cntrl_0_pd_30:3_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_30:3_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_30:3_state_unknown":                       0,
+				"raid_cntrl_0_pd_30:5_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_30:5_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_30:5_state_failed":                        0,
+				"raid_cntrl_0_pd_30:5_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_30:5_state_offline":                       0,
+				"raid_cntrl_0_pd_30:5_state_online":                        1,
+				"raid_cntrl_0_pd_30:5_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_30:5_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_30:5_state_unknown":                       0,
+				"raid_cntrl_0_pd_30:6_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_30:6_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_30:6_state_failed":                        0,
+				"raid_cntrl_0_pd_30:6_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_30:6_state_offline":                       0,
+				"raid_cntrl_0_pd_30:6_state_online":                        1,
+				"raid_cntrl_0_pd_30:6_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_30:6_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_30:6_state_unknown":                       0,
+				"raid_cntrl_0_pd_30:8_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_30:8_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_30:8_state_failed":                        0,
+				"raid_cntrl_0_pd_30:8_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_30:8_state_offline":                       0,
+				"raid_cntrl_0_pd_30:8_state_online":                        1,
+				"raid_cntrl_0_pd_30:8_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_30:8_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_30:8_state_unknown":                       0,
+				"raid_cntrl_0_pd_30:9_predictive_failure_status_active":    0,
+				"raid_cntrl_0_pd_30:9_predictive_failure_status_inactive":  1,
+				"raid_cntrl_0_pd_30:9_state_failed":                        0,
+				"raid_cntrl_0_pd_30:9_state_hot_spare":                     0,
+				"raid_cntrl_0_pd_30:9_state_offline":                       0,
+				"raid_cntrl_0_pd_30:9_state_online":                        1,
+				"raid_cntrl_0_pd_30:9_state_rebuilding":                    0,
+				"raid_cntrl_0_pd_30:9_state_unconfigured":                  0,
+				"raid_cntrl_0_pd_30:9_state_unknown":                       0,
+				"raid_cntrl_0_status_degraded":                             0,
+				"raid_cntrl_0_status_failed":                               0,
+				"raid_cntrl_0_status_ok":                                   1,
+				"raid_cntrl_0_status_unknown":                              0,
+				"raid_cntrl_0_temperature":                                 44,
 			},
 		},
 		"err on exec": {
@@ -238,14 +789,60 @@ func TestCollector_Collect(t *testing.T) {
 			assert.Len(t, *collr.Charts(), test.wantCharts, "wantCharts")
 
 			module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+			collr = New()
+			collr.RAIDCharts = true
+			collr.exec = test.prepareMock()
+
+			mx = collr.Collect(context.Background())
+
+			assert.Len(t, *collr.Charts(), test.wantRAIDCharts, "wantRAIDCharts")
+
+			module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 		})
 	}
 }
 
+func TestCollector_Collect_PatrolReadRetry(t *testing.T) {
+	collr := New()
+	collr.RAIDCharts = true
+	mock := prepareMockMegaRaidOK()
+	mock.errOnPatrolReadInfo = true
+	collr.exec = mock
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+	assert.NotContains(t, mx, "raid_cntrl_0_patrol_read_state_stopped")
+
+	// the query is not repeated until the retry delay passes
+	_ = collr.Collect(context.Background())
+	assert.Equal(t, 1, mock.patrolReadInfoCalls)
+	assert.Equal(t, patrolReadRetryMinDelay, collr.patrolReadRetryDelay)
+
+	mock.errOnPatrolReadInfo = false
+	collr.patrolReadRetryTime = time.Time{}
+
+	mx = collr.Collect(context.Background())
+	assert.Equal(t, 2, mock.patrolReadInfoCalls)
+	assert.Equal(t, int64(1), mx["raid_cntrl_0_patrol_read_state_stopped"])
+	assert.Zero(t, collr.patrolReadRetryDelay)
+	assert.True(t, collr.Charts().Has("raid_controller_0_patrol_read_state"))
+}
+
 func prepareMockMegaRaidOK() *mockStorCliExec {
 	return &mockStorCliExec{
 		controllersInfoData: dataMegaControllerInfo,
 		drivesInfoData:      dataMegaDrivesInfo,
+		patrolReadInfoData:  dataMegaPatrolReadInfo,
+	}
+}
+
+func prepareMockMegaRaidRebuildOK() *mockStorCliExec {
+	return &mockStorCliExec{
+		controllersInfoData: dataMegaControllerInfoRebuild,
+		drivesInfoData:      dataMegaDrivesInfo,
+		patrolReadInfoData:  dataMegaPatrolReadInfo,
+		rebuildInfoData:     dataMegaRebuildInfo,
 	}
 }
 
@@ -280,8 +877,12 @@ func prepareMockEmptyResponse() *mockStorCliExec {
 
 type mockStorCliExec struct {
 	errOnInfo           bool
+	errOnPatrolReadInfo bool
+	patrolReadInfoCalls int
 	controllersInfoData []byte
 	drivesInfoData      []byte
+	patrolReadInfoData  []byte
+	rebuildInfoData     []byte
 }
 
 func (m *mockStorCliExec) controllersInfo() ([]byte, error) {
@@ -297,3 +898,18 @@ func (m *mockStorCliExec) drivesInfo() ([]byte, error) {
 	}
 	return m.drivesInfoData, nil
 }
+
+func (m *mockStorCliExec) patrolReadInfo() ([]byte, error) {
+	m.patrolReadInfoCalls++
+	if m.errOnInfo || m.errOnPatrolReadInfo {
+		return nil, errors.New("mock.patrolReadInfo() error")
+	}
+	return m.patrolReadInfoData, nil
+}
+
+func (m *mockStorCliExec) rebuildInfo() ([]byte, error) {
+	if m.errOnInfo {
+		return nil, errors.New("mock.rebuildInfo() error")
+	}
+	return m.rebuildInfoData, nil
+}
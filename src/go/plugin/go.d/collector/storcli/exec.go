@@ -16,6 +16,8 @@ import (
 type storCli interface {
 	controllersInfo() ([]byte, error)
 	drivesInfo() ([]byte, error)
+	patrolReadInfo() ([]byte, error)
+	rebuildInfo() ([]byte, error)
 }
 
 func newStorCliExec(ndsudoPath string, timeout time.Duration, log *logger.Logger) *storCliExec {
@@ -41,6 +43,14 @@ func (e *storCliExec) drivesInfo() ([]byte, error) {
 	return e.execute("storcli-drives-info")
 }
 
+func (e *storCliExec) patrolReadInfo() ([]byte, error) {
+	return e.execute("storcli-patrolread-info")
+}
+
+func (e *storCliExec) rebuildInfo() ([]byte, error) {
+	return e.execute("storcli-rebuild-info")
+}
+
 func (e *storCliExec) execute(args ...string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
 	defer cancel()
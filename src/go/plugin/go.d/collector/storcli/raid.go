@@ -0,0 +1,436 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package storcli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/hwraid"
+)
+
+const (
+	patrolReadRetryMinDelay = time.Minute
+	patrolReadRetryMaxDelay = time.Hour
+)
+
+type (
+	patrolReadInfoResponse struct {
+		Controllers []struct {
+			CommandStatus struct {
+				Controller int    `json:"Controller"`
+				Status     string `json:"Status"`
+			} `json:"Command Status"`
+			ResponseData struct {
+				ControllerProperties []struct {
+					CtrlProp string `json:"Ctrl_Prop"`
+					Value    string `json:"Value"`
+				} `json:"Controller Properties"`
+			} `json:"Response Data"`
+		} `json:"Controllers"`
+	}
+	rebuildInfoResponse struct {
+		Controllers []struct {
+			CommandStatus struct {
+				Controller int    `json:"Controller"`
+				Status     string `json:"Status"`
+			} `json:"Command Status"`
+			ResponseData []struct {
+				DriveID  string     `json:"Drive-ID"`
+				Progress storNumber `json:"Progress%"`
+				Status   string     `json:"Status"`
+			} `json:"Response Data"`
+		} `json:"Controllers"`
+	}
+)
+
+func (c *Collector) megaraidRAIDControllers(cntrlResp *controllersInfoResponse, drivesResp *drivesInfoResponse) []*hwraid.Controller {
+	states := megaraidDriveStates(drivesResp)
+	patrolRead := c.queryPatrolReadStates()
+
+	var rebuilding bool
+	var controllers []*hwraid.Controller
+
+	for _, v := range cntrlResp.Controllers {
+		cntrl := v.ResponseData
+		num := strconv.Itoa(cntrl.Basics.Controller)
+
+		rc := &hwraid.Controller{
+			ID:         num,
+			Model:      strings.TrimSpace(cntrl.Basics.Model),
+			Status:     controllerRAIDStatus(cntrl.Status.ControllerStatus),
+			PatrolRead: patrolRead[cntrl.Basics.Controller],
+		}
+		if strings.EqualFold(cntrl.HwCfg.TemperatureSensorForROC, "present") {
+			rc.Temperature = ptr(int64(cntrl.HwCfg.ROCTemperatureC))
+		}
+
+		if len(cntrl.BBUInfo) > 0 {
+			bbu := cntrl.BBUInfo[0]
+			rc.Cache = &hwraid.Cache{BBUStatus: bbuRAIDStatus(bbu.State)}
+			if v, ok := parseInt(getTemperature(bbu.Temp)); ok {
+				rc.Cache.BBUTemperature = &v
+			}
+		}
+
+		for _, enc := range cntrl.EnclosureList {
+			rc.Enclosures = append(rc.Enclosures, &hwraid.Enclosure{
+				ID:     enc.EID.String(),
+				Status: enclosureRAIDStatus(enc.State),
+			})
+		}
+
+		dgVD := make(map[string]string)
+		for _, vd := range cntrl.VDList {
+			dg, id, ok := strings.Cut(vd.DGVD, "/") // DG/VD
+			if !ok {
+				continue
+			}
+			if _, ok := dgVD[dg]; !ok {
+				dgVD[dg] = id
+			}
+			rc.LogicalDrives = append(rc.LogicalDrives, &hwraid.LogicalDrive{
+				ID:        id,
+				Name:      vd.Name,
+				RAIDLevel: vd.Type,
+				State:     vdRAIDState(vd.State),
+			})
+		}
+
+		for _, pd := range cntrl.PDList {
+			rpd := newRAIDPhysicalDrive(pd)
+			rpd.LogicalDrive = dgVD[pd.DG.String()]
+			rpd.RebuildProgress = ptr(int64(0))
+			if st, ok := states[num+"/"+pd.EIDSlt]; ok {
+				setRAIDDriveState(rpd, st)
+			}
+			rebuilding = rebuilding || rpd.State == hwraid.PDStateRebuilding
+			rc.PhysicalDrives = append(rc.PhysicalDrives, rpd)
+		}
+
+		controllers = append(controllers, rc)
+	}
+
+	if rebuilding {
+		c.collectRebuildProgress(controllers)
+	}
+
+	return controllers
+}
+
+func mpt3sasRAIDControllers(cntrlResp *controllersInfoResponse) []*hwraid.Controller {
+	var controllers []*hwraid.Controller
+
+	for _, v := range cntrlResp.Controllers {
+		cntrl := v.ResponseData
+
+		rc := &hwraid.Controller{
+			ID:     strconv.Itoa(cntrl.Basics.Controller),
+			Model:  strings.TrimSpace(cntrl.Basics.Model),
+			Status: controllerRAIDStatus(cntrl.Status.ControllerStatus),
+		}
+		if strings.EqualFold(cntrl.HwCfg.TemperatureSensorForROC, "present") {
+			rc.Temperature = ptr(int64(cntrl.HwCfg.ROCTemperatureC))
+		}
+
+		for id := range cntrl.PhysicalDeviceInformation {
+			if strings.HasSuffix(id, "Detailed Information") {
+				continue
+			}
+			id = strings.TrimPrefix(id, "Drive ") // Drive /c0/e23/s0
+			pd, err := getDriveInfo(cntrl.PhysicalDeviceInformation, id)
+			if err != nil {
+				continue
+			}
+			rpd := newRAIDPhysicalDrive(*pd)
+			if data, err := getDriveDetailedInfo(cntrl.PhysicalDeviceInformation, id); err == nil {
+				if st, err := getDriveState(data, id); err == nil {
+					setRAIDDriveState(rpd, st)
+				}
+			}
+			rc.PhysicalDrives = append(rc.PhysicalDrives, rpd)
+		}
+
+		controllers = append(controllers, rc)
+	}
+
+	return controllers
+}
+
+func newRAIDPhysicalDrive(pd driveInfo) *hwraid.PhysicalDrive {
+	enc, slot, _ := strings.Cut(pd.EIDSlt, ":") // EID:Slt
+	return &hwraid.PhysicalDrive{
+		ID:        pd.EIDSlt,
+		Enclosure: strings.TrimSpace(enc),
+		Slot:      slot,
+		Model:     strings.TrimSpace(pd.Model),
+		MediaType: pd.Med,
+		State:     pdRAIDState(pd.State),
+	}
+}
+
+func setRAIDDriveState(rpd *hwraid.PhysicalDrive, st *driveState) {
+	if v, ok := parseInt(st.MediaErrorCount.String()); ok {
+		rpd.MediaErrors = &v
+	}
+	if v, ok := parseInt(st.OtherErrorCount.String()); ok {
+		rpd.OtherErrors = &v
+	}
+	if v, ok := parseInt(st.PredictiveFailureCount.String()); ok {
+		rpd.PredictiveFailures = &v
+		rpd.PredictiveFailure = v > 0
+	}
+	if v, ok := parseInt(getTemperature(st.DriveTemperature)); ok {
+		rpd.Temperature = &v
+	}
+	rpd.PredictiveFailure = rpd.PredictiveFailure || st.SmartAlertFlagged == "Yes"
+}
+
+// megaraidDriveStates returns the drive states keyed by "<controller>/<EID:Slt>".
+func megaraidDriveStates(resp *drivesInfoResponse) map[string]*driveState {
+	states := make(map[string]*driveState)
+	if resp == nil {
+		return states
+	}
+
+	for _, cntrl := range resp.Controllers {
+		for k := range cntrl.ResponseData {
+			if strings.HasSuffix(k, "Detailed Information") {
+				continue
+			}
+			id := strings.TrimPrefix(k, "Drive ") // Drive /c0/e252/s0
+			info, err := getDriveInfo(cntrl.ResponseData, id)
+			if err != nil {
+				continue
+			}
+			data, err := getDriveDetailedInfo(cntrl.ResponseData, id)
+			if err != nil {
+				continue
+			}
+			st, err := getDriveState(data, id)
+			if err != nil {
+				continue
+			}
+			states[fmt.Sprintf("%d/%s", cntrl.CommandStatus.Controller, info.EIDSlt)] = st
+		}
+	}
+
+	return states
+}
+
+// queryPatrolReadStates returns the patrol read states keyed by controller number.
+func (c *Collector) queryPatrolReadStates() map[int]string {
+	if time.Now().Before(c.patrolReadRetryTime) {
+		return nil
+	}
+
+	resp, err := c.queryPatrolReadInfo()
+	if err != nil {
+		c.backoffPatrolRead(err)
+		return nil
+	}
+	c.patrolReadRetryDelay = 0
+
+	states := make(map[int]string)
+
+	for _, cntrl := range resp.Controllers {
+		var mode, state string
+		for _, prop := range cntrl.ResponseData.ControllerProperties {
+			switch prop.CtrlProp {
+			case "PR Mode":
+				mode = prop.Value
+			case "PR Current State":
+				state = prop.Value
+			}
+		}
+		if st := patrolReadRAIDState(mode, state); st != "" {
+			states[cntrl.CommandStatus.Controller] = st
+		}
+	}
+
+	return states
+}
+
+func (c *Collector) backoffPatrolRead(err error) {
+	c.patrolReadRetryDelay = min(max(c.patrolReadRetryDelay*2, patrolReadRetryMinDelay), patrolReadRetryMaxDelay)
+	c.patrolReadRetryTime = time.Now().Add(c.patrolReadRetryDelay)
+	c.Warningf("patrol read info is not available, retrying in %s: %v", c.patrolReadRetryDelay, err)
+}
+
+func (c *Collector) collectRebuildProgress(controllers []*hwraid.Controller) {
+	resp, err := c.queryRebuildInfo()
+	if err != nil {
+		c.Warningf("failed to query drives rebuild progress: %v", err)
+		return
+	}
+
+	progress := make(map[string]int64)
+	for _, cntrl := range resp.Controllers {
+		for _, d := range cntrl.ResponseData {
+			v, ok := parseInt(d.Progress.String())
+			if !ok {
+				continue
+			}
+			// /c0/e252/s3
+			parts := strings.Split(strings.TrimPrefix(d.DriveID, "/"), "/")
+			if len(parts) != 3 {
+				continue
+			}
+			id := strings.TrimPrefix(parts[0], "c") + "/" + strings.TrimPrefix(parts[1], "e") + ":" + strings.TrimPrefix(parts[2], "s")
+			progress[id] = v
+		}
+	}
+
+	for _, cntrl := range controllers {
+		for _, pd := range cntrl.PhysicalDrives {
+			if v, ok := progress[cntrl.ID+"/"+pd.ID]; ok && pd.State == hwraid.PDStateRebuilding {
+				pd.RebuildProgress = &v
+			}
+		}
+	}
+}
+
+func (c *Collector) queryPatrolReadInfo() (*patrolReadInfoResponse, error) {
+	bs, err := c.exec.patrolReadInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bs) == 0 {
+		return nil, errors.New("empty response")
+	}
+
+	var resp patrolReadInfoResponse
+	if err := json.Unmarshal(bs, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Controllers) == 0 {
+		return nil, errors.New("no controllers found")
+	}
+	if st := resp.Controllers[0].CommandStatus.Status; st != "Success" {
+		return nil, fmt.Errorf("command status error: %s", st)
+	}
+
+	return &resp, nil
+}
+
+func (c *Collector) queryRebuildInfo() (*rebuildInfoResponse, error) {
+	bs, err := c.exec.rebuildInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bs) == 0 {
+		return nil, errors.New("empty response")
+	}
+
+	var resp rebuildInfoResponse
+	if err := json.Unmarshal(bs, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Controllers) == 0 {
+		return nil, errors.New("no controllers found")
+	}
+
+	return &resp, nil
+}
+
+func controllerRAIDStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "optimal", "ok":
+		return hwraid.StatusOK
+	case "degraded", "partially degraded", "needs attention":
+		return hwraid.StatusDegraded
+	case "failed":
+		return hwraid.StatusFailed
+	default:
+		return hwraid.StatusUnknown
+	}
+}
+
+func bbuRAIDStatus(state string) string {
+	// 'Optimal', 'Dgd (Needs Attention)', 'Failed', 'Learning'
+	switch s := strings.ToLower(state); {
+	case s == "optimal":
+		return hwraid.StatusOK
+	case strings.HasPrefix(s, "dgd"), strings.HasPrefix(s, "degraded"), s == "learning", s == "charging":
+		return hwraid.StatusDegraded
+	case strings.HasPrefix(s, "failed"):
+		return hwraid.StatusFailed
+	default:
+		return hwraid.StatusUnknown
+	}
+}
+
+func enclosureRAIDStatus(state string) string {
+	switch strings.ToLower(state) {
+	case "ok":
+		return hwraid.StatusOK
+	case "critical", "noncritical", "non critical", "degraded":
+		return hwraid.StatusDegraded
+	case "failed", "unrecoverable":
+		return hwraid.StatusFailed
+	default:
+		return hwraid.StatusUnknown
+	}
+}
+
+func vdRAIDState(state string) string {
+	switch state {
+	case "Optl":
+		return hwraid.LDStateOptimal
+	case "Dgrd", "Pdgd":
+		return hwraid.LDStateDegraded
+	case "Rec":
+		return hwraid.LDStateRebuilding
+	case "OfLn":
+		return hwraid.LDStateOffline
+	default:
+		return hwraid.LDStateUnknown
+	}
+}
+
+func pdRAIDState(state string) string {
+	switch state {
+	case "Onln", "JBOD", "CpyBck":
+		return hwraid.PDStateOnline
+	case "DHS", "GHS":
+		return hwraid.PDStateHotSpare
+	case "UGood", "UGUnsp", "UGShld":
+		return hwraid.PDStateUnconfigured
+	case "Rbld":
+		return hwraid.PDStateRebuilding
+	case "UBad", "UBUnsp", "Failed":
+		return hwraid.PDStateFailed
+	case "Offln":
+		return hwraid.PDStateOffline
+	default:
+		return hwraid.PDStateUnknown
+	}
+}
+
+func patrolReadRAIDState(mode, state string) string {
+	if strings.HasPrefix(strings.ToLower(mode), "disable") {
+		return hwraid.PatrolReadDisabled
+	}
+	// 'Stopped', 'Active 45', 'Paused'
+	s, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(state)), " ")
+	switch s {
+	case "active":
+		return hwraid.PatrolReadActive
+	case "paused":
+		return hwraid.PatrolReadPaused
+	case "stopped":
+		return hwraid.PatrolReadStopped
+	default:
+		return ""
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
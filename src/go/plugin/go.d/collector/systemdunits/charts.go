@@ -17,9 +17,108 @@ import (
 
 const (
 	prioUnitState = module.Priority + iota
+	prioUnitStateDuration
+	prioUnitRestarts
+	prioUnitResult
+	prioUnitExecMainStatus
+	prioUnitCPUUsage
+	prioUnitMemoryUsage
+	prioUnitIO
+	prioUnitTasks
 	prioUnitFileState
 )
 
+var unitDetailChartsTmpl = map[string]module.Chart{
+	unitChartStateDuration: {
+		ID:       "unit_%s_%s_state_duration",
+		Title:    "%s Unit Time Since Last State Change",
+		Units:    "seconds",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_state_duration",
+		Priority: prioUnitStateDuration,
+		Dims: module.Dims{
+			{ID: "unit_%s_%s_state_change_ago", Name: "time"},
+		},
+	},
+	unitChartRestarts: {
+		ID:       "unit_%s_%s_restarts",
+		Title:    "%s Unit Restarts",
+		Units:    "restarts",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_restarts",
+		Priority: prioUnitRestarts,
+		Dims: module.Dims{
+			{ID: "unit_%s_%s_restarts", Name: "restarts"},
+		},
+	},
+	unitChartResult: {
+		ID:       "unit_%s_%s_result",
+		Title:    "%s Unit Result",
+		Units:    "result",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_result",
+		Priority: prioUnitResult,
+	},
+	unitChartExecMainStatus: {
+		ID:       "unit_%s_%s_exec_main_status",
+		Title:    "%s Unit Main Process Exit Status",
+		Units:    "status",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_exec_main_status",
+		Priority: prioUnitExecMainStatus,
+		Dims: module.Dims{
+			{ID: "unit_%s_%s_exec_main_status", Name: "exit_status"},
+		},
+	},
+	unitChartCPUUsage: {
+		ID:       "unit_%s_%s_cpu_usage",
+		Title:    "%s Unit CPU Usage",
+		Units:    "percentage",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_cpu_usage",
+		Priority: prioUnitCPUUsage,
+		Dims: module.Dims{
+			// nanoseconds per second => percentage
+			{ID: "unit_%s_%s_cpu_usage", Name: "cpu", Algo: module.Incremental, Div: 1e7},
+		},
+	},
+	unitChartMemoryUsage: {
+		ID:       "unit_%s_%s_memory_usage",
+		Title:    "%s Unit Memory Usage",
+		Units:    "bytes",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_memory_usage",
+		Priority: prioUnitMemoryUsage,
+		Dims: module.Dims{
+			{ID: "unit_%s_%s_memory_current", Name: "current"},
+		},
+	},
+	unitChartIO: {
+		ID:       "unit_%s_%s_io",
+		Title:    "%s Unit IO",
+		Units:    "bytes/s",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_io",
+		Type:     module.Area,
+		Priority: prioUnitIO,
+		Dims: module.Dims{
+			{ID: "unit_%s_%s_io_read_bytes", Name: "read", Algo: module.Incremental},
+			{ID: "unit_%s_%s_io_write_bytes", Name: "write", Algo: module.Incremental, Mul: -1},
+		},
+	},
+	unitChartTasks: {
+		ID:       "unit_%s_%s_tasks",
+		Title:    "%s Unit Tasks",
+		Units:    "tasks",
+		Fam:      "%s units",
+		Ctx:      "systemd.%s_unit_tasks",
+		Priority: prioUnitTasks,
+		Dims: module.Dims{
+			{ID: "unit_%s_%s_tasks_current", Name: "current"},
+		},
+	},
+}
+
 func (c *Collector) addUnitCharts(name, typ string) {
 	chart := module.Chart{
 		ID:       "unit_%s_%s_state",
@@ -54,6 +153,36 @@ func (c *Collector) addUnitCharts(name, typ string) {
 	}
 }
 
+func (c *Collector) addUnitDetailChart(kind, name, typ string) {
+	tmpl, ok := unitDetailChartsTmpl[kind]
+	if !ok {
+		return
+	}
+
+	chart := tmpl.Copy()
+
+	chart.ID = fmt.Sprintf(chart.ID, name, typ)
+	chart.Title = fmt.Sprintf(chart.Title, cases.Title(language.English, cases.Compact).String(typ))
+	chart.Fam = fmt.Sprintf(chart.Fam, typ)
+	chart.Ctx = fmt.Sprintf(chart.Ctx, typ)
+	chart.Labels = []module.Label{
+		{Key: "unit_name", Value: name},
+	}
+
+	if kind == unitChartResult {
+		for _, r := range unitResults {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "unit_%s_%s_result_" + r, Name: r})
+		}
+	}
+	for _, d := range chart.Dims {
+		d.ID = fmt.Sprintf(d.ID, name, typ)
+	}
+
+	if err := c.Charts().Add(chart); err != nil {
+		c.Warning(err)
+	}
+}
+
 func (c *Collector) removeUnitCharts(name, typ string) {
 	px := fmt.Sprintf("unit_%s_%s_", name, typ)
 	c.removeCharts(px)
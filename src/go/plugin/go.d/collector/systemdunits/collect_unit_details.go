@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package systemdunits
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+const stateChangeTimestampProperty = "StateChangeTimestamp"
+
+// unitTypeInterfaces maps unit types to the systemd D-Bus interfaces holding their type specific properties.
+// https://www.freedesktop.org/software/systemd/man/latest/org.freedesktop.systemd1.html
+var unitTypeInterfaces = map[string]string{
+	"service":   "Service",
+	"socket":    "Socket",
+	"mount":     "Mount",
+	"automount": "Automount",
+	"swap":      "Swap",
+	"timer":     "Timer",
+	"path":      "Path",
+	"slice":     "Slice",
+	"scope":     "Scope",
+}
+
+const unitResultOther = "other"
+
+// unitResults are the values of the "Result" property, the reason of the last unit failure.
+var unitResults = []string{
+	"success",
+	"exit-code",
+	"signal",
+	"core-dump",
+	"timeout",
+	"watchdog",
+	"start-limit-hit",
+	"resources",
+	"oom-kill",
+	"protocol",
+	unitResultOther,
+}
+
+const (
+	unitChartRestarts       = "restarts"
+	unitChartResult         = "result"
+	unitChartExecMainStatus = "exec_main_status"
+	unitChartStateDuration  = "state_duration"
+	unitChartCPUUsage       = "cpu_usage"
+	unitChartMemoryUsage    = "memory_usage"
+	unitChartIO             = "io"
+	unitChartTasks          = "tasks"
+)
+
+func (c *Collector) collectUnitDetails(mx map[string]int64, conn systemdConnection, unitName, name, typ string) {
+	px := fmt.Sprintf("unit_%s_%s_", name, typ)
+
+	if ts, ok := c.getUnitStateChangeTimestamp(conn, unitName); ok && ts > 0 {
+		mx[px+"state_change_ago"] = int64(time.Since(time.UnixMicro(ts)).Seconds())
+		c.ensureUnitDetailChart(unitName, unitChartStateDuration, name, typ)
+	}
+
+	iface, ok := unitTypeInterfaces[typ]
+	if !ok {
+		return
+	}
+
+	props, err := c.getUnitTypeProperties(conn, unitName, iface)
+	if err != nil {
+		c.Debug(err)
+		return
+	}
+
+	if v, ok := unitPropertyInt(props, "NRestarts"); ok {
+		mx[px+"restarts"] = v
+		c.ensureUnitDetailChart(unitName, unitChartRestarts, name, typ)
+	}
+	if v, ok := props["Result"].(string); ok && v != "" {
+		if !slices.Contains(unitResults, v) {
+			v = unitResultOther
+		}
+		for _, r := range unitResults {
+			mx[px+"result_"+r] = 0
+		}
+		mx[px+"result_"+v] = 1
+		c.ensureUnitDetailChart(unitName, unitChartResult, name, typ)
+	}
+	if v, ok := unitPropertyInt(props, "ExecMainStatus"); ok {
+		mx[px+"exec_main_status"] = v
+		c.ensureUnitDetailChart(unitName, unitChartExecMainStatus, name, typ)
+	}
+
+	// cgroup accounting properties are not set if the accounting is disabled or the unit is not running
+	if v, ok := unitPropertyInt(props, "CPUUsageNSec"); ok {
+		mx[px+"cpu_usage"] = v
+		c.ensureUnitDetailChart(unitName, unitChartCPUUsage, name, typ)
+	}
+	if v, ok := unitPropertyInt(props, "MemoryCurrent"); ok {
+		mx[px+"memory_current"] = v
+		c.ensureUnitDetailChart(unitName, unitChartMemoryUsage, name, typ)
+	}
+	read, ok1 := unitPropertyInt(props, "IOReadBytes")
+	write, ok2 := unitPropertyInt(props, "IOWriteBytes")
+	if ok1 && ok2 {
+		mx[px+"io_read_bytes"] = read
+		mx[px+"io_write_bytes"] = write
+		c.ensureUnitDetailChart(unitName, unitChartIO, name, typ)
+	}
+	if v, ok := unitPropertyInt(props, "TasksCurrent"); ok {
+		mx[px+"tasks_current"] = v
+		c.ensureUnitDetailChart(unitName, unitChartTasks, name, typ)
+	}
+}
+
+func (c *Collector) ensureUnitDetailChart(unitName, chart, name, typ string) {
+	charts, ok := c.unitDetailCharts[unitName]
+	if !ok {
+		charts = make(map[string]bool)
+		c.unitDetailCharts[unitName] = charts
+	}
+	if !charts[chart] {
+		charts[chart] = true
+		c.addUnitDetailChart(chart, name, typ)
+	}
+}
+
+func (c *Collector) getUnitStateChangeTimestamp(conn systemdConnection, unit string) (int64, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout.Duration())
+	defer cancel()
+
+	c.Debugf("calling function 'GetUnitProperty' for unit '%s'", unit)
+
+	prop, err := conn.GetUnitPropertyContext(ctx, unit, stateChangeTimestampProperty)
+	if err != nil {
+		c.Debugf("error on GetUnitProperty '%s' for unit '%s': %v", stateChangeTimestampProperty, unit, err)
+		return 0, false
+	}
+
+	return unitPropertyInt(map[string]any{stateChangeTimestampProperty: prop.Value.Value()}, stateChangeTimestampProperty)
+}
+
+func (c *Collector) getUnitTypeProperties(conn systemdConnection, unit, iface string) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout.Duration())
+	defer cancel()
+
+	c.Debugf("calling function 'GetUnitTypeProperties' for unit '%s'", unit)
+
+	props, err := conn.GetUnitTypePropertiesContext(ctx, unit, iface)
+	if err != nil {
+		return nil, fmt.Errorf("error on GetUnitTypeProperties for unit '%s': %v", unit, err)
+	}
+
+	return props, nil
+}
+
+// unitPropertyInt returns the numeric property value. systemd uses the maximum value of the type to indicate that
+// the value is not set (e.g. MemoryCurrent of a unit without memory accounting).
+func unitPropertyInt(props map[string]any, name string) (int64, bool) {
+	switch v := props[name].(type) {
+	case uint64:
+		if v >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case uint32:
+		if v == math.MaxUint32 {
+			return 0, false
+		}
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
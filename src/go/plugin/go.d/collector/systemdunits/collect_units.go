@@ -79,11 +79,16 @@ func (c *Collector) collectUnits(mx map[string]int64, conn systemdConnection) er
 			mx[fmt.Sprintf("unit_%s_%s_state_%s", name, typ, s)] = 0
 		}
 		mx[fmt.Sprintf("unit_%s_%s_state_%s", name, typ, unit.ActiveState)] = 1
+
+		if c.CollectUnitDetails {
+			c.collectUnitDetails(mx, conn, unit.Name, name, typ)
+		}
 	}
 
 	for k := range c.seenUnits {
 		if !seen[k] {
 			delete(c.seenUnits, k)
+			delete(c.unitDetailCharts, k)
 			if name, typ, ok := extractUnitNameType(k); ok {
 				c.removeUnitCharts(name, typ)
 			}
@@ -38,15 +38,17 @@ func New() *Collector {
 			Timeout:               confopt.Duration(time.Second * 2),
 			Include:               []string{"*.service"},
 			SkipTransient:         false,
+			CollectUnitDetails:    false,
 			CollectUnitFiles:      false,
 			IncludeUnitFiles:      []string{"*.service"},
 			CollectUnitFilesEvery: confopt.Duration(time.Minute * 5),
 		},
-		charts:        &module.Charts{},
-		client:        newSystemdDBusClient(),
-		seenUnits:     make(map[string]bool),
-		unitTransient: make(map[string]bool),
-		seenUnitFiles: make(map[string]bool),
+		charts:           &module.Charts{},
+		client:           newSystemdDBusClient(),
+		seenUnits:        make(map[string]bool),
+		unitTransient:    make(map[string]bool),
+		unitDetailCharts: make(map[string]map[string]bool),
+		seenUnitFiles:    make(map[string]bool),
 	}
 }
 
@@ -55,6 +57,7 @@ type Config struct {
 	Timeout               confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
 	Include               []string         `yaml:"include,omitempty" json:"include"`
 	SkipTransient         bool             `yaml:"skip_transient" json:"skip_transient"`
+	CollectUnitDetails    bool             `yaml:"collect_unit_details" json:"collect_unit_details"`
 	CollectUnitFiles      bool             `yaml:"collect_unit_files" json:"collect_unit_files"`
 	IncludeUnitFiles      []string         `yaml:"include_unit_files,omitempty" json:"include_unit_files"`
 	CollectUnitFilesEvery confopt.Duration `yaml:"collect_unit_files_every,omitempty" json:"collect_unit_files_every"`
@@ -69,9 +72,10 @@ type Collector struct {
 
 	systemdVersion int
 
-	seenUnits     map[string]bool
-	unitTransient map[string]bool
-	unitSr        matcher.Matcher
+	seenUnits        map[string]bool
+	unitTransient    map[string]bool
+	unitDetailCharts map[string]map[string]bool
+	unitSr           matcher.Matcher
 
 	lastListUnitFilesTime time.Time
 	cachedUnitFiles       []dbus.UnitFile
@@ -97,6 +101,7 @@ func (c *Collector) Init(context.Context) error {
 
 	c.Debugf("timeout: %s", c.Timeout)
 	c.Debugf("units: patterns '%v'", c.Include)
+	c.Debugf("unit details: enabled '%v'", c.CollectUnitDetails)
 	c.Debugf("unit files: enabled '%v', every '%s', patterns: %v",
 		c.CollectUnitFiles, c.CollectUnitFilesEvery, c.IncludeUnitFiles)
 
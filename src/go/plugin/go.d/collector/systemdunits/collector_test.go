@@ -8,15 +8,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 
 	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -867,6 +870,126 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_UnitDetails(t *testing.T) {
+	collr := New()
+	collr.Include = []string{"*.service", "system.slice"}
+	collr.CollectUnitDetails = true
+	collr.client = prepareOKClient(230)
+	require.NoError(t, collr.Init(context.Background()))
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	for k, v := range mx {
+		if strings.HasSuffix(k, "_state_change_ago") {
+			assert.InDelta(t, 3600, v, 5, k)
+			mx[k] = 3600
+		}
+	}
+
+	want := map[string]int64{
+		"unit_system_slice_cpu_usage":                                   5000000000,
+		"unit_system_slice_memory_current":                              104857600,
+		"unit_system_slice_state_activating":                            0,
+		"unit_system_slice_state_active":                                1,
+		"unit_system_slice_state_change_ago":                            3600,
+		"unit_system_slice_state_deactivating":                          0,
+		"unit_system_slice_state_failed":                                0,
+		"unit_system_slice_state_inactive":                              0,
+		"unit_system_slice_tasks_current":                               42,
+		"unit_systemd-ask-password-wall_service_exec_main_status":       0,
+		"unit_systemd-ask-password-wall_service_restarts":               0,
+		"unit_systemd-ask-password-wall_service_result_core-dump":       0,
+		"unit_systemd-ask-password-wall_service_result_exit-code":       0,
+		"unit_systemd-ask-password-wall_service_result_oom-kill":        0,
+		"unit_systemd-ask-password-wall_service_result_other":           1,
+		"unit_systemd-ask-password-wall_service_result_protocol":        0,
+		"unit_systemd-ask-password-wall_service_result_resources":       0,
+		"unit_systemd-ask-password-wall_service_result_signal":          0,
+		"unit_systemd-ask-password-wall_service_result_start-limit-hit": 0,
+		"unit_systemd-ask-password-wall_service_result_success":         0,
+		"unit_systemd-ask-password-wall_service_result_timeout":         0,
+		"unit_systemd-ask-password-wall_service_result_watchdog":        0,
+		"unit_systemd-ask-password-wall_service_state_activating":       0,
+		"unit_systemd-ask-password-wall_service_state_active":           0,
+		"unit_systemd-ask-password-wall_service_state_change_ago":       3600,
+		"unit_systemd-ask-password-wall_service_state_deactivating":     0,
+		"unit_systemd-ask-password-wall_service_state_failed":           0,
+		"unit_systemd-ask-password-wall_service_state_inactive":         1,
+		"unit_systemd-fsck-root_service_exec_main_status":               0,
+		"unit_systemd-fsck-root_service_io_read_bytes":                  4096,
+		"unit_systemd-fsck-root_service_io_write_bytes":                 8192,
+		"unit_systemd-fsck-root_service_restarts":                       0,
+		"unit_systemd-fsck-root_service_result_core-dump":               0,
+		"unit_systemd-fsck-root_service_result_exit-code":               0,
+		"unit_systemd-fsck-root_service_result_oom-kill":                0,
+		"unit_systemd-fsck-root_service_result_other":                   0,
+		"unit_systemd-fsck-root_service_result_protocol":                0,
+		"unit_systemd-fsck-root_service_result_resources":               0,
+		"unit_systemd-fsck-root_service_result_signal":                  0,
+		"unit_systemd-fsck-root_service_result_start-limit-hit":         1,
+		"unit_systemd-fsck-root_service_result_success":                 0,
+		"unit_systemd-fsck-root_service_result_timeout":                 0,
+		"unit_systemd-fsck-root_service_result_watchdog":                0,
+		"unit_systemd-fsck-root_service_state_activating":               0,
+		"unit_systemd-fsck-root_service_state_active":                   0,
+		"unit_systemd-fsck-root_service_state_change_ago":               3600,
+		"unit_systemd-fsck-root_service_state_deactivating":             0,
+		"unit_systemd-fsck-root_service_state_failed":                   0,
+		"unit_systemd-fsck-root_service_state_inactive":                 1,
+		"unit_user-runtime-dir@1000_service_exec_main_status":           0,
+		"unit_user-runtime-dir@1000_service_restarts":                   0,
+		"unit_user-runtime-dir@1000_service_result_core-dump":           0,
+		"unit_user-runtime-dir@1000_service_result_exit-code":           0,
+		"unit_user-runtime-dir@1000_service_result_oom-kill":            0,
+		"unit_user-runtime-dir@1000_service_result_other":               0,
+		"unit_user-runtime-dir@1000_service_result_protocol":            0,
+		"unit_user-runtime-dir@1000_service_result_resources":           0,
+		"unit_user-runtime-dir@1000_service_result_signal":              0,
+		"unit_user-runtime-dir@1000_service_result_start-limit-hit":     0,
+		"unit_user-runtime-dir@1000_service_result_success":             1,
+		"unit_user-runtime-dir@1000_service_result_timeout":             0,
+		"unit_user-runtime-dir@1000_service_result_watchdog":            0,
+		"unit_user-runtime-dir@1000_service_state_activating":           0,
+		"unit_user-runtime-dir@1000_service_state_active":               1,
+		"unit_user-runtime-dir@1000_service_state_change_ago":           3600,
+		"unit_user-runtime-dir@1000_service_state_deactivating":         0,
+		"unit_user-runtime-dir@1000_service_state_failed":               0,
+		"unit_user-runtime-dir@1000_service_state_inactive":             0,
+		"unit_user@1000_service_cpu_usage":                              2000000000,
+		"unit_user@1000_service_exec_main_status":                       1,
+		"unit_user@1000_service_memory_current":                         1048576,
+		"unit_user@1000_service_restarts":                               5,
+		"unit_user@1000_service_result_core-dump":                       0,
+		"unit_user@1000_service_result_exit-code":                       1,
+		"unit_user@1000_service_result_oom-kill":                        0,
+		"unit_user@1000_service_result_other":                           0,
+		"unit_user@1000_service_result_protocol":                        0,
+		"unit_user@1000_service_result_resources":                       0,
+		"unit_user@1000_service_result_signal":                          0,
+		"unit_user@1000_service_result_start-limit-hit":                 0,
+		"unit_user@1000_service_result_success":                         0,
+		"unit_user@1000_service_result_timeout":                         0,
+		"unit_user@1000_service_result_watchdog":                        0,
+		"unit_user@1000_service_state_activating":                       0,
+		"unit_user@1000_service_state_active":                           1,
+		"unit_user@1000_service_state_change_ago":                       3600,
+		"unit_user@1000_service_state_deactivating":                     0,
+		"unit_user@1000_service_state_failed":                           0,
+		"unit_user@1000_service_state_inactive":                         0,
+		"unit_user@1000_service_tasks_current":                          3,
+	}
+
+	assert.Equal(t, want, mx)
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	assert.True(t, collr.Charts().Has("unit_user@1000_service_restarts"))
+	assert.True(t, collr.Charts().Has("unit_systemd-fsck-root_service_io"))
+	assert.False(t, collr.Charts().Has("unit_user@1000_service_io"))
+	assert.False(t, collr.Charts().Has("unit_user-runtime-dir@1000_service_memory_usage"))
+	assert.False(t, collr.Charts().Has("unit_system_slice_restarts"))
+}
+
 func TestCollector_connectionReuse(t *testing.T) {
 	collr := New()
 	collr.Include = []string{"*"}
@@ -962,6 +1085,12 @@ func (m *mockConn) GetManagerProperty(prop string) (string, error) {
 }
 
 func (m *mockConn) GetUnitPropertyContext(_ context.Context, unit string, propertyName string) (*dbus.Property, error) {
+	if propertyName == stateChangeTimestampProperty {
+		return &dbus.Property{
+			Name:  propertyName,
+			Value: godbus.MakeVariant(uint64(time.Now().Add(-time.Hour).UnixMicro())),
+		}, nil
+	}
 	if propertyName != transientProperty {
 		return nil, fmt.Errorf("'GetUnitProperty' unkown property name: %s", propertyName)
 	}
@@ -979,6 +1108,19 @@ func (m *mockConn) GetUnitPropertyContext(_ context.Context, unit string, proper
 	return &prop, nil
 }
 
+func (m *mockConn) GetUnitTypePropertiesContext(_ context.Context, unit string, unitType string) (map[string]interface{}, error) {
+	if _, typ, _ := extractUnitNameType(unit); unitTypeInterfaces[typ] != unitType {
+		return nil, fmt.Errorf("'GetUnitTypeProperties' unknown interface '%s' for unit '%s'", unitType, unit)
+	}
+
+	props := make(map[string]interface{})
+	for k, v := range mockSystemdUnitTypeProperties[unit] {
+		props[k] = v
+	}
+
+	return props, nil
+}
+
 func (m *mockConn) ListUnitsContext(_ context.Context) ([]dbus.UnitStatus, error) {
 	if m.errOnListUnits {
 		return nil, errors.New("'ListUnits' call error")
@@ -1098,6 +1240,51 @@ var mockSystemdUnits = []dbus.UnitStatus{
 	{Name: `logrotate.timer`, LoadState: "loaded", ActiveState: "active"},
 }
 
+const notSet = uint64(math.MaxUint64)
+
+var mockSystemdUnitTypeProperties = map[string]map[string]interface{}{
+	"user@1000.service": {
+		"NRestarts":      uint32(5),
+		"Result":         "exit-code",
+		"ExecMainStatus": int32(1),
+		"CPUUsageNSec":   uint64(2_000_000_000),
+		"MemoryCurrent":  uint64(1_048_576),
+		"IOReadBytes":    notSet,
+		"IOWriteBytes":   notSet,
+		"TasksCurrent":   uint64(3),
+	},
+	"user-runtime-dir@1000.service": {
+		"NRestarts":      uint32(0),
+		"Result":         "success",
+		"ExecMainStatus": int32(0),
+		"CPUUsageNSec":   notSet,
+		"MemoryCurrent":  notSet,
+		"IOReadBytes":    notSet,
+		"IOWriteBytes":   notSet,
+		"TasksCurrent":   notSet,
+	},
+	"systemd-fsck-root.service": {
+		"NRestarts":      uint32(0),
+		"Result":         "start-limit-hit",
+		"ExecMainStatus": int32(0),
+		"CPUUsageNSec":   notSet,
+		"MemoryCurrent":  notSet,
+		"IOReadBytes":    uint64(4096),
+		"IOWriteBytes":   uint64(8192),
+		"TasksCurrent":   notSet,
+	},
+	"systemd-ask-password-wall.service": {
+		"NRestarts":      uint32(0),
+		"Result":         "some-new-result",
+		"ExecMainStatus": int32(0),
+	},
+	"system.slice": {
+		"CPUUsageNSec":  uint64(5_000_000_000),
+		"MemoryCurrent": uint64(104_857_600),
+		"TasksCurrent":  uint64(42),
+	},
+}
+
 var mockSystemdUnitFiles = []dbus.UnitFile{
 	{Path: "/lib/systemd/system/systemd-tmpfiles-clean.timer", Type: "static"},
 	{Path: "/lib/systemd/system/sysstat-summary.timer", Type: "disabled"},
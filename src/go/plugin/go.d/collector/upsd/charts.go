@@ -14,11 +14,17 @@ const (
 	prioUpsLoadUsage
 	prioUpsStatus
 	prioUpsTemperature
+	prioUpsBatteryTransfers
+	prioUpsOnBatteryDuration
+	prioUpsOnBatteryTime
 
 	prioBatteryCharge
 	prioBatteryEstimatedRuntime
 	prioBatteryVoltage
 	prioBatteryVoltageNominal
+	prioBatteryAge
+	prioSelfTestResult
+	prioSelfTestAge
 
 	prioInputVoltage
 	prioInputVoltageNominal
@@ -33,6 +39,11 @@ const (
 	prioOutputCurrentNominal
 	prioOutputFrequency
 	prioOutputFrequencyNominal
+
+	prioOutletRealPower
+	prioOutletPower
+	prioOutletCurrent
+	prioOutletStatus
 )
 
 var upsChartsTmpl = module.Charts{
@@ -40,11 +51,17 @@ var upsChartsTmpl = module.Charts{
 	upsLoadUsageChartTmpl.Copy(),
 	upsStatusChartTmpl.Copy(),
 	upsTemperatureChartTmpl.Copy(),
+	upsBatteryTransfersChartTmpl.Copy(),
+	upsOnBatteryDurationChartTmpl.Copy(),
+	upsOnBatteryTimeChartTmpl.Copy(),
 
 	upsBatteryChargePercentChartTmpl.Copy(),
 	upsBatteryEstimatedRuntimeChartTmpl.Copy(),
 	upsBatteryVoltageChartTmpl.Copy(),
 	upsBatteryVoltageNominalChartTmpl.Copy(),
+	upsBatteryAgeChartTmpl.Copy(),
+	upsSelfTestResultChartTmpl.Copy(),
+	upsSelfTestAgeChartTmpl.Copy(),
 
 	upsInputVoltageChartTmpl.Copy(),
 	upsInputVoltageNominalChartTmpl.Copy(),
@@ -125,6 +142,43 @@ var (
 			{ID: "ups_%s_ups.temperature", Name: "temperature", Div: varPrecision},
 		},
 	}
+	upsBatteryTransfersChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.battery_transfers",
+		Title:    "UPS transfers to battery",
+		Units:    "transfers/s",
+		Fam:      "ups",
+		Ctx:      "upsd.ups_battery_transfers",
+		Priority: prioUpsBatteryTransfers,
+		Dims: module.Dims{
+			{ID: "ups_%s_ups.battery_transfers", Name: "transfers", Algo: module.Incremental},
+		},
+	}
+	upsOnBatteryDurationChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.on_battery_duration",
+		Title:    "UPS on battery event duration",
+		Units:    "seconds",
+		Fam:      "ups",
+		Ctx:      "upsd.ups_on_battery_duration",
+		Priority: prioUpsOnBatteryDuration,
+		Dims: module.Dims{
+			{ID: "ups_%s_ups.on_battery.current_duration", Name: "current"},
+			{ID: "ups_%s_ups.on_battery.last_duration", Name: "last"},
+		},
+	}
+	upsOnBatteryTimeChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.on_battery_time",
+		Title:    "UPS cumulative time on battery",
+		Units:    "seconds",
+		Fam:      "ups",
+		Ctx:      "upsd.ups_on_battery_time",
+		Priority: prioUpsOnBatteryTime,
+		Dims: module.Dims{
+			{ID: "ups_%s_ups.on_battery.total_time", Name: "on_battery"},
+		},
+	}
 )
 
 var (
@@ -177,6 +231,45 @@ var (
 			{ID: "ups_%s_battery.voltage.nominal", Name: "nominal_voltage", Div: varPrecision},
 		},
 	}
+	upsBatteryAgeChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.battery_age",
+		Title:    "UPS Battery age (time since battery change)",
+		Units:    "seconds",
+		Fam:      "battery",
+		Ctx:      "upsd.ups_battery_age",
+		Priority: prioBatteryAge,
+		Dims: module.Dims{
+			{ID: "ups_%s_battery.age", Name: "age"},
+		},
+	}
+	upsSelfTestResultChartTmpl = func() module.Chart {
+		chart := module.Chart{
+			IDSep:    true,
+			ID:       "%s.self_test_result",
+			Title:    "UPS Battery self-test result",
+			Units:    "status",
+			Fam:      "battery",
+			Ctx:      "upsd.ups_self_test_result",
+			Priority: prioSelfTestResult,
+		}
+		for _, v := range upsTestResults {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "ups_%s_ups.test.result." + v, Name: v})
+		}
+		return chart
+	}()
+	upsSelfTestAgeChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.self_test_age",
+		Title:    "UPS Battery time since last self-test",
+		Units:    "seconds",
+		Fam:      "battery",
+		Ctx:      "upsd.ups_self_test_age",
+		Priority: prioSelfTestAge,
+		Dims: module.Dims{
+			{ID: "ups_%s_ups.test.age", Name: "age"},
+		},
+	}
 )
 
 var (
@@ -329,6 +422,58 @@ var (
 	}
 )
 
+var (
+	outletRealPowerChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.outlet_%s_realpower",
+		Title:    "UPS Outlet real power",
+		Units:    "Watts",
+		Fam:      "outlets",
+		Ctx:      "upsd.ups_outlet_realpower",
+		Priority: prioOutletRealPower,
+		Dims: module.Dims{
+			{ID: "ups_%s_outlet.%s.realpower", Name: "realpower", Div: varPrecision},
+		},
+	}
+	outletPowerChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.outlet_%s_power",
+		Title:    "UPS Outlet apparent power",
+		Units:    "VA",
+		Fam:      "outlets",
+		Ctx:      "upsd.ups_outlet_power",
+		Priority: prioOutletPower,
+		Dims: module.Dims{
+			{ID: "ups_%s_outlet.%s.power", Name: "power", Div: varPrecision},
+		},
+	}
+	outletCurrentChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.outlet_%s_current",
+		Title:    "UPS Outlet current",
+		Units:    "Ampere",
+		Fam:      "outlets",
+		Ctx:      "upsd.ups_outlet_current",
+		Priority: prioOutletCurrent,
+		Dims: module.Dims{
+			{ID: "ups_%s_outlet.%s.current", Name: "current", Div: varPrecision},
+		},
+	}
+	outletStatusChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "%s.outlet_%s_status",
+		Title:    "UPS Outlet status",
+		Units:    "status",
+		Fam:      "outlets",
+		Ctx:      "upsd.ups_outlet_status",
+		Priority: prioOutletStatus,
+		Dims: module.Dims{
+			{ID: "ups_%s_outlet.%s.status.on", Name: "on"},
+			{ID: "ups_%s_outlet.%s.status.off", Name: "off"},
+		},
+	}
+)
+
 func (c *Collector) addUPSCharts(ups upsUnit) {
 	charts := upsChartsTmpl.Copy()
 
@@ -341,6 +486,10 @@ func (c *Collector) addUPSCharts(ups upsUnit) {
 		{varBatteryVoltageNominal, upsBatteryVoltageNominalChartTmpl.ID},
 
 		{varUpsTemperature, upsTemperatureChartTmpl.ID},
+		{varUpsStatus, upsBatteryTransfersChartTmpl.ID},
+		{varUpsStatus, upsOnBatteryDurationChartTmpl.ID},
+		{varUpsStatus, upsOnBatteryTimeChartTmpl.ID},
+		{varUpsTestResult, upsSelfTestResultChartTmpl.ID},
 
 		{varInputVoltage, upsInputVoltageChartTmpl.ID},
 		{varInputVoltageNominal, upsInputVoltageNominalChartTmpl.ID},
@@ -362,19 +511,25 @@ func (c *Collector) addUPSCharts(ups upsUnit) {
 		}
 	}
 
+	for _, v := range []struct{ v, id string }{
+		{varBatteryDate, upsBatteryAgeChartTmpl.ID},
+		{varUpsTestDate, upsSelfTestAgeChartTmpl.ID},
+	} {
+		if _, ok := parseDate(ups.vars[v.v]); !ok {
+			removed = append(removed, v.v)
+			_ = charts.Remove(v.id)
+		}
+	}
+
 	c.Debugf("UPS '%s' no metrics: %v", ups.name, removed)
 
 	name := cleanUpsName(ups.name)
 
 	for _, chart := range *charts {
 		chart.ID = fmt.Sprintf(chart.ID, name)
-		chart.Labels = []module.Label{
-			{Key: "ups_name", Value: ups.name},
-			{Key: "battery_type", Value: ups.vars[varBatteryType]},
-			{Key: "device_model", Value: ups.vars[varDeviceModel]},
-			{Key: "device_serial", Value: ups.vars[varDeviceSerial]},
-			{Key: "device_manufacturer", Value: ups.vars[varDeviceMfr]},
-			{Key: "device_type", Value: ups.vars[varDeviceType]},
+		chart.Labels = upsChartLabels(ups)
+		if chart.ID == fmt.Sprintf(upsStatusChartTmpl.ID, name) {
+			chart.Labels = append(chart.Labels, module.Label{Key: "instant_commands", Value: ""})
 		}
 		for _, dim := range chart.Dims {
 			dim.ID = fmt.Sprintf(dim.ID, ups.name)
@@ -386,6 +541,78 @@ func (c *Collector) addUPSCharts(ups upsUnit) {
 	}
 }
 
+func (c *Collector) updateInstantCommandsLabel(name, cmds string) {
+	chart := c.Charts().Get(fmt.Sprintf(upsStatusChartTmpl.ID, cleanUpsName(name)))
+	if chart == nil {
+		return
+	}
+	for i, l := range chart.Labels {
+		if l.Key == "instant_commands" {
+			chart.Labels[i].Value = cmds
+			chart.MarkNotCreated()
+			return
+		}
+	}
+}
+
+func (c *Collector) addOutletCharts(ups upsUnit, id string) {
+	charts := module.Charts{}
+
+	px := "outlet." + id + "."
+
+	for _, v := range []struct {
+		v     string
+		chart *module.Chart
+	}{
+		{px + varOutletRealPower, &outletRealPowerChartTmpl},
+		{px + varOutletPower, &outletPowerChartTmpl},
+		{px + varOutletCurrent, &outletCurrentChartTmpl},
+		{px + varOutletStatus, &outletStatusChartTmpl},
+	} {
+		if hasVar(ups.vars, v.v) {
+			charts = append(charts, v.chart.Copy())
+		}
+	}
+
+	name := cleanUpsName(ups.name)
+
+	for _, chart := range charts {
+		chart.ID = fmt.Sprintf(chart.ID, name, id)
+		chart.Labels = append(upsChartLabels(ups),
+			module.Label{Key: "outlet_id", Value: id},
+			module.Label{Key: "outlet_desc", Value: ups.vars[px+varOutletDesc]},
+		)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, ups.name, id)
+		}
+	}
+
+	if err := c.Charts().Add(charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeOutletCharts(upsName, id string) {
+	px := fmt.Sprintf("%s.outlet_%s_", cleanUpsName(upsName), id)
+	for _, chart := range *c.Charts() {
+		if strings.HasPrefix(chart.ID, px) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func upsChartLabels(ups upsUnit) []module.Label {
+	return []module.Label{
+		{Key: "ups_name", Value: ups.name},
+		{Key: "battery_type", Value: ups.vars[varBatteryType]},
+		{Key: "device_model", Value: ups.vars[varDeviceModel]},
+		{Key: "device_serial", Value: ups.vars[varDeviceSerial]},
+		{Key: "device_manufacturer", Value: ups.vars[varDeviceMfr]},
+		{Key: "device_type", Value: ups.vars[varDeviceType]},
+	}
+}
+
 func (c *Collector) removeUPSCharts(name string) {
 	name = cleanUpsName(name)
 	for _, chart := range *c.Charts() {
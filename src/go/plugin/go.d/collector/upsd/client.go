@@ -16,6 +16,7 @@ const (
 	commandPassword = "PASSWORD %s"
 	commandListUPS  = "LIST UPS"
 	commandListVar  = "LIST VAR %s"
+	commandListCmd  = "LIST CMD %s"
 	commandLogout   = "LOGOUT"
 )
 
@@ -27,6 +28,7 @@ type upsdConn interface {
 	disconnect() error
 	authenticate(string, string) error
 	upsUnits() ([]upsUnit, error)
+	upsCommands(string) ([]string, error)
 }
 
 type upsUnit struct {
@@ -128,6 +130,29 @@ func (c *upsdClient) upsUnits() ([]upsUnit, error) {
 	return upsUnits, nil
 }
 
+func (c *upsdClient) upsCommands(name string) ([]string, error) {
+	cmd := fmt.Sprintf(commandListCmd, name)
+	resp, err := c.sendCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmds []string
+
+	for _, v := range resp {
+		if !strings.HasPrefix(v, "CMD ") {
+			continue
+		}
+		parts := splitLine(v)
+		if len(parts) < 3 {
+			continue
+		}
+		cmds = append(cmds, parts[2])
+	}
+
+	return cmds, nil
+}
+
 func (c *upsdClient) sendCommand(cmd string) ([]string, error) {
 	var resp []string
 	var errMsg string
@@ -4,8 +4,12 @@ package upsd
 
 import (
 	"errors"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
 )
 
 func (c *Collector) collect() (map[string]int64, error) {
@@ -54,6 +58,7 @@ func (c *Collector) establishConnection() (upsdConn, error) {
 
 func (c *Collector) collectUPSUnits(mx map[string]int64, upsUnits []upsUnit) {
 	seen := make(map[string]bool)
+	now := c.now()
 
 	for _, ups := range upsUnits {
 		seen[ups.name] = true
@@ -63,6 +68,7 @@ func (c *Collector) collectUPSUnits(mx map[string]int64, upsUnits []upsUnit) {
 			c.upsUnits[ups.name] = true
 			c.addUPSCharts(ups)
 		}
+		c.updateInstantCommands(ups.name, now)
 
 		writeVar(mx, ups, varBatteryCharge)
 		writeVar(mx, ups, varBatteryRuntime)
@@ -88,16 +94,58 @@ func (c *Collector) collectUPSUnits(mx map[string]int64, upsUnits []upsUnit) {
 		writeVar(mx, ups, varUpsTemperature)
 		writeUpsLoadUsage(mx, ups)
 		writeUpsStatus(mx, ups)
+		writeUpsSelfTest(mx, ups, now)
+		writeDateAge(mx, ups, varBatteryDate, "battery.age", now)
+		c.writeBatteryEvents(mx, ups, now)
+		c.collectOutlets(mx, ups)
 	}
 
 	for name := range c.upsUnits {
 		if !seen[name] {
 			delete(c.upsUnits, name)
+			delete(c.outlets, name)
+			delete(c.batteryEvents, name)
+			delete(c.instantCmds, name)
 			c.removeUPSCharts(name)
 		}
 	}
 }
 
+const instantCommandsRefreshEvery = time.Minute * 5
+
+// instantCommands tracks the instant commands ("LIST CMD") of a UPS.
+type instantCommands struct {
+	value       string
+	refreshTime time.Time
+}
+
+// updateInstantCommands refreshes the instant commands supported by the UPS. They are exposed read-only
+// (as a status chart label) and re-listed every instantCommandsRefreshEvery.
+func (c *Collector) updateInstantCommands(name string, now time.Time) {
+	ic, ok := c.instantCmds[name]
+	if !ok {
+		ic = &instantCommands{}
+		c.instantCmds[name] = ic
+	}
+	if ok && now.Before(ic.refreshTime) {
+		return
+	}
+	ic.refreshTime = now.Add(instantCommandsRefreshEvery)
+
+	cmds, err := c.conn.upsCommands(name)
+	if err != nil {
+		c.Debugf("failed to list instant commands of UPS '%s': %v", name, err)
+		return
+	}
+	slices.Sort(cmds)
+
+	if v := strings.Join(cmds, ","); !ok || v != ic.value {
+		c.Debugf("UPS '%s' instant commands: %v", name, cmds)
+		ic.value = v
+		c.updateInstantCommandsLabel(name, v)
+	}
+}
+
 func writeVar(mx map[string]int64, ups upsUnit, v string) {
 	s, ok := ups.vars[v]
 	if !ok {
@@ -170,6 +218,197 @@ func writeUpsStatus(mx map[string]int64, ups upsUnit) {
 	}
 }
 
+// https://github.com/networkupstools/nut/blob/master/docs/nut-names.txt (ups.test.result is an opaque string)
+const (
+	testResultNone       = "none"
+	testResultInProgress = "in_progress"
+	testResultScheduled  = "scheduled"
+	testResultPassed     = "passed"
+	testResultWarning    = "warning"
+	testResultFailed     = "failed"
+	testResultAborted    = "aborted"
+	testResultOther      = "other"
+)
+
+var upsTestResults = []string{
+	testResultNone,
+	testResultInProgress,
+	testResultScheduled,
+	testResultPassed,
+	testResultWarning,
+	testResultFailed,
+	testResultAborted,
+	testResultOther,
+}
+
+func writeUpsSelfTest(mx map[string]int64, ups upsUnit, now time.Time) {
+	writeDateAge(mx, ups, varUpsTestDate, "ups.test.age", now)
+
+	if !hasVar(ups.vars, varUpsTestResult) {
+		return
+	}
+
+	res := parseTestResult(ups.vars[varUpsTestResult])
+	px := prefix(ups) + "ups.test.result."
+
+	for _, v := range upsTestResults {
+		mx[px+v] = metrix.Bool(v == res)
+	}
+}
+
+func parseTestResult(s string) string {
+	// drivers report e.g. 'No test initiated', 'In progress', 'Done and passed', 'Done and warning', 'Done and error', 'Aborted'
+	s = strings.ToLower(s)
+
+	switch {
+	case strings.HasPrefix(s, "no test"):
+		return testResultNone
+	case strings.Contains(s, "in progress"):
+		return testResultInProgress
+	case strings.Contains(s, "scheduled"):
+		return testResultScheduled
+	case strings.Contains(s, "passed"):
+		return testResultPassed
+	case strings.Contains(s, "warning"):
+		return testResultWarning
+	case strings.Contains(s, "error"), strings.Contains(s, "fail"):
+		return testResultFailed
+	case strings.Contains(s, "abort"):
+		return testResultAborted
+	default:
+		return testResultOther
+	}
+}
+
+func writeDateAge(mx map[string]int64, ups upsUnit, v, key string, now time.Time) {
+	date, ok := parseDate(ups.vars[v])
+	if !ok {
+		return
+	}
+	mx[prefix(ups)+key] = max(0, int64(now.Sub(date).Seconds()))
+}
+
+func parseDate(s string) (time.Time, bool) {
+	// date variables are opaque strings, drivers use 'YYYY/MM/DD', 'YYYY-MM-DD' or 'MM/DD/YY'
+	for _, layout := range []string{"2006/01/02", "2006-01-02", "01/02/06", "01/02/2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// batteryEvents tracks the transfers to battery derived from the 'OB' flag transitions of 'ups.status'.
+type batteryEvents struct {
+	onBattery bool
+	since     time.Time
+	transfers int64
+	last      time.Duration // duration of the last completed on-battery event
+	total     time.Duration // cumulative duration of the completed on-battery events
+}
+
+func (c *Collector) writeBatteryEvents(mx map[string]int64, ups upsUnit, now time.Time) {
+	if !hasVar(ups.vars, varUpsStatus) {
+		return
+	}
+
+	onBattery := slices.Contains(strings.Fields(ups.vars[varUpsStatus]), "OB")
+
+	ev, ok := c.batteryEvents[ups.name]
+	if !ok {
+		// The UPS may already be on battery when first seen: the transfer is not counted,
+		// and the duration is measured from now.
+		ev = &batteryEvents{onBattery: onBattery, since: now}
+		c.batteryEvents[ups.name] = ev
+	}
+
+	switch {
+	case onBattery && !ev.onBattery:
+		ev.transfers++
+		ev.since = now
+	case !onBattery && ev.onBattery:
+		ev.last = now.Sub(ev.since)
+		ev.total += ev.last
+	}
+	ev.onBattery = onBattery
+
+	var current time.Duration
+	if onBattery {
+		current = now.Sub(ev.since)
+	}
+
+	px := prefix(ups)
+	mx[px+"ups.battery_transfers"] = ev.transfers
+	mx[px+"ups.on_battery.current_duration"] = int64(current.Seconds())
+	mx[px+"ups.on_battery.last_duration"] = int64(ev.last.Seconds())
+	mx[px+"ups.on_battery.total_time"] = int64((ev.total + current).Seconds())
+}
+
+func (c *Collector) collectOutlets(mx map[string]int64, ups upsUnit) {
+	outlets := upsOutlets(ups)
+
+	if c.outlets[ups.name] == nil {
+		c.outlets[ups.name] = make(map[string]bool)
+	}
+	seen := c.outlets[ups.name]
+
+	for _, id := range outlets {
+		if !seen[id] {
+			seen[id] = true
+			c.addOutletCharts(ups, id)
+		}
+
+		px := "outlet." + id + "."
+
+		writeVar(mx, ups, px+varOutletRealPower)
+		writeVar(mx, ups, px+varOutletPower)
+		writeVar(mx, ups, px+varOutletCurrent)
+
+		if st, ok := ups.vars[px+varOutletStatus]; ok {
+			mx[prefix(ups)+px+"status.on"] = metrix.Bool(st == "on")
+			mx[prefix(ups)+px+"status.off"] = metrix.Bool(st == "off")
+		}
+	}
+
+	for id := range seen {
+		if !slices.Contains(outlets, id) {
+			delete(seen, id)
+			c.removeOutletCharts(ups.name, id)
+		}
+	}
+}
+
+// upsOutlets returns the sorted outlet indexes ('outlet.<n>.*' variables).
+func upsOutlets(ups upsUnit) []string {
+	var outlets []string
+
+	for v := range ups.vars {
+		s, ok := strings.CutPrefix(v, "outlet.")
+		if !ok {
+			continue
+		}
+		id, _, ok := strings.Cut(s, ".")
+		if !ok {
+			continue
+		}
+		if _, err := strconv.Atoi(id); err != nil {
+			// 'outlet.group.*', 'outlet.id', ...
+			continue
+		}
+		if !slices.Contains(outlets, id) {
+			outlets = append(outlets, id)
+		}
+	}
+
+	slices.SortFunc(outlets, func(a, b string) int {
+		na, _ := strconv.Atoi(a)
+		nb, _ := strconv.Atoi(b)
+		return na - nb
+	})
+
+	return outlets
+}
+
 func hasVar(vars map[string]string, v string) bool {
 	_, ok := vars[v]
 	return ok
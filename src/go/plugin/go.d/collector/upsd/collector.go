@@ -29,9 +29,13 @@ func New() *Collector {
 			Address: "127.0.0.1:3493",
 			Timeout: confopt.Duration(time.Second * 2),
 		},
-		newUpsdConn: newUpsdConn,
-		charts:      &module.Charts{},
-		upsUnits:    make(map[string]bool),
+		newUpsdConn:   newUpsdConn,
+		now:           time.Now,
+		charts:        &module.Charts{},
+		upsUnits:      make(map[string]bool),
+		outlets:       make(map[string]map[string]bool),
+		batteryEvents: make(map[string]*batteryEvents),
+		instantCmds:   make(map[string]*instantCommands),
 	}
 }
 
@@ -52,8 +56,12 @@ type Collector struct {
 
 	conn        upsdConn
 	newUpsdConn func(Config) upsdConn
+	now         func() time.Time
 
-	upsUnits map[string]bool
+	upsUnits      map[string]bool
+	outlets       map[string]map[string]bool
+	batteryEvents map[string]*batteryEvents
+	instantCmds   map[string]*instantCommands
 }
 
 func (c *Collector) Configuration() any {
@@ -7,7 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 
@@ -145,77 +147,121 @@ func TestCollector_Collect(t *testing.T) {
 			prepareUpsd: New,
 			prepareMock: prepareMockConnOK,
 			wantCollected: map[string]int64{
-				"ups_cp1500_battery.charge":          10000,
-				"ups_cp1500_battery.runtime":         489000,
-				"ups_cp1500_battery.voltage":         2400,
-				"ups_cp1500_battery.voltage.nominal": 2400,
-				"ups_cp1500_input.voltage":           22700,
-				"ups_cp1500_input.voltage.nominal":   23000,
-				"ups_cp1500_output.voltage":          26000,
-				"ups_cp1500_ups.load":                800,
-				"ups_cp1500_ups.load.usage":          4300,
-				"ups_cp1500_ups.realpower.nominal":   90000,
-				"ups_cp1500_ups.status.BOOST":        0,
-				"ups_cp1500_ups.status.BYPASS":       0,
-				"ups_cp1500_ups.status.CAL":          0,
-				"ups_cp1500_ups.status.CHRG":         0,
-				"ups_cp1500_ups.status.DISCHRG":      0,
-				"ups_cp1500_ups.status.FSD":          0,
-				"ups_cp1500_ups.status.HB":           0,
-				"ups_cp1500_ups.status.LB":           0,
-				"ups_cp1500_ups.status.OB":           0,
-				"ups_cp1500_ups.status.OFF":          0,
-				"ups_cp1500_ups.status.OL":           1,
-				"ups_cp1500_ups.status.OVER":         0,
-				"ups_cp1500_ups.status.RB":           0,
-				"ups_cp1500_ups.status.TRIM":         0,
-				"ups_cp1500_ups.status.other":        0,
-				"ups_cp1600_battery.charge":          10000,
-				"ups_cp1600_battery.runtime":         197000,
-				"ups_cp1600_battery.voltage":         5480,
-				"ups_cp1600_battery.voltage.nominal": 4800,
-				"ups_cp1600_ups.status.BOOST":        0,
-				"ups_cp1600_ups.status.BYPASS":       0,
-				"ups_cp1600_ups.status.CAL":          0,
-				"ups_cp1600_ups.status.CHRG":         0,
-				"ups_cp1600_ups.status.DISCHRG":      0,
-				"ups_cp1600_ups.status.FSD":          0,
-				"ups_cp1600_ups.status.HB":           0,
-				"ups_cp1600_ups.status.LB":           0,
-				"ups_cp1600_ups.status.OB":           0,
-				"ups_cp1600_ups.status.OFF":          0,
-				"ups_cp1600_ups.status.OL":           1,
-				"ups_cp1600_ups.status.OVER":         0,
-				"ups_cp1600_ups.status.RB":           0,
-				"ups_cp1600_ups.status.TRIM":         0,
-				"ups_cp1600_ups.status.other":        0,
-				"ups_pr3000_battery.charge":          10000,
-				"ups_pr3000_battery.runtime":         110800,
-				"ups_pr3000_battery.voltage":         5990,
-				"ups_pr3000_battery.voltage.nominal": 4800,
-				"ups_pr3000_input.voltage":           22500,
-				"ups_pr3000_input.voltage.nominal":   23000,
-				"ups_pr3000_output.voltage":          22500,
-				"ups_pr3000_ups.load":                2800,
-				"ups_pr3000_ups.load.usage":          84000,
-				"ups_pr3000_ups.realpower.nominal":   300000,
-				"ups_pr3000_ups.status.BOOST":        0,
-				"ups_pr3000_ups.status.BYPASS":       0,
-				"ups_pr3000_ups.status.CAL":          0,
-				"ups_pr3000_ups.status.CHRG":         0,
-				"ups_pr3000_ups.status.DISCHRG":      0,
-				"ups_pr3000_ups.status.FSD":          0,
-				"ups_pr3000_ups.status.HB":           0,
-				"ups_pr3000_ups.status.LB":           0,
-				"ups_pr3000_ups.status.OB":           0,
-				"ups_pr3000_ups.status.OFF":          0,
-				"ups_pr3000_ups.status.OL":           1,
-				"ups_pr3000_ups.status.OVER":         0,
-				"ups_pr3000_ups.status.RB":           0,
-				"ups_pr3000_ups.status.TRIM":         0,
-				"ups_pr3000_ups.status.other":        0,
+				"ups_cp1500_battery.charge":                  10000,
+				"ups_cp1500_battery.runtime":                 489000,
+				"ups_cp1500_battery.voltage":                 2400,
+				"ups_cp1500_battery.voltage.nominal":         2400,
+				"ups_cp1500_input.voltage":                   22700,
+				"ups_cp1500_input.voltage.nominal":           23000,
+				"ups_cp1500_output.voltage":                  26000,
+				"ups_cp1500_ups.battery_transfers":           0,
+				"ups_cp1500_ups.load":                        800,
+				"ups_cp1500_ups.load.usage":                  4300,
+				"ups_cp1500_ups.on_battery.current_duration": 0,
+				"ups_cp1500_ups.on_battery.last_duration":    0,
+				"ups_cp1500_ups.on_battery.total_time":       0,
+				"ups_cp1500_ups.realpower.nominal":           90000,
+				"ups_cp1500_ups.status.BOOST":                0,
+				"ups_cp1500_ups.status.BYPASS":               0,
+				"ups_cp1500_ups.status.CAL":                  0,
+				"ups_cp1500_ups.status.CHRG":                 0,
+				"ups_cp1500_ups.status.DISCHRG":              0,
+				"ups_cp1500_ups.status.FSD":                  0,
+				"ups_cp1500_ups.status.HB":                   0,
+				"ups_cp1500_ups.status.LB":                   0,
+				"ups_cp1500_ups.status.OB":                   0,
+				"ups_cp1500_ups.status.OFF":                  0,
+				"ups_cp1500_ups.status.OL":                   1,
+				"ups_cp1500_ups.status.OVER":                 0,
+				"ups_cp1500_ups.status.RB":                   0,
+				"ups_cp1500_ups.status.TRIM":                 0,
+				"ups_cp1500_ups.status.other":                0,
+				"ups_cp1500_ups.test.result.aborted":         0,
+				"ups_cp1500_ups.test.result.failed":          0,
+				"ups_cp1500_ups.test.result.in_progress":     0,
+				"ups_cp1500_ups.test.result.none":            1,
+				"ups_cp1500_ups.test.result.other":           0,
+				"ups_cp1500_ups.test.result.passed":          0,
+				"ups_cp1500_ups.test.result.scheduled":       0,
+				"ups_cp1500_ups.test.result.warning":         0,
+				"ups_cp1600_battery.age":                     189216000,
+				"ups_cp1600_battery.charge":                  10000,
+				"ups_cp1600_battery.runtime":                 197000,
+				"ups_cp1600_battery.voltage":                 5480,
+				"ups_cp1600_battery.voltage.nominal":         4800,
+				"ups_cp1600_outlet.1.current":                80,
+				"ups_cp1600_outlet.1.realpower":              9600,
+				"ups_cp1600_outlet.1.status.off":             0,
+				"ups_cp1600_outlet.1.status.on":              1,
+				"ups_cp1600_outlet.2.status.off":             1,
+				"ups_cp1600_outlet.2.status.on":              0,
+				"ups_cp1600_ups.battery_transfers":           0,
+				"ups_cp1600_ups.on_battery.current_duration": 0,
+				"ups_cp1600_ups.on_battery.last_duration":    0,
+				"ups_cp1600_ups.on_battery.total_time":       0,
+				"ups_cp1600_ups.status.BOOST":                0,
+				"ups_cp1600_ups.status.BYPASS":               0,
+				"ups_cp1600_ups.status.CAL":                  0,
+				"ups_cp1600_ups.status.CHRG":                 0,
+				"ups_cp1600_ups.status.DISCHRG":              0,
+				"ups_cp1600_ups.status.FSD":                  0,
+				"ups_cp1600_ups.status.HB":                   0,
+				"ups_cp1600_ups.status.LB":                   0,
+				"ups_cp1600_ups.status.OB":                   0,
+				"ups_cp1600_ups.status.OFF":                  0,
+				"ups_cp1600_ups.status.OL":                   1,
+				"ups_cp1600_ups.status.OVER":                 0,
+				"ups_cp1600_ups.status.RB":                   0,
+				"ups_cp1600_ups.status.TRIM":                 0,
+				"ups_cp1600_ups.status.other":                0,
+				"ups_cp1600_ups.test.age":                    2678400,
+				"ups_cp1600_ups.test.result.aborted":         0,
+				"ups_cp1600_ups.test.result.failed":          0,
+				"ups_cp1600_ups.test.result.in_progress":     0,
+				"ups_cp1600_ups.test.result.none":            0,
+				"ups_cp1600_ups.test.result.other":           0,
+				"ups_cp1600_ups.test.result.passed":          1,
+				"ups_cp1600_ups.test.result.scheduled":       0,
+				"ups_cp1600_ups.test.result.warning":         0,
+				"ups_pr3000_battery.charge":                  10000,
+				"ups_pr3000_battery.runtime":                 110800,
+				"ups_pr3000_battery.voltage":                 5990,
+				"ups_pr3000_battery.voltage.nominal":         4800,
+				"ups_pr3000_input.voltage":                   22500,
+				"ups_pr3000_input.voltage.nominal":           23000,
+				"ups_pr3000_output.voltage":                  22500,
+				"ups_pr3000_ups.battery_transfers":           0,
+				"ups_pr3000_ups.load":                        2800,
+				"ups_pr3000_ups.load.usage":                  84000,
+				"ups_pr3000_ups.on_battery.current_duration": 0,
+				"ups_pr3000_ups.on_battery.last_duration":    0,
+				"ups_pr3000_ups.on_battery.total_time":       0,
+				"ups_pr3000_ups.realpower.nominal":           300000,
+				"ups_pr3000_ups.status.BOOST":                0,
+				"ups_pr3000_ups.status.BYPASS":               0,
+				"ups_pr3000_ups.status.CAL":                  0,
+				"ups_pr3000_ups.status.CHRG":                 0,
+				"ups_pr3000_ups.status.DISCHRG":              0,
+				"ups_pr3000_ups.status.FSD":                  0,
+				"ups_pr3000_ups.status.HB":                   0,
+				"ups_pr3000_ups.status.LB":                   0,
+				"ups_pr3000_ups.status.OB":                   0,
+				"ups_pr3000_ups.status.OFF":                  0,
+				"ups_pr3000_ups.status.OL":                   1,
+				"ups_pr3000_ups.status.OVER":                 0,
+				"ups_pr3000_ups.status.RB":                   0,
+				"ups_pr3000_ups.status.TRIM":                 0,
+				"ups_pr3000_ups.status.other":                0,
+				"ups_pr3000_ups.test.result.aborted":         0,
+				"ups_pr3000_ups.test.result.failed":          0,
+				"ups_pr3000_ups.test.result.in_progress":     0,
+				"ups_pr3000_ups.test.result.none":            1,
+				"ups_pr3000_ups.test.result.other":           0,
+				"ups_pr3000_ups.test.result.passed":          0,
+				"ups_pr3000_ups.test.result.scheduled":       0,
+				"ups_pr3000_ups.test.result.warning":         0,
 			},
-			wantCharts:           25,
+			wantCharts:           43,
 			wantConnConnect:      true,
 			wantConnDisconnect:   false,
 			wantConnAuthenticate: false,
@@ -266,6 +312,7 @@ func TestCollector_Collect(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			collr := test.prepareUpsd()
+			collr.now = func() time.Time { return testNow }
 			require.NoError(t, collr.Init(context.Background()))
 
 			mock := test.prepareMock()
@@ -287,6 +334,118 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_BatteryEvents(t *testing.T) {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+
+	mock := prepareMockConnOK()
+	collr.newUpsdConn = func(Config) upsdConn { return mock }
+
+	now := testNow
+	collr.now = func() time.Time { return now }
+
+	steps := []struct {
+		status  string
+		elapsed time.Duration
+		want    [4]int64 // transfers, current duration, last duration, total time
+	}{
+		{status: "OL", want: [4]int64{0, 0, 0, 0}},
+		{status: "OB DISCHRG", elapsed: time.Second * 10, want: [4]int64{1, 0, 0, 0}},
+		{status: "OB DISCHRG", elapsed: time.Second * 30, want: [4]int64{1, 30, 0, 30}},
+		{status: "OL CHRG", elapsed: time.Second * 30, want: [4]int64{1, 0, 60, 60}},
+		{status: "OB", elapsed: time.Second * 10, want: [4]int64{2, 0, 60, 60}},
+		{status: "OB", elapsed: time.Second * 5, want: [4]int64{2, 5, 60, 65}},
+		{status: "OL", elapsed: time.Second * 5, want: [4]int64{2, 0, 10, 70}},
+	}
+
+	for i, step := range steps {
+		now = now.Add(step.elapsed)
+		mock.upsStatus = step.status
+
+		mx := collr.Collect(context.Background())
+		require.NotNilf(t, mx, "step %d", i)
+
+		got := [4]int64{
+			mx["ups_cp1500_ups.battery_transfers"],
+			mx["ups_cp1500_ups.on_battery.current_duration"],
+			mx["ups_cp1500_ups.on_battery.last_duration"],
+			mx["ups_cp1500_ups.on_battery.total_time"],
+		}
+		assert.Equalf(t, step.want, got, "step %d (%s)", i, step.status)
+	}
+}
+
+func TestCollector_Collect_InstantCommands(t *testing.T) {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	collr.now = func() time.Time { return now }
+	mock := prepareMockConnOK()
+	collr.newUpsdConn = func(Config) upsdConn { return mock }
+
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	chart := collr.Charts().Get("cp1500.status")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{
+		Key:   "instant_commands",
+		Value: "beeper.disable,beeper.enable,load.off,test.battery.start.quick",
+	})
+
+	for _, chart := range *collr.Charts() {
+		if !strings.HasSuffix(chart.ID, ".status") {
+			for _, l := range chart.Labels {
+				assert.NotEqualf(t, "instant_commands", l.Key, "chart '%s'", chart.ID)
+			}
+		}
+	}
+
+	chart = collr.Charts().Get("pr3000.status")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "instant_commands", Value: ""})
+
+	mock.extraCmds = []string{"shutdown.return"}
+	now = now.Add(time.Minute)
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	chart = collr.Charts().Get("cp1500.status")
+	assert.Contains(t, chart.Labels, module.Label{
+		Key:   "instant_commands",
+		Value: "beeper.disable,beeper.enable,load.off,test.battery.start.quick",
+	}, "instant commands refreshed before the refresh interval")
+
+	now = now.Add(instantCommandsRefreshEvery)
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	chart = collr.Charts().Get("cp1500.status")
+	assert.Contains(t, chart.Labels, module.Label{
+		Key:   "instant_commands",
+		Value: "beeper.disable,beeper.enable,load.off,shutdown.return,test.battery.start.quick",
+	})
+}
+
+func TestCollector_Collect_OutletRemoved(t *testing.T) {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+
+	mock := prepareMockConnOK()
+	collr.newUpsdConn = func(Config) upsdConn { return mock }
+
+	require.NotNil(t, collr.Collect(context.Background()))
+	require.NotNil(t, collr.Charts().Get("cp1600.outlet_2_status"))
+
+	mock.noOutlet2 = true
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	for _, chart := range *collr.Charts() {
+		removed := chart.ID == "cp1600.outlet_2_status"
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", chart.ID)
+	}
+}
+
+var testNow = time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+
 func prepareMockConnOK() *mockUpsdConn {
 	return &mockUpsdConn{}
 }
@@ -314,6 +473,10 @@ type mockUpsdConn struct {
 	errOnUpsUnits        bool
 	commandErrOnUpsUnits bool
 
+	upsStatus string   // overrides 'ups.status' of all UPS units if set
+	extraCmds []string // appended to the instant commands of all UPS units if set
+	noOutlet2 bool
+
 	calledConnect      bool
 	calledDisconnect   bool
 	calledAuthenticate bool
@@ -343,6 +506,17 @@ func (m *mockUpsdConn) authenticate(_, _ string) error {
 	return nil
 }
 
+func (m *mockUpsdConn) upsCommands(name string) ([]string, error) {
+	switch name {
+	case "cp1500":
+		return append([]string{"test.battery.start.quick", "beeper.enable", "beeper.disable", "load.off"}, m.extraCmds...), nil
+	case "cp1600":
+		return append([]string{"test.battery.start.deep", "test.battery.start.quick", "test.battery.stop"}, m.extraCmds...), nil
+	default:
+		return nil, fmt.Errorf("%w: mock command error on upsCommands()", errUpsdCommand)
+	}
+}
+
 func (m *mockUpsdConn) upsUnits() ([]upsUnit, error) {
 	if m.errOnUpsUnits {
 		return nil, errors.New("mock error on upsUnits()")
@@ -476,6 +650,15 @@ func (m *mockUpsdConn) upsUnits() ([]upsUnit, error) {
 				"driver.version.data":           "APC HID 0.98",
 				"driver.version.internal":       "0.47",
 				"driver.version.usb":            "libusb-1.0.26 (API: 0x1000109)",
+				"battery.date":                  "2019/01/12",
+				"outlet.1.current":              "0.80",
+				"outlet.1.desc":                 "Outlet group 1",
+				"outlet.1.realpower":            "96",
+				"outlet.1.status":               "on",
+				"outlet.2.desc":                 "Outlet group 2",
+				"outlet.2.status":               "off",
+				"outlet.desc":                   "Main Outlet",
+				"outlet.id":                     "0",
 				"ups.beeper.status":             "enabled",
 				"ups.delay.shutdown":            "20",
 				"ups.firmware":                  "UPS 09.8 / ID=20",
@@ -485,6 +668,8 @@ func (m *mockUpsdConn) upsUnits() ([]upsUnit, error) {
 				"ups.productid":                 "0003",
 				"ups.serial":                    "****************",
 				"ups.status":                    "OL",
+				"ups.test.date":                 "2024/12/10",
+				"ups.test.result":               "Done and passed",
 				"ups.timer.reboot":              "-1",
 				"ups.timer.shutdown":            "-1",
 				"ups.vendorid":                  "051d",
@@ -492,5 +677,15 @@ func (m *mockUpsdConn) upsUnits() ([]upsUnit, error) {
 		},
 	}
 
+	for _, ups := range upsUnits {
+		if m.upsStatus != "" {
+			ups.vars["ups.status"] = m.upsStatus
+		}
+		if m.noOutlet2 {
+			delete(ups.vars, "outlet.2.desc")
+			delete(ups.vars, "outlet.2.status")
+		}
+	}
+
 	return upsUnits, nil
 }
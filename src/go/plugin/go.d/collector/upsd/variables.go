@@ -11,6 +11,7 @@ const (
 	varBatteryVoltage        = "battery.voltage"
 	varBatteryVoltageNominal = "battery.voltage.nominal"
 	varBatteryType           = "battery.type"
+	varBatteryDate           = "battery.date"
 
 	varInputVoltage          = "input.voltage"
 	varInputVoltageNominal   = "input.voltage.nominal"
@@ -31,9 +32,18 @@ const (
 	varUpsRealPowerNominal = "ups.realpower.nominal"
 	varUpsTemperature      = "ups.temperature"
 	varUpsStatus           = "ups.status"
+	varUpsTestResult       = "ups.test.result"
+	varUpsTestDate         = "ups.test.date"
 
 	varDeviceModel  = "device.model"
 	varDeviceSerial = "device.serial"
 	varDeviceMfr    = "device.mfr"
 	varDeviceType   = "device.type"
+
+	// per-outlet variables are 'outlet.<n>.<name>'
+	varOutletStatus    = "status"
+	varOutletDesc      = "desc"
+	varOutletRealPower = "realpower"
+	varOutletPower     = "power"
+	varOutletCurrent   = "current"
 )
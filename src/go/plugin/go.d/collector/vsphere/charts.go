@@ -36,6 +36,31 @@ const (
 	prioHostNetworkErrors
 	prioHostOverallStatus
 	prioHostSystemUptime
+
+	prioDatastoreSpaceUtilization
+	prioDatastoreSpaceUsage
+	prioDatastoreIO
+	prioDatastoreIOPS
+	prioDatastoreLatency
+	prioDatastoreAccessibility
+	prioDatastoreOverallStatus
+
+	prioClusterCPUUtilization
+	prioClusterCPUUsage
+	prioClusterMemoryUtilization
+	prioClusterMemoryUsage
+	prioClusterHosts
+	prioClusterServices
+	prioClusterDRSAutomationLevel
+	prioClusterDRSScore
+	prioClusterOverallStatus
+
+	prioResourcePoolCPUUsage
+	prioResourcePoolMemoryUsage
+	prioResourcePoolOverallStatus
+
+	prioTriggeredAlarms
+	prioEvents
 )
 
 var (
@@ -386,44 +411,364 @@ var (
 	}
 )
 
+var (
+	datastoreChartsTmpl = module.Charts{
+		datastoreSpaceUtilizationChartTmpl.Copy(),
+		datastoreSpaceUsageChartTmpl.Copy(),
+
+		datastoreIOChartTmpl.Copy(),
+		datastoreIOPSChartTmpl.Copy(),
+		datastoreLatencyChartTmpl.Copy(),
+
+		datastoreAccessibilityChartTmpl.Copy(),
+		datastoreOverallStatusChartTmpl.Copy(),
+	}
+	datastoreSpaceUtilizationChartTmpl = module.Chart{
+		ID:       "%s_space_utilization",
+		Title:    "Datastore space utilization",
+		Units:    "percentage",
+		Fam:      "datastores space",
+		Ctx:      "vsphere.datastore_space_utilization",
+		Priority: prioDatastoreSpaceUtilization,
+		Dims: module.Dims{
+			{ID: "%s_space.utilization", Name: "used", Div: 100},
+		},
+	}
+	datastoreSpaceUsageChartTmpl = module.Chart{
+		ID:       "%s_space_usage",
+		Title:    "Datastore space usage",
+		Units:    "bytes",
+		Fam:      "datastores space",
+		Ctx:      "vsphere.datastore_space_usage",
+		Type:     module.Stacked,
+		Priority: prioDatastoreSpaceUsage,
+		Dims: module.Dims{
+			{ID: "%s_space.free", Name: "free"},
+			{ID: "%s_space.used", Name: "used"},
+		},
+	}
+	datastoreIOChartTmpl = module.Chart{
+		ID:       "%s_io",
+		Title:    "Datastore IO",
+		Units:    "KiB/s",
+		Fam:      "datastores io",
+		Ctx:      "vsphere.datastore_io",
+		Type:     module.Area,
+		Priority: prioDatastoreIO,
+		Dims: module.Dims{
+			{ID: "%s_datastore.read.average", Name: "read"},
+			{ID: "%s_datastore.write.average", Name: "write", Mul: -1},
+		},
+	}
+	datastoreIOPSChartTmpl = module.Chart{
+		ID:       "%s_iops",
+		Title:    "Datastore IOPS",
+		Units:    "operations/s",
+		Fam:      "datastores io",
+		Ctx:      "vsphere.datastore_iops",
+		Priority: prioDatastoreIOPS,
+		Dims: module.Dims{
+			{ID: "%s_datastore.numberReadAveraged.average", Name: "read"},
+			{ID: "%s_datastore.numberWriteAveraged.average", Name: "write", Mul: -1},
+		},
+	}
+	datastoreLatencyChartTmpl = module.Chart{
+		ID:       "%s_latency",
+		Title:    "Datastore max latency across hosts",
+		Units:    "milliseconds",
+		Fam:      "datastores io",
+		Ctx:      "vsphere.datastore_latency",
+		Priority: prioDatastoreLatency,
+		Dims: module.Dims{
+			{ID: "%s_datastore.totalReadLatency.average", Name: "read"},
+			{ID: "%s_datastore.totalWriteLatency.average", Name: "write"},
+		},
+	}
+	datastoreAccessibilityChartTmpl = module.Chart{
+		ID:       "%s_accessibility",
+		Title:    "Datastore accessibility",
+		Units:    "status",
+		Fam:      "datastores status",
+		Ctx:      "vsphere.datastore_accessibility",
+		Priority: prioDatastoreAccessibility,
+		Dims: module.Dims{
+			{ID: "%s_accessibility.accessible", Name: "accessible"},
+			{ID: "%s_accessibility.inaccessible", Name: "inaccessible"},
+		},
+	}
+	datastoreOverallStatusChartTmpl = module.Chart{
+		ID:       "%s_overall_status",
+		Title:    "Datastore overall alarm status",
+		Units:    "status",
+		Fam:      "datastores status",
+		Ctx:      "vsphere.datastore_overall_status",
+		Priority: prioDatastoreOverallStatus,
+		Dims: module.Dims{
+			{ID: "%s_overall.status.green", Name: "green"},
+			{ID: "%s_overall.status.red", Name: "red"},
+			{ID: "%s_overall.status.yellow", Name: "yellow"},
+			{ID: "%s_overall.status.gray", Name: "gray"},
+		},
+	}
+)
+
+var (
+	clusterChartsTmpl = module.Charts{
+		clusterCPUUtilizationChartTmpl.Copy(),
+		clusterCPUUsageChartTmpl.Copy(),
+		clusterMemUtilizationChartTmpl.Copy(),
+		clusterMemUsageChartTmpl.Copy(),
+
+		clusterHostsChartTmpl.Copy(),
+
+		clusterServicesChartTmpl.Copy(),
+		clusterDRSAutomationLevelChartTmpl.Copy(),
+		clusterDRSScoreChartTmpl.Copy(),
+
+		clusterOverallStatusChartTmpl.Copy(),
+	}
+	clusterCPUUtilizationChartTmpl = module.Chart{
+		ID:       "%s_cpu_utilization",
+		Title:    "Cluster CPU utilization",
+		Units:    "percentage",
+		Fam:      "clusters cpu",
+		Ctx:      "vsphere.cluster_cpu_utilization",
+		Priority: prioClusterCPUUtilization,
+		Dims: module.Dims{
+			{ID: "%s_cpu.utilization", Name: "used", Div: 100},
+		},
+	}
+	clusterCPUUsageChartTmpl = module.Chart{
+		ID:       "%s_cpu_usage",
+		Title:    "Cluster CPU usage",
+		Units:    "MHz",
+		Fam:      "clusters cpu",
+		Ctx:      "vsphere.cluster_cpu_usage",
+		Priority: prioClusterCPUUsage,
+		Dims: module.Dims{
+			{ID: "%s_cpu.usage", Name: "used"},
+			{ID: "%s_cpu.capacity", Name: "capacity"},
+		},
+	}
+	clusterMemUtilizationChartTmpl = module.Chart{
+		ID:       "%s_mem_utilization",
+		Title:    "Cluster memory utilization",
+		Units:    "percentage",
+		Fam:      "clusters mem",
+		Ctx:      "vsphere.cluster_mem_utilization",
+		Priority: prioClusterMemoryUtilization,
+		Dims: module.Dims{
+			{ID: "%s_mem.utilization", Name: "used", Div: 100},
+		},
+	}
+	clusterMemUsageChartTmpl = module.Chart{
+		ID:       "%s_mem_usage",
+		Title:    "Cluster memory usage",
+		Units:    "bytes",
+		Fam:      "clusters mem",
+		Ctx:      "vsphere.cluster_mem_usage",
+		Priority: prioClusterMemoryUsage,
+		Dims: module.Dims{
+			{ID: "%s_mem.usage", Name: "used"},
+			{ID: "%s_mem.capacity", Name: "capacity"},
+		},
+	}
+	clusterHostsChartTmpl = module.Chart{
+		ID:       "%s_hosts",
+		Title:    "Cluster hosts",
+		Units:    "hosts",
+		Fam:      "clusters hosts",
+		Ctx:      "vsphere.cluster_hosts",
+		Priority: prioClusterHosts,
+		Dims: module.Dims{
+			{ID: "%s_hosts.total", Name: "total"},
+			{ID: "%s_hosts.effective", Name: "effective"},
+		},
+	}
+	clusterServicesChartTmpl = module.Chart{
+		ID:       "%s_services",
+		Title:    "Cluster DRS and HA state",
+		Units:    "status",
+		Fam:      "clusters services",
+		Ctx:      "vsphere.cluster_services",
+		Priority: prioClusterServices,
+		Dims: module.Dims{
+			{ID: "%s_drs.enabled", Name: "drs"},
+			{ID: "%s_ha.enabled", Name: "ha"},
+			{ID: "%s_ha.admission_control.enabled", Name: "ha_admission_control"},
+		},
+	}
+	clusterDRSAutomationLevelChartTmpl = module.Chart{
+		ID:       "%s_drs_automation_level",
+		Title:    "Cluster DRS automation level",
+		Units:    "level",
+		Fam:      "clusters services",
+		Ctx:      "vsphere.cluster_drs_automation_level",
+		Priority: prioClusterDRSAutomationLevel,
+		Dims: module.Dims{
+			{ID: "%s_drs.automation_level.manual", Name: "manual"},
+			{ID: "%s_drs.automation_level.partiallyAutomated", Name: "partially_automated"},
+			{ID: "%s_drs.automation_level.fullyAutomated", Name: "fully_automated"},
+		},
+	}
+	clusterDRSScoreChartTmpl = module.Chart{
+		ID:       "%s_drs_score",
+		Title:    "Cluster DRS score",
+		Units:    "percentage",
+		Fam:      "clusters services",
+		Ctx:      "vsphere.cluster_drs_score",
+		Priority: prioClusterDRSScore,
+		Dims: module.Dims{
+			{ID: "%s_drs.score", Name: "score"},
+		},
+	}
+	clusterOverallStatusChartTmpl = module.Chart{
+		ID:       "%s_overall_status",
+		Title:    "Cluster overall alarm status",
+		Units:    "status",
+		Fam:      "clusters status",
+		Ctx:      "vsphere.cluster_overall_status",
+		Priority: prioClusterOverallStatus,
+		Dims: module.Dims{
+			{ID: "%s_overall.status.green", Name: "green"},
+			{ID: "%s_overall.status.red", Name: "red"},
+			{ID: "%s_overall.status.yellow", Name: "yellow"},
+			{ID: "%s_overall.status.gray", Name: "gray"},
+		},
+	}
+)
+
+var (
+	resourcePoolChartsTmpl = module.Charts{
+		resourcePoolCPUUsageChartTmpl.Copy(),
+		resourcePoolMemUsageChartTmpl.Copy(),
+		resourcePoolOverallStatusChartTmpl.Copy(),
+	}
+	resourcePoolCPUUsageChartTmpl = module.Chart{
+		ID:       "%s_cpu_usage",
+		Title:    "Resource pool CPU usage",
+		Units:    "MHz",
+		Fam:      "resource pools cpu",
+		Ctx:      "vsphere.resource_pool_cpu_usage",
+		Priority: prioResourcePoolCPUUsage,
+		Dims: module.Dims{
+			{ID: "%s_cpu.usage", Name: "used"},
+			{ID: "%s_cpu.reservation_used", Name: "reservation_used"},
+		},
+	}
+	resourcePoolMemUsageChartTmpl = module.Chart{
+		ID:       "%s_mem_usage",
+		Title:    "Resource pool memory usage",
+		Units:    "bytes",
+		Fam:      "resource pools mem",
+		Ctx:      "vsphere.resource_pool_mem_usage",
+		Priority: prioResourcePoolMemoryUsage,
+		Dims: module.Dims{
+			{ID: "%s_mem.usage", Name: "used"},
+			{ID: "%s_mem.reservation_used", Name: "reservation_used"},
+		},
+	}
+	resourcePoolOverallStatusChartTmpl = module.Chart{
+		ID:       "%s_overall_status",
+		Title:    "Resource pool overall alarm status",
+		Units:    "status",
+		Fam:      "resource pools status",
+		Ctx:      "vsphere.resource_pool_overall_status",
+		Priority: prioResourcePoolOverallStatus,
+		Dims: module.Dims{
+			{ID: "%s_overall.status.green", Name: "green"},
+			{ID: "%s_overall.status.red", Name: "red"},
+			{ID: "%s_overall.status.yellow", Name: "yellow"},
+			{ID: "%s_overall.status.gray", Name: "gray"},
+		},
+	}
+)
+
+var (
+	triggeredAlarmsChart = module.Chart{
+		ID:       "triggered_alarms",
+		Title:    "Triggered alarms",
+		Units:    "alarms",
+		Fam:      "alarms",
+		Ctx:      "vsphere.triggered_alarms",
+		Priority: prioTriggeredAlarms,
+		Dims: module.Dims{
+			{ID: "triggered_alarms.red", Name: "red"},
+			{ID: "triggered_alarms.yellow", Name: "yellow"},
+			{ID: "triggered_alarms.acknowledged", Name: "acknowledged"},
+		},
+	}
+	eventsChart = module.Chart{
+		ID:       "events",
+		Title:    "Events",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "vsphere.events",
+		Type:     module.Stacked,
+		Priority: prioEvents,
+		Dims: module.Dims{
+			{ID: "events.info", Name: "info", Algo: module.Incremental},
+			{ID: "events.warning", Name: "warning", Algo: module.Incremental},
+			{ID: "events.error", Name: "error", Algo: module.Incremental},
+			{ID: "events.user", Name: "user", Algo: module.Incremental},
+		},
+	}
+)
+
 const failedUpdatesLimit = 10
 
 func (c *Collector) updateCharts() {
-	for id, fails := range c.discoveredHosts {
-		if fails >= failedUpdatesLimit {
-			c.removeFromCharts(id)
-			delete(c.charted, id)
-			delete(c.discoveredHosts, id)
-			continue
+	c.updateEntitiesCharts(c.discoveredHosts, func(id string) *module.Charts {
+		if host := c.resources.Hosts.Get(id); host != nil {
+			return newHostCharts(host)
 		}
-
-		host := c.resources.Hosts.Get(id)
-		if host == nil || c.charted[id] || fails != 0 {
-			continue
+		return nil
+	})
+	c.updateEntitiesCharts(c.discoveredVMs, func(id string) *module.Charts {
+		if vm := c.resources.VMs.Get(id); vm != nil {
+			return newVMCHarts(vm)
 		}
-
-		c.charted[id] = true
-		charts := newHostCharts(host)
-		if err := c.Charts().Add(*charts...); err != nil {
-			c.Error(err)
+		return nil
+	})
+	c.updateEntitiesCharts(c.discoveredDatastores, func(id string) *module.Charts {
+		if ds := c.resources.Datastores.Get(id); ds != nil {
+			return newDatastoreCharts(ds)
 		}
-	}
+		return nil
+	})
+	c.updateEntitiesCharts(c.discoveredClusters, func(id string) *module.Charts {
+		if cluster := c.resources.Clusters.Get(id); cluster != nil {
+			return newClusterCharts(cluster)
+		}
+		return nil
+	})
+	c.updateEntitiesCharts(c.discoveredPools, func(id string) *module.Charts {
+		if pool := c.resources.ResourcePools.Get(id); pool != nil {
+			return newResourcePoolCharts(pool)
+		}
+		return nil
+	})
+}
 
-	for id, fails := range c.discoveredVMs {
+func (c *Collector) updateEntitiesCharts(discovered map[string]int, newCharts func(id string) *module.Charts) {
+	for id, fails := range discovered {
 		if fails >= failedUpdatesLimit {
 			c.removeFromCharts(id)
 			delete(c.charted, id)
-			delete(c.discoveredVMs, id)
+			delete(discovered, id)
 			continue
 		}
 
-		vm := c.resources.VMs.Get(id)
-		if vm == nil || c.charted[id] || fails != 0 {
+		if c.charted[id] || fails != 0 {
+			continue
+		}
+
+		charts := newCharts(id)
+		if charts == nil {
 			continue
 		}
 
 		c.charted[id] = true
-		charts := newVMCHarts(vm)
 		if err := c.Charts().Add(*charts...); err != nil {
 			c.Error(err)
 		}
@@ -482,7 +827,85 @@ func getHostClusterName(host *rs.Host) string {
 	return host.Hier.Cluster.Name
 }
 
-func (c *Collector) removeFromCharts(prefix string) {
+func newDatastoreCharts(ds *rs.Datastore) *module.Charts {
+	charts := datastoreChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, ds.ID)
+		chart.Labels = []module.Label{
+			{Key: "datacenter", Value: ds.Hier.DC.Name},
+			{Key: "datastore", Value: ds.Name},
+			{Key: "type", Value: ds.Type},
+		}
+
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, ds.ID)
+		}
+	}
+
+	return charts
+}
+
+func newClusterCharts(cluster *rs.Cluster) *module.Charts {
+	charts := clusterChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, cluster.ID)
+		chart.Labels = []module.Label{
+			{Key: "datacenter", Value: cluster.Hier.DC.Name},
+			{Key: "cluster", Value: cluster.Name},
+		}
+
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, cluster.ID)
+		}
+	}
+
+	return charts
+}
+
+func newResourcePoolCharts(pool *rs.ResourcePool) *module.Charts {
+	charts := resourcePoolChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, pool.ID)
+		chart.Labels = []module.Label{
+			{Key: "datacenter", Value: pool.Hier.DC.Name},
+			{Key: "cluster", Value: pool.Hier.Cluster.Name},
+			{Key: "resource_pool", Value: pool.Name},
+		}
+
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, pool.ID)
+		}
+	}
+
+	return charts
+}
+
+func (c *Collector) addAlarmsChartsOnce() {
+	if c.alarmsCharted {
+		return
+	}
+	c.alarmsCharted = true
+	if err := c.Charts().Add(triggeredAlarmsChart.Copy()); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) addEventsChartsOnce() {
+	if c.eventsCharted {
+		return
+	}
+	c.eventsCharted = true
+	if err := c.Charts().Add(eventsChart.Copy()); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) removeFromCharts(id string) {
+	// chart IDs are "<id>_<name>", the separator avoids matching "host-21" by "host-2"
+	prefix := id + "_"
 	for _, c := range *c.Charts() {
 		if strings.HasPrefix(c.ID, prefix) {
 			c.MarkRemove()
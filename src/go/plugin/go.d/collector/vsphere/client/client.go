@@ -11,10 +11,12 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/tlscfg"
 
 	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/event"
 	"github.com/vmware/govmomi/performance"
 	"github.com/vmware/govmomi/session"
 	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
@@ -26,6 +28,9 @@ const (
 	computeResource = "ComputeResource"
 	hostSystem      = "HostSystem"
 	virtualMachine  = "VirtualMachine"
+	datastore       = "Datastore"
+	resourcePool    = "ResourcePool"
+	clusterResource = "ClusterComputeResource"
 
 	maxIdleConnections = 32
 )
@@ -42,6 +47,7 @@ type Client struct {
 	client *govmomi.Client
 	root   *view.ContainerView
 	perf   *performance.Manager
+	events *event.Manager
 }
 
 func newSoapClient(config Config) (*soap.Client, error) {
@@ -121,6 +127,7 @@ func New(config Config) (*Client, error) {
 		client: vmomiClient,
 		perf:   perfManager,
 		root:   containerView,
+		events: event.NewManager(vimClient),
 	}
 
 	return client, nil
@@ -178,3 +185,45 @@ func (c *Client) VirtualMachines(pathSet ...string) (vms []mo.VirtualMachine, er
 func (c *Client) CounterInfoByName() (map[string]*types.PerfCounterInfo, error) {
 	return c.perf.CounterInfoByName(context.Background())
 }
+
+func (c *Client) Datastores(pathSet ...string) (dss []mo.Datastore, err error) {
+	err = c.root.Retrieve(context.Background(), []string{datastore}, pathSet, &dss)
+	return
+}
+
+func (c *Client) ResourcePools(pathSet ...string) (pools []mo.ResourcePool, err error) {
+	err = c.root.Retrieve(context.Background(), []string{resourcePool}, pathSet, &pools)
+	return
+}
+
+func (c *Client) ClusterComputeResources(pathSet ...string) (clusters []mo.ClusterComputeResource, err error) {
+	err = c.root.Retrieve(context.Background(), []string{clusterResource}, pathSet, &clusters)
+	return
+}
+
+// TriggeredAlarms returns the alarms triggered by the root folder and all its descendants.
+func (c *Client) TriggeredAlarms() ([]types.AlarmState, error) {
+	var root mo.Folder
+	err := c.client.RetrieveOne(context.Background(), c.client.ServiceContent.RootFolder, []string{"triggeredAlarmState"}, &root)
+	if err != nil {
+		return nil, err
+	}
+	return root.TriggeredAlarmState, nil
+}
+
+func (c *Client) CurrentTime() (time.Time, error) {
+	t, err := methods.GetCurrentTime(context.Background(), c.client)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return *t, nil
+}
+
+func (c *Client) Events(filter types.EventFilterSpec) ([]types.BaseEvent, error) {
+	return c.events.QueryEvents(context.Background(), filter)
+}
+
+// EventCategory returns the event category: "info", "warning", "error" or "user".
+func (c *Client) EventCategory(e types.BaseEvent) (string, error) {
+	return c.events.EventCategory(context.Background(), e)
+}
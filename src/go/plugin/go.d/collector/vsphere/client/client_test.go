@@ -122,6 +122,58 @@ func TestClient_VirtualMachines(t *testing.T) {
 	assert.NotEmpty(t, vms)
 }
 
+func TestClient_Datastores(t *testing.T) {
+	client, teardown := prepareClient(t)
+	defer teardown()
+
+	dss, err := client.Datastores()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, dss)
+}
+
+func TestClient_ResourcePools(t *testing.T) {
+	client, teardown := prepareClient(t)
+	defer teardown()
+
+	pools, err := client.ResourcePools()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pools)
+}
+
+func TestClient_ClusterComputeResources(t *testing.T) {
+	client, teardown := prepareClient(t)
+	defer teardown()
+
+	clusters, err := client.ClusterComputeResources()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, clusters)
+}
+
+func TestClient_TriggeredAlarms(t *testing.T) {
+	client, teardown := prepareClient(t)
+	defer teardown()
+
+	_, err := client.TriggeredAlarms()
+	assert.NoError(t, err)
+}
+
+func TestClient_Events(t *testing.T) {
+	client, teardown := prepareClient(t)
+	defer teardown()
+
+	now, err := client.CurrentTime()
+	require.NoError(t, err)
+	assert.False(t, now.IsZero())
+
+	events, err := client.Events(types.EventFilterSpec{})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+
+	category, err := client.EventCategory(events[0])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, category)
+}
+
 func TestClient_PerformanceMetrics(t *testing.T) {
 	client, teardown := prepareClient(t)
 	defer teardown()
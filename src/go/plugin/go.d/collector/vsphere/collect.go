@@ -5,17 +5,30 @@ package vsphere
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	rs "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/resources"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
 
 	"github.com/vmware/govmomi/performance"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 // ManagedEntityStatus
 var overallStatuses = []string{"green", "red", "yellow", "gray"}
 
+// ManagedEntityStatus of a triggered alarm
+var triggeredAlarmStatuses = []string{"red", "yellow"}
+
+// DrsBehavior
+var drsAutomationLevels = []string{"manual", "partiallyAutomated", "fullyAutomated"}
+
+// EventCategory
+var eventCategories = []string{"info", "warning", "error", "user"}
+
 func (c *Collector) collect() (map[string]int64, error) {
 	c.collectionLock.Lock()
 	defer c.collectionLock.Unlock()
@@ -34,6 +47,13 @@ func (c *Collector) collect() (map[string]int64, error) {
 		return nil, err
 	}
 
+	inv := c.ScrapeInventory()
+	c.collectDatastores(mx, inv.Datastores)
+	c.collectClusters(mx, inv.Clusters, inv.ResourcePools)
+	c.collectResourcePools(mx, inv.ResourcePools)
+	c.collectAlarms(mx, inv.Alarms)
+	c.collectEvents(mx)
+
 	c.updateCharts()
 
 	c.Debugf("metrics collected, process took %s", time.Since(t))
@@ -124,3 +144,192 @@ func writeVMMetrics(mx map[string]int64, vm *rs.VM, metrics []performance.Metric
 		mx[key] = metrix.Bool(vm.OverallStatus == v)
 	}
 }
+
+func (c *Collector) collectDatastores(mx map[string]int64, dss []mo.Datastore) {
+	for id := range c.discoveredDatastores {
+		c.discoveredDatastores[id]++
+	}
+
+	for _, raw := range dss {
+		if ds := c.resources.Datastores.Get(raw.Reference().Value); ds != nil {
+			writeDatastoreMetrics(mx, ds, raw)
+			c.discoveredDatastores[ds.ID] = 0
+		}
+	}
+
+	if len(c.resources.Datastores) == 0 || len(c.resources.Hosts) == 0 {
+		return
+	}
+
+	byUUID := make(map[string]*rs.Datastore, len(c.resources.Datastores))
+	for _, ds := range c.resources.Datastores {
+		if ds.UUID != "" {
+			byUUID[ds.UUID] = ds
+		}
+	}
+
+	// NOTE: a datastore is usually mounted on several hosts
+	for _, metric := range c.ScrapeHostsDatastores(c.resources.Hosts) {
+		for _, series := range metric.Value {
+			ds := byUUID[series.Instance]
+			if ds == nil || len(series.Value) == 0 || series.Value[0] == -1 {
+				continue
+			}
+			key := fmt.Sprintf("%s_%s", ds.ID, series.Name)
+			if strings.Contains(series.Name, "Latency") {
+				mx[key] = max(mx[key], series.Value[0])
+			} else {
+				mx[key] += series.Value[0]
+			}
+		}
+	}
+}
+
+func writeDatastoreMetrics(mx map[string]int64, ds *rs.Datastore, raw mo.Datastore) {
+	px := ds.ID + "_"
+	summary := raw.Summary
+	used := summary.Capacity - summary.FreeSpace
+
+	mx[px+"space.used"] = used
+	mx[px+"space.free"] = summary.FreeSpace
+	mx[px+"space.utilization"] = 0
+	if summary.Capacity > 0 {
+		mx[px+"space.utilization"] = used * 10000 / summary.Capacity
+	}
+	mx[px+"accessibility.accessible"] = metrix.Bool(summary.Accessible)
+	mx[px+"accessibility.inaccessible"] = metrix.Bool(!summary.Accessible)
+
+	for _, v := range overallStatuses {
+		mx[px+"overall.status."+v] = metrix.Bool(string(raw.OverallStatus) == v)
+	}
+}
+
+func (c *Collector) collectClusters(mx map[string]int64, clusters []mo.ClusterComputeResource, pools []mo.ResourcePool) {
+	for id := range c.discoveredClusters {
+		c.discoveredClusters[id]++
+	}
+
+	rootPools := make(map[string]*types.ResourcePoolRuntimeInfo)
+	for i := range pools {
+		rootPools[pools[i].Reference().Value] = &pools[i].Runtime
+	}
+
+	for _, raw := range clusters {
+		cluster := c.resources.Clusters.Get(raw.Reference().Value)
+		if cluster == nil {
+			continue
+		}
+		var rt *types.ResourcePoolRuntimeInfo
+		if raw.ResourcePool != nil {
+			rt = rootPools[raw.ResourcePool.Value]
+		}
+		writeClusterMetrics(mx, cluster, raw, rt)
+		c.discoveredClusters[cluster.ID] = 0
+	}
+}
+
+func writeClusterMetrics(mx map[string]int64, cluster *rs.Cluster, raw mo.ClusterComputeResource, rt *types.ResourcePoolRuntimeInfo) {
+	px := cluster.ID + "_"
+
+	if cfg, ok := raw.ConfigurationEx.(*types.ClusterConfigInfoEx); ok {
+		drs, das := cfg.DrsConfig, cfg.DasConfig
+		mx[px+"drs.enabled"] = metrix.Bool(drs.Enabled != nil && *drs.Enabled)
+		mx[px+"ha.enabled"] = metrix.Bool(das.Enabled != nil && *das.Enabled)
+		mx[px+"ha.admission_control.enabled"] = metrix.Bool(das.AdmissionControlEnabled != nil && *das.AdmissionControlEnabled)
+		for _, v := range drsAutomationLevels {
+			mx[px+"drs.automation_level."+v] = metrix.Bool(string(drs.DefaultVmBehavior) == v)
+		}
+	}
+
+	if summary, ok := raw.Summary.(*types.ClusterComputeResourceSummary); ok {
+		mx[px+"hosts.total"] = int64(summary.NumHosts)
+		mx[px+"hosts.effective"] = int64(summary.NumEffectiveHosts)
+		mx[px+"drs.score"] = int64(summary.DrsScore)
+
+		cpuCapacity := int64(summary.EffectiveCpu)           // MHz
+		memCapacity := summary.EffectiveMemory * 1024 * 1024 // MB
+		mx[px+"cpu.capacity"] = cpuCapacity
+		mx[px+"mem.capacity"] = memCapacity
+
+		if rt != nil {
+			mx[px+"cpu.usage"] = rt.Cpu.OverallUsage
+			mx[px+"mem.usage"] = rt.Memory.OverallUsage
+			mx[px+"cpu.utilization"] = 0
+			mx[px+"mem.utilization"] = 0
+			if cpuCapacity > 0 {
+				mx[px+"cpu.utilization"] = rt.Cpu.OverallUsage * 10000 / cpuCapacity
+			}
+			if memCapacity > 0 {
+				mx[px+"mem.utilization"] = rt.Memory.OverallUsage * 10000 / memCapacity
+			}
+		}
+	}
+
+	for _, v := range overallStatuses {
+		mx[px+"overall.status."+v] = metrix.Bool(string(raw.OverallStatus) == v)
+	}
+}
+
+func (c *Collector) collectResourcePools(mx map[string]int64, pools []mo.ResourcePool) {
+	for id := range c.discoveredPools {
+		c.discoveredPools[id]++
+	}
+
+	for _, raw := range pools {
+		if pool := c.resources.ResourcePools.Get(raw.Reference().Value); pool != nil {
+			writeResourcePoolMetrics(mx, pool, raw)
+			c.discoveredPools[pool.ID] = 0
+		}
+	}
+}
+
+func writeResourcePoolMetrics(mx map[string]int64, pool *rs.ResourcePool, raw mo.ResourcePool) {
+	px := pool.ID + "_"
+	rt := raw.Runtime
+
+	mx[px+"cpu.usage"] = rt.Cpu.OverallUsage
+	mx[px+"cpu.reservation_used"] = rt.Cpu.ReservationUsed
+	mx[px+"mem.usage"] = rt.Memory.OverallUsage
+	mx[px+"mem.reservation_used"] = rt.Memory.ReservationUsed
+
+	for _, v := range overallStatuses {
+		mx[px+"overall.status."+v] = metrix.Bool(string(rt.OverallStatus) == v)
+	}
+}
+
+func (c *Collector) collectAlarms(mx map[string]int64, alarms []types.AlarmState) {
+	if alarms == nil {
+		return
+	}
+
+	for _, v := range triggeredAlarmStatuses {
+		mx["triggered_alarms."+v] = 0
+	}
+	mx["triggered_alarms.acknowledged"] = 0
+
+	for _, alarm := range alarms {
+		if status := string(alarm.OverallStatus); slices.Contains(triggeredAlarmStatuses, status) {
+			mx["triggered_alarms."+status]++
+		}
+		if alarm.Acknowledged != nil && *alarm.Acknowledged {
+			mx["triggered_alarms.acknowledged"]++
+		}
+	}
+
+	c.addAlarmsChartsOnce()
+}
+
+func (c *Collector) collectEvents(mx map[string]int64) {
+	counts, err := c.ScrapeEvents()
+	if err != nil {
+		c.Errorf("scraping events: %v", err)
+		return
+	}
+
+	for _, v := range eventCategories {
+		c.events[v] += counts[v]
+		mx["events."+v] = c.events[v]
+	}
+
+	c.addEventsChartsOnce()
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/match"
 	rs "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/resources"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/scrape"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
 
@@ -44,11 +45,15 @@ func New() *Collector {
 			HostsInclude:      []string{"/*"},
 			VMsInclude:        []string{"/*"},
 		},
-		collectionLock:  &sync.RWMutex{},
-		charts:          &module.Charts{},
-		discoveredHosts: make(map[string]int),
-		discoveredVMs:   make(map[string]int),
-		charted:         make(map[string]bool),
+		collectionLock:       &sync.RWMutex{},
+		charts:               &module.Charts{},
+		discoveredHosts:      make(map[string]int),
+		discoveredVMs:        make(map[string]int),
+		discoveredDatastores: make(map[string]int),
+		discoveredClusters:   make(map[string]int),
+		discoveredPools:      make(map[string]int),
+		charted:              make(map[string]bool),
+		events:               make(map[string]int64),
 	}
 }
 
@@ -71,12 +76,18 @@ type (
 		discoverer
 		scraper
 
-		collectionLock  *sync.RWMutex
-		resources       *rs.Resources
-		discoveryTask   *task
-		discoveredHosts map[string]int
-		discoveredVMs   map[string]int
-		charted         map[string]bool
+		collectionLock       *sync.RWMutex
+		resources            *rs.Resources
+		discoveryTask        *task
+		discoveredHosts      map[string]int
+		discoveredVMs        map[string]int
+		discoveredDatastores map[string]int
+		discoveredClusters   map[string]int
+		discoveredPools      map[string]int
+		charted              map[string]bool
+		alarmsCharted        bool
+		eventsCharted        bool
+		events               map[string]int64
 	}
 	discoverer interface {
 		Discover() (*rs.Resources, error)
@@ -84,6 +95,9 @@ type (
 	scraper interface {
 		ScrapeHosts(rs.Hosts) []performance.EntityMetric
 		ScrapeVMs(rs.VMs) []performance.EntityMetric
+		ScrapeHostsDatastores(rs.Hosts) []performance.EntityMetric
+		ScrapeInventory() *scrape.Inventory
+		ScrapeEvents() (map[string]int64, error)
 	}
 )
 
@@ -13,12 +13,17 @@ import (
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/discover"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/match"
 	rs "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/resources"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/scrape"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/performance"
 	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 var (
@@ -132,8 +137,13 @@ func TestCollector_Collect(t *testing.T) {
 	collr, model, teardown := prepareVSphereSim(t)
 	defer teardown()
 
+	createResourcePool(t, collr.URL, "pool0")
 	require.NoError(t, collr.Init(context.Background()))
 
+	for _, ds := range collr.resources.Datastores {
+		ds.UUID = mockDatastoreUUID
+	}
+
 	collr.scraper = mockScraper{collr.scraper}
 
 	expected := map[string]int64{
@@ -329,6 +339,60 @@ func TestCollector_Collect(t *testing.T) {
 		"vm-71_overall.status.red":            0,
 		"vm-71_overall.status.yellow":         0,
 		"vm-71_sys.uptime.latest":             200,
+
+		"datastore-59_accessibility.accessible":              1,
+		"datastore-59_accessibility.inaccessible":            0,
+		"datastore-59_datastore.numberReadAveraged.average":  1200,
+		"datastore-59_datastore.numberWriteAveraged.average": 1200,
+		"datastore-59_datastore.read.average":                1200,
+		"datastore-59_datastore.totalReadLatency.average":    300,
+		"datastore-59_datastore.totalWriteLatency.average":   300,
+		"datastore-59_datastore.write.average":               1200,
+		"datastore-59_overall.status.gray":                   0,
+		"datastore-59_overall.status.green":                  1,
+		"datastore-59_overall.status.red":                    0,
+		"datastore-59_overall.status.yellow":                 0,
+		"datastore-59_space.free":                            250,
+		"datastore-59_space.used":                            750,
+		"datastore-59_space.utilization":                     7500,
+
+		"domain-c28_cpu.capacity":                            4000,
+		"domain-c28_cpu.usage":                               1000,
+		"domain-c28_cpu.utilization":                         2500,
+		"domain-c28_drs.automation_level.fullyAutomated":     0,
+		"domain-c28_drs.automation_level.manual":             0,
+		"domain-c28_drs.automation_level.partiallyAutomated": 0,
+		"domain-c28_drs.enabled":                             1,
+		"domain-c28_drs.score":                               90,
+		"domain-c28_ha.admission_control.enabled":            0,
+		"domain-c28_ha.enabled":                              0,
+		"domain-c28_hosts.effective":                         3,
+		"domain-c28_hosts.total":                             3,
+		"domain-c28_mem.capacity":                            2147483648,
+		"domain-c28_mem.usage":                               1073741824,
+		"domain-c28_mem.utilization":                         5000,
+		"domain-c28_overall.status.gray":                     0,
+		"domain-c28_overall.status.green":                    1,
+		"domain-c28_overall.status.red":                      0,
+		"domain-c28_overall.status.yellow":                   0,
+
+		"resgroup-73_cpu.reservation_used":  500,
+		"resgroup-73_cpu.usage":             1000,
+		"resgroup-73_mem.reservation_used":  536870912,
+		"resgroup-73_mem.usage":             1073741824,
+		"resgroup-73_overall.status.gray":   0,
+		"resgroup-73_overall.status.green":  1,
+		"resgroup-73_overall.status.red":    0,
+		"resgroup-73_overall.status.yellow": 0,
+
+		"triggered_alarms.acknowledged": 1,
+		"triggered_alarms.red":          1,
+		"triggered_alarms.yellow":       2,
+
+		"events.error":   1,
+		"events.info":    3,
+		"events.user":    0,
+		"events.warning": 2,
 	}
 
 	mx := collr.Collect(context.Background())
@@ -336,11 +400,21 @@ func TestCollector_Collect(t *testing.T) {
 	require.Equal(t, expected, mx)
 
 	count := model.Count()
+	numPools := 1
 	assert.Len(t, collr.discoveredHosts, count.Host)
 	assert.Len(t, collr.discoveredVMs, count.Machine)
-	assert.Len(t, collr.charted, count.Host+count.Machine)
-
-	assert.Len(t, *collr.Charts(), count.Host*len(hostChartsTmpl)+count.Machine*len(vmChartsTmpl))
+	assert.Len(t, collr.discoveredDatastores, count.Datastore)
+	assert.Len(t, collr.discoveredClusters, count.Cluster)
+	assert.Len(t, collr.discoveredPools, numPools)
+	assert.Len(t, collr.charted, count.Host+count.Machine+count.Datastore+count.Cluster+numPools)
+
+	assert.Len(t, *collr.Charts(), count.Host*len(hostChartsTmpl)+
+		count.Machine*len(vmChartsTmpl)+
+		count.Datastore*len(datastoreChartsTmpl)+
+		count.Cluster*len(clusterChartsTmpl)+
+		numPools*len(resourcePoolChartsTmpl)+
+		2, // triggered alarms and events
+	)
 	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
 }
 
@@ -388,10 +462,14 @@ func TestCollector_Collect_RemoveHostsVMsInRuntime(t *testing.T) {
 
 	assert.Len(t, collr.discoveredHosts, 1)
 	assert.Len(t, collr.discoveredVMs, 1)
-	assert.Len(t, collr.charted, 2)
+	assert.True(t, collr.charted[okHostId])
+	assert.True(t, collr.charted[okVmId])
 
 	for _, c := range *collr.Charts() {
-		if strings.HasPrefix(c.ID, okHostId) || strings.HasPrefix(c.ID, okVmId) {
+		if !strings.HasPrefix(c.ID, "host-") && !strings.HasPrefix(c.ID, "vm-") {
+			continue
+		}
+		if strings.HasPrefix(c.ID, okHostId+"_") || strings.HasPrefix(c.ID, okVmId+"_") {
 			assert.False(t, c.Obsolete)
 		} else {
 			assert.True(t, c.Obsolete)
@@ -418,8 +496,15 @@ func TestCollector_Collect_Run(t *testing.T) {
 	count := model.Count()
 	assert.Len(t, collr.discoveredHosts, count.Host)
 	assert.Len(t, collr.discoveredVMs, count.Machine)
-	assert.Len(t, collr.charted, count.Host+count.Machine)
-	assert.Len(t, *collr.charts, count.Host*len(hostChartsTmpl)+count.Machine*len(vmChartsTmpl))
+	assert.Len(t, collr.discoveredDatastores, count.Datastore)
+	assert.Len(t, collr.discoveredClusters, count.Cluster)
+	assert.Len(t, collr.charted, count.Host+count.Machine+count.Datastore+count.Cluster)
+	assert.Len(t, *collr.charts, count.Host*len(hostChartsTmpl)+
+		count.Machine*len(vmChartsTmpl)+
+		count.Datastore*len(datastoreChartsTmpl)+
+		count.Cluster*len(clusterChartsTmpl)+
+		2, // triggered alarms and events
+	)
 }
 
 func prepareVSphereSim(t *testing.T) (collr *Collector, model *simulator.Model, teardown func()) {
@@ -456,6 +541,51 @@ func (s mockScraper) ScrapeVMs(vms rs.VMs) []performance.EntityMetric {
 	return populateMetrics(ms, 200)
 }
 
+const mockDatastoreUUID = "5f1b2c3d-4e5f6a7b-8c9d-000c29a1b2c3"
+
+func (s mockScraper) ScrapeHostsDatastores(hosts rs.Hosts) []performance.EntityMetric {
+	ms := s.scraper.ScrapeHostsDatastores(hosts)
+	for i := range ms {
+		for ii := range ms[i].Value {
+			ms[i].Value[ii].Instance = mockDatastoreUUID
+		}
+	}
+	return populateMetrics(ms, 300)
+}
+
+func (s mockScraper) ScrapeInventory() *scrape.Inventory {
+	inv := s.scraper.ScrapeInventory()
+	for i := range inv.Datastores {
+		inv.Datastores[i].Summary.Capacity = 1000
+		inv.Datastores[i].Summary.FreeSpace = 250
+	}
+	for i := range inv.Clusters {
+		if summary, ok := inv.Clusters[i].Summary.(*types.ClusterComputeResourceSummary); ok {
+			summary.EffectiveCpu = 4000
+			summary.EffectiveMemory = 2048
+			summary.DrsScore = 90
+		}
+	}
+	for i := range inv.ResourcePools {
+		rt := &inv.ResourcePools[i].Runtime
+		rt.Cpu.OverallUsage = 1000
+		rt.Cpu.ReservationUsed = 500
+		rt.Memory.OverallUsage = 1024 * 1024 * 1024
+		rt.Memory.ReservationUsed = 512 * 1024 * 1024
+		rt.OverallStatus = types.ManagedEntityStatusGreen
+	}
+	inv.Alarms = append(inv.Alarms,
+		types.AlarmState{OverallStatus: types.ManagedEntityStatusRed, Acknowledged: types.NewBool(true)},
+		types.AlarmState{OverallStatus: types.ManagedEntityStatusYellow},
+		types.AlarmState{OverallStatus: types.ManagedEntityStatusYellow},
+	)
+	return inv
+}
+
+func (s mockScraper) ScrapeEvents() (map[string]int64, error) {
+	return map[string]int64{"info": 3, "warning": 2, "error": 1}, nil
+}
+
 func populateMetrics(ms []performance.EntityMetric, value int64) []performance.EntityMetric {
 	for i := range ms {
 		for ii := range ms[i].Value {
@@ -470,6 +600,20 @@ func populateMetrics(ms []performance.EntityMetric, value int64) []performance.E
 	return ms
 }
 
+func createResourcePool(t *testing.T, vCenterURL, name string) {
+	ctx := context.Background()
+	u, err := soap.ParseURL(vCenterURL)
+	require.NoError(t, err)
+	c, err := govmomi.NewClient(ctx, u, true)
+	require.NoError(t, err)
+	defer func() { _ = c.Logout(ctx) }()
+
+	root, err := find.NewFinder(c.Client).ResourcePool(ctx, "/DC0/host/DC0_C0/Resources")
+	require.NoError(t, err)
+	_, err = root.Create(ctx, name, types.DefaultResourceConfigSpec())
+	require.NoError(t, err)
+}
+
 type mockHostMatcher struct{ name string }
 type mockVMMatcher struct{ name string }
 
@@ -3,6 +3,8 @@
 package discover
 
 import (
+	"path"
+	"strings"
 	"time"
 
 	rs "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/resources"
@@ -21,8 +23,11 @@ func (d Discoverer) build(raw *resources) *rs.Resources {
 	fixClustersParentID(&res)
 	res.Hosts = d.buildHosts(raw.hosts)
 	res.VMs = d.buildVMs(raw.vms)
+	res.Datastores = d.buildDatastores(raw.dss)
+	fixDatastoresParentID(&res)
+	res.ResourcePools = d.buildResourcePools(raw.pools)
 
-	d.Infof("discovering : building : built %d/%d dcs, %d/%d folders, %d/%d clusters, %d/%d hosts, %d/%d vms, process took %s",
+	d.Infof("discovering : building : built %d/%d dcs, %d/%d folders, %d/%d clusters, %d/%d hosts, %d/%d vms, %d/%d datastores, %d/%d resource pools, process took %s",
 		len(res.DataCenters),
 		len(raw.dcs),
 		len(res.Folders),
@@ -33,6 +38,10 @@ func (d Discoverer) build(raw *resources) *rs.Resources {
 		len(raw.hosts),
 		len(res.VMs),
 		len(raw.vms),
+		len(res.Datastores),
+		len(raw.dss),
+		len(res.ResourcePools),
+		len(raw.pools),
 		time.Since(t),
 	)
 	return &res
@@ -46,6 +55,14 @@ func fixClustersParentID(res *rs.Resources) {
 	}
 }
 
+// datastore parent is folder (or datastore cluster) by default
+// should be called after buildDatacenters, buildFolders and buildDatastores
+func fixDatastoresParentID(res *rs.Resources) {
+	for _, ds := range res.Datastores {
+		ds.ParentID = findClusterDcID(ds.ParentID, res.Folders)
+	}
+}
+
 func findClusterDcID(parentID string, folders rs.Folders) string {
 	f := folders.Get(parentID)
 	if f == nil {
@@ -178,3 +195,61 @@ func newVM(raw mo.VirtualMachine) *rs.VM {
 		Ref:           raw.Reference(),
 	}
 }
+
+func (Discoverer) buildDatastores(raw []mo.Datastore) rs.Datastores {
+	dss := make(rs.Datastores)
+	for _, d := range raw {
+		dss.Put(newDatastore(d))
+	}
+	return dss
+}
+
+func newDatastore(raw mo.Datastore) *rs.Datastore {
+	// datastore1 datastore-61 group-s5 ds:///vmfs/volumes/5f1b2c3d-.../ VMFS
+	return &rs.Datastore{
+		Name:     raw.Name,
+		ID:       raw.Reference().Value,
+		ParentID: raw.Parent.Value,
+		Type:     raw.Summary.Type,
+		UUID:     datastoreUUID(raw.Summary.Url),
+		Ref:      raw.Reference(),
+	}
+}
+
+// datastoreUUID extracts the datastore UUID from its URL ("ds:///vmfs/volumes/<uuid>/").
+func datastoreUUID(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	if url == "" {
+		return ""
+	}
+	return path.Base(url)
+}
+
+func (d Discoverer) buildResourcePools(raw []mo.ResourcePool) rs.ResourcePools {
+	var num int
+	pools := make(rs.ResourcePools)
+	for _, p := range raw {
+		// every compute resource has a hidden root resource pool ("Resources"),
+		// its usage is the compute resource usage.
+		if p.Parent == nil || (p.Parent.Type != "ResourcePool" && p.Parent.Type != "VirtualApp") {
+			num++
+			continue
+		}
+		pools.Put(newResourcePool(p))
+	}
+	if num > 0 {
+		d.Debugf("discovering : building : skipped %d root resource pools", num)
+	}
+	return pools
+}
+
+func newResourcePool(raw mo.ResourcePool) *rs.ResourcePool {
+	// Pool1 resgroup-74 resgroup-73 domain-c52
+	return &rs.ResourcePool{
+		Name:     raw.Name,
+		ID:       raw.Reference().Value,
+		ParentID: raw.Parent.Value,
+		OwnerID:  raw.Owner.Value,
+		Ref:      raw.Reference(),
+	}
+}
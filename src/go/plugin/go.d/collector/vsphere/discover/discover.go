@@ -21,6 +21,8 @@ type Client interface {
 	ComputeResources(pathSet ...string) ([]mo.ComputeResource, error)
 	Hosts(pathSet ...string) ([]mo.HostSystem, error)
 	VirtualMachines(pathSet ...string) ([]mo.VirtualMachine, error)
+	Datastores(pathSet ...string) ([]mo.Datastore, error)
+	ResourcePools(pathSet ...string) ([]mo.ResourcePool, error)
 
 	CounterInfoByName() (map[string]*types.PerfCounterInfo, error)
 }
@@ -44,6 +46,8 @@ type resources struct {
 	clusters []mo.ComputeResource
 	hosts    []mo.HostSystem
 	vms      []mo.VirtualMachine
+	dss      []mo.Datastore
+	pools    []mo.ResourcePool
 }
 
 func (d Discoverer) Discover() (*rs.Resources, error) {
@@ -73,11 +77,15 @@ func (d Discoverer) Discover() (*rs.Resources, error) {
 		return nil, fmt.Errorf("collecting metric lists : %v", err)
 	}
 
-	d.Infof("discovering : discovered %d/%d hosts, %d/%d vms, the whole process took %s",
+	d.Infof("discovering : discovered %d/%d hosts, %d/%d vms, %d/%d datastores, %d/%d resource pools, the whole process took %s",
 		len(res.Hosts),
 		len(raw.hosts),
 		len(res.VMs),
 		len(raw.vms),
+		len(res.Datastores),
+		len(raw.dss),
+		len(res.ResourcePools),
+		len(raw.pools),
 		time.Since(startTime))
 
 	return res, nil
@@ -90,6 +98,8 @@ var (
 	clusterPathSet    = []string{"name", "parent"}
 	hostPathSet       = []string{"name", "parent", "runtime.powerState", "summary.overallStatus"}
 	vmPathSet         = []string{"name", "runtime.host", "runtime.powerState", "summary.overallStatus"}
+	datastorePathSet  = []string{"name", "parent", "summary.url", "summary.type"}
+	poolPathSet       = []string{"name", "parent", "owner"}
 )
 
 func (d Discoverer) discover() (*resources, error) {
@@ -131,21 +141,39 @@ func (d Discoverer) discover() (*resources, error) {
 	}
 	d.Debugf("discovering : found %d vms, process took %s", len(hosts), time.Since(t))
 
+	t = time.Now()
+	dss, err := d.Datastores(datastorePathSet...)
+	if err != nil {
+		return nil, err
+	}
+	d.Debugf("discovering : found %d datastores, process took %s", len(dss), time.Since(t))
+
+	t = time.Now()
+	pools, err := d.ResourcePools(poolPathSet...)
+	if err != nil {
+		return nil, err
+	}
+	d.Debugf("discovering : found %d resource pools, process took %s", len(pools), time.Since(t))
+
 	raw := resources{
 		dcs:      datacenters,
 		folders:  folders,
 		clusters: clusters,
 		hosts:    hosts,
 		vms:      vms,
+		dss:      dss,
+		pools:    pools,
 	}
 
-	d.Infof("discovering : found %d dcs, %d folders, %d clusters (%d dummy), %d hosts, %d vms, process took %s",
+	d.Infof("discovering : found %d dcs, %d folders, %d clusters (%d dummy), %d hosts, %d vms, %d datastores, %d resource pools, process took %s",
 		len(raw.dcs),
 		len(raw.folders),
 		len(clusters),
 		numOfDummyClusters(clusters),
 		len(raw.hosts),
 		len(raw.vms),
+		len(raw.dss),
+		len(raw.pools),
 		time.Since(start),
 	)
 
@@ -29,6 +29,7 @@ func TestDiscoverer_Discover(t *testing.T) {
 	assert.True(t, len(res.Clusters) > 0)
 	assert.True(t, len(res.Hosts) > 0)
 	assert.True(t, len(res.VMs) > 0)
+	assert.True(t, len(res.Datastores) > 0)
 	assert.True(t, isHierarchySet(res))
 	assert.True(t, isMetricListsCollected(res))
 }
@@ -47,6 +48,8 @@ func TestDiscoverer_discover(t *testing.T) {
 	assert.Lenf(t, raw.clusters, count.Cluster+dummyClusters, "clusters")
 	assert.Lenf(t, raw.hosts, count.Host, "hosts")
 	assert.Lenf(t, raw.vms, count.Machine, "hosts")
+	assert.Lenf(t, raw.dss, count.Datastore, "datastores")
+	assert.Lenf(t, raw.pools, count.Pool, "resource pools")
 }
 
 func TestDiscoverer_build(t *testing.T) {
@@ -63,6 +66,8 @@ func TestDiscoverer_build(t *testing.T) {
 	assert.Lenf(t, res.Clusters, len(raw.clusters), "clusters")
 	assert.Lenf(t, res.Hosts, len(raw.hosts), "hosts")
 	assert.Lenf(t, res.VMs, len(raw.vms), "hosts")
+	assert.Lenf(t, res.Datastores, len(raw.dss), "datastores")
+	assert.Lenf(t, res.ResourcePools, 0, "resource pools") // only root resource pools
 }
 
 func TestDiscoverer_setHierarchy(t *testing.T) {
@@ -109,6 +114,23 @@ func TestDiscoverer_collectMetricLists(t *testing.T) {
 	assert.True(t, isMetricListsCollected(res))
 }
 
+func Test_datastoreUUID(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want string
+	}{
+		"vmfs":  {url: "ds:///vmfs/volumes/5f1b2c3d-4e5f6a7b-8c9d-000c29a1b2c3/", want: "5f1b2c3d-4e5f6a7b-8c9d-000c29a1b2c3"},
+		"vsan":  {url: "ds:///vmfs/volumes/vsan:52a8c1d2e3f4a5b6-c7d8e9f0a1b2c3d4/", want: "vsan:52a8c1d2e3f4a5b6-c7d8e9f0a1b2c3d4"},
+		"empty": {url: "", want: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, datastoreUUID(test.url))
+		})
+	}
+}
+
 func prepareDiscovererSim(t *testing.T) (d *Discoverer, model *simulator.Model, teardown func()) {
 	model, srv := createSim(t)
 	teardown = func() { model.Remove(); srv.Close() }
@@ -153,12 +175,22 @@ func isHierarchySet(res *rs.Resources) bool {
 			return false
 		}
 	}
+	for _, ds := range res.Datastores {
+		if !ds.Hier.IsSet() {
+			return false
+		}
+	}
+	for _, p := range res.ResourcePools {
+		if !p.Hier.IsSet() {
+			return false
+		}
+	}
 	return true
 }
 
 func isMetricListsCollected(res *rs.Resources) bool {
 	for _, h := range res.Hosts {
-		if h.MetricList == nil {
+		if h.MetricList == nil || h.DatastoreMetricList == nil {
 			return false
 		}
 	}
@@ -21,8 +21,10 @@ func (d Discoverer) collectMetricLists(res *rs.Resources) error {
 	}
 
 	hostML := simpleHostMetricList(perfCounters)
+	hostDsML := hostDatastoreMetricList(perfCounters)
 	for _, h := range res.Hosts {
 		h.MetricList = hostML
+		h.DatastoreMetricList = hostDsML
 	}
 	vmML := simpleVMMetricList(perfCounters)
 	for _, v := range res.VMs {
@@ -74,6 +76,19 @@ func simpleMetricList(metrics []string, pci map[string]*types.PerfCounterInfo) p
 	return pml
 }
 
+// hostDatastoreMetricList returns per datastore metrics, the datastore UUID is the metric Instance.
+func hostDatastoreMetricList(pci map[string]*types.PerfCounterInfo) performance.MetricList {
+	sort.Strings(hostDatastoreMetrics)
+
+	var pml performance.MetricList
+	for _, v := range hostDatastoreMetrics {
+		if m, ok := pci[v]; ok {
+			pml = append(pml, types.PerfMetricId{CounterId: m.Key, Instance: "*"})
+		}
+	}
+	return pml
+}
+
 var (
 	vmMetrics = []string{
 		"cpu.usage.average",
@@ -132,4 +147,13 @@ var (
 
 		"sys.uptime.latest",
 	}
+
+	hostDatastoreMetrics = []string{
+		"datastore.read.average",
+		"datastore.write.average",
+		"datastore.numberReadAveraged.average",
+		"datastore.numberWriteAveraged.average",
+		"datastore.totalReadLatency.average",
+		"datastore.totalWriteLatency.average",
+	}
 )
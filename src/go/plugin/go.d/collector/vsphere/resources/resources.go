@@ -44,11 +44,13 @@ Virtual Datacenter Architecture Representation (partial).
 */
 
 type Resources struct {
-	DataCenters DataCenters
-	Folders     Folders
-	Clusters    Clusters
-	Hosts       Hosts
-	VMs         VMs
+	DataCenters   DataCenters
+	Folders       Folders
+	Clusters      Clusters
+	Hosts         Hosts
+	VMs           VMs
+	Datastores    Datastores
+	ResourcePools ResourcePools
 }
 
 type (
@@ -88,7 +90,9 @@ type (
 		Hier          HostHierarchy
 		OverallStatus string
 		MetricList    performance.MetricList
-		Ref           types.ManagedObjectReference
+		// DatastoreMetricList is a list of per datastore (Instance is "*") metrics.
+		DatastoreMetricList performance.MetricList
+		Ref                 types.ManagedObjectReference
 	}
 
 	VMHierarchy struct {
@@ -106,6 +110,33 @@ type (
 		MetricList    performance.MetricList
 		Ref           types.ManagedObjectReference
 	}
+
+	DatastoreHierarchy struct {
+		DC HierarchyValue
+	}
+	Datastore struct {
+		Name     string
+		ID       string
+		ParentID string
+		Hier     DatastoreHierarchy
+		Type     string
+		// UUID is the datastore instance name in the host "datastore.*" performance metrics.
+		UUID string
+		Ref  types.ManagedObjectReference
+	}
+
+	ResourcePoolHierarchy struct {
+		DC      HierarchyValue
+		Cluster HierarchyValue
+	}
+	ResourcePool struct {
+		Name     string
+		ID       string
+		ParentID string
+		OwnerID  string
+		Hier     ResourcePoolHierarchy
+		Ref      types.ManagedObjectReference
+	}
 )
 
 func (v *HierarchyValue) IsSet() bool         { return v.ID != "" && v.Name != "" }
@@ -115,23 +146,32 @@ func (h ClusterHierarchy) IsSet() bool { return h.DC.IsSet() }
 func (h HostHierarchy) IsSet() bool    { return h.DC.IsSet() && h.Cluster.IsSet() }
 func (h VMHierarchy) IsSet() bool      { return h.DC.IsSet() && h.Cluster.IsSet() && h.Host.IsSet() }
 
+func (h DatastoreHierarchy) IsSet() bool    { return h.DC.IsSet() }
+func (h ResourcePoolHierarchy) IsSet() bool { return h.DC.IsSet() && h.Cluster.IsSet() }
+
 type (
-	DataCenters map[string]*Datacenter
-	Folders     map[string]*Folder
-	Clusters    map[string]*Cluster
-	Hosts       map[string]*Host
-	VMs         map[string]*VM
+	DataCenters   map[string]*Datacenter
+	Folders       map[string]*Folder
+	Clusters      map[string]*Cluster
+	Hosts         map[string]*Host
+	VMs           map[string]*VM
+	Datastores    map[string]*Datastore
+	ResourcePools map[string]*ResourcePool
 )
 
-func (dcs DataCenters) Put(dc *Datacenter)        { dcs[dc.ID] = dc }
-func (dcs DataCenters) Get(id string) *Datacenter { return dcs[id] }
-func (fs Folders) Put(folder *Folder)             { fs[folder.ID] = folder }
-func (fs Folders) Get(id string) *Folder          { return fs[id] }
-func (cs Clusters) Put(cluster *Cluster)          { cs[cluster.ID] = cluster }
-func (cs Clusters) Get(id string) *Cluster        { return cs[id] }
-func (hs Hosts) Put(host *Host)                   { hs[host.ID] = host }
-func (hs Hosts) Remove(id string)                 { delete(hs, id) }
-func (hs Hosts) Get(id string) *Host              { return hs[id] }
-func (vs VMs) Put(vm *VM)                         { vs[vm.ID] = vm }
-func (vs VMs) Remove(id string)                   { delete(vs, id) }
-func (vs VMs) Get(id string) *VM                  { return vs[id] }
+func (dcs DataCenters) Put(dc *Datacenter)           { dcs[dc.ID] = dc }
+func (dcs DataCenters) Get(id string) *Datacenter    { return dcs[id] }
+func (fs Folders) Put(folder *Folder)                { fs[folder.ID] = folder }
+func (fs Folders) Get(id string) *Folder             { return fs[id] }
+func (cs Clusters) Put(cluster *Cluster)             { cs[cluster.ID] = cluster }
+func (cs Clusters) Get(id string) *Cluster           { return cs[id] }
+func (hs Hosts) Put(host *Host)                      { hs[host.ID] = host }
+func (hs Hosts) Remove(id string)                    { delete(hs, id) }
+func (hs Hosts) Get(id string) *Host                 { return hs[id] }
+func (vs VMs) Put(vm *VM)                            { vs[vm.ID] = vm }
+func (vs VMs) Remove(id string)                      { delete(vs, id) }
+func (vs VMs) Get(id string) *VM                     { return vs[id] }
+func (ds Datastores) Put(d *Datastore)               { ds[d.ID] = d }
+func (ds Datastores) Get(id string) *Datastore       { return ds[id] }
+func (ps ResourcePools) Put(p *ResourcePool)         { ps[p.ID] = p }
+func (ps ResourcePools) Get(id string) *ResourcePool { return ps[id] }
@@ -13,12 +13,20 @@ import (
 	rs "github.com/netdata/netdata/go/plugins/plugin/go.d/collector/vsphere/resources"
 
 	"github.com/vmware/govmomi/performance"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
 type Client interface {
 	Version() string
 	PerformanceMetrics([]types.PerfQuerySpec) ([]performance.EntityMetric, error)
+	Datastores(pathSet ...string) ([]mo.Datastore, error)
+	ResourcePools(pathSet ...string) ([]mo.ResourcePool, error)
+	ClusterComputeResources(pathSet ...string) ([]mo.ClusterComputeResource, error)
+	TriggeredAlarms() ([]types.AlarmState, error)
+	CurrentTime() (time.Time, error)
+	Events(filter types.EventFilterSpec) ([]types.BaseEvent, error)
+	EventCategory(e types.BaseEvent) (string, error)
 }
 
 func New(client Client) *Scraper {
@@ -31,6 +39,9 @@ type Scraper struct {
 	*logger.Logger
 	Client
 	maxQuery int
+
+	eventsSince  time.Time
+	lastEventKey int32
 }
 
 // Default settings for vCenter 6.5 and above is 256, prior versions of vCenter have this set to 64.
@@ -67,6 +78,127 @@ func (s *Scraper) ScrapeVMs(vms rs.VMs) []performance.EntityMetric {
 	return ms
 }
 
+// ScrapeHostsDatastores scrapes per datastore metrics from hosts, the datastore UUID is the metric Instance.
+func (s *Scraper) ScrapeHostsDatastores(hosts rs.Hosts) []performance.EntityMetric {
+	t := time.Now()
+	pqs := newHostsDatastorePerfQuerySpecs(hosts)
+	ms := s.scrapeMetrics(pqs)
+	s.Debugf("scraping : scraped datastore metrics for %d/%d hosts, process took %s",
+		len(ms),
+		len(hosts),
+		time.Since(t),
+	)
+	return ms
+}
+
+// Inventory holds inventory objects properties that are not available as real-time performance metrics.
+type Inventory struct {
+	Datastores    []mo.Datastore
+	Clusters      []mo.ClusterComputeResource
+	ResourcePools []mo.ResourcePool
+	// Alarms is nil if the triggered alarms could not be scraped.
+	Alarms []types.AlarmState
+}
+
+var (
+	datastorePathSet = []string{"summary", "overallStatus"}
+	clusterPathSet   = []string{"summary", "configurationEx", "overallStatus", "resourcePool"}
+	poolPathSet      = []string{"runtime"}
+)
+
+func (s *Scraper) ScrapeInventory() *Inventory {
+	t := time.Now()
+	tc := newThrottledCaller(5)
+	var inv Inventory
+
+	tc.call(func() {
+		v, err := s.Datastores(datastorePathSet...)
+		if err != nil {
+			s.Errorf("scraping : datastores : %v", err)
+		}
+		inv.Datastores = v
+	})
+	tc.call(func() {
+		v, err := s.ClusterComputeResources(clusterPathSet...)
+		if err != nil {
+			s.Errorf("scraping : clusters : %v", err)
+		}
+		inv.Clusters = v
+	})
+	tc.call(func() {
+		v, err := s.ResourcePools(poolPathSet...)
+		if err != nil {
+			s.Errorf("scraping : resource pools : %v", err)
+		}
+		inv.ResourcePools = v
+	})
+	tc.call(func() {
+		v, err := s.TriggeredAlarms()
+		if err != nil {
+			s.Errorf("scraping : triggered alarms : %v", err)
+			return
+		}
+		if v == nil {
+			v = []types.AlarmState{}
+		}
+		inv.Alarms = v
+	})
+	tc.wait()
+
+	s.Debugf("scraping : scraped %d datastores, %d clusters, %d resource pools, %d triggered alarms, process took %s",
+		len(inv.Datastores),
+		len(inv.Clusters),
+		len(inv.ResourcePools),
+		len(inv.Alarms),
+		time.Since(t),
+	)
+	return &inv
+}
+
+// ScrapeEvents returns the number of events by category ("info", "warning", "error", "user")
+// that happened since the previous call. The first call only sets the starting point.
+func (s *Scraper) ScrapeEvents() (map[string]int64, error) {
+	if s.eventsSince.IsZero() {
+		now, err := s.CurrentTime()
+		if err != nil {
+			return nil, err
+		}
+		s.eventsSince = now
+		return map[string]int64{}, nil
+	}
+
+	since := s.eventsSince
+	events, err := s.Events(types.EventFilterSpec{
+		Time: &types.EventFilterSpecByTime{BeginTime: &since},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	lastKey := s.lastEventKey
+	for _, e := range events {
+		ev := e.GetEvent()
+		// the time filter is inclusive
+		if ev.Key <= s.lastEventKey {
+			continue
+		}
+		category, err := s.EventCategory(e)
+		if err != nil || category == "" {
+			category = "info"
+		}
+		counts[category]++
+
+		lastKey = max(lastKey, ev.Key)
+		if ev.CreatedTime.After(s.eventsSince) {
+			s.eventsSince = ev.CreatedTime
+		}
+	}
+	s.lastEventKey = lastKey
+
+	return counts, nil
+}
+
 func (s *Scraper) scrapeMetrics(pqs []types.PerfQuerySpec) []performance.EntityMetric {
 	tc := newThrottledCaller(5)
 	var ms []performance.EntityMetric
@@ -144,6 +276,24 @@ func newVMsPerfQuerySpecs(vms rs.VMs) []types.PerfQuerySpec {
 	return pqs
 }
 
+func newHostsDatastorePerfQuerySpecs(hosts rs.Hosts) []types.PerfQuerySpec {
+	pqs := make([]types.PerfQuerySpec, 0, len(hosts))
+	for _, host := range hosts {
+		if len(host.DatastoreMetricList) == 0 {
+			continue
+		}
+		pq := types.PerfQuerySpec{
+			Entity:     host.Ref,
+			MaxSample:  pqsMaxSample,
+			MetricId:   host.DatastoreMetricList,
+			IntervalId: pqsIntervalID,
+			Format:     pqsFormat,
+		}
+		pqs = append(pqs, pq)
+	}
+	return pqs
+}
+
 func parseVersion(version string) (major, minor int, err error) {
 	parts := strings.Split(version, ".")
 	if len(parts) < 2 {
@@ -37,6 +37,45 @@ func TestScraper_ScrapeHosts(t *testing.T) {
 	assert.Len(t, metrics, len(res.Hosts))
 }
 
+func TestScraper_ScrapeHostsDatastores(t *testing.T) {
+	s, res, teardown := prepareScraper(t)
+	defer teardown()
+
+	metrics := s.ScrapeHostsDatastores(res.Hosts)
+	require.Len(t, metrics, len(res.Hosts))
+	for _, m := range metrics {
+		for _, v := range m.Value {
+			assert.NotEmpty(t, v.Instance)
+		}
+	}
+}
+
+func TestScraper_ScrapeInventory(t *testing.T) {
+	s, res, teardown := prepareScraper(t)
+	defer teardown()
+
+	inv := s.ScrapeInventory()
+	assert.Len(t, inv.Datastores, len(res.Datastores))
+	assert.NotEmpty(t, inv.Clusters)
+	assert.NotEmpty(t, inv.ResourcePools)
+	for _, ds := range inv.Datastores {
+		assert.NotZero(t, ds.Summary.Capacity)
+	}
+}
+
+func TestScraper_ScrapeEvents(t *testing.T) {
+	s, _, teardown := prepareScraper(t)
+	defer teardown()
+
+	counts, err := s.ScrapeEvents()
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+	assert.False(t, s.eventsSince.IsZero())
+
+	_, err = s.ScrapeEvents()
+	assert.NoError(t, err)
+}
+
 func prepareScraper(t *testing.T) (s *Scraper, res *rs.Resources, teardown func()) {
 	model, srv := createSim(t)
 	teardown = func() { model.Remove(); srv.Close() }
@@ -2,22 +2,133 @@
 
 package whoisquery
 
-import "github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
-
-var baseCharts = module.Charts{
-	{
-		ID:    "time_until_expiration",
-		Title: "Time Until Domain Expiration",
-		Units: "seconds",
-		Fam:   "expiration time",
-		Ctx:   "whoisquery.time_until_expiration",
-		Opts:  module.Opts{StoreFirst: true},
+import (
+	"fmt"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+const (
+	prioTimeUntilExpiration = module.Priority + iota
+	prioDomainStatus
+	prioDNSSECStatus
+	prioNameservers
+	prioRegistrationChanges
+)
+
+var domainChartsTmpl = module.Charts{
+	domainTimeUntilExpirationChartTmpl.Copy(),
+	domainStatusChartTmpl.Copy(),
+	domainDNSSECStatusChartTmpl.Copy(),
+	domainNameserversChartTmpl.Copy(),
+	domainRegistrationChangesChartTmpl.Copy(),
+}
+
+var (
+	domainTimeUntilExpirationChartTmpl = module.Chart{
+		ID:       "domain_%s_time_until_expiration",
+		Title:    "Time Until Domain Expiration",
+		Units:    "seconds",
+		Fam:      "expiration time",
+		Ctx:      "whoisquery.time_until_expiration",
+		Priority: prioTimeUntilExpiration,
+		Opts:     module.Opts{StoreFirst: true},
 		Dims: module.Dims{
-			{ID: "expiry"},
+			{ID: "domain_%s_expiry", Name: "expiry"},
 		},
 		Vars: module.Vars{
 			{ID: "days_until_expiration_warning"},
 			{ID: "days_until_expiration_critical"},
 		},
-	},
+	}
+	domainStatusChartTmpl = module.Chart{
+		ID:       "domain_%s_status",
+		Title:    "Domain Status Codes",
+		Units:    "status",
+		Fam:      "status",
+		Ctx:      "whoisquery.domain_status",
+		Priority: prioDomainStatus,
+		Opts:     module.Opts{StoreFirst: true},
+		Dims:     domainStatusDims(),
+	}
+	domainDNSSECStatusChartTmpl = module.Chart{
+		ID:       "domain_%s_dnssec_status",
+		Title:    "Domain DNSSEC Delegation Status",
+		Units:    "status",
+		Fam:      "dnssec",
+		Ctx:      "whoisquery.dnssec_status",
+		Priority: prioDNSSECStatus,
+		Opts:     module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "domain_%s_dnssec_signed", Name: "signed"},
+			{ID: "domain_%s_dnssec_unsigned", Name: "unsigned"},
+		},
+	}
+	domainNameserversChartTmpl = module.Chart{
+		ID:       "domain_%s_nameservers",
+		Title:    "Domain Delegated Nameservers",
+		Units:    "nameservers",
+		Fam:      "nameservers",
+		Ctx:      "whoisquery.nameservers",
+		Priority: prioNameservers,
+		Opts:     module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "domain_%s_nameservers", Name: "nameservers"},
+		},
+	}
+	domainRegistrationChangesChartTmpl = module.Chart{
+		ID:       "domain_%s_registration_changes",
+		Title:    "Domain Registration Data Changes",
+		Units:    "changes",
+		Fam:      "changes",
+		Ctx:      "whoisquery.registration_changes",
+		Priority: prioRegistrationChanges,
+		Opts:     module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "domain_%s_change_nameservers", Name: "nameservers"},
+			{ID: "domain_%s_change_registrar", Name: "registrar"},
+			{ID: "domain_%s_change_dnssec", Name: "dnssec"},
+			{ID: "domain_%s_change_lock_removed", Name: "lock_removed"},
+		},
+	}
+)
+
+func domainStatusDims() module.Dims {
+	var dims module.Dims
+	for _, code := range eppStatusCodes {
+		dims = append(dims, &module.Dim{ID: "domain_%s_status_" + code, Name: code})
+	}
+	return dims
+}
+
+func (c *Collector) addDomainCharts(ds *domainState) {
+	charts := domainChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, ds.id)
+		chart.Labels = ds.chartLabels()
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, ds.id)
+		}
+	}
+
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Collector) updateDomainChartsLabels(ds *domainState) {
+	for _, tmpl := range domainChartsTmpl {
+		if chart := c.Charts().Get(fmt.Sprintf(tmpl.ID, ds.id)); chart != nil {
+			chart.Labels = ds.chartLabels()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (ds *domainState) chartLabels() []module.Label {
+	return []module.Label{
+		{Key: "domain", Value: ds.name},
+		{Key: "registrar", Value: ds.registrar},
+	}
 }
@@ -2,22 +2,198 @@
 
 package whoisquery
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxConcurrentLookups limits the number of simultaneous RDAP/WHOIS queries per job.
+const maxConcurrentLookups = 8
+
+// https://www.icann.org/resources/pages/epp-status-codes-2014-06-16-en
+var eppStatusCodes = []string{
+	"ok",
+	"inactive",
+	"add_period",
+	"auto_renew_period",
+	"renew_period",
+	"transfer_period",
+	"redemption_period",
+	"pending_create",
+	"pending_delete",
+	"pending_renew",
+	"pending_restore",
+	"pending_transfer",
+	"pending_update",
+	"client_hold",
+	"client_delete_prohibited",
+	"client_renew_prohibited",
+	"client_transfer_prohibited",
+	"client_update_prohibited",
+	"server_hold",
+	"server_delete_prohibited",
+	"server_renew_prohibited",
+	"server_transfer_prohibited",
+	"server_update_prohibited",
+}
+
+type domainState struct {
+	name string
+	id   string
+
+	hasCharts   bool
+	source      string
+	registrar   string
+	nameservers []string
+	dnssec      bool
+	status      []string
+}
+
+type lookupResult struct {
+	domain string
+	info   *domainInfo
+	err    error
+}
 
 func (c *Collector) collect() (map[string]int64, error) {
-	remainingTime, err := c.prov.remainingTime()
-	if err != nil {
-		return nil, fmt.Errorf("%v (source: %s)", err, c.Source)
-	}
+	results := c.lookupDomains()
 
 	mx := make(map[string]int64)
-	c.collectExpiration(mx, remainingTime)
+	var errs []error
 
-	return mx, nil
-}
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%v (domain: %s)", res.err, res.domain))
+			continue
+		}
+		c.collectDomain(mx, res.domain, res.info)
+	}
+
+	if len(mx) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	for _, err := range errs {
+		c.Warning(err)
+	}
 
-func (c *Collector) collectExpiration(mx map[string]int64, remainingTime float64) {
-	mx["expiry"] = int64(remainingTime)
 	mx["days_until_expiration_warning"] = c.DaysUntilWarn
 	mx["days_until_expiration_critical"] = c.DaysUntilCrit
+
+	return mx, nil
+}
+
+func (c *Collector) lookupDomains() []lookupResult {
+	results := make([]lookupResult, len(c.domainNames))
+	sem := make(chan struct{}, maxConcurrentLookups)
+	var wg sync.WaitGroup
+
+	for i, domain := range c.domainNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			info, err := c.prov.lookup(domain)
+			results[i] = lookupResult{domain: domain, info: info, err: err}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (c *Collector) collectDomain(mx map[string]int64, domain string, info *domainInfo) {
+	ds, ok := c.domains[domain]
+	if !ok {
+		ds = &domainState{name: domain, id: strings.ReplaceAll(domain, ".", "_")}
+		c.domains[domain] = ds
+	}
+
+	c.Debugf("domain '%s': collected using %s", domain, info.source)
+
+	px := fmt.Sprintf("domain_%s_", ds.id)
+
+	mx[px+"expiry"] = int64(time.Until(info.expiration).Seconds())
+
+	for _, code := range eppStatusCodes {
+		mx[px+"status_"+code] = boolToInt(slices.Contains(info.status, code))
+	}
+
+	mx[px+"dnssec_signed"] = boolToInt(info.dnssec)
+	mx[px+"dnssec_unsigned"] = boolToInt(!info.dnssec)
+	mx[px+"nameservers"] = int64(len(info.nameservers))
+
+	mx[px+"change_nameservers"] = 0
+	mx[px+"change_registrar"] = 0
+	mx[px+"change_dnssec"] = 0
+	mx[px+"change_lock_removed"] = 0
+
+	if !ds.hasCharts {
+		ds.hasCharts = true
+		ds.update(info)
+		c.addDomainCharts(ds)
+		return
+	}
+
+	registrarChanged := ds.registrar != info.registrar
+
+	if ds.source == info.source {
+		c.detectChanges(mx, px, ds, info)
+	} else {
+		// RDAP and WHOIS represent registration data differently, comparing them would report false changes
+		c.Debugf("domain '%s': data source changed from %s to %s", domain, ds.source, info.source)
+	}
+
+	ds.update(info)
+
+	if registrarChanged {
+		c.updateDomainChartsLabels(ds)
+	}
+}
+
+func (c *Collector) detectChanges(mx map[string]int64, px string, ds *domainState, info *domainInfo) {
+	if !slices.Equal(ds.nameservers, info.nameservers) {
+		c.Infof("domain '%s': nameservers changed from %v to %v", ds.name, ds.nameservers, info.nameservers)
+		mx[px+"change_nameservers"] = 1
+	}
+	if ds.registrar != info.registrar {
+		c.Infof("domain '%s': registrar changed from '%s' to '%s'", ds.name, ds.registrar, info.registrar)
+		mx[px+"change_registrar"] = 1
+	}
+	if ds.dnssec != info.dnssec {
+		c.Infof("domain '%s': DNSSEC delegation changed from %v to %v", ds.name, ds.dnssec, info.dnssec)
+		mx[px+"change_dnssec"] = 1
+	}
+	for _, code := range ds.status {
+		if isLockStatus(code) && !slices.Contains(info.status, code) {
+			c.Infof("domain '%s': status '%s' removed", ds.name, code)
+			mx[px+"change_lock_removed"] = 1
+		}
+	}
+}
+
+func (ds *domainState) update(info *domainInfo) {
+	ds.source = info.source
+	ds.registrar = info.registrar
+	ds.nameservers = info.nameservers
+	ds.dnssec = info.dnssec
+	ds.status = info.status
+}
+
+func isLockStatus(code string) bool {
+	return strings.HasSuffix(code, "_prohibited")
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
 }
@@ -31,16 +31,22 @@ func New() *Collector {
 	return &Collector{
 		Config: Config{
 			Timeout:       confopt.Duration(time.Second * 5),
+			Protocol:      protocolAuto,
 			DaysUntilWarn: 30,
 			DaysUntilCrit: 15,
 		},
+		charts:  &module.Charts{},
+		domains: make(map[string]*domainState),
 	}
 }
 
 type Config struct {
 	Vnode         string           `yaml:"vnode,omitempty" json:"vnode"`
 	UpdateEvery   int              `yaml:"update_every,omitempty" json:"update_every"`
-	Source        string           `yaml:"source" json:"source"`
+	Source        string           `yaml:"source,omitempty" json:"source"`
+	Domains       []string         `yaml:"domains,omitempty" json:"domains"`
+	Protocol      string           `yaml:"protocol,omitempty" json:"protocol"`
+	RDAPServerURL string           `yaml:"rdap_server_url,omitempty" json:"rdap_server_url"`
 	Timeout       confopt.Duration `yaml:"timeout,omitempty" json:"timeout"`
 	DaysUntilWarn int64            `yaml:"days_until_expiration_warning,omitempty" json:"days_until_expiration_warning"`
 	DaysUntilCrit int64            `yaml:"days_until_expiration_critical,omitempty" json:"days_until_expiration_critical"`
@@ -53,6 +59,9 @@ type Collector struct {
 	charts *module.Charts
 
 	prov provider
+
+	domainNames []string
+	domains     map[string]*domainState
 }
 
 func (c *Collector) Configuration() any {
@@ -70,7 +79,7 @@ func (c *Collector) Init(context.Context) error {
 	}
 	c.prov = prov
 
-	c.charts = c.initCharts()
+	c.domainNames = c.initDomainNames()
 
 	return nil
 }
@@ -5,8 +5,12 @@ package whoisquery
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 
@@ -17,12 +21,15 @@ import (
 var (
 	dataConfigJSON, _ = os.ReadFile("testdata/config.json")
 	dataConfigYAML, _ = os.ReadFile("testdata/config.yaml")
+
+	dataRDAPDomain, _ = os.ReadFile("testdata/rdap-domain.json")
 )
 
 func Test_testDataIsValid(t *testing.T) {
 	for name, data := range map[string][]byte{
 		"dataConfigJSON": dataConfigJSON,
 		"dataConfigYAML": dataConfigYAML,
+		"dataRDAPDomain": dataRDAPDomain,
 	} {
 		require.NotNil(t, data, name)
 	}
@@ -45,19 +52,44 @@ func TestCollector_Charts(t *testing.T) {
 }
 
 func TestCollector_Init(t *testing.T) {
-	const net = iota
 	tests := map[string]struct {
-		config       Config
-		providerType int
-		err          bool
+		config      Config
+		wantFail    bool
+		wantDomains []string
+		wantProv    func(t *testing.T, prov provider)
 	}{
-		"ok from net": {
-			config:       Config{Source: "example.org"},
-			providerType: net,
+		"source, auto protocol": {
+			config:      Config{Source: "example.org"},
+			wantDomains: []string{"example.org"},
+			wantProv: func(t *testing.T, prov provider) {
+				fp, ok := prov.(*fallbackProvider)
+				require.True(t, ok)
+				require.Len(t, fp.providers, 2)
+				assert.IsType(t, &rdapClient{}, fp.providers[0])
+				assert.IsType(t, &whoisClient{}, fp.providers[1])
+			},
+		},
+		"domains, whois protocol": {
+			config:      Config{Source: "Example.org.", Domains: []string{"example.org", "example.net", " "}, Protocol: "whois"},
+			wantDomains: []string{"example.org", "example.net"},
+			wantProv: func(t *testing.T, prov provider) {
+				assert.IsType(t, &whoisClient{}, prov)
+			},
+		},
+		"domains, rdap protocol": {
+			config:      Config{Domains: []string{"example.org"}, Protocol: "rdap"},
+			wantDomains: []string{"example.org"},
+			wantProv: func(t *testing.T, prov provider) {
+				assert.IsType(t, &rdapClient{}, prov)
+			},
 		},
 		"empty source": {
-			config: Config{Source: ""},
-			err:    true,
+			config:   Config{Source: ""},
+			wantFail: true,
+		},
+		"unknown protocol": {
+			config:   Config{Source: "example.org", Protocol: "dns"},
+			wantFail: true,
 		},
 	}
 
@@ -66,17 +98,12 @@ func TestCollector_Init(t *testing.T) {
 			collr := New()
 			collr.Config = test.config
 
-			if test.err {
+			if test.wantFail {
 				assert.Error(t, collr.Init(context.Background()))
 			} else {
 				require.NoError(t, collr.Init(context.Background()))
-
-				var typeOK bool
-				if test.providerType == net {
-					_, typeOK = collr.prov.(*whoisClient)
-				}
-
-				assert.True(t, typeOK)
+				assert.Equal(t, test.wantDomains, collr.domainNames)
+				test.wantProv(t, collr.prov)
 			}
 		})
 	}
@@ -84,54 +111,262 @@ func TestCollector_Init(t *testing.T) {
 
 func TestCollector_Check(t *testing.T) {
 	collr := New()
-	collr.prov = &mockProvider{remTime: 12345.678}
+	collr.Source = "example.com"
+	require.NoError(t, collr.Init(context.Background()))
+	collr.prov = &mockProvider{infos: map[string]*domainInfo{"example.com": newTestDomainInfo()}}
 
 	assert.NoError(t, collr.Check(context.Background()))
 }
 
 func TestCollector_Check_ReturnsFalseOnProviderError(t *testing.T) {
 	collr := New()
-	collr.prov = &mockProvider{err: true}
+	collr.Source = "example.com"
+	require.NoError(t, collr.Init(context.Background()))
+	collr.prov = &mockProvider{}
 
 	assert.Error(t, collr.Check(context.Background()))
 }
 
 func TestCollector_Collect(t *testing.T) {
 	collr := New()
-	collr.Source = "example.com"
+	collr.Domains = []string{"example.com", "example.net"}
 	require.NoError(t, collr.Init(context.Background()))
-	collr.prov = &mockProvider{remTime: 12345}
+	collr.prov = &mockProvider{infos: map[string]*domainInfo{"example.com": newTestDomainInfo()}}
 
 	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Len(t, *collr.Charts(), len(domainChartsTmpl))
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	assert.InDelta(t, 12345, mx["domain_example_com_expiry"], 5)
+	delete(mx, "domain_example_com_expiry")
 
 	expected := map[string]int64{
-		"expiry":                         12345,
-		"days_until_expiration_warning":  30,
-		"days_until_expiration_critical": 15,
+		"days_until_expiration_critical":                       15,
+		"days_until_expiration_warning":                        30,
+		"domain_example_com_change_dnssec":                     0,
+		"domain_example_com_change_lock_removed":               0,
+		"domain_example_com_change_nameservers":                0,
+		"domain_example_com_change_registrar":                  0,
+		"domain_example_com_dnssec_signed":                     1,
+		"domain_example_com_dnssec_unsigned":                   0,
+		"domain_example_com_nameservers":                       2,
+		"domain_example_com_status_add_period":                 0,
+		"domain_example_com_status_auto_renew_period":          0,
+		"domain_example_com_status_client_delete_prohibited":   1,
+		"domain_example_com_status_client_hold":                0,
+		"domain_example_com_status_client_renew_prohibited":    0,
+		"domain_example_com_status_client_transfer_prohibited": 1,
+		"domain_example_com_status_client_update_prohibited":   0,
+		"domain_example_com_status_inactive":                   0,
+		"domain_example_com_status_ok":                         0,
+		"domain_example_com_status_pending_create":             0,
+		"domain_example_com_status_pending_delete":             0,
+		"domain_example_com_status_pending_renew":              0,
+		"domain_example_com_status_pending_restore":            0,
+		"domain_example_com_status_pending_transfer":           0,
+		"domain_example_com_status_pending_update":             0,
+		"domain_example_com_status_redemption_period":          0,
+		"domain_example_com_status_renew_period":               0,
+		"domain_example_com_status_server_delete_prohibited":   0,
+		"domain_example_com_status_server_hold":                0,
+		"domain_example_com_status_server_renew_prohibited":    0,
+		"domain_example_com_status_server_transfer_prohibited": 0,
+		"domain_example_com_status_server_update_prohibited":   0,
+		"domain_example_com_status_transfer_period":            0,
 	}
 
-	assert.NotZero(t, mx)
 	assert.Equal(t, expected, mx)
-	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+}
+
+func TestCollector_Collect_DetectsChanges(t *testing.T) {
+	collr := New()
+	collr.Source = "example.com"
+	require.NoError(t, collr.Init(context.Background()))
+	info := newTestDomainInfo()
+	collr.prov = &mockProvider{infos: map[string]*domainInfo{"example.com": info}}
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	changed := *info
+	changed.registrar = "Other Registrar, Inc."
+	changed.nameservers = []string{"ns1.other.net", "ns2.other.net"}
+	changed.dnssec = false
+	changed.status = []string{"client_delete_prohibited"}
+	collr.prov = &mockProvider{infos: map[string]*domainInfo{"example.com": &changed}}
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(1), mx["domain_example_com_change_nameservers"])
+	assert.Equal(t, int64(1), mx["domain_example_com_change_registrar"])
+	assert.Equal(t, int64(1), mx["domain_example_com_change_dnssec"])
+	assert.Equal(t, int64(1), mx["domain_example_com_change_lock_removed"])
+	assert.Equal(t, int64(0), mx["domain_example_com_status_client_transfer_prohibited"])
+
+	for _, chart := range *collr.Charts() {
+		assert.Contains(t, chart.Labels, module.Label{Key: "registrar", Value: "Other Registrar, Inc."})
+	}
+
+	mx = collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(0), mx["domain_example_com_change_nameservers"])
+	assert.Equal(t, int64(0), mx["domain_example_com_change_registrar"])
+	assert.Equal(t, int64(0), mx["domain_example_com_change_dnssec"])
+	assert.Equal(t, int64(0), mx["domain_example_com_change_lock_removed"])
+}
+
+func TestCollector_Collect_IgnoresChangesOnDataSourceSwitch(t *testing.T) {
+	collr := New()
+	collr.Source = "example.com"
+	require.NoError(t, collr.Init(context.Background()))
+	info := newTestDomainInfo()
+	collr.prov = &mockProvider{infos: map[string]*domainInfo{"example.com": info}}
+
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	fallback := *info
+	fallback.source = protocolWHOIS
+	fallback.registrar = "EXAMPLE REGISTRAR INC"
+	fallback.dnssec = false
+	collr.prov = &mockProvider{infos: map[string]*domainInfo{"example.com": &fallback}}
+
+	mx := collr.Collect(context.Background())
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(0), mx["domain_example_com_change_registrar"])
+	assert.Equal(t, int64(0), mx["domain_example_com_change_dnssec"])
 }
 
 func TestCollector_Collect_ReturnsNilOnProviderError(t *testing.T) {
 	collr := New()
 	collr.Source = "example.com"
 	require.NoError(t, collr.Init(context.Background()))
-	collr.prov = &mockProvider{err: true}
+	collr.prov = &mockProvider{}
 
 	assert.Nil(t, collr.Collect(context.Background()))
 }
 
+func TestRDAPClient_Lookup(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		assert.Equal(t, rdapContentType, r.Header.Get("Accept"))
+
+		switch r.URL.Path {
+		case "/bootstrap.json":
+			_, _ = fmt.Fprintf(w, `{"services": [[["net", "org"], ["%s/org/"]], [["co.uk"], ["%s/couk/"]]]}`,
+				"http://"+r.Host, "http://"+r.Host)
+		case "/org/domain/example.org":
+			_, _ = w.Write(dataRDAPDomain)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := newRDAPClient(New().Config)
+	require.NoError(t, err)
+	client.bootstrapURL = srv.URL + "/bootstrap.json"
+
+	info, err := client.lookup("example.org")
+	require.NoError(t, err)
+
+	expected := &domainInfo{
+		source:      protocolRDAP,
+		expiration:  time.Date(2030, 8, 13, 4, 0, 0, 0, time.UTC),
+		registrar:   "Example Registrar, Inc.",
+		nameservers: []string{"a.iana-servers.net", "b.iana-servers.net"},
+		dnssec:      true,
+		status:      []string{"client_delete_prohibited", "client_transfer_prohibited", "client_update_prohibited"},
+	}
+	assert.Equal(t, expected, info)
+
+	_, err = client.lookup("example.co.uk")
+	assert.Error(t, err)
+	_, err = client.lookup("example.com")
+	assert.Error(t, err)
+
+	// the bootstrap registry is cached
+	assert.Equal(t, []string{"/bootstrap.json", "/org/domain/example.org", "/couk/domain/example.co.uk"}, requests)
+}
+
+func TestRDAPClient_Lookup_ServerURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rdap/domain/example.org" {
+			_, _ = w.Write(dataRDAPDomain)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	cfg := New().Config
+	cfg.RDAPServerURL = srv.URL + "/rdap/"
+	client, err := newRDAPClient(cfg)
+	require.NoError(t, err)
+
+	info, err := client.lookup("example.org")
+	require.NoError(t, err)
+	assert.Equal(t, "Example Registrar, Inc.", info.registrar)
+}
+
+func TestFallbackProvider_Lookup(t *testing.T) {
+	info := newTestDomainInfo()
+
+	prov := &fallbackProvider{providers: []provider{
+		&mockProvider{},
+		&mockProvider{infos: map[string]*domainInfo{"example.com": info}},
+	}}
+
+	v, err := prov.lookup("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, info, v)
+
+	_, err = prov.lookup("example.org")
+	assert.Error(t, err)
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	tests := map[string]string{
+		"clientTransferProhibited":   "client_transfer_prohibited",
+		"client transfer prohibited": "client_transfer_prohibited",
+		"serverHold":                 "server_hold",
+		"redemption period":          "redemption_period",
+		"active":                     "ok",
+		"OK":                         "ok",
+		"ACTIVE":                     "ok",
+	}
+
+	for status, want := range tests {
+		t.Run(status, func(t *testing.T) {
+			assert.Equal(t, want, normalizeStatus(status))
+		})
+	}
+}
+
+func newTestDomainInfo() *domainInfo {
+	return &domainInfo{
+		source:      protocolRDAP,
+		expiration:  time.Now().Add(time.Second * 12345),
+		registrar:   "Example Registrar, Inc.",
+		nameservers: []string{"a.iana-servers.net", "b.iana-servers.net"},
+		dnssec:      true,
+		status:      []string{"client_delete_prohibited", "client_transfer_prohibited"},
+	}
+}
+
 type mockProvider struct {
-	remTime float64
-	err     bool
+	infos map[string]*domainInfo
 }
 
-func (m mockProvider) remainingTime() (float64, error) {
-	if m.err {
-		return 0, errors.New("mock remaining time error")
+func (m *mockProvider) lookup(domain string) (*domainInfo, error) {
+	info, ok := m.infos[domain]
+	if !ok {
+		return nil, errors.New("mock lookup error")
 	}
-	return m.remTime, nil
+	return info, nil
 }
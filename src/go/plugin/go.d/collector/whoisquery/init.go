@@ -4,13 +4,18 @@ package whoisquery
 
 import (
 	"errors"
-
-	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"fmt"
+	"strings"
 )
 
 func (c *Collector) validateConfig() error {
-	if c.Source == "" {
-		return errors.New("source is not set")
+	if c.Source == "" && len(c.Domains) == 0 {
+		return errors.New("neither 'source' nor 'domains' is set")
+	}
+	switch c.Protocol {
+	case "", protocolAuto, protocolRDAP, protocolWHOIS:
+	default:
+		return fmt.Errorf("unknown protocol '%s' (supported: %s, %s, %s)", c.Protocol, protocolAuto, protocolRDAP, protocolWHOIS)
 	}
 	return nil
 }
@@ -19,14 +24,18 @@ func (c *Collector) initProvider() (provider, error) {
 	return newProvider(c.Config)
 }
 
-func (c *Collector) initCharts() *module.Charts {
-	charts := baseCharts.Copy()
+func (c *Collector) initDomainNames() []string {
+	var names []string
+	seen := make(map[string]bool)
 
-	for _, chart := range *charts {
-		chart.Labels = []module.Label{
-			{Key: "domain", Value: c.Source},
+	for _, name := range append([]string{c.Source}, c.Domains...) {
+		name = strings.ToLower(strings.Trim(strings.TrimSpace(name), "."))
+		if name == "" || seen[name] {
+			continue
 		}
+		seen[name] = true
+		names = append(names, name)
 	}
 
-	return charts
+	return names
 }
@@ -5,54 +5,117 @@ package whoisquery
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/araddon/dateparse"
 	"github.com/likexian/whois"
 	whoisparser "github.com/likexian/whois-parser"
 )
 
+const (
+	protocolAuto  = "auto"
+	protocolRDAP  = "rdap"
+	protocolWHOIS = "whois"
+)
+
 type provider interface {
-	remainingTime() (float64, error)
+	lookup(domain string) (*domainInfo, error)
 }
 
-type whoisClient struct {
-	domainAddress string
-	client        *whois.Client
+type domainInfo struct {
+	source      string
+	expiration  time.Time
+	registrar   string
+	nameservers []string
+	dnssec      bool
+	status      []string
 }
 
 func newProvider(config Config) (provider, error) {
-	domain := config.Source
+	switch config.Protocol {
+	case protocolWHOIS:
+		return newWhoisClient(config), nil
+	case protocolRDAP:
+		return newRDAPClient(config)
+	case "", protocolAuto:
+		rdap, err := newRDAPClient(config)
+		if err != nil {
+			return nil, err
+		}
+		return &fallbackProvider{providers: []provider{rdap, newWhoisClient(config)}}, nil
+	default:
+		return nil, fmt.Errorf("unknown protocol '%s'", config.Protocol)
+	}
+}
+
+// fallbackProvider queries the providers in order and returns the first successful result.
+type fallbackProvider struct {
+	providers []provider
+}
+
+func (p *fallbackProvider) lookup(domain string) (*domainInfo, error) {
+	var errs []error
+
+	for _, prov := range p.providers {
+		info, err := prov.lookup(domain)
+		if err == nil {
+			return info, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+type whoisClient struct {
+	client *whois.Client
+}
+
+func newWhoisClient(config Config) *whoisClient {
 	client := whois.NewClient()
 	client.SetTimeout(config.Timeout.Duration())
 
-	return &whoisClient{
-		domainAddress: domain,
-		client:        client,
-	}, nil
+	return &whoisClient{client: client}
 }
 
-func (c *whoisClient) remainingTime() (float64, error) {
-	info, err := c.queryWhoisInfo()
+func (c *whoisClient) lookup(domain string) (*domainInfo, error) {
+	info, err := c.queryWhoisInfo(domain)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("whois: %v", err)
 	}
 
-	if info.Domain.ExpirationDate == "" {
-		if !strings.HasPrefix(c.domainAddress, "=") {
-			// some servers support requesting extended data
-			// https://github.com/netdata/netdata/issues/17907#issuecomment-2171758380
-			c.domainAddress = fmt.Sprintf("= %s", c.domainAddress)
-			return c.remainingTime()
+	if info.Domain != nil && info.Domain.ExpirationDate == "" {
+		// some servers support requesting extended data
+		// https://github.com/netdata/netdata/issues/17907#issuecomment-2171758380
+		if v, err := c.queryWhoisInfo(fmt.Sprintf("= %s", domain)); err == nil {
+			info = v
 		}
 	}
 
-	return parseWhoisInfoExpirationDate(info)
+	expiration, err := parseWhoisInfoExpirationDate(info)
+	if err != nil {
+		return nil, fmt.Errorf("whois: %v", err)
+	}
+
+	di := &domainInfo{
+		source:      protocolWHOIS,
+		expiration:  expiration,
+		nameservers: normalizeNameservers(info.Domain.NameServers),
+		dnssec:      info.Domain.DNSSec,
+		status:      normalizeStatuses(info.Domain.Status),
+	}
+	if info.Registrar != nil {
+		di.registrar = info.Registrar.Name
+	}
+
+	return di, nil
 }
 
-func (c *whoisClient) queryWhoisInfo() (*whoisparser.WhoisInfo, error) {
-	resp, err := c.client.Whois(c.domainAddress)
+func (c *whoisClient) queryWhoisInfo(domain string) (*whoisparser.WhoisInfo, error) {
+	resp, err := c.client.Whois(domain)
 	if err != nil {
 		return nil, err
 	}
@@ -65,31 +128,73 @@ func (c *whoisClient) queryWhoisInfo() (*whoisparser.WhoisInfo, error) {
 	return &info, nil
 }
 
-func parseWhoisInfoExpirationDate(info *whoisparser.WhoisInfo) (float64, error) {
+func parseWhoisInfoExpirationDate(info *whoisparser.WhoisInfo) (time.Time, error) {
 	if info == nil || info.Domain == nil {
-		return 0, errors.New("nil Whois Info")
+		return time.Time{}, errors.New("nil Whois Info")
 	}
 
 	if info.Domain.ExpirationDateInTime != nil {
-		return time.Until(*info.Domain.ExpirationDateInTime).Seconds(), nil
+		return *info.Domain.ExpirationDateInTime, nil
 	}
 
 	date := info.Domain.ExpirationDate
 	if date == "" {
-		return 0, errors.New("no expiration date")
+		return time.Time{}, errors.New("no expiration date")
 	}
 
 	if strings.Contains(date, " ") {
 		// https://community.netdata.cloud/t/whois-query-monitor-cannot-parse-expiration-time/3485
 		if v, err := time.Parse("2006.01.02 15:04:05", date); err == nil {
-			return time.Until(v).Seconds(), nil
+			return v, nil
 		}
 	}
 
-	expire, err := dateparse.ParseAny(date)
-	if err != nil {
-		return 0, err
+	return dateparse.ParseAny(date)
+}
+
+func normalizeNameservers(servers []string) []string {
+	var res []string
+	for _, srv := range servers {
+		if srv = strings.ToLower(strings.Trim(strings.TrimSpace(srv), ".")); srv != "" {
+			res = append(res, srv)
+		}
 	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+func normalizeStatuses(statuses []string) []string {
+	var res []string
+	for _, st := range statuses {
+		if st = normalizeStatus(st); st != "" {
+			res = append(res, st)
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
 
-	return time.Until(expire).Seconds(), nil
+// normalizeStatus converts both EPP status codes as returned by WHOIS ("clientTransferProhibited")
+// and RDAP status values ("client transfer prohibited") to snake case ("client_transfer_prohibited").
+func normalizeStatus(status string) string {
+	status = strings.TrimSpace(status)
+
+	var sb strings.Builder
+	var prev rune
+	for i, r := range status {
+		switch {
+		case r == ' ' || r == '-' || r == '_':
+			r = '_'
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(prev):
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+		prev = r
+	}
+
+	if status = sb.String(); status == "active" {
+		// RFC 8056: RDAP "active" maps to EPP "ok"
+		return "ok"
+	}
+	return status
 }
@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package whoisquery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/araddon/dateparse"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+const (
+	// https://www.iana.org/assignments/rdap-dns/rdap-dns.xhtml
+	rdapBootstrapURL          = "https://data.iana.org/rdap/dns.json"
+	rdapBootstrapEvery        = time.Hour * 24
+	rdapContentType           = "application/rdap+json"
+	rdapEventExpiration       = "expiration"
+	rdapEntityRoleRegistrar   = "registrar"
+	rdapVCardPropertyFullName = "fn"
+)
+
+type (
+	// https://datatracker.ietf.org/doc/html/rfc9224#section-4
+	rdapBootstrapResponse struct {
+		Services [][][]string `json:"services"`
+	}
+
+	// https://datatracker.ietf.org/doc/html/rfc9083#section-5.3
+	rdapDomainResponse struct {
+		Status []string `json:"status"`
+		Events []struct {
+			EventAction string `json:"eventAction"`
+			EventDate   string `json:"eventDate"`
+		} `json:"events"`
+		Nameservers []struct {
+			LDHName string `json:"ldhName"`
+		} `json:"nameservers"`
+		SecureDNS *struct {
+			DelegationSigned bool `json:"delegationSigned"`
+		} `json:"secureDNS"`
+		Entities []rdapEntity `json:"entities"`
+	}
+	rdapEntity struct {
+		Roles      []string `json:"roles"`
+		VCardArray []any    `json:"vcardArray"`
+	}
+)
+
+type rdapClient struct {
+	httpClient   *http.Client
+	serverURL    string
+	bootstrapURL string
+
+	mu              sync.Mutex
+	services        map[string]string
+	bootstrapExpiry time.Time
+}
+
+func newRDAPClient(config Config) (*rdapClient, error) {
+	httpClient, err := web.NewHTTPClient(web.ClientConfig{Timeout: config.Timeout})
+	if err != nil {
+		return nil, err
+	}
+
+	if config.RDAPServerURL != "" {
+		if _, err := url.Parse(config.RDAPServerURL); err != nil {
+			return nil, fmt.Errorf("invalid rdap_server_url: %v", err)
+		}
+	}
+
+	return &rdapClient{
+		httpClient:   httpClient,
+		serverURL:    config.RDAPServerURL,
+		bootstrapURL: rdapBootstrapURL,
+	}, nil
+}
+
+func (c *rdapClient) lookup(domain string) (*domainInfo, error) {
+	serverURL, err := c.findServer(domain)
+	if err != nil {
+		return nil, fmt.Errorf("rdap: %v", err)
+	}
+
+	req, err := newRDAPRequest(strings.TrimSuffix(serverURL, "/") + "/domain/" + url.PathEscape(domain))
+	if err != nil {
+		return nil, fmt.Errorf("rdap: %v", err)
+	}
+
+	var resp rdapDomainResponse
+	if err := web.DoHTTP(c.httpClient).RequestJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("rdap: %v", err)
+	}
+
+	info, err := resp.domainInfo()
+	if err != nil {
+		return nil, fmt.Errorf("rdap: %v", err)
+	}
+
+	return info, nil
+}
+
+func (c *rdapClient) findServer(domain string) (string, error) {
+	if c.serverURL != "" {
+		return c.serverURL, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.services == nil || time.Now().After(c.bootstrapExpiry) {
+		services, err := c.queryBootstrap()
+		if err != nil {
+			if c.services == nil {
+				return "", fmt.Errorf("bootstrap: %v", err)
+			}
+			// keep using the previously fetched registry
+		} else {
+			c.services = services
+		}
+		c.bootstrapExpiry = time.Now().Add(rdapBootstrapEvery)
+	}
+
+	// the longest matching label sequence wins (RFC 9224, section 4)
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i := range labels {
+		if v, ok := c.services[strings.Join(labels[i:], ".")]; ok {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("no RDAP service found for '%s'", domain)
+}
+
+func (c *rdapClient) queryBootstrap() (map[string]string, error) {
+	req, err := newRDAPRequest(c.bootstrapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp rdapBootstrapResponse
+	if err := web.DoHTTP(c.httpClient).RequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]string)
+	for _, svc := range resp.Services {
+		if len(svc) != 2 || len(svc[1]) == 0 {
+			continue
+		}
+		// prefer HTTPS if the registry offers several base URLs
+		baseURL := svc[1][0]
+		if i := slices.IndexFunc(svc[1], func(s string) bool { return strings.HasPrefix(s, "https://") }); i > 0 {
+			baseURL = svc[1][i]
+		}
+		for _, tld := range svc[0] {
+			services[strings.ToLower(tld)] = baseURL
+		}
+	}
+
+	if len(services) == 0 {
+		return nil, errors.New("no services in bootstrap registry")
+	}
+
+	return services, nil
+}
+
+func newRDAPRequest(uri string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", rdapContentType)
+	return req, nil
+}
+
+func (r *rdapDomainResponse) domainInfo() (*domainInfo, error) {
+	info := &domainInfo{
+		source: protocolRDAP,
+		status: normalizeStatuses(r.Status),
+		dnssec: r.SecureDNS != nil && r.SecureDNS.DelegationSigned,
+	}
+
+	for _, ev := range r.Events {
+		if ev.EventAction != rdapEventExpiration {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, ev.EventDate)
+		if err != nil {
+			if t, err = dateparse.ParseAny(ev.EventDate); err != nil {
+				return nil, fmt.Errorf("parse expiration date '%s': %v", ev.EventDate, err)
+			}
+		}
+		info.expiration = t
+	}
+	if info.expiration.IsZero() {
+		return nil, errors.New("no expiration date")
+	}
+
+	var nss []string
+	for _, ns := range r.Nameservers {
+		nss = append(nss, ns.LDHName)
+	}
+	info.nameservers = normalizeNameservers(nss)
+
+	for _, ent := range r.Entities {
+		if slices.Contains(ent.Roles, rdapEntityRoleRegistrar) {
+			info.registrar = vCardFullName(ent.VCardArray)
+			break
+		}
+	}
+
+	return info, nil
+}
+
+// vCardFullName returns the "fn" property of a jCard (RFC 7095):
+// ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "Example Registrar"]]]
+func vCardFullName(vcard []any) string {
+	if len(vcard) != 2 {
+		return ""
+	}
+	props, ok := vcard[1].([]any)
+	if !ok {
+		return ""
+	}
+	for _, p := range props {
+		prop, ok := p.([]any)
+		if !ok || len(prop) < 4 {
+			continue
+		}
+		if name, _ := prop[0].(string); name == rdapVCardPropertyFullName {
+			v, _ := prop[3].(string)
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
@@ -4,7 +4,6 @@ package wireguard
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 )
@@ -14,6 +13,8 @@ const (
 	prioDevicePeers
 	prioPeerNetworkIO
 	prioPeerLatestHandShake
+	prioPeerHandshakeStatus
+	prioPeerEndpointChanges
 )
 
 var (
@@ -52,6 +53,8 @@ var (
 	peerChartsTmpl = module.Charts{
 		peerNetworkIOChartTmpl.Copy(),
 		peerLatestHandShakeChartTmpl.Copy(),
+		peerHandshakeStatusChartTmpl.Copy(),
+		peerEndpointChangesChartTmpl.Copy(),
 	}
 
 	peerNetworkIOChartTmpl = module.Chart{
@@ -78,44 +81,68 @@ var (
 			{ID: "peer_%s_latest_handshake_ago", Name: "time"},
 		},
 	}
+	peerHandshakeStatusChartTmpl = module.Chart{
+		ID:       "peer_%s_handshake_status",
+		Title:    "Peer handshake status",
+		Units:    "status",
+		Fam:      "peer latest handshake",
+		Ctx:      "wireguard.peer_handshake_status",
+		Priority: prioPeerHandshakeStatus,
+		Dims: module.Dims{
+			{ID: "peer_%s_handshake_status_fresh", Name: "fresh"},
+			{ID: "peer_%s_handshake_status_stale", Name: "stale"},
+		},
+	}
+	peerEndpointChangesChartTmpl = module.Chart{
+		ID:       "peer_%s_endpoint_changes",
+		Title:    "Peer endpoint changes",
+		Units:    "changes/s",
+		Fam:      "peer endpoint",
+		Ctx:      "wireguard.peer_endpoint_changes",
+		Priority: prioPeerEndpointChanges,
+		Dims: module.Dims{
+			{ID: "peer_%s_endpoint_changes", Name: "changes", Algo: module.Incremental},
+		},
+	}
 )
 
-func newDeviceCharts(device string) *module.Charts {
+func newDeviceCharts(id, device, netns string) *module.Charts {
 	charts := deviceChartsTmpl.Copy()
 
 	for _, c := range *charts {
-		c.ID = fmt.Sprintf(c.ID, device)
+		c.ID = fmt.Sprintf(c.ID, id)
 		c.Labels = []module.Label{
 			{Key: "device", Value: device},
 		}
+		if netns != "" {
+			c.Labels = append(c.Labels, module.Label{Key: "netns", Value: netns})
+		}
 		for _, d := range c.Dims {
-			d.ID = fmt.Sprintf(d.ID, device)
+			d.ID = fmt.Sprintf(d.ID, id)
 		}
 	}
 
 	return charts
 }
 
-func (c *Collector) addNewDeviceCharts(device string) {
-	charts := newDeviceCharts(device)
+func (c *Collector) addNewDeviceCharts(id, device, netns string) {
+	charts := newDeviceCharts(id, device, netns)
 
 	if err := c.Charts().Add(*charts...); err != nil {
 		c.Warning(err)
 	}
 }
 
-func (c *Collector) removeDeviceCharts(device string) {
-	prefix := fmt.Sprintf("device_%s", device)
-
-	for _, c := range *c.Charts() {
-		if strings.HasPrefix(c.ID, prefix) {
-			c.MarkRemove()
-			c.MarkNotCreated()
+func (c *Collector) removeDeviceCharts(id string) {
+	for _, tmpl := range deviceChartsTmpl {
+		if chart := c.Charts().Get(fmt.Sprintf(tmpl.ID, id)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
 		}
 	}
 }
 
-func newPeerCharts(id, device, pubKey string) *module.Charts {
+func newPeerCharts(id, device, netns, pubKey, allowedIPs string) *module.Charts {
 	charts := peerChartsTmpl.Copy()
 
 	for _, c := range *charts {
@@ -123,6 +150,10 @@ func newPeerCharts(id, device, pubKey string) *module.Charts {
 		c.Labels = []module.Label{
 			{Key: "device", Value: device},
 			{Key: "public_key", Value: pubKey},
+			{Key: "allowed_ips", Value: allowedIPs},
+		}
+		if netns != "" {
+			c.Labels = append(c.Labels, module.Label{Key: "netns", Value: netns})
 		}
 		for _, d := range c.Dims {
 			d.ID = fmt.Sprintf(d.ID, id)
@@ -132,8 +163,8 @@ func newPeerCharts(id, device, pubKey string) *module.Charts {
 	return charts
 }
 
-func (c *Collector) addNewPeerCharts(id, device, pubKey string) {
-	charts := newPeerCharts(id, device, pubKey)
+func (c *Collector) addNewPeerCharts(id, device, netns, pubKey, allowedIPs string) {
+	charts := newPeerCharts(id, device, netns, pubKey, allowedIPs)
 
 	if err := c.Charts().Add(*charts...); err != nil {
 		c.Warning(err)
@@ -141,12 +172,10 @@ func (c *Collector) addNewPeerCharts(id, device, pubKey string) {
 }
 
 func (c *Collector) removePeerCharts(id string) {
-	prefix := fmt.Sprintf("peer_%s", id)
-
-	for _, c := range *c.Charts() {
-		if strings.HasPrefix(c.ID, prefix) {
-			c.MarkRemove()
-			c.MarkNotCreated()
+	for _, tmpl := range peerChartsTmpl {
+		if chart := c.Charts().Get(fmt.Sprintf(tmpl.ID, id)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
 		}
 	}
 }
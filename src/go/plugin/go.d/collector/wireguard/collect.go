@@ -3,12 +3,24 @@
 package wireguard
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
+type netnsDevice struct {
+	*wgtypes.Device
+	netns string // empty for the current network namespace
+}
+
 func (c *Collector) collect() (map[string]int64, error) {
 	if c.client == nil {
 		client, err := c.newWGClient()
@@ -25,7 +37,9 @@ func (c *Collector) collect() (map[string]int64, error) {
 		return nil, fmt.Errorf("retrieving WireGuard devices: %v", err)
 	}
 
-	if len(devices) == 0 {
+	allDevices := c.collectNetNSDevices(devices)
+
+	if len(allDevices) == 0 {
 		c.Info("no WireGuard devices found on the host system")
 	}
 
@@ -36,27 +50,91 @@ func (c *Collector) collect() (map[string]int64, error) {
 
 	mx := make(map[string]int64)
 
-	c.collectDevicesPeers(mx, devices, now)
+	c.collectDevicesPeers(mx, allDevices, now)
 
 	if now.Sub(c.cleanupLastTime) > c.cleanupEvery {
 		c.cleanupLastTime = now
-		c.cleanupDevicesPeers(devices)
+		c.cleanupDevicesPeers(allDevices)
 	}
 
 	return mx, nil
 }
 
-func (c *Collector) collectDevicesPeers(mx map[string]int64, devices []*wgtypes.Device, now time.Time) {
+// collectNetNSDevices returns the current network namespace devices followed by the devices
+// of the network namespaces matching 'netns_paths'.
+func (c *Collector) collectNetNSDevices(devices []*wgtypes.Device) []netnsDevice {
+	var all []netnsDevice
+
+	// Userspace implementations (wireguard-go, boringtun) are discovered via UAPI UNIX sockets,
+	// and are visible from every network namespace, so they are deduplicated by name and key.
+	// Kernel devices are per namespace: the same name and key in two namespaces are two devices.
+	type userspaceDevice struct {
+		name   string
+		pubKey wgtypes.Key
+	}
+	seen := make(map[userspaceDevice]bool)
+	add := func(netns string, devices []*wgtypes.Device) {
+		for _, d := range devices {
+			if d.Type == wgtypes.Userspace {
+				k := userspaceDevice{name: d.Name, pubKey: d.PublicKey}
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+			}
+			all = append(all, netnsDevice{Device: d, netns: netns})
+		}
+	}
+
+	add("", devices)
+
+	for _, path := range c.netnsFiles() {
+		devices, err := c.netnsDevices(path)
+		if err != nil {
+			// the namespace may have been deleted after the lookup
+			if !errors.Is(err, fs.ErrNotExist) {
+				c.Warningf("failed to retrieve WireGuard devices in network namespace '%s': %v", path, err)
+			}
+			continue
+		}
+		add(netnsName(path), devices)
+	}
+
+	return all
+}
+
+func (c *Collector) netnsFiles() []string {
+	var files []string
+
+	for _, pattern := range c.NetNSPaths {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			c.Warningf("failed to match network namespace files '%s': %v", pattern, err)
+			continue
+		}
+		for _, path := range matches {
+			if !slices.Contains(files, path) {
+				files = append(files, path)
+			}
+		}
+	}
+
+	return files
+}
+
+func (c *Collector) collectDevicesPeers(mx map[string]int64, devices []netnsDevice, now time.Time) {
 	for _, d := range devices {
-		if !c.devices[d.Name] {
-			c.devices[d.Name] = true
-			c.addNewDeviceCharts(d.Name)
+		devID := deviceID(d.netns, d.Name)
+
+		if !c.devices[devID] {
+			c.devices[devID] = true
+			c.addNewDeviceCharts(devID, d.Name, d.netns)
 		}
 
-		mx["device_"+d.Name+"_peers"] = int64(len(d.Peers))
+		mx["device_"+devID+"_peers"] = int64(len(d.Peers))
 		if len(d.Peers) == 0 {
-			mx["device_"+d.Name+"_receive"] = 0
-			mx["device_"+d.Name+"_transmit"] = 0
+			mx["device_"+devID+"_receive"] = 0
+			mx["device_"+devID+"_transmit"] = 0
 			continue
 		}
 
@@ -66,28 +144,53 @@ func (c *Collector) collectDevicesPeers(mx map[string]int64, devices []*wgtypes.
 			}
 
 			pubKey := p.PublicKey.String()
-			id := peerID(d.Name, pubKey)
+			id := peerID(devID, pubKey)
 
 			if !c.peers[id] {
 				c.peers[id] = true
-				c.addNewPeerCharts(id, d.Name, pubKey)
+				c.addNewPeerCharts(id, d.Name, d.netns, pubKey, peerAllowedIPs(p))
 			}
 
-			mx["device_"+d.Name+"_receive"] += p.ReceiveBytes
-			mx["device_"+d.Name+"_transmit"] += p.TransmitBytes
+			c.trackPeerEndpoint(id, p)
+
+			handshakeAgo := now.Sub(p.LastHandshakeTime)
+			stale := handshakeAgo > c.HandshakeStaleThreshold.Duration()
+
+			mx["device_"+devID+"_receive"] += p.ReceiveBytes
+			mx["device_"+devID+"_transmit"] += p.TransmitBytes
 			mx["peer_"+id+"_receive"] = p.ReceiveBytes
 			mx["peer_"+id+"_transmit"] = p.TransmitBytes
-			mx["peer_"+id+"_latest_handshake_ago"] = int64(now.Sub(p.LastHandshakeTime).Seconds())
+			mx["peer_"+id+"_latest_handshake_ago"] = int64(handshakeAgo.Seconds())
+			mx["peer_"+id+"_handshake_status_fresh"] = metrix.Bool(!stale)
+			mx["peer_"+id+"_handshake_status_stale"] = metrix.Bool(stale)
+			mx["peer_"+id+"_endpoint_changes"] = c.peerEndpointChanges[id]
 		}
 	}
 }
 
-func (c *Collector) cleanupDevicesPeers(devices []*wgtypes.Device) {
+// trackPeerEndpoint counts peer endpoint changes (roaming). The first seen endpoint is not counted.
+func (c *Collector) trackPeerEndpoint(id string, p wgtypes.Peer) {
+	if p.Endpoint == nil {
+		return
+	}
+
+	endpoint := p.Endpoint.String()
+
+	prev, ok := c.peerEndpoints[id]
+	c.peerEndpoints[id] = endpoint
+
+	if ok && prev != endpoint {
+		c.peerEndpointChanges[id]++
+	}
+}
+
+func (c *Collector) cleanupDevicesPeers(devices []netnsDevice) {
 	seenDevices, seenPeers := make(map[string]bool), make(map[string]bool)
 	for _, d := range devices {
-		seenDevices[d.Name] = true
+		devID := deviceID(d.netns, d.Name)
+		seenDevices[devID] = true
 		for _, p := range d.Peers {
-			seenPeers[peerID(d.Name, p.PublicKey.String())] = true
+			seenPeers[peerID(devID, p.PublicKey.String())] = true
 		}
 	}
 	for d := range c.devices {
@@ -99,11 +202,39 @@ func (c *Collector) cleanupDevicesPeers(devices []*wgtypes.Device) {
 	for p := range c.peers {
 		if !seenPeers[p] {
 			delete(c.peers, p)
+			delete(c.peerEndpoints, p)
+			delete(c.peerEndpointChanges, p)
 			c.removePeerCharts(p)
 		}
 	}
 }
 
+// deviceID returns the device ID. Neither interface nor network namespace names can contain '/'.
+func deviceID(netns, device string) string {
+	if netns == "" {
+		return device
+	}
+	return netns + "/" + device
+}
+
 func peerID(device, peerPublicKey string) string {
 	return device + "_" + peerPublicKey
 }
+
+func netnsName(path string) string {
+	// '/proc/<pid>/ns/net'
+	if filepath.Base(path) == "net" && filepath.Base(filepath.Dir(path)) == "ns" {
+		return filepath.Base(filepath.Dir(filepath.Dir(path)))
+	}
+	// '/run/netns/<name>', '/var/run/docker/netns/<id>'
+	return filepath.Base(path)
+}
+
+func peerAllowedIPs(p wgtypes.Peer) string {
+	ips := make([]string, 0, len(p.AllowedIPs))
+	for _, ipNet := range p.AllowedIPs {
+		ips = append(ips, ipNet.String())
+	}
+	slices.Sort(ips)
+	return strings.Join(ips, ",")
+}
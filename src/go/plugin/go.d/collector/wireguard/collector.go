@@ -6,9 +6,11 @@ import (
 	"context"
 	_ "embed"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/confopt"
 
 	"golang.zx2c4.com/wireguard/wgctrl"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -27,16 +29,26 @@ func init() {
 
 func New() *Collector {
 	return &Collector{
-		newWGClient:  func() (wgClient, error) { return wgctrl.New() },
-		charts:       &module.Charts{},
-		devices:      make(map[string]bool),
-		peers:        make(map[string]bool),
-		cleanupEvery: time.Minute,
+		Config: Config{
+			// WireGuard initiates a new handshake every 2 minutes (REKEY_AFTER_TIME)
+			// while the tunnel has traffic, and drops session keys after 3 minutes (REJECT_AFTER_TIME).
+			HandshakeStaleThreshold: confopt.Duration(time.Minute * 3),
+		},
+		newWGClient:         func() (wgClient, error) { return wgctrl.New() },
+		netnsDevices:        devicesInNetNS,
+		charts:              &module.Charts{},
+		devices:             make(map[string]bool),
+		peers:               make(map[string]bool),
+		peerEndpoints:       make(map[string]string),
+		peerEndpointChanges: make(map[string]int64),
+		cleanupEvery:        time.Minute,
 	}
 }
 
 type Config struct {
-	UpdateEvery int `yaml:"update_every,omitempty" json:"update_every"`
+	UpdateEvery             int              `yaml:"update_every,omitempty" json:"update_every"`
+	HandshakeStaleThreshold confopt.Duration `yaml:"handshake_stale_threshold,omitempty" json:"handshake_stale_threshold"`
+	NetNSPaths              []string         `yaml:"netns_paths,omitempty" json:"netns_paths"`
 }
 
 type (
@@ -46,13 +58,16 @@ type (
 
 		charts *module.Charts
 
-		client      wgClient
-		newWGClient func() (wgClient, error)
+		client       wgClient
+		newWGClient  func() (wgClient, error)
+		netnsDevices func(path string) ([]*wgtypes.Device, error)
 
-		cleanupLastTime time.Time
-		cleanupEvery    time.Duration
-		devices         map[string]bool
-		peers           map[string]bool
+		cleanupLastTime     time.Time
+		cleanupEvery        time.Duration
+		devices             map[string]bool
+		peers               map[string]bool
+		peerEndpoints       map[string]string
+		peerEndpointChanges map[string]int64
 	}
 	wgClient interface {
 		Devices() ([]*wgtypes.Device, error)
@@ -65,6 +80,9 @@ func (c *Collector) Configuration() any {
 }
 
 func (c *Collector) Init(context.Context) error {
+	if err := c.validateConfig(); err != nil {
+		return fmt.Errorf("config validation: %v", err)
+	}
 	return nil
 }
 
@@ -6,7 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -37,7 +39,46 @@ func TestCollector_ConfigurationSerialize(t *testing.T) {
 }
 
 func TestCollector_Init(t *testing.T) {
-	assert.NoError(t, New().Init(context.Background()))
+	tests := map[string]struct {
+		config   Config
+		wantFail bool
+	}{
+		"success with default config": {
+			wantFail: false,
+			config:   New().Config,
+		},
+		"success with network namespaces": {
+			wantFail: false,
+			config: Config{
+				HandshakeStaleThreshold: New().HandshakeStaleThreshold,
+				NetNSPaths:              []string{"/run/netns/*"},
+			},
+		},
+		"fail when handshake stale threshold is not set": {
+			wantFail: true,
+			config:   Config{},
+		},
+		"fail when network namespace pattern is bad": {
+			wantFail: true,
+			config: Config{
+				HandshakeStaleThreshold: New().HandshakeStaleThreshold,
+				NetNSPaths:              []string{"/run/netns/["},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collr := New()
+			collr.Config = test.config
+
+			if test.wantFail {
+				assert.Error(t, collr.Init(context.Background()))
+			} else {
+				assert.NoError(t, collr.Init(context.Background()))
+			}
+		})
+	}
 }
 
 func TestCollector_Charts(t *testing.T) {
@@ -199,18 +240,30 @@ func TestCollector_Collect(t *testing.T) {
 						"device_wg2_peers":    2,
 						"device_wg2_receive":  0,
 						"device_wg2_transmit": 0,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago": 60,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":              0,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":             0,
-						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago": 60,
-						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":              0,
-						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":             0,
-						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago": 60,
-						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":              0,
-						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":             0,
-						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago": 60,
-						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":              0,
-						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":             0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg2_cGVlcjIxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg2_cGVlcjIyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
 					}
 
 					copyLatestHandshake(mx, expected)
@@ -236,12 +289,18 @@ func TestCollector_Collect(t *testing.T) {
 						"device_wg1_peers":    4,
 						"device_wg1_receive":  0,
 						"device_wg1_transmit": 0,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago": 60,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":              0,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":             0,
-						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago": 60,
-						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":              0,
-						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":             0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
 					}
 
 					copyLatestHandshake(mx, expected)
@@ -250,6 +309,94 @@ func TestCollector_Collect(t *testing.T) {
 				},
 			},
 		},
+		"peer with stale handshake": {
+			{
+				prepareMock: func(m *mockClient) {
+					d1 := prepareDevice(1)
+					d1.Peers = append(d1.Peers, preparePeer("11"))
+					d1.Peers = append(d1.Peers, prepareStalePeer("12"))
+					m.devices = append(m.devices, d1)
+				},
+				check: func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+
+					expected := map[string]int64{
+						"device_wg1_peers":    2,
+						"device_wg1_receive":  0,
+						"device_wg1_transmit": 0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 1,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   300,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg1_cGVlcjEyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+					}
+
+					copyLatestHandshake(mx, expected)
+					assert.Equal(t, expected, mx)
+					assert.Equal(t, len(deviceChartsTmpl)+len(peerChartsTmpl)*2, len(*collr.Charts()))
+				},
+			},
+		},
+		"peer endpoint changed": {
+			{
+				prepareMock: func(m *mockClient) {
+					d1 := prepareDevice(1)
+					p := preparePeer("11")
+					p.Endpoint = &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 51820}
+					d1.Peers = append(d1.Peers, p)
+					m.devices = append(m.devices, d1)
+				},
+				check: func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+					assert.Equal(t, int64(0), mx["peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes"])
+				},
+			},
+			{
+				prepareMock: func(m *mockClient) {
+					m.devices[0].Peers[0].Endpoint = &net.UDPAddr{IP: net.ParseIP("198.51.100.1"), Port: 40000}
+				},
+				check: func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+					assert.Equal(t, int64(1), mx["peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes"])
+				},
+			},
+			{
+				prepareMock: func(m *mockClient) {
+					m.devices[0].Peers[0].Endpoint = nil
+				},
+				check: func(t *testing.T, collr *Collector) {
+					mx := collr.Collect(context.Background())
+					assert.Equal(t, int64(1), mx["peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes"])
+				},
+			},
+		},
+		"peer allowed ips label": {
+			{
+				prepareMock: func(m *mockClient) {
+					d1 := prepareDevice(1)
+					p := preparePeer("11")
+					_, ipNet1, _ := net.ParseCIDR("10.0.1.0/24")
+					_, ipNet2, _ := net.ParseCIDR("10.0.0.2/32")
+					p.AllowedIPs = []net.IPNet{*ipNet1, *ipNet2}
+					d1.Peers = append(d1.Peers, p)
+					m.devices = append(m.devices, d1)
+				},
+				check: func(t *testing.T, collr *Collector) {
+					_ = collr.Collect(context.Background())
+
+					chart := collr.Charts().Get("peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status")
+					require.NotNil(t, chart)
+					assert.Contains(t, chart.Labels, module.Label{Key: "allowed_ips", Value: "10.0.0.2/32,10.0.1.0/24"})
+				},
+			},
+		},
 		"device added at runtime": {
 			{
 				prepareMock: func(m *mockClient) {
@@ -348,9 +495,12 @@ func TestCollector_Collect(t *testing.T) {
 						"device_wg1_peers":    1,
 						"device_wg1_receive":  0,
 						"device_wg1_transmit": 0,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago": 60,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":              0,
-						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":             0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+						"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
 					}
 					copyLatestHandshake(mx, expected)
 					assert.Equal(t, expected, mx)
@@ -445,6 +595,123 @@ func TestCollector_Collect(t *testing.T) {
 	}
 }
 
+func TestCollector_Collect_NetNS(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"tenant1", "tenant2"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	collr := New()
+	collr.NetNSPaths = []string{filepath.Join(dir, "*")}
+	require.NoError(t, collr.Init(context.Background()))
+
+	hostDev := prepareDevice(1)
+	hostDev.Type = wgtypes.Userspace
+	hostDev.Peers = append(hostDev.Peers, preparePeer("11"))
+	collr.client = &mockClient{devices: []*wgtypes.Device{hostDev}}
+
+	// kernel devices with the same name and key in different namespaces (e.g. cloned containers)
+	clonedDev := func() *wgtypes.Device {
+		d := prepareDevice(7)
+		d.Type = wgtypes.LinuxKernel
+		d.PublicKey = preparePeer("c7").PublicKey
+		return d
+	}
+
+	collr.netnsDevices = func(path string) ([]*wgtypes.Device, error) {
+		switch filepath.Base(path) {
+		case "tenant1":
+			d := prepareDevice(0)
+			d.PublicKey = preparePeer("t1").PublicKey
+			d.Peers = append(d.Peers, preparePeer("01"))
+			return []*wgtypes.Device{d, clonedDev()}, nil
+		case "tenant2":
+			// a userspace device is visible from every network namespace
+			d := prepareDevice(0)
+			d.PublicKey = preparePeer("t2").PublicKey
+			d.Peers = append(d.Peers, preparePeer("02"))
+			return []*wgtypes.Device{d, hostDev, clonedDev()}, nil
+		default:
+			return nil, errors.New("unexpected network namespace")
+		}
+	}
+
+	mx := collr.Collect(context.Background())
+
+	expected := map[string]int64{
+		"device_tenant1/wg0_peers":    1,
+		"device_tenant1/wg0_receive":  0,
+		"device_tenant1/wg0_transmit": 0,
+		"device_tenant1/wg7_peers":    0,
+		"device_tenant1/wg7_receive":  0,
+		"device_tenant1/wg7_transmit": 0,
+		"device_tenant2/wg0_peers":    1,
+		"device_tenant2/wg0_receive":  0,
+		"device_tenant2/wg0_transmit": 0,
+		"device_tenant2/wg7_peers":    0,
+		"device_tenant2/wg7_receive":  0,
+		"device_tenant2/wg7_transmit": 0,
+		"device_wg1_peers":            1,
+		"device_wg1_receive":          0,
+		"device_wg1_transmit":         0,
+		"peer_tenant1/wg0_cGVlcjAxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+		"peer_tenant1/wg0_cGVlcjAxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+		"peer_tenant1/wg0_cGVlcjAxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+		"peer_tenant1/wg0_cGVlcjAxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+		"peer_tenant1/wg0_cGVlcjAxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+		"peer_tenant1/wg0_cGVlcjAxAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+		"peer_tenant2/wg0_cGVlcjAyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":       0,
+		"peer_tenant2/wg0_cGVlcjAyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh": 1,
+		"peer_tenant2/wg0_cGVlcjAyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale": 0,
+		"peer_tenant2/wg0_cGVlcjAyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":   60,
+		"peer_tenant2/wg0_cGVlcjAyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                0,
+		"peer_tenant2/wg0_cGVlcjAyAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":               0,
+		"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_endpoint_changes":               0,
+		"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_fresh":         1,
+		"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_handshake_status_stale":         0,
+		"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_latest_handshake_ago":           60,
+		"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_receive":                        0,
+		"peer_wg1_cGVlcjExAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=_transmit":                       0,
+	}
+
+	copyLatestHandshake(mx, expected)
+	assert.Equal(t, expected, mx)
+	assert.Equal(t, len(deviceChartsTmpl)*5+len(peerChartsTmpl)*3, len(*collr.Charts()))
+	module.TestMetricsHasAllChartsDims(t, collr.Charts(), mx)
+
+	chart := collr.Charts().Get("device_tenant1/wg0_peers")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "netns", Value: "tenant1"})
+	assert.Contains(t, chart.Labels, module.Label{Key: "device", Value: "wg0"})
+
+	chart = collr.Charts().Get("device_wg1_peers")
+	require.NotNil(t, chart)
+	for _, l := range chart.Labels {
+		assert.NotEqual(t, "netns", l.Key)
+	}
+}
+
+func TestCollector_Collect_DeviceRemovedByExactID(t *testing.T) {
+	collr := New()
+	require.NoError(t, collr.Init(context.Background()))
+	m := &mockClient{devices: []*wgtypes.Device{{Name: "wg"}, {Name: "wg_1"}}}
+	collr.client = m
+
+	require.NotNil(t, collr.Collect(context.Background()))
+	require.True(t, collr.Charts().Has("device_wg_peers"))
+	require.True(t, collr.Charts().Has("device_wg_1_peers"))
+
+	m.devices = m.devices[1:]
+	collr.cleanupLastTime = time.Now().Add(-collr.cleanupEvery * 2)
+
+	require.NotNil(t, collr.Collect(context.Background()))
+
+	for _, chart := range *collr.Charts() {
+		removed := chart.ID == "device_wg_network_io" || chart.ID == "device_wg_peers"
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", chart.ID)
+	}
+}
+
 type mockClient struct {
 	devices      []*wgtypes.Device
 	errOnDevices bool
@@ -482,6 +749,12 @@ func preparePeer(s string) wgtypes.Peer {
 	}
 }
 
+func prepareStalePeer(s string) wgtypes.Peer {
+	p := preparePeer(s)
+	p.LastHandshakeTime = time.Now().Add(-time.Minute * 5)
+	return p
+}
+
 func prepareNoLastHandshakePeer(s string) wgtypes.Peer {
 	p := preparePeer(s)
 	var lh time.Time
@@ -492,7 +765,7 @@ func prepareNoLastHandshakePeer(s string) wgtypes.Peer {
 func copyLatestHandshake(dst, src map[string]int64) {
 	for k, v := range src {
 		if strings.HasSuffix(k, "latest_handshake_ago") {
-			if _, ok := dst[k]; ok {
+			if v2, ok := dst[k]; ok && v2-v <= 1 && v-v2 <= 1 {
 				dst[k] = v
 			}
 		}
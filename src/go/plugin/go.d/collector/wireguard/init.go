@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package wireguard
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+func (c *Collector) validateConfig() error {
+	if c.HandshakeStaleThreshold.Duration() <= 0 {
+		return errors.New("'handshake_stale_threshold' must be positive")
+	}
+	for _, pattern := range c.NetNSPaths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad 'netns_paths' pattern '%s': %v", pattern, err)
+		}
+	}
+	return nil
+}
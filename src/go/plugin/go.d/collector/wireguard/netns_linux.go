@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+
+package wireguard
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// devicesInNetNS returns WireGuard devices of the network namespace referred to by path
+// (e.g. '/run/netns/tenant1', '/proc/<pid>/ns/net'). Entering a namespace requires CAP_SYS_ADMIN.
+func devicesInNetNS(path string) ([]*wgtypes.Device, error) {
+	type result struct {
+		devices []*wgtypes.Device
+		err     error
+	}
+
+	ch := make(chan result, 1)
+
+	go func() {
+		// The thread is never unlocked: once it has entered another network namespace
+		// it must not be reused by other goroutines, and the runtime terminates it when this goroutine exits.
+		runtime.LockOSThread()
+
+		devices, err := func() ([]*wgtypes.Device, error) {
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer func() { _ = f.Close() }()
+
+			if err := unix.Setns(int(f.Fd()), unix.CLONE_NEWNET); err != nil {
+				return nil, fmt.Errorf("entering network namespace: %v", err)
+			}
+
+			// the netlink socket is created in the network namespace of the calling thread
+			client, err := wgctrl.New()
+			if err != nil {
+				return nil, fmt.Errorf("creating WireGuard client: %v", err)
+			}
+			defer func() { _ = client.Close() }()
+
+			return client.Devices()
+		}()
+
+		ch <- result{devices: devices, err: err}
+	}()
+
+	res := <-ch
+
+	return res.devices, res.err
+}
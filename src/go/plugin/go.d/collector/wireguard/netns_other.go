@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !linux
+
+package wireguard
+
+import (
+	"errors"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func devicesInNetNS(string) ([]*wgtypes.Device, error) {
+	return nil, errors.New("network namespaces are supported only on Linux")
+}
@@ -19,6 +19,18 @@ const (
 	prioZpoolSpaceUsage
 
 	prioZpoolFragmentation
+
+	prioZpoolScanState
+	prioZpoolScanProgress
+	prioZpoolScanETA
+	prioZpoolErrors
+
+	prioVdevErrors
+	prioVdevLatency
+	prioVdevDiskLatency
+	prioVdevQueueLatency
+	prioVdevQueuePending
+	prioVdevQueueActive
 )
 
 var zpoolChartsTmpl = module.Charts{
@@ -28,6 +40,11 @@ var zpoolChartsTmpl = module.Charts{
 	zpoolSpaceUsageChartTmpl.Copy(),
 
 	zpoolFragmentationChartTmpl.Copy(),
+
+	zpoolScanStateChartTmpl.Copy(),
+	zpoolScanProgressChartTmpl.Copy(),
+	zpoolScanETAChartTmpl.Copy(),
+	zpoolErrorsChartTmpl.Copy(),
 }
 
 var (
@@ -88,10 +105,72 @@ var (
 			{ID: "zpool_%s_frag", Name: "fragmentation"},
 		},
 	}
+
+	zpoolScanStateChartTmpl = module.Chart{
+		ID:       "zfspool_%s_scan_state",
+		Title:    "Zpool scan state",
+		Units:    "state",
+		Fam:      "scan",
+		Ctx:      "zfspool.pool_scan_state",
+		Type:     module.Line,
+		Priority: prioZpoolScanState,
+		Dims: module.Dims{
+			{ID: "zpool_%s_scan_state_idle", Name: "idle"},
+			{ID: "zpool_%s_scan_state_scrub", Name: "scrub"},
+			{ID: "zpool_%s_scan_state_resilver", Name: "resilver"},
+			{ID: "zpool_%s_scan_state_scrub_paused", Name: "scrub_paused"},
+		},
+	}
+	zpoolScanProgressChartTmpl = module.Chart{
+		ID:       "zfspool_%s_scan_progress",
+		Title:    "Zpool scan progress",
+		Units:    "percentage",
+		Fam:      "scan",
+		Ctx:      "zfspool.pool_scan_progress",
+		Type:     module.Line,
+		Priority: prioZpoolScanProgress,
+		Dims: module.Dims{
+			{ID: "zpool_%s_scan_progress", Name: "progress", Div: 100},
+		},
+	}
+	zpoolScanETAChartTmpl = module.Chart{
+		ID:       "zfspool_%s_scan_eta",
+		Title:    "Zpool scan estimated time to completion",
+		Units:    "seconds",
+		Fam:      "scan",
+		Ctx:      "zfspool.pool_scan_eta",
+		Type:     module.Line,
+		Priority: prioZpoolScanETA,
+		Dims: module.Dims{
+			{ID: "zpool_%s_scan_eta", Name: "eta"},
+		},
+	}
+	zpoolErrorsChartTmpl = module.Chart{
+		ID:       "zfspool_%s_errors",
+		Title:    "Zpool errors",
+		Units:    "errors",
+		Fam:      "errors",
+		Ctx:      "zfspool.pool_errors",
+		Type:     module.Line,
+		Priority: prioZpoolErrors,
+		Dims: module.Dims{
+			{ID: "zpool_%s_data_errors", Name: "data"},
+			{ID: "zpool_%s_scan_errors", Name: "last_scan"},
+		},
+	}
 )
 
 var vdevChartsTmpl = module.Charts{
 	vdevHealthStateChartTmpl.Copy(),
+
+	vdevErrorsChartTmpl.Copy(),
+
+	vdevLatencyChartTmpl.Copy(),
+	vdevDiskLatencyChartTmpl.Copy(),
+	vdevQueueLatencyChartTmpl.Copy(),
+
+	vdevQueuePendingChartTmpl.Copy(),
+	vdevQueueActiveChartTmpl.Copy(),
 }
 
 var (
@@ -113,6 +192,94 @@ var (
 			{ID: "vdev_%s_health_state_suspended", Name: "suspended"},
 		},
 	}
+
+	vdevErrorsChartTmpl = module.Chart{
+		ID:       "vdev_%s_errors",
+		Title:    "Zpool Vdev errors",
+		Units:    "errors",
+		Fam:      "errors",
+		Ctx:      "zfspool.vdev_errors",
+		Type:     module.Line,
+		Priority: prioVdevErrors,
+		Dims: module.Dims{
+			{ID: "vdev_%s_read_errors", Name: "read"},
+			{ID: "vdev_%s_write_errors", Name: "write"},
+			{ID: "vdev_%s_checksum_errors", Name: "checksum"},
+		},
+	}
+
+	vdevLatencyChartTmpl = module.Chart{
+		ID:       "vdev_%s_latency",
+		Title:    "Zpool Vdev total I/O latency",
+		Units:    "milliseconds",
+		Fam:      "latency",
+		Ctx:      "zfspool.vdev_latency",
+		Type:     module.Line,
+		Priority: prioVdevLatency,
+		Dims: module.Dims{
+			{ID: "vdev_%s_total_wait_read", Name: "read", Div: 1e6},
+			{ID: "vdev_%s_total_wait_write", Name: "write", Div: 1e6},
+		},
+	}
+	vdevDiskLatencyChartTmpl = module.Chart{
+		ID:       "vdev_%s_disk_latency",
+		Title:    "Zpool Vdev disk I/O latency",
+		Units:    "milliseconds",
+		Fam:      "latency",
+		Ctx:      "zfspool.vdev_disk_latency",
+		Type:     module.Line,
+		Priority: prioVdevDiskLatency,
+		Dims: module.Dims{
+			{ID: "vdev_%s_disk_wait_read", Name: "read", Div: 1e6},
+			{ID: "vdev_%s_disk_wait_write", Name: "write", Div: 1e6},
+		},
+	}
+	vdevQueueLatencyChartTmpl = module.Chart{
+		ID:       "vdev_%s_queue_latency",
+		Title:    "Zpool Vdev I/O queue latency",
+		Units:    "milliseconds",
+		Fam:      "latency",
+		Ctx:      "zfspool.vdev_queue_latency",
+		Type:     module.Line,
+		Priority: prioVdevQueueLatency,
+		Dims: module.Dims{
+			{ID: "vdev_%s_syncq_wait_read", Name: "sync_read", Div: 1e6},
+			{ID: "vdev_%s_syncq_wait_write", Name: "sync_write", Div: 1e6},
+			{ID: "vdev_%s_asyncq_wait_read", Name: "async_read", Div: 1e6},
+			{ID: "vdev_%s_asyncq_wait_write", Name: "async_write", Div: 1e6},
+		},
+	}
+
+	vdevQueuePendingChartTmpl = module.Chart{
+		ID:       "vdev_%s_queue_pending",
+		Title:    "Zpool Vdev pending I/O requests",
+		Units:    "requests",
+		Fam:      "queue",
+		Ctx:      "zfspool.vdev_queue_pending",
+		Type:     module.Line,
+		Priority: prioVdevQueuePending,
+		Dims: module.Dims{
+			{ID: "vdev_%s_syncq_read_pend", Name: "sync_read"},
+			{ID: "vdev_%s_syncq_write_pend", Name: "sync_write"},
+			{ID: "vdev_%s_asyncq_read_pend", Name: "async_read"},
+			{ID: "vdev_%s_asyncq_write_pend", Name: "async_write"},
+		},
+	}
+	vdevQueueActiveChartTmpl = module.Chart{
+		ID:       "vdev_%s_queue_active",
+		Title:    "Zpool Vdev active I/O requests",
+		Units:    "requests",
+		Fam:      "queue",
+		Ctx:      "zfspool.vdev_queue_active",
+		Type:     module.Line,
+		Priority: prioVdevQueueActive,
+		Dims: module.Dims{
+			{ID: "vdev_%s_syncq_read_activ", Name: "sync_read"},
+			{ID: "vdev_%s_syncq_write_activ", Name: "sync_write"},
+			{ID: "vdev_%s_asyncq_read_activ", Name: "async_read"},
+			{ID: "vdev_%s_asyncq_write_activ", Name: "async_write"},
+		},
+	}
 )
 
 func (c *Collector) addZpoolCharts(name string) {
@@ -24,6 +24,12 @@ func (c *Collector) collect() (map[string]int64, error) {
 	if err := c.collectZpoolListVdev(mx); err != nil {
 		return mx, err
 	}
+	if err := c.collectZpoolStatus(mx); err != nil {
+		return mx, err
+	}
+	if err := c.collectZpoolIostat(mx); err != nil {
+		return mx, err
+	}
 
 	return mx, nil
 }
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package zfspool
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// zpoolIostatColumns are the latency (nanoseconds) and queue depth columns collected from 'zpool iostat -l -q'.
+var zpoolIostatColumns = []string{
+	"total_wait_read",
+	"total_wait_write",
+	"disk_wait_read",
+	"disk_wait_write",
+	"syncq_wait_read",
+	"syncq_wait_write",
+	"asyncq_wait_read",
+	"asyncq_wait_write",
+	"syncq_read_pend",
+	"syncq_read_activ",
+	"syncq_write_pend",
+	"syncq_write_activ",
+	"asyncq_read_pend",
+	"asyncq_read_activ",
+	"asyncq_write_pend",
+	"asyncq_write_activ",
+}
+
+type vdevIostatEntry struct {
+	vdevEntry
+	values map[string]string
+}
+
+func (c *Collector) collectZpoolIostat(mx map[string]int64) error {
+	if len(c.seenVdevs) == 0 {
+		return nil
+	}
+
+	bs, err := c.exec.iostat()
+	if err != nil {
+		return err
+	}
+
+	vdevs, err := parseZpoolIostatOutput(bs)
+	if err != nil {
+		return fmt.Errorf("bad zpool iostat output: %v", err)
+	}
+
+	for _, vdev := range vdevs {
+		if !c.seenVdevs[vdev.vdev] {
+			continue
+		}
+
+		px := fmt.Sprintf("vdev_%s_", vdev.vdev)
+
+		for _, col := range zpoolIostatColumns {
+			// '-' means no I/O during the sample interval
+			v, _ := parseInt(vdev.values[col])
+			mx[px+col] = v
+		}
+	}
+
+	return nil
+}
+
+func parseZpoolIostatOutput(bs []byte) ([]vdevIostatEntry, error) {
+	/*
+	   # zpool iostat -v -l -q -p -L -y 1 1
+	                  capacity     operations     bandwidth    total_wait     disk_wait    syncq_wait    asyncq_wait  scrub   trim  syncq_read    syncq_write   asyncq_read  asyncq_write   scrubq_read   trimq_write
+	   pool         alloc   free   read  write   read  write   read  write   read  write   read  write   read  write   wait   wait   pend  activ   pend  activ   pend  activ   pend  activ   pend  activ   pend  activ
+	   -----------  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----
+	   rpool          ...
+	     mirror-0     ...
+	       nvme2n1p3  ...
+	   -----------  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----  -----
+	*/
+
+	var groups, headers []string
+	var vdevs []vdevIostatEntry
+	var block []vdevIostatEntry
+
+	flush := func() error {
+		defer func() { block = nil }()
+		if len(block) < 2 {
+			return nil
+		}
+		nodes := make([]*vdevEntry, len(block))
+		for i := range block {
+			nodes[i] = &block[i].vdevEntry
+		}
+		if err := resolveVdevPaths(nodes); err != nil {
+			return err
+		}
+		// first is Pool
+		vdevs = append(vdevs, block[1:]...)
+		return nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if len(headers) == 0 {
+			switch {
+			case len(groups) == 0:
+				groups = strings.Fields(line)
+			default:
+				var err error
+				if headers, err = zpoolIostatHeaders(groups, strings.Fields(line)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "-") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		values := strings.Fields(line)
+		if len(values) == 0 || len(values) > len(headers) {
+			return nil, fmt.Errorf("unexpected columns: headers(%d)  values(%d) (line '%s')", len(headers), len(values), line)
+		}
+
+		vdev := vdevIostatEntry{
+			vdevEntry: vdevEntry{
+				name:  values[0],
+				level: len(line) - len(strings.TrimLeft(line, " ")),
+			},
+			values: make(map[string]string),
+		}
+		if len(block) == 0 {
+			vdev.level = -1 // Pool
+		}
+		for i, v := range values[1:] {
+			vdev.values[headers[i+1]] = v
+		}
+
+		block = append(block, vdev)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(vdevs) == 0 {
+		return nil, errors.New("no vdevs found")
+	}
+
+	return vdevs, nil
+}
+
+// zpoolIostatHeaders combines the two header lines (column groups and their columns) into column names,
+// e.g. "total_wait" and "read" into "total_wait_read". The set of groups depends on the OpenZFS version.
+func zpoolIostatHeaders(groups, columns []string) ([]string, error) {
+	if len(columns) == 0 || (columns[0] != "pool" && columns[0] != "NAME") {
+		return nil, fmt.Errorf("missing headers (columns '%s')", strings.Join(columns, " "))
+	}
+
+	headers := []string{"name"}
+	i := 1
+
+	for _, group := range groups {
+		// single-column groups are scan-like waits: "scrub", "trim", "rebuild", "initialize"
+		n := 1
+		switch group {
+		case "capacity", "operations", "bandwidth":
+			n = 2
+		default:
+			if strings.Contains(group, "_") {
+				n = 2
+			}
+		}
+		if i+n > len(columns) {
+			return nil, fmt.Errorf("unexpected headers: groups(%d) columns(%d)", len(groups), len(columns))
+		}
+		for _, col := range columns[i : i+n] {
+			headers = append(headers, group+"_"+col)
+		}
+		i += n
+	}
+
+	if i != len(columns) {
+		return nil, fmt.Errorf("unexpected headers: groups(%d) columns(%d)", len(groups), len(columns))
+	}
+
+	return headers, nil
+}
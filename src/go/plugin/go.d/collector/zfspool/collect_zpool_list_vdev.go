@@ -110,10 +110,25 @@ func parseZpoolListVdevOutput(bs []byte) ([]vdevEntry, error) {
 		}
 	}
 
-	// set parent/child relationships
+	nodes := make([]*vdevEntry, len(vdevs))
 	for i := range vdevs {
-		v := &vdevs[i]
+		nodes[i] = &vdevs[i]
+	}
+	if err := resolveVdevPaths(nodes); err != nil {
+		return nil, err
+	}
+
+	// first is Pool
+	if len(vdevs) < 2 {
+		return nil, fmt.Errorf("no vdevs found")
+	}
+
+	return vdevs[1:], nil
+}
 
+// resolveVdevPaths sets the full path of each vdev within the zpool hierarchy. The first entry is the pool.
+func resolveVdevPaths(vdevs []*vdevEntry) error {
+	for i, v := range vdevs {
 		switch i {
 		case 0:
 			v.vdev = v.name
@@ -126,15 +141,9 @@ func parseZpoolListVdevOutput(bs []byte) ([]vdevEntry, error) {
 				}
 			}
 			if v.vdev == "" {
-				return nil, fmt.Errorf("no parent for vdev '%s'", v.name)
+				return fmt.Errorf("no parent for vdev '%s'", v.name)
 			}
 		}
 	}
-
-	// first is Pool
-	if len(vdevs) < 2 {
-		return nil, fmt.Errorf("no vdevs found")
-	}
-
-	return vdevs[1:], nil
+	return nil
 }
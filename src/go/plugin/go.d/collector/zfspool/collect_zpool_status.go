@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package zfspool
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var zpoolScanStates = []string{
+	"idle",
+	"scrub",
+	"resilver",
+	"scrub_paused",
+}
+
+type zpoolStatusEntry struct {
+	scanState    string
+	scanProgress string // percentage done, set only while a scan is in progress
+	scanETA      int64  // seconds, 0 if unknown
+	scanErrors   string // errors found by the last completed scan
+	dataErrors   int64
+	vdevs        []vdevStatusEntry
+}
+
+type vdevStatusEntry struct {
+	vdevEntry
+	readErrors  string
+	writeErrors string
+	cksumErrors string
+}
+
+var (
+	reScanProgress = regexp.MustCompile(`([\d.]+)% done`)
+	reScanETA      = regexp.MustCompile(`(?:(\d+) days )?(\d+):(\d+):(\d+) to go`)
+	reScanErrors   = regexp.MustCompile(`with (\d+) errors`)
+	reDataErrors   = regexp.MustCompile(`^(\d+) data errors`)
+)
+
+func (c *Collector) collectZpoolStatus(mx map[string]int64) error {
+	for pool := range c.seenZpools {
+		bs, err := c.exec.status(pool)
+		if err != nil {
+			return err
+		}
+
+		st, err := parseZpoolStatusOutput(bs)
+		if err != nil {
+			return fmt.Errorf("bad zpool status output (pool '%s'): %v", pool, err)
+		}
+
+		px := "zpool_" + pool + "_"
+
+		for _, s := range zpoolScanStates {
+			mx[px+"scan_state_"+s] = 0
+		}
+		mx[px+"scan_state_"+st.scanState] = 1
+
+		mx[px+"scan_progress"] = 0
+		if v, ok := parseFloat(st.scanProgress); ok {
+			mx[px+"scan_progress"] = int64(v * 100)
+		}
+		mx[px+"scan_eta"] = st.scanETA
+		mx[px+"scan_errors"] = 0
+		if v, ok := parseInt(st.scanErrors); ok {
+			mx[px+"scan_errors"] = v
+		}
+		mx[px+"data_errors"] = st.dataErrors
+
+		for _, vdev := range st.vdevs {
+			if !c.seenVdevs[vdev.vdev] {
+				continue
+			}
+
+			px := fmt.Sprintf("vdev_%s_", vdev.vdev)
+
+			if v, ok := parseInt(vdev.readErrors); ok {
+				mx[px+"read_errors"] = v
+			}
+			if v, ok := parseInt(vdev.writeErrors); ok {
+				mx[px+"write_errors"] = v
+			}
+			if v, ok := parseInt(vdev.cksumErrors); ok {
+				mx[px+"checksum_errors"] = v
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseZpoolStatusOutput(bs []byte) (*zpoolStatusEntry, error) {
+	/*
+	   # zpool status -p -L rpool
+	     pool: rpool
+	    state: DEGRADED
+	     scan: scrub in progress since Sun Oct 13 00:24:01 2024
+	           1352345600000 / 3276543000000 scanned at 312345678/s, 845123456789 / 3276543000000 issued at 214345678/s
+	           0 repaired, 27.66% done, 02:55:12 to go
+	   config:
+
+	           NAME           STATE     READ WRITE CKSUM
+	           rpool          DEGRADED     0     0     0
+	             mirror-0     DEGRADED     0     0     0
+	               nvme2n1p3  ONLINE       0     0     0
+	               nvme0n1p3  DEGRADED     0     0    27  too many errors
+
+	   errors: No known data errors
+	*/
+
+	st := &zpoolStatusEntry{scanState: "idle"}
+
+	var section string
+	var scan strings.Builder
+	var headers []string
+	var vdevs []vdevStatusEntry
+
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "\t") {
+			key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+			if !ok {
+				continue
+			}
+			section, value = key, strings.TrimSpace(value)
+
+			switch section {
+			case "scan":
+				scan.WriteString(value)
+			case "errors":
+				if m := reDataErrors.FindStringSubmatch(value); m != nil {
+					st.dataErrors, _ = strconv.ParseInt(m[1], 10, 64)
+				}
+			}
+			continue
+		}
+
+		switch section {
+		case "scan":
+			scan.WriteString(" " + strings.TrimSpace(line))
+		case "config":
+			line = line[1:]
+
+			if len(headers) == 0 {
+				if !strings.HasPrefix(line, "NAME") {
+					return nil, fmt.Errorf("missing config headers (line '%s')", line)
+				}
+				headers = strings.Fields(line)
+				continue
+			}
+
+			values := strings.Fields(line)
+
+			vdev := vdevStatusEntry{
+				vdevEntry: vdevEntry{
+					name:  values[0],
+					level: len(line) - len(strings.TrimLeft(line, " ")),
+				},
+			}
+			if len(vdevs) == 0 {
+				vdev.level = -1 // Pool
+			}
+
+			for i, v := range values {
+				if i >= len(headers) {
+					break // trailing notes, e.g. "too many errors"
+				}
+				switch strings.ToLower(headers[i]) {
+				case "state":
+					vdev.health = strings.ToLower(v)
+				case "read":
+					vdev.readErrors = v
+				case "write":
+					vdev.writeErrors = v
+				case "cksum":
+					vdev.cksumErrors = v
+				}
+			}
+
+			vdevs = append(vdevs, vdev)
+		}
+	}
+
+	if len(vdevs) == 0 {
+		return nil, errors.New("no config found")
+	}
+
+	nodes := make([]*vdevEntry, len(vdevs))
+	for i := range vdevs {
+		nodes[i] = &vdevs[i].vdevEntry
+	}
+	if err := resolveVdevPaths(nodes); err != nil {
+		return nil, err
+	}
+	st.vdevs = vdevs[1:]
+
+	parseZpoolScan(st, scan.String())
+
+	return st, nil
+}
+
+func parseZpoolScan(st *zpoolStatusEntry, scan string) {
+	switch {
+	case strings.HasPrefix(scan, "scrub in progress"):
+		st.scanState = "scrub"
+	case strings.HasPrefix(scan, "resilver in progress"):
+		st.scanState = "resilver"
+	case strings.HasPrefix(scan, "scrub paused"):
+		st.scanState = "scrub_paused"
+	}
+
+	if st.scanState == "idle" {
+		if m := reScanErrors.FindStringSubmatch(scan); m != nil {
+			st.scanErrors = m[1]
+		}
+		return
+	}
+
+	if m := reScanProgress.FindStringSubmatch(scan); m != nil {
+		st.scanProgress = m[1]
+	}
+	if m := reScanETA.FindStringSubmatch(scan); m != nil {
+		var secs int64
+		for i, mul := range []int64{86400, 3600, 60, 1} {
+			v, _ := strconv.ParseInt(m[i+1], 10, 64)
+			secs += v * mul
+		}
+		st.scanETA = secs
+	}
+}
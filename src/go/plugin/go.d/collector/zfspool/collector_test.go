@@ -24,6 +24,10 @@ var (
 	dataZpoolList, _                  = os.ReadFile("testdata/zpool-list.txt")
 	dataZpoolListWithVdev, _          = os.ReadFile("testdata/zpool-list-vdev.txt")
 	dataZpoolListWithVdevLogsCache, _ = os.ReadFile("testdata/zpool-list-vdev-logs-cache.txt")
+	dataZpoolStatus, _                = os.ReadFile("testdata/zpool-status.txt")
+	dataZpoolStatusLogsCache, _       = os.ReadFile("testdata/zpool-status-logs-cache.txt")
+	dataZpoolIostat, _                = os.ReadFile("testdata/zpool-iostat.txt")
+	dataZpoolIostatLogsCache, _       = os.ReadFile("testdata/zpool-iostat-logs-cache.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -34,6 +38,10 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataZpoolList":                  dataZpoolList,
 		"dataZpoolListWithVdev":          dataZpoolListWithVdev,
 		"dataZpoolListWithVdevLogsCache": dataZpoolListWithVdevLogsCache,
+		"dataZpoolStatus":                dataZpoolStatus,
+		"dataZpoolStatusLogsCache":       dataZpoolStatusLogsCache,
+		"dataZpoolIostat":                dataZpoolIostat,
+		"dataZpoolIostatLogsCache":       dataZpoolIostatLogsCache,
 	} {
 		require.NotNil(t, data, name)
 
@@ -224,6 +232,137 @@ func TestCollector_Collect(t *testing.T) {
 				"zpool_zion_health_state_removed":    0,
 				"zpool_zion_health_state_suspended":  0,
 				"zpool_zion_health_state_unavail":    0,
+
+				"vdev_rpool/mirror-0/nvme0n1p3_asyncq_read_activ":  1,
+				"vdev_rpool/mirror-0/nvme0n1p3_asyncq_read_pend":   0,
+				"vdev_rpool/mirror-0/nvme0n1p3_asyncq_wait_read":   3856,
+				"vdev_rpool/mirror-0/nvme0n1p3_asyncq_wait_write":  4967,
+				"vdev_rpool/mirror-0/nvme0n1p3_asyncq_write_activ": 2,
+				"vdev_rpool/mirror-0/nvme0n1p3_asyncq_write_pend":  4,
+				"vdev_rpool/mirror-0/nvme0n1p3_checksum_errors":    27,
+				"vdev_rpool/mirror-0/nvme0n1p3_disk_wait_read":     316345,
+				"vdev_rpool/mirror-0/nvme0n1p3_disk_wait_write":    1027456,
+				"vdev_rpool/mirror-0/nvme0n1p3_read_errors":        0,
+				"vdev_rpool/mirror-0/nvme0n1p3_syncq_read_activ":   1,
+				"vdev_rpool/mirror-0/nvme0n1p3_syncq_read_pend":    0,
+				"vdev_rpool/mirror-0/nvme0n1p3_syncq_wait_read":    1634,
+				"vdev_rpool/mirror-0/nvme0n1p3_syncq_wait_write":   2745,
+				"vdev_rpool/mirror-0/nvme0n1p3_syncq_write_activ":  1,
+				"vdev_rpool/mirror-0/nvme0n1p3_syncq_write_pend":   0,
+				"vdev_rpool/mirror-0/nvme0n1p3_total_wait_read":    416345,
+				"vdev_rpool/mirror-0/nvme0n1p3_total_wait_write":   1527456,
+				"vdev_rpool/mirror-0/nvme0n1p3_write_errors":       2,
+				"vdev_rpool/mirror-0/nvme2n1p3_asyncq_read_activ":  1,
+				"vdev_rpool/mirror-0/nvme2n1p3_asyncq_read_pend":   1,
+				"vdev_rpool/mirror-0/nvme2n1p3_asyncq_wait_read":   3756,
+				"vdev_rpool/mirror-0/nvme2n1p3_asyncq_wait_write":  4867,
+				"vdev_rpool/mirror-0/nvme2n1p3_asyncq_write_activ": 2,
+				"vdev_rpool/mirror-0/nvme2n1p3_asyncq_write_pend":  3,
+				"vdev_rpool/mirror-0/nvme2n1p3_checksum_errors":    0,
+				"vdev_rpool/mirror-0/nvme2n1p3_disk_wait_read":     315345,
+				"vdev_rpool/mirror-0/nvme2n1p3_disk_wait_write":    1026456,
+				"vdev_rpool/mirror-0/nvme2n1p3_read_errors":        0,
+				"vdev_rpool/mirror-0/nvme2n1p3_syncq_read_activ":   0,
+				"vdev_rpool/mirror-0/nvme2n1p3_syncq_read_pend":    0,
+				"vdev_rpool/mirror-0/nvme2n1p3_syncq_wait_read":    1534,
+				"vdev_rpool/mirror-0/nvme2n1p3_syncq_wait_write":   2645,
+				"vdev_rpool/mirror-0/nvme2n1p3_syncq_write_activ":  1,
+				"vdev_rpool/mirror-0/nvme2n1p3_syncq_write_pend":   0,
+				"vdev_rpool/mirror-0/nvme2n1p3_total_wait_read":    415345,
+				"vdev_rpool/mirror-0/nvme2n1p3_total_wait_write":   1526456,
+				"vdev_rpool/mirror-0/nvme2n1p3_write_errors":       0,
+				"vdev_rpool/mirror-0_asyncq_read_activ":            1,
+				"vdev_rpool/mirror-0_asyncq_read_pend":             0,
+				"vdev_rpool/mirror-0_asyncq_wait_read":             3656,
+				"vdev_rpool/mirror-0_asyncq_wait_write":            4767,
+				"vdev_rpool/mirror-0_asyncq_write_activ":           2,
+				"vdev_rpool/mirror-0_asyncq_write_pend":            2,
+				"vdev_rpool/mirror-0_checksum_errors":              0,
+				"vdev_rpool/mirror-0_disk_wait_read":               314345,
+				"vdev_rpool/mirror-0_disk_wait_write":              1025456,
+				"vdev_rpool/mirror-0_read_errors":                  0,
+				"vdev_rpool/mirror-0_syncq_read_activ":             2,
+				"vdev_rpool/mirror-0_syncq_read_pend":              0,
+				"vdev_rpool/mirror-0_syncq_wait_read":              1434,
+				"vdev_rpool/mirror-0_syncq_wait_write":             2545,
+				"vdev_rpool/mirror-0_syncq_write_activ":            1,
+				"vdev_rpool/mirror-0_syncq_write_pend":             0,
+				"vdev_rpool/mirror-0_total_wait_read":              414345,
+				"vdev_rpool/mirror-0_total_wait_write":             1525456,
+				"vdev_rpool/mirror-0_write_errors":                 0,
+				"vdev_zion/mirror-0/nvme0n1p3_asyncq_read_activ":   1,
+				"vdev_zion/mirror-0/nvme0n1p3_asyncq_read_pend":    0,
+				"vdev_zion/mirror-0/nvme0n1p3_asyncq_wait_read":    3856,
+				"vdev_zion/mirror-0/nvme0n1p3_asyncq_wait_write":   4967,
+				"vdev_zion/mirror-0/nvme0n1p3_asyncq_write_activ":  2,
+				"vdev_zion/mirror-0/nvme0n1p3_asyncq_write_pend":   4,
+				"vdev_zion/mirror-0/nvme0n1p3_checksum_errors":     27,
+				"vdev_zion/mirror-0/nvme0n1p3_disk_wait_read":      316345,
+				"vdev_zion/mirror-0/nvme0n1p3_disk_wait_write":     1027456,
+				"vdev_zion/mirror-0/nvme0n1p3_read_errors":         0,
+				"vdev_zion/mirror-0/nvme0n1p3_syncq_read_activ":    1,
+				"vdev_zion/mirror-0/nvme0n1p3_syncq_read_pend":     0,
+				"vdev_zion/mirror-0/nvme0n1p3_syncq_wait_read":     1634,
+				"vdev_zion/mirror-0/nvme0n1p3_syncq_wait_write":    2745,
+				"vdev_zion/mirror-0/nvme0n1p3_syncq_write_activ":   1,
+				"vdev_zion/mirror-0/nvme0n1p3_syncq_write_pend":    0,
+				"vdev_zion/mirror-0/nvme0n1p3_total_wait_read":     416345,
+				"vdev_zion/mirror-0/nvme0n1p3_total_wait_write":    1527456,
+				"vdev_zion/mirror-0/nvme0n1p3_write_errors":        2,
+				"vdev_zion/mirror-0/nvme2n1p3_asyncq_read_activ":   1,
+				"vdev_zion/mirror-0/nvme2n1p3_asyncq_read_pend":    1,
+				"vdev_zion/mirror-0/nvme2n1p3_asyncq_wait_read":    3756,
+				"vdev_zion/mirror-0/nvme2n1p3_asyncq_wait_write":   4867,
+				"vdev_zion/mirror-0/nvme2n1p3_asyncq_write_activ":  2,
+				"vdev_zion/mirror-0/nvme2n1p3_asyncq_write_pend":   3,
+				"vdev_zion/mirror-0/nvme2n1p3_checksum_errors":     0,
+				"vdev_zion/mirror-0/nvme2n1p3_disk_wait_read":      315345,
+				"vdev_zion/mirror-0/nvme2n1p3_disk_wait_write":     1026456,
+				"vdev_zion/mirror-0/nvme2n1p3_read_errors":         0,
+				"vdev_zion/mirror-0/nvme2n1p3_syncq_read_activ":    0,
+				"vdev_zion/mirror-0/nvme2n1p3_syncq_read_pend":     0,
+				"vdev_zion/mirror-0/nvme2n1p3_syncq_wait_read":     1534,
+				"vdev_zion/mirror-0/nvme2n1p3_syncq_wait_write":    2645,
+				"vdev_zion/mirror-0/nvme2n1p3_syncq_write_activ":   1,
+				"vdev_zion/mirror-0/nvme2n1p3_syncq_write_pend":    0,
+				"vdev_zion/mirror-0/nvme2n1p3_total_wait_read":     415345,
+				"vdev_zion/mirror-0/nvme2n1p3_total_wait_write":    1526456,
+				"vdev_zion/mirror-0/nvme2n1p3_write_errors":        0,
+				"vdev_zion/mirror-0_asyncq_read_activ":             1,
+				"vdev_zion/mirror-0_asyncq_read_pend":              0,
+				"vdev_zion/mirror-0_asyncq_wait_read":              3656,
+				"vdev_zion/mirror-0_asyncq_wait_write":             4767,
+				"vdev_zion/mirror-0_asyncq_write_activ":            2,
+				"vdev_zion/mirror-0_asyncq_write_pend":             2,
+				"vdev_zion/mirror-0_checksum_errors":               0,
+				"vdev_zion/mirror-0_disk_wait_read":                314345,
+				"vdev_zion/mirror-0_disk_wait_write":               1025456,
+				"vdev_zion/mirror-0_read_errors":                   0,
+				"vdev_zion/mirror-0_syncq_read_activ":              2,
+				"vdev_zion/mirror-0_syncq_read_pend":               0,
+				"vdev_zion/mirror-0_syncq_wait_read":               1434,
+				"vdev_zion/mirror-0_syncq_wait_write":              2545,
+				"vdev_zion/mirror-0_syncq_write_activ":             1,
+				"vdev_zion/mirror-0_syncq_write_pend":              0,
+				"vdev_zion/mirror-0_total_wait_read":               414345,
+				"vdev_zion/mirror-0_total_wait_write":              1525456,
+				"vdev_zion/mirror-0_write_errors":                  0,
+				"zpool_rpool_data_errors":                          0,
+				"zpool_rpool_scan_errors":                          0,
+				"zpool_rpool_scan_eta":                             3741,
+				"zpool_rpool_scan_progress":                        5131,
+				"zpool_rpool_scan_state_idle":                      0,
+				"zpool_rpool_scan_state_resilver":                  0,
+				"zpool_rpool_scan_state_scrub":                     1,
+				"zpool_rpool_scan_state_scrub_paused":              0,
+				"zpool_zion_data_errors":                           0,
+				"zpool_zion_scan_errors":                           0,
+				"zpool_zion_scan_eta":                              3741,
+				"zpool_zion_scan_progress":                         5131,
+				"zpool_zion_scan_state_idle":                       0,
+				"zpool_zion_scan_state_resilver":                   0,
+				"zpool_zion_scan_state_scrub":                      1,
+				"zpool_zion_scan_state_scrub_paused":               0,
 			},
 		},
 		"success case vdev logs and cache": {
@@ -360,6 +499,327 @@ func TestCollector_Collect(t *testing.T) {
 				"zpool_zion_health_state_removed":    0,
 				"zpool_zion_health_state_suspended":  0,
 				"zpool_zion_health_state_unavail":    0,
+
+				"vdev_rpool/cache/sdb2_asyncq_read_activ":                          1,
+				"vdev_rpool/cache/sdb2_asyncq_read_pend":                           0,
+				"vdev_rpool/cache/sdb2_asyncq_wait_read":                           4456,
+				"vdev_rpool/cache/sdb2_asyncq_wait_write":                          5567,
+				"vdev_rpool/cache/sdb2_asyncq_write_activ":                         2,
+				"vdev_rpool/cache/sdb2_asyncq_write_pend":                          10,
+				"vdev_rpool/cache/sdb2_checksum_errors":                            0,
+				"vdev_rpool/cache/sdb2_disk_wait_read":                             322345,
+				"vdev_rpool/cache/sdb2_disk_wait_write":                            1033456,
+				"vdev_rpool/cache/sdb2_read_errors":                                0,
+				"vdev_rpool/cache/sdb2_syncq_read_activ":                           1,
+				"vdev_rpool/cache/sdb2_syncq_read_pend":                            0,
+				"vdev_rpool/cache/sdb2_syncq_wait_read":                            2234,
+				"vdev_rpool/cache/sdb2_syncq_wait_write":                           3345,
+				"vdev_rpool/cache/sdb2_syncq_write_activ":                          1,
+				"vdev_rpool/cache/sdb2_syncq_write_pend":                           0,
+				"vdev_rpool/cache/sdb2_total_wait_read":                            422345,
+				"vdev_rpool/cache/sdb2_total_wait_write":                           1533456,
+				"vdev_rpool/cache/sdb2_write_errors":                               0,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_asyncq_read_activ":  1,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_asyncq_read_pend":   1,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_asyncq_wait_read":   4556,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_asyncq_wait_write":  5667,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_asyncq_write_activ": 2,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_asyncq_write_pend":  11,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_checksum_errors":    0,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_disk_wait_read":     323345,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_disk_wait_write":    1034456,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_read_errors":        0,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_syncq_read_activ":   2,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_syncq_read_pend":    0,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_syncq_wait_read":    2334,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_syncq_wait_write":   3445,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_syncq_write_activ":  1,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_syncq_write_pend":   0,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_total_wait_read":    423345,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_total_wait_write":   1534456,
+				"vdev_rpool/cache/wwn-0x500151795954c095-part2_write_errors":       0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_asyncq_read_activ":  1,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_asyncq_read_pend":   0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_asyncq_wait_read":   4256,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_asyncq_wait_write":  5367,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_asyncq_write_activ": 2,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_asyncq_write_pend":  8,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_checksum_errors":    0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_disk_wait_read":     320345,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_disk_wait_write":    1031456,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_read_errors":        0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_syncq_read_activ":   2,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_syncq_read_pend":    0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_syncq_wait_read":    2034,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_syncq_wait_write":   3145,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_syncq_write_activ":  1,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_syncq_write_pend":   0,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_total_wait_read":    420345,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_total_wait_write":   1531456,
+				"vdev_rpool/logs/mirror-1/14807975228228307538_write_errors":       0,
+				"vdev_rpool/logs/mirror-1/sdb1_asyncq_read_activ":                  1,
+				"vdev_rpool/logs/mirror-1/sdb1_asyncq_read_pend":                   1,
+				"vdev_rpool/logs/mirror-1/sdb1_asyncq_wait_read":                   4156,
+				"vdev_rpool/logs/mirror-1/sdb1_asyncq_wait_write":                  5267,
+				"vdev_rpool/logs/mirror-1/sdb1_asyncq_write_activ":                 2,
+				"vdev_rpool/logs/mirror-1/sdb1_asyncq_write_pend":                  7,
+				"vdev_rpool/logs/mirror-1/sdb1_checksum_errors":                    0,
+				"vdev_rpool/logs/mirror-1/sdb1_disk_wait_read":                     319345,
+				"vdev_rpool/logs/mirror-1/sdb1_disk_wait_write":                    1030456,
+				"vdev_rpool/logs/mirror-1/sdb1_read_errors":                        0,
+				"vdev_rpool/logs/mirror-1/sdb1_syncq_read_activ":                   1,
+				"vdev_rpool/logs/mirror-1/sdb1_syncq_read_pend":                    0,
+				"vdev_rpool/logs/mirror-1/sdb1_syncq_wait_read":                    1934,
+				"vdev_rpool/logs/mirror-1/sdb1_syncq_wait_write":                   3045,
+				"vdev_rpool/logs/mirror-1/sdb1_syncq_write_activ":                  1,
+				"vdev_rpool/logs/mirror-1/sdb1_syncq_write_pend":                   0,
+				"vdev_rpool/logs/mirror-1/sdb1_total_wait_read":                    419345,
+				"vdev_rpool/logs/mirror-1/sdb1_total_wait_write":                   1530456,
+				"vdev_rpool/logs/mirror-1/sdb1_write_errors":                       0,
+				"vdev_rpool/logs/mirror-1_asyncq_read_activ":                       1,
+				"vdev_rpool/logs/mirror-1_asyncq_read_pend":                        0,
+				"vdev_rpool/logs/mirror-1_asyncq_wait_read":                        4056,
+				"vdev_rpool/logs/mirror-1_asyncq_wait_write":                       5167,
+				"vdev_rpool/logs/mirror-1_asyncq_write_activ":                      2,
+				"vdev_rpool/logs/mirror-1_asyncq_write_pend":                       6,
+				"vdev_rpool/logs/mirror-1_checksum_errors":                         0,
+				"vdev_rpool/logs/mirror-1_disk_wait_read":                          318345,
+				"vdev_rpool/logs/mirror-1_disk_wait_write":                         1029456,
+				"vdev_rpool/logs/mirror-1_read_errors":                             0,
+				"vdev_rpool/logs/mirror-1_syncq_read_activ":                        0,
+				"vdev_rpool/logs/mirror-1_syncq_read_pend":                         0,
+				"vdev_rpool/logs/mirror-1_syncq_wait_read":                         1834,
+				"vdev_rpool/logs/mirror-1_syncq_wait_write":                        2945,
+				"vdev_rpool/logs/mirror-1_syncq_write_activ":                       1,
+				"vdev_rpool/logs/mirror-1_syncq_write_pend":                        0,
+				"vdev_rpool/logs/mirror-1_total_wait_read":                         418345,
+				"vdev_rpool/logs/mirror-1_total_wait_write":                        1529456,
+				"vdev_rpool/logs/mirror-1_write_errors":                            0,
+				"vdev_rpool/mirror-0/sdc2_asyncq_read_activ":                       1,
+				"vdev_rpool/mirror-0/sdc2_asyncq_read_pend":                        1,
+				"vdev_rpool/mirror-0/sdc2_asyncq_wait_read":                        3756,
+				"vdev_rpool/mirror-0/sdc2_asyncq_wait_write":                       4867,
+				"vdev_rpool/mirror-0/sdc2_asyncq_write_activ":                      2,
+				"vdev_rpool/mirror-0/sdc2_asyncq_write_pend":                       3,
+				"vdev_rpool/mirror-0/sdc2_checksum_errors":                         0,
+				"vdev_rpool/mirror-0/sdc2_disk_wait_read":                          315345,
+				"vdev_rpool/mirror-0/sdc2_disk_wait_write":                         1026456,
+				"vdev_rpool/mirror-0/sdc2_read_errors":                             0,
+				"vdev_rpool/mirror-0/sdc2_syncq_read_activ":                        0,
+				"vdev_rpool/mirror-0/sdc2_syncq_read_pend":                         0,
+				"vdev_rpool/mirror-0/sdc2_syncq_wait_read":                         1534,
+				"vdev_rpool/mirror-0/sdc2_syncq_wait_write":                        2645,
+				"vdev_rpool/mirror-0/sdc2_syncq_write_activ":                       1,
+				"vdev_rpool/mirror-0/sdc2_syncq_write_pend":                        0,
+				"vdev_rpool/mirror-0/sdc2_total_wait_read":                         415345,
+				"vdev_rpool/mirror-0/sdc2_total_wait_write":                        1526456,
+				"vdev_rpool/mirror-0/sdc2_write_errors":                            0,
+				"vdev_rpool/mirror-0/sdd2_asyncq_read_activ":                       1,
+				"vdev_rpool/mirror-0/sdd2_asyncq_read_pend":                        0,
+				"vdev_rpool/mirror-0/sdd2_asyncq_wait_read":                        3856,
+				"vdev_rpool/mirror-0/sdd2_asyncq_wait_write":                       4967,
+				"vdev_rpool/mirror-0/sdd2_asyncq_write_activ":                      2,
+				"vdev_rpool/mirror-0/sdd2_asyncq_write_pend":                       4,
+				"vdev_rpool/mirror-0/sdd2_checksum_errors":                         0,
+				"vdev_rpool/mirror-0/sdd2_disk_wait_read":                          316345,
+				"vdev_rpool/mirror-0/sdd2_disk_wait_write":                         1027456,
+				"vdev_rpool/mirror-0/sdd2_read_errors":                             1,
+				"vdev_rpool/mirror-0/sdd2_syncq_read_activ":                        1,
+				"vdev_rpool/mirror-0/sdd2_syncq_read_pend":                         0,
+				"vdev_rpool/mirror-0/sdd2_syncq_wait_read":                         1634,
+				"vdev_rpool/mirror-0/sdd2_syncq_wait_write":                        2745,
+				"vdev_rpool/mirror-0/sdd2_syncq_write_activ":                       1,
+				"vdev_rpool/mirror-0/sdd2_syncq_write_pend":                        0,
+				"vdev_rpool/mirror-0/sdd2_total_wait_read":                         416345,
+				"vdev_rpool/mirror-0/sdd2_total_wait_write":                        1527456,
+				"vdev_rpool/mirror-0/sdd2_write_errors":                            0,
+				"vdev_rpool/mirror-0_asyncq_read_activ":                            1,
+				"vdev_rpool/mirror-0_asyncq_read_pend":                             0,
+				"vdev_rpool/mirror-0_asyncq_wait_read":                             3656,
+				"vdev_rpool/mirror-0_asyncq_wait_write":                            4767,
+				"vdev_rpool/mirror-0_asyncq_write_activ":                           2,
+				"vdev_rpool/mirror-0_asyncq_write_pend":                            2,
+				"vdev_rpool/mirror-0_checksum_errors":                              0,
+				"vdev_rpool/mirror-0_disk_wait_read":                               314345,
+				"vdev_rpool/mirror-0_disk_wait_write":                              1025456,
+				"vdev_rpool/mirror-0_read_errors":                                  0,
+				"vdev_rpool/mirror-0_syncq_read_activ":                             2,
+				"vdev_rpool/mirror-0_syncq_read_pend":                              0,
+				"vdev_rpool/mirror-0_syncq_wait_read":                              1434,
+				"vdev_rpool/mirror-0_syncq_wait_write":                             2545,
+				"vdev_rpool/mirror-0_syncq_write_activ":                            1,
+				"vdev_rpool/mirror-0_syncq_write_pend":                             0,
+				"vdev_rpool/mirror-0_total_wait_read":                              414345,
+				"vdev_rpool/mirror-0_total_wait_write":                             1525456,
+				"vdev_rpool/mirror-0_write_errors":                                 0,
+				"vdev_zion/cache/sdb2_asyncq_read_activ":                           1,
+				"vdev_zion/cache/sdb2_asyncq_read_pend":                            0,
+				"vdev_zion/cache/sdb2_asyncq_wait_read":                            4456,
+				"vdev_zion/cache/sdb2_asyncq_wait_write":                           5567,
+				"vdev_zion/cache/sdb2_asyncq_write_activ":                          2,
+				"vdev_zion/cache/sdb2_asyncq_write_pend":                           10,
+				"vdev_zion/cache/sdb2_checksum_errors":                             0,
+				"vdev_zion/cache/sdb2_disk_wait_read":                              322345,
+				"vdev_zion/cache/sdb2_disk_wait_write":                             1033456,
+				"vdev_zion/cache/sdb2_read_errors":                                 0,
+				"vdev_zion/cache/sdb2_syncq_read_activ":                            1,
+				"vdev_zion/cache/sdb2_syncq_read_pend":                             0,
+				"vdev_zion/cache/sdb2_syncq_wait_read":                             2234,
+				"vdev_zion/cache/sdb2_syncq_wait_write":                            3345,
+				"vdev_zion/cache/sdb2_syncq_write_activ":                           1,
+				"vdev_zion/cache/sdb2_syncq_write_pend":                            0,
+				"vdev_zion/cache/sdb2_total_wait_read":                             422345,
+				"vdev_zion/cache/sdb2_total_wait_write":                            1533456,
+				"vdev_zion/cache/sdb2_write_errors":                                0,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_asyncq_read_activ":   1,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_asyncq_read_pend":    1,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_asyncq_wait_read":    4556,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_asyncq_wait_write":   5667,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_asyncq_write_activ":  2,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_asyncq_write_pend":   11,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_checksum_errors":     0,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_disk_wait_read":      323345,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_disk_wait_write":     1034456,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_read_errors":         0,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_syncq_read_activ":    2,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_syncq_read_pend":     0,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_syncq_wait_read":     2334,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_syncq_wait_write":    3445,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_syncq_write_activ":   1,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_syncq_write_pend":    0,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_total_wait_read":     423345,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_total_wait_write":    1534456,
+				"vdev_zion/cache/wwn-0x500151795954c095-part2_write_errors":        0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_asyncq_read_activ":   1,
+				"vdev_zion/logs/mirror-1/14807975228228307538_asyncq_read_pend":    0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_asyncq_wait_read":    4256,
+				"vdev_zion/logs/mirror-1/14807975228228307538_asyncq_wait_write":   5367,
+				"vdev_zion/logs/mirror-1/14807975228228307538_asyncq_write_activ":  2,
+				"vdev_zion/logs/mirror-1/14807975228228307538_asyncq_write_pend":   8,
+				"vdev_zion/logs/mirror-1/14807975228228307538_checksum_errors":     0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_disk_wait_read":      320345,
+				"vdev_zion/logs/mirror-1/14807975228228307538_disk_wait_write":     1031456,
+				"vdev_zion/logs/mirror-1/14807975228228307538_read_errors":         0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_syncq_read_activ":    2,
+				"vdev_zion/logs/mirror-1/14807975228228307538_syncq_read_pend":     0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_syncq_wait_read":     2034,
+				"vdev_zion/logs/mirror-1/14807975228228307538_syncq_wait_write":    3145,
+				"vdev_zion/logs/mirror-1/14807975228228307538_syncq_write_activ":   1,
+				"vdev_zion/logs/mirror-1/14807975228228307538_syncq_write_pend":    0,
+				"vdev_zion/logs/mirror-1/14807975228228307538_total_wait_read":     420345,
+				"vdev_zion/logs/mirror-1/14807975228228307538_total_wait_write":    1531456,
+				"vdev_zion/logs/mirror-1/14807975228228307538_write_errors":        0,
+				"vdev_zion/logs/mirror-1/sdb1_asyncq_read_activ":                   1,
+				"vdev_zion/logs/mirror-1/sdb1_asyncq_read_pend":                    1,
+				"vdev_zion/logs/mirror-1/sdb1_asyncq_wait_read":                    4156,
+				"vdev_zion/logs/mirror-1/sdb1_asyncq_wait_write":                   5267,
+				"vdev_zion/logs/mirror-1/sdb1_asyncq_write_activ":                  2,
+				"vdev_zion/logs/mirror-1/sdb1_asyncq_write_pend":                   7,
+				"vdev_zion/logs/mirror-1/sdb1_checksum_errors":                     0,
+				"vdev_zion/logs/mirror-1/sdb1_disk_wait_read":                      319345,
+				"vdev_zion/logs/mirror-1/sdb1_disk_wait_write":                     1030456,
+				"vdev_zion/logs/mirror-1/sdb1_read_errors":                         0,
+				"vdev_zion/logs/mirror-1/sdb1_syncq_read_activ":                    1,
+				"vdev_zion/logs/mirror-1/sdb1_syncq_read_pend":                     0,
+				"vdev_zion/logs/mirror-1/sdb1_syncq_wait_read":                     1934,
+				"vdev_zion/logs/mirror-1/sdb1_syncq_wait_write":                    3045,
+				"vdev_zion/logs/mirror-1/sdb1_syncq_write_activ":                   1,
+				"vdev_zion/logs/mirror-1/sdb1_syncq_write_pend":                    0,
+				"vdev_zion/logs/mirror-1/sdb1_total_wait_read":                     419345,
+				"vdev_zion/logs/mirror-1/sdb1_total_wait_write":                    1530456,
+				"vdev_zion/logs/mirror-1/sdb1_write_errors":                        0,
+				"vdev_zion/logs/mirror-1_asyncq_read_activ":                        1,
+				"vdev_zion/logs/mirror-1_asyncq_read_pend":                         0,
+				"vdev_zion/logs/mirror-1_asyncq_wait_read":                         4056,
+				"vdev_zion/logs/mirror-1_asyncq_wait_write":                        5167,
+				"vdev_zion/logs/mirror-1_asyncq_write_activ":                       2,
+				"vdev_zion/logs/mirror-1_asyncq_write_pend":                        6,
+				"vdev_zion/logs/mirror-1_checksum_errors":                          0,
+				"vdev_zion/logs/mirror-1_disk_wait_read":                           318345,
+				"vdev_zion/logs/mirror-1_disk_wait_write":                          1029456,
+				"vdev_zion/logs/mirror-1_read_errors":                              0,
+				"vdev_zion/logs/mirror-1_syncq_read_activ":                         0,
+				"vdev_zion/logs/mirror-1_syncq_read_pend":                          0,
+				"vdev_zion/logs/mirror-1_syncq_wait_read":                          1834,
+				"vdev_zion/logs/mirror-1_syncq_wait_write":                         2945,
+				"vdev_zion/logs/mirror-1_syncq_write_activ":                        1,
+				"vdev_zion/logs/mirror-1_syncq_write_pend":                         0,
+				"vdev_zion/logs/mirror-1_total_wait_read":                          418345,
+				"vdev_zion/logs/mirror-1_total_wait_write":                         1529456,
+				"vdev_zion/logs/mirror-1_write_errors":                             0,
+				"vdev_zion/mirror-0/sdc2_asyncq_read_activ":                        1,
+				"vdev_zion/mirror-0/sdc2_asyncq_read_pend":                         1,
+				"vdev_zion/mirror-0/sdc2_asyncq_wait_read":                         3756,
+				"vdev_zion/mirror-0/sdc2_asyncq_wait_write":                        4867,
+				"vdev_zion/mirror-0/sdc2_asyncq_write_activ":                       2,
+				"vdev_zion/mirror-0/sdc2_asyncq_write_pend":                        3,
+				"vdev_zion/mirror-0/sdc2_checksum_errors":                          0,
+				"vdev_zion/mirror-0/sdc2_disk_wait_read":                           315345,
+				"vdev_zion/mirror-0/sdc2_disk_wait_write":                          1026456,
+				"vdev_zion/mirror-0/sdc2_read_errors":                              0,
+				"vdev_zion/mirror-0/sdc2_syncq_read_activ":                         0,
+				"vdev_zion/mirror-0/sdc2_syncq_read_pend":                          0,
+				"vdev_zion/mirror-0/sdc2_syncq_wait_read":                          1534,
+				"vdev_zion/mirror-0/sdc2_syncq_wait_write":                         2645,
+				"vdev_zion/mirror-0/sdc2_syncq_write_activ":                        1,
+				"vdev_zion/mirror-0/sdc2_syncq_write_pend":                         0,
+				"vdev_zion/mirror-0/sdc2_total_wait_read":                          415345,
+				"vdev_zion/mirror-0/sdc2_total_wait_write":                         1526456,
+				"vdev_zion/mirror-0/sdc2_write_errors":                             0,
+				"vdev_zion/mirror-0/sdd2_asyncq_read_activ":                        1,
+				"vdev_zion/mirror-0/sdd2_asyncq_read_pend":                         0,
+				"vdev_zion/mirror-0/sdd2_asyncq_wait_read":                         3856,
+				"vdev_zion/mirror-0/sdd2_asyncq_wait_write":                        4967,
+				"vdev_zion/mirror-0/sdd2_asyncq_write_activ":                       2,
+				"vdev_zion/mirror-0/sdd2_asyncq_write_pend":                        4,
+				"vdev_zion/mirror-0/sdd2_checksum_errors":                          0,
+				"vdev_zion/mirror-0/sdd2_disk_wait_read":                           316345,
+				"vdev_zion/mirror-0/sdd2_disk_wait_write":                          1027456,
+				"vdev_zion/mirror-0/sdd2_read_errors":                              1,
+				"vdev_zion/mirror-0/sdd2_syncq_read_activ":                         1,
+				"vdev_zion/mirror-0/sdd2_syncq_read_pend":                          0,
+				"vdev_zion/mirror-0/sdd2_syncq_wait_read":                          1634,
+				"vdev_zion/mirror-0/sdd2_syncq_wait_write":                         2745,
+				"vdev_zion/mirror-0/sdd2_syncq_write_activ":                        1,
+				"vdev_zion/mirror-0/sdd2_syncq_write_pend":                         0,
+				"vdev_zion/mirror-0/sdd2_total_wait_read":                          416345,
+				"vdev_zion/mirror-0/sdd2_total_wait_write":                         1527456,
+				"vdev_zion/mirror-0/sdd2_write_errors":                             0,
+				"vdev_zion/mirror-0_asyncq_read_activ":                             1,
+				"vdev_zion/mirror-0_asyncq_read_pend":                              0,
+				"vdev_zion/mirror-0_asyncq_wait_read":                              3656,
+				"vdev_zion/mirror-0_asyncq_wait_write":                             4767,
+				"vdev_zion/mirror-0_asyncq_write_activ":                            2,
+				"vdev_zion/mirror-0_asyncq_write_pend":                             2,
+				"vdev_zion/mirror-0_checksum_errors":                               0,
+				"vdev_zion/mirror-0_disk_wait_read":                                314345,
+				"vdev_zion/mirror-0_disk_wait_write":                               1025456,
+				"vdev_zion/mirror-0_read_errors":                                   0,
+				"vdev_zion/mirror-0_syncq_read_activ":                              2,
+				"vdev_zion/mirror-0_syncq_read_pend":                               0,
+				"vdev_zion/mirror-0_syncq_wait_read":                               1434,
+				"vdev_zion/mirror-0_syncq_wait_write":                              2545,
+				"vdev_zion/mirror-0_syncq_write_activ":                             1,
+				"vdev_zion/mirror-0_syncq_write_pend":                              0,
+				"vdev_zion/mirror-0_total_wait_read":                               414345,
+				"vdev_zion/mirror-0_total_wait_write":                              1525456,
+				"vdev_zion/mirror-0_write_errors":                                  0,
+				"zpool_rpool_data_errors":                                          3,
+				"zpool_rpool_scan_errors":                                          3,
+				"zpool_rpool_scan_eta":                                             0,
+				"zpool_rpool_scan_progress":                                        0,
+				"zpool_rpool_scan_state_idle":                                      1,
+				"zpool_rpool_scan_state_resilver":                                  0,
+				"zpool_rpool_scan_state_scrub":                                     0,
+				"zpool_rpool_scan_state_scrub_paused":                              0,
+				"zpool_zion_data_errors":                                           3,
+				"zpool_zion_scan_errors":                                           3,
+				"zpool_zion_scan_eta":                                              0,
+				"zpool_zion_scan_progress":                                         0,
+				"zpool_zion_scan_state_idle":                                       1,
+				"zpool_zion_scan_state_resilver":                                   0,
+				"zpool_zion_scan_state_scrub":                                      0,
+				"zpool_zion_scan_state_scrub_paused":                               0,
 			},
 		},
 		"error on list call": {
@@ -493,10 +953,73 @@ cache                               -      -      -        -         -      -
 	}
 }
 
+func TestCollector_parseZpoolScan(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  zpoolStatusEntry
+	}{
+		"none requested": {
+			input: "none requested",
+			want:  zpoolStatusEntry{scanState: "idle"},
+		},
+		"scrub finished": {
+			input: "scrub repaired 0 in 02:13:44 with 3 errors on Sun Oct  6 02:37:45 2024",
+			want:  zpoolStatusEntry{scanState: "idle", scanErrors: "3"},
+		},
+		"scrub in progress": {
+			input: "scrub in progress since Sun Oct 13 00:24:01 2024 1352345600000 / 1647130456064 scanned at 312345678/s, 845123456789 / 1647130456064 issued at 214345678/s 0 repaired, 51.31% done, 01:02:21 to go",
+			want:  zpoolStatusEntry{scanState: "scrub", scanProgress: "51.31", scanETA: 3741},
+		},
+		"resilver in progress": {
+			input: "resilver in progress since Sun Oct 13 00:24:01 2024 1352345600000 / 1647130456064 scanned at 312345678/s, 845123456789 / 1647130456064 issued at 214345678/s 123456789 resilvered, 5.10% done, 2 days 01:00:00 to go",
+			want:  zpoolStatusEntry{scanState: "resilver", scanProgress: "5.10", scanETA: 2*86400 + 3600},
+		},
+		"scrub paused": {
+			input: "scrub paused since Sun Oct 13 01:00:00 2024 scrub started on Sun Oct 13 00:24:01 2024 845123456789 / 1647130456064 scanned, 845123456789 / 1647130456064 issued, 0 repaired, 51.31% done",
+			want:  zpoolStatusEntry{scanState: "scrub_paused", scanProgress: "51.31"},
+		},
+		"scrub in progress no estimated completion time": {
+			input: "scrub in progress since Sun Oct 13 00:24:01 2024 0 / 1647130456064 scanned, 0 / 1647130456064 issued, 0 repaired, 0.00% done, no estimated completion time",
+			want:  zpoolStatusEntry{scanState: "scrub", scanProgress: "0.00"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			st := zpoolStatusEntry{scanState: "idle"}
+			parseZpoolScan(&st, test.input)
+			assert.Equal(t, test.want, st)
+		})
+	}
+}
+
+func TestCollector_parseZpoolIostatOutput(t *testing.T) {
+	vdevs, err := parseZpoolIostatOutput(dataZpoolIostatLogsCache)
+	require.NoError(t, err)
+
+	var names []string
+	for _, v := range vdevs {
+		names = append(names, v.vdev)
+	}
+	assert.Contains(t, names, "rpool/logs/mirror-1/sdb1")
+	assert.Contains(t, names, "zion/cache/sdb2")
+	assert.Equal(t, "420345", vdevs[6].values["total_wait_read"])
+	assert.Equal(t, "-", vdevs[6].values["scrub_wait"])
+
+	_, err = parseZpoolIostatOutput([]byte(`
+              capacity     operations     bandwidth
+pool        alloc   free   read  write   read
+----------  -----  -----  -----  -----  -----
+`))
+	assert.Error(t, err)
+}
+
 func prepareMockOk() *mockZpoolCLIExec {
 	return &mockZpoolCLIExec{
 		listData:         dataZpoolList,
 		listWithVdevData: dataZpoolListWithVdev,
+		statusData:       dataZpoolStatus,
+		iostatData:       dataZpoolIostat,
 	}
 }
 
@@ -504,6 +1027,8 @@ func prepareMockOkVdevLogsCache() *mockZpoolCLIExec {
 	return &mockZpoolCLIExec{
 		listData:         dataZpoolList,
 		listWithVdevData: dataZpoolListWithVdevLogsCache,
+		statusData:       dataZpoolStatusLogsCache,
+		iostatData:       dataZpoolIostatLogsCache,
 	}
 }
 
@@ -531,6 +1056,8 @@ type mockZpoolCLIExec struct {
 	errOnList        bool
 	listData         []byte
 	listWithVdevData []byte
+	statusData       []byte
+	iostatData       []byte
 }
 
 func (m *mockZpoolCLIExec) list() ([]byte, error) {
@@ -547,3 +1074,14 @@ func (m *mockZpoolCLIExec) listWithVdev(pool string) ([]byte, error) {
 
 	return []byte(s), nil
 }
+
+func (m *mockZpoolCLIExec) status(pool string) ([]byte, error) {
+	s := string(m.statusData)
+	s = strings.ReplaceAll(s, "rpool", pool)
+
+	return []byte(s), nil
+}
+
+func (m *mockZpoolCLIExec) iostat() ([]byte, error) {
+	return m.iostatData, nil
+}
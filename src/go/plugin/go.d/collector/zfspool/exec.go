@@ -16,6 +16,8 @@ import (
 type zpoolCli interface {
 	list() ([]byte, error)
 	listWithVdev(pool string) ([]byte, error)
+	status(pool string) ([]byte, error)
+	iostat() ([]byte, error)
 }
 
 func newZpoolCLIExec(binPath string, timeout time.Duration) *zpoolCLIExec {
@@ -61,3 +63,34 @@ func (e *zpoolCLIExec) listWithVdev(pool string) ([]byte, error) {
 
 	return bs, nil
 }
+
+func (e *zpoolCLIExec) status(pool string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, e.binPath, "status", "-p", "-L", pool)
+	e.Debugf("executing '%s'", cmd)
+
+	bs, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error on '%s': %v", cmd, err)
+	}
+
+	return bs, nil
+}
+
+func (e *zpoolCLIExec) iostat() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	// A single 1-second sample (-y skips the since-boot report) of all pools.
+	cmd := exec.CommandContext(ctx, e.binPath, "iostat", "-v", "-l", "-q", "-p", "-L", "-y", "1", "1")
+	e.Debugf("executing '%s'", cmd)
+
+	bs, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error on '%s': %v", cmd, err)
+	}
+
+	return bs, nil
+}
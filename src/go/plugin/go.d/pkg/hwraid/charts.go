@@ -0,0 +1,504 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hwraid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+// The charts go after the collector's own charts.
+const (
+	prioControllerStatus = module.Priority + 1000 + iota
+	prioControllerTemperature
+	prioControllerPatrolReadState
+	prioControllerCacheStatus
+	prioControllerCacheTemperature
+	prioControllerBBUStatus
+	prioControllerBBUTemperature
+	prioControllerBBUCharge
+
+	prioEnclosureStatus
+
+	prioLogicalDriveState
+	prioLogicalDriveRebuildProgress
+
+	prioPhysicalDriveState
+	prioPhysicalDrivePredictiveFailureStatus
+	prioPhysicalDriveErrors
+	prioPhysicalDrivePredictiveFailures
+	prioPhysicalDriveTemperature
+	prioPhysicalDriveRebuildProgress
+)
+
+var controllerChartsTmpl = module.Charts{
+	controllerStatusChartTmpl.Copy(),
+	controllerTemperatureChartTmpl.Copy(),
+	controllerPatrolReadStateChartTmpl.Copy(),
+	controllerCacheStatusChartTmpl.Copy(),
+	controllerCacheTemperatureChartTmpl.Copy(),
+	controllerBBUStatusChartTmpl.Copy(),
+	controllerBBUTemperatureChartTmpl.Copy(),
+	controllerBBUChargeChartTmpl.Copy(),
+}
+
+var (
+	controllerStatusChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_status",
+		Title:    "RAID controller status",
+		Units:    "status",
+		Fam:      "raid controller",
+		Ctx:      "raid.controller_status",
+		Type:     module.Line,
+		Priority: prioControllerStatus,
+		Dims:     stateDims("raid_cntrl_%s_status_", statuses),
+	}
+	controllerTemperatureChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_temperature",
+		Title:    "RAID controller temperature",
+		Units:    "Celsius",
+		Fam:      "raid controller",
+		Ctx:      "raid.controller_temperature",
+		Type:     module.Line,
+		Priority: prioControllerTemperature,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_temperature", Name: "temperature"},
+		},
+	}
+	controllerPatrolReadStateChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_patrol_read_state",
+		Title:    "RAID controller patrol read state",
+		Units:    "state",
+		Fam:      "raid controller",
+		Ctx:      "raid.controller_patrol_read_state",
+		Type:     module.Line,
+		Priority: prioControllerPatrolReadState,
+		Dims:     stateDims("raid_cntrl_%s_patrol_read_state_", patrolReadStates),
+	}
+	controllerCacheStatusChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_cache_status",
+		Title:    "RAID controller cache status",
+		Units:    "status",
+		Fam:      "raid cache",
+		Ctx:      "raid.controller_cache_status",
+		Type:     module.Line,
+		Priority: prioControllerCacheStatus,
+		Dims:     stateDims("raid_cntrl_%s_cache_status_", statuses),
+	}
+	controllerCacheTemperatureChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_cache_temperature",
+		Title:    "RAID controller cache temperature",
+		Units:    "Celsius",
+		Fam:      "raid cache",
+		Ctx:      "raid.controller_cache_temperature",
+		Type:     module.Line,
+		Priority: prioControllerCacheTemperature,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_cache_temperature", Name: "temperature"},
+		},
+	}
+	controllerBBUStatusChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_bbu_status",
+		Title:    "RAID controller BBU status",
+		Units:    "status",
+		Fam:      "raid cache",
+		Ctx:      "raid.controller_bbu_status",
+		Type:     module.Line,
+		Priority: prioControllerBBUStatus,
+		Dims:     stateDims("raid_cntrl_%s_bbu_status_", statuses),
+	}
+	controllerBBUTemperatureChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_bbu_temperature",
+		Title:    "RAID controller BBU temperature",
+		Units:    "Celsius",
+		Fam:      "raid cache",
+		Ctx:      "raid.controller_bbu_temperature",
+		Type:     module.Line,
+		Priority: prioControllerBBUTemperature,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_bbu_temperature", Name: "temperature"},
+		},
+	}
+	controllerBBUChargeChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_bbu_charge",
+		Title:    "RAID controller BBU charge",
+		Units:    "percentage",
+		Fam:      "raid cache",
+		Ctx:      "raid.controller_bbu_charge",
+		Type:     module.Area,
+		Priority: prioControllerBBUCharge,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_bbu_charge", Name: "charge"},
+		},
+	}
+)
+
+var enclosureChartsTmpl = module.Charts{
+	enclosureStatusChartTmpl.Copy(),
+}
+
+var (
+	enclosureStatusChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_enclosure_%s_status",
+		Title:    "RAID enclosure status",
+		Units:    "status",
+		Fam:      "raid enclosure",
+		Ctx:      "raid.enclosure_status",
+		Type:     module.Line,
+		Priority: prioEnclosureStatus,
+		Dims:     stateDims("raid_cntrl_%s_enc_%s_status_", statuses),
+	}
+)
+
+var logicalDriveChartsTmpl = module.Charts{
+	logicalDriveStateChartTmpl.Copy(),
+	logicalDriveRebuildProgressChartTmpl.Copy(),
+}
+
+var (
+	logicalDriveStateChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_ld_%s_state",
+		Title:    "RAID logical drive state",
+		Units:    "state",
+		Fam:      "raid ld",
+		Ctx:      "raid.logical_drive_state",
+		Type:     module.Line,
+		Priority: prioLogicalDriveState,
+		Dims:     stateDims("raid_cntrl_%s_ld_%s_state_", ldStates),
+	}
+	logicalDriveRebuildProgressChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_ld_%s_rebuild_progress",
+		Title:    "RAID logical drive rebuild progress",
+		Units:    "percentage",
+		Fam:      "raid ld",
+		Ctx:      "raid.logical_drive_rebuild_progress",
+		Type:     module.Line,
+		Priority: prioLogicalDriveRebuildProgress,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_ld_%s_rebuild_progress", Name: "progress"},
+		},
+	}
+)
+
+var physicalDriveChartsTmpl = module.Charts{
+	physicalDriveStateChartTmpl.Copy(),
+	physicalDrivePredictiveFailureStatusChartTmpl.Copy(),
+	physicalDriveErrorsChartTmpl.Copy(),
+	physicalDrivePredictiveFailuresChartTmpl.Copy(),
+	physicalDriveTemperatureChartTmpl.Copy(),
+	physicalDriveRebuildProgressChartTmpl.Copy(),
+}
+
+var (
+	physicalDriveStateChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_pd_%s_state",
+		Title:    "RAID physical drive state",
+		Units:    "state",
+		Fam:      "raid pd",
+		Ctx:      "raid.physical_drive_state",
+		Type:     module.Line,
+		Priority: prioPhysicalDriveState,
+		Dims:     stateDims("raid_cntrl_%s_pd_%s_state_", pdStates),
+	}
+	physicalDrivePredictiveFailureStatusChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_pd_%s_predictive_failure_status",
+		Title:    "RAID physical drive predictive failure status",
+		Units:    "status",
+		Fam:      "raid pd",
+		Ctx:      "raid.physical_drive_predictive_failure_status",
+		Type:     module.Line,
+		Priority: prioPhysicalDrivePredictiveFailureStatus,
+		Dims:     stateDims("raid_cntrl_%s_pd_%s_predictive_failure_status_", []string{"active", "inactive"}),
+	}
+	physicalDriveErrorsChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_pd_%s_errors",
+		Title:    "RAID physical drive errors",
+		Units:    "errors/s",
+		Fam:      "raid pd",
+		Ctx:      "raid.physical_drive_errors",
+		Type:     module.Line,
+		Priority: prioPhysicalDriveErrors,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_pd_%s_media_errors", Name: "media", Algo: module.Incremental},
+			{ID: "raid_cntrl_%s_pd_%s_other_errors", Name: "other", Algo: module.Incremental},
+		},
+	}
+	physicalDrivePredictiveFailuresChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_pd_%s_predictive_failures",
+		Title:    "RAID physical drive predictive failures",
+		Units:    "failures/s",
+		Fam:      "raid pd",
+		Ctx:      "raid.physical_drive_predictive_failures",
+		Type:     module.Line,
+		Priority: prioPhysicalDrivePredictiveFailures,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_pd_%s_predictive_failures", Name: "predictive_failures", Algo: module.Incremental},
+		},
+	}
+	physicalDriveTemperatureChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_pd_%s_temperature",
+		Title:    "RAID physical drive temperature",
+		Units:    "Celsius",
+		Fam:      "raid pd",
+		Ctx:      "raid.physical_drive_temperature",
+		Type:     module.Line,
+		Priority: prioPhysicalDriveTemperature,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_pd_%s_temperature", Name: "temperature"},
+		},
+	}
+	physicalDriveRebuildProgressChartTmpl = module.Chart{
+		ID:       "raid_controller_%s_pd_%s_rebuild_progress",
+		Title:    "RAID physical drive rebuild progress",
+		Units:    "percentage",
+		Fam:      "raid pd",
+		Ctx:      "raid.physical_drive_rebuild_progress",
+		Type:     module.Line,
+		Priority: prioPhysicalDriveRebuildProgress,
+		Dims: module.Dims{
+			{ID: "raid_cntrl_%s_pd_%s_rebuild_progress", Name: "progress"},
+		},
+	}
+)
+
+// Charts keeps the RAID charts of a collector in sync with the controllers it reports:
+// it adds charts for new controllers, enclosures and drives, adds optional charts (and dimensions)
+// once the tool starts reporting their values, and removes charts of the ones that are gone.
+type Charts struct {
+	charts *module.Charts
+	// added maps a controller, enclosure or drive to its charts by ID.
+	added map[string]map[string]*module.Chart
+}
+
+func NewCharts(charts *module.Charts) *Charts {
+	return &Charts{
+		charts: charts,
+		added:  make(map[string]map[string]*module.Chart),
+	}
+}
+
+func (c *Charts) Update(controllers []*Controller) error {
+	seen := make(map[string]bool)
+	var errs []string
+
+	add := func(key string, charts *module.Charts) {
+		seen[key] = true
+		if err := c.addCharts(key, charts); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	for _, cntrl := range controllers {
+		px := "raid_controller_" + cntrl.ID + "_"
+
+		add(px, newControllerCharts(cntrl))
+
+		for _, enc := range cntrl.Enclosures {
+			add(px+"enclosure_"+enc.ID+"_", newEnclosureCharts(cntrl, enc))
+		}
+		for _, ld := range cntrl.LogicalDrives {
+			add(px+"ld_"+ld.ID+"_", newLogicalDriveCharts(cntrl, ld))
+		}
+		for _, pd := range cntrl.PhysicalDrives {
+			add(px+"pd_"+pd.ID+"_", newPhysicalDriveCharts(cntrl, pd))
+		}
+	}
+
+	for key, charts := range c.added {
+		if !seen[key] {
+			delete(c.added, key)
+			removeCharts(charts)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to add RAID charts: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// addCharts adds the charts that were not added yet and the missing dimensions of the ones that were.
+func (c *Charts) addCharts(key string, charts *module.Charts) error {
+	added, ok := c.added[key]
+	if !ok {
+		added = make(map[string]*module.Chart)
+		c.added[key] = added
+	}
+
+	var errs []string
+
+	for _, chart := range *charts {
+		existing, ok := added[chart.ID]
+		if !ok {
+			if err := c.charts.Add(chart); err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			added[chart.ID] = chart
+			continue
+		}
+
+		for _, dim := range chart.Dims {
+			if existing.GetDim(dim.ID) != nil {
+				continue
+			}
+			if err := existing.AddDim(dim); err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			existing.MarkNotCreated()
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func newControllerCharts(cntrl *Controller) *module.Charts {
+	charts := controllerChartsTmpl.Copy()
+
+	if cntrl.Status == "" {
+		_ = charts.Remove(controllerStatusChartTmpl.ID)
+	}
+	if cntrl.Temperature == nil {
+		_ = charts.Remove(controllerTemperatureChartTmpl.ID)
+	}
+	if cntrl.PatrolRead == "" {
+		_ = charts.Remove(controllerPatrolReadStateChartTmpl.ID)
+	}
+	cache := cntrl.Cache
+	if cache == nil {
+		cache = &Cache{}
+	}
+	if cache.Status == "" {
+		_ = charts.Remove(controllerCacheStatusChartTmpl.ID)
+	}
+	if cache.Temperature == nil {
+		_ = charts.Remove(controllerCacheTemperatureChartTmpl.ID)
+	}
+	if cache.BBUStatus == "" {
+		_ = charts.Remove(controllerBBUStatusChartTmpl.ID)
+	}
+	if cache.BBUTemperature == nil {
+		_ = charts.Remove(controllerBBUTemperatureChartTmpl.ID)
+	}
+	if cache.BBUChargePercentage == nil {
+		_ = charts.Remove(controllerBBUChargeChartTmpl.ID)
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, cntrl.ID)
+		chart.Labels = controllerLabels(cntrl)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, cntrl.ID)
+		}
+	}
+
+	return charts
+}
+
+func newEnclosureCharts(cntrl *Controller, enc *Enclosure) *module.Charts {
+	charts := enclosureChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, cntrl.ID, enc.ID)
+		chart.Labels = append(controllerLabels(cntrl),
+			module.Label{Key: "enclosure", Value: enc.ID},
+		)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, cntrl.ID, enc.ID)
+		}
+	}
+
+	return charts
+}
+
+func newLogicalDriveCharts(cntrl *Controller, ld *LogicalDrive) *module.Charts {
+	charts := logicalDriveChartsTmpl.Copy()
+
+	if ld.RebuildProgress == nil {
+		_ = charts.Remove(logicalDriveRebuildProgressChartTmpl.ID)
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, cntrl.ID, ld.ID)
+		chart.Labels = append(controllerLabels(cntrl),
+			module.Label{Key: "logical_drive", Value: ld.ID},
+			module.Label{Key: "logical_drive_name", Value: ld.Name},
+			module.Label{Key: "raid_level", Value: ld.RAIDLevel},
+		)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, cntrl.ID, ld.ID)
+		}
+	}
+
+	return charts
+}
+
+func newPhysicalDriveCharts(cntrl *Controller, pd *PhysicalDrive) *module.Charts {
+	charts := physicalDriveChartsTmpl.Copy()
+
+	if pd.MediaErrors == nil && pd.OtherErrors == nil {
+		_ = charts.Remove(physicalDriveErrorsChartTmpl.ID)
+	} else if chart := charts.Get(physicalDriveErrorsChartTmpl.ID); chart != nil {
+		if pd.MediaErrors == nil {
+			_ = chart.RemoveDim(physicalDriveErrorsChartTmpl.Dims[0].ID)
+		}
+		if pd.OtherErrors == nil {
+			_ = chart.RemoveDim(physicalDriveErrorsChartTmpl.Dims[1].ID)
+		}
+	}
+	if pd.PredictiveFailures == nil {
+		_ = charts.Remove(physicalDrivePredictiveFailuresChartTmpl.ID)
+	}
+	if pd.Temperature == nil {
+		_ = charts.Remove(physicalDriveTemperatureChartTmpl.ID)
+	}
+	if pd.RebuildProgress == nil {
+		_ = charts.Remove(physicalDriveRebuildProgressChartTmpl.ID)
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, cntrl.ID, pd.ID)
+		chart.Labels = append(controllerLabels(cntrl),
+			module.Label{Key: "physical_drive", Value: pd.ID},
+			module.Label{Key: "enclosure", Value: pd.Enclosure},
+			module.Label{Key: "slot", Value: pd.Slot},
+			module.Label{Key: "logical_drive", Value: pd.LogicalDrive},
+			module.Label{Key: "model", Value: pd.Model},
+			module.Label{Key: "media_type", Value: pd.MediaType},
+		)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, cntrl.ID, pd.ID)
+		}
+	}
+
+	return charts
+}
+
+func removeCharts(charts map[string]*module.Chart) {
+	for _, chart := range charts {
+		chart.MarkRemove()
+		chart.MarkNotCreated()
+	}
+}
+
+func controllerLabels(cntrl *Controller) []module.Label {
+	return []module.Label{
+		{Key: "controller", Value: cntrl.ID},
+		{Key: "controller_model", Value: cntrl.Model},
+	}
+}
+
+func stateDims(px string, states []string) module.Dims {
+	var dims module.Dims
+	for _, st := range states {
+		dims = append(dims, &module.Dim{ID: px + st, Name: st})
+	}
+	return dims
+}
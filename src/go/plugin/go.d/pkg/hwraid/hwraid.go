@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package hwraid provides a vendor-neutral model of hardware RAID controllers
+// (controller, enclosure, logical drive, physical drive, cache/BBU).
+//
+// Hardware RAID collectors (storcli, megacli, hpssa, adaptecraid) convert their CLI output into this model,
+// and the package turns it into metrics and charts with the same contexts and labels regardless of the vendor,
+// so alerts can be written once for all of them.
+package hwraid
+
+import (
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+)
+
+// Controller, enclosure, cache and BBU statuses.
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusFailed   = "failed"
+	StatusUnknown  = "unknown"
+)
+
+// Logical drive states.
+const (
+	LDStateOptimal    = "optimal"
+	LDStateDegraded   = "degraded"
+	LDStateRebuilding = "rebuilding"
+	LDStateFailed     = "failed"
+	LDStateOffline    = "offline"
+	LDStateUnknown    = "unknown"
+)
+
+// Physical drive states.
+const (
+	PDStateOnline       = "online"
+	PDStateHotSpare     = "hot_spare"
+	PDStateUnconfigured = "unconfigured"
+	PDStateRebuilding   = "rebuilding"
+	PDStateFailed       = "failed"
+	PDStateOffline      = "offline"
+	PDStateUnknown      = "unknown"
+)
+
+// Patrol read (background media scan) states.
+const (
+	PatrolReadActive   = "active"
+	PatrolReadPaused   = "paused"
+	PatrolReadStopped  = "stopped"
+	PatrolReadDisabled = "disabled"
+)
+
+var (
+	statuses = []string{
+		StatusOK,
+		StatusDegraded,
+		StatusFailed,
+		StatusUnknown,
+	}
+	ldStates = []string{
+		LDStateOptimal,
+		LDStateDegraded,
+		LDStateRebuilding,
+		LDStateFailed,
+		LDStateOffline,
+		LDStateUnknown,
+	}
+	pdStates = []string{
+		PDStateOnline,
+		PDStateHotSpare,
+		PDStateUnconfigured,
+		PDStateRebuilding,
+		PDStateFailed,
+		PDStateOffline,
+		PDStateUnknown,
+	}
+	patrolReadStates = []string{
+		PatrolReadActive,
+		PatrolReadPaused,
+		PatrolReadStopped,
+		PatrolReadDisabled,
+	}
+)
+
+// Optional numeric values are pointers: nil means the tool does not report the value,
+// and the corresponding chart (or dimension) is not created until the value is reported.
+
+type (
+	Controller struct {
+		// ID identifies the controller within the collector job (controller number, adapter number or slot).
+		ID          string
+		Model       string
+		Status      string // one of Status*, empty if not reported
+		Temperature *int64 // Celsius
+
+		PatrolRead string // one of PatrolRead*, empty if not reported
+
+		Cache *Cache
+
+		Enclosures     []*Enclosure
+		LogicalDrives  []*LogicalDrive
+		PhysicalDrives []*PhysicalDrive
+	}
+	Cache struct {
+		Status              string // one of Status*, empty if not reported
+		Temperature         *int64 // Celsius
+		BBUStatus           string // one of Status*, empty if there is no BBU
+		BBUTemperature      *int64 // Celsius
+		BBUChargePercentage *int64
+	}
+	Enclosure struct {
+		ID     string
+		Status string // one of Status*
+	}
+	LogicalDrive struct {
+		ID        string
+		Name      string
+		RAIDLevel string
+		State     string // one of LDState*
+		// RebuildProgress is the rebuild completion percentage; 0 if the drive is not rebuilding.
+		RebuildProgress *int64
+	}
+	PhysicalDrive struct {
+		// ID identifies the drive within the controller (e.g. "252:0", "2I:1:1" or a device number).
+		ID           string
+		Enclosure    string
+		Slot         string
+		LogicalDrive string
+		Model        string
+		MediaType    string
+		State        string // one of PDState*
+		// PredictiveFailure is set if the drive (or its SMART) reports that it is likely to fail.
+		PredictiveFailure  bool
+		MediaErrors        *int64
+		OtherErrors        *int64
+		PredictiveFailures *int64
+		Temperature        *int64 // Celsius
+		// RebuildProgress is the rebuild completion percentage; 0 if the drive is not rebuilding.
+		RebuildProgress *int64
+	}
+)
+
+// WriteMetrics writes the metrics of the controllers to mx.
+func WriteMetrics(mx map[string]int64, controllers []*Controller) {
+	for _, cntrl := range controllers {
+		px := cntrlPrefix(cntrl)
+
+		if cntrl.Status != "" {
+			writeState(mx, px+"status_", statuses, cntrl.Status, StatusUnknown)
+		}
+		writeOptional(mx, px+"temperature", cntrl.Temperature)
+		if cntrl.PatrolRead != "" {
+			writeState(mx, px+"patrol_read_state_", patrolReadStates, cntrl.PatrolRead, "")
+		}
+
+		if cache := cntrl.Cache; cache != nil {
+			if cache.Status != "" {
+				writeState(mx, px+"cache_status_", statuses, cache.Status, StatusUnknown)
+			}
+			writeOptional(mx, px+"cache_temperature", cache.Temperature)
+			if cache.BBUStatus != "" {
+				writeState(mx, px+"bbu_status_", statuses, cache.BBUStatus, StatusUnknown)
+			}
+			writeOptional(mx, px+"bbu_temperature", cache.BBUTemperature)
+			writeOptional(mx, px+"bbu_charge", cache.BBUChargePercentage)
+		}
+
+		for _, enc := range cntrl.Enclosures {
+			writeState(mx, encPrefix(cntrl, enc)+"status_", statuses, enc.Status, StatusUnknown)
+		}
+
+		for _, ld := range cntrl.LogicalDrives {
+			px := ldPrefix(cntrl, ld)
+			writeState(mx, px+"state_", ldStates, ld.State, LDStateUnknown)
+			writeOptional(mx, px+"rebuild_progress", ld.RebuildProgress)
+		}
+
+		for _, pd := range cntrl.PhysicalDrives {
+			px := pdPrefix(cntrl, pd)
+			writeState(mx, px+"state_", pdStates, pd.State, PDStateUnknown)
+			mx[px+"predictive_failure_status_active"] = metrix.Bool(pd.PredictiveFailure)
+			mx[px+"predictive_failure_status_inactive"] = metrix.Bool(!pd.PredictiveFailure)
+			writeOptional(mx, px+"media_errors", pd.MediaErrors)
+			writeOptional(mx, px+"other_errors", pd.OtherErrors)
+			writeOptional(mx, px+"predictive_failures", pd.PredictiveFailures)
+			writeOptional(mx, px+"temperature", pd.Temperature)
+			writeOptional(mx, px+"rebuild_progress", pd.RebuildProgress)
+		}
+	}
+}
+
+func cntrlPrefix(cntrl *Controller) string {
+	return "raid_cntrl_" + cntrl.ID + "_"
+}
+
+func encPrefix(cntrl *Controller, enc *Enclosure) string {
+	return cntrlPrefix(cntrl) + "enc_" + enc.ID + "_"
+}
+
+func ldPrefix(cntrl *Controller, ld *LogicalDrive) string {
+	return cntrlPrefix(cntrl) + "ld_" + ld.ID + "_"
+}
+
+func pdPrefix(cntrl *Controller, pd *PhysicalDrive) string {
+	return cntrlPrefix(cntrl) + "pd_" + pd.ID + "_"
+}
+
+func writeState(mx map[string]int64, px string, states []string, state, fallback string) {
+	found := false
+	for _, st := range states {
+		mx[px+st] = 0
+		if st == state {
+			found = true
+		}
+	}
+	if !found {
+		if fallback == "" {
+			return
+		}
+		state = fallback
+	}
+	mx[px+state] = 1
+}
+
+func writeOptional(mx map[string]int64, key string, v *int64) {
+	if v != nil {
+		mx[key] = *v
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hwraid
+
+import (
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	mx := make(map[string]int64)
+
+	WriteMetrics(mx, prepareControllers())
+
+	expected := map[string]int64{
+		"raid_cntrl_0_bbu_charge":                                  90,
+		"raid_cntrl_0_bbu_status_degraded":                         0,
+		"raid_cntrl_0_bbu_status_failed":                           0,
+		"raid_cntrl_0_bbu_status_ok":                               0,
+		"raid_cntrl_0_bbu_status_unknown":                          1,
+		"raid_cntrl_0_enc_252_status_degraded":                     0,
+		"raid_cntrl_0_enc_252_status_failed":                       0,
+		"raid_cntrl_0_enc_252_status_ok":                           0,
+		"raid_cntrl_0_enc_252_status_unknown":                      1,
+		"raid_cntrl_0_ld_0_rebuild_progress":                       0,
+		"raid_cntrl_0_ld_0_state_degraded":                         1,
+		"raid_cntrl_0_ld_0_state_failed":                           0,
+		"raid_cntrl_0_ld_0_state_offline":                          0,
+		"raid_cntrl_0_ld_0_state_optimal":                          0,
+		"raid_cntrl_0_ld_0_state_rebuilding":                       0,
+		"raid_cntrl_0_ld_0_state_unknown":                          0,
+		"raid_cntrl_0_patrol_read_state_active":                    0,
+		"raid_cntrl_0_patrol_read_state_disabled":                  0,
+		"raid_cntrl_0_patrol_read_state_paused":                    0,
+		"raid_cntrl_0_patrol_read_state_stopped":                   1,
+		"raid_cntrl_0_pd_252:0_media_errors":                       3,
+		"raid_cntrl_0_pd_252:0_predictive_failure_status_active":   1,
+		"raid_cntrl_0_pd_252:0_predictive_failure_status_inactive": 0,
+		"raid_cntrl_0_pd_252:0_rebuild_progress":                   0,
+		"raid_cntrl_0_pd_252:0_state_failed":                       0,
+		"raid_cntrl_0_pd_252:0_state_hot_spare":                    0,
+		"raid_cntrl_0_pd_252:0_state_offline":                      0,
+		"raid_cntrl_0_pd_252:0_state_online":                       1,
+		"raid_cntrl_0_pd_252:0_state_rebuilding":                   0,
+		"raid_cntrl_0_pd_252:0_state_unconfigured":                 0,
+		"raid_cntrl_0_pd_252:0_state_unknown":                      0,
+		"raid_cntrl_0_pd_252:0_temperature":                        35,
+		"raid_cntrl_0_pd_252:1_predictive_failure_status_active":   0,
+		"raid_cntrl_0_pd_252:1_predictive_failure_status_inactive": 1,
+		"raid_cntrl_0_pd_252:1_rebuild_progress":                   42,
+		"raid_cntrl_0_pd_252:1_state_failed":                       0,
+		"raid_cntrl_0_pd_252:1_state_hot_spare":                    0,
+		"raid_cntrl_0_pd_252:1_state_offline":                      0,
+		"raid_cntrl_0_pd_252:1_state_online":                       0,
+		"raid_cntrl_0_pd_252:1_state_rebuilding":                   1,
+		"raid_cntrl_0_pd_252:1_state_unconfigured":                 0,
+		"raid_cntrl_0_pd_252:1_state_unknown":                      0,
+		"raid_cntrl_0_status_degraded":                             1,
+		"raid_cntrl_0_status_failed":                               0,
+		"raid_cntrl_0_status_ok":                                   0,
+		"raid_cntrl_0_status_unknown":                              0,
+		"raid_cntrl_0_temperature":                                 60,
+	}
+
+	assert.Equal(t, expected, mx)
+}
+
+func TestCharts_Update(t *testing.T) {
+	charts := &module.Charts{}
+	rc := NewCharts(charts)
+
+	controllers := prepareControllers()
+	require.NoError(t, rc.Update(controllers))
+
+	mx := make(map[string]int64)
+	WriteMetrics(mx, controllers)
+	module.TestMetricsHasAllChartsDims(t, charts, mx)
+
+	// controller (5) + enclosure (1) + ld 0 (2) + pd 252:0 (5) + pd 252:1 (3)
+	assert.Len(t, *charts, 5+1+2+5+3)
+
+	chart := charts.Get("raid_controller_0_pd_252:1_state")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "enclosure", Value: "252"})
+	assert.Contains(t, chart.Labels, module.Label{Key: "controller_model", Value: "PERC H730P Mini"})
+
+	// the rebuilding drive is gone, its charts must be removed; a repeated update must not fail
+	controllers[0].PhysicalDrives = controllers[0].PhysicalDrives[:1]
+	require.NoError(t, rc.Update(controllers))
+	require.NoError(t, rc.Update(controllers))
+
+	for _, chart := range *charts {
+		removed := chart.ID == "raid_controller_0_pd_252:1_state" ||
+			chart.ID == "raid_controller_0_pd_252:1_predictive_failure_status" ||
+			chart.ID == "raid_controller_0_pd_252:1_rebuild_progress"
+		assert.Equalf(t, removed, chart.Obsolete, "chart '%s' obsolete", chart.ID)
+	}
+}
+
+func TestCharts_Update_OptionalChartsAddedOnceReported(t *testing.T) {
+	charts := &module.Charts{}
+	rc := NewCharts(charts)
+
+	controllers := prepareControllers()
+	cntrl := controllers[0]
+	cntrl.Temperature = nil
+	cntrl.LogicalDrives[0].RebuildProgress = nil
+	require.NoError(t, rc.Update(controllers))
+
+	assert.False(t, charts.Has("raid_controller_0_temperature"))
+	assert.False(t, charts.Has("raid_controller_0_ld_0_rebuild_progress"))
+	chart := charts.Get("raid_controller_0_pd_252:0_errors")
+	require.NotNil(t, chart)
+	assert.Nil(t, chart.GetDim("raid_cntrl_0_pd_252:0_other_errors"))
+
+	cntrl.Temperature = ptr(55)
+	cntrl.LogicalDrives[0].RebuildProgress = ptr(10)
+	cntrl.PhysicalDrives[0].OtherErrors = ptr(1)
+	require.NoError(t, rc.Update(controllers))
+	require.NoError(t, rc.Update(controllers))
+
+	mx := make(map[string]int64)
+	WriteMetrics(mx, controllers)
+	module.TestMetricsHasAllChartsDims(t, charts, mx)
+
+	assert.True(t, charts.Has("raid_controller_0_temperature"))
+	assert.True(t, charts.Has("raid_controller_0_ld_0_rebuild_progress"))
+	assert.NotNil(t, chart.GetDim("raid_cntrl_0_pd_252:0_other_errors"))
+}
+
+func prepareControllers() []*Controller {
+	return []*Controller{
+		{
+			ID:          "0",
+			Model:       "PERC H730P Mini",
+			Status:      StatusDegraded,
+			Temperature: ptr(60),
+			PatrolRead:  PatrolReadStopped,
+			Cache: &Cache{
+				BBUStatus:           "Learning", // not a known status
+				BBUChargePercentage: ptr(90),
+			},
+			Enclosures: []*Enclosure{
+				{ID: "252", Status: "Critical"}, // not a known status
+			},
+			LogicalDrives: []*LogicalDrive{
+				{ID: "0", Name: "os", RAIDLevel: "RAID1", State: LDStateDegraded, RebuildProgress: ptr(0)},
+			},
+			PhysicalDrives: []*PhysicalDrive{
+				{
+					ID:                "252:0",
+					Enclosure:         "252",
+					Slot:              "0",
+					LogicalDrive:      "0",
+					State:             PDStateOnline,
+					PredictiveFailure: true,
+					MediaErrors:       ptr(3),
+					Temperature:       ptr(35),
+					RebuildProgress:   ptr(0),
+				},
+				{
+					ID:              "252:1",
+					Enclosure:       "252",
+					Slot:            "1",
+					LogicalDrive:    "0",
+					State:           PDStateRebuilding,
+					RebuildProgress: ptr(42),
+				},
+			},
+		},
+	}
+}
+
+func ptr(v int64) *int64 { return &v }
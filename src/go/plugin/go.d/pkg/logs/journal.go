@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxJournalFieldSize limits the size of a single binary field value.
+// journald itself refuses entries larger than 768 MiB, real log fields are much smaller.
+const maxJournalFieldSize = 64 * 1024 * 1024
+
+type JournalConfig struct {
+	Mapping map[string]string `yaml:"mapping" json:"mapping"`
+}
+
+// JournalParser parses entries in the journal export format
+// (https://systemd.io/JOURNAL_EXPORT_FORMATS/#journal-export-format), as produced by
+// 'journalctl --output=export' and JournalReader. Each entry field is assigned to the LogLine
+// using the field name (e.g. MESSAGE, PRIORITY, _SYSTEMD_UNIT) unless it is mapped to another name.
+type JournalParser struct {
+	reader  *bufio.Reader
+	buf     []byte
+	mapping map[string]string
+}
+
+func NewJournalParser(config JournalConfig, in io.Reader) (*JournalParser, error) {
+	parser := &JournalParser{
+		reader:  bufio.NewReader(in),
+		mapping: config.Mapping,
+		buf:     make([]byte, 0, 1024),
+	}
+	return parser, nil
+}
+
+func (p *JournalParser) ReadLine(line LogLine) error {
+	var err error
+	if p.buf, err = readJournalEntry(p.reader, p.buf[:0]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return err
+		}
+		return &ParseError{msg: fmt.Sprintf("journal parse: %v", err), err: err}
+	}
+	return p.Parse(p.buf, line)
+}
+
+func (p *JournalParser) Parse(row []byte, line LogLine) error {
+	err := parseJournalEntry(row, func(key, value []byte) error {
+		name := string(key)
+		if mapped, ok := p.mapping[name]; ok {
+			name = mapped
+		}
+		return line.Assign(name, string(value))
+	})
+	if err != nil {
+		return &ParseError{msg: fmt.Sprintf("journal parse: %v", err), err: err}
+	}
+	return nil
+}
+
+func (p *JournalParser) Info() string {
+	return fmt.Sprintf("journal: %q", p.mapping)
+}
+
+// readJournalEntry reads a single export format entry from r and appends its raw representation
+// (without the terminating empty line) to buf. Empty lines preceding the entry are skipped.
+// It returns io.EOF if there is no entry to read and io.ErrUnexpectedEOF if the entry is truncated.
+func readJournalEntry(r *bufio.Reader, buf []byte) ([]byte, error) {
+	start := len(buf)
+
+	for {
+		lineStart := len(buf)
+
+		var err error
+		if buf, err = appendLine(r, buf); err != nil {
+			if errors.Is(err, io.EOF) && len(buf) > start {
+				return buf[:start], io.ErrUnexpectedEOF
+			}
+			return buf[:start], err
+		}
+
+		line := buf[lineStart:]
+
+		if len(line) == 1 {
+			buf = buf[:lineStart]
+			if lineStart == start {
+				continue
+			}
+			return buf, nil
+		}
+
+		if bytes.IndexByte(line, '=') != -1 {
+			continue
+		}
+
+		// binary field: "KEY\n" followed by the little-endian 64-bit value size, the value and "\n"
+		var size [8]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return buf[:start], unexpectedEOF(err)
+		}
+		n := binary.LittleEndian.Uint64(size[:])
+		if n > maxJournalFieldSize {
+			return buf[:start], fmt.Errorf("field '%s' size %d exceeds the limit", bytes.TrimSpace(line), n)
+		}
+
+		buf = append(buf, size[:]...)
+		buf = growSlice(buf, int(n)+1)
+		if _, err := io.ReadFull(r, buf[len(buf)-int(n)-1:]); err != nil {
+			return buf[:start], unexpectedEOF(err)
+		}
+		if buf[len(buf)-1] != '\n' {
+			return buf[:start], fmt.Errorf("field '%s' is not terminated by a newline", bytes.TrimSpace(line))
+		}
+	}
+}
+
+// parseJournalEntry calls fn for every field of a raw export format entry.
+func parseJournalEntry(entry []byte, fn func(key, value []byte) error) error {
+	for len(entry) > 0 {
+		i := bytes.IndexByte(entry, '\n')
+		if i == -1 {
+			i = len(entry)
+		}
+		line := entry[:i]
+		entry = entry[min(i+1, len(entry)):]
+
+		if len(line) == 0 {
+			continue
+		}
+
+		if key, value, ok := bytes.Cut(line, []byte("=")); ok {
+			if len(key) == 0 {
+				return errors.New("empty field name")
+			}
+			if err := fn(key, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if len(entry) < 8 {
+			return fmt.Errorf("field '%s': missing binary value size", line)
+		}
+		n := binary.LittleEndian.Uint64(entry[:8])
+		entry = entry[8:]
+		if n >= uint64(len(entry)) || entry[n] != '\n' {
+			return fmt.Errorf("field '%s': invalid binary value size %d", line, n)
+		}
+		if err := fn(line, entry[:n]); err != nil {
+			return err
+		}
+		entry = entry[n+1:]
+	}
+	return nil
+}
+
+func appendLine(r *bufio.Reader, buf []byte) ([]byte, error) {
+	for {
+		line, err := r.ReadSlice('\n')
+		buf = append(buf, line...)
+		if err == nil {
+			return buf, nil
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return buf, err
+		}
+	}
+}
+
+func growSlice(buf []byte, n int) []byte {
+	if cap(buf)-len(buf) < n {
+		b := make([]byte, len(buf), len(buf)+n)
+		copy(b, buf)
+		buf = b
+	}
+	return buf[:len(buf)+n]
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
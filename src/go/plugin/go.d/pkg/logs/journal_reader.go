@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/logger"
+)
+
+const (
+	// maxJournalBufferSize limits the amount of entries buffered between two reads.
+	// New entries are dropped (and counted) while the buffer is full.
+	maxJournalBufferSize = 8 * 1024 * 1024
+	journalRestartEvery  = time.Second * 10
+	journalStartupWait   = time.Millisecond * 500
+)
+
+type JournalReaderConfig struct {
+	// Directory is the journal directory to read ('journalctl --directory'), the system journal if not set.
+	Directory string `yaml:"journal_directory,omitempty" json:"journal_directory"`
+	// Files are journal files to read ('journalctl --file'), globbing is supported.
+	Files []string `yaml:"journal_files,omitempty" json:"journal_files"`
+	// Units are systemd unit names or patterns ('journalctl --unit').
+	Units []string `yaml:"units,omitempty" json:"units"`
+	// Priority is a syslog priority or a range of priorities ('journalctl --priority'), e.g. "err" or "0..3".
+	Priority string `yaml:"priority,omitempty" json:"priority"`
+	// Matches are journal field matches in the "FIELD=value" form ('journalctl MATCHES').
+	// Matches for the same field are combined with OR, for different fields with AND.
+	Matches []string `yaml:"matches,omitempty" json:"matches"`
+}
+
+// JournalReader reads new systemd journal entries in the journal export format.
+// It follows the journal using 'journalctl --follow --output=export' and buffers complete entries,
+// Read returns io.EOF once all buffered entries are consumed, as Reader does at the end of a file.
+// If journalctl exits, it is restarted and resumes after the last read entry.
+type JournalReader struct {
+	config     JournalReaderConfig
+	log        *logger.Logger
+	journalctl string
+
+	restartEvery time.Duration
+	startupWait  time.Duration
+
+	mux     sync.Mutex
+	buf     bytes.Buffer
+	cursor  string
+	dropped int64
+
+	cmd       *exec.Cmd
+	done      chan struct{}
+	stderr    *bytes.Buffer
+	startedAt time.Time
+}
+
+// OpenJournal starts following the journal from its end.
+func OpenJournal(config JournalReaderConfig, log *logger.Logger) (*JournalReader, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	r := newJournalReader(config, log)
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func newJournalReader(config JournalReaderConfig, log *logger.Logger) *JournalReader {
+	return &JournalReader{
+		config:       config,
+		log:          log,
+		journalctl:   "journalctl",
+		restartEvery: journalRestartEvery,
+		startupWait:  journalStartupWait,
+	}
+}
+
+func (r *JournalReader) open() error {
+	path, err := exec.LookPath(r.journalctl)
+	if err != nil {
+		return fmt.Errorf("journalctl not found: %v", err)
+	}
+	r.journalctl = path
+
+	if err := r.start(); err != nil {
+		return err
+	}
+
+	// journalctl exits immediately on invalid arguments or inaccessible journal
+	select {
+	case <-r.done:
+		return fmt.Errorf("'%s' exited: %s", r.cmd, r.stderrMessage())
+	case <-time.After(r.startupWait):
+		return nil
+	}
+}
+
+// Dropped returns the number of entries dropped because the buffer was full.
+func (r *JournalReader) Dropped() int64 {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.dropped
+}
+
+func (r *JournalReader) Read(p []byte) (int, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.buf.Len() > 0 {
+		return r.buf.Read(p)
+	}
+
+	if r.done != nil && r.isExited() && time.Since(r.startedAt) >= r.restartEvery {
+		r.log.Debugf("journalctl exited (%s), restarting", r.stderrMessage())
+		if err := r.start(); err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, io.EOF
+}
+
+func (r *JournalReader) Close() error {
+	if r == nil || r.cmd == nil {
+		return nil
+	}
+
+	if !r.isExited() && r.cmd.Process != nil {
+		_ = r.cmd.Process.Kill()
+	}
+	<-r.done
+
+	r.cmd = nil
+	return nil
+}
+
+func (r *JournalReader) isExited() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *JournalReader) start() error {
+	cmd := exec.Command(r.journalctl, journalctlArgs(r.config, r.cursor)...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	r.log.Debugf("executing '%s'", cmd)
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	r.cmd, r.done, r.stderr, r.startedAt = cmd, done, stderr, time.Now()
+
+	go r.follow(cmd, stdout, done)
+
+	return nil
+}
+
+func (r *JournalReader) follow(cmd *exec.Cmd, stdout io.Reader, done chan struct{}) {
+	defer close(done)
+
+	br := bufio.NewReaderSize(stdout, 64*1024)
+	var entry []byte
+
+	for {
+		var err error
+		if entry, err = readJournalEntry(br, entry[:0]); err != nil {
+			if !errors.Is(err, io.EOF) {
+				r.log.Debugf("journal read: %v", err)
+			}
+			break
+		}
+
+		var cursor string
+		_ = parseJournalEntry(entry, func(key, value []byte) error {
+			if string(key) == "__CURSOR" {
+				cursor = string(value)
+			}
+			return nil
+		})
+
+		r.mux.Lock()
+		if r.buf.Len()+len(entry)+1 > maxJournalBufferSize {
+			r.dropped++
+		} else {
+			r.buf.Write(entry)
+			r.buf.WriteByte('\n')
+		}
+		if cursor != "" {
+			r.cursor = cursor
+		}
+		r.mux.Unlock()
+	}
+
+	_ = cmd.Wait()
+}
+
+func (r *JournalReader) stderrMessage() string {
+	if r.isExited() && r.stderr != nil {
+		if s := strings.TrimSpace(r.stderr.String()); s != "" {
+			return s
+		}
+	}
+	if r.cmd != nil && r.cmd.ProcessState != nil {
+		return r.cmd.ProcessState.String()
+	}
+	return "unknown error"
+}
+
+func journalctlArgs(config JournalReaderConfig, cursor string) []string {
+	args := []string{"--follow", "--output=export", "--no-pager", "--quiet"}
+
+	if cursor != "" {
+		args = append(args, "--after-cursor="+cursor)
+	} else {
+		args = append(args, "--lines=0")
+	}
+	if config.Directory != "" {
+		args = append(args, "--directory="+config.Directory)
+	}
+	for _, file := range config.Files {
+		args = append(args, "--file="+file)
+	}
+	for _, unit := range config.Units {
+		args = append(args, "--unit="+unit)
+	}
+	if config.Priority != "" {
+		args = append(args, "--priority="+config.Priority)
+	}
+
+	return append(args, config.Matches...)
+}
+
+var (
+	reJournalFieldMatch = regexp.MustCompile(`^[A-Z0-9_]+=`)
+	reJournalPriority   = regexp.MustCompile(`^(?:[0-7]|emerg|alert|crit|err|warning|notice|info|debug)(?:\.\.(?:[0-7]|emerg|alert|crit|err|warning|notice|info|debug))?$`)
+)
+
+func (c JournalReaderConfig) validate() error {
+	if c.Directory != "" && len(c.Files) > 0 {
+		return errors.New("'journal_directory' and 'journal_files' are mutually exclusive")
+	}
+	if c.Priority != "" && !reJournalPriority.MatchString(c.Priority) {
+		return fmt.Errorf("bad priority: %q", c.Priority)
+	}
+	for _, m := range c.Matches {
+		if m != "+" && !reJournalFieldMatch.MatchString(m) {
+			return fmt.Errorf("bad match (expected 'FIELD=value' or '+'): %q", m)
+		}
+	}
+	for _, u := range c.Units {
+		if u == "" {
+			return fmt.Errorf("bad unit: %q", u)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJournalExport = "__CURSOR=s=abc;i=1\nPRIORITY=6\n_SYSTEMD_UNIT=sshd.service\nMESSAGE=Failed password for root\n\n" +
+	"__CURSOR=s=abc;i=2\nPRIORITY=3\n_TRANSPORT=kernel\nMESSAGE=Out of memory: Killed process 1234\n\n"
+
+func TestJournalReaderConfig_validate(t *testing.T) {
+	tests := map[string]struct {
+		config  JournalReaderConfig
+		wantErr bool
+	}{
+		"empty config": {
+			config: JournalReaderConfig{},
+		},
+		"full config": {
+			config: JournalReaderConfig{
+				Directory: "/var/log/journal",
+				Units:     []string{"sshd.service", "nginx*"},
+				Priority:  "emerg..err",
+				Matches:   []string{"SYSLOG_IDENTIFIER=sshd", "+", "_TRANSPORT=kernel"},
+			},
+		},
+		"numeric priority": {
+			config: JournalReaderConfig{Priority: "3"},
+		},
+		"fails on bad priority": {
+			config:  JournalReaderConfig{Priority: "error"},
+			wantErr: true,
+		},
+		"fails on bad match": {
+			config:  JournalReaderConfig{Matches: []string{"--since=today"}},
+			wantErr: true,
+		},
+		"fails on lowercase match field": {
+			config:  JournalReaderConfig{Matches: []string{"message=test"}},
+			wantErr: true,
+		},
+		"fails on empty unit": {
+			config:  JournalReaderConfig{Units: []string{""}},
+			wantErr: true,
+		},
+		"fails on both directory and files": {
+			config:  JournalReaderConfig{Directory: "/var/log/journal", Files: []string{"/tmp/*.journal"}},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.wantErr {
+				assert.Error(t, test.config.validate())
+			} else {
+				assert.NoError(t, test.config.validate())
+			}
+		})
+	}
+}
+
+func Test_journalctlArgs(t *testing.T) {
+	tests := map[string]struct {
+		config   JournalReaderConfig
+		cursor   string
+		wantArgs []string
+	}{
+		"default": {
+			wantArgs: []string{"--follow", "--output=export", "--no-pager", "--quiet", "--lines=0"},
+		},
+		"with filters": {
+			config: JournalReaderConfig{
+				Directory: "/var/log/journal",
+				Units:     []string{"sshd.service", "nginx*"},
+				Priority:  "0..3",
+				Matches:   []string{"_TRANSPORT=kernel"},
+			},
+			wantArgs: []string{
+				"--follow", "--output=export", "--no-pager", "--quiet", "--lines=0",
+				"--directory=/var/log/journal",
+				"--unit=sshd.service", "--unit=nginx*",
+				"--priority=0..3",
+				"_TRANSPORT=kernel",
+			},
+		},
+		"with files and cursor": {
+			config: JournalReaderConfig{Files: []string{"/tmp/a.journal"}},
+			cursor: "s=abc;i=2",
+			wantArgs: []string{
+				"--follow", "--output=export", "--no-pager", "--quiet", "--after-cursor=s=abc;i=2",
+				"--file=/tmp/a.journal",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.wantArgs, journalctlArgs(test.config, test.cursor))
+		})
+	}
+}
+
+func TestJournalReader_Read(t *testing.T) {
+	r, argsFile := prepareTestJournalReader(t, testJournalExport, true)
+	defer func() { _ = r.Close() }()
+
+	p, err := NewJournalParser(JournalConfig{}, r)
+	require.NoError(t, err)
+
+	var got []map[string]string
+	require.Eventually(t, func() bool {
+		for {
+			line := newLogLine()
+			if err := p.ReadLine(line); err != nil {
+				require.ErrorIs(t, err, io.EOF)
+				break
+			}
+			got = append(got, line.assigned)
+		}
+		return len(got) == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	assert.Equal(t, "Failed password for root", got[0]["MESSAGE"])
+	assert.Equal(t, "sshd.service", got[0]["_SYSTEMD_UNIT"])
+	assert.Equal(t, "3", got[1]["PRIORITY"])
+	assert.Equal(t, "kernel", got[1]["_TRANSPORT"])
+
+	// no new entries
+	assert.ErrorIs(t, p.ReadLine(newLogLine()), io.EOF)
+	assert.Equal(t, int64(0), r.Dropped())
+
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(args), "--unit=sshd.service")
+}
+
+func TestJournalReader_Read_RestartAfterCursor(t *testing.T) {
+	r, argsFile := prepareTestJournalReader(t, testJournalExport, false)
+	defer func() { _ = r.Close() }()
+
+	// journalctl exits after writing the entries, a read after that restarts it after the last read entry
+	br := bufio.NewReader(r)
+	var entries int
+	require.Eventually(t, func() bool {
+		for {
+			if _, err := readJournalEntry(br, nil); err != nil {
+				break
+			}
+			entries++
+		}
+		args, _ := os.ReadFile(argsFile)
+		return strings.Contains(string(args), "--after-cursor=s=abc;i=2")
+	}, time.Second*5, time.Millisecond*10)
+
+	assert.GreaterOrEqual(t, entries, 2)
+}
+
+func TestOpenJournal_FailsOnJournalctlError(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "journalctl")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho 'Failed to open journal directory' >&2\nexit 1\n"), 0755))
+
+	r := newJournalReader(JournalReaderConfig{}, nil)
+	r.journalctl = script
+	r.startupWait = time.Second * 5
+
+	err := r.open()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to open journal directory")
+}
+
+func TestOpenJournal_FailsOnBadConfig(t *testing.T) {
+	_, err := OpenJournal(JournalReaderConfig{Priority: "bad"}, nil)
+	assert.Error(t, err)
+}
+
+func prepareTestJournalReader(t *testing.T, export string, follow bool) (*JournalReader, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "journal.export")
+	argsFile := filepath.Join(dir, "args")
+	script := filepath.Join(dir, "journalctl")
+
+	require.NoError(t, os.WriteFile(dataFile, []byte(export), 0644))
+
+	content := "#!/bin/sh\necho \"$@\" >> " + argsFile + "\ncat " + dataFile + "\n"
+	if follow {
+		content += "exec sleep 30\n"
+	} else {
+		content += "exec sleep 0.2\n"
+	}
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+
+	r := newJournalReader(JournalReaderConfig{Units: []string{"sshd.service"}}, nil)
+	r.journalctl = script
+	r.restartEvery = 0
+	r.startupWait = time.Millisecond * 10
+	require.NoError(t, r.open())
+
+	return r, argsFile
+}
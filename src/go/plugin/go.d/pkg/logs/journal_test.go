@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func journalBinaryField(name, value string) string {
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	return name + "\n" + string(size[:]) + value + "\n"
+}
+
+func TestNewJournalParser(t *testing.T) {
+	tests := map[string]struct {
+		config  JournalConfig
+		wantErr bool
+	}{
+		"empty config": {
+			config:  JournalConfig{},
+			wantErr: false,
+		},
+		"with mappings": {
+			config:  JournalConfig{Mapping: map[string]string{"_SYSTEMD_UNIT": "unit"}},
+			wantErr: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := NewJournalParser(test.config, nil)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, p)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, p)
+			}
+		})
+	}
+}
+
+func TestJournalParser_ReadLine(t *testing.T) {
+	tests := map[string]struct {
+		config       JournalConfig
+		input        string
+		wantAssigned []map[string]string
+		wantErr      bool
+	}{
+		"single entry": {
+			input: "__CURSOR=s=1;i=1\nPRIORITY=6\n_SYSTEMD_UNIT=sshd.service\nMESSAGE=Accepted publickey for root\n\n",
+			wantAssigned: []map[string]string{
+				{
+					"__CURSOR":      "s=1;i=1",
+					"PRIORITY":      "6",
+					"_SYSTEMD_UNIT": "sshd.service",
+					"MESSAGE":       "Accepted publickey for root",
+				},
+			},
+		},
+		"multiple entries with leading empty lines": {
+			input: "\n\nPRIORITY=6\nMESSAGE=one\n\nPRIORITY=3\nMESSAGE=two\n\n",
+			wantAssigned: []map[string]string{
+				{"PRIORITY": "6", "MESSAGE": "one"},
+				{"PRIORITY": "3", "MESSAGE": "two"},
+			},
+		},
+		"value with '='": {
+			input: "MESSAGE=a=b\n\n",
+			wantAssigned: []map[string]string{
+				{"MESSAGE": "a=b"},
+			},
+		},
+		"binary field": {
+			input: "PRIORITY=3\n" + journalBinaryField("MESSAGE", "line1\n\nline2") + "_COMM=kernel\n\n",
+			wantAssigned: []map[string]string{
+				{"PRIORITY": "3", "MESSAGE": "line1\n\nline2", "_COMM": "kernel"},
+			},
+		},
+		"with mappings": {
+			config: JournalConfig{Mapping: map[string]string{
+				"_SYSTEMD_UNIT": "unit",
+				"PRIORITY":      "priority",
+			}},
+			input: "PRIORITY=4\n_SYSTEMD_UNIT=nginx.service\nMESSAGE=test\n\n",
+			wantAssigned: []map[string]string{
+				{"priority": "4", "unit": "nginx.service", "MESSAGE": "test"},
+			},
+		},
+		"error on truncated entry": {
+			input:   "PRIORITY=4\nMESSAGE=test\n",
+			wantErr: true,
+		},
+		"error on truncated binary field": {
+			input:   "PRIORITY=4\nMESSAGE\n\x10\x00",
+			wantErr: true,
+		},
+		"error on binary field without trailing newline": {
+			input:   strings.TrimSuffix(journalBinaryField("MESSAGE", "test"), "\n") + "X\n\n",
+			wantErr: true,
+		},
+		"error on assign": {
+			input:   "ERR=value\n\n",
+			wantErr: true,
+		},
+		"error on empty input": {
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := NewJournalParser(test.config, strings.NewReader(test.input))
+			require.NoError(t, err)
+			require.NotNil(t, p)
+
+			if test.wantErr {
+				assert.Error(t, p.ReadLine(newLogLine()))
+				return
+			}
+
+			for _, want := range test.wantAssigned {
+				line := newLogLine()
+				require.NoError(t, p.ReadLine(line))
+				assert.Equal(t, want, line.assigned)
+			}
+			assert.ErrorIs(t, p.ReadLine(newLogLine()), io.EOF)
+		})
+	}
+}
+
+func TestJournalParser_Parse(t *testing.T) {
+	tests := map[string]struct {
+		config       JournalConfig
+		input        string
+		wantAssigned map[string]string
+		wantErr      bool
+	}{
+		"text fields": {
+			input:        "PRIORITY=6\nMESSAGE=hello\n",
+			wantAssigned: map[string]string{"PRIORITY": "6", "MESSAGE": "hello"},
+		},
+		"without trailing newline": {
+			input:        "PRIORITY=6\nMESSAGE=hello",
+			wantAssigned: map[string]string{"PRIORITY": "6", "MESSAGE": "hello"},
+		},
+		"binary field": {
+			input:        journalBinaryField("MESSAGE", "a\nb") + "PRIORITY=2\n",
+			wantAssigned: map[string]string{"MESSAGE": "a\nb", "PRIORITY": "2"},
+		},
+		"error on empty field name": {
+			input:   "=value\n",
+			wantErr: true,
+		},
+		"error on invalid binary field size": {
+			input:   "MESSAGE\n\xff\x00\x00\x00\x00\x00\x00\x00abc\n",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			line := newLogLine()
+			p, err := NewJournalParser(test.config, nil)
+			require.NoError(t, err)
+
+			err = p.Parse([]byte(test.input), line)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.True(t, IsParseError(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.wantAssigned, line.assigned)
+			}
+		})
+	}
+}
+
+func Test_readJournalEntry_UnexpectedEOF(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("PRIORITY=6\nMESSAGE=partial"))
+
+	buf, err := readJournalEntry(r, nil)
+
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+	assert.Empty(t, buf)
+}
@@ -33,18 +33,20 @@ type (
 )
 
 const (
-	TypeCSV    = "csv"
-	TypeLTSV   = "ltsv"
-	TypeRegExp = "regexp"
-	TypeJSON   = "json"
+	TypeCSV     = "csv"
+	TypeLTSV    = "ltsv"
+	TypeRegExp  = "regexp"
+	TypeJSON    = "json"
+	TypeJournal = "journal"
 )
 
 type ParserConfig struct {
-	LogType string       `yaml:"log_type,omitempty" json:"log_type"`
-	CSV     CSVConfig    `yaml:"csv_config,omitempty" json:"csv_config"`
-	LTSV    LTSVConfig   `yaml:"ltsv_config,omitempty" json:"ltsv_config"`
-	RegExp  RegExpConfig `yaml:"regexp_config,omitempty" json:"regexp_config"`
-	JSON    JSONConfig   `yaml:"json_config,omitempty" json:"json_config"`
+	LogType string        `yaml:"log_type,omitempty" json:"log_type"`
+	CSV     CSVConfig     `yaml:"csv_config,omitempty" json:"csv_config"`
+	LTSV    LTSVConfig    `yaml:"ltsv_config,omitempty" json:"ltsv_config"`
+	RegExp  RegExpConfig  `yaml:"regexp_config,omitempty" json:"regexp_config"`
+	JSON    JSONConfig    `yaml:"json_config,omitempty" json:"json_config"`
+	Journal JournalConfig `yaml:"journal_config,omitempty" json:"journal_config"`
 }
 
 func NewParser(config ParserConfig, in io.Reader) (Parser, error) {
@@ -57,6 +59,8 @@ func NewParser(config ParserConfig, in io.Reader) (Parser, error) {
 		return NewRegExpParser(config.RegExp, in)
 	case TypeJSON:
 		return NewJSONParser(config.JSON, in)
+	case TypeJournal:
+		return NewJournalParser(config.Journal, in)
 	default:
 		return nil, fmt.Errorf("invalid type: %q", config.LogType)
 	}
@@ -1,3 +1,32 @@
 // SPDX-License-Identifier: GPL-3.0-or-later
 
 package logs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewParser_Journal(t *testing.T) {
+	config := ParserConfig{
+		LogType: TypeJournal,
+		Journal: JournalConfig{Mapping: map[string]string{"_SYSTEMD_UNIT": "unit"}},
+	}
+
+	p, err := NewParser(config, strings.NewReader("_SYSTEMD_UNIT=sshd.service\nMESSAGE=Failed password for root\n\n"))
+	require.NoError(t, err)
+	require.IsType(t, (*JournalParser)(nil), p)
+
+	line := newLogLine()
+	require.NoError(t, p.ReadLine(line))
+	assert.Equal(t, "sshd.service", line.assigned["unit"])
+	assert.Equal(t, "Failed password for root", line.assigned["MESSAGE"])
+}
+
+func TestNewParser_InvalidType(t *testing.T) {
+	_, err := NewParser(ParserConfig{LogType: "unknown"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package timesync provides a daemon-neutral time synchronization health state.
+//
+// Time synchronization collectors (chrony, ntpd) derive the state from the leap indicator
+// and their own synchronization status, and the package turns it into a chart with the same context
+// regardless of the daemon, so alerts can be written once for all of them.
+package timesync
+
+import (
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/metrix"
+)
+
+// Leap indicator values (RFC 5905, section 7.3).
+const (
+	LeapNoWarning    = 0
+	LeapInsertSecond = 1
+	LeapDeleteSecond = 2
+	LeapAlarm        = 3
+)
+
+// Health states.
+const (
+	StateSynchronized      = "synchronized"
+	StateLeapInsertPending = "leap_insert_pending"
+	StateLeapDeletePending = "leap_delete_pending"
+	StateUnsynchronized    = "unsynchronized"
+)
+
+var states = []string{
+	StateSynchronized,
+	StateLeapInsertPending,
+	StateLeapDeletePending,
+	StateUnsynchronized,
+}
+
+// State returns the health state for the leap indicator.
+// The leap alarm condition means the clock is not synchronized.
+func State(leap int, synchronized bool) string {
+	if !synchronized {
+		return StateUnsynchronized
+	}
+	switch leap {
+	case LeapNoWarning:
+		return StateSynchronized
+	case LeapInsertSecond:
+		return StateLeapInsertPending
+	case LeapDeleteSecond:
+		return StateLeapDeletePending
+	default:
+		return StateUnsynchronized
+	}
+}
+
+// WriteMetrics writes the health state metrics into mx.
+func WriteMetrics(mx map[string]int64, state string) {
+	for _, st := range states {
+		mx["timesync_health_state_"+st] = metrix.Bool(st == state)
+	}
+}
+
+// Chart returns a new time sync health chart, it goes before the collector's own charts.
+func Chart() *module.Chart {
+	chart := &module.Chart{
+		ID:       "timesync_health",
+		Title:    "Time synchronization health",
+		Units:    "state",
+		Fam:      "health",
+		Ctx:      "timesync.health",
+		Type:     module.Line,
+		Priority: module.Priority - 1,
+	}
+	for _, st := range states {
+		chart.Dims = append(chart.Dims, &module.Dim{ID: "timesync_health_state_" + st, Name: st})
+	}
+	return chart
+}
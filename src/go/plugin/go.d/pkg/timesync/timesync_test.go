@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package timesync
+
+import (
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState(t *testing.T) {
+	tests := map[string]struct {
+		leap         int
+		synchronized bool
+		want         string
+	}{
+		"no warning":             {leap: LeapNoWarning, synchronized: true, want: StateSynchronized},
+		"insert second":          {leap: LeapInsertSecond, synchronized: true, want: StateLeapInsertPending},
+		"delete second":          {leap: LeapDeleteSecond, synchronized: true, want: StateLeapDeletePending},
+		"alarm":                  {leap: LeapAlarm, synchronized: true, want: StateUnsynchronized},
+		"not synchronized":       {leap: LeapNoWarning, synchronized: false, want: StateUnsynchronized},
+		"unknown leap indicator": {leap: 7, synchronized: true, want: StateUnsynchronized},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, State(test.leap, test.synchronized))
+		})
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	mx := make(map[string]int64)
+
+	WriteMetrics(mx, StateLeapInsertPending)
+
+	expected := map[string]int64{
+		"timesync_health_state_leap_delete_pending": 0,
+		"timesync_health_state_leap_insert_pending": 1,
+		"timesync_health_state_synchronized":        0,
+		"timesync_health_state_unsynchronized":      0,
+	}
+
+	assert.Equal(t, expected, mx)
+	module.TestMetricsHasAllChartsDims(t, &module.Charts{Chart()}, mx)
+}